@@ -64,6 +64,24 @@ func catalogDiscountSchedule(ctx context.Context, id uint, body apicontract.Disc
 		return apicontract.DiscountSchedule(response.(apicontract.ScheduleAdminDiscountCampaign200JSONResponse)), nil
 	})
 }
+func catalogPreviewDiscountSchedule(ctx context.Context, body apicontract.DiscountSchedulePreviewRequest) (apicontract.DiscountSchedulePreviewResponse, error) {
+	return withCatalogEndpoints(ctx, func(ctx context.Context, e *httpapi.CatalogEndpoints) (apicontract.DiscountSchedulePreviewResponse, error) {
+		response, err := e.PreviewAdminDiscountSchedule(ctx, apicontract.PreviewAdminDiscountScheduleRequestObject{Body: &body})
+		if err != nil {
+			return apicontract.DiscountSchedulePreviewResponse{}, err
+		}
+		return apicontract.DiscountSchedulePreviewResponse(response.(apicontract.PreviewAdminDiscountSchedule200JSONResponse)), nil
+	})
+}
+func catalogDiscountBudget(ctx context.Context, id uint) (apicontract.DiscountBudgetStatus, error) {
+	return withCatalogEndpoints(ctx, func(ctx context.Context, e *httpapi.CatalogEndpoints) (apicontract.DiscountBudgetStatus, error) {
+		response, err := e.GetAdminDiscountCampaignBudget(ctx, apicontract.GetAdminDiscountCampaignBudgetRequestObject{Id: int(id)})
+		if err != nil {
+			return apicontract.DiscountBudgetStatus{}, err
+		}
+		return apicontract.DiscountBudgetStatus(response.(apicontract.GetAdminDiscountCampaignBudget200JSONResponse)), nil
+	})
+}
 func catalogDiscountLifecycle(ctx context.Context) (apicontract.DiscountLifecycleRunResponse, error) {
 	return withCatalogEndpoints(ctx, func(ctx context.Context, e *httpapi.CatalogEndpoints) (apicontract.DiscountLifecycleRunResponse, error) {
 		response, err := e.RunAdminDiscountLifecycle(ctx, apicontract.RunAdminDiscountLifecycleRequestObject{})
@@ -137,6 +155,15 @@ func catalogPreviewPromotion(ctx context.Context, body apicontract.PromotionEval
 		return apicontract.PromotionEvaluationResponse(response.(apicontract.PreviewAdminPromotion200JSONResponse)), nil
 	})
 }
+func catalogSimulatePromotion(ctx context.Context, body apicontract.PromotionSimulationRequest) (apicontract.PromotionSimulationResponse, error) {
+	return withCatalogEndpoints(ctx, func(ctx context.Context, e *httpapi.CatalogEndpoints) (apicontract.PromotionSimulationResponse, error) {
+		response, err := e.SimulateAdminPromotion(ctx, apicontract.SimulateAdminPromotionRequestObject{Body: &body})
+		if err != nil {
+			return apicontract.PromotionSimulationResponse{}, err
+		}
+		return apicontract.PromotionSimulationResponse(response.(apicontract.SimulateAdminPromotion200JSONResponse)), nil
+	})
+}
 func catalogPromotionTemplates(ctx context.Context, active bool) (apicontract.PromotionTemplateListResponse, error) {
 	return withCatalogEndpoints(ctx, func(ctx context.Context, e *httpapi.CatalogEndpoints) (apicontract.PromotionTemplateListResponse, error) {
 		response, err := e.ListAdminPromotionTemplates(ctx, apicontract.ListAdminPromotionTemplatesRequestObject{Params: apicontract.ListAdminPromotionTemplatesParams{Active: &active}})
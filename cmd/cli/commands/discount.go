@@ -24,6 +24,8 @@ func NewDiscountCmd() *cobra.Command {
 	cmd.AddCommand(newDisableDiscountCampaignCmd())
 	cmd.AddCommand(newArchiveDiscountCampaignCmd())
 	cmd.AddCommand(newScheduleDiscountCampaignCmd())
+	cmd.AddCommand(newPreviewDiscountScheduleCmd())
+	cmd.AddCommand(newGetDiscountBudgetCmd())
 	cmd.AddCommand(newRunDiscountLifecycleCmd())
 	cmd.AddCommand(newListDiscountHistoryCmd())
 	cmd.AddCommand(newListDiscountAuditCmd())
@@ -31,6 +33,7 @@ func NewDiscountCmd() *cobra.Command {
 	cmd.AddCommand(newRunDiscountReconciliationCmd())
 	cmd.AddCommand(newCreatePromotionCmd())
 	cmd.AddCommand(newPreviewPromotionCmd())
+	cmd.AddCommand(newSimulatePromotionCmd())
 	cmd.AddCommand(newListPromotionTemplatesCmd())
 	cmd.AddCommand(newCreatePromotionTemplateCmd())
 	cmd.AddCommand(newInstantiatePromotionTemplateCmd())
@@ -54,6 +57,8 @@ type productDiscountFlags struct {
 	customerSegment     string
 	globalUsageCap      int
 	perCustomerUsageCap int
+	budgetAmount        float64
+	budgetAlerts        []int
 }
 
 func newListDiscountCampaignsCmd() *cobra.Command {
@@ -144,55 +149,121 @@ func newArchiveDiscountCampaignCmd() *cobra.Command {
 	return newSimpleDiscountCampaignActionCmd("archive", "Archive a discount campaign", "archived")
 }
 
+type discountScheduleFlags struct {
+	scheduleType string
+	recurrence   string
+	windowStart  string
+	windowEnd    string
+	untilAt      string
+	timezoneName string
+}
+
+func (f *discountScheduleFlags) bind(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.scheduleType, "type", "one_time", "Schedule type: one_time or recurring")
+	cmd.Flags().StringVar(&f.recurrence, "recurrence", "", "Recurrence: daily, weekly, monthly, or RFC 5545 RRULE/EXDATE lines (e.g. FREQ=WEEKLY;BYDAY=FR)")
+	cmd.Flags().StringVar(&f.windowStart, "window-start", "", "Window start time (RFC3339)")
+	cmd.Flags().StringVar(&f.windowEnd, "window-end", "", "Window end time (RFC3339)")
+	cmd.Flags().StringVar(&f.untilAt, "until-at", "", "Optional recurrence end time (RFC3339)")
+	cmd.Flags().StringVar(&f.timezoneName, "timezone", "", "Schedule timezone (IANA name, e.g. America/Toronto)")
+}
+
+func (f discountScheduleFlags) toContract() (apicontract.DiscountScheduleInput, error) {
+	start, err := parseCLITime(f.windowStart, "window-start")
+	if err != nil {
+		return apicontract.DiscountScheduleInput{}, err
+	}
+	end, err := parseCLITime(f.windowEnd, "window-end")
+	if err != nil {
+		return apicontract.DiscountScheduleInput{}, err
+	}
+	var until *time.Time
+	if strings.TrimSpace(f.untilAt) != "" {
+		parsed, err := parseCLITime(f.untilAt, "until-at")
+		if err != nil {
+			return apicontract.DiscountScheduleInput{}, err
+		}
+		until = &parsed
+	}
+	var recurrence *string
+	if strings.TrimSpace(f.recurrence) != "" {
+		// Allow literal "\n" so RRULE and EXDATE lines fit in one flag.
+		value := strings.ReplaceAll(strings.TrimSpace(f.recurrence), `\n`, "\n")
+		recurrence = &value
+	}
+	var timezone *string
+	if strings.TrimSpace(f.timezoneName) != "" {
+		value := strings.TrimSpace(f.timezoneName)
+		timezone = &value
+	}
+	return apicontract.DiscountScheduleInput{
+		ScheduleType: apicontract.DiscountScheduleInputScheduleType(strings.TrimSpace(f.scheduleType)),
+		Recurrence:   recurrence,
+		WindowStart:  start,
+		WindowEnd:    end,
+		UntilAt:      until,
+		Timezone:     timezone,
+	}, nil
+}
+
 func newScheduleDiscountCampaignCmd() *cobra.Command {
 	var id uint
-	var scheduleType string
-	var recurrence string
-	var windowStart string
-	var windowEnd string
-	var untilAt string
-	var timezoneName string
+	var flags discountScheduleFlags
 	var format string
 
 	cmd := &cobra.Command{
 		Use:   "schedule",
 		Short: "Create or update a discount campaign schedule",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			start, err := parseCLITime(windowStart, "window-start")
+			payload, err := flags.toContract()
 			if err != nil {
 				return err
 			}
-			end, err := parseCLITime(windowEnd, "window-end")
+			schedule, err := catalogDiscountSchedule(cmd.Context(), id, payload)
 			if err != nil {
 				return err
 			}
-			var until *time.Time
-			if strings.TrimSpace(untilAt) != "" {
-				parsed, err := parseCLITime(untilAt, "until-at")
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(schedule)
+				return nil
+			}
+			fmt.Printf("✓ Schedule saved for campaign %d (next run: %s)\n", schedule.CampaignId, formatOptionalTime(schedule.NextRunAt))
+			return nil
+		},
+	}
+	cmd.Flags().UintVar(&id, "id", 0, "Discount campaign ID")
+	flags.bind(cmd)
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	markRequired(cmd, "id", "window-start", "window-end")
+	return cmd
+}
+
+func newPreviewDiscountScheduleCmd() *cobra.Command {
+	var flags discountScheduleFlags
+	var from string
+	var count int
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "schedule-preview",
+		Short: "Preview the next windows of a discount schedule without saving it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schedule, err := flags.toContract()
+			if err != nil {
+				return err
+			}
+			payload := apicontract.DiscountSchedulePreviewRequest{Schedule: schedule, Count: &count}
+			if strings.TrimSpace(from) != "" {
+				parsed, err := parseCLITime(from, "from")
 				if err != nil {
 					return err
 				}
-				until = &parsed
-			}
-			var recurrencePtr *apicontract.DiscountScheduleInputRecurrence
-			if strings.TrimSpace(recurrence) != "" {
-				value := apicontract.DiscountScheduleInputRecurrence(strings.TrimSpace(recurrence))
-				recurrencePtr = &value
-			}
-			var timezonePtr *string
-			if strings.TrimSpace(timezoneName) != "" {
-				value := strings.TrimSpace(timezoneName)
-				timezonePtr = &value
-			}
-			payload := apicontract.DiscountScheduleInput{
-				ScheduleType: apicontract.DiscountScheduleInputScheduleType(strings.TrimSpace(scheduleType)),
-				Recurrence:   recurrencePtr,
-				WindowStart:  start,
-				WindowEnd:    end,
-				UntilAt:      until,
-				Timezone:     timezonePtr,
+				payload.From = &parsed
 			}
-			schedule, err := catalogDiscountSchedule(cmd.Context(), id, payload)
+			resp, err := catalogPreviewDiscountSchedule(cmd.Context(), payload)
 			if err != nil {
 				return err
 			}
@@ -201,22 +272,62 @@ func newScheduleDiscountCampaignCmd() *cobra.Command {
 				return err
 			}
 			if selectedFormat == outputFormatJSON {
-				printJSON(schedule)
+				printJSON(resp)
 				return nil
 			}
-			fmt.Printf("✓ Schedule saved for campaign %d (next run: %s)\n", schedule.CampaignId, formatOptionalTime(schedule.NextRunAt))
+			if len(resp.Windows) == 0 {
+				fmt.Println("No upcoming windows")
+				return nil
+			}
+			fmt.Printf("%-26s %-26s\n", "Start", "End")
+			fmt.Println("----------------------------------------------------")
+			for _, window := range resp.Windows {
+				fmt.Printf("%-26s %-26s\n", window.Start.Format(time.RFC3339), window.End.Format(time.RFC3339))
+			}
+			return nil
+		},
+	}
+	flags.bind(cmd)
+	cmd.Flags().StringVar(&from, "from", "", "List windows ending after this time (RFC3339, default now)")
+	cmd.Flags().IntVar(&count, "count", 10, "Number of windows to list (1-100)")
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	markRequired(cmd, "window-start", "window-end")
+	return cmd
+}
+
+func newGetDiscountBudgetCmd() *cobra.Command {
+	var id uint
+	var format string
+	cmd := &cobra.Command{
+		Use:   "budget",
+		Short: "Show discount campaign budget spend and alerts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := catalogDiscountBudget(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(resp)
+				return nil
+			}
+			if resp.BudgetAmount == nil {
+				fmt.Printf("Campaign %d has no budget (spent %.2f)\n", resp.CampaignId, resp.SpentAmount)
+				return nil
+			}
+			fmt.Printf("Campaign: %d (%s)\nBudget: %.2f\nSpent: %.2f\nRemaining: %.2f\n", resp.CampaignId, resp.Status, *resp.BudgetAmount, resp.SpentAmount, *resp.RemainingAmount)
+			for _, alert := range resp.Alerts {
+				fmt.Printf("Alert %d%% at %s (spent %.2f)\n", alert.ThresholdPercent, alert.TriggeredAt.Format(time.RFC3339), alert.SpentAmount)
+			}
 			return nil
 		},
 	}
 	cmd.Flags().UintVar(&id, "id", 0, "Discount campaign ID")
-	cmd.Flags().StringVar(&scheduleType, "type", "one_time", "Schedule type: one_time or recurring")
-	cmd.Flags().StringVar(&recurrence, "recurrence", "", "Recurring cadence: daily, weekly, or monthly")
-	cmd.Flags().StringVar(&windowStart, "window-start", "", "Window start time (RFC3339)")
-	cmd.Flags().StringVar(&windowEnd, "window-end", "", "Window end time (RFC3339)")
-	cmd.Flags().StringVar(&untilAt, "until-at", "", "Optional recurrence end time (RFC3339)")
-	cmd.Flags().StringVar(&timezoneName, "timezone", "", "Schedule timezone")
 	addOutputFormatFlag(cmd, &format, string(outputFormatText))
-	markRequired(cmd, "id", "window-start", "window-end")
+	markRequired(cmd, "id")
 	return cmd
 }
 
@@ -436,6 +547,65 @@ func newPreviewPromotionCmd() *cobra.Command {
 	return cmd
 }
 
+func newSimulatePromotionCmd() *cobra.Command {
+	var inputFile string
+	var from string
+	var to string
+	var channel string
+	var format string
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Estimate a draft promotion's cost over historical orders",
+		Long: "Replay a draft promotion (PromotionInput JSON) over paid, shipped and delivered orders\n" +
+			"placed in [--from, --to). Nothing is written; the draft's schedule, coupon and usage caps are ignored.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var campaign apicontract.PromotionInput
+			if err := loadJSONFile(inputFile, &campaign); err != nil {
+				return err
+			}
+			start, err := parseCLITime(from, "from")
+			if err != nil {
+				return err
+			}
+			end, err := parseCLITime(to, "to")
+			if err != nil {
+				return err
+			}
+			payload := apicontract.PromotionSimulationRequest{Campaign: campaign, From: start, To: end}
+			if trimmed := strings.TrimSpace(channel); trimmed != "" {
+				payload.Channel = &trimmed
+			}
+			resp, err := catalogSimulatePromotion(cmd.Context(), payload)
+			if err != nil {
+				return err
+			}
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(resp)
+				return nil
+			}
+			fmt.Printf("Window: %s to %s\n", resp.From.Format(time.RFC3339), resp.To.Format(time.RFC3339))
+			fmt.Printf("Orders evaluated: %d\nAffected orders: %d\n", resp.OrdersEvaluated, resp.AffectedOrders)
+			fmt.Printf("Expected discount: $%.2f\n", resp.DiscountTotal)
+			fmt.Printf("Revenue: $%.2f actual, $%.2f simulated\n", resp.ActualRevenue, resp.SimulatedRevenue)
+			fmt.Printf("AOV: $%.2f actual, $%.2f simulated (%+.2f, %+.2f%%)\n", resp.ActualAov, resp.SimulatedAov, resp.AovChange, resp.AovChangePercent)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&inputFile, "input", "", "Path to PromotionInput JSON describing the draft campaign")
+	cmd.Flags().StringVar(&from, "from", "", "Window start (RFC3339)")
+	cmd.Flags().StringVar(&to, "to", "", "Window end, exclusive (RFC3339)")
+	cmd.Flags().StringVar(&channel, "channel", "", "Channel to evaluate as (web, app, admin)")
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	cmd.MarkFlagRequired("input")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+	return cmd
+}
+
 func newListPromotionTemplatesCmd() *cobra.Command {
 	var includeInactive bool
 	var format string
@@ -547,6 +717,8 @@ func (f *productDiscountFlags) bind(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&f.customerSegment, "customer-segment", "", "Optional customer segment")
 	cmd.Flags().IntVar(&f.globalUsageCap, "global-usage-cap", 0, "Optional global usage cap")
 	cmd.Flags().IntVar(&f.perCustomerUsageCap, "per-customer-usage-cap", 0, "Optional per-customer usage cap")
+	cmd.Flags().Float64Var(&f.budgetAmount, "budget", 0, "Optional discount budget; the campaign is disabled once it is spent")
+	cmd.Flags().IntSliceVar(&f.budgetAlerts, "budget-alert", nil, "Budget alert threshold percent (repeatable or comma-separated)")
 }
 
 func (f productDiscountFlags) toContract(cmd *cobra.Command) (apicontract.ProductDiscountInput, error) {
@@ -603,23 +775,33 @@ func (f productDiscountFlags) toContract(cmd *cobra.Command) (apicontract.Produc
 	if f.perCustomerUsageCap > 0 {
 		perCustomerUsageCap = &f.perCustomerUsageCap
 	}
+	var budgetAmount *float64
+	if f.budgetAmount > 0 {
+		budgetAmount = &f.budgetAmount
+	}
+	var budgetAlerts *[]int
+	if len(f.budgetAlerts) > 0 {
+		budgetAlerts = &f.budgetAlerts
+	}
 	status := apicontract.ProductDiscountInputStatus(strings.TrimSpace(f.status))
 	return apicontract.ProductDiscountInput{
-		Name:                strings.TrimSpace(f.name),
-		ProductIds:          productIDs,
-		DiscountMode:        apicontract.ProductDiscountInputDiscountMode(strings.TrimSpace(f.discountMode)),
-		DiscountValue:       f.discountValue,
-		StartsAt:            startsAt,
-		EndsAt:              endsAt,
-		Priority:            &f.priority,
-		IsExclusive:         &f.isExclusive,
-		Status:              &status,
-		Metadata:            metadata,
-		CouponCode:          couponCode,
-		Channels:            channels,
-		CustomerSegment:     customerSegment,
-		GlobalUsageCap:      globalUsageCap,
-		PerCustomerUsageCap: perCustomerUsageCap,
+		Name:                  strings.TrimSpace(f.name),
+		ProductIds:            productIDs,
+		DiscountMode:          apicontract.ProductDiscountInputDiscountMode(strings.TrimSpace(f.discountMode)),
+		DiscountValue:         f.discountValue,
+		StartsAt:              startsAt,
+		EndsAt:                endsAt,
+		Priority:              &f.priority,
+		IsExclusive:           &f.isExclusive,
+		Status:                &status,
+		Metadata:              metadata,
+		CouponCode:            couponCode,
+		Channels:              channels,
+		CustomerSegment:       customerSegment,
+		GlobalUsageCap:        globalUsageCap,
+		PerCustomerUsageCap:   perCustomerUsageCap,
+		BudgetAmount:          budgetAmount,
+		BudgetAlertThresholds: budgetAlerts,
 	}, nil
 }
 
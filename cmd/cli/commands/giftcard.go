@@ -0,0 +1,406 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	"ecommerce/internal/httpapi"
+
+	"github.com/spf13/cobra"
+)
+
+func NewGiftCardCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gift-card",
+		Short: "Gift card and store credit administration commands",
+	}
+
+	cmd.AddCommand(newListGiftCardsCmd())
+	cmd.AddCommand(newIssueGiftCardCmd())
+	cmd.AddCommand(newGetGiftCardCmd())
+	cmd.AddCommand(newAdjustGiftCardCmd())
+	cmd.AddCommand(newSetGiftCardStatusCmd())
+	cmd.AddCommand(newReconcileGiftCardsCmd())
+
+	return cmd
+}
+
+func withGiftCardEndpoints[T any](ctx context.Context, run func(context.Context, *httpapi.CheckoutProviderEndpoints) (T, error)) (T, error) {
+	var zero T
+	db := getDB()
+	defer closeDB(db)
+	endpoints, err := httpapi.NewCheckoutProviderEndpoints(httpapi.CheckoutProviderEndpointsOptions{DB: db})
+	if err != nil {
+		return zero, err
+	}
+	return run(ctx, endpoints)
+}
+
+func newListGiftCardsCmd() *cobra.Command {
+	var status string
+	var source string
+	var userID uint
+	var page int
+	var limit int
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List gift cards",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := apicontract.ListAdminGiftCardsParams{Page: &page, Limit: &limit}
+			query := url.Values{}
+			query.Set("page", strconv.Itoa(page))
+			query.Set("limit", strconv.Itoa(limit))
+			if status != "" {
+				params.Status = &status
+				query.Set("status", status)
+			}
+			if source != "" {
+				params.Source = &source
+				query.Set("source", source)
+			}
+			if userID != 0 {
+				value := int(userID)
+				params.UserId = &value
+				query.Set("user_id", strconv.Itoa(value))
+			}
+
+			var resp apicontract.GiftCardListResponse
+			var err error
+			if isRemoteMode() {
+				resp, err = invokeRemoteJSON[apicontract.GiftCardListResponse](http.MethodGet, "/api/v1/admin/gift-cards?"+query.Encode(), nil)
+			} else {
+				resp, err = withGiftCardEndpoints(cmd.Context(), func(ctx context.Context, endpoints *httpapi.CheckoutProviderEndpoints) (apicontract.GiftCardListResponse, error) {
+					response, err := endpoints.ListAdminGiftCards(ctx, apicontract.ListAdminGiftCardsRequestObject{Params: params})
+					if err != nil {
+						return apicontract.GiftCardListResponse{}, err
+					}
+					return apicontract.GiftCardListResponse(response.(apicontract.ListAdminGiftCards200JSONResponse)), nil
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(resp)
+				return nil
+			}
+			if len(resp.Data) == 0 {
+				fmt.Println("No gift cards found")
+				return nil
+			}
+			for _, card := range resp.Data {
+				fmt.Printf("%d\t****%s\t%s\t%s\t%.2f/%.2f %s\n", card.Id, card.CodeLast4, card.Status, card.Source, card.Balance, card.InitialAmount, card.Currency)
+			}
+			fmt.Printf("Page %d of %d (%d total)\n", resp.Pagination.Page, resp.Pagination.TotalPages, resp.Pagination.Total)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&status, "status", "", "Filter by status (ACTIVE, DISABLED, EXPIRED)")
+	cmd.Flags().StringVar(&source, "source", "", "Filter by source (PURCHASED, ADMIN, REFUND_CREDIT)")
+	cmd.Flags().UintVar(&userID, "user-id", 0, "Filter by owning user ID")
+	cmd.Flags().IntVar(&page, "page", 1, "Page number")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Page size")
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	return cmd
+}
+
+func newIssueGiftCardCmd() *cobra.Command {
+	var amount float64
+	var currency string
+	var source string
+	var userID uint
+	var orderID uint
+	var expiresAt string
+	var note string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue a gift card or store credit",
+		Long:  "Issue a gift card. The full code is only shown once; store it before closing the terminal.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body := apicontract.GiftCardIssueInput{Amount: amount}
+			if currency != "" {
+				body.Currency = &currency
+			}
+			if source != "" {
+				body.Source = &source
+			}
+			if note != "" {
+				body.Note = &note
+			}
+			if userID != 0 {
+				value := int(userID)
+				body.UserId = &value
+			}
+			if orderID != 0 {
+				value := int(orderID)
+				body.OrderId = &value
+			}
+			if expiresAt != "" {
+				parsed, err := parseCLITime(expiresAt, "expires-at")
+				if err != nil {
+					return err
+				}
+				body.ExpiresAt = &parsed
+			}
+
+			var resp apicontract.GiftCardIssueResponse
+			var err error
+			if isRemoteMode() {
+				resp, err = invokeRemoteJSON[apicontract.GiftCardIssueResponse](http.MethodPost, "/api/v1/admin/gift-cards", body)
+			} else {
+				resp, err = withGiftCardEndpoints(cmd.Context(), func(ctx context.Context, endpoints *httpapi.CheckoutProviderEndpoints) (apicontract.GiftCardIssueResponse, error) {
+					response, err := endpoints.IssueAdminGiftCard(ctx, apicontract.IssueAdminGiftCardRequestObject{Body: &body})
+					if err != nil {
+						return apicontract.GiftCardIssueResponse{}, err
+					}
+					return apicontract.GiftCardIssueResponse(response.(apicontract.IssueAdminGiftCard201JSONResponse)), nil
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(resp)
+				return nil
+			}
+			fmt.Printf("Issued gift card %d (%s)\nCode: %s\nBalance: %.2f %s\n", resp.GiftCard.Id, resp.GiftCard.Source, resp.Code, resp.GiftCard.Balance, resp.GiftCard.Currency)
+			return nil
+		},
+	}
+
+	cmd.Flags().Float64Var(&amount, "amount", 0, "Initial balance")
+	cmd.Flags().StringVar(&currency, "currency", "", "ISO currency code (defaults to USD)")
+	cmd.Flags().StringVar(&source, "source", "", "Card source (ADMIN, PURCHASED, REFUND_CREDIT)")
+	cmd.Flags().UintVar(&userID, "user-id", 0, "Owning user ID")
+	cmd.Flags().UintVar(&orderID, "order-id", 0, "Order the card was purchased with or credits")
+	cmd.Flags().StringVar(&expiresAt, "expires-at", "", "Expiry time in RFC3339")
+	cmd.Flags().StringVar(&note, "note", "", "Internal note")
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	markRequired(cmd, "amount")
+	return cmd
+}
+
+func loadAdminGiftCard(ctx context.Context, id uint) (apicontract.GiftCard, error) {
+	if isRemoteMode() {
+		return invokeRemoteJSON[apicontract.GiftCard](http.MethodGet, fmt.Sprintf("/api/v1/admin/gift-cards/%d", id), nil)
+	}
+	return withGiftCardEndpoints(ctx, func(ctx context.Context, endpoints *httpapi.CheckoutProviderEndpoints) (apicontract.GiftCard, error) {
+		response, err := endpoints.GetAdminGiftCard(ctx, apicontract.GetAdminGiftCardRequestObject{Id: int(id)})
+		if err != nil {
+			return apicontract.GiftCard{}, err
+		}
+		return apicontract.GiftCard(response.(apicontract.GetAdminGiftCard200JSONResponse)), nil
+	})
+}
+
+func newGetGiftCardCmd() *cobra.Command {
+	var id uint
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show a gift card and its ledger",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			card, err := loadAdminGiftCard(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(card)
+				return nil
+			}
+			printGiftCard(card)
+			if card.Ledger != nil {
+				for _, entry := range *card.Ledger {
+					fmt.Printf("%s\t%s\t%+.2f\t%.2f\t%s\t%s\n", entry.CreatedAt.Format(time.RFC3339), entry.Operation, entry.Amount, entry.BalanceAfter, entry.Actor, entry.Reason)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().UintVar(&id, "id", 0, "Gift card ID")
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	markRequired(cmd, "id")
+	return cmd
+}
+
+func newAdjustGiftCardCmd() *cobra.Command {
+	var id uint
+	var amount float64
+	var reason string
+	var idempotencyKey string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "adjust",
+		Short: "Credit or debit a gift card balance",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body := apicontract.GiftCardAdjustmentInput{Amount: amount, Reason: reason}
+			var card apicontract.GiftCard
+			var err error
+			if isRemoteMode() {
+				if idempotencyKey != "" {
+					return fmt.Errorf("--idempotency-key is only supported in local mode")
+				}
+				card, err = invokeRemoteJSON[apicontract.GiftCard](http.MethodPost, fmt.Sprintf("/api/v1/admin/gift-cards/%d/adjustments", id), body)
+			} else {
+				card, err = withGiftCardEndpoints(cmd.Context(), func(ctx context.Context, endpoints *httpapi.CheckoutProviderEndpoints) (apicontract.GiftCard, error) {
+					request := apicontract.AdjustAdminGiftCardRequestObject{Id: int(id), Body: &body}
+					if idempotencyKey != "" {
+						request.Params.IdempotencyKey = &idempotencyKey
+					}
+					response, err := endpoints.AdjustAdminGiftCard(ctx, request)
+					if err != nil {
+						return apicontract.GiftCard{}, err
+					}
+					return apicontract.GiftCard(response.(apicontract.AdjustAdminGiftCard200JSONResponse)), nil
+				})
+			}
+			if err != nil {
+				return err
+			}
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(card)
+				return nil
+			}
+			printGiftCard(card)
+			return nil
+		},
+	}
+
+	cmd.Flags().UintVar(&id, "id", 0, "Gift card ID")
+	cmd.Flags().Float64Var(&amount, "amount", 0, "Signed adjustment amount")
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason recorded in the ledger")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Key that makes retries of this adjustment safe")
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	markRequired(cmd, "id", "amount", "reason")
+	return cmd
+}
+
+func newSetGiftCardStatusCmd() *cobra.Command {
+	var id uint
+	var status string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "set-status",
+		Short: "Enable or disable a gift card",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body := apicontract.GiftCardStatusInput{Status: status}
+			var card apicontract.GiftCard
+			var err error
+			if isRemoteMode() {
+				card, err = invokeRemoteJSON[apicontract.GiftCard](http.MethodPost, fmt.Sprintf("/api/v1/admin/gift-cards/%d/status", id), body)
+			} else {
+				card, err = withGiftCardEndpoints(cmd.Context(), func(ctx context.Context, endpoints *httpapi.CheckoutProviderEndpoints) (apicontract.GiftCard, error) {
+					response, err := endpoints.UpdateAdminGiftCardStatus(ctx, apicontract.UpdateAdminGiftCardStatusRequestObject{Id: int(id), Body: &body})
+					if err != nil {
+						return apicontract.GiftCard{}, err
+					}
+					return apicontract.GiftCard(response.(apicontract.UpdateAdminGiftCardStatus200JSONResponse)), nil
+				})
+			}
+			if err != nil {
+				return err
+			}
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(card)
+				return nil
+			}
+			printGiftCard(card)
+			return nil
+		},
+	}
+
+	cmd.Flags().UintVar(&id, "id", 0, "Gift card ID")
+	cmd.Flags().StringVar(&status, "status", "", "New status (ACTIVE or DISABLED)")
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	markRequired(cmd, "id", "status")
+	return cmd
+}
+
+func newReconcileGiftCardsCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Check gift card balances against the ledger and payment transactions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var report apicontract.GiftCardReconciliationReport
+			var err error
+			if isRemoteMode() {
+				report, err = invokeRemoteJSON[apicontract.GiftCardReconciliationReport](http.MethodGet, "/api/v1/admin/gift-cards/reconciliation", nil)
+			} else {
+				report, err = withGiftCardEndpoints(cmd.Context(), func(ctx context.Context, endpoints *httpapi.CheckoutProviderEndpoints) (apicontract.GiftCardReconciliationReport, error) {
+					response, err := endpoints.ReconcileAdminGiftCards(ctx, apicontract.ReconcileAdminGiftCardsRequestObject{})
+					if err != nil {
+						return apicontract.GiftCardReconciliationReport{}, err
+					}
+					return apicontract.GiftCardReconciliationReport(response.(apicontract.ReconcileAdminGiftCards200JSONResponse)), nil
+				})
+			}
+			if err != nil {
+				return err
+			}
+			selectedFormat, err := normalizeOutputFormat(format)
+			if err != nil {
+				return err
+			}
+			if selectedFormat == outputFormatJSON {
+				printJSON(report)
+				return nil
+			}
+			fmt.Printf("Checked %d cards and %d transactions\n", report.CardsChecked, report.TransactionsChecked)
+			for _, drift := range report.Drifts {
+				fmt.Printf("DRIFT %s: %s (expected %s, actual %s)\n", drift.Field, drift.Message, drift.Expected, drift.Actual)
+			}
+			if len(report.Drifts) > 0 {
+				return fmt.Errorf("gift card reconciliation found %d drift(s)", len(report.Drifts))
+			}
+			return nil
+		},
+	}
+
+	addOutputFormatFlag(cmd, &format, string(outputFormatText))
+	return cmd
+}
+
+func printGiftCard(card apicontract.GiftCard) {
+	fmt.Printf("Gift card %d (****%s)\nStatus: %s\nSource: %s\nBalance: %.2f of %.2f %s\n", card.Id, card.CodeLast4, card.Status, card.Source, card.Balance, card.InitialAmount, card.Currency)
+	if card.ExpiresAt != nil {
+		fmt.Printf("Expires: %s\n", card.ExpiresAt.Format(time.RFC3339))
+	}
+}
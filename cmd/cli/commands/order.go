@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,13 +40,14 @@ type shipmentInspect struct {
 }
 
 type orderInspectResponse struct {
-	Order           apicontract.Order              `json:"order"`
-	User            *models.User                   `json:"user,omitempty"`
-	CheckoutSession *models.CheckoutSession        `json:"checkout_session,omitempty"`
-	Payments        apicontract.OrderPaymentLedger `json:"payments"`
-	StatusHistory   []models.OrderStatusHistory    `json:"status_history"`
-	Snapshots       []orderSnapshotInspect         `json:"snapshots"`
-	Shipments       []shipmentInspect              `json:"shipments"`
+	Order           apicontract.Order                `json:"order"`
+	User            *models.User                     `json:"user,omitempty"`
+	CheckoutSession *models.CheckoutSession          `json:"checkout_session,omitempty"`
+	Payments        apicontract.OrderPaymentLedger   `json:"payments"`
+	StatusHistory   []models.OrderStatusHistory      `json:"status_history"`
+	Snapshots       []orderSnapshotInspect           `json:"snapshots"`
+	Shipments       []shipmentInspect                `json:"shipments"`
+	Timeline        []apicontract.OrderTimelineEvent `json:"timeline"`
 }
 
 func NewOrderCmd() *cobra.Command {
@@ -64,33 +66,29 @@ func NewOrderCmd() *cobra.Command {
 
 func newListOrdersCmd() *cobra.Command {
 	var format string
-	var query string
+	var filters orderListFilters
 	var page int
 	var limit int
 
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "List orders",
+		Short: "List and search orders",
+		Long: `List orders, newest first. Filters combine; --view applies a saved
+admin order view, and any filter given alongside it replaces the view's.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			values := url.Values{}
-			if strings.TrimSpace(query) != "" {
-				values.Set("q", strings.TrimSpace(query))
-			}
-			if page > 0 {
-				values.Set("page", fmt.Sprintf("%d", page))
-			}
-			if limit > 0 {
-				values.Set("limit", fmt.Sprintf("%d", limit))
-			}
-
-			path := "/api/v1/admin/orders"
-			if encoded := values.Encode(); encoded != "" {
-				path += "?" + encoded
+			params, err := filters.params(cmd)
+			if err != nil {
+				return err
 			}
+			params.Page = positiveIntPointer(page)
+			params.Limit = positiveIntPointer(limit)
 
 			var resp apicontract.OrderPage
-			var err error
 			if isRemoteMode() {
+				path := "/api/v1/admin/orders"
+				if encoded := orderListQuery(params).Encode(); encoded != "" {
+					path += "?" + encoded
+				}
 				resp, err = invokeRemoteJSON[apicontract.OrderPage](http.MethodGet, path, nil)
 			} else {
 				mediaService := newMediaService()
@@ -99,7 +97,7 @@ func newListOrdersCmd() *cobra.Command {
 				if endpointErr != nil {
 					return endpointErr
 				}
-				response, endpointErr := endpoints.ListAdminOrders(cmd.Context(), apicontract.ListAdminOrdersRequestObject{Params: apicontract.ListAdminOrdersParams{Q: stringPointer(query), Page: positiveIntPointer(page), Limit: positiveIntPointer(limit)}})
+				response, endpointErr := endpoints.ListAdminOrders(cmd.Context(), apicontract.ListAdminOrdersRequestObject{Params: params})
 				if endpointErr != nil {
 					return endpointErr
 				}
@@ -123,32 +121,109 @@ func newListOrdersCmd() *cobra.Command {
 				return nil
 			}
 
-			fmt.Printf("%-8s %-10s %-12s %-10s %-22s\n", "ID", "User", "Status", "Total", "Created")
-			fmt.Println("------------------------------------------------------------------")
+			fmt.Printf("%-8s %-10s %-12s %-10s %-22s %s\n", "ID", "User", "Status", "Total", "Created", "Tags")
+			fmt.Println("--------------------------------------------------------------------------------")
 			for _, order := range resp.Data {
 				userID := "guest"
 				if order.UserId != nil {
 					userID = fmt.Sprintf("%d", *order.UserId)
 				}
-				fmt.Printf("%-8d %-10s %-12s $%-9.2f %-22s\n",
+				tags := ""
+				if order.Tags != nil {
+					tags = strings.Join(*order.Tags, ",")
+				}
+				fmt.Printf("%-8d %-10s %-12s $%-9.2f %-22s %s\n",
 					order.Id,
 					userID,
 					order.Status,
 					order.Total,
 					order.CreatedAt.Format(time.RFC3339),
+					tags,
 				)
 			}
+			fmt.Printf("Page %d of %d (%d orders)\n", resp.Pagination.Page, resp.Pagination.TotalPages, resp.Pagination.Total)
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&query, "q", "", "Search term")
+	cmd.Flags().StringVar(&filters.query, "q", "", "Search term matched against id, guest email and status")
+	cmd.Flags().StringVar(&filters.email, "email", "", "Part of the guest or account email")
+	cmd.Flags().StringVar(&filters.name, "name", "", "Part of the customer name, username or shipping address")
+	cmd.Flags().StringVar(&filters.sku, "sku", "", "Part of any line's SKU")
+	cmd.Flags().StringVar(&filters.token, "token", "", "Order confirmation token")
+	cmd.Flags().StringVar(&filters.tracking, "tracking", "", "Shipment tracking number")
+	cmd.Flags().StringVar(&filters.paymentRef, "payment-ref", "", "Payment provider transaction reference")
+	cmd.Flags().StringVar(&filters.tag, "tag", "", "Order tag")
+	cmd.Flags().StringVar(&filters.status, "status", "", "Order status, e.g. PAID or SHIPPED")
+	cmd.Flags().StringVar(&filters.from, "from", "", "Placed on or after this date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&filters.to, "to", "", "Placed on or before this date (YYYY-MM-DD)")
+	cmd.Flags().Float64Var(&filters.minTotal, "min-total", 0, "Minimum order total")
+	cmd.Flags().Float64Var(&filters.maxTotal, "max-total", 0, "Maximum order total")
+	cmd.Flags().IntVar(&filters.view, "view", 0, "Saved order view ID")
 	cmd.Flags().IntVar(&page, "page", 0, "Page number")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Page size")
 	addOutputFormatFlag(cmd, &format, string(outputFormatText))
 	return cmd
 }
 
+type orderListFilters struct {
+	query, email, name, sku, token, tracking, paymentRef, tag, status, from, to string
+	minTotal, maxTotal                                                          float64
+	view                                                                        int
+}
+
+func (f orderListFilters) params(cmd *cobra.Command) (apicontract.ListAdminOrdersParams, error) {
+	params := apicontract.ListAdminOrdersParams{
+		Q: stringPointer(f.query), Email: stringPointer(f.email), Name: stringPointer(f.name), Sku: stringPointer(f.sku),
+		ConfirmationToken: stringPointer(f.token), TrackingNumber: stringPointer(f.tracking),
+		PaymentReference: stringPointer(f.paymentRef), Tag: stringPointer(f.tag),
+		StartDate: stringPointer(f.from), EndDate: stringPointer(f.to), View: positiveIntPointer(f.view),
+	}
+	if status := stringPointer(f.status); status != nil {
+		if !models.IsValidOrderStatus(strings.ToUpper(*status)) {
+			return params, fmt.Errorf("invalid status %q", *status)
+		}
+		value := apicontract.ListAdminOrdersParamsStatus(strings.ToUpper(*status))
+		params.Status = &value
+	}
+	if cmd.Flags().Changed("min-total") {
+		params.MinTotal = &f.minTotal
+	}
+	if cmd.Flags().Changed("max-total") {
+		params.MaxTotal = &f.maxTotal
+	}
+	return params, nil
+}
+
+// orderListQuery encodes params as the admin order list query string.
+func orderListQuery(params apicontract.ListAdminOrdersParams) url.Values {
+	values := url.Values{}
+	for key, value := range map[string]*string{
+		"q": params.Q, "email": params.Email, "name": params.Name, "sku": params.Sku,
+		"confirmation_token": params.ConfirmationToken, "tracking_number": params.TrackingNumber,
+		"payment_reference": params.PaymentReference, "tag": params.Tag,
+		"start_date": params.StartDate, "end_date": params.EndDate,
+	} {
+		if value != nil {
+			values.Set(key, *value)
+		}
+	}
+	if params.Status != nil {
+		values.Set("status", string(*params.Status))
+	}
+	for key, value := range map[string]*float64{"min_total": params.MinTotal, "max_total": params.MaxTotal} {
+		if value != nil {
+			values.Set(key, strconv.FormatFloat(*value, 'f', -1, 64))
+		}
+	}
+	for key, value := range map[string]*int{"view": params.View, "page": params.Page, "limit": params.Limit} {
+		if value != nil {
+			values.Set(key, strconv.Itoa(*value))
+		}
+	}
+	return values
+}
+
 func stringPointer(value string) *string {
 	if strings.TrimSpace(value) == "" {
 		return nil
@@ -315,6 +390,18 @@ func newInspectOrderCmd() *cobra.Command {
 			fmt.Printf("Snapshots: %d\n", len(response.Snapshots))
 			fmt.Printf("Shipments: %d\n", len(response.Shipments))
 			fmt.Printf("Status Events: %d\n", len(response.StatusHistory))
+			fmt.Println()
+			fmt.Println("Timeline:")
+			for _, event := range response.Timeline {
+				line := fmt.Sprintf("  %s  %-22s %s", event.OccurredAt.Format(time.RFC3339), event.Type, event.Summary)
+				if event.Actor != "" {
+					line += "  by " + event.Actor
+				}
+				if event.CorrelationId != "" {
+					line += "  [" + event.CorrelationId + "]"
+				}
+				fmt.Println(line)
+			}
 			return nil
 		},
 	}
@@ -340,6 +427,10 @@ func buildOrderInspectResponse(db *gorm.DB, mediaService *media.Service, orderID
 		return orderInspectResponse{}, err
 	}
 	payments := apicontract.OrderPaymentLedger(paymentResponse.(apicontract.GetAdminOrderPayments200JSONResponse))
+	timelineResponse, err := endpoints.GetAdminOrderTimeline(context.Background(), apicontract.GetAdminOrderTimelineRequestObject{Id: int(orderID)})
+	if err != nil {
+		return orderInspectResponse{}, err
+	}
 
 	var orderRow models.Order
 	if err := db.First(&orderRow, orderID).Error; err != nil {
@@ -349,6 +440,7 @@ func buildOrderInspectResponse(db *gorm.DB, mediaService *media.Service, orderID
 	response := orderInspectResponse{
 		Order:    order,
 		Payments: payments,
+		Timeline: timelineResponse.(apicontract.GetAdminOrderTimeline200JSONResponse).Events,
 	}
 
 	if orderRow.UserID != nil {
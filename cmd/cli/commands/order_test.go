@@ -42,6 +42,15 @@ func TestBuildOrderInspectResponseIncludesOperationalData(t *testing.T) {
 		&models.OrderCheckoutSnapshotItem{},
 		&models.OrderTaxLine{},
 		&models.OrderStatusHistory{},
+		&models.OrderTag{},
+		&models.OrderNote{},
+		&models.ProviderOperation{},
+		&models.InventoryItem{},
+		&models.InventoryMovement{},
+		&models.InventoryReservation{},
+		&models.WebhookEvent{},
+		&models.DiscountCampaign{},
+		&models.DiscountRedemption{},
 		&models.Shipment{},
 		&models.ShipmentRate{},
 		&models.ShipmentPackage{},
@@ -272,4 +281,38 @@ func TestBuildOrderInspectResponseIncludesOperationalData(t *testing.T) {
 	if len(response.Shipments) != 1 || len(response.Shipments[0].TrackingEvents) != 1 {
 		t.Fatalf("expected shipment with tracking event, got %+v", response.Shipments)
 	}
+	types := map[string]bool{}
+	for _, event := range response.Timeline {
+		types[event.Type] = true
+	}
+	for _, want := range []string{"order.placed", "order.status_changed", "payment.capture", "shipment.created", "shipment.tracking"} {
+		if !types[want] {
+			t.Fatalf("expected a %s timeline event, got %+v", want, response.Timeline)
+		}
+	}
+}
+
+func TestOrderListFlagsBecomeAdminQuery(t *testing.T) {
+	cmd := newListOrdersCmd()
+	if err := cmd.ParseFlags([]string{"--email", " jane@example.com ", "--sku", "MUG", "--tag", "vip", "--status", "paid", "--from", "2026-08-01", "--min-total", "0", "--view", "3", "--limit", "50"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	var filters orderListFilters
+	filters.email, filters.sku, filters.tag, filters.status, filters.from, filters.view = " jane@example.com ", "MUG", "vip", "paid", "2026-08-01", 3
+	params, err := filters.params(cmd)
+	if err != nil {
+		t.Fatalf("params: %v", err)
+	}
+	limit := 50
+	params.Limit = &limit
+	got := orderListQuery(params).Encode()
+	want := "email=jane%40example.com&limit=50&min_total=0&sku=MUG&start_date=2026-08-01&status=PAID&tag=vip&view=3"
+	if got != want {
+		t.Fatalf("query = %q, want %q", got, want)
+	}
+
+	filters.status = "lost"
+	if _, err := filters.params(cmd); err == nil {
+		t.Fatal("expected an unknown status to be rejected")
+	}
 }
@@ -68,6 +68,7 @@ func newRootCmd() *cobra.Command {
 	rootCmd.AddCommand(NewProductAttributeCmd())
 	rootCmd.AddCommand(NewOrderCmd())
 	rootCmd.AddCommand(NewDiscountCmd())
+	rootCmd.AddCommand(NewGiftCardCmd())
 	rootCmd.AddCommand(NewInventoryCmd())
 	rootCmd.AddCommand(NewWebsiteCmd())
 	rootCmd.AddCommand(NewCMSCmd())
@@ -10,6 +10,16 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Rate limit stores. The memory store is the default: it costs nothing per
+// request but keeps counters per process, so with N replicas a client can
+// make up to N times the declared limit. The database store shares counters
+// across replicas at the price of a write to the primary on every limited
+// request; choose it when limits must hold cluster-wide.
+const (
+	RateLimitStoreDatabase = "database"
+	RateLimitStoreMemory   = "memory"
+)
+
 type Config struct {
 	DBURL                          string        `mapstructure:"DATABASE_URL"`
 	AutoApplyMigrations            bool          `mapstructure:"AUTO_APPLY_MIGRATIONS"`
@@ -28,6 +38,9 @@ type Config struct {
 	ProviderCredentialsKeyVersion  string        `mapstructure:"PROVIDER_CREDENTIALS_ACTIVE_KEY_VERSION"`
 	ProviderReconciliationInterval string        `mapstructure:"PROVIDER_RECONCILIATION_INTERVAL"`
 	CMSInvalidationWebhookURL      string        `mapstructure:"CMS_INVALIDATION_WEBHOOK_URL"`
+	AbandonedCartIdleAfter         time.Duration `mapstructure:"ABANDONED_CART_IDLE_AFTER"`
+	AbandonedCartOutboxPath        string        `mapstructure:"ABANDONED_CART_OUTBOX_PATH"`
+	RateLimitStore                 string        `mapstructure:"RATE_LIMIT_STORE"`
 	HTTPReadHeaderTimeout          time.Duration `mapstructure:"HTTP_READ_HEADER_TIMEOUT"`
 	HTTPReadTimeout                time.Duration `mapstructure:"HTTP_READ_TIMEOUT"`
 	HTTPWriteTimeout               time.Duration `mapstructure:"HTTP_WRITE_TIMEOUT"`
@@ -57,6 +70,9 @@ var configKeys = []string{
 	"PROVIDER_CREDENTIALS_ACTIVE_KEY_VERSION",
 	"PROVIDER_RECONCILIATION_INTERVAL",
 	"CMS_INVALIDATION_WEBHOOK_URL",
+	"ABANDONED_CART_IDLE_AFTER",
+	"ABANDONED_CART_OUTBOX_PATH",
+	"RATE_LIMIT_STORE",
 	"HTTP_READ_HEADER_TIMEOUT",
 	"HTTP_READ_TIMEOUT",
 	"HTTP_WRITE_TIMEOUT",
@@ -109,6 +125,8 @@ func LoadConfig() (config Config, err error) {
 	v.SetDefault("PROVIDER_QUERY_TIMEOUT", "15s")
 	v.SetDefault("PROVIDER_COMPENSATION_TIMEOUT", "1m")
 	v.SetDefault("PROVIDER_LEASE_DURATION", "2m")
+	v.SetDefault("ABANDONED_CART_IDLE_AFTER", "4h")
+	v.SetDefault("RATE_LIMIT_STORE", RateLimitStoreMemory)
 	v.AutomaticEnv()
 	for _, key := range configKeys {
 		if bindErr := v.BindEnv(key); bindErr != nil {
@@ -139,11 +157,15 @@ func (c Config) validate() error {
 		{"PROVIDER_QUERY_TIMEOUT", c.ProviderQueryTimeout},
 		{"PROVIDER_COMPENSATION_TIMEOUT", c.ProviderCompensationTimeout},
 		{"PROVIDER_LEASE_DURATION", c.ProviderLeaseDuration},
+		{"ABANDONED_CART_IDLE_AFTER", c.AbandonedCartIdleAfter},
 	}
 	for _, duration := range durations {
 		if duration.value <= 0 {
 			return fmt.Errorf("%s must be a positive duration", duration.name)
 		}
 	}
+	if c.RateLimitStore != RateLimitStoreDatabase && c.RateLimitStore != RateLimitStoreMemory {
+		return fmt.Errorf("RATE_LIMIT_STORE must be %q or %q", RateLimitStoreDatabase, RateLimitStoreMemory)
+	}
 	return nil
 }
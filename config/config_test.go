@@ -84,6 +84,7 @@ func TestLoadConfigDurationDefaults(t *testing.T) {
 		"PROVIDER_QUERY_TIMEOUT":        {cfg.ProviderQueryTimeout, 15 * time.Second},
 		"PROVIDER_COMPENSATION_TIMEOUT": {cfg.ProviderCompensationTimeout, time.Minute},
 		"PROVIDER_LEASE_DURATION":       {cfg.ProviderLeaseDuration, 2 * time.Minute},
+		"ABANDONED_CART_IDLE_AFTER":     {cfg.AbandonedCartIdleAfter, 4 * time.Hour},
 	}
 	for name, duration := range expected {
 		if duration.got != duration.want {
@@ -152,6 +153,24 @@ func TestLoadConfigRejectsInvalidDurations(t *testing.T) {
 	}
 }
 
+func TestLoadConfigRateLimitStore(t *testing.T) {
+	useEmptyConfigDir(t)
+	unsetEnvironment(t, configKeys...)
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.RateLimitStore != RateLimitStoreMemory {
+		t.Fatalf("RateLimitStore = %q, want %q", cfg.RateLimitStore, RateLimitStoreMemory)
+	}
+
+	t.Setenv("RATE_LIMIT_STORE", "redis")
+	if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), "RATE_LIMIT_STORE") {
+		t.Fatalf("LoadConfig accepted an unknown RATE_LIMIT_STORE: %v", err)
+	}
+}
+
 func TestLoadConfigAllowsMissingFiles(t *testing.T) {
 	tempDir := t.TempDir()
 	originalWD, err := os.Getwd()
@@ -377,6 +377,57 @@ export interface paths {
 		patch: operations["setDefaultPaymentMethod"];
 		trace?: never;
 	};
+	"/api/v1/me/gift-cards": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listMyGiftCards"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/me/saved-carts": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/** @description Saved carts the current user created or that were prepared for them. */
+		get: operations["listMySavedCarts"];
+		put?: never;
+		/** @description Saves the items of the current cart under a name and returns a shareable link token. */
+		post: operations["saveMyCart"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/me/saved-carts/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		/** @description Archives a saved cart owned by the current user and disables its share link. */
+		delete: operations["archiveMySavedCart"];
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
 	"/api/v1/me/addresses": {
 		parameters: {
 			query?: never;
@@ -553,23 +604,24 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/me/checkout/quote": {
+	"/api/v1/checkout/fields": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		/** @description Lists the merchant checkout fields shown on a sales channel. Submit their values as attributes when quoting and placing the order. */
+		get: operations["listCheckoutFields"];
 		put?: never;
-		post: operations["quoteCheckout"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/checkout/quote": {
+	"/api/v1/me/checkout/quote": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -578,14 +630,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["quoteCheckoutSession"];
+		post: operations["quoteCheckout"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/checkout/orders": {
+	"/api/v1/checkout/quote": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -594,30 +646,31 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["createCheckoutOrder"];
+		post: operations["quoteCheckoutSession"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/checkout/orders/{id}/payments/authorize": {
+	"/api/v1/checkout/state": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		/** @description Returns every checkout step with its validation status and the next action the shopper has to take. */
+		get: operations["getCheckoutState"];
 		put?: never;
-		post: operations["authorizeCheckoutOrderPayment"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/checkout/orders/{id}/shipping/rates": {
+	"/api/v1/checkout/state/contact": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -625,23 +678,25 @@ export interface paths {
 			cookie?: never;
 		};
 		get?: never;
-		put?: never;
-		post: operations["quoteCheckoutOrderShippingRates"];
+		/** @description Sets the contact email and phone. Changing them invalidates the later steps. */
+		put: operations["updateCheckoutContact"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/checkout/orders/{id}/shipping/tracking": {
+	"/api/v1/checkout/state/shipping-address": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getCheckoutOrderShippingTracking"];
-		put?: never;
+		get?: never;
+		/** @description Sets the shipping address. Requires the contact step and invalidates the shipping method, payment and review steps when it changes. */
+		put: operations["updateCheckoutShippingAddress"];
 		post?: never;
 		delete?: never;
 		options?: never;
@@ -649,7 +704,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/checkout/orders/{id}/tax/finalize": {
+	"/api/v1/checkout/state/shipping-method": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -657,31 +712,33 @@ export interface paths {
 			cookie?: never;
 		};
 		get?: never;
-		put?: never;
-		post: operations["finalizeCheckoutOrderTax"];
+		/** @description Selects a shipping provider for the current address and cart. */
+		put: operations["updateCheckoutShippingMethod"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/me/orders": {
+	"/api/v1/checkout/state/payment": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listUserOrders"];
-		put?: never;
-		post: operations["createOrder"];
+		get?: never;
+		/** @description Sets the payment provider and tax details after the shipping method is chosen. */
+		put: operations["updateCheckoutPayment"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/me/orders/claim": {
+	"/api/v1/checkout/state/review": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -690,78 +747,83 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["claimGuestOrder"];
+		/** @description Quotes the completed checkout, stores a checkout snapshot and completes the review step. The returned snapshot_id is used to authorize payment once the order is placed. Merchant checkout field values are validated here and kept for the order. */
+		post: operations["confirmCheckoutReview"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/me/orders/{id}": {
+	"/api/v1/checkout/cart/restore": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getUserOrder"];
+		get?: never;
 		put?: never;
-		post?: never;
+		/** @description Rebuilds an abandoned cart from a signed recovery link in a new checkout session and sets the session cookie. A link expires seven days after it is sent and restores its cart only once. */
+		post: operations["restoreCheckoutCart"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/me/orders/{id}/cancel": {
+	"/api/v1/checkout/saved-carts/{token}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		/** @description Previews a shared saved cart. */
+		get: operations["getCheckoutSavedCart"];
 		put?: never;
-		post: operations["cancelUserOrder"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/products": {
+	"/api/v1/checkout/saved-carts/{token}/convert": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminProducts"];
+		get?: never;
 		put?: never;
-		post: operations["createProduct"];
+		/** @description Adds the saved cart's items to the current checkout session cart. Lines from a price-locked cart keep the quoted unit price, for up to the quoted quantity, until the saved cart expires. A price-locked cart can be converted only once. */
+		post: operations["convertCheckoutSavedCart"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/campaigns": {
+	"/api/v1/checkout/express": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminDiscountCampaigns"];
+		get?: never;
 		put?: never;
-		post: operations["createAdminDiscountCampaign"];
+		/** @description Starts a buy-now checkout for a single variant in its own short-lived session. The shopper's regular cart is left untouched. Send the returned token in the X-Express-Checkout header on the cart, state, quote, order and payment operations to act on the express checkout instead of the regular cart. */
+		post: operations["createExpressCheckout"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/promotions": {
+	"/api/v1/checkout/draft-orders/{token}": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -770,14 +832,15 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["createAdminPromotionCampaign"];
+		/** @description Opens the checkout for a draft order's payment link, or resumes the one opened earlier. Send the returned token in the X-Express-Checkout header on the state, quote, order and payment operations to pay for the draft; its cart cannot be changed. A draft made for a customer only opens for that customer's account, and a guest draft only when signed out. */
+		post: operations["startDraftOrderCheckout"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/promotions/preview": {
+	"/api/v1/checkout/gift-cards/balance": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -786,30 +849,30 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["previewAdminPromotion"];
+		post: operations["checkGiftCardBalance"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/templates": {
+	"/api/v1/checkout/orders": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminPromotionTemplates"];
+		get?: never;
 		put?: never;
-		post: operations["createAdminPromotionTemplate"];
+		post: operations["createCheckoutOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/templates/{id}/instantiate": {
+	"/api/v1/checkout/orders/{id}/payments/authorize": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -818,14 +881,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["instantiateAdminPromotionTemplate"];
+		post: operations["authorizeCheckoutOrderPayment"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/campaigns/{id}": {
+	"/api/v1/checkout/orders/{id}/shipping/rates": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -834,30 +897,30 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post?: never;
+		post: operations["quoteCheckoutOrderShippingRates"];
 		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminDiscountCampaign"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/campaigns/{id}/disable": {
+	"/api/v1/checkout/orders/{id}/shipping/tracking": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getCheckoutOrderShippingTracking"];
 		put?: never;
-		post: operations["disableAdminDiscountCampaign"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/campaigns/{id}/schedule": {
+	"/api/v1/checkout/orders/{id}/tax/finalize": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -866,30 +929,30 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["scheduleAdminDiscountCampaign"];
+		post: operations["finalizeCheckoutOrderTax"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/campaigns/{id}/archive": {
+	"/api/v1/me/orders": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listUserOrders"];
 		put?: never;
-		post: operations["archiveAdminDiscountCampaign"];
+		post: operations["createOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/lifecycle/run": {
+	"/api/v1/me/orders/claim": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -898,21 +961,21 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["runAdminDiscountLifecycle"];
+		post: operations["claimGuestOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/history": {
+	"/api/v1/me/orders/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminDiscountHistory"];
+		get: operations["getUserOrder"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -921,14 +984,14 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/audit": {
+	"/api/v1/me/orders/{id}/documents": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminDiscountAudit"];
+		get: operations["listUserOrderDocuments"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -937,14 +1000,14 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/metrics": {
+	"/api/v1/me/orders/{id}/documents/{documentId}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminDiscountMetrics"];
+		get: operations["downloadUserOrderDocument"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -953,7 +1016,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/discounts/reconciliation/run": {
+	"/api/v1/me/orders/{id}/cancel": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -962,62 +1025,63 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["runAdminDiscountReconciliation"];
+		post: operations["cancelUserOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/brands": {
+	"/api/v1/me/orders/{id}/gift-cards": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminBrands"];
+		get?: never;
 		put?: never;
-		post: operations["createAdminBrand"];
+		/** @description Issues the gift cards bought on a paid order, one per unit of every gift card line, to the current user. Codes are returned only when a card is issued; calling again returns only cards that were still owed. */
+		post: operations["claimUserOrderGiftCards"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/brands/{id}": {
+	"/api/v1/admin/products": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminProducts"];
 		put?: never;
-		post?: never;
-		delete: operations["deleteAdminBrand"];
+		post: operations["createProduct"];
+		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminBrand"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/categories": {
+	"/api/v1/admin/discounts/campaigns": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCategories"];
+		get: operations["listAdminDiscountCampaigns"];
 		put?: never;
-		post: operations["createAdminCategory"];
+		post: operations["createAdminDiscountCampaign"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/categories/{id}": {
+	"/api/v1/admin/discounts/promotions": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1026,30 +1090,30 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post?: never;
-		delete: operations["deleteAdminCategory"];
+		post: operations["createAdminPromotionCampaign"];
+		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminCategory"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/product-attributes": {
+	"/api/v1/admin/discounts/promotions/preview": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminProductAttributes"];
+		get?: never;
 		put?: never;
-		post: operations["createAdminProductAttribute"];
+		post: operations["previewAdminPromotion"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/product-attributes/{id}": {
+	"/api/v1/admin/discounts/promotions/simulate": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1058,30 +1122,36 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post?: never;
-		delete: operations["deleteAdminProductAttribute"];
+		/**
+		 * @description Replays a draft promotion over paid, shipped and delivered orders placed
+		 *     in the window. Nothing is persisted. The draft's schedule, coupon code
+		 *     and usage caps are ignored: it is evaluated alone, as if it ran for the
+		 *     whole window and its coupon was presented on every order.
+		 */
+		post: operations["simulateAdminPromotion"];
+		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminProductAttribute"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/products/{id}": {
+	"/api/v1/admin/discounts/templates": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminProduct"];
+		get: operations["listAdminPromotionTemplates"];
 		put?: never;
-		post?: never;
-		delete: operations["deleteProduct"];
+		post: operations["createAdminPromotionTemplate"];
+		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateProduct"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/products/{id}/media": {
+	"/api/v1/admin/discounts/templates/{id}/instantiate": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1090,14 +1160,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["attachProductMedia"];
+		post: operations["instantiateAdminPromotionTemplate"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/products/{id}/publish": {
+	"/api/v1/admin/discounts/campaigns/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1106,14 +1176,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["publishProduct"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
-		patch?: never;
+		patch: operations["updateAdminDiscountCampaign"];
 		trace?: never;
 	};
-	"/api/v1/admin/products/{id}/unpublish": {
+	"/api/v1/admin/discounts/campaigns/{id}/disable": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1122,14 +1192,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["unpublishProduct"];
+		post: operations["disableAdminDiscountCampaign"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/products/{id}/draft": {
+	"/api/v1/admin/discounts/campaigns/{id}/schedule": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1138,14 +1208,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post?: never;
-		delete: operations["discardProductDraft"];
+		post: operations["scheduleAdminDiscountCampaign"];
+		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/products/{id}/media/order": {
+	"/api/v1/admin/discounts/campaigns/{id}/archive": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1154,30 +1224,30 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post?: never;
+		post: operations["archiveAdminDiscountCampaign"];
 		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateProductMediaOrder"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/products/{id}/media/{mediaId}": {
+	"/api/v1/admin/discounts/campaigns/{id}/budget": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminDiscountCampaignBudget"];
 		put?: never;
 		post?: never;
-		delete: operations["detachProductMedia"];
+		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/products/{id}/related": {
+	"/api/v1/admin/discounts/schedules/preview": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1186,37 +1256,37 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post?: never;
+		post: operations["previewAdminDiscountSchedule"];
 		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateProductRelated"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/orders": {
+	"/api/v1/admin/discounts/lifecycle/run": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminOrders"];
+		get?: never;
 		put?: never;
-		post?: never;
+		post: operations["runAdminDiscountLifecycle"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/orders/{id}": {
+	"/api/v1/admin/discounts/history": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminOrder"];
+		get: operations["listAdminDiscountHistory"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -1225,14 +1295,14 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/orders/{id}/payments": {
+	"/api/v1/admin/discounts/audit": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminOrderPayments"];
+		get: operations["listAdminDiscountAudit"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -1241,23 +1311,23 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/orders/{id}/shipping/labels": {
+	"/api/v1/admin/discounts/metrics": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminDiscountMetrics"];
 		put?: never;
-		post: operations["createAdminOrderShippingLabel"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/orders/{id}/payments/{intentId}/capture": {
+	"/api/v1/admin/discounts/reconciliation/run": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1266,30 +1336,46 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["captureAdminOrderPayment"];
+		post: operations["runAdminDiscountReconciliation"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/orders/{id}/payments/{intentId}/void": {
+	"/api/v1/admin/customer-segments": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminCustomerSegments"];
 		put?: never;
-		post: operations["voidAdminOrderPayment"];
+		post: operations["createAdminCustomerSegment"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/orders/{id}/payments/{intentId}/refund": {
+	"/api/v1/admin/customer-segments/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCustomerSegment"];
+		put?: never;
+		post?: never;
+		delete: operations["deleteAdminCustomerSegment"];
+		options?: never;
+		head?: never;
+		patch: operations["updateAdminCustomerSegment"];
+		trace?: never;
+	};
+	"/api/v1/admin/customer-segments/{id}/refresh": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1298,37 +1384,37 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["refundAdminOrderPayment"];
+		post: operations["refreshAdminCustomerSegment"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/orders/{id}/status": {
+	"/api/v1/admin/customer-segments/{id}/members": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminCustomerSegmentMembers"];
 		put?: never;
 		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateOrderStatus"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/webhooks/events": {
+	"/api/v1/admin/abandoned-carts": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminWebhookEvents"];
+		get: operations["listAdminAbandonedCarts"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -1337,14 +1423,14 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/tax/reports/export": {
+	"/api/v1/admin/abandoned-carts/report": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["exportAdminTaxReport"];
+		get: operations["getAdminAbandonedCartReport"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -1353,30 +1439,31 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/credentials": {
+	"/api/v1/admin/saved-carts": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminProviderCredentials"];
+		get: operations["listAdminSavedCarts"];
 		put?: never;
-		post: operations["upsertAdminProviderCredential"];
+		/** @description Creates a saved cart or quote on behalf of a customer. Unit prices may only be overridden when prices are locked. */
+		post: operations["createAdminSavedCart"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/overview": {
+	"/api/v1/admin/saved-carts/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminProviderOperationsOverview"];
+		get: operations["getAdminSavedCart"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -1385,7 +1472,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/credentials/{id}/rotate": {
+	"/api/v1/admin/saved-carts/{id}/archive": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1394,37 +1481,37 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["rotateAdminProviderCredential"];
+		post: operations["archiveAdminSavedCart"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/operations": {
+	"/api/v1/admin/gift-cards": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminProviderOperations"];
+		get: operations["listAdminGiftCards"];
 		put?: never;
-		post?: never;
+		post: operations["issueAdminGiftCard"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/operations/{id}": {
+	"/api/v1/admin/gift-cards/reconciliation": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminProviderOperation"];
+		get: operations["reconcileAdminGiftCards"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -1433,23 +1520,23 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/operations/{id}/query-outcome": {
+	"/api/v1/admin/gift-cards/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminGiftCard"];
 		put?: never;
-		post: operations["queryAdminProviderOperationOutcome"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/operations/{id}/retry-finalize": {
+	"/api/v1/admin/gift-cards/{id}/adjustments": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1458,14 +1545,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["retryFinalizeAdminProviderOperation"];
+		post: operations["adjustAdminGiftCard"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/operations/{id}/retry-compensation": {
+	"/api/v1/admin/gift-cards/{id}/status": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1474,142 +1561,142 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["retryCompensationAdminProviderOperation"];
+		post: operations["updateAdminGiftCardStatus"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/reconciliation/cases": {
+	"/api/v1/admin/brands": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminProviderReconciliationCases"];
+		get: operations["listAdminBrands"];
 		put?: never;
-		post?: never;
+		post: operations["createAdminBrand"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/reconciliation/cases/{id}": {
+	"/api/v1/admin/brands/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminProviderReconciliationCase"];
+		get?: never;
 		put?: never;
 		post?: never;
-		delete?: never;
+		delete: operations["deleteAdminBrand"];
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminProviderReconciliationCase"];
+		patch: operations["updateAdminBrand"];
 		trace?: never;
 	};
-	"/api/v1/admin/providers/reconciliation/runs": {
+	"/api/v1/admin/categories": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminProviderReconciliationRuns"];
+		get: operations["listAdminCategories"];
 		put?: never;
-		post: operations["createAdminProviderReconciliationRun"];
+		post: operations["createAdminCategory"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/providers/reconciliation/runs/{id}": {
+	"/api/v1/admin/categories/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminProviderReconciliationRun"];
+		get?: never;
 		put?: never;
 		post?: never;
-		delete?: never;
+		delete: operations["deleteAdminCategory"];
 		options?: never;
 		head?: never;
-		patch?: never;
+		patch: operations["updateAdminCategory"];
 		trace?: never;
 	};
-	"/api/v1/webhooks/{provider}": {
+	"/api/v1/admin/product-attributes": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminProductAttributes"];
 		put?: never;
-		post: operations["receiveWebhookEvent"];
+		post: operations["createAdminProductAttribute"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/users": {
+	"/api/v1/admin/product-attributes/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listUsers"];
+		get?: never;
 		put?: never;
 		post?: never;
-		delete?: never;
+		delete: operations["deleteAdminProductAttribute"];
 		options?: never;
 		head?: never;
-		patch?: never;
+		patch: operations["updateAdminProductAttribute"];
 		trace?: never;
 	};
-	"/api/v1/admin/users/{id}/role": {
+	"/api/v1/admin/products/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminProduct"];
 		put?: never;
 		post?: never;
-		delete?: never;
+		delete: operations["deleteProduct"];
 		options?: never;
 		head?: never;
-		patch: operations["updateUserRole"];
+		patch: operations["updateProduct"];
 		trace?: never;
 	};
-	"/api/v1/admin/checkout/plugins": {
+	"/api/v1/admin/products/{id}/media": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCheckoutPlugins"];
+		get?: never;
 		put?: never;
-		post?: never;
+		post: operations["attachProductMedia"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/checkout/plugins/{type}/{id}": {
+	"/api/v1/admin/products/{id}/publish": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1618,46 +1705,46 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post?: never;
+		post: operations["publishProduct"];
 		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminCheckoutPlugin"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/reservations": {
+	"/api/v1/admin/products/{id}/unpublish": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminInventoryReservations"];
+		get?: never;
 		put?: never;
-		post?: never;
+		post: operations["unpublishProduct"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/alerts": {
+	"/api/v1/admin/products/{id}/draft": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminInventoryAlerts"];
+		get?: never;
 		put?: never;
 		post?: never;
-		delete?: never;
+		delete: operations["discardProductDraft"];
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/alerts/{id}/ack": {
+	"/api/v1/admin/products/{id}/media/order": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1666,14 +1753,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["ackAdminInventoryAlert"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
-		patch?: never;
+		patch: operations["updateProductMediaOrder"];
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/alerts/{id}/resolve": {
+	"/api/v1/admin/products/{id}/media/{mediaId}": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1682,46 +1769,47 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["resolveAdminInventoryAlert"];
-		delete?: never;
+		post?: never;
+		delete: operations["detachProductMedia"];
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/thresholds": {
+	"/api/v1/admin/products/{id}/related": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminInventoryThresholds"];
-		put: operations["upsertAdminInventoryThreshold"];
+		get?: never;
+		put?: never;
 		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
-		patch?: never;
+		patch: operations["updateProductRelated"];
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/thresholds/{id}": {
+	"/api/v1/admin/order-views": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminOrderViews"];
 		put?: never;
-		post?: never;
-		delete: operations["deleteAdminInventoryThreshold"];
+		/** @description Saves a named set of order list filters for the signed-in admin. Other admins do not see it. */
+		post: operations["createAdminOrderView"];
+		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/adjustments": {
+	"/api/v1/admin/order-views/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1729,38 +1817,39 @@ export interface paths {
 			cookie?: never;
 		};
 		get?: never;
-		put?: never;
-		post: operations["createAdminInventoryAdjustment"];
-		delete?: never;
+		put: operations["updateAdminOrderView"];
+		post?: never;
+		delete: operations["deleteAdminOrderView"];
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/reconciliation": {
+	"/api/v1/admin/orders": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminOrders"];
 		put?: never;
-		post: operations["runAdminInventoryReconciliation"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/inventory/variants/{product_variant_id}/timeline": {
+	"/api/v1/admin/orders/export": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminInventoryTimeline"];
+		/** @description Exports orders as CSV with one column per checkout field attribute. */
+		get: operations["exportAdminOrders"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -1769,39 +1858,39 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/purchase-orders": {
+	"/api/v1/admin/orders/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminPurchaseOrders"];
+		get: operations["getAdminOrder"];
 		put?: never;
-		post: operations["createAdminPurchaseOrder"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/purchase-orders/{id}/issue": {
+	"/api/v1/admin/orders/{id}/payments": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminOrderPayments"];
 		put?: never;
-		post: operations["issueAdminPurchaseOrder"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/purchase-orders/{id}/cancel": {
+	"/api/v1/admin/orders/{id}/shipping/labels": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1810,14 +1899,14 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["cancelAdminPurchaseOrder"];
+		post: operations["createAdminOrderShippingLabel"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/purchase-orders/{id}/receive": {
+	"/api/v1/admin/orders/{id}/payments/{intentId}/capture": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1826,30 +1915,30 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["receiveAdminPurchaseOrder"];
+		post: operations["captureAdminOrderPayment"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages": {
+	"/api/v1/admin/orders/{id}/payments/{intentId}/void": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCmsPages"];
+		get?: never;
 		put?: never;
-		post: operations["createAdminCmsPage"];
+		post: operations["voidAdminOrderPayment"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/preview": {
+	"/api/v1/admin/orders/{id}/payments/{intentId}/refund": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -1858,86 +1947,86 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["previewAdminCmsPayload"];
+		post: operations["refundAdminOrderPayment"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}": {
+	"/api/v1/admin/orders/{id}/status": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsPage"];
+		get?: never;
 		put?: never;
 		post?: never;
-		delete: operations["deleteAdminCmsPage"];
+		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminCmsPage"];
+		patch: operations["updateOrderStatus"];
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/publish": {
+	"/api/v1/admin/webhooks/events": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminWebhookEvents"];
 		put?: never;
-		post: operations["publishAdminCmsPage"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/unpublish": {
+	"/api/v1/admin/tax/reports/export": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["exportAdminTaxReport"];
 		put?: never;
-		post: operations["unpublishAdminCmsPage"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/draft": {
+	"/api/v1/admin/providers/credentials": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminProviderCredentials"];
 		put?: never;
-		post?: never;
-		delete: operations["discardAdminCmsPageDraft"];
+		post: operations["upsertAdminProviderCredential"];
+		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/locales": {
+	"/api/v1/admin/providers/overview": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsLocales"];
-		put: operations["updateAdminCmsLocales"];
+		get: operations["getAdminProviderOperationsOverview"];
+		put?: never;
 		post?: never;
 		delete?: never;
 		options?: never;
@@ -1945,94 +2034,110 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/variants": {
+	"/api/v1/admin/providers/credentials/{id}/rotate": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCmsPageVariants"];
+		get?: never;
 		put?: never;
-		post: operations["createAdminCmsPageVariant"];
+		post: operations["rotateAdminProviderCredential"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/variants/{variant_id}": {
+	"/api/v1/admin/providers/operations": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
-		put: operations["updateAdminCmsPageVariant"];
+		get: operations["listAdminProviderOperations"];
+		put?: never;
 		post?: never;
-		delete: operations["deleteAdminCmsPageVariant"];
+		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/variants/{variant_id}/{action}": {
+	"/api/v1/admin/providers/operations/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminProviderOperation"];
 		put?: never;
-		post: operations["transitionAdminCmsPageVariant"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/audit": {
+	"/api/v1/admin/providers/operations/{id}/query-outcome": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCmsAuditEvents"];
+		get?: never;
 		put?: never;
-		post?: never;
+		post: operations["queryAdminProviderOperationOutcome"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/governance": {
+	"/api/v1/admin/providers/operations/{id}/retry-finalize": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsGovernance"];
-		put: operations["updateAdminCmsGovernance"];
-		post?: never;
+		get?: never;
+		put?: never;
+		post: operations["retryFinalizeAdminProviderOperation"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/entries/{id}/workflow": {
+	"/api/v1/admin/providers/operations/{id}/retry-compensation": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsEntryWorkflow"];
+		get?: never;
+		put?: never;
+		post: operations["retryCompensationAdminProviderOperation"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/providers/reconciliation/cases": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminProviderReconciliationCases"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -2041,39 +2146,55 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/entries/{id}/workflow/{action}": {
+	"/api/v1/admin/providers/reconciliation/cases/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminProviderReconciliationCase"];
 		put?: never;
-		post: operations["transitionAdminCmsEntryWorkflow"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch: operations["updateAdminProviderReconciliationCase"];
+		trace?: never;
+	};
+	"/api/v1/admin/providers/reconciliation/runs": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminProviderReconciliationRuns"];
+		put?: never;
+		post: operations["createAdminProviderReconciliationRun"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/entries/{id}/comments": {
+	"/api/v1/admin/providers/reconciliation/runs/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminProviderReconciliationRun"];
 		put?: never;
-		post: operations["createAdminCmsEntryComment"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/comments/{id}/resolve": {
+	"/api/v1/webhooks/{provider}": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2082,30 +2203,30 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["resolveAdminCmsComment"];
+		post: operations["receiveWebhookEvent"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/entries/{id}/variants": {
+	"/api/v1/admin/users": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCmsEntryVariants"];
+		get: operations["listUsers"];
 		put?: never;
-		post: operations["createAdminCmsEntryVariant"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/entries/{id}/variants/{variant_id}": {
+	"/api/v1/admin/users/{id}/role": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2113,38 +2234,86 @@ export interface paths {
 			cookie?: never;
 		};
 		get?: never;
-		put: operations["updateAdminCmsEntryVariant"];
+		put?: never;
 		post?: never;
-		delete: operations["deleteAdminCmsEntryVariant"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch: operations["updateUserRole"];
+		trace?: never;
+	};
+	"/api/v1/admin/checkout/fields": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCheckoutFields"];
+		put?: never;
+		post: operations["createAdminCheckoutField"];
+		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/entries/{id}/variants/{variant_id}/{action}": {
+	"/api/v1/admin/checkout/fields/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["getAdminCheckoutField"];
+		put: operations["updateAdminCheckoutField"];
+		post?: never;
+		delete: operations["deleteAdminCheckoutField"];
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/risk/settings": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminRiskSettings"];
+		put: operations["updateAdminRiskSettings"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/fulfillment-orders": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminFulfillmentOrders"];
 		put?: never;
-		post: operations["transitionAdminCmsEntryVariant"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/operations": {
+	"/api/v1/admin/fulfillment-orders/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsOperations"];
+		get: operations["getAdminFulfillmentOrder"];
 		put?: never;
 		post?: never;
 		delete?: never;
@@ -2153,7 +2322,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/operations/invalidation/{id}/retry": {
+	"/api/v1/admin/fulfillment-orders/{id}/pick": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2162,14 +2331,15 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["retryAdminCmsInvalidation"];
+		/** @description Prints the pick list and moves an unfulfilled order to PICKING. */
+		post: operations["pickAdminFulfillmentOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/restore/preview": {
+	"/api/v1/admin/fulfillment-orders/{id}/pack": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2178,30 +2348,32 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["previewAdminCmsRestore"];
+		/** @description Confirms the packed quantities. Every line must be packed in full. */
+		post: operations["packAdminFulfillmentOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/export": {
+	"/api/v1/admin/fulfillment-orders/{id}/ship": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["exportAdminCmsContent"];
+		get?: never;
 		put?: never;
-		post: operations["restoreAdminCmsContent"];
+		/** @description Records the hand-over to the carrier once a label was bought. The order moves to PARTIALLY_SHIPPED while other units are still to ship and to SHIPPED once none are. */
+		post: operations["shipAdminFulfillmentOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/rollback": {
+	"/api/v1/admin/fulfillment-orders/{id}/route": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2210,22 +2382,24 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["rollbackAdminCmsPage"];
+		/** @description Overrides the routing rules for a fulfillment order nobody has started picking. The order's committed stock for its lines moves to the chosen location, which must be active, sellable and hold enough of it. */
+		post: operations["routeAdminFulfillmentOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/delivery": {
+	"/api/v1/admin/orders/{id}/timeline": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsPageDelivery"];
-		put: operations["updateAdminCmsPageDelivery"];
+		/** @description Merges the order's status changes, payment transactions, provider operations, shipments and tracking updates, inventory movements and released stock reservations, webhooks, discount redemptions and staff notes into one chronological list. Notes added through the notes endpoint appear as comment events. */
+		get: operations["getAdminOrderTimeline"];
+		put?: never;
 		post?: never;
 		delete?: never;
 		options?: never;
@@ -2233,15 +2407,16 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/pages/{id}/seo": {
+	"/api/v1/admin/orders/{id}/tags": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsPageSeo"];
-		put: operations["updateAdminCmsPageSeo"];
+		get?: never;
+		/** @description Replaces the order's tags. Tags are lowercased and duplicates dropped. */
+		put: operations["setAdminOrderTags"];
 		post?: never;
 		delete?: never;
 		options?: never;
@@ -2249,23 +2424,24 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/redirects": {
+	"/api/v1/admin/orders/{id}/notes": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCmsRedirects"];
+		get: operations["listAdminOrderNotes"];
 		put?: never;
-		post: operations["createAdminCmsRedirect"];
+		/** @description Adds an internal note. Notes are only visible to staff. */
+		post: operations["createAdminOrderNote"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/redirects/{id}": {
+	"/api/v1/admin/orders/{id}/notes/{noteId}": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2275,61 +2451,62 @@ export interface paths {
 		get?: never;
 		put?: never;
 		post?: never;
-		delete: operations["deleteAdminCmsRedirect"];
+		delete: operations["deleteAdminOrderNote"];
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminCmsRedirect"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/navigation": {
+	"/api/v1/admin/orders/{id}/documents": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCmsNavigation"];
+		get: operations["listAdminOrderDocuments"];
 		put?: never;
-		post: operations["createAdminCmsNavigation"];
+		/** @description Issues an invoice, a packing slip for one of the order's fulfillment orders, or a credit note for one of its refunds. Documents are rendered once, as PDF and HTML, and never change afterwards; asking for a document that was already issued returns it. Credit notes are also issued automatically when a refund completes or store credit is issued in place of one, invoicing the order first if it never was. */
+		post: operations["issueAdminOrderDocument"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/navigation/{id}": {
+	"/api/v1/admin/orders/{id}/documents/{documentId}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsNavigation"];
+		get: operations["downloadAdminOrderDocument"];
 		put?: never;
 		post?: never;
-		delete: operations["deleteAdminCmsNavigation"];
+		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminCmsNavigation"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/navigation/{id}/publish": {
+	"/api/v1/admin/orders/{id}/fulfillment-orders": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminOrderFulfillmentOrders"];
 		put?: never;
-		post: operations["publishAdminCmsNavigation"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/navigation/{id}/unpublish": {
+	"/api/v1/admin/orders/{id}/items/{itemId}/cancel": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2338,62 +2515,65 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["unpublishAdminCmsNavigation"];
+		/** @description Cancels units of a paid line that will not ship, such as a backorder that cannot be filled. Their stock is released, open fulfillment orders shrink and the order status is derived again from what has shipped. */
+		post: operations["cancelAdminOrderItem"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/navigation/{id}/draft": {
+	"/api/v1/admin/orders/{id}/edits": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminOrderEdits"];
 		put?: never;
-		post?: never;
-		delete: operations["discardAdminCmsNavigationDraft"];
+		/** @description Opens an edit session on a PAID order with nothing packed or shipped. The session starts from the order's unshipped lines and is priced through the discount campaigns and the order's shipping and tax providers. An order has at most one open edit. */
+		post: operations["beginAdminOrderEdit"];
+		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/global": {
+	"/api/v1/admin/order-edits/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["listAdminCmsGlobalRegions"];
+		get: operations["getAdminOrderEdit"];
 		put?: never;
-		post: operations["createAdminCmsGlobalRegion"];
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/global/{id}": {
+	"/api/v1/admin/order-edits/{id}/changes": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminCmsGlobalRegion"];
+		get?: never;
 		put?: never;
-		post?: never;
-		delete: operations["deleteAdminCmsGlobalRegion"];
+		/** @description Stages one change on an open edit and recalculates its totals and balance. Nothing on the order changes until the edit is committed. */
+		post: operations["stageAdminOrderEditChange"];
+		delete?: never;
 		options?: never;
 		head?: never;
-		patch: operations["updateAdminCmsGlobalRegion"];
+		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/global/{id}/publish": {
+	"/api/v1/admin/order-edits/{id}/commit": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2402,14 +2582,15 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["publishAdminCmsGlobalRegion"];
+		/** @description Settles the edit's balance and applies it to the order. A positive balance is authorized on the order's payment method and a negative one refunded from its captured payment, both through the provider operation executor. Once the payment succeeds, stock is committed or released, the order total and address are updated and the order is routed to its warehouses again. */
+		post: operations["commitAdminOrderEdit"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/global/{id}/unpublish": {
+	"/api/v1/admin/order-edits/{id}/cancel": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2418,38 +2599,41 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["unpublishAdminCmsGlobalRegion"];
+		/** @description Discards an open edit. The order is left as it was. */
+		post: operations["cancelAdminOrderEdit"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/cms/global/{id}/draft": {
+	"/api/v1/admin/legal-entities": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminLegalEntities"];
 		put?: never;
-		post?: never;
-		delete: operations["discardAdminCmsGlobalRegionDraft"];
+		/** @description Registers a company that issues invoices and credit notes. Orders shipping to its country are invoiced in its name; the default entity covers every other country. */
+		post: operations["createAdminLegalEntity"];
+		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/website": {
+	"/api/v1/admin/legal-entities/{id}": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminWebsiteSettings"];
-		put: operations["updateWebsiteSettings"];
+		get?: never;
+		/** @description Changes the entity's details. The code and the numbering sequences cannot be changed; new prefixes apply to documents issued from now on. */
+		put: operations["updateAdminLegalEntity"];
 		post?: never;
 		delete?: never;
 		options?: never;
@@ -2457,15 +2641,33 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/preview": {
+	"/api/v1/admin/draft-orders": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get: operations["getAdminPreview"];
+		get: operations["listAdminDraftOrders"];
 		put?: never;
+		/** @description Creates an order on behalf of a customer or a guest email, for example one taken over the phone. Lines may override the variant price and take a percentage discount. The draft is priced with the chosen shipping provider and the active tax provider; no stock is reserved until it is paid. */
+		post: operations["createAdminDraftOrder"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/draft-orders/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminDraftOrder"];
+		/** @description Replaces the draft's customer, lines and shipping and prices it again. An invoiced draft goes back to OPEN and its payment link stops working until it is sent again. */
+		put: operations["updateAdminDraftOrder"];
 		post?: never;
 		delete?: never;
 		options?: never;
@@ -2473,7 +2675,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/preview/start": {
+	"/api/v1/admin/draft-orders/{id}/payment-link": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2482,14 +2684,15 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["startAdminPreview"];
+		/** @description Marks the draft INVOICED and returns the link to send the customer. Opening the link starts a checkout holding the draft's lines at the draft's prices with the contact and shipping steps filled in. Sending the link again renews it and closes any checkout opened from the previous one. */
+		post: operations["sendAdminDraftOrderPaymentLink"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/admin/preview/stop": {
+	"/api/v1/admin/draft-orders/{id}/mark-paid": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2498,14 +2701,15 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["stopAdminPreview"];
+		/** @description Places the draft as a PAID order for a payment taken outside checkout, such as cash or a bank transfer. Stock is committed and the order is routed for fulfillment like any paid order. */
+		post: operations["markAdminDraftOrderPaid"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/media/uploads": {
+	"/api/v1/admin/draft-orders/{id}/cancel": {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2514,2723 +2718,7932 @@ export interface paths {
 		};
 		get?: never;
 		put?: never;
-		post: operations["createMediaUpload"];
+		/** @description Cancels a draft that was not paid and closes its payment link. */
+		post: operations["cancelAdminDraftOrder"];
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	"/api/v1/media/uploads/{path}": {
+	"/api/v1/admin/risk/reviews": {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
+		get: operations["listAdminRiskReviews"];
 		put?: never;
 		post?: never;
 		delete?: never;
 		options?: never;
-		head: operations["headMediaUpload"];
-		patch: operations["patchMediaUpload"];
+		head?: never;
+		patch?: never;
 		trace?: never;
 	};
-}
-export type webhooks = Record<string, never>;
-export interface components {
-	schemas: {
-		/** @description A machine-readable issue associated with a request value. */
-		ValidationIssue: {
-			/**
-			 * @description JSON Pointer identifying the request value, or an empty string for the document root.
-			 * @example /items/0/quantity
-			 */
-			path: string;
-			/**
-			 * @description Stable machine-readable issue code.
-			 * @example minimum
-			 */
-			code: string;
-			/**
-			 * @description Safe human-readable explanation of this issue occurrence.
-			 * @example Quantity must be at least 1.
-			 */
-			detail: string;
+	"/api/v1/admin/orders/{id}/risk": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		/** @description RFC 9457 problem details with stable application extensions. */
-		Problem: {
-			/**
-			 * Format: uri
-			 * @description Stable URI identifying the problem category.
-			 * @example https://ecommerce.local/problems/validation-failed
-			 */
-			type: string;
-			/**
-			 * @description Stable human-readable summary of the problem category.
-			 * @example Validation failed
-			 */
-			title: string;
-			/**
-			 * Format: int32
-			 * @description HTTP status code returned for this occurrence.
-			 * @example 422
-			 */
-			status: number;
-			/**
-			 * @description Safe occurrence-specific explanation.
-			 * @example One or more request values are invalid.
-			 */
-			detail: string;
-			/**
-			 * @description URI reference identifying this problem occurrence or related operation.
-			 * @example /api/v1/provider-operations/01J2EXAMPLE
-			 */
+		get: operations["getAdminOrderRisk"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/orders/{id}/risk/approve": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		/** @description Releases an order held for risk review so it can be captured and fulfilled. */
+		post: operations["approveAdminOrderRisk"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/orders/{id}/risk/reject": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		/** @description Cancels an order held for risk review. Any payment authorization stays open until it is voided through the order payment endpoints. */
+		post: operations["rejectAdminOrderRisk"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/checkout/plugins": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCheckoutPlugins"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/checkout/plugins/{type}/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch: operations["updateAdminCheckoutPlugin"];
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/reservations": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminInventoryReservations"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/alerts": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminInventoryAlerts"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/alerts/{id}/ack": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["ackAdminInventoryAlert"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/alerts/{id}/resolve": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["resolveAdminInventoryAlert"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/thresholds": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminInventoryThresholds"];
+		put: operations["upsertAdminInventoryThreshold"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/thresholds/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		delete: operations["deleteAdminInventoryThreshold"];
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/adjustments": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["createAdminInventoryAdjustment"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/reconciliation": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["runAdminInventoryReconciliation"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/variants/{product_variant_id}/timeline": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminInventoryTimeline"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/variants/{product_variant_id}/levels": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminInventoryLevels"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/locations": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminStockLocations"];
+		put?: never;
+		post: operations["createAdminStockLocation"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/locations/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch: operations["updateAdminStockLocation"];
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/transfers": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminInventoryTransfers"];
+		put?: never;
+		post: operations["createAdminInventoryTransfer"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/transfers/{id}/ship": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["shipAdminInventoryTransfer"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/transfers/{id}/receive": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["receiveAdminInventoryTransfer"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/inventory/transfers/{id}/cancel": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["cancelAdminInventoryTransfer"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/order-routing/rules": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminOrderRoutingRules"];
+		/** @description Replaces the ranking. Rules are applied in the order given; rules left out are kept, inactive, after them. */
+		put: operations["setAdminOrderRoutingRules"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/order-routing/simulate": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		/** @description Routes a hypothetical cart to a shipping address with the current rules and stock, without reserving anything. */
+		post: operations["simulateAdminOrderRouting"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/purchase-orders": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminPurchaseOrders"];
+		put?: never;
+		post: operations["createAdminPurchaseOrder"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/purchase-orders/{id}/issue": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["issueAdminPurchaseOrder"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/purchase-orders/{id}/cancel": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["cancelAdminPurchaseOrder"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/purchase-orders/{id}/receive": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["receiveAdminPurchaseOrder"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCmsPages"];
+		put?: never;
+		post: operations["createAdminCmsPage"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/preview": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["previewAdminCmsPayload"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsPage"];
+		put?: never;
+		post?: never;
+		delete: operations["deleteAdminCmsPage"];
+		options?: never;
+		head?: never;
+		patch: operations["updateAdminCmsPage"];
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/publish": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["publishAdminCmsPage"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/unpublish": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["unpublishAdminCmsPage"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/draft": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		delete: operations["discardAdminCmsPageDraft"];
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/locales": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsLocales"];
+		put: operations["updateAdminCmsLocales"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/variants": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCmsPageVariants"];
+		put?: never;
+		post: operations["createAdminCmsPageVariant"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/variants/{variant_id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put: operations["updateAdminCmsPageVariant"];
+		post?: never;
+		delete: operations["deleteAdminCmsPageVariant"];
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/variants/{variant_id}/{action}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["transitionAdminCmsPageVariant"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/audit": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCmsAuditEvents"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/governance": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsGovernance"];
+		put: operations["updateAdminCmsGovernance"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/entries/{id}/workflow": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsEntryWorkflow"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/entries/{id}/workflow/{action}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["transitionAdminCmsEntryWorkflow"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/entries/{id}/comments": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["createAdminCmsEntryComment"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/comments/{id}/resolve": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["resolveAdminCmsComment"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/entries/{id}/variants": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCmsEntryVariants"];
+		put?: never;
+		post: operations["createAdminCmsEntryVariant"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/entries/{id}/variants/{variant_id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put: operations["updateAdminCmsEntryVariant"];
+		post?: never;
+		delete: operations["deleteAdminCmsEntryVariant"];
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/entries/{id}/variants/{variant_id}/{action}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["transitionAdminCmsEntryVariant"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/operations": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsOperations"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/operations/invalidation/{id}/retry": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["retryAdminCmsInvalidation"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/restore/preview": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["previewAdminCmsRestore"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/export": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["exportAdminCmsContent"];
+		put?: never;
+		post: operations["restoreAdminCmsContent"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/rollback": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["rollbackAdminCmsPage"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/delivery": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsPageDelivery"];
+		put: operations["updateAdminCmsPageDelivery"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/pages/{id}/seo": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsPageSeo"];
+		put: operations["updateAdminCmsPageSeo"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/redirects": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCmsRedirects"];
+		put?: never;
+		post: operations["createAdminCmsRedirect"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/redirects/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		delete: operations["deleteAdminCmsRedirect"];
+		options?: never;
+		head?: never;
+		patch: operations["updateAdminCmsRedirect"];
+		trace?: never;
+	};
+	"/api/v1/admin/cms/navigation": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCmsNavigation"];
+		put?: never;
+		post: operations["createAdminCmsNavigation"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/navigation/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsNavigation"];
+		put?: never;
+		post?: never;
+		delete: operations["deleteAdminCmsNavigation"];
+		options?: never;
+		head?: never;
+		patch: operations["updateAdminCmsNavigation"];
+		trace?: never;
+	};
+	"/api/v1/admin/cms/navigation/{id}/publish": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["publishAdminCmsNavigation"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/navigation/{id}/unpublish": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["unpublishAdminCmsNavigation"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/navigation/{id}/draft": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		delete: operations["discardAdminCmsNavigationDraft"];
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/global": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["listAdminCmsGlobalRegions"];
+		put?: never;
+		post: operations["createAdminCmsGlobalRegion"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/global/{id}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminCmsGlobalRegion"];
+		put?: never;
+		post?: never;
+		delete: operations["deleteAdminCmsGlobalRegion"];
+		options?: never;
+		head?: never;
+		patch: operations["updateAdminCmsGlobalRegion"];
+		trace?: never;
+	};
+	"/api/v1/admin/cms/global/{id}/publish": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["publishAdminCmsGlobalRegion"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/global/{id}/unpublish": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["unpublishAdminCmsGlobalRegion"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/cms/global/{id}/draft": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		delete: operations["discardAdminCmsGlobalRegionDraft"];
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/website": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminWebsiteSettings"];
+		put: operations["updateWebsiteSettings"];
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/preview": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get: operations["getAdminPreview"];
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/preview/start": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["startAdminPreview"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/admin/preview/stop": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["stopAdminPreview"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/media/uploads": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post: operations["createMediaUpload"];
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	"/api/v1/media/uploads/{path}": {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head: operations["headMediaUpload"];
+		patch: operations["patchMediaUpload"];
+		trace?: never;
+	};
+}
+export type webhooks = Record<string, never>;
+export interface components {
+	schemas: {
+		/** @description A machine-readable issue associated with a request value. */
+		ValidationIssue: {
+			/**
+			 * @description JSON Pointer identifying the request value, or an empty string for the document root.
+			 * @example /items/0/quantity
+			 */
+			path: string;
+			/**
+			 * @description Stable machine-readable issue code.
+			 * @example minimum
+			 */
+			code: string;
+			/**
+			 * @description Safe human-readable explanation of this issue occurrence.
+			 * @example Quantity must be at least 1.
+			 */
+			detail: string;
+		};
+		/** @description RFC 9457 problem details with stable application extensions. */
+		Problem: {
+			/**
+			 * Format: uri
+			 * @description Stable URI identifying the problem category.
+			 * @example https://ecommerce.local/problems/validation-failed
+			 */
+			type: string;
+			/**
+			 * @description Stable human-readable summary of the problem category.
+			 * @example Validation failed
+			 */
+			title: string;
+			/**
+			 * Format: int32
+			 * @description HTTP status code returned for this occurrence.
+			 * @example 422
+			 */
+			status: number;
+			/**
+			 * @description Safe occurrence-specific explanation.
+			 * @example One or more request values are invalid.
+			 */
+			detail: string;
+			/**
+			 * @description URI reference identifying this problem occurrence or related operation.
+			 * @example /api/v1/provider-operations/01J2EXAMPLE
+			 */
 			instance?: string;
 			/**
-			 * @description Stable machine-readable application error code.
-			 * @example validation_failed
+			 * @description Stable machine-readable application error code.
+			 * @example validation_failed
+			 */
+			code: string;
+			/**
+			 * @description Identifier used to correlate the response with logs and traces.
+			 * @example 01J2EXAMPLE8Y4Q2S7M9K3N6P5R
+			 */
+			correlation_id: string;
+			/** @description Field or value issues, present when the problem has validation details. */
+			errors?: components["schemas"]["ValidationIssue"][];
+		};
+		/**
+		 * @deprecated
+		 * @description Transitional legacy error body. New and migrated operations use Problem.
+		 */
+		Error: {
+			error: string;
+			code?: string;
+		};
+		MessageResponse: {
+			message: string;
+		};
+		/** @enum {string} */
+		CmsEntryStatus: "DRAFT" | "SCHEDULED" | "PUBLISHED" | "ARCHIVED";
+		CmsEntry: {
+			id: number;
+			/** @enum {string} */
+			entry_type: "page" | "layout" | "global" | "navigation" | "template";
+			key: string;
+			status: components["schemas"]["CmsEntryStatus"];
+			current_version_id?: number | null;
+			published_version_id?: number | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsEntryVersion: {
+			id: number;
+			entry_id: number;
+			version_number: number;
+			schema_version: number;
+			payload: components["schemas"]["CmsPagePayload"];
+			created_by?: number | null;
+			change_summary?: string;
+			/** Format: date-time */
+			created_at: string;
+		};
+		CmsPublication: {
+			id: number;
+			entry_id: number;
+			version_id: number;
+			published_by?: number | null;
+			/** Format: date-time */
+			published_at: string;
+			rollback_from_publication_id?: number | null;
+			notes?: string;
+		};
+		CmsNavigationMenu: {
+			id: number;
+			entry_id: number;
+			key: string;
+			title: string;
+			location: string;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsNavigationItem: {
+			id: number;
+			menu_id: number;
+			parent_id?: number | null;
+			label: string;
+			/** @enum {string} */
+			item_type: "internal" | "external" | "category" | "product" | "page" | "dropdown";
+			target_ref: string;
+			url: string;
+			sort_order: number;
+			is_enabled: boolean;
+		};
+		CmsNavigationDraftRequest: {
+			key: string;
+			title: string;
+			location: string;
+			items: components["schemas"]["CmsNavigationItemInput"][];
+			change_summary?: string;
+		};
+		CmsNavigationItemInput: {
+			id?: number;
+			parent_id?: number | null;
+			label: string;
+			/** @enum {string} */
+			item_type: "internal" | "external" | "category" | "product" | "page" | "dropdown";
+			target_ref: string;
+			url: string;
+			sort_order: number;
+			is_enabled: boolean;
+		};
+		CmsNavigationResponse: {
+			menu: components["schemas"]["CmsNavigationMenu"];
+			entry: components["schemas"]["CmsEntry"];
+			items: components["schemas"]["CmsNavigationItem"][];
+			current_version?: components["schemas"]["CmsEntryVersion"];
+			published_version?: components["schemas"]["CmsEntryVersion"];
+			latest_publication?: components["schemas"]["CmsPublication"];
+			has_unpublished_draft: boolean;
+		};
+		CmsNavigationListResponse: {
+			data: components["schemas"]["CmsNavigationResponse"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		CmsGlobalRegion: {
+			id: number;
+			entry_id: number;
+			key: string;
+			title: string;
+			region: string;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsGlobalRegionDraftRequest: {
+			key: string;
+			title: string;
+			region: string;
+			payload: components["schemas"]["CmsPagePayload"];
+			change_summary?: string;
+		};
+		CmsGlobalRegionResponse: {
+			region: components["schemas"]["CmsGlobalRegion"];
+			entry: components["schemas"]["CmsEntry"];
+			current_version?: components["schemas"]["CmsEntryVersion"];
+			published_version?: components["schemas"]["CmsEntryVersion"];
+			latest_publication?: components["schemas"]["CmsPublication"];
+			has_unpublished_draft: boolean;
+		};
+		CmsGlobalRegionListResponse: {
+			data: components["schemas"]["CmsGlobalRegionResponse"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		CmsPage: {
+			id: number;
+			entry_id: number;
+			path: string;
+			slug: string;
+			title: string;
+			template_key: string;
+			/** @enum {string} */
+			visibility: "public" | "hidden";
+			seo_metadata_id?: number | null;
+			is_homepage: boolean;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsPageResponse: {
+			page: components["schemas"]["CmsPage"];
+			entry: components["schemas"]["CmsEntry"];
+			current_version?: components["schemas"]["CmsEntryVersion"];
+			published_version?: components["schemas"]["CmsEntryVersion"];
+			latest_publication?: components["schemas"]["CmsPublication"];
+			has_unpublished_draft: boolean;
+			delivery?: components["schemas"]["CmsDeliveryDecision"];
+			seo?: components["schemas"]["CmsSEOMetadata"];
+			localization?: components["schemas"]["CmsResolvedLocalization"];
+		};
+		CmsPageListResponse: {
+			data: components["schemas"]["CmsPageResponse"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		CmsPageDraftRequest: {
+			path: string;
+			slug?: string;
+			title: string;
+			template_key?: string;
+			/** @enum {string} */
+			visibility?: "public" | "hidden";
+			is_homepage?: boolean;
+			payload: components["schemas"]["CmsPagePayload"];
+			change_summary?: string;
+		};
+		CmsPagePayload: {
+			blocks?: components["schemas"]["CmsContentBlock"][];
+		} & {
+			[key: string]: unknown;
+		};
+		CmsContentBlock:
+			| components["schemas"]["CmsHeroBlock"]
+			| components["schemas"]["CmsRichTextBlock"]
+			| components["schemas"]["CmsImageBlock"]
+			| components["schemas"]["CmsGalleryBlock"]
+			| components["schemas"]["CmsVideoBlock"]
+			| components["schemas"]["CmsFAQBlock"]
+			| components["schemas"]["CmsCTABlock"]
+			| components["schemas"]["CmsPromoBannerBlock"]
+			| components["schemas"]["CmsProductRailBlock"]
+			| components["schemas"]["CmsCategoryTilesBlock"]
+			| components["schemas"]["CmsPromotionHighlightBlock"]
+			| components["schemas"]["CmsInventoryMessageBlock"]
+			| components["schemas"]["CmsTestimonialBlock"]
+			| components["schemas"]["CmsSocialEmbedBlock"]
+			| components["schemas"]["CmsCustomHTMLBlock"]
+			| components["schemas"]["CmsFooterBlock"];
+		CmsLink: {
+			label: string;
+			url: string;
+		};
+		CmsHeroBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsHeroBlock";
+			title: string;
+			subtitle?: string;
+			image_media_id?: string;
+			primary_cta?: components["schemas"]["CmsLink"];
+		};
+		CmsRichTextBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsRichTextBlock";
+			body: string;
+		};
+		CmsImageBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsImageBlock";
+			media_id: string;
+			alt?: string;
+			caption?: string;
+		};
+		CmsGalleryBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsGalleryBlock";
+			images: components["schemas"]["CmsGalleryImage"][];
+		};
+		CmsGalleryImage: {
+			media_id: string;
+			alt?: string;
+			caption?: string;
+		};
+		CmsVideoBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsVideoBlock";
+			url: string;
+			title?: string;
+		};
+		CmsFAQBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsFAQBlock";
+			items: {
+				question: string;
+				answer: string;
+			}[];
+		};
+		CmsCTABlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsCTABlock";
+			label: string;
+			url: string;
+			body?: string;
+		};
+		CmsPromoBannerBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsPromoBannerBlock";
+			title: string;
+			body?: string;
+			link?: components["schemas"]["CmsLink"];
+		};
+		CmsProductRailBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsProductRailBlock";
+			title: string;
+			subtitle?: string;
+			/** @enum {string} */
+			source: "manual" | "newest" | "search" | "category";
+			product_ids?: number[];
+			query?: string;
+			category_slug?: string;
+			/** @enum {string} */
+			sort?: "created_at" | "price" | "name";
+			/** @enum {string} */
+			order?: "asc" | "desc";
+			limit: number;
+			/** @enum {string} */
+			image_aspect?: "square" | "wide";
+		};
+		CmsCategoryTilesBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsCategoryTilesBlock";
+			title: string;
+			subtitle?: string;
+			category_slugs: string[];
+			category_media_ids?: {
+				[key: string]: string;
+			};
+			/** @enum {string} */
+			image_aspect?: "square" | "wide";
+		};
+		CmsPromotionHighlightBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsPromotionHighlightBlock";
+			title: string;
+			body?: string;
+			badge?: string;
+			promotion_code?: string;
+			campaign_id?: number;
+			link?: components["schemas"]["CmsLink"];
+		};
+		CmsInventoryMessageBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsInventoryMessageBlock";
+			product_id: number;
+			low_stock_threshold?: number;
+			in_stock_message?: string;
+			low_stock_message?: string;
+			out_of_stock_message?: string;
+		};
+		CmsTestimonialBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsTestimonialBlock";
+			quote: string;
+			attribution: string;
+			rating?: number;
+		};
+		CmsSocialEmbedBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsSocialEmbedBlock";
+			/** @enum {string} */
+			provider: "instagram" | "tiktok" | "youtube";
+			url: string;
+			title?: string;
+		};
+		CmsCustomHTMLBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsCustomHTMLBlock";
+			html: string;
+		};
+		CmsFooterColumn: {
+			title: string;
+			links: components["schemas"]["CmsLink"][];
+		};
+		CmsFooterBlock: {
+			/**
+			 * @description discriminator enum property added by openapi-typescript
+			 * @enum {string}
+			 */
+			type: "CmsFooterBlock";
+			brand_name: string;
+			tagline?: string;
+			columns: components["schemas"]["CmsFooterColumn"][];
+			social_links: components["schemas"]["CmsLink"][];
+			copyright: string;
+			/** @enum {string} */
+			layout: "columns" | "centered" | "minimal";
+		};
+		CmsLocale: {
+			code: string;
+			name: string;
+			enabled: boolean;
+			is_default: boolean;
+			fallback_locale?: string | null;
+		};
+		CmsLocaleInput: {
+			code: string;
+			name: string;
+			enabled: boolean;
+			is_default: boolean;
+			fallback_locale?: string | null;
+		};
+		CmsLocaleSettings: {
+			locales: components["schemas"]["CmsLocale"][];
+		};
+		CmsLocaleSettingsInput: {
+			locales: components["schemas"]["CmsLocaleInput"][];
+		};
+		CmsPageVariant: {
+			id: number;
+			page_id: number;
+			entry_id: number;
+			locale: string;
+			market: string;
+			path: string;
+			slug: string;
+			title: string;
+			payload: components["schemas"]["CmsPagePayload"];
+			/** @enum {string} */
+			status: "draft" | "in_review" | "changes_requested" | "approved" | "published";
+			revision: number;
+			submitted_by?: string | null;
+			approved_by?: string | null;
+			/** Format: date-time */
+			published_at?: string | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsPageVariantInput: {
+			locale: string;
+			market?: string;
+			path: string;
+			slug?: string;
+			title: string;
+			payload: components["schemas"]["CmsPagePayload"];
+			change_summary?: string;
+		};
+		CmsWorkflowActionInput: {
+			comment?: string;
+		};
+		CmsRoleAssignment: {
+			subject: string;
+			/** @enum {string} */
+			role: "author" | "editor" | "publisher";
+		};
+		CmsGovernance: {
+			approval_required: boolean;
+			invalidation_webhook_url: string;
+			roles: components["schemas"]["CmsRoleAssignment"][];
+		};
+		CmsGovernanceInput: {
+			approval_required: boolean;
+			invalidation_webhook_url: string;
+			roles: components["schemas"]["CmsRoleAssignment"][];
+		};
+		CmsChangeComment: {
+			id: number;
+			entry_id: number;
+			variant_id?: number | null;
+			actor: string;
+			body: string;
+			resolved_by?: string | null;
+			/** Format: date-time */
+			resolved_at?: string | null;
+			/** Format: date-time */
+			created_at: string;
+		};
+		CmsCommentInput: {
+			body: string;
+		};
+		CmsEntryWorkflow: {
+			entry_id: number;
+			version_id: number;
+			/** @enum {string} */
+			status: "draft" | "in_review" | "changes_requested" | "approved";
+			submitted_by?: string | null;
+			approved_by?: string | null;
+			comments: components["schemas"]["CmsChangeComment"][];
+		};
+		CmsEntryVariant: {
+			id: number;
+			entry_id: number;
+			locale: string;
+			market: string;
+			payload: {
+				[key: string]: unknown;
+			};
+			/** @enum {string} */
+			status: "draft" | "in_review" | "changes_requested" | "approved" | "published";
+			revision: number;
+			submitted_by?: string | null;
+			approved_by?: string | null;
+			/** Format: date-time */
+			published_at?: string | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsEntryVariantInput: {
+			locale: string;
+			market?: string;
+			payload: {
+				[key: string]: unknown;
+			};
+			change_summary?: string;
+		};
+		CmsInvalidationEvent: {
+			id: number;
+			entry_id: number;
+			variant_id?: number | null;
+			reason: string;
+			/** @enum {string} */
+			status: "pending" | "sent" | "failed";
+			attempts: number;
+			last_error: string;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			sent_at?: string | null;
+		};
+		CmsOperations: {
+			pending_schedules: number;
+			active_experiments: number;
+			invalidations: components["schemas"]["CmsInvalidationEvent"][];
+		};
+		CmsRestorePreview: {
+			valid: boolean;
+			schema_version: number;
+			pages: number;
+			navigation: number;
+			global_regions: number;
+			variants: number;
+			warnings: string[];
+			errors: string[];
+		};
+		CmsAuditEvent: {
+			id: number;
+			entry_id: number;
+			version_id?: number | null;
+			variant_id?: number | null;
+			action: string;
+			actor: string;
+			detail: string;
+			/** Format: date-time */
+			created_at: string;
+		};
+		CmsResolvedLocalization: {
+			requested_locale: string;
+			resolved_locale: string;
+			market: string;
+			used_fallback: boolean;
+			alternates: {
+				locale: string;
+				market?: string;
+				path: string;
+			}[];
+		};
+		CmsContentExport: {
+			schema_version: number;
+			/** Format: date-time */
+			exported_at: string;
+			locales: components["schemas"]["CmsLocale"][];
+			pages: components["schemas"]["CmsPageResponse"][];
+			navigation: components["schemas"]["CmsNavigationResponse"][];
+			global_regions: components["schemas"]["CmsGlobalRegionResponse"][];
+			variants: components["schemas"]["CmsPageVariant"][];
+		};
+		CmsPublishRequest: {
+			notes?: string;
+		};
+		CmsPreviewRequest: {
+			payload: components["schemas"]["CmsPagePayload"];
+		};
+		CmsPreviewBlock: {
+			key: string;
+			type: string;
+			/** @enum {string} */
+			status: "ok" | "degraded" | "static";
+			item_count: number;
+			messages: string[];
+		};
+		CmsPreviewResponse: {
+			blocks: components["schemas"]["CmsPreviewBlock"][];
+		};
+		CmsRollbackRequest: {
+			version_id: number;
+			notes?: string;
+		};
+		CmsSchedule: {
+			id: number;
+			entry_id: number;
+			version_id: number;
+			/** Format: date-time */
+			publish_at: string;
+			/** Format: date-time */
+			unpublish_at?: string | null;
+			timezone: string;
+			/** @enum {string} */
+			status: "pending" | "active" | "completed" | "cancelled";
+			/** Format: date-time */
+			last_transition_at?: string | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsScheduleInput: {
+			/** Format: date-time */
+			publish_at: string;
+			/** Format: date-time */
+			unpublish_at?: string | null;
+			timezone: string;
+		};
+		CmsTargetingRule: {
+			id: number;
+			priority: number;
+			is_enabled: boolean;
+			markets: string[];
+			device_classes: ("desktop" | "mobile" | "tablet")[];
+			auth_states: ("guest" | "authenticated")[];
+			referrers: string[];
+			utm_sources: string[];
+			segment_keys: string[];
+		};
+		CmsTargetingRuleInput: {
+			priority: number;
+			is_enabled: boolean;
+			markets: string[];
+			device_classes: ("desktop" | "mobile" | "tablet")[];
+			auth_states: ("guest" | "authenticated")[];
+			referrers: string[];
+			utm_sources: string[];
+			segment_keys: string[];
+		};
+		CmsExperimentVariant: {
+			id: number;
+			experiment_id: number;
+			name: string;
+			version_id: number;
+			allocation: number;
+		};
+		CmsExperimentVariantInput: {
+			name: string;
+			version_id: number;
+			allocation: number;
+		};
+		CmsExperiment: {
+			id: number;
+			entry_id: number;
+			name: string;
+			/** @enum {string} */
+			status: "draft" | "active" | "paused" | "completed";
+			/** @enum {string} */
+			sticky_key: "visitor" | "customer";
+			/** Format: date-time */
+			starts_at: string;
+			/** Format: date-time */
+			ends_at?: string | null;
+			variants: components["schemas"]["CmsExperimentVariant"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsExperimentInput: {
+			name: string;
+			/** @enum {string} */
+			status: "draft" | "active" | "paused" | "completed";
+			/** @enum {string} */
+			sticky_key: "visitor" | "customer";
+			/** Format: date-time */
+			starts_at: string;
+			/** Format: date-time */
+			ends_at?: string | null;
+			variants: components["schemas"]["CmsExperimentVariantInput"][];
+		};
+		CmsPageDeliveryRequest: {
+			schedule?: components["schemas"]["CmsScheduleInput"];
+			targeting_rules: components["schemas"]["CmsTargetingRuleInput"][];
+			experiment?: components["schemas"]["CmsExperimentInput"];
+		};
+		CmsPageDeliveryResponse: {
+			schedule?: components["schemas"]["CmsSchedule"];
+			targeting_rules: components["schemas"]["CmsTargetingRule"][];
+			experiment?: components["schemas"]["CmsExperiment"];
+			recent_publications: components["schemas"]["CmsPublication"][];
+		};
+		CmsDeliveryDecision: {
+			content_version_id: number;
+			experiment_id?: number | null;
+			experiment_variant_id?: number | null;
+			correlation_id: string;
+		};
+		CmsContentEventRequest: {
+			content_version_id: number;
+			experiment_id?: number | null;
+			experiment_variant_id?: number | null;
+			correlation_id: string;
+			/** @enum {string} */
+			event_type: "impression" | "conversion";
+		};
+		CmsSEOMetadata: {
+			title: string;
+			description: string;
+			canonical_url: string;
+			/** @enum {string} */
+			robots: "index_follow" | "noindex_follow" | "index_nofollow" | "noindex_nofollow";
+			og_title: string;
+			og_description: string;
+			og_image_media_id?: string | null;
+			/** @enum {string} */
+			twitter_card: "summary" | "summary_large_image";
+			twitter_title: string;
+			twitter_description: string;
+			twitter_image_media_id?: string | null;
+			json_ld: {
+				[key: string]: unknown;
+			}[];
+		};
+		CmsSEOInput: {
+			title: string;
+			description: string;
+			canonical_url: string;
+			/** @enum {string} */
+			robots: "index_follow" | "noindex_follow" | "index_nofollow" | "noindex_nofollow";
+			og_title: string;
+			og_description: string;
+			og_image_media_id?: string | null;
+			/** @enum {string} */
+			twitter_card: "summary" | "summary_large_image";
+			twitter_title: string;
+			twitter_description: string;
+			twitter_image_media_id?: string | null;
+			json_ld: {
+				[key: string]: unknown;
+			}[];
+		};
+		CmsSEOResponse: {
+			metadata: components["schemas"]["CmsSEOMetadata"];
+			issues: string[];
+		};
+		CmsRedirectRule: {
+			id: number;
+			source_pattern: string;
+			/** @enum {string} */
+			match_type: "exact" | "prefix";
+			target_url: string;
+			/** @enum {integer} */
+			redirect_type: 301 | 302;
+			priority: number;
+			is_enabled: boolean;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CmsRedirectInput: {
+			source_pattern: string;
+			/** @enum {string} */
+			match_type: "exact" | "prefix";
+			target_url: string;
+			/** @enum {integer} */
+			redirect_type: 301 | 302;
+			priority: number;
+			is_enabled: boolean;
+		};
+		CmsRedirectResolution: {
+			target_url: string;
+			/** @enum {integer} */
+			redirect_type: 301 | 302;
+		};
+		DraftPreviewSessionResponse: {
+			active: boolean;
+			/** Format: date-time */
+			expires_at?: string | null;
+		};
+		Pagination: {
+			page: number;
+			limit: number;
+			total: number;
+			total_pages: number;
+		};
+		User: {
+			id: number;
+			subject: string;
+			username: string;
+			email: string;
+			name: string | null;
+			profile_photo_url: string | null;
+			/** @enum {string} */
+			role: "admin" | "customer";
+			currency: string;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			/** Format: date-time */
+			deleted_at?: string | null;
+		};
+		RegisterRequest: {
+			username: string;
+			email: string;
+			password: string;
+			name?: string;
+		};
+		LoginRequest: {
+			email: string;
+			password: string;
+		};
+		AuthResponse: {
+			token?: string;
+			user: components["schemas"]["User"];
+		};
+		AuthConfigResponse: {
+			local_sign_in_enabled: boolean;
+			oidc_enabled: boolean;
+		};
+		UpdateProfileRequest: {
+			name?: string;
+			currency?: string;
+			profile_photo_url?: string;
+		};
+		Brand: {
+			id: number;
+			name: string;
+			slug: string;
+			description?: string | null;
+			logo_url?: string | null;
+			is_active: boolean;
+		};
+		BrandListResponse: {
+			data: components["schemas"]["Brand"][];
+		};
+		BrandInput: {
+			name: string;
+			slug?: string | null;
+			description?: string | null;
+			logo?: components["schemas"]["BrandLogoInput"];
+			is_active?: boolean;
+		};
+		BrandLogoInput: {
+			media_id: string;
+		};
+		Category: {
+			id: number;
+			name: string;
+			slug: string;
+			description?: string | null;
+			is_active: boolean;
+			sort_order: number;
+			parent_id?: number | null;
+			path: string;
+			depth: number;
+		};
+		CategoryListResponse: {
+			data: components["schemas"]["Category"][];
+		};
+		CategoryInput: {
+			name: string;
+			slug?: string | null;
+			description?: string | null;
+			is_active?: boolean;
+			sort_order?: number;
+			parent_id?: number | null;
+		};
+		ProductAttributeDefinition: {
+			id: number;
+			key: string;
+			slug: string;
+			/** @enum {string} */
+			type: "text" | "number" | "boolean" | "enum";
+			filterable: boolean;
+			sortable: boolean;
+			enum_values: string[];
+		};
+		ProductAttributeDefinitionInput: {
+			key: string;
+			slug?: string | null;
+			/** @enum {string} */
+			type: "text" | "number" | "boolean" | "enum";
+			filterable?: boolean;
+			sortable?: boolean;
+			enum_values?: string[];
+		};
+		ProductAttributeDefinitionListResponse: {
+			data: components["schemas"]["ProductAttributeDefinition"][];
+		};
+		ProductOptionValue: {
+			id?: number;
+			value: string;
+			position: number;
+		};
+		ProductOption: {
+			id?: number;
+			name: string;
+			position: number;
+			display_type: string;
+			values: components["schemas"]["ProductOptionValue"][];
+		};
+		ProductVariantSelection: {
+			product_option_value_id?: number;
+			option_name: string;
+			option_value: string;
+			position: number;
+		};
+		ProductVariant: {
+			id?: number;
+			sku: string;
+			title: string;
+			/** Format: double */
+			price: number;
+			/** Format: double */
+			compare_at_price?: number | null;
+			stock: number;
+			position: number;
+			is_published: boolean;
+			weight_grams?: number | null;
+			/** Format: double */
+			length_cm?: number | null;
+			/** Format: double */
+			width_cm?: number | null;
+			/** Format: double */
+			height_cm?: number | null;
+			/** @description Smallest quantity accepted on a cart line or order. */
+			min_quantity: number;
+			/** @description Largest quantity accepted on a cart line or order; null for no maximum. */
+			max_quantity?: number | null;
+			/** @description Quantities must be a multiple of this value, e.g. 6 for a variant sold in packs of 6. */
+			quantity_step: number;
+			/** @description Most units one customer may buy across all of their pending and paid orders, including guest orders placed under their email; null for no limit. */
+			purchase_limit?: number | null;
+			/** @description What happens when stock runs out. One of DENY (stop selling), BACKORDER (keep selling up to backorder_limit) or PREORDER (before preorder_release_at every unit is backordered up to backorder_limit and held back from picking, shipping and capture; after it, sell from stock like DENY). */
+			inventory_policy: string;
+			/** @description Most units that may be sold past stock at once under BACKORDER, or pre-ordered before release under PREORDER; null for no cap. */
+			backorder_limit?: number | null;
+			/**
+			 * Format: date-time
+			 * @description Release date of a PREORDER variant. Pre-ordered units are not picked, shipped or captured before it.
+			 */
+			preorder_release_at?: string | null;
+			/**
+			 * Format: date-time
+			 * @description Date units sold past stock are promised to ship. Pre-orders fall back to their release date.
+			 */
+			promised_ship_at?: string | null;
+			/** @description Whether the variant sells gift cards. Each unit of a paid order becomes a card worth the unit price. */
+			is_gift_card: boolean;
+			selections: components["schemas"]["ProductVariantSelection"][];
+		};
+		ProductAttributeValue: {
+			product_attribute_id: number;
+			key: string;
+			slug: string;
+			type: string;
+			text_value?: string | null;
+			/** Format: double */
+			number_value?: number | null;
+			boolean_value?: boolean | null;
+			enum_value?: string | null;
+			position: number;
+		};
+		ProductSEO: {
+			title?: string | null;
+			description?: string | null;
+			canonical_path?: string | null;
+			og_image_media_id?: string | null;
+			noindex?: boolean;
+		};
+		ProductPriceRange: {
+			/** Format: double */
+			min: number;
+			/** Format: double */
+			max: number;
+		};
+		AppliedCampaign: {
+			id: number;
+			level_id?: number | null;
+			name: string;
+			/** Format: double */
+			discount_amount: number;
+		};
+		PriceBreakdown: {
+			/** Format: double */
+			base_price: number;
+			/** Format: double */
+			discount_amount: number;
+			/** Format: double */
+			final_price: number;
+			applied_campaigns: components["schemas"]["AppliedCampaign"][];
+		};
+		DiscountTarget: {
+			id: number;
+			/** @enum {string} */
+			target_type: "product" | "variant" | "category" | "brand";
+			target_id: number;
+		};
+		PromotionCondition: {
+			product_ids?: number[];
+			product_variant_ids?: number[];
+			category_ids?: number[];
+			brand_ids?: number[];
+			min_quantity?: number;
+			/** Format: double */
+			min_subtotal?: number;
+		};
+		PromotionAction: {
+			/** @enum {string} */
+			mode: "percent" | "fixed" | "fixed_price" | "free_item";
+			/** Format: double */
+			value?: number;
+			/** @enum {string} */
+			target_type?: "cart" | "product" | "variant" | "category" | "brand";
+			target_ids?: number[];
+			product_ids?: number[];
+			product_variant_ids?: number[];
+			category_ids?: number[];
+			brand_ids?: number[];
+			sku?: string;
+		};
+		PromotionRuleInput: {
+			condition: components["schemas"]["PromotionCondition"];
+			action: components["schemas"]["PromotionAction"];
+			/** @enum {string} */
+			stack_policy?: "none" | "additive";
+			max_applications_per_order?: number | null;
+		};
+		PromotionTargetInput: {
+			/** @enum {string} */
+			target_type: "product" | "variant" | "category" | "brand";
+			target_id: number;
+		};
+		PromotionLevelInput: {
+			name: string;
+			/** @default 0 */
+			priority: number;
+			action: components["schemas"]["PromotionAction"];
+			/** @enum {string} */
+			stack_policy?: "none" | "additive";
+			max_applications_per_order?: number | null;
+			targets: components["schemas"]["PromotionTargetInput"][];
+		};
+		PromotionInput: {
+			name: string;
+			/** Format: date-time */
+			starts_at: string;
+			/** Format: date-time */
+			ends_at?: string | null;
+			/** @default 0 */
+			priority: number;
+			/** @default false */
+			is_exclusive: boolean;
+			/** @enum {string} */
+			status?: "active" | "scheduled" | "disabled";
+			metadata?: {
+				[key: string]: unknown;
+			};
+			coupon_code?: string | null;
+			channels?: ("web" | "app" | "admin")[];
+			customer_segment?: string;
+			global_usage_cap?: number | null;
+			per_customer_usage_cap?: number | null;
+			/** Format: double */
+			budget_amount?: number | null;
+			budget_alert_thresholds?: number[];
+			rules?: components["schemas"]["PromotionRuleInput"][];
+			levels?: components["schemas"]["PromotionLevelInput"][];
+		};
+		PromotionEvaluationLine: {
+			product_id: number;
+			product_variant_id: number;
+			quantity: number;
+			/** Format: double */
+			base_price: number;
+			/** Format: double */
+			discount_amount: number;
+			/** Format: double */
+			final_price: number;
+			applied_campaigns: components["schemas"]["AppliedCampaign"][];
+		};
+		PromotionEvaluationRequestLine: {
+			product_id: number;
+			product_variant_id: number;
+			brand_id?: number | null;
+			category_ids?: number[];
+			sku?: string;
+			quantity: number;
+			/** Format: double */
+			unit_price: number;
+		};
+		PromotionEvaluationRequest: {
+			coupon_code?: string;
+			/** @enum {string} */
+			channel?: "web" | "app" | "admin";
+			customer_segment?: string;
+			/** @description Resolve segment-targeted campaigns from this customer's materialized segment membership when customer_segment is omitted. */
+			customer_id?: number;
+			lines: components["schemas"]["PromotionEvaluationRequestLine"][];
+		};
+		PromotionSimulationRequest: {
+			campaign: components["schemas"]["PromotionInput"];
+			/** Format: date-time */
+			from: string;
+			/** Format: date-time */
+			to: string;
+			/** @description Channel to evaluate as; one of web, app or admin. Defaults to web. */
+			channel?: string;
+		};
+		PromotionSimulationResponse: {
+			/** Format: date-time */
+			from: string;
+			/** Format: date-time */
+			to: string;
+			orders_evaluated: number;
+			affected_orders: number;
+			/** Format: double */
+			discount_total: number;
+			/** Format: double */
+			actual_revenue: number;
+			/** Format: double */
+			simulated_revenue: number;
+			/** Format: double */
+			actual_aov: number;
+			/** Format: double */
+			simulated_aov: number;
+			/** Format: double */
+			aov_change: number;
+			/** Format: double */
+			aov_change_percent: number;
+		};
+		PromotionTemplateInput: {
+			name: string;
+			description?: string;
+			template: components["schemas"]["PromotionInput"];
+			is_active?: boolean | null;
+		};
+		PromotionTemplateInstantiateInput: {
+			name?: string | null;
+			/** Format: date-time */
+			starts_at?: string | null;
+			/** Format: date-time */
+			ends_at?: string | null;
+			coupon_code?: string | null;
+			channels?: ("web" | "app" | "admin")[];
+			customer_segment?: string | null;
+			global_usage_cap?: number | null;
+			per_customer_usage_cap?: number | null;
+		};
+		PromotionTemplate: {
+			id: number;
+			name: string;
+			description: string;
+			template_json: string;
+			template: {
+				[key: string]: unknown;
+			};
+			is_active: boolean;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		PromotionTemplateListResponse: {
+			templates: components["schemas"]["PromotionTemplate"][];
+		};
+		PromotionEvaluationResponse: {
+			/** Format: double */
+			subtotal: number;
+			/** Format: double */
+			discount_total: number;
+			/** Format: double */
+			final_subtotal: number;
+			lines: components["schemas"]["PromotionEvaluationLine"][];
+		};
+		ProductDiscountInput: {
+			name: string;
+			product_ids: number[];
+			/** @enum {string} */
+			discount_mode: "percent" | "fixed";
+			/** Format: double */
+			discount_value: number;
+			/** Format: date-time */
+			starts_at: string;
+			/** Format: date-time */
+			ends_at?: string | null;
+			/** @default 0 */
+			priority: number;
+			/** @default false */
+			is_exclusive: boolean;
+			/** @enum {string} */
+			status?: "active" | "disabled";
+			metadata?: {
+				[key: string]: unknown;
+			};
+			coupon_code?: string | null;
+			channels?: ("web" | "app" | "admin")[];
+			customer_segment?: string;
+			global_usage_cap?: number | null;
+			per_customer_usage_cap?: number | null;
+			/** Format: double */
+			budget_amount?: number | null;
+			budget_alert_thresholds?: number[];
+		};
+		DiscountCampaign: {
+			id: number;
+			name: string;
+			/** @enum {string} */
+			type: "product_discount" | "promotion";
+			/** @enum {string} */
+			status: "active" | "scheduled" | "disabled" | "archived";
+			/** Format: date-time */
+			starts_at: string;
+			/** Format: date-time */
+			ends_at?: string | null;
+			/** @enum {string} */
+			discount_mode: "percent" | "fixed";
+			/** Format: double */
+			discount_value: number;
+			priority: number;
+			is_exclusive: boolean;
+			metadata?: {
+				[key: string]: unknown;
+			};
+			coupon_code?: string | null;
+			channels?: ("web" | "app" | "admin")[];
+			customer_segment?: string | null;
+			global_usage_cap?: number | null;
+			per_customer_usage_cap?: number | null;
+			/** Format: double */
+			budget_amount?: number | null;
+			budget_alert_thresholds?: number[];
+			targets: components["schemas"]["DiscountTarget"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		DiscountCampaignListResponse: {
+			campaigns: components["schemas"]["DiscountCampaign"][];
+		};
+		DiscountScheduleInput: {
+			/** @enum {string} */
+			schedule_type: "one_time" | "recurring";
+			/** @description daily, weekly, monthly, or newline-separated RFC 5545 RRULE and EXDATE lines evaluated in timezone */
+			recurrence?: string;
+			/** Format: date-time */
+			window_start: string;
+			/** Format: date-time */
+			window_end: string;
+			/** Format: date-time */
+			until_at?: string | null;
+			timezone?: string;
+		};
+		DiscountSchedule: {
+			id: number;
+			campaign_id: number;
+			/** @enum {string} */
+			schedule_type: "one_time" | "recurring";
+			recurrence?: string | null;
+			/** Format: date-time */
+			window_start: string;
+			/** Format: date-time */
+			window_end: string;
+			/** Format: date-time */
+			until_at?: string | null;
+			timezone: string;
+			/** Format: date-time */
+			last_run_at?: string | null;
+			/** Format: date-time */
+			next_run_at?: string | null;
+		};
+		DiscountSchedulePreviewRequest: {
+			schedule: components["schemas"]["DiscountScheduleInput"];
+			/** Format: date-time */
+			from?: string;
+			/** @default 10 */
+			count: number;
+		};
+		DiscountScheduleOccurrence: {
+			/** Format: date-time */
+			start: string;
+			/** Format: date-time */
+			end: string;
+		};
+		DiscountSchedulePreviewResponse: {
+			windows: components["schemas"]["DiscountScheduleOccurrence"][];
+		};
+		DiscountLifecycleRunResponse: {
+			activated: number;
+			deactivated: number;
+			archived: number;
+		};
+		DiscountStateHistory: {
+			id: number;
+			campaign_id: number;
+			from_status: string;
+			to_status: string;
+			reason: string;
+			source: string;
+			actor: string;
+			/** Format: date-time */
+			changed_at: string;
+		};
+		DiscountStateHistoryListResponse: {
+			history: components["schemas"]["DiscountStateHistory"][];
+		};
+		DiscountBudgetAlert: {
+			id: number;
+			campaign_id: number;
+			threshold_percent: number;
+			/** Format: double */
+			budget_amount: number;
+			/** Format: double */
+			spent_amount: number;
+			/** Format: date-time */
+			triggered_at: string;
+		};
+		DiscountBudgetStatus: {
+			campaign_id: number;
+			status: string;
+			/** Format: double */
+			budget_amount?: number | null;
+			/** Format: double */
+			spent_amount: number;
+			/** Format: double */
+			remaining_amount?: number | null;
+			alert_thresholds: number[];
+			alerts: components["schemas"]["DiscountBudgetAlert"][];
+		};
+		DiscountCampaignAudit: {
+			id: number;
+			campaign_id: number;
+			event_type: string;
+			source: string;
+			actor: string;
+			summary: string;
+			before_json: string;
+			after_json: string;
+			/** Format: date-time */
+			changed_at: string;
+		};
+		DiscountCampaignAuditListResponse: {
+			audit: components["schemas"]["DiscountCampaignAudit"][];
+		};
+		DiscountEvaluationMetrics: {
+			/** Format: int64 */
+			total_evaluations: number;
+			/** Format: int64 */
+			failed_evaluations: number;
+			/** Format: int64 */
+			matched_evaluations: number;
+			/** Format: int64 */
+			total_latency_ms: number;
+			/** Format: int64 */
+			last_latency_ms: number;
+			/** Format: int64 */
+			last_line_count: number;
+			/** Format: int64 */
+			last_candidate_campaigns: number;
+			/** Format: int64 */
+			last_matched_campaigns: number;
+			/** Format: date-time */
+			last_evaluated_at?: string | null;
+			last_error: string;
+		};
+		DiscountReconciliationIssue: {
+			campaign_id: number;
+			schedule_id: number;
+			expected_status: string;
+			actual_status: string;
+			/** Format: date-time */
+			expected_start?: string;
+			/** Format: date-time */
+			actual_start?: string;
+			/** Format: date-time */
+			expected_end?: string | null;
+			/** Format: date-time */
+			actual_end?: string | null;
+			message: string;
+		};
+		DiscountReconciliationReport: {
+			/** Format: date-time */
+			checked_at: string;
+			issues: components["schemas"]["DiscountReconciliationIssue"][];
+		};
+		/** @description Every populated criterion must match. Order criteria count paid, shipped and delivered orders only. */
+		CustomerSegmentRules: {
+			min_order_count?: number | null;
+			max_order_count?: number | null;
+			/** Format: double */
+			min_lifetime_spend?: number | null;
+			/** Format: double */
+			max_lifetime_spend?: number | null;
+			/** Format: date-time */
+			first_order_after?: string | null;
+			/** Format: date-time */
+			first_order_before?: string | null;
+			first_order_within_days?: number | null;
+			/** @description ISO 3166-1 alpha-2 codes matched against any saved address. */
+			countries?: string[];
+			roles?: string[];
+			/** Format: date-time */
+			signed_up_after?: string | null;
+			/** Format: date-time */
+			signed_up_before?: string | null;
+			signed_up_within_days?: number | null;
+		};
+		CustomerSegmentInput: {
+			key: string;
+			name: string;
+			description?: string;
+			rules: components["schemas"]["CustomerSegmentRules"];
+			is_active?: boolean | null;
+		};
+		CustomerSegment: {
+			id: number;
+			key: string;
+			name: string;
+			description: string;
+			rules: components["schemas"]["CustomerSegmentRules"];
+			is_active: boolean;
+			member_count: number;
+			/** Format: date-time */
+			last_refreshed_at?: string | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		CustomerSegmentListResponse: {
+			segments: components["schemas"]["CustomerSegment"][];
+		};
+		CustomerSegmentRefreshResult: {
+			segment_id: number;
+			key: string;
+			added: number;
+			removed: number;
+			members: number;
+		};
+		CustomerSegmentMember: {
+			user_id: number;
+			/** Format: date-time */
+			matched_at: string;
+		};
+		CustomerSegmentMemberListResponse: {
+			data: components["schemas"]["CustomerSegmentMember"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		GiftCard: {
+			id: number;
+			code_last4: string;
+			currency: string;
+			/** Format: double */
+			initial_amount: number;
+			/** Format: double */
+			balance: number;
+			status: string;
+			source: string;
+			user_id?: number | null;
+			order_id?: number | null;
+			order_item_id?: number | null;
+			/** Format: date-time */
+			expires_at?: string | null;
+			note: string;
+			issued_by: string;
+			ledger?: components["schemas"]["GiftCardLedgerEntry"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		GiftCardLedgerEntry: {
+			id: number;
+			operation: string;
+			/** Format: double */
+			amount: number;
+			/** Format: double */
+			balance_after: number;
+			payment_intent_id?: number | null;
+			order_id?: number | null;
+			actor: string;
+			reason: string;
+			/** Format: date-time */
+			created_at: string;
+		};
+		GiftCardListResponse: {
+			data: components["schemas"]["GiftCard"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		GiftCardIssueInput: {
+			/** Format: double */
+			amount: number;
+			currency?: string;
+			/** @description ADMIN (default), PURCHASED or REFUND_CREDIT; the latter two require order_id. */
+			source?: string;
+			user_id?: number;
+			order_id?: number;
+			/** Format: date-time */
+			expires_at?: string;
+			note?: string;
+		};
+		GiftCardIssueResponse: {
+			gift_card: components["schemas"]["GiftCard"];
+			code: string;
+		};
+		GiftCardIssueListResponse: {
+			data: components["schemas"]["GiftCardIssueResponse"][];
+		};
+		GiftCardAdjustmentInput: {
+			/** Format: double */
+			amount: number;
+			reason: string;
+		};
+		GiftCardStatusInput: {
+			status: string;
+		};
+		GiftCardBalanceRequest: {
+			code: string;
+		};
+		GiftCardBalance: {
+			code_last4: string;
+			/** Format: double */
+			balance: number;
+			currency: string;
+			/** Format: date-time */
+			expires_at?: string | null;
+		};
+		GiftCardTender: {
+			code: string;
+			/** Format: double */
+			amount?: number;
+		};
+		GiftCardReconciliationDrift: {
+			gift_card_id?: number | null;
+			ledger_entry_id?: number | null;
+			payment_transaction_id?: number | null;
+			field: string;
+			expected: string;
+			actual: string;
+			message: string;
+		};
+		GiftCardReconciliationReport: {
+			/** Format: date-time */
+			checked_at: string;
+			cards_checked: number;
+			transactions_checked: number;
+			drifts: components["schemas"]["GiftCardReconciliationDrift"][];
+		};
+		Product: {
+			id: number;
+			sku: string;
+			name: string;
+			subtitle?: string | null;
+			description: string;
+			/** Format: double */
+			price: number;
+			/** Format: double */
+			base_price?: number;
+			/** Format: double */
+			discount_amount?: number;
+			/** Format: double */
+			final_price?: number;
+			applied_campaigns?: components["schemas"]["AppliedCampaign"][];
+			price_breakdown?: components["schemas"]["PriceBreakdown"];
+			stock: number;
+			images: string[];
+			cover_image?: string | null;
+			brand?: components["schemas"]["Brand"];
+			default_variant_id?: number | null;
+			default_variant_sku?: string | null;
+			price_range: components["schemas"]["ProductPriceRange"];
+			options: components["schemas"]["ProductOption"][];
+			variants: components["schemas"]["ProductVariant"][];
+			attributes: components["schemas"]["ProductAttributeValue"][];
+			seo: components["schemas"]["ProductSEO"];
+			related_products: components["schemas"]["RelatedProduct"][];
+			categories: components["schemas"]["Category"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			/** Format: date-time */
+			deleted_at?: string | null;
+			is_published?: boolean;
+			has_draft_changes?: boolean;
+			/** Format: date-time */
+			draft_updated_at?: string | null;
+		};
+		RelatedProduct: {
+			id: number;
+			sku: string;
+			name: string;
+			description: string | null;
+			/** Format: double */
+			price?: number;
+			stock: number;
+			cover_image?: string | null;
+		};
+		ProductOptionValueInput: {
+			value: string;
+			position?: number;
+		};
+		ProductOptionInput: {
+			name: string;
+			position?: number;
+			display_type?: string;
+			values: components["schemas"]["ProductOptionValueInput"][];
+		};
+		ProductVariantSelectionInput: {
+			option_name: string;
+			option_value: string;
+			position?: number;
+		};
+		ProductVariantInput: {
+			sku: string;
+			title: string;
+			/** Format: double */
+			price: number;
+			/** Format: double */
+			compare_at_price?: number | null;
+			stock: number;
+			position?: number;
+			is_published?: boolean;
+			weight_grams?: number | null;
+			/** Format: double */
+			length_cm?: number | null;
+			/** Format: double */
+			width_cm?: number | null;
+			/** Format: double */
+			height_cm?: number | null;
+			/** @description Defaults to 1. */
+			min_quantity?: number;
+			max_quantity?: number | null;
+			/** @description Defaults to 1. */
+			quantity_step?: number;
+			/** @description Per-customer cap across all pending and paid orders, including guest orders placed under the customer's email. */
+			purchase_limit?: number | null;
+			/** @description One of DENY, BACKORDER or PREORDER. Defaults to DENY. */
+			inventory_policy?: string;
+			backorder_limit?: number | null;
+			/**
+			 * Format: date-time
+			 * @description Required for PREORDER.
+			 */
+			preorder_release_at?: string | null;
+			/** Format: date-time */
+			promised_ship_at?: string | null;
+			/** @description Defaults to false. */
+			is_gift_card?: boolean;
+			selections: components["schemas"]["ProductVariantSelectionInput"][];
+		};
+		ProductAttributeValueInput: {
+			product_attribute_id: number;
+			text_value?: string | null;
+			/** Format: double */
+			number_value?: number | null;
+			boolean_value?: boolean | null;
+			enum_value?: string | null;
+			position?: number;
+		};
+		ProductSEOInput: {
+			title?: string | null;
+			description?: string | null;
+			canonical_path?: string | null;
+			og_image_media_id?: string | null;
+			noindex?: boolean;
+		};
+		ProductUpsertInput: {
+			sku: string;
+			name: string;
+			subtitle?: string | null;
+			description: string;
+			images: string[];
+			related_product_ids: number[];
+			category_ids: number[];
+			brand_id?: number | null;
+			default_variant_sku?: string | null;
+			options: components["schemas"]["ProductOptionInput"][];
+			variants: components["schemas"]["ProductVariantInput"][];
+			attributes: components["schemas"]["ProductAttributeValueInput"][];
+			seo: components["schemas"]["ProductSEOInput"];
+		};
+		ProductPage: {
+			data: components["schemas"]["Product"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		CartItem: {
+			id: number;
+			cart_id: number;
+			product_variant_id: number;
+			product_variant: components["schemas"]["ProductVariant"];
+			quantity: number;
+			/**
+			 * Format: double
+			 * @description Quoted unit price from a price-locked saved cart.
+			 */
+			locked_price?: number | null;
+			/** Format: date-time */
+			locked_until?: string | null;
+			/** @description Number of units the quoted price covers. The line is charged at the current price once its quantity exceeds it. */
+			locked_quantity?: number | null;
+			saved_cart_id?: number | null;
+			/** Format: double */
+			base_price?: number;
+			/** Format: double */
+			discount_amount?: number;
+			/** Format: double */
+			final_price?: number;
+			applied_campaigns?: components["schemas"]["AppliedCampaign"][];
+			product: components["schemas"]["Product"];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			/** Format: date-time */
+			deleted_at?: string | null;
+		};
+		Cart: {
+			id: number;
+			user_id: number;
+			items: components["schemas"]["CartItem"][];
+			merge?: components["schemas"]["CartMerge"];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			/** Format: date-time */
+			deleted_at?: string | null;
+		};
+		/** @description Present on the response of the request that folded a guest cart into the signed-in user's cart. */
+		CartMerge: {
+			/** Format: date-time */
+			merged_at: string;
+			lines: components["schemas"]["CartMergeLine"][];
+		};
+		CartMergeLine: {
+			product_variant_id: number;
+			guest_quantity: number;
+			previous_quantity: number;
+			/** @description Resulting quantity in the merged cart. */
+			quantity: number;
+			/** @description ADDED, SUMMED, CLAMPED or DROPPED. */
+			outcome: string;
+			notice?: string;
+		};
+		CartRestoreRequest: {
+			token: string;
+		};
+		CartRestoreResponse: {
+			cart: components["schemas"]["Cart"];
+			lines: components["schemas"]["CartMergeLine"][];
+		};
+		SavedCartItem: {
+			id: number;
+			product_variant_id: number;
+			product_variant: components["schemas"]["ProductVariant"];
+			product: components["schemas"]["Product"];
+			quantity: number;
+			/**
+			 * Format: double
+			 * @description Unit price captured when the cart was saved; charged on conversion when prices are locked.
+			 */
+			unit_price: number;
+			/** Format: double */
+			current_price: number;
+		};
+		SavedCart: {
+			id: number;
+			name: string;
+			note: string;
+			share_token: string;
+			owner_user_id?: number | null;
+			/** @description When set, only this customer (or the owner) can convert the saved cart. */
+			customer_user_id?: number | null;
+			created_by: string;
+			lock_prices: boolean;
+			/** Format: date-time */
+			expires_at?: string | null;
+			/** @description ACTIVE or ARCHIVED. */
+			status: string;
+			expired: boolean;
+			conversion_count: number;
+			/** Format: date-time */
+			last_converted_at?: string | null;
+			/** Format: double */
+			subtotal: number;
+			items: components["schemas"]["SavedCartItem"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		SavedCartListResponse: {
+			data: components["schemas"]["SavedCart"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		SavedCartSaveRequest: {
+			name: string;
+			note?: string;
+			/** Format: date-time */
+			expires_at?: string;
+		};
+		SavedCartItemInput: {
+			product_variant_id: number;
+			quantity: number;
+			/** Format: double */
+			unit_price?: number;
+		};
+		SavedCartCreateRequest: {
+			name: string;
+			note?: string;
+			customer_user_id?: number;
+			lock_prices?: boolean;
+			/**
+			 * Format: date-time
+			 * @description Required when lock_prices is true.
+			 */
+			expires_at?: string;
+			items: components["schemas"]["SavedCartItemInput"][];
+		};
+		SavedCartConvertResponse: {
+			cart: components["schemas"]["Cart"];
+			saved_cart: components["schemas"]["SavedCart"];
+			lines: components["schemas"]["CartMergeLine"][];
+		};
+		ExpressCheckout: {
+			/** @description Value for the X-Express-Checkout header. */
+			token: string;
+			/** Format: date-time */
+			expires_at: string;
+			cart: components["schemas"]["Cart"];
+		};
+		AbandonedCartItem: {
+			product_variant_id: number;
+			sku: string;
+			title: string;
+			product_name: string;
+			quantity: number;
+			/** Format: double */
+			price: number;
+		};
+		AbandonedCart: {
+			id: number;
+			checkout_session_id: number;
+			user_id?: number | null;
+			email: string;
+			items: components["schemas"]["AbandonedCartItem"][];
+			item_count: number;
+			/** Format: double */
+			subtotal: number;
+			/** @description QUEUED, SENT, FAILED, SKIPPED, RESTORED, RECOVERED or EXPIRED (contacted but not recovered within 30 days of detection). */
+			status: string;
+			/** Format: date-time */
+			detected_at: string;
+			attempts: number;
+			last_error?: string;
+			/** Format: date-time */
+			notified_at?: string | null;
+			restore_count: number;
+			/** Format: date-time */
+			restored_at?: string | null;
+			recovered_order_id?: number | null;
+			/** Format: date-time */
+			recovered_at?: string | null;
+		};
+		AbandonedCartListResponse: {
+			data: components["schemas"]["AbandonedCart"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		AbandonedCartReport: {
+			/** Format: date-time */
+			from: string;
+			/** Format: date-time */
+			to: string;
+			detected: number;
+			notified: number;
+			restored: number;
+			recovered: number;
+			/** Format: double */
+			abandoned_value: number;
+			/**
+			 * Format: double
+			 * @description Total of recovered orders that are still paid, shipped or delivered.
+			 */
+			recovered_revenue: number;
+		};
+		CheckoutCartSummary: {
+			item_count: number;
+		};
+		AddCartItemRequest: {
+			product_variant_id: number;
+			quantity: number;
+		};
+		UpdateCartItemRequest: {
+			quantity: number;
+		};
+		OrderItem: {
+			id: number;
+			order_id: number;
+			product_variant_id: number;
+			variant_sku: string;
+			variant_title: string;
+			quantity: number;
+			/** Format: double */
+			price: number;
+			/** @description BACKORDER when the line was sold past stock, PREORDER when it was sold before the variant's release, otherwise empty. */
+			inventory_policy?: string;
+			/**
+			 * Format: date-time
+			 * @description Ship date promised when the line was sold past stock.
 			 */
+			promised_ship_at?: string | null;
+			/** @description Units that have left in a shipment. */
+			quantity_fulfilled?: number;
+			/** @description Units that will not ship. */
+			quantity_cancelled?: number;
+			/** @description Units still to ship. */
+			quantity_remaining?: number;
+			product_variant: components["schemas"]["ProductVariant"];
+			product: components["schemas"]["Product"];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			/** Format: date-time */
+			deleted_at?: string | null;
+		};
+		Order: {
+			id: number;
+			user_id?: number | null;
+			checkout_session_id: number;
+			/** Format: email */
+			guest_email?: string | null;
+			confirmation_token?: string | null;
+			/** @enum {string} */
+			status:
+				| "PENDING"
+				| "PAID"
+				| "FAILED"
+				| "PARTIALLY_SHIPPED"
+				| "SHIPPED"
+				| "DELIVERED"
+				| "CANCELLED"
+				| "REFUNDED";
+			can_cancel: boolean;
+			/** Format: double */
+			total: number;
+			payment_method_display?: string | null;
+			shipping_address_pretty?: string | null;
+			/** @description Merchant checkout field values keyed by field key. */
+			attributes?: {
+				[key: string]: string;
+			};
+			/** @description Staff tags; only included in admin responses. */
+			tags?: string[];
+			items: components["schemas"]["OrderItem"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			/** Format: date-time */
+			deleted_at?: string | null;
+		};
+		OrderPage: {
+			data: components["schemas"]["Order"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		CreateOrderItemRequest: {
+			product_variant_id: number;
+			quantity: number;
+		};
+		CreateOrderRequest: {
+			items: components["schemas"]["CreateOrderItemRequest"][];
+		};
+		CreateCheckoutOrderRequest: {
+			/** Format: email */
+			guest_email?: string | null;
+			/** @description Sales channel whose checkout fields apply (web, app or admin). Defaults to web. */
+			channel?: string;
+			/** @description Values for the merchant checkout fields, keyed by field key. */
+			attributes?: {
+				[key: string]: string;
+			};
+		};
+		ClaimGuestOrderRequest: {
+			/** Format: email */
+			email: string;
+			confirmation_token: string;
+		};
+		ProcessPaymentInputMethod: {
+			cardholder_name: string;
+			card_number: string;
+			exp_month: number;
+			exp_year: number;
+		};
+		ProcessPaymentInputAddress: {
+			full_name: string;
+			line1: string;
+			line2?: string;
+			city: string;
+			state?: string;
+			postal_code: string;
+			country: string;
+		};
+		ProcessPaymentRequest: {
+			payment_method_id?: number;
+			address_id?: number;
+			payment_method?: components["schemas"]["ProcessPaymentInputMethod"];
+			address?: components["schemas"]["ProcessPaymentInputAddress"];
+			payment_provider_id?: string;
+			shipping_provider_id?: string;
+			tax_provider_id?: string;
+			payment_data?: {
+				[key: string]: string;
+			};
+			shipping_data?: {
+				[key: string]: string;
+			};
+			tax_data?: {
+				[key: string]: string;
+			};
+		};
+		AuthorizeCheckoutOrderPaymentRequest: {
+			snapshot_id: number;
+			gift_cards?: components["schemas"]["GiftCardTender"][];
+		};
+		ProcessPaymentResponse: {
+			message: string;
+			order: components["schemas"]["Order"];
+		};
+		AdminOrderPaymentAmountRequest: {
+			/** Format: double */
+			amount?: number;
+		};
+		PaymentTransactionRecord: {
+			id: number;
+			/** @enum {string} */
+			operation: "AUTHORIZE" | "CAPTURE" | "VOID" | "REFUND";
+			provider_txn_id: string;
+			idempotency_key: string;
+			/** Format: double */
+			amount: number;
+			/** @enum {string} */
+			status: "PENDING" | "SUCCEEDED" | "FAILED";
+			raw_response_redacted: string;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		PaymentIntentRecord: {
+			id: number;
+			order_id: number;
+			snapshot_id: number;
+			provider: string;
+			/** @enum {string} */
+			status:
+				| "REQUIRES_ACTION"
+				| "AUTHORIZED"
+				| "PARTIALLY_CAPTURED"
+				| "CAPTURED"
+				| "VOIDED"
+				| "REFUNDED"
+				| "FAILED";
+			/** Format: double */
+			authorized_amount: number;
+			/** Format: double */
+			captured_amount: number;
+			/** Format: double */
+			refundable_amount: number;
+			currency: string;
+			version: number;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			transactions: components["schemas"]["PaymentTransactionRecord"][];
+		};
+		OrderPaymentLedger: {
+			order_id: number;
+			intents: components["schemas"]["PaymentIntentRecord"][];
+		};
+		InventoryReservation: {
+			id: number;
+			product_variant_id: number;
+			quantity: number;
+			/** @enum {string} */
+			status: "ACTIVE" | "CONSUMED" | "RELEASED" | "EXPIRED" | "BACKORDERED";
+			/** Format: date-time */
+			expires_at: string;
+			owner_type: string;
+			owner_id?: number | null;
+			checkout_session_id?: number | null;
+			order_id?: number | null;
+			/** @description Taken without stock behind it; allocated when a purchase order receipt brings stock in. */
+			backordered?: boolean;
+			/** @description Location holding the reserved units; absent while a backorder waits for stock. */
+			stock_location_id?: number | null;
+			/** Format: date-time */
+			allocated_at?: string | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		InventoryReservationList: {
+			items: components["schemas"]["InventoryReservation"][];
+		};
+		InventoryAlert: {
+			id: number;
+			product_variant_id: number;
+			/** @enum {string} */
+			alert_type: "LOW_STOCK" | "OUT_OF_STOCK" | "RECOVERY";
+			/** @enum {string} */
+			status: "OPEN" | "ACKED" | "RESOLVED";
+			available: number;
+			threshold: number;
+			/** Format: date-time */
+			opened_at: string;
+			/** Format: date-time */
+			acked_at?: string | null;
+			acked_by_type?: string;
+			acked_by_id?: number | null;
+			/** Format: date-time */
+			resolved_at?: string | null;
+			resolved_by_type?: string;
+			resolved_by_id?: number | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		InventoryAlertList: {
+			items: components["schemas"]["InventoryAlert"][];
+		};
+		InventoryThreshold: {
+			id: number;
+			product_variant_id?: number | null;
+			low_stock_quantity: number;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		InventoryThresholdList: {
+			items: components["schemas"]["InventoryThreshold"][];
+		};
+		InventoryThresholdRequest: {
+			product_variant_id?: number | null;
+			low_stock_quantity: number;
+		};
+		InventoryMovement: {
+			id: number;
+			inventory_item_id: number;
+			movement_type: string;
+			quantity_delta: number;
+			reference_type: string;
+			reference_id?: number | null;
+			reason_code: string;
+			actor_type: string;
+			actor_id?: number | null;
+			/** @description Location whose on-hand stock moved. */
+			stock_location_id?: number | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		/** @enum {string} */
+		InventoryAdjustmentReason:
+			| "CYCLE_COUNT_GAIN"
+			| "CYCLE_COUNT_LOSS"
+			| "DAMAGE"
+			| "SHRINKAGE"
+			| "RETURN_RESTOCK"
+			| "CORRECTION";
+		InventoryAdjustment: {
+			id: number;
+			inventory_item_id: number;
+			product_variant_id: number;
+			quantity_delta: number;
+			reason_code: components["schemas"]["InventoryAdjustmentReason"];
+			notes: string;
+			actor_type: string;
+			actor_id?: number | null;
+			approved_by_type: string;
+			approved_by_id?: number | null;
+			/** Format: date-time */
+			approved_at?: string | null;
+			/** @description Location that was counted. */
+			stock_location_id?: number | null;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		InventoryAvailability: {
+			product_variant_id: number;
+			on_hand: number;
+			reserved: number;
+			available: number;
+		};
+		InventoryAdjustmentRequest: {
+			product_variant_id: number;
+			quantity_delta: number;
+			reason_code: components["schemas"]["InventoryAdjustmentReason"];
+			notes?: string;
+			approved_by_type?: string;
+			approved_by_id?: number | null;
+			/** @description Stock location counted; the default location when omitted. */
+			location_code?: string;
+		};
+		InventoryAdjustmentResponse: {
+			adjustment: components["schemas"]["InventoryAdjustment"];
+			availability: components["schemas"]["InventoryAvailability"];
+		};
+		InventoryReconciliationIssue: {
+			issue_type: string;
+			inventory_item_id: number;
+			product_variant_id: number;
+			expected: number;
+			actual: number;
+			message: string;
+			entity_type: string;
+			entity_id?: number | null;
+		};
+		InventoryReconciliationReport: {
+			/** Format: date-time */
+			checked_at: string;
+			issues: components["schemas"]["InventoryReconciliationIssue"][];
+		};
+		InventoryTimeline: {
+			product_variant_id: number;
+			movements: components["schemas"]["InventoryMovement"][];
+			reservations: components["schemas"]["InventoryReservation"][];
+			adjustments: components["schemas"]["InventoryAdjustment"][];
+		};
+		Supplier: {
+			id: number;
+			name: string;
+			email: string;
+			notes: string;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		SupplierRequest: {
+			name: string;
+			email?: string;
+			notes?: string;
+		};
+		PurchaseOrderItem: {
+			id: number;
+			product_variant_id: number;
+			quantity_ordered: number;
+			quantity_received: number;
+			unit_cost: number;
+		};
+		PurchaseOrder: {
+			id: number;
+			supplier_id?: number | null;
+			supplier?: components["schemas"]["Supplier"];
+			/** @enum {string} */
+			status: "DRAFT" | "ISSUED" | "PARTIALLY_RECEIVED" | "RECEIVED" | "CANCELLED";
+			notes: string;
+			/** Format: date-time */
+			issued_at?: string | null;
+			/** Format: date-time */
+			received_at?: string | null;
+			/** Format: date-time */
+			cancelled_at?: string | null;
+			items: components["schemas"]["PurchaseOrderItem"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		PurchaseOrderList: {
+			items: components["schemas"]["PurchaseOrder"][];
+		};
+		PurchaseOrderItemRequest: {
+			product_variant_id: number;
+			quantity_ordered: number;
+			unit_cost?: number;
+		};
+		PurchaseOrderRequest: {
+			supplier_id?: number | null;
+			supplier?: components["schemas"]["SupplierRequest"];
+			notes?: string;
+			items: components["schemas"]["PurchaseOrderItemRequest"][];
+		};
+		PurchaseOrderReceiveItemRequest: {
+			purchase_order_item_id: number;
+			quantity_received: number;
+		};
+		StockLocation: {
+			id: number;
 			code: string;
-			/**
-			 * @description Identifier used to correlate the response with logs and traces.
-			 * @example 01J2EXAMPLE8Y4Q2S7M9K3N6P5R
-			 */
-			correlation_id: string;
-			/** @description Field or value issues, present when the problem has validation details. */
-			errors?: components["schemas"]["ValidationIssue"][];
+			name: string;
+			/** @description WAREHOUSE or STORE. */
+			kind: string;
+			/** @description Stock here counts towards what the storefront can sell. */
+			sellable: boolean;
+			active: boolean;
+			/** @description Order in which locations are drawn from; lowest first. */
+			position: number;
+			/** @description ISO 3166-1 alpha-2 country the location ships from; empty when unknown. */
+			country: string;
+			/** @description State or region code the location ships from. */
+			state: string;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		StockLocationList: {
+			items: components["schemas"]["StockLocation"][];
+		};
+		StockLocationRequest: {
+			code: string;
+			name: string;
+			/** @description WAREHOUSE or STORE; WAREHOUSE when omitted. */
+			kind?: string;
+			sellable?: boolean;
+			active?: boolean;
+			position?: number;
+			country?: string;
+			state?: string;
+		};
+		StockLocationUpdateRequest: {
+			name?: string;
+			/** @description WAREHOUSE or STORE. */
+			kind?: string;
+			sellable?: boolean;
+			active?: boolean;
+			position?: number;
+			country?: string;
+			state?: string;
+		};
+		OrderRoutingRule: {
+			/** @description FULL_ORDER prefers a location that can ship the whole order, PROXIMITY one in the destination state or country, and LOCATION_PRIORITY the lowest location position. */
+			rule: string;
+			position: number;
+			active: boolean;
+		};
+		OrderRoutingRuleList: {
+			items: components["schemas"]["OrderRoutingRule"][];
+		};
+		OrderRoutingRuleInput: {
+			/** @description FULL_ORDER, PROXIMITY or LOCATION_PRIORITY. */
+			rule: string;
+			active: boolean;
+		};
+		OrderRoutingRulesRequest: {
+			rules: components["schemas"]["OrderRoutingRuleInput"][];
+		};
+		OrderRoutingLine: {
+			product_variant_id: number;
+			quantity: number;
+		};
+		OrderRoutingAddress: {
+			country?: string;
+			state?: string;
+		};
+		OrderRoutingSimulationRequest: {
+			items: components["schemas"]["OrderRoutingLine"][];
+			address?: components["schemas"]["OrderRoutingAddress"];
+		};
+		OrderRoutingAssignment: {
+			location: components["schemas"]["StockLocation"];
+			lines: components["schemas"]["OrderRoutingLine"][];
+			explanation: string;
+		};
+		OrderRoutingPlan: {
+			/** @description Active rules in the order they were applied. */
+			rules: string[];
+			assignments: components["schemas"]["OrderRoutingAssignment"][];
+			/** @description Units no sellable location can supply. */
+			unrouted: components["schemas"]["OrderRoutingLine"][];
+			/** @description The cart ships from more than one location. */
+			split: boolean;
+		};
+		FulfillmentRerouteInput: {
+			location_code: string;
+			reason?: string;
+		};
+		InventoryLocationLevel: {
+			location: components["schemas"]["StockLocation"];
+			on_hand: number;
+			reserved: number;
+			available: number;
+		};
+		InventoryLocationLevelList: {
+			product_variant_id: number;
+			items: components["schemas"]["InventoryLocationLevel"][];
+		};
+		InventoryTransferItem: {
+			id: number;
+			product_variant_id: number;
+			quantity: number;
+		};
+		InventoryTransfer: {
+			id: number;
+			from_location_id: number;
+			to_location_id: number;
+			/** @description DRAFT, IN_TRANSIT, RECEIVED or CANCELLED. */
+			status: string;
+			notes: string;
+			actor_type: string;
+			actor_id?: number | null;
+			/** Format: date-time */
+			shipped_at?: string | null;
+			/** Format: date-time */
+			received_at?: string | null;
+			/** Format: date-time */
+			cancelled_at?: string | null;
+			items: components["schemas"]["InventoryTransferItem"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		InventoryTransferList: {
+			items: components["schemas"]["InventoryTransfer"][];
+		};
+		InventoryTransferItemRequest: {
+			product_variant_id: number;
+			quantity: number;
+		};
+		InventoryTransferRequest: {
+			from_location_code: string;
+			to_location_code: string;
+			notes?: string;
+			items: components["schemas"]["InventoryTransferItemRequest"][];
+		};
+		PurchaseOrderReceiveRequest: {
+			notes?: string;
+			/** @description Stock location the goods arrived at; the default location when omitted. */
+			location_code?: string;
+			items: components["schemas"]["PurchaseOrderReceiveItemRequest"][];
+		};
+		InventoryReceiptItem: {
+			id: number;
+			purchase_order_item_id: number;
+			product_variant_id: number;
+			quantity_received: number;
+		};
+		InventoryReceipt: {
+			id: number;
+			purchase_order_id: number;
+			/** Format: date-time */
+			received_at: string;
+			notes: string;
+			/** @description Location the goods were received into. */
+			stock_location_id?: number | null;
+			items: components["schemas"]["InventoryReceiptItem"][];
+		};
+		PurchaseOrderReceiptResponse: {
+			purchase_order: components["schemas"]["PurchaseOrder"];
+			receipt: components["schemas"]["InventoryReceipt"];
+		};
+		AdminOrderPaymentLifecycleResponse: {
+			message: string;
+			order: components["schemas"]["Order"];
+			payment_intent: components["schemas"]["PaymentIntentRecord"];
+			transaction: components["schemas"]["PaymentTransactionRecord"];
+		};
+		WebhookIngestResponse: {
+			message: string;
+			event_id: number;
+			provider_event_id: string;
+			duplicate: boolean;
+		};
+		WebhookEventRecord: {
+			id: number;
+			provider: string;
+			provider_event_id: string;
+			event_type: string;
+			signature_valid: boolean;
+			payload: string;
+			/** Format: date-time */
+			received_at: string;
+			/** Format: date-time */
+			processed_at?: string | null;
+			attempt_count: number;
+			last_error: string;
+			/** @enum {string} */
+			status: "PENDING" | "PROCESSED" | "DEAD_LETTER" | "REJECTED";
+		};
+		WebhookEventPage: {
+			data: components["schemas"]["WebhookEventRecord"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		CheckoutOrderShippingRatesRequest: {
+			snapshot_id: number;
+		};
+		ShipmentRate: {
+			id: number;
+			provider: string;
+			provider_rate_id: string;
+			service_code: string;
+			service_name: string;
+			/** Format: double */
+			amount: number;
+			currency: string;
+			selected: boolean;
+			shipment_id?: number | null;
+			/** Format: date-time */
+			expires_at?: string | null;
+		};
+		ShipmentPackage: {
+			id: number;
+			reference: string;
+			weight_grams: number;
+			length_cm: number;
+			width_cm: number;
+			height_cm: number;
+			/** @description Fulfillment lines carried in the package. */
+			lines?: components["schemas"]["ShipmentPackageLine"][];
+		};
+		TrackingEvent: {
+			id: number;
+			provider: string;
+			provider_event_id: string;
+			/** @enum {string} */
+			status: "LABEL_PURCHASED" | "IN_TRANSIT" | "DELIVERED" | "EXCEPTION";
+			tracking_number: string;
+			location: string;
+			description: string;
+			/** Format: date-time */
+			occurred_at: string;
+		};
+		Shipment: {
+			id: number;
+			order_id: number;
+			snapshot_id: number;
+			provider: string;
+			shipment_rate_id: number;
+			fulfillment_order_id?: number;
+			provider_shipment_id: string;
+			/** @enum {string} */
+			status: "QUOTED" | "LABEL_PURCHASED" | "IN_TRANSIT" | "DELIVERED" | "EXCEPTION";
+			currency: string;
+			service_code: string;
+			service_name: string;
+			/** Format: double */
+			amount: number;
+			shipping_address_pretty: string;
+			tracking_number: string;
+			tracking_url: string;
+			label_url: string;
+			/** Format: date-time */
+			purchased_at?: string | null;
+			/** Format: date-time */
+			finalized_at?: string | null;
+			/** Format: date-time */
+			delivered_at?: string | null;
+			rates: components["schemas"]["ShipmentRate"][];
+			packages: components["schemas"]["ShipmentPackage"][];
+			tracking_events: components["schemas"]["TrackingEvent"][];
+		};
+		CheckoutOrderShippingRatesResponse: {
+			order_id: number;
+			snapshot_id: number;
+			provider: string;
+			rates: components["schemas"]["ShipmentRate"][];
+		};
+		CheckoutOrderTrackingResponse: {
+			order_id: number;
+			shipments: components["schemas"]["Shipment"][];
+		};
+		AdminOrderShippingLabelPackageRequest: {
+			reference?: string;
+			weight_grams?: number;
+			length_cm?: number;
+			width_cm?: number;
+			height_cm?: number;
+		};
+		AdminOrderShippingLabelRequest: {
+			rate_id: number;
+			/** @description Packed fulfillment order the label is for. Its lines are recorded on the package. */
+			fulfillment_order_id?: number;
+			package?: components["schemas"]["AdminOrderShippingLabelPackageRequest"];
+		};
+		AdminOrderShippingLabelResponse: {
+			message: string;
+			shipment: components["schemas"]["Shipment"];
+		};
+		CheckoutOrderTaxFinalizeRequest: {
+			snapshot_id: number;
+			inclusive_pricing?: boolean;
+		};
+		TaxLine: {
+			id?: number;
+			snapshot_item_id?: number | null;
+			/** @enum {string} */
+			line_type: "ITEM" | "SHIPPING";
+			product_variant_id?: number | null;
+			quantity: number;
+			jurisdiction: string;
+			tax_code: string;
+			tax_name: string;
+			/** Format: double */
+			taxable_amount: number;
+			/** Format: double */
+			tax_amount: number;
+			tax_rate_basis_points: number;
+			inclusive: boolean;
+		};
+		CheckoutOrderTaxFinalizeResponse: {
+			message: string;
+			order_id: number;
+			snapshot_id: number;
+			provider: string;
+			currency: string;
+			inclusive_pricing: boolean;
+			/** Format: double */
+			total_tax: number;
+			lines: components["schemas"]["TaxLine"][];
+		};
+		ProviderCredentialRequest: {
+			/** @enum {string} */
+			provider_type: "payment" | "shipping" | "tax";
+			provider_id: string;
+			/** @enum {string} */
+			environment: "sandbox" | "production";
+			label?: string;
+			secret_data: {
+				[key: string]: string;
+			};
+			supported_currencies?: string[];
+			settlement_currency?: string;
+			/** @enum {string} */
+			fx_mode?: "same_currency_only" | "provider_managed";
+		};
+		ProviderCredential: {
+			id: number;
+			/** @enum {string} */
+			provider_type: "payment" | "shipping" | "tax";
+			provider_id: string;
+			/** @enum {string} */
+			environment: "sandbox" | "production";
+			label: string;
+			key_version: string;
+			supported_currencies: string[];
+			settlement_currency?: string;
+			/** @enum {string} */
+			fx_mode: "same_currency_only" | "provider_managed";
+			/** Format: date-time */
+			last_rotated_at: string;
+			/** Format: date-time */
+			updated_at: string;
+		};
+		ProviderCredentialEnvelope: {
+			credential: components["schemas"]["ProviderCredential"];
+		};
+		ProviderCredentialListResponse: {
+			data: components["schemas"]["ProviderCredential"][];
+		};
+		ProviderWebhookStatusSummary: {
+			pending_count: number;
+			processed_count: number;
+			dead_letter_count: number;
+			rejected_count: number;
+		};
+		ProviderOperationStatusSummary: {
+			total_count: number;
+			active_count: number;
+			unknown_count: number;
+			finalize_retry_count: number;
+			compensation_retry_count: number;
+			failed_count: number;
+			completed_count: number;
+		};
+		ProviderReconciliationCaseSummary: {
+			open_count: number;
+			unassigned_count: number;
+		};
+		ProviderOperationsOverview: {
+			/** @enum {string} */
+			runtime_environment: "sandbox" | "production";
+			credential_service_configured: boolean;
+			webhook_events: components["schemas"]["ProviderWebhookStatusSummary"];
+			operations: components["schemas"]["ProviderOperationStatusSummary"];
+			reconciliation_cases: components["schemas"]["ProviderReconciliationCaseSummary"];
 		};
 		/**
-		 * @deprecated
-		 * @description Transitional legacy error body. New and migrated operations use Problem.
+		 * @description Durable lifecycle state of an externally effective provider operation.
+		 * @enum {string}
 		 */
-		Error: {
-			error: string;
-			code?: string;
+		ProviderOperationStatus:
+			| "PREPARED"
+			| "EXECUTING"
+			| "OUTCOME_UNKNOWN"
+			| "PROVIDER_SUCCEEDED"
+			| "FINALIZING"
+			| "FINALIZE_RETRY"
+			| "COMPENSATION_PREPARED"
+			| "COMPENSATING"
+			| "COMPENSATION_SUCCEEDED"
+			| "COMPENSATION_RETRY"
+			| "COMPLETED"
+			| "FAILED"
+			| "RECONCILING"
+			| "RECONCILIATION_REQUIRED";
+		/** @enum {string} */
+		ProviderOperationAttemptOutcome: "SUCCEEDED" | "FAILED" | "UNKNOWN";
+		ProviderOperationAttempt: {
+			id: number;
+			attempt_number: number;
+			phase: string;
+			outcome: components["schemas"]["ProviderOperationAttemptOutcome"];
+			/** @enum {string} */
+			provider_outcome: "SUCCEEDED" | "FAILED" | "UNKNOWN" | "NOT_FOUND" | "";
+			provider_reference?: string;
+			operation_key: string;
+			retryable: boolean;
+			/** @description Safe classified failure metadata; provider payloads and secrets are never exposed. */
+			problem?: components["schemas"]["Problem"] | null;
+			/** Format: date-time */
+			started_at: string;
+			/** Format: date-time */
+			finished_at: string;
 		};
-		MessageResponse: {
+		/** @enum {string} */
+		ProviderReconciliationCaseStatus: "OPEN" | "RESOLVED";
+		/** @enum {string} */
+		ProviderReconciliationCaseOutcome:
+			| "CONFIRMED_SUCCEEDED"
+			| "CONFIRMED_FAILED"
+			| "RETRY_REQUIRED"
+			| "MANUAL_REVIEW";
+		ProviderReconciliationCase: {
+			id: number;
+			operation_id: number;
+			attempt_id?: number | null;
+			/** @enum {string} */
+			provider_type: "payment" | "shipping" | "tax";
+			provider_id: string;
+			/** @enum {string} */
+			environment: "sandbox" | "production";
+			operation: string;
+			operation_status: components["schemas"]["ProviderOperationStatus"];
+			status: components["schemas"]["ProviderReconciliationCaseStatus"];
+			outcome?: components["schemas"]["ProviderReconciliationCaseOutcome"] | null;
+			/** @description Safe machine-readable reason for reconciliation. */
+			reason: string;
+			case_type: string;
+			/** @enum {string} */
+			provider_outcome: "SUCCEEDED" | "FAILED" | "UNKNOWN" | "NOT_FOUND" | "";
+			operation_key: string;
+			assigned_to?: string | null;
+			resolution_note?: string | null;
+			problem?: components["schemas"]["Problem"] | null;
+			/** Format: date-time */
+			next_attempt_at?: string | null;
+			/** Format: date-time */
+			opened_at: string;
+			/** Format: date-time */
+			resolved_at?: string | null;
+		};
+		ProviderReconciliationCaseUpdate: {
+			assigned_to?: string | null;
+			status?: components["schemas"]["ProviderReconciliationCaseStatus"];
+			outcome?: components["schemas"]["ProviderReconciliationCaseOutcome"];
+			resolution_note?: string;
+		};
+		ProviderReconciliationCaseEnvelope: {
+			case: components["schemas"]["ProviderReconciliationCase"];
+		};
+		ProviderReconciliationCasePage: {
+			data: components["schemas"]["ProviderReconciliationCase"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		/** @description Safe API representation of a durable provider operation ledger record. */
+		ProviderOperation: {
+			id: number;
+			operation_key: string;
+			/** @enum {string} */
+			provider_type: "payment" | "shipping" | "tax";
+			provider_id: string;
+			/** @enum {string} */
+			environment: "sandbox" | "production";
+			/** @description Stable provider operation kind, such as payment.capture. */
+			operation: string;
+			idempotency_key: string;
+			correlation_id: string;
+			entity_type: string;
+			entity_id: number;
+			status: components["schemas"]["ProviderOperationStatus"];
+			provider_outcome?: string;
+			provider_reference?: string;
+			retryable: boolean;
+			available_actions?: ("query_outcome" | "retry_finalize" | "retry_compensation")[];
+			problem?: components["schemas"]["Problem"] | null;
+			parent_operation_id?: number | null;
+			compensation_operation_id?: number | null;
+			attempts: components["schemas"]["ProviderOperationAttempt"][];
+			reconciliation_cases: components["schemas"]["ProviderReconciliationCase"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			/** Format: date-time */
+			completed_at?: string | null;
+			/** Format: date-time */
+			next_attempt_at?: string | null;
+		};
+		ProviderOperationEnvelope: {
+			operation: components["schemas"]["ProviderOperation"];
+		};
+		ProviderOperationAcceptedEnvelope: {
 			message: string;
+			operation_key: string;
+			status: components["schemas"]["ProviderOperationStatus"];
 		};
-		/** @enum {string} */
-		CmsEntryStatus: "DRAFT" | "SCHEDULED" | "PUBLISHED" | "ARCHIVED";
-		CmsEntry: {
+		ProviderOperationPage: {
+			data: components["schemas"]["ProviderOperation"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		ProviderReconciliationRunRequest: {
+			/** @enum {string} */
+			provider_type: "payment" | "shipping" | "tax";
+			provider_id: string;
+		};
+		ProviderReconciliationDrift: {
+			id: number;
+			entity_type: string;
+			entity_id: number;
+			provider_reference: string;
+			/** @enum {string} */
+			severity: "ERROR" | "WARN";
+			field_name: string;
+			expected_value: string;
+			actual_value: string;
+			message: string;
+		};
+		ProviderReconciliationRun: {
 			id: number;
 			/** @enum {string} */
-			entry_type: "page" | "layout" | "global" | "navigation" | "template";
+			provider_type: "payment" | "shipping" | "tax";
+			provider_id: string;
+			/** @enum {string} */
+			environment: "sandbox" | "production";
+			/** @enum {string} */
+			trigger: "MANUAL" | "SCHEDULED";
+			/** @enum {string} */
+			status: "SUCCEEDED" | "FAILED";
+			checked_count: number;
+			drift_count: number;
+			error_count: number;
+			/** Format: date-time */
+			started_at: string;
+			/** Format: date-time */
+			finished_at?: string | null;
+			drifts?: components["schemas"]["ProviderReconciliationDrift"][];
+		};
+		ProviderReconciliationRunEnvelope: {
+			run: components["schemas"]["ProviderReconciliationRun"];
+		};
+		ProviderReconciliationRunPage: {
+			data: components["schemas"]["ProviderReconciliationRun"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		ClaimGuestOrderResponse: {
+			message: string;
+			order: components["schemas"]["Order"];
+		};
+		CheckoutPluginFieldOption: {
+			value: string;
+			label: string;
+		};
+		CheckoutPluginField: {
 			key: string;
-			status: components["schemas"]["CmsEntryStatus"];
-			current_version_id?: number | null;
-			published_version_id?: number | null;
+			label: string;
+			/** @enum {string} */
+			type: "text" | "number" | "checkbox" | "select" | "date";
+			required: boolean;
+			placeholder?: string;
+			help_text?: string;
+			options?: components["schemas"]["CheckoutPluginFieldOption"][];
+		};
+		CheckoutFieldDefinitionList: {
+			data: components["schemas"]["CheckoutPluginField"][];
+		};
+		CheckoutField: {
+			id: number;
+			key: string;
+			label: string;
+			/** @description One of text, select, checkbox or date. */
+			type: string;
+			required: boolean;
+			placeholder?: string;
+			help_text?: string;
+			options: components["schemas"]["CheckoutPluginFieldOption"][];
+			/** @description Sales channels (web, app, admin) showing the field. Empty means every channel. */
+			channels: string[];
+			/** @description Maximum text length; 0 uses the default limit. */
+			max_length: number;
+			position: number;
+			active: boolean;
 			/** Format: date-time */
 			created_at: string;
 			/** Format: date-time */
 			updated_at: string;
 		};
-		CmsEntryVersion: {
-			id: number;
-			entry_id: number;
-			version_number: number;
-			schema_version: number;
-			payload: components["schemas"]["CmsPagePayload"];
-			created_by?: number | null;
-			change_summary?: string;
+		RiskSettings: {
+			enabled: boolean;
+			/** @description Orders scoring at or above this are held for manual review. */
+			review_score: number;
+			/** @description Orders scoring at or above this are refused before payment. */
+			reject_score: number;
+			/**
+			 * Format: double
+			 * @description First orders at or above this total add to the score.
+			 */
+			high_value_threshold: number;
+			velocity_window_minutes: number;
+			/** @description Other orders from the same email, client address or card BIN within the window before velocity adds to the score. */
+			velocity_limit: number;
+			/** @description Email domains treated as disposable in addition to the built-in list. */
+			disposable_domains: string[];
+			/** @description ID of a risk provider plugin consulted for every order; empty when only built-in rules apply. */
+			external_scorer_id: string;
+			/** Format: date-time */
+			updated_at?: string;
+		};
+		RiskSettingsInput: {
+			enabled: boolean;
+			review_score: number;
+			reject_score: number;
+			/** Format: double */
+			high_value_threshold: number;
+			velocity_window_minutes: number;
+			velocity_limit: number;
+			disposable_domains?: string[];
+			external_scorer_id?: string;
+		};
+		RiskSignal: {
+			code: string;
+			score: number;
+			message: string;
+		};
+		RiskAssessment: {
+			id: number;
+			score: number;
+			/** @description One of ALLOW, REVIEW or REJECT. */
+			action: string;
+			reasons: components["schemas"]["RiskSignal"][];
+			email?: string;
+			client_ip?: string;
+			card_bin?: string;
+			scorer_id: string;
 			/** Format: date-time */
 			created_at: string;
 		};
-		CmsPublication: {
-			id: number;
-			entry_id: number;
-			version_id: number;
-			published_by?: number | null;
+		OrderRisk: {
+			order: components["schemas"]["Order"];
+			/** @description Latest score from 0 to 100; null when the order was never screened. */
+			score?: number | null;
+			/** @description Latest action, one of ALLOW, REVIEW or REJECT; empty when never screened. */
+			action: string;
+			/** @description PENDING while held, then APPROVED or REJECTED; empty when no review was needed. */
+			review_status: string;
+			reviewed_by?: string;
 			/** Format: date-time */
-			published_at: string;
-			rollback_from_publication_id?: number | null;
-			notes?: string;
+			reviewed_at?: string | null;
+			reasons: components["schemas"]["RiskSignal"][];
+			/** @description Every screening run for the order, newest first. Omitted from list responses. */
+			assessments?: components["schemas"]["RiskAssessment"][];
 		};
-		CmsNavigationMenu: {
+		OrderRiskListResponse: {
+			data: components["schemas"]["OrderRisk"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		RiskReviewDecisionInput: {
+			/** @description Reason recorded in the order status history when rejecting. */
+			note?: string;
+		};
+		FulfillmentOrder: {
 			id: number;
-			entry_id: number;
-			key: string;
-			title: string;
-			location: string;
+			order_id: number;
+			location_code: string;
+			/** @description One of UNFULFILLED, PICKING, PACKED, LABEL_PURCHASED, SHIPPED, DELIVERED or CANCELLED. */
+			status: string;
+			/** Format: date-time */
+			pick_list_at?: string;
+			picked_by: string;
+			/** Format: date-time */
+			packed_at?: string;
+			packed_by: string;
+			packing_note: string;
+			/** Format: date-time */
+			label_purchased_at?: string;
+			/** Format: date-time */
+			shipped_at?: string;
+			/** Format: date-time */
+			delivered_at?: string;
+			/** Format: date-time */
+			cancelled_at?: string;
+			/** @description Why the order was routed to this location, or who rerouted it. */
+			routing_explanation: string;
+			lines: components["schemas"]["FulfillmentOrderLine"][];
 			/** Format: date-time */
 			created_at: string;
 			/** Format: date-time */
 			updated_at: string;
 		};
-		CmsNavigationItem: {
+		FulfillmentOrderLine: {
 			id: number;
-			menu_id: number;
-			parent_id?: number | null;
-			label: string;
-			/** @enum {string} */
-			item_type: "internal" | "external" | "category" | "product" | "page" | "dropdown";
-			target_ref: string;
-			url: string;
-			sort_order: number;
-			is_enabled: boolean;
+			order_item_id: number;
+			product_variant_id: number;
+			variant_sku: string;
+			variant_title: string;
+			quantity: number;
+			quantity_picked: number;
+			quantity_packed: number;
 		};
-		CmsNavigationDraftRequest: {
-			key: string;
-			title: string;
-			location: string;
-			items: components["schemas"]["CmsNavigationItemInput"][];
-			change_summary?: string;
+		FulfillmentOrderListResponse: {
+			data: components["schemas"]["FulfillmentOrder"][];
+			pagination: components["schemas"]["Pagination"];
 		};
-		CmsNavigationItemInput: {
-			id?: number;
-			parent_id?: number | null;
+		FulfillmentStepInput: {
+			note?: string;
+		};
+		FulfillmentPackingInput: {
+			/** @description Packing note kept on the fulfillment order. */
+			note?: string;
+			/** @description Packed quantity per line. Omit to pack every line in full. */
+			lines?: components["schemas"]["FulfillmentPackedLine"][];
+		};
+		FulfillmentPackedLine: {
+			line_id: number;
+			quantity: number;
+		};
+		ShipmentPackageLine: {
+			fulfillment_order_line_id: number;
+			quantity: number;
+		};
+		CheckoutFieldList: {
+			data: components["schemas"]["CheckoutField"][];
+		};
+		CheckoutFieldRequest: {
+			/** @description Attribute key; cannot be changed once created. */
+			key: string;
 			label: string;
+			/** @description One of text, select, checkbox or date. */
+			type: string;
+			required?: boolean;
+			placeholder?: string;
+			help_text?: string;
+			options?: components["schemas"]["CheckoutPluginFieldOption"][];
+			/** @description Sales channels (web, app, admin) showing the field. Empty means every channel. */
+			channels?: string[];
+			max_length?: number;
+			position?: number;
+			active?: boolean;
+		};
+		CheckoutPluginState: {
+			code: string;
 			/** @enum {string} */
-			item_type: "internal" | "external" | "category" | "product" | "page" | "dropdown";
-			target_ref: string;
-			url: string;
-			sort_order: number;
-			is_enabled: boolean;
+			severity: "info" | "success" | "warning" | "error";
+			message: string;
 		};
-		CmsNavigationResponse: {
-			menu: components["schemas"]["CmsNavigationMenu"];
-			entry: components["schemas"]["CmsEntry"];
-			items: components["schemas"]["CmsNavigationItem"][];
-			current_version?: components["schemas"]["CmsEntryVersion"];
-			published_version?: components["schemas"]["CmsEntryVersion"];
-			latest_publication?: components["schemas"]["CmsPublication"];
-			has_unpublished_draft: boolean;
+		CheckoutPlugin: {
+			id: string;
+			/** @enum {string} */
+			type: "payment" | "shipping" | "tax";
+			name: string;
+			description: string;
+			status: string;
+			enabled: boolean;
+			fields: components["schemas"]["CheckoutPluginField"][];
+			states: components["schemas"]["CheckoutPluginState"][];
 		};
-		CmsNavigationListResponse: {
-			data: components["schemas"]["CmsNavigationResponse"][];
-			pagination: components["schemas"]["Pagination"];
+		CheckoutPluginCatalog: {
+			payment: components["schemas"]["CheckoutPlugin"][];
+			shipping: components["schemas"]["CheckoutPlugin"][];
+			tax: components["schemas"]["CheckoutPlugin"][];
 		};
-		CmsGlobalRegion: {
-			id: number;
-			entry_id: number;
-			key: string;
-			title: string;
-			region: string;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+		CheckoutContactInput: {
+			/** Format: email */
+			email: string;
+			phone?: string;
 		};
-		CmsGlobalRegionDraftRequest: {
-			key: string;
-			title: string;
-			region: string;
-			payload: components["schemas"]["CmsPagePayload"];
-			change_summary?: string;
+		CheckoutAddress: {
+			full_name: string;
+			company?: string;
+			line1: string;
+			line2?: string;
+			city: string;
+			state?: string;
+			postal_code: string;
+			/** @description Two-letter ISO country code. */
+			country: string;
 		};
-		CmsGlobalRegionResponse: {
-			region: components["schemas"]["CmsGlobalRegion"];
-			entry: components["schemas"]["CmsEntry"];
-			current_version?: components["schemas"]["CmsEntryVersion"];
-			published_version?: components["schemas"]["CmsEntryVersion"];
-			latest_publication?: components["schemas"]["CmsPublication"];
-			has_unpublished_draft: boolean;
+		CheckoutShippingMethodInput: {
+			provider_id: string;
+			/** @description Provider fields that are not part of the address, such as service_level. */
+			data?: {
+				[key: string]: string;
+			};
 		};
-		CmsGlobalRegionListResponse: {
-			data: components["schemas"]["CmsGlobalRegionResponse"][];
-			pagination: components["schemas"]["Pagination"];
+		CheckoutPaymentInput: {
+			provider_id: string;
+			data?: {
+				[key: string]: string;
+			};
+			tax_data?: {
+				[key: string]: string;
+			};
 		};
-		CmsPage: {
-			id: number;
-			entry_id: number;
-			path: string;
-			slug: string;
-			title: string;
-			template_key: string;
+		CheckoutStateStep: {
 			/** @enum {string} */
-			visibility: "public" | "hidden";
-			seo_metadata_id?: number | null;
-			is_homepage: boolean;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+			step: "contact" | "shipping_address" | "shipping_method" | "payment" | "review";
+			/**
+			 * @description STALE steps hold data that must be confirmed again because an earlier step, the cart or the provider configuration changed.
+			 * @enum {string}
+			 */
+			status: "COMPLETE" | "INCOMPLETE" | "STALE";
+			problems: string[];
 		};
-		CmsPageResponse: {
-			page: components["schemas"]["CmsPage"];
-			entry: components["schemas"]["CmsEntry"];
-			current_version?: components["schemas"]["CmsEntryVersion"];
-			published_version?: components["schemas"]["CmsEntryVersion"];
-			latest_publication?: components["schemas"]["CmsPublication"];
-			has_unpublished_draft: boolean;
-			delivery?: components["schemas"]["CmsDeliveryDecision"];
-			seo?: components["schemas"]["CmsSEOMetadata"];
-			localization?: components["schemas"]["CmsResolvedLocalization"];
+		CheckoutStateContact: {
+			email: string;
+			phone?: string;
 		};
-		CmsPageListResponse: {
-			data: components["schemas"]["CmsPageResponse"][];
-			pagination: components["schemas"]["Pagination"];
+		CheckoutStateShippingMethod: {
+			provider_id: string;
+			data: {
+				[key: string]: string;
+			};
 		};
-		CmsPageDraftRequest: {
-			path: string;
-			slug?: string;
-			title: string;
-			template_key?: string;
+		CheckoutStatePayment: {
+			provider_id: string;
+			display: string;
+		};
+		CheckoutStateTotals: {
+			currency: string;
+			/** Format: double */
+			subtotal: number;
+			/** Format: double */
+			shipping: number;
+			/** Format: double */
+			tax: number;
+			/** Format: double */
+			total: number;
+		};
+		CheckoutStateReview: {
+			snapshot_id: number;
+			/** Format: date-time */
+			expires_at: string;
+		};
+		CheckoutState: {
+			revision: number;
 			/** @enum {string} */
-			visibility?: "public" | "hidden";
-			is_homepage?: boolean;
-			payload: components["schemas"]["CmsPagePayload"];
-			change_summary?: string;
+			next_action:
+				| "ADD_ITEMS"
+				| "PROVIDE_CONTACT"
+				| "PROVIDE_SHIPPING_ADDRESS"
+				| "SELECT_SHIPPING_METHOD"
+				| "PROVIDE_PAYMENT"
+				| "CONFIRM_REVIEW"
+				| "PLACE_ORDER";
+			item_count: number;
+			/** Format: double */
+			subtotal: number;
+			steps: components["schemas"]["CheckoutStateStep"][];
+			contact?: components["schemas"]["CheckoutStateContact"];
+			shipping_address?: components["schemas"]["CheckoutAddress"];
+			shipping_method?: components["schemas"]["CheckoutStateShippingMethod"];
+			payment?: components["schemas"]["CheckoutStatePayment"];
+			totals?: components["schemas"]["CheckoutStateTotals"];
+			review?: components["schemas"]["CheckoutStateReview"];
+			attributes?: {
+				[key: string]: string;
+			};
 		};
-		CmsPagePayload: {
-			blocks?: components["schemas"]["CmsContentBlock"][];
-		} & {
-			[key: string]: unknown;
+		CheckoutReviewRequest: {
+			/** @description Sales channel whose checkout fields apply (web, app or admin). Defaults to web. */
+			channel?: string;
+			/** @description Values for the merchant checkout fields, keyed by field key. */
+			attributes?: {
+				[key: string]: string;
+			};
 		};
-		CmsContentBlock:
-			| components["schemas"]["CmsHeroBlock"]
-			| components["schemas"]["CmsRichTextBlock"]
-			| components["schemas"]["CmsImageBlock"]
-			| components["schemas"]["CmsGalleryBlock"]
-			| components["schemas"]["CmsVideoBlock"]
-			| components["schemas"]["CmsFAQBlock"]
-			| components["schemas"]["CmsCTABlock"]
-			| components["schemas"]["CmsPromoBannerBlock"]
-			| components["schemas"]["CmsProductRailBlock"]
-			| components["schemas"]["CmsCategoryTilesBlock"]
-			| components["schemas"]["CmsPromotionHighlightBlock"]
-			| components["schemas"]["CmsInventoryMessageBlock"]
-			| components["schemas"]["CmsTestimonialBlock"]
-			| components["schemas"]["CmsSocialEmbedBlock"]
-			| components["schemas"]["CmsCustomHTMLBlock"]
-			| components["schemas"]["CmsFooterBlock"];
-		CmsLink: {
-			label: string;
-			url: string;
+		CheckoutQuoteRequest: {
+			payment_provider_id: string;
+			shipping_provider_id: string;
+			tax_provider_id: string;
+			payment_data?: {
+				[key: string]: string;
+			};
+			shipping_data?: {
+				[key: string]: string;
+			};
+			tax_data?: {
+				[key: string]: string;
+			};
+			/** @description Sales channel whose checkout fields apply (web, app or admin). Defaults to web. */
+			channel?: string;
+			/** @description Values for the merchant checkout fields, keyed by field key. */
+			attributes?: {
+				[key: string]: string;
+			};
 		};
-		CmsHeroBlock: {
-			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
-			 */
-			type: "CmsHeroBlock";
-			title: string;
-			subtitle?: string;
-			image_media_id?: string;
-			primary_cta?: components["schemas"]["CmsLink"];
+		CheckoutQuoteResponse: {
+			snapshot_id?: number | null;
+			/** Format: date-time */
+			expires_at?: string | null;
+			currency: string;
+			/** Format: double */
+			subtotal: number;
+			/** Format: double */
+			discount_total?: number;
+			/** Format: double */
+			shipping: number;
+			/** Format: double */
+			tax: number;
+			/** Format: double */
+			total: number;
+			valid: boolean;
+			payment_states: components["schemas"]["CheckoutPluginState"][];
+			shipping_states: components["schemas"]["CheckoutPluginState"][];
+			tax_states: components["schemas"]["CheckoutPluginState"][];
 		};
-		CmsRichTextBlock: {
-			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
-			 */
-			type: "CmsRichTextBlock";
-			body: string;
+		UpdateCheckoutPluginRequest: {
+			enabled: boolean;
 		};
-		CmsImageBlock: {
-			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
-			 */
-			type: "CmsImageBlock";
-			media_id: string;
-			alt?: string;
-			caption?: string;
+		UpdateOrderStatusRequest: {
+			/** @enum {string} */
+			status:
+				| "PENDING"
+				| "PAID"
+				| "FAILED"
+				| "PARTIALLY_SHIPPED"
+				| "SHIPPED"
+				| "DELIVERED"
+				| "CANCELLED"
+				| "REFUNDED";
+			/** @description Recorded in the order's status history. Defaults to admin_status_update. */
+			reason?: string;
+		};
+		CancelOrderItemRequest: {
+			/** @description Units to cancel. Cannot exceed the units still to ship outside a packed parcel. */
+			quantity: number;
+			/** @description Recorded in the order's status history. Defaults to item_quantity_cancelled. */
+			reason?: string;
+		};
+		OrderEditRequest: {
+			/** @description Why the order is being edited, kept with the edit's history. */
+			reason?: string;
+		};
+		OrderEditChangeRequest: {
+			/** @description ADD_ITEM adds quantity units of product_variant_id; SET_QUANTITY sets line_id to quantity, removing it at zero; SWAP_VARIANT replaces line_id's variant with another option of the same product; UPDATE_ADDRESS replaces the shipping address with shipping_data. */
+			kind: string;
+			line_id?: number;
+			product_variant_id?: number;
+			quantity?: number;
+			/** @description Shipping provider fields for the new address, as sent at checkout. */
+			shipping_data?: {
+				[key: string]: string;
+			};
 		};
-		CmsGalleryBlock: {
+		OrderEditLine: {
+			id: number;
+			/** @description The order line this edit line changes; null for an added line. */
+			order_item_id?: number | null;
+			product_variant_id: number;
+			variant_sku: string;
+			variant_title: string;
+			quantity: number;
+			/** Format: double */
+			unit_price: number;
 			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
+			 * Format: double
+			 * @description Discount the campaigns give the whole line.
 			 */
-			type: "CmsGalleryBlock";
-			images: components["schemas"]["CmsGalleryImage"][];
+			discount_amount: number;
 		};
-		CmsGalleryImage: {
-			media_id: string;
-			alt?: string;
-			caption?: string;
+		OrderEditChange: {
+			id: number;
+			/** @description ADD_ITEM, REMOVE_ITEM, SET_QUANTITY, SWAP_VARIANT, UPDATE_ADDRESS, OPEN, COMMIT or CANCEL. */
+			kind: string;
+			summary: string;
+			actor: string;
+			/** Format: date-time */
+			created_at: string;
 		};
-		CmsVideoBlock: {
+		OrderEdit: {
+			id: number;
+			order_id: number;
+			/** @description OPEN, COMMITTED or CANCELLED. */
+			status: string;
+			reason: string;
+			actor: string;
+			shipping_address_pretty: string;
+			currency: string;
+			/** Format: double */
+			subtotal: number;
+			/** Format: double */
+			discount_amount: number;
+			/** Format: double */
+			shipping_amount: number;
+			/** Format: double */
+			tax_amount: number;
+			/** Format: double */
+			total: number;
 			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
+			 * Format: double
+			 * @description The order total when the edit was opened.
 			 */
-			type: "CmsVideoBlock";
-			url: string;
-			title?: string;
-		};
-		CmsFAQBlock: {
+			original_total: number;
 			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
+			 * Format: double
+			 * @description What the customer owes for the edit when positive, or is refunded when negative.
 			 */
-			type: "CmsFAQBlock";
-			items: {
-				question: string;
-				answer: string;
-			}[];
+			balance_due: number;
+			/** @description Provider operation settling the balance; empty until a commit starts one. */
+			payment_operation_key: string;
+			/** Format: date-time */
+			committed_at?: string | null;
+			/** Format: date-time */
+			cancelled_at?: string | null;
+			lines: components["schemas"]["OrderEditLine"][];
+			changes: components["schemas"]["OrderEditChange"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
 		};
-		CmsCTABlock: {
-			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
-			 */
-			type: "CmsCTABlock";
-			label: string;
-			url: string;
-			body?: string;
+		OrderEditList: {
+			items: components["schemas"]["OrderEdit"][];
 		};
-		CmsPromoBannerBlock: {
-			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
-			 */
-			type: "CmsPromoBannerBlock";
-			title: string;
-			body?: string;
-			link?: components["schemas"]["CmsLink"];
+		OrderEditCommitResponse: {
+			message: string;
+			edit: components["schemas"]["OrderEdit"];
+			order: components["schemas"]["Order"];
 		};
-		CmsProductRailBlock: {
+		DraftOrderLineInput: {
+			product_variant_id: number;
+			quantity: number;
 			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
+			 * Format: double
+			 * @description Overrides the variant's current price.
 			 */
-			type: "CmsProductRailBlock";
-			title: string;
-			subtitle?: string;
-			/** @enum {string} */
-			source: "manual" | "newest" | "search" | "category";
-			product_ids?: number[];
-			query?: string;
-			category_slug?: string;
-			/** @enum {string} */
-			sort?: "created_at" | "price" | "name";
-			/** @enum {string} */
-			order?: "asc" | "desc";
-			limit: number;
-			/** @enum {string} */
-			image_aspect?: "square" | "wide";
-		};
-		CmsCategoryTilesBlock: {
+			unit_price?: number;
 			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
+			 * Format: double
+			 * @description Percentage taken off the unit price.
 			 */
-			type: "CmsCategoryTilesBlock";
-			title: string;
-			subtitle?: string;
-			category_slugs: string[];
-			category_media_ids?: {
+			discount_percent?: number;
+		};
+		DraftOrderRequest: {
+			/** @description The customer the order is for. Leave out to use guest_email. */
+			customer_user_id?: number;
+			/** Format: email */
+			guest_email?: string;
+			note?: string;
+			shipping_provider_id: string;
+			/** @description Shipping provider fields, address included, as sent at checkout. */
+			shipping_data?: {
 				[key: string]: string;
 			};
-			/** @enum {string} */
-			image_aspect?: "square" | "wide";
-		};
-		CmsPromotionHighlightBlock: {
-			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
-			 */
-			type: "CmsPromotionHighlightBlock";
-			title: string;
-			body?: string;
-			badge?: string;
-			promotion_code?: string;
-			campaign_id?: number;
-			link?: components["schemas"]["CmsLink"];
-		};
-		CmsInventoryMessageBlock: {
-			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
-			 */
-			type: "CmsInventoryMessageBlock";
-			product_id: number;
-			low_stock_threshold?: number;
-			in_stock_message?: string;
-			low_stock_message?: string;
-			out_of_stock_message?: string;
+			lines: components["schemas"]["DraftOrderLineInput"][];
 		};
-		CmsTestimonialBlock: {
+		DraftOrderLine: {
+			id: number;
+			product_variant_id: number;
+			variant_sku: string;
+			variant_title: string;
+			quantity: number;
 			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
+			 * Format: double
+			 * @description The variant's price when the line was priced.
 			 */
-			type: "CmsTestimonialBlock";
-			quote: string;
-			attribution: string;
-			rating?: number;
-		};
-		CmsSocialEmbedBlock: {
+			list_price: number;
+			/** Format: double */
+			unit_price: number;
+			/** Format: double */
+			discount_percent: number;
 			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
+			 * Format: double
+			 * @description What the customer pays per unit, after the discount.
 			 */
-			type: "CmsSocialEmbedBlock";
-			/** @enum {string} */
-			provider: "instagram" | "tiktok" | "youtube";
-			url: string;
-			title?: string;
+			final_unit_price: number;
 		};
-		CmsCustomHTMLBlock: {
-			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
-			 */
-			type: "CmsCustomHTMLBlock";
-			html: string;
+		DraftOrder: {
+			id: number;
+			/** @description OPEN, INVOICED, COMPLETED or CANCELLED. */
+			status: string;
+			customer_user_id?: number | null;
+			guest_email?: string | null;
+			note: string;
+			created_by: string;
+			shipping_provider_id: string;
+			shipping_data: {
+				[key: string]: string;
+			};
+			shipping_address_pretty: string;
+			currency: string;
+			/** Format: double */
+			subtotal: number;
+			/** Format: double */
+			shipping_amount: number;
+			/** Format: double */
+			tax_amount: number;
+			/** Format: double */
+			total: number;
+			/** Format: date-time */
+			payment_link_expires_at?: string | null;
+			checkout_session_id?: number | null;
+			/** @description The order the draft became once paid. */
+			order_id?: number | null;
+			/** @description The offline method recorded by staff, or the payment provider used through the payment link. */
+			payment_method: string;
+			payment_reference: string;
+			/** Format: date-time */
+			invoiced_at?: string | null;
+			/** Format: date-time */
+			completed_at?: string | null;
+			/** Format: date-time */
+			cancelled_at?: string | null;
+			lines: components["schemas"]["DraftOrderLine"][];
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
 		};
-		CmsFooterColumn: {
-			title: string;
-			links: components["schemas"]["CmsLink"][];
+		DraftOrderListResponse: {
+			data: components["schemas"]["DraftOrder"][];
+			pagination: components["schemas"]["Pagination"];
 		};
-		CmsFooterBlock: {
+		DraftOrderPaymentLinkRequest: {
 			/**
-			 * @description discriminator enum property added by openapi-typescript
-			 * @enum {string}
+			 * Format: date-time
+			 * @description When the link stops working. Defaults to seven days from now.
 			 */
-			type: "CmsFooterBlock";
-			brand_name: string;
-			tagline?: string;
-			columns: components["schemas"]["CmsFooterColumn"][];
-			social_links: components["schemas"]["CmsLink"][];
-			copyright: string;
-			/** @enum {string} */
-			layout: "columns" | "centered" | "minimal";
+			expires_at?: string;
 		};
-		CmsLocale: {
-			code: string;
-			name: string;
-			enabled: boolean;
-			is_default: boolean;
-			fallback_locale?: string | null;
+		DraftOrderPaymentLink: {
+			/** @description Storefront page that opens the draft's checkout. */
+			url: string;
+			/** Format: date-time */
+			expires_at: string;
+			draft_order: components["schemas"]["DraftOrder"];
 		};
-		CmsLocaleInput: {
-			code: string;
-			name: string;
-			enabled: boolean;
-			is_default: boolean;
-			fallback_locale?: string | null;
+		DraftOrderMarkPaidRequest: {
+			/** @description How the customer paid, for example cash, bank_transfer or card_terminal. */
+			payment_method: string;
+			/** @description Receipt or transfer reference, kept with the payment. */
+			payment_reference?: string;
 		};
-		CmsLocaleSettings: {
-			locales: components["schemas"]["CmsLocale"][];
+		OrderTimeline: {
+			order_id: number;
+			events: components["schemas"]["OrderTimelineEvent"][];
 		};
-		CmsLocaleSettingsInput: {
-			locales: components["schemas"]["CmsLocaleInput"][];
+		OrderTimelineEvent: {
+			/** @description order.placed, order.status_changed, payment.<operation> (e.g. payment.capture), provider.operation, shipment.created, shipment.tracking, inventory.movement, inventory.reservation_released, webhook.received, discount.redeemed or comment. */
+			type: string;
+			/** Format: date-time */
+			occurred_at: string;
+			/** @description Table the event was read from. */
+			source: string;
+			source_id: number;
+			/** @description Who caused the event, such as an admin subject, user:<username>, guest:<email> or provider:<id>; empty when unknown. */
+			actor: string;
+			/** @description Ties together events caused by the same request or webhook; empty when none was recorded. */
+			correlation_id: string;
+			summary: string;
+			details: {
+				[key: string]: string;
+			};
 		};
-		CmsPageVariant: {
+		OrderTags: {
+			tags: string[];
+		};
+		OrderNote: {
 			id: number;
-			page_id: number;
-			entry_id: number;
-			locale: string;
-			market: string;
-			path: string;
-			slug: string;
-			title: string;
-			payload: components["schemas"]["CmsPagePayload"];
-			/** @enum {string} */
-			status: "draft" | "in_review" | "changes_requested" | "approved" | "published";
-			revision: number;
-			submitted_by?: string | null;
-			approved_by?: string | null;
+			order_id: number;
+			body: string;
+			author: string;
 			/** Format: date-time */
-			published_at?: string | null;
+			created_at: string;
+		};
+		OrderNoteList: {
+			items: components["schemas"]["OrderNote"][];
+		};
+		OrderNoteRequest: {
+			body: string;
+		};
+		/** @description Order list filters, named as the listAdminOrders query parameters. */
+		OrderViewFilters: {
+			q?: string;
+			email?: string;
+			name?: string;
+			sku?: string;
+			confirmation_token?: string;
+			tracking_number?: string;
+			payment_reference?: string;
+			tag?: string;
+			status?: string;
+			/** @description YYYY-MM-DD. */
+			start_date?: string;
+			/** @description YYYY-MM-DD. */
+			end_date?: string;
+			/** Format: double */
+			min_total?: number;
+			/** Format: double */
+			max_total?: number;
+		};
+		OrderView: {
+			id: number;
+			name: string;
+			filters: components["schemas"]["OrderViewFilters"];
 			/** Format: date-time */
 			created_at: string;
 			/** Format: date-time */
 			updated_at: string;
 		};
-		CmsPageVariantInput: {
-			locale: string;
-			market?: string;
-			path: string;
-			slug?: string;
-			title: string;
-			payload: components["schemas"]["CmsPagePayload"];
-			change_summary?: string;
-		};
-		CmsWorkflowActionInput: {
-			comment?: string;
+		OrderViewList: {
+			items: components["schemas"]["OrderView"][];
 		};
-		CmsRoleAssignment: {
-			subject: string;
-			/** @enum {string} */
-			role: "author" | "editor" | "publisher";
+		OrderViewRequest: {
+			name: string;
+			filters: components["schemas"]["OrderViewFilters"];
 		};
-		CmsGovernance: {
-			approval_required: boolean;
-			invalidation_webhook_url: string;
-			roles: components["schemas"]["CmsRoleAssignment"][];
+		LegalEntity: {
+			id: number;
+			code: string;
+			name: string;
+			/** @description Postal address printed on documents, one line per row. */
+			address: string;
+			/** @description VAT or other tax registration number printed on documents. */
+			tax_id: string;
+			/** @description ISO 3166-1 alpha-2 country whose orders this entity invoices; empty for a default-only entity. */
+			country: string;
+			/** @description Invoices orders shipping to countries no other entity covers. */
+			is_default: boolean;
+			invoice_prefix: string;
+			credit_note_prefix: string;
+			/** @description Sequence number the next invoice will take. */
+			next_invoice_number: number;
+			next_credit_note_number: number;
+			/** Format: date-time */
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
 		};
-		CmsGovernanceInput: {
-			approval_required: boolean;
-			invalidation_webhook_url: string;
-			roles: components["schemas"]["CmsRoleAssignment"][];
+		LegalEntityList: {
+			items: components["schemas"]["LegalEntity"][];
 		};
-		CmsChangeComment: {
+		LegalEntityRequest: {
+			/** @description Required when creating; ignored on update. */
+			code?: string;
+			name: string;
+			address?: string;
+			tax_id?: string;
+			country?: string;
+			is_default?: boolean;
+			/** @description Defaults to INV-. */
+			invoice_prefix?: string;
+			/** @description Defaults to CN-. */
+			credit_note_prefix?: string;
+		};
+		OrderDocument: {
 			id: number;
-			entry_id: number;
-			variant_id?: number | null;
-			actor: string;
-			body: string;
-			resolved_by?: string | null;
-			/** Format: date-time */
-			resolved_at?: string | null;
+			order_id: number;
+			/** @description INVOICE, PACKING_SLIP or CREDIT_NOTE. */
+			type: string;
+			number: string;
+			legal_entity_id?: number;
+			fulfillment_order_id?: number;
+			/** @description Refund transaction a credit note records. */
+			payment_transaction_id?: number;
+			/** @description Gift card a credit note records when store credit was issued in place of a refund. */
+			gift_card_id?: number;
+			/** @description Invoice a credit note corrects. */
+			invoice_id?: number;
+			currency: string;
+			/**
+			 * Format: double
+			 * @description Invoiced or credited amount; 0 for packing slips.
+			 */
+			total: number;
+			/** @description SHA-256 of the PDF as issued. */
+			checksum: string;
+			issued_by: string;
 			/** Format: date-time */
-			created_at: string;
+			issued_at: string;
 		};
-		CmsCommentInput: {
-			body: string;
+		OrderDocumentList: {
+			items: components["schemas"]["OrderDocument"][];
 		};
-		CmsEntryWorkflow: {
-			entry_id: number;
-			version_id: number;
+		OrderDocumentRequest: {
 			/** @enum {string} */
-			status: "draft" | "in_review" | "changes_requested" | "approved";
-			submitted_by?: string | null;
-			approved_by?: string | null;
-			comments: components["schemas"]["CmsChangeComment"][];
+			type: "INVOICE" | "PACKING_SLIP" | "CREDIT_NOTE";
+			/** @description Required for a packing slip. */
+			fulfillment_order_id?: number;
+			/** @description Refund transaction; required for a credit note. */
+			payment_transaction_id?: number;
+		};
+		DraftOrderPaidResponse: {
+			draft_order: components["schemas"]["DraftOrder"];
+			order: components["schemas"]["Order"];
 		};
-		CmsEntryVariant: {
+		SavedPaymentMethod: {
 			id: number;
-			entry_id: number;
-			locale: string;
-			market: string;
-			payload: {
-				[key: string]: unknown;
-			};
-			/** @enum {string} */
-			status: "draft" | "in_review" | "changes_requested" | "approved" | "published";
-			revision: number;
-			submitted_by?: string | null;
-			approved_by?: string | null;
+			user_id: number;
+			type: string;
+			brand: string;
+			last4: string;
+			exp_month: number;
+			exp_year: number;
+			cardholder_name: string;
+			nickname: string;
+			is_default: boolean;
 			/** Format: date-time */
-			published_at?: string | null;
+			created_at: string;
+			/** Format: date-time */
+			updated_at: string;
+			/** Format: date-time */
+			deleted_at?: string | null;
+		};
+		CreateSavedPaymentMethodRequest: {
+			cardholder_name: string;
+			card_number: string;
+			exp_month: number;
+			exp_year: number;
+			nickname?: string;
+			set_default?: boolean;
+		};
+		SavedAddress: {
+			id: number;
+			user_id: number;
+			label: string;
+			full_name: string;
+			line1: string;
+			line2: string;
+			city: string;
+			state: string;
+			postal_code: string;
+			country: string;
+			phone: string;
+			is_default: boolean;
 			/** Format: date-time */
 			created_at: string;
 			/** Format: date-time */
 			updated_at: string;
+			/** Format: date-time */
+			deleted_at?: string | null;
 		};
-		CmsEntryVariantInput: {
-			locale: string;
-			market?: string;
-			payload: {
-				[key: string]: unknown;
+		CreateSavedAddressRequest: {
+			label?: string;
+			full_name: string;
+			line1: string;
+			line2?: string;
+			city: string;
+			state?: string;
+			postal_code: string;
+			country: string;
+			phone?: string;
+			set_default?: boolean;
+		};
+		UserPage: {
+			data: components["schemas"]["User"][];
+			pagination: components["schemas"]["Pagination"];
+		};
+		UpdateUserRoleRequest: {
+			/** @enum {string} */
+			role: "admin" | "customer";
+		};
+		UpdateRelatedRequest: {
+			related_ids: number[];
+		};
+		MediaIDsRequest: {
+			media_ids: string[];
+		};
+		WebsiteSettings: {
+			site_title: string;
+			allow_guest_checkout: boolean;
+			/** @description Controls whether coupon-code-gated campaigns can be applied. */
+			coupon_codes_enabled: boolean;
+			oidc_provider: string;
+			oidc_client_id: string;
+			/** @description Write-only client secret. Responses always return an empty string. */
+			oidc_client_secret: string;
+			/** @description True when an encrypted OIDC client secret is stored. */
+			oidc_client_secret_configured: boolean;
+			/** @description Set true on update to remove the stored OIDC client secret. */
+			clear_oidc_client_secret: boolean;
+			oidc_redirect_uri: string;
+		};
+		WebsiteSettingsRequest: {
+			settings: components["schemas"]["WebsiteSettings"];
+		};
+		WebsiteSettingsResponse: {
+			settings: components["schemas"]["WebsiteSettings"];
+			/** Format: date-time */
+			updated_at: string;
+		};
+	};
+	responses: {
+		/** @description The request is malformed or does not satisfy the operation contract. */
+		BadRequestProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
+		};
+		/** @description Authentication is required or the supplied credentials are invalid. */
+		AuthenticationRequiredProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
 			};
-			change_summary?: string;
 		};
-		CmsInvalidationEvent: {
-			id: number;
-			entry_id: number;
-			variant_id?: number | null;
-			reason: string;
-			/** @enum {string} */
-			status: "pending" | "sent" | "failed";
-			attempts: number;
-			last_error: string;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			sent_at?: string | null;
+		/** @description The authenticated principal is not allowed to perform this operation. */
+		ForbiddenProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsOperations: {
-			pending_schedules: number;
-			active_experiments: number;
-			invalidations: components["schemas"]["CmsInvalidationEvent"][];
+		/** @description The requested resource does not exist or is not visible to the caller. */
+		NotFoundProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsRestorePreview: {
-			valid: boolean;
-			schema_version: number;
-			pages: number;
-			navigation: number;
-			global_regions: number;
-			variants: number;
-			warnings: string[];
-			errors: string[];
+		/** @description The request conflicts with resource state, version, or idempotency history. */
+		ConflictProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsAuditEvent: {
-			id: number;
-			entry_id: number;
-			version_id?: number | null;
-			variant_id?: number | null;
-			action: string;
-			actor: string;
-			detail: string;
-			/** Format: date-time */
-			created_at: string;
+		/** @description A required capability or configuration is unavailable. */
+		PreconditionFailedProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsResolvedLocalization: {
-			requested_locale: string;
-			resolved_locale: string;
-			market: string;
-			used_fallback: boolean;
-			alternates: {
-				locale: string;
-				market?: string;
-				path: string;
-			}[];
+		/** @description The request is well-formed but fails semantic validation. */
+		ValidationProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsContentExport: {
-			schema_version: number;
-			/** Format: date-time */
-			exported_at: string;
-			locales: components["schemas"]["CmsLocale"][];
-			pages: components["schemas"]["CmsPageResponse"][];
-			navigation: components["schemas"]["CmsNavigationResponse"][];
-			global_regions: components["schemas"]["CmsGlobalRegionResponse"][];
-			variants: components["schemas"]["CmsPageVariant"][];
+		/** @description The request payload exceeds the operation's size limit. */
+		PayloadTooLargeProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsPublishRequest: {
-			notes?: string;
+		/** @description The caller has exceeded an applicable request limit. */
+		TooManyRequestsProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsPreviewRequest: {
-			payload: components["schemas"]["CmsPagePayload"];
+		/** @description An unexpected internal error occurred. */
+		InternalServerErrorProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsPreviewBlock: {
-			key: string;
-			type: string;
-			/** @enum {string} */
-			status: "ok" | "degraded" | "static";
-			item_count: number;
-			messages: string[];
+		/** @description An upstream service returned an invalid or unsuccessful response. */
+		BadGatewayProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsPreviewResponse: {
-			blocks: components["schemas"]["CmsPreviewBlock"][];
+		/** @description A required service or capability is temporarily unavailable. */
+		ServiceUnavailableProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsRollbackRequest: {
-			version_id: number;
-			notes?: string;
+		/** @description A required upstream operation did not complete before its deadline. */
+		GatewayTimeoutProblem: {
+			headers: {
+				[name: string]: unknown;
+			};
+			content: {
+				"application/problem+json": components["schemas"]["Problem"];
+			};
 		};
-		CmsSchedule: {
-			id: number;
-			entry_id: number;
-			version_id: number;
-			/** Format: date-time */
-			publish_at: string;
-			/** Format: date-time */
-			unpublish_at?: string | null;
-			timezone: string;
-			/** @enum {string} */
-			status: "pending" | "active" | "completed" | "cancelled";
-			/** Format: date-time */
-			last_transition_at?: string | null;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+	};
+	parameters: never;
+	requestBodies: never;
+	headers: never;
+	pathItems: never;
+}
+export type $defs = Record<string, never>;
+export interface operations {
+	register: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CmsScheduleInput: {
-			/** Format: date-time */
-			publish_at: string;
-			/** Format: date-time */
-			unpublish_at?: string | null;
-			timezone: string;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["RegisterRequest"];
+			};
 		};
-		CmsTargetingRule: {
-			id: number;
-			priority: number;
-			is_enabled: boolean;
-			markets: string[];
-			device_classes: ("desktop" | "mobile" | "tablet")[];
-			auth_states: ("guest" | "authenticated")[];
-			referrers: string[];
-			utm_sources: string[];
-			segment_keys: string[];
+		responses: {
+			/** @description Registered */
+			201: {
+				headers: {
+					/** @description Session and CSRF cookies. */
+					"Set-Cookie"?: string[];
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["AuthResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CmsTargetingRuleInput: {
-			priority: number;
-			is_enabled: boolean;
-			markets: string[];
-			device_classes: ("desktop" | "mobile" | "tablet")[];
-			auth_states: ("guest" | "authenticated")[];
-			referrers: string[];
-			utm_sources: string[];
-			segment_keys: string[];
+	};
+	login: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CmsExperimentVariant: {
-			id: number;
-			experiment_id: number;
-			name: string;
-			version_id: number;
-			allocation: number;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["LoginRequest"];
+			};
+		};
+		responses: {
+			/** @description Authenticated */
+			200: {
+				headers: {
+					/** @description Session and CSRF cookies. */
+					"Set-Cookie"?: string[];
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["AuthResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CmsExperimentVariantInput: {
-			name: string;
-			version_id: number;
-			allocation: number;
+	};
+	logout: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CmsExperiment: {
-			id: number;
-			entry_id: number;
-			name: string;
-			/** @enum {string} */
-			status: "draft" | "active" | "paused" | "completed";
-			/** @enum {string} */
-			sticky_key: "visitor" | "customer";
-			/** Format: date-time */
-			starts_at: string;
-			/** Format: date-time */
-			ends_at?: string | null;
-			variants: components["schemas"]["CmsExperimentVariant"][];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+		requestBody?: never;
+		responses: {
+			/** @description Logged out */
+			200: {
+				headers: {
+					/** @description Expired session and CSRF cookies. */
+					"Set-Cookie"?: string[];
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["MessageResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CmsExperimentInput: {
-			name: string;
-			/** @enum {string} */
-			status: "draft" | "active" | "paused" | "completed";
-			/** @enum {string} */
-			sticky_key: "visitor" | "customer";
-			/** Format: date-time */
-			starts_at: string;
-			/** Format: date-time */
-			ends_at?: string | null;
-			variants: components["schemas"]["CmsExperimentVariantInput"][];
+	};
+	getAuthConfig: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CmsPageDeliveryRequest: {
-			schedule?: components["schemas"]["CmsScheduleInput"];
-			targeting_rules: components["schemas"]["CmsTargetingRuleInput"][];
-			experiment?: components["schemas"]["CmsExperimentInput"];
+		requestBody?: never;
+		responses: {
+			/** @description Authentication configuration */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["AuthConfigResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CmsPageDeliveryResponse: {
-			schedule?: components["schemas"]["CmsSchedule"];
-			targeting_rules: components["schemas"]["CmsTargetingRule"][];
-			experiment?: components["schemas"]["CmsExperiment"];
-			recent_publications: components["schemas"]["CmsPublication"][];
+	};
+	oidcLogin: {
+		parameters: {
+			query?: {
+				redirect?: string;
+				response_format?: "json";
+			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CmsDeliveryDecision: {
-			content_version_id: number;
-			experiment_id?: number | null;
-			experiment_variant_id?: number | null;
-			correlation_id: string;
+		requestBody?: never;
+		responses: {
+			/** @description Redirect to provider */
+			302: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content?: never;
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CmsContentEventRequest: {
-			content_version_id: number;
-			experiment_id?: number | null;
-			experiment_variant_id?: number | null;
-			correlation_id: string;
-			/** @enum {string} */
-			event_type: "impression" | "conversion";
+	};
+	oidcCallback: {
+		parameters: {
+			query?: {
+				code?: string;
+				state?: string;
+				format?: "json";
+			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CmsSEOMetadata: {
-			title: string;
-			description: string;
-			canonical_url: string;
-			/** @enum {string} */
-			robots: "index_follow" | "noindex_follow" | "index_nofollow" | "noindex_nofollow";
-			og_title: string;
-			og_description: string;
-			og_image_media_id?: string | null;
-			/** @enum {string} */
-			twitter_card: "summary" | "summary_large_image";
-			twitter_title: string;
-			twitter_description: string;
-			twitter_image_media_id?: string | null;
-			json_ld: {
-				[key: string]: unknown;
-			}[];
+		requestBody?: never;
+		responses: {
+			/** @description Callback as JSON */
+			200: {
+				headers: {
+					/** @description Session and CSRF cookies. */
+					"Set-Cookie"?: string[];
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["AuthResponse"];
+				};
+			};
+			/** @description Callback as redirect */
+			302: {
+				headers: {
+					/** @description Session and CSRF cookies. */
+					"Set-Cookie"?: string[];
+					[name: string]: unknown;
+				};
+				content?: never;
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CmsSEOInput: {
-			title: string;
-			description: string;
-			canonical_url: string;
-			/** @enum {string} */
-			robots: "index_follow" | "noindex_follow" | "index_nofollow" | "noindex_nofollow";
-			og_title: string;
-			og_description: string;
-			og_image_media_id?: string | null;
-			/** @enum {string} */
-			twitter_card: "summary" | "summary_large_image";
-			twitter_title: string;
-			twitter_description: string;
-			twitter_image_media_id?: string | null;
-			json_ld: {
-				[key: string]: unknown;
-			}[];
+	};
+	listProducts: {
+		parameters: {
+			query?: {
+				q?: string;
+				min_price?: number;
+				max_price?: number;
+				brand_slug?: string;
+				category_slug?: string[];
+				has_variant_stock?: boolean;
+				attribute?: {
+					[key: string]: string;
+				};
+				sort?: "price" | "name" | "created_at";
+				order?: "asc" | "desc";
+				page?: number;
+				limit?: number;
+			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CmsSEOResponse: {
-			metadata: components["schemas"]["CmsSEOMetadata"];
-			issues: string[];
+		requestBody?: never;
+		responses: {
+			/** @description Paginated products */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ProductPage"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CmsRedirectRule: {
-			id: number;
-			source_pattern: string;
-			/** @enum {string} */
-			match_type: "exact" | "prefix";
-			target_url: string;
-			/** @enum {integer} */
-			redirect_type: 301 | 302;
-			priority: number;
-			is_enabled: boolean;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+	};
+	listBrands: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CmsRedirectInput: {
-			source_pattern: string;
-			/** @enum {string} */
-			match_type: "exact" | "prefix";
-			target_url: string;
-			/** @enum {integer} */
-			redirect_type: 301 | 302;
-			priority: number;
-			is_enabled: boolean;
+		requestBody?: never;
+		responses: {
+			/** @description Active brands */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["BrandListResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CmsRedirectResolution: {
-			target_url: string;
-			/** @enum {integer} */
-			redirect_type: 301 | 302;
+	};
+	listCategories: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		DraftPreviewSessionResponse: {
-			active: boolean;
-			/** Format: date-time */
-			expires_at?: string | null;
+		requestBody?: never;
+		responses: {
+			/** @description Active categories */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CategoryListResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		Pagination: {
-			page: number;
-			limit: number;
-			total: number;
-			total_pages: number;
+	};
+	listProductAttributes: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		User: {
-			id: number;
-			subject: string;
-			username: string;
-			email: string;
-			name: string | null;
-			profile_photo_url: string | null;
-			/** @enum {string} */
-			role: "admin" | "customer";
-			currency: string;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			deleted_at?: string | null;
+		requestBody?: never;
+		responses: {
+			/** @description Filterable product attribute definitions */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ProductAttributeDefinitionListResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		RegisterRequest: {
-			username: string;
-			email: string;
-			password: string;
-			name?: string;
+	};
+	getProduct: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		LoginRequest: {
-			email: string;
-			password: string;
+		requestBody?: never;
+		responses: {
+			/** @description Product */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Product"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		AuthResponse: {
-			token?: string;
-			user: components["schemas"]["User"];
+	};
+	resolveContentHomepage: {
+		parameters: {
+			query?: {
+				market?: string;
+				locale?: string;
+				device?: "desktop" | "mobile" | "tablet";
+				segment?: string;
+				utm_source?: string;
+				assignment_key?: string;
+			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		AuthConfigResponse: {
-			local_sign_in_enabled: boolean;
-			oidc_enabled: boolean;
+		requestBody?: never;
+		responses: {
+			/** @description Published CMS homepage */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CmsPageResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		UpdateProfileRequest: {
-			name?: string;
-			currency?: string;
-			profile_photo_url?: string;
+	};
+	resolveContentPage: {
+		parameters: {
+			query?: {
+				market?: string;
+				locale?: string;
+				device?: "desktop" | "mobile" | "tablet";
+				segment?: string;
+				utm_source?: string;
+				assignment_key?: string;
+			};
+			header?: never;
+			path: {
+				path: string;
+			};
+			cookie?: never;
 		};
-		Brand: {
-			id: number;
-			name: string;
-			slug: string;
-			description?: string | null;
-			logo_url?: string | null;
-			is_active: boolean;
+		requestBody?: never;
+		responses: {
+			/** @description Published CMS page */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CmsPageResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		BrandListResponse: {
-			data: components["schemas"]["Brand"][];
+	};
+	recordContentEvent: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		BrandInput: {
-			name: string;
-			slug?: string | null;
-			description?: string | null;
-			logo?: components["schemas"]["BrandLogoInput"];
-			is_active?: boolean;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CmsContentEventRequest"];
+			};
 		};
-		BrandLogoInput: {
-			media_id: string;
+		responses: {
+			/** @description Content event accepted */
+			202: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["MessageResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		Category: {
-			id: number;
-			name: string;
-			slug: string;
-			description?: string | null;
-			is_active: boolean;
-			sort_order: number;
-			parent_id?: number | null;
-			path: string;
-			depth: number;
+	};
+	resolveContentRedirect: {
+		parameters: {
+			query: {
+				path: string;
+			};
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Matching redirect */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CmsRedirectResolution"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CategoryListResponse: {
-			data: components["schemas"]["Category"][];
+	};
+	getContentSitemap: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CategoryInput: {
-			name: string;
-			slug?: string | null;
-			description?: string | null;
-			is_active?: boolean;
-			sort_order?: number;
-			parent_id?: number | null;
+		requestBody?: never;
+		responses: {
+			/** @description Storefront sitemap */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/xml": string;
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProductAttributeDefinition: {
-			id: number;
-			key: string;
-			slug: string;
-			/** @enum {string} */
-			type: "text" | "number" | "boolean" | "enum";
-			filterable: boolean;
-			sortable: boolean;
-			enum_values: string[];
+	};
+	getContentNavigation: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				location: string;
+			};
+			cookie?: never;
 		};
-		ProductAttributeDefinitionInput: {
-			key: string;
-			slug?: string | null;
-			/** @enum {string} */
-			type: "text" | "number" | "boolean" | "enum";
-			filterable?: boolean;
-			sortable?: boolean;
-			enum_values?: string[];
+		requestBody?: never;
+		responses: {
+			/** @description Published CMS navigation menu */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CmsNavigationResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProductAttributeDefinitionListResponse: {
-			data: components["schemas"]["ProductAttributeDefinition"][];
+	};
+	getContentGlobalRegion: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				region: string;
+			};
+			cookie?: never;
 		};
-		ProductOptionValue: {
-			id?: number;
-			value: string;
-			position: number;
+		requestBody?: never;
+		responses: {
+			/** @description Published CMS global region */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CmsGlobalRegionResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProductOption: {
-			id?: number;
-			name: string;
-			position: number;
-			display_type: string;
-			values: components["schemas"]["ProductOptionValue"][];
+	};
+	getProfile: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProductVariantSelection: {
-			product_option_value_id?: number;
-			option_name: string;
-			option_value: string;
-			position: number;
+		requestBody?: never;
+		responses: {
+			/** @description Profile */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["User"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProductVariant: {
-			id?: number;
-			sku: string;
-			title: string;
-			/** Format: double */
-			price: number;
-			/** Format: double */
-			compare_at_price?: number | null;
-			stock: number;
-			position: number;
-			is_published: boolean;
-			weight_grams?: number | null;
-			/** Format: double */
-			length_cm?: number | null;
-			/** Format: double */
-			width_cm?: number | null;
-			/** Format: double */
-			height_cm?: number | null;
-			selections: components["schemas"]["ProductVariantSelection"][];
+	};
+	updateProfile: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProductAttributeValue: {
-			product_attribute_id: number;
-			key: string;
-			slug: string;
-			type: string;
-			text_value?: string | null;
-			/** Format: double */
-			number_value?: number | null;
-			boolean_value?: boolean | null;
-			enum_value?: string | null;
-			position: number;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["UpdateProfileRequest"];
+			};
 		};
-		ProductSEO: {
-			title?: string | null;
-			description?: string | null;
-			canonical_path?: string | null;
-			og_image_media_id?: string | null;
-			noindex?: boolean;
+		responses: {
+			/** @description Updated profile */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["User"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProductPriceRange: {
-			/** Format: double */
-			min: number;
-			/** Format: double */
-			max: number;
+	};
+	setProfilePhoto: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		AppliedCampaign: {
-			id: number;
-			level_id?: number | null;
-			name: string;
-			/** Format: double */
-			discount_amount: number;
+		requestBody: {
+			content: {
+				"application/json": {
+					media_id: string;
+				};
+			};
 		};
-		PriceBreakdown: {
-			/** Format: double */
-			base_price: number;
-			/** Format: double */
-			discount_amount: number;
-			/** Format: double */
-			final_price: number;
-			applied_campaigns: components["schemas"]["AppliedCampaign"][];
+		responses: {
+			/** @description Updated profile */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["User"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
+			413: components["responses"]["PayloadTooLargeProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	deleteProfilePhoto: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		DiscountTarget: {
-			id: number;
-			/** @enum {string} */
-			target_type: "product" | "variant" | "category" | "brand";
-			target_id: number;
+		requestBody?: never;
+		responses: {
+			/** @description Updated profile */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["User"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PromotionCondition: {
-			product_ids?: number[];
-			product_variant_ids?: number[];
-			category_ids?: number[];
-			brand_ids?: number[];
-			min_quantity?: number;
-			/** Format: double */
-			min_subtotal?: number;
+	};
+	listSavedPaymentMethods: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		PromotionAction: {
-			/** @enum {string} */
-			mode: "percent" | "fixed" | "fixed_price" | "free_item";
-			/** Format: double */
-			value?: number;
-			/** @enum {string} */
-			target_type?: "cart" | "product" | "variant" | "category" | "brand";
-			target_ids?: number[];
-			product_ids?: number[];
-			product_variant_ids?: number[];
-			category_ids?: number[];
-			brand_ids?: number[];
-			sku?: string;
+		requestBody?: never;
+		responses: {
+			/** @description Saved payment methods */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedPaymentMethod"][];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PromotionRuleInput: {
-			condition: components["schemas"]["PromotionCondition"];
-			action: components["schemas"]["PromotionAction"];
-			/** @enum {string} */
-			stack_policy?: "none" | "additive";
-			max_applications_per_order?: number | null;
+	};
+	createSavedPaymentMethod: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		PromotionTargetInput: {
-			/** @enum {string} */
-			target_type: "product" | "variant" | "category" | "brand";
-			target_id: number;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CreateSavedPaymentMethodRequest"];
+			};
 		};
-		PromotionLevelInput: {
-			name: string;
-			/** @default 0 */
-			priority: number;
-			action: components["schemas"]["PromotionAction"];
-			/** @enum {string} */
-			stack_policy?: "none" | "additive";
-			max_applications_per_order?: number | null;
-			targets: components["schemas"]["PromotionTargetInput"][];
+		responses: {
+			/** @description Created payment method */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedPaymentMethod"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PromotionInput: {
-			name: string;
-			/** Format: date-time */
-			starts_at: string;
-			/** Format: date-time */
-			ends_at?: string | null;
-			/** @default 0 */
-			priority: number;
-			/** @default false */
-			is_exclusive: boolean;
-			/** @enum {string} */
-			status?: "active" | "scheduled" | "disabled";
-			metadata?: {
-				[key: string]: unknown;
+	};
+	deleteSavedPaymentMethod: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
 			};
-			coupon_code?: string | null;
-			channels?: ("web" | "app" | "admin")[];
-			customer_segment?: string;
-			global_usage_cap?: number | null;
-			per_customer_usage_cap?: number | null;
-			rules?: components["schemas"]["PromotionRuleInput"][];
-			levels?: components["schemas"]["PromotionLevelInput"][];
+			cookie?: never;
 		};
-		PromotionEvaluationLine: {
-			product_id: number;
-			product_variant_id: number;
-			quantity: number;
-			/** Format: double */
-			base_price: number;
-			/** Format: double */
-			discount_amount: number;
-			/** Format: double */
-			final_price: number;
-			applied_campaigns: components["schemas"]["AppliedCampaign"][];
+		requestBody?: never;
+		responses: {
+			/** @description Deleted */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["MessageResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PromotionEvaluationRequestLine: {
-			product_id: number;
-			product_variant_id: number;
-			brand_id?: number | null;
-			category_ids?: number[];
-			sku?: string;
-			quantity: number;
-			/** Format: double */
-			unit_price: number;
+	};
+	setDefaultPaymentMethod: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		PromotionEvaluationRequest: {
-			coupon_code?: string;
-			/** @enum {string} */
-			channel?: "web" | "app" | "admin";
-			customer_segment?: string;
-			lines: components["schemas"]["PromotionEvaluationRequestLine"][];
+		requestBody?: never;
+		responses: {
+			/** @description Updated payment method */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedPaymentMethod"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PromotionTemplateInput: {
-			name: string;
-			description?: string;
-			template: components["schemas"]["PromotionInput"];
-			is_active?: boolean | null;
+	};
+	listMyGiftCards: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		PromotionTemplateInstantiateInput: {
-			name?: string | null;
-			/** Format: date-time */
-			starts_at?: string | null;
-			/** Format: date-time */
-			ends_at?: string | null;
-			coupon_code?: string | null;
-			channels?: ("web" | "app" | "admin")[];
-			customer_segment?: string | null;
-			global_usage_cap?: number | null;
-			per_customer_usage_cap?: number | null;
+		requestBody?: never;
+		responses: {
+			/** @description Gift cards and store credit issued to the current user */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["GiftCard"][];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PromotionTemplate: {
-			id: number;
-			name: string;
-			description: string;
-			template_json: string;
-			template: {
-				[key: string]: unknown;
+	};
+	listMySavedCarts: {
+		parameters: {
+			query?: {
+				status?: string;
+				page?: number;
+				limit?: number;
 			};
-			is_active: boolean;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		PromotionTemplateListResponse: {
-			templates: components["schemas"]["PromotionTemplate"][];
+		requestBody?: never;
+		responses: {
+			/** @description Saved carts */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedCartListResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PromotionEvaluationResponse: {
-			/** Format: double */
-			subtotal: number;
-			/** Format: double */
-			discount_total: number;
-			/** Format: double */
-			final_subtotal: number;
-			lines: components["schemas"]["PromotionEvaluationLine"][];
+	};
+	saveMyCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProductDiscountInput: {
-			name: string;
-			product_ids: number[];
-			/** @enum {string} */
-			discount_mode: "percent" | "fixed";
-			/** Format: double */
-			discount_value: number;
-			/** Format: date-time */
-			starts_at: string;
-			/** Format: date-time */
-			ends_at?: string | null;
-			/** @default 0 */
-			priority: number;
-			/** @default false */
-			is_exclusive: boolean;
-			/** @enum {string} */
-			status?: "active" | "disabled";
-			metadata?: {
-				[key: string]: unknown;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["SavedCartSaveRequest"];
 			};
-			coupon_code?: string | null;
-			channels?: ("web" | "app" | "admin")[];
-			customer_segment?: string;
-			global_usage_cap?: number | null;
-			per_customer_usage_cap?: number | null;
 		};
-		DiscountCampaign: {
-			id: number;
-			name: string;
-			/** @enum {string} */
-			type: "product_discount" | "promotion";
-			/** @enum {string} */
-			status: "active" | "scheduled" | "disabled" | "archived";
-			/** Format: date-time */
-			starts_at: string;
-			/** Format: date-time */
-			ends_at?: string | null;
-			/** @enum {string} */
-			discount_mode: "percent" | "fixed";
-			/** Format: double */
-			discount_value: number;
-			priority: number;
-			is_exclusive: boolean;
-			metadata?: {
-				[key: string]: unknown;
+		responses: {
+			/** @description Saved cart */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedCart"];
+				};
 			};
-			coupon_code?: string | null;
-			channels?: ("web" | "app" | "admin")[];
-			customer_segment?: string | null;
-			global_usage_cap?: number | null;
-			per_customer_usage_cap?: number | null;
-			targets: components["schemas"]["DiscountTarget"][];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		DiscountCampaignListResponse: {
-			campaigns: components["schemas"]["DiscountCampaign"][];
+	};
+	archiveMySavedCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		DiscountScheduleInput: {
-			/** @enum {string} */
-			schedule_type: "one_time" | "recurring";
-			/** @enum {string} */
-			recurrence?: "daily" | "weekly" | "monthly";
-			/** Format: date-time */
-			window_start: string;
-			/** Format: date-time */
-			window_end: string;
-			/** Format: date-time */
-			until_at?: string | null;
-			timezone?: string;
+		requestBody?: never;
+		responses: {
+			/** @description Archived saved cart */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedCart"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		DiscountSchedule: {
-			id: number;
-			campaign_id: number;
-			/** @enum {string} */
-			schedule_type: "one_time" | "recurring";
-			recurrence?: string | null;
-			/** Format: date-time */
-			window_start: string;
-			/** Format: date-time */
-			window_end: string;
-			/** Format: date-time */
-			until_at?: string | null;
-			timezone: string;
-			/** Format: date-time */
-			last_run_at?: string | null;
-			/** Format: date-time */
-			next_run_at?: string | null;
+	};
+	listSavedAddresses: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		DiscountLifecycleRunResponse: {
-			activated: number;
-			deactivated: number;
-			archived: number;
+		requestBody?: never;
+		responses: {
+			/** @description Saved addresses */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedAddress"][];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		DiscountStateHistory: {
-			id: number;
-			campaign_id: number;
-			from_status: string;
-			to_status: string;
-			reason: string;
-			source: string;
-			actor: string;
-			/** Format: date-time */
-			changed_at: string;
+	};
+	createSavedAddress: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		DiscountStateHistoryListResponse: {
-			history: components["schemas"]["DiscountStateHistory"][];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CreateSavedAddressRequest"];
+			};
 		};
-		DiscountCampaignAudit: {
-			id: number;
-			campaign_id: number;
-			event_type: string;
-			source: string;
-			actor: string;
-			summary: string;
-			before_json: string;
-			after_json: string;
-			/** Format: date-time */
-			changed_at: string;
+		responses: {
+			/** @description Created address */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedAddress"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		DiscountCampaignAuditListResponse: {
-			audit: components["schemas"]["DiscountCampaignAudit"][];
+	};
+	deleteSavedAddress: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		DiscountEvaluationMetrics: {
-			/** Format: int64 */
-			total_evaluations: number;
-			/** Format: int64 */
-			failed_evaluations: number;
-			/** Format: int64 */
-			matched_evaluations: number;
-			/** Format: int64 */
-			total_latency_ms: number;
-			/** Format: int64 */
-			last_latency_ms: number;
-			/** Format: int64 */
-			last_line_count: number;
-			/** Format: int64 */
-			last_candidate_campaigns: number;
-			/** Format: int64 */
-			last_matched_campaigns: number;
-			/** Format: date-time */
-			last_evaluated_at?: string | null;
-			last_error: string;
+		requestBody?: never;
+		responses: {
+			/** @description Deleted */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["MessageResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		DiscountReconciliationIssue: {
-			campaign_id: number;
-			schedule_id: number;
-			expected_status: string;
-			actual_status: string;
-			/** Format: date-time */
-			expected_start?: string;
-			/** Format: date-time */
-			actual_start?: string;
-			/** Format: date-time */
-			expected_end?: string | null;
-			/** Format: date-time */
-			actual_end?: string | null;
-			message: string;
+	};
+	setDefaultAddress: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		DiscountReconciliationReport: {
-			/** Format: date-time */
-			checked_at: string;
-			issues: components["schemas"]["DiscountReconciliationIssue"][];
+		requestBody?: never;
+		responses: {
+			/** @description Updated address */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedAddress"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		Product: {
-			id: number;
-			sku: string;
-			name: string;
-			subtitle?: string | null;
-			description: string;
-			/** Format: double */
-			price: number;
-			/** Format: double */
-			base_price?: number;
-			/** Format: double */
-			discount_amount?: number;
-			/** Format: double */
-			final_price?: number;
-			applied_campaigns?: components["schemas"]["AppliedCampaign"][];
-			price_breakdown?: components["schemas"]["PriceBreakdown"];
-			stock: number;
-			images: string[];
-			cover_image?: string | null;
-			brand?: components["schemas"]["Brand"];
-			default_variant_id?: number | null;
-			default_variant_sku?: string | null;
-			price_range: components["schemas"]["ProductPriceRange"];
-			options: components["schemas"]["ProductOption"][];
-			variants: components["schemas"]["ProductVariant"][];
-			attributes: components["schemas"]["ProductAttributeValue"][];
-			seo: components["schemas"]["ProductSEO"];
-			related_products: components["schemas"]["RelatedProduct"][];
-			categories: components["schemas"]["Category"][];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			deleted_at?: string | null;
-			is_published?: boolean;
-			has_draft_changes?: boolean;
-			/** Format: date-time */
-			draft_updated_at?: string | null;
+	};
+	getCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		RelatedProduct: {
-			id: number;
-			sku: string;
-			name: string;
-			description: string | null;
-			/** Format: double */
-			price?: number;
-			stock: number;
-			cover_image?: string | null;
+		requestBody?: never;
+		responses: {
+			/** @description Cart */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Cart"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProductOptionValueInput: {
-			value: string;
-			position?: number;
+	};
+	addCartItem: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProductOptionInput: {
-			name: string;
-			position?: number;
-			display_type?: string;
-			values: components["schemas"]["ProductOptionValueInput"][];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["AddCartItemRequest"];
+			};
 		};
-		ProductVariantSelectionInput: {
-			option_name: string;
-			option_value: string;
-			position?: number;
+		responses: {
+			/** @description Cart */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Cart"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProductVariantInput: {
-			sku: string;
-			title: string;
-			/** Format: double */
-			price: number;
-			/** Format: double */
-			compare_at_price?: number | null;
-			stock: number;
-			position?: number;
-			is_published?: boolean;
-			weight_grams?: number | null;
-			/** Format: double */
-			length_cm?: number | null;
-			/** Format: double */
-			width_cm?: number | null;
-			/** Format: double */
-			height_cm?: number | null;
-			selections: components["schemas"]["ProductVariantSelectionInput"][];
+	};
+	getCheckoutCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProductAttributeValueInput: {
-			product_attribute_id: number;
-			text_value?: string | null;
-			/** Format: double */
-			number_value?: number | null;
-			boolean_value?: boolean | null;
-			enum_value?: string | null;
-			position?: number;
+		requestBody?: never;
+		responses: {
+			/** @description Cart */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Cart"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProductSEOInput: {
-			title?: string | null;
-			description?: string | null;
-			canonical_path?: string | null;
-			og_image_media_id?: string | null;
-			noindex?: boolean;
+	};
+	getCheckoutCartSummary: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProductUpsertInput: {
-			sku: string;
-			name: string;
-			subtitle?: string | null;
-			description: string;
-			images: string[];
-			related_product_ids: number[];
-			category_ids: number[];
-			brand_id?: number | null;
-			default_variant_sku?: string | null;
-			options: components["schemas"]["ProductOptionInput"][];
-			variants: components["schemas"]["ProductVariantInput"][];
-			attributes: components["schemas"]["ProductAttributeValueInput"][];
-			seo: components["schemas"]["ProductSEOInput"];
+		requestBody?: never;
+		responses: {
+			/** @description Cart summary */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutCartSummary"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	addCheckoutCartItem: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProductPage: {
-			data: components["schemas"]["Product"][];
-			pagination: components["schemas"]["Pagination"];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["AddCartItemRequest"];
+			};
 		};
-		CartItem: {
-			id: number;
-			cart_id: number;
-			product_variant_id: number;
-			product_variant: components["schemas"]["ProductVariant"];
-			quantity: number;
-			/** Format: double */
-			base_price?: number;
-			/** Format: double */
-			discount_amount?: number;
-			/** Format: double */
-			final_price?: number;
-			applied_campaigns?: components["schemas"]["AppliedCampaign"][];
-			product: components["schemas"]["Product"];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			deleted_at?: string | null;
+		responses: {
+			/** @description Cart */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Cart"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		Cart: {
-			id: number;
-			user_id: number;
-			items: components["schemas"]["CartItem"][];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			deleted_at?: string | null;
+	};
+	deleteCheckoutCartItem: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				itemId: number;
+			};
+			cookie?: never;
 		};
-		CheckoutCartSummary: {
-			item_count: number;
+		requestBody?: never;
+		responses: {
+			/** @description Deleted */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["MessageResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		AddCartItemRequest: {
-			product_variant_id: number;
-			quantity: number;
+	};
+	updateCheckoutCartItem: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				itemId: number;
+			};
+			cookie?: never;
 		};
-		UpdateCartItemRequest: {
-			quantity: number;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["UpdateCartItemRequest"];
+			};
 		};
-		OrderItem: {
-			id: number;
-			order_id: number;
-			product_variant_id: number;
-			variant_sku: string;
-			variant_title: string;
-			quantity: number;
-			/** Format: double */
-			price: number;
-			product_variant: components["schemas"]["ProductVariant"];
-			product: components["schemas"]["Product"];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			deleted_at?: string | null;
+		responses: {
+			/** @description Updated item */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CartItem"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		Order: {
-			id: number;
-			user_id?: number | null;
-			checkout_session_id: number;
-			/** Format: email */
-			guest_email?: string | null;
-			confirmation_token?: string | null;
-			/** @enum {string} */
-			status: "PENDING" | "PAID" | "FAILED" | "SHIPPED" | "DELIVERED" | "CANCELLED" | "REFUNDED";
-			can_cancel: boolean;
-			/** Format: double */
-			total: number;
-			payment_method_display?: string | null;
-			shipping_address_pretty?: string | null;
-			items: components["schemas"]["OrderItem"][];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			deleted_at?: string | null;
+	};
+	deleteCartItem: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				itemId: number;
+			};
+			cookie?: never;
 		};
-		OrderPage: {
-			data: components["schemas"]["Order"][];
-			pagination: components["schemas"]["Pagination"];
+		requestBody?: never;
+		responses: {
+			/** @description Deleted */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["MessageResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CreateOrderItemRequest: {
-			product_variant_id: number;
-			quantity: number;
+	};
+	updateCartItem: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				itemId: number;
+			};
+			cookie?: never;
 		};
-		CreateOrderRequest: {
-			items: components["schemas"]["CreateOrderItemRequest"][];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["UpdateCartItemRequest"];
+			};
 		};
-		CreateCheckoutOrderRequest: {
-			/** Format: email */
-			guest_email?: string | null;
+		responses: {
+			/** @description Updated item */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CartItem"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ClaimGuestOrderRequest: {
-			/** Format: email */
-			email: string;
-			confirmation_token: string;
+	};
+	listCheckoutPlugins: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProcessPaymentInputMethod: {
-			cardholder_name: string;
-			card_number: string;
-			exp_month: number;
-			exp_year: number;
+		requestBody?: never;
+		responses: {
+			/** @description Available checkout provider plugins */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutPluginCatalog"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProcessPaymentInputAddress: {
-			full_name: string;
-			line1: string;
-			line2?: string;
-			city: string;
-			state?: string;
-			postal_code: string;
-			country: string;
+	};
+	listCheckoutSessionPlugins: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProcessPaymentRequest: {
-			payment_method_id?: number;
-			address_id?: number;
-			payment_method?: components["schemas"]["ProcessPaymentInputMethod"];
-			address?: components["schemas"]["ProcessPaymentInputAddress"];
-			payment_provider_id?: string;
-			shipping_provider_id?: string;
-			tax_provider_id?: string;
-			payment_data?: {
-				[key: string]: string;
+		requestBody?: never;
+		responses: {
+			/** @description Available checkout provider plugins */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutPluginCatalog"];
+				};
 			};
-			shipping_data?: {
-				[key: string]: string;
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	listCheckoutFields: {
+		parameters: {
+			query?: {
+				/** @description Sales channel (web, app or admin). Defaults to web. */
+				channel?: string;
 			};
-			tax_data?: {
-				[key: string]: string;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Checkout fields */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutFieldDefinitionList"];
+				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		AuthorizeCheckoutOrderPaymentRequest: {
-			snapshot_id: number;
+	};
+	quoteCheckout: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProcessPaymentResponse: {
-			message: string;
-			order: components["schemas"]["Order"];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutQuoteRequest"];
+			};
 		};
-		AdminOrderPaymentAmountRequest: {
-			/** Format: double */
-			amount?: number;
+		responses: {
+			/** @description Quote */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutQuoteResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PaymentTransactionRecord: {
-			id: number;
-			/** @enum {string} */
-			operation: "AUTHORIZE" | "CAPTURE" | "VOID" | "REFUND";
-			provider_txn_id: string;
-			idempotency_key: string;
-			/** Format: double */
-			amount: number;
-			/** @enum {string} */
-			status: "PENDING" | "SUCCEEDED" | "FAILED";
-			raw_response_redacted: string;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+	};
+	quoteCheckoutSession: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		PaymentIntentRecord: {
-			id: number;
-			order_id: number;
-			snapshot_id: number;
-			provider: string;
-			/** @enum {string} */
-			status:
-				| "REQUIRES_ACTION"
-				| "AUTHORIZED"
-				| "PARTIALLY_CAPTURED"
-				| "CAPTURED"
-				| "VOIDED"
-				| "REFUNDED"
-				| "FAILED";
-			/** Format: double */
-			authorized_amount: number;
-			/** Format: double */
-			captured_amount: number;
-			/** Format: double */
-			refundable_amount: number;
-			currency: string;
-			version: number;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			transactions: components["schemas"]["PaymentTransactionRecord"][];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutQuoteRequest"];
+			};
 		};
-		OrderPaymentLedger: {
-			order_id: number;
-			intents: components["schemas"]["PaymentIntentRecord"][];
+		responses: {
+			/** @description Quote */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutQuoteResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		InventoryReservation: {
-			id: number;
-			product_variant_id: number;
-			quantity: number;
-			/** @enum {string} */
-			status: "ACTIVE" | "CONSUMED" | "RELEASED" | "EXPIRED";
-			/** Format: date-time */
-			expires_at: string;
-			owner_type: string;
-			owner_id?: number | null;
-			checkout_session_id?: number | null;
-			order_id?: number | null;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+	};
+	getCheckoutState: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		InventoryReservationList: {
-			items: components["schemas"]["InventoryReservation"][];
+		requestBody?: never;
+		responses: {
+			/** @description Current checkout state */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutState"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		InventoryAlert: {
-			id: number;
-			product_variant_id: number;
-			/** @enum {string} */
-			alert_type: "LOW_STOCK" | "OUT_OF_STOCK" | "RECOVERY";
-			/** @enum {string} */
-			status: "OPEN" | "ACKED" | "RESOLVED";
-			available: number;
-			threshold: number;
-			/** Format: date-time */
-			opened_at: string;
-			/** Format: date-time */
-			acked_at?: string | null;
-			acked_by_type?: string;
-			acked_by_id?: number | null;
-			/** Format: date-time */
-			resolved_at?: string | null;
-			resolved_by_type?: string;
-			resolved_by_id?: number | null;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+	};
+	updateCheckoutContact: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		InventoryAlertList: {
-			items: components["schemas"]["InventoryAlert"][];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutContactInput"];
+			};
 		};
-		InventoryThreshold: {
-			id: number;
-			product_variant_id?: number | null;
-			low_stock_quantity: number;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+		responses: {
+			/** @description Current checkout state */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutState"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		InventoryThresholdList: {
-			items: components["schemas"]["InventoryThreshold"][];
+	};
+	updateCheckoutShippingAddress: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutAddress"];
+			};
 		};
-		InventoryThresholdRequest: {
-			product_variant_id?: number | null;
-			low_stock_quantity: number;
+		responses: {
+			/** @description Current checkout state */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutState"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		InventoryMovement: {
-			id: number;
-			inventory_item_id: number;
-			movement_type: string;
-			quantity_delta: number;
-			reference_type: string;
-			reference_id?: number | null;
-			reason_code: string;
-			actor_type: string;
-			actor_id?: number | null;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+	};
+	updateCheckoutShippingMethod: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		/** @enum {string} */
-		InventoryAdjustmentReason:
-			| "CYCLE_COUNT_GAIN"
-			| "CYCLE_COUNT_LOSS"
-			| "DAMAGE"
-			| "SHRINKAGE"
-			| "RETURN_RESTOCK"
-			| "CORRECTION";
-		InventoryAdjustment: {
-			id: number;
-			inventory_item_id: number;
-			product_variant_id: number;
-			quantity_delta: number;
-			reason_code: components["schemas"]["InventoryAdjustmentReason"];
-			notes: string;
-			actor_type: string;
-			actor_id?: number | null;
-			approved_by_type: string;
-			approved_by_id?: number | null;
-			/** Format: date-time */
-			approved_at?: string | null;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutShippingMethodInput"];
+			};
 		};
-		InventoryAvailability: {
-			product_variant_id: number;
-			on_hand: number;
-			reserved: number;
-			available: number;
+		responses: {
+			/** @description Current checkout state */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutState"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		InventoryAdjustmentRequest: {
-			product_variant_id: number;
-			quantity_delta: number;
-			reason_code: components["schemas"]["InventoryAdjustmentReason"];
-			notes?: string;
-			approved_by_type?: string;
-			approved_by_id?: number | null;
+	};
+	updateCheckoutPayment: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		InventoryAdjustmentResponse: {
-			adjustment: components["schemas"]["InventoryAdjustment"];
-			availability: components["schemas"]["InventoryAvailability"];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutPaymentInput"];
+			};
 		};
-		InventoryReconciliationIssue: {
-			issue_type: string;
-			inventory_item_id: number;
-			product_variant_id: number;
-			expected: number;
-			actual: number;
-			message: string;
-			entity_type: string;
-			entity_id?: number | null;
+		responses: {
+			/** @description Current checkout state */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutState"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		InventoryReconciliationReport: {
-			/** Format: date-time */
-			checked_at: string;
-			issues: components["schemas"]["InventoryReconciliationIssue"][];
+	};
+	confirmCheckoutReview: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		InventoryTimeline: {
-			product_variant_id: number;
-			movements: components["schemas"]["InventoryMovement"][];
-			reservations: components["schemas"]["InventoryReservation"][];
-			adjustments: components["schemas"]["InventoryAdjustment"][];
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["CheckoutReviewRequest"];
+			};
 		};
-		Supplier: {
-			id: number;
-			name: string;
-			email: string;
-			notes: string;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+		responses: {
+			/** @description Current checkout state */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutState"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		SupplierRequest: {
-			name: string;
-			email?: string;
-			notes?: string;
+	};
+	restoreCheckoutCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		PurchaseOrderItem: {
-			id: number;
-			product_variant_id: number;
-			quantity_ordered: number;
-			quantity_received: number;
-			unit_cost: number;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CartRestoreRequest"];
+			};
 		};
-		PurchaseOrder: {
-			id: number;
-			supplier_id?: number | null;
-			supplier?: components["schemas"]["Supplier"];
-			/** @enum {string} */
-			status: "DRAFT" | "ISSUED" | "PARTIALLY_RECEIVED" | "RECEIVED" | "CANCELLED";
-			notes: string;
-			/** Format: date-time */
-			issued_at?: string | null;
-			/** Format: date-time */
-			received_at?: string | null;
-			/** Format: date-time */
-			cancelled_at?: string | null;
-			items: components["schemas"]["PurchaseOrderItem"][];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
+		responses: {
+			/** @description Restored cart */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CartRestoreResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PurchaseOrderList: {
-			items: components["schemas"]["PurchaseOrder"][];
+	};
+	getCheckoutSavedCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				token: string;
+			};
+			cookie?: never;
 		};
-		PurchaseOrderItemRequest: {
-			product_variant_id: number;
-			quantity_ordered: number;
-			unit_cost?: number;
+		requestBody?: never;
+		responses: {
+			/** @description Saved cart */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedCart"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PurchaseOrderRequest: {
-			supplier_id?: number | null;
-			supplier?: components["schemas"]["SupplierRequest"];
-			notes?: string;
-			items: components["schemas"]["PurchaseOrderItemRequest"][];
+	};
+	convertCheckoutSavedCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				token: string;
+			};
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Cart with the converted saved cart lines */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedCartConvertResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		PurchaseOrderReceiveItemRequest: {
-			purchase_order_item_id: number;
-			quantity_received: number;
+	};
+	createExpressCheckout: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		PurchaseOrderReceiveRequest: {
-			notes?: string;
-			items: components["schemas"]["PurchaseOrderReceiveItemRequest"][];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["AddCartItemRequest"];
+			};
 		};
-		InventoryReceiptItem: {
-			id: number;
-			purchase_order_item_id: number;
-			product_variant_id: number;
-			quantity_received: number;
+		responses: {
+			/** @description Express checkout */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ExpressCheckout"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		InventoryReceipt: {
-			id: number;
-			purchase_order_id: number;
-			/** Format: date-time */
-			received_at: string;
-			notes: string;
-			items: components["schemas"]["InventoryReceiptItem"][];
+	};
+	startDraftOrderCheckout: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				token: string;
+			};
+			cookie?: never;
 		};
-		PurchaseOrderReceiptResponse: {
-			purchase_order: components["schemas"]["PurchaseOrder"];
-			receipt: components["schemas"]["InventoryReceipt"];
+		requestBody?: never;
+		responses: {
+			/** @description Draft order checkout */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ExpressCheckout"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		AdminOrderPaymentLifecycleResponse: {
-			message: string;
-			order: components["schemas"]["Order"];
-			payment_intent: components["schemas"]["PaymentIntentRecord"];
-			transaction: components["schemas"]["PaymentTransactionRecord"];
+	};
+	checkGiftCardBalance: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		WebhookIngestResponse: {
-			message: string;
-			event_id: number;
-			provider_event_id: string;
-			duplicate: boolean;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["GiftCardBalanceRequest"];
+			};
 		};
-		WebhookEventRecord: {
-			id: number;
-			provider: string;
-			provider_event_id: string;
-			event_type: string;
-			signature_valid: boolean;
-			payload: string;
-			/** Format: date-time */
-			received_at: string;
-			/** Format: date-time */
-			processed_at?: string | null;
-			attempt_count: number;
-			last_error: string;
-			/** @enum {string} */
-			status: "PENDING" | "PROCESSED" | "DEAD_LETTER" | "REJECTED";
+		responses: {
+			/** @description Gift card balance */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["GiftCardBalance"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			429: components["responses"]["TooManyRequestsProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		WebhookEventPage: {
-			data: components["schemas"]["WebhookEventRecord"][];
-			pagination: components["schemas"]["Pagination"];
+	};
+	createCheckoutOrder: {
+		parameters: {
+			query?: never;
+			header?: {
+				"Idempotency-Key"?: string;
+			};
+			path?: never;
+			cookie?: never;
 		};
-		CheckoutOrderShippingRatesRequest: {
-			snapshot_id: number;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CreateCheckoutOrderRequest"];
+			};
 		};
-		ShipmentRate: {
-			id: number;
-			provider: string;
-			provider_rate_id: string;
-			service_code: string;
-			service_name: string;
-			/** Format: double */
-			amount: number;
-			currency: string;
-			selected: boolean;
-			shipment_id?: number | null;
-			/** Format: date-time */
-			expires_at?: string | null;
+		responses: {
+			/** @description Created order */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Order"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
+			429: components["responses"]["TooManyRequestsProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ShipmentPackage: {
-			id: number;
-			reference: string;
-			weight_grams: number;
-			length_cm: number;
-			width_cm: number;
-			height_cm: number;
+	};
+	authorizeCheckoutOrderPayment: {
+		parameters: {
+			query?: never;
+			header?: {
+				"Idempotency-Key"?: string;
+			};
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		TrackingEvent: {
-			id: number;
-			provider: string;
-			provider_event_id: string;
-			/** @enum {string} */
-			status: "LABEL_PURCHASED" | "IN_TRANSIT" | "DELIVERED" | "EXCEPTION";
-			tracking_number: string;
-			location: string;
-			description: string;
-			/** Format: date-time */
-			occurred_at: string;
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["AuthorizeCheckoutOrderPaymentRequest"];
+			};
 		};
-		Shipment: {
-			id: number;
-			order_id: number;
-			snapshot_id: number;
-			provider: string;
-			shipment_rate_id: number;
-			provider_shipment_id: string;
-			/** @enum {string} */
-			status: "QUOTED" | "LABEL_PURCHASED" | "IN_TRANSIT" | "DELIVERED" | "EXCEPTION";
-			currency: string;
-			service_code: string;
-			service_name: string;
-			/** Format: double */
-			amount: number;
-			shipping_address_pretty: string;
-			tracking_number: string;
-			tracking_url: string;
-			label_url: string;
-			/** Format: date-time */
-			purchased_at?: string | null;
-			/** Format: date-time */
-			finalized_at?: string | null;
-			/** Format: date-time */
-			delivered_at?: string | null;
-			rates: components["schemas"]["ShipmentRate"][];
-			packages: components["schemas"]["ShipmentPackage"][];
-			tracking_events: components["schemas"]["TrackingEvent"][];
+		responses: {
+			/** @description Payment result */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ProcessPaymentResponse"];
+				};
+			};
+			/** @description Payment authorization is durably recorded and requires reconciliation */
+			202: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ProviderOperationAcceptedEnvelope"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			429: components["responses"]["TooManyRequestsProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CheckoutOrderShippingRatesResponse: {
-			order_id: number;
-			snapshot_id: number;
-			provider: string;
-			rates: components["schemas"]["ShipmentRate"][];
+	};
+	quoteCheckoutOrderShippingRates: {
+		parameters: {
+			query?: never;
+			header?: {
+				"Idempotency-Key"?: string;
+			};
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		CheckoutOrderTrackingResponse: {
-			order_id: number;
-			shipments: components["schemas"]["Shipment"][];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutOrderShippingRatesRequest"];
+			};
 		};
-		AdminOrderShippingLabelPackageRequest: {
-			reference?: string;
-			weight_grams?: number;
-			length_cm?: number;
-			width_cm?: number;
-			height_cm?: number;
+		responses: {
+			/** @description Persisted shipping rates for the checkout snapshot */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutOrderShippingRatesResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		AdminOrderShippingLabelRequest: {
-			rate_id: number;
-			package?: components["schemas"]["AdminOrderShippingLabelPackageRequest"];
+	};
+	getCheckoutOrderShippingTracking: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		AdminOrderShippingLabelResponse: {
-			message: string;
-			shipment: components["schemas"]["Shipment"];
+		requestBody?: never;
+		responses: {
+			/** @description Shipment tracking timeline for the order */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutOrderTrackingResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CheckoutOrderTaxFinalizeRequest: {
-			snapshot_id: number;
-			inclusive_pricing?: boolean;
+	};
+	finalizeCheckoutOrderTax: {
+		parameters: {
+			query?: never;
+			header?: {
+				"Idempotency-Key"?: string;
+			};
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		TaxLine: {
-			id?: number;
-			snapshot_item_id?: number | null;
-			/** @enum {string} */
-			line_type: "ITEM" | "SHIPPING";
-			product_variant_id?: number | null;
-			quantity: number;
-			jurisdiction: string;
-			tax_code: string;
-			tax_name: string;
-			/** Format: double */
-			taxable_amount: number;
-			/** Format: double */
-			tax_amount: number;
-			tax_rate_basis_points: number;
-			inclusive: boolean;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutOrderTaxFinalizeRequest"];
+			};
 		};
-		CheckoutOrderTaxFinalizeResponse: {
-			message: string;
-			order_id: number;
-			snapshot_id: number;
-			provider: string;
-			currency: string;
-			inclusive_pricing: boolean;
-			/** Format: double */
-			total_tax: number;
-			lines: components["schemas"]["TaxLine"][];
+		responses: {
+			/** @description Tax finalization result */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutOrderTaxFinalizeResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProviderCredentialRequest: {
-			/** @enum {string} */
-			provider_type: "payment" | "shipping" | "tax";
-			provider_id: string;
-			/** @enum {string} */
-			environment: "sandbox" | "production";
-			label?: string;
-			secret_data: {
-				[key: string]: string;
+	};
+	listUserOrders: {
+		parameters: {
+			query?: {
+				status?:
+					| "PENDING"
+					| "PAID"
+					| "FAILED"
+					| "PARTIALLY_SHIPPED"
+					| "SHIPPED"
+					| "DELIVERED"
+					| "CANCELLED"
+					| "REFUNDED";
+				start_date?: string;
+				end_date?: string;
+				page?: number;
+				limit?: number;
 			};
-			supported_currencies?: string[];
-			settlement_currency?: string;
-			/** @enum {string} */
-			fx_mode?: "same_currency_only" | "provider_managed";
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProviderCredential: {
-			id: number;
-			/** @enum {string} */
-			provider_type: "payment" | "shipping" | "tax";
-			provider_id: string;
-			/** @enum {string} */
-			environment: "sandbox" | "production";
-			label: string;
-			key_version: string;
-			supported_currencies: string[];
-			settlement_currency?: string;
-			/** @enum {string} */
-			fx_mode: "same_currency_only" | "provider_managed";
-			/** Format: date-time */
-			last_rotated_at: string;
-			/** Format: date-time */
-			updated_at: string;
+		requestBody?: never;
+		responses: {
+			/** @description Orders page */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["OrderPage"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProviderCredentialEnvelope: {
-			credential: components["schemas"]["ProviderCredential"];
+	};
+	createOrder: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProviderCredentialListResponse: {
-			data: components["schemas"]["ProviderCredential"][];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CreateOrderRequest"];
+			};
 		};
-		ProviderWebhookStatusSummary: {
-			pending_count: number;
-			processed_count: number;
-			dead_letter_count: number;
-			rejected_count: number;
+		responses: {
+			/** @description Created order */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Order"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProviderOperationStatusSummary: {
-			total_count: number;
-			active_count: number;
-			unknown_count: number;
-			finalize_retry_count: number;
-			compensation_retry_count: number;
-			failed_count: number;
-			completed_count: number;
+	};
+	claimGuestOrder: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProviderReconciliationCaseSummary: {
-			open_count: number;
-			unassigned_count: number;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["ClaimGuestOrderRequest"];
+			};
+		};
+		responses: {
+			/** @description Guest order linked to authenticated user */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ClaimGuestOrderResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProviderOperationsOverview: {
-			/** @enum {string} */
-			runtime_environment: "sandbox" | "production";
-			credential_service_configured: boolean;
-			webhook_events: components["schemas"]["ProviderWebhookStatusSummary"];
-			operations: components["schemas"]["ProviderOperationStatusSummary"];
-			reconciliation_cases: components["schemas"]["ProviderReconciliationCaseSummary"];
+	};
+	getUserOrder: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		/**
-		 * @description Durable lifecycle state of an externally effective provider operation.
-		 * @enum {string}
-		 */
-		ProviderOperationStatus:
-			| "PREPARED"
-			| "EXECUTING"
-			| "OUTCOME_UNKNOWN"
-			| "PROVIDER_SUCCEEDED"
-			| "FINALIZING"
-			| "FINALIZE_RETRY"
-			| "COMPENSATION_PREPARED"
-			| "COMPENSATING"
-			| "COMPENSATION_SUCCEEDED"
-			| "COMPENSATION_RETRY"
-			| "COMPLETED"
-			| "FAILED"
-			| "RECONCILING"
-			| "RECONCILIATION_REQUIRED";
-		/** @enum {string} */
-		ProviderOperationAttemptOutcome: "SUCCEEDED" | "FAILED" | "UNKNOWN";
-		ProviderOperationAttempt: {
-			id: number;
-			attempt_number: number;
-			phase: string;
-			outcome: components["schemas"]["ProviderOperationAttemptOutcome"];
-			/** @enum {string} */
-			provider_outcome: "SUCCEEDED" | "FAILED" | "UNKNOWN" | "NOT_FOUND" | "";
-			provider_reference?: string;
-			operation_key: string;
-			retryable: boolean;
-			/** @description Safe classified failure metadata; provider payloads and secrets are never exposed. */
-			problem?: components["schemas"]["Problem"] | null;
-			/** Format: date-time */
-			started_at: string;
-			/** Format: date-time */
-			finished_at: string;
+		requestBody?: never;
+		responses: {
+			/** @description Order */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Order"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		/** @enum {string} */
-		ProviderReconciliationCaseStatus: "OPEN" | "RESOLVED";
-		/** @enum {string} */
-		ProviderReconciliationCaseOutcome:
-			| "CONFIRMED_SUCCEEDED"
-			| "CONFIRMED_FAILED"
-			| "RETRY_REQUIRED"
-			| "MANUAL_REVIEW";
-		ProviderReconciliationCase: {
-			id: number;
-			operation_id: number;
-			attempt_id?: number | null;
-			/** @enum {string} */
-			provider_type: "payment" | "shipping" | "tax";
-			provider_id: string;
-			/** @enum {string} */
-			environment: "sandbox" | "production";
-			operation: string;
-			operation_status: components["schemas"]["ProviderOperationStatus"];
-			status: components["schemas"]["ProviderReconciliationCaseStatus"];
-			outcome?: components["schemas"]["ProviderReconciliationCaseOutcome"] | null;
-			/** @description Safe machine-readable reason for reconciliation. */
-			reason: string;
-			case_type: string;
-			/** @enum {string} */
-			provider_outcome: "SUCCEEDED" | "FAILED" | "UNKNOWN" | "NOT_FOUND" | "";
-			operation_key: string;
-			assigned_to?: string | null;
-			resolution_note?: string | null;
-			problem?: components["schemas"]["Problem"] | null;
-			/** Format: date-time */
-			next_attempt_at?: string | null;
-			/** Format: date-time */
-			opened_at: string;
-			/** Format: date-time */
-			resolved_at?: string | null;
+	};
+	listUserOrderDocuments: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		ProviderReconciliationCaseUpdate: {
-			assigned_to?: string | null;
-			status?: components["schemas"]["ProviderReconciliationCaseStatus"];
-			outcome?: components["schemas"]["ProviderReconciliationCaseOutcome"];
-			resolution_note?: string;
+		requestBody?: never;
+		responses: {
+			/** @description Invoices, credit notes and packing slips issued for the order */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["OrderDocumentList"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProviderReconciliationCaseEnvelope: {
-			case: components["schemas"]["ProviderReconciliationCase"];
+	};
+	downloadUserOrderDocument: {
+		parameters: {
+			query?: {
+				/** @description pdf (the default) or html. */
+				format?: "pdf" | "html";
+			};
+			header?: never;
+			path: {
+				id: number;
+				documentId: number;
+			};
+			cookie?: never;
 		};
-		ProviderReconciliationCasePage: {
-			data: components["schemas"]["ProviderReconciliationCase"][];
-			pagination: components["schemas"]["Pagination"];
+		requestBody?: never;
+		responses: {
+			/** @description The document as issued */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/pdf": string;
+					"text/html": string;
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		/** @description Safe API representation of a durable provider operation ledger record. */
-		ProviderOperation: {
-			id: number;
-			operation_key: string;
-			/** @enum {string} */
-			provider_type: "payment" | "shipping" | "tax";
-			provider_id: string;
-			/** @enum {string} */
-			environment: "sandbox" | "production";
-			/** @description Stable provider operation kind, such as payment.capture. */
-			operation: string;
-			idempotency_key: string;
-			correlation_id: string;
-			entity_type: string;
-			entity_id: number;
-			status: components["schemas"]["ProviderOperationStatus"];
-			provider_outcome?: string;
-			provider_reference?: string;
-			retryable: boolean;
-			available_actions?: ("query_outcome" | "retry_finalize" | "retry_compensation")[];
-			problem?: components["schemas"]["Problem"] | null;
-			parent_operation_id?: number | null;
-			compensation_operation_id?: number | null;
-			attempts: components["schemas"]["ProviderOperationAttempt"][];
-			reconciliation_cases: components["schemas"]["ProviderReconciliationCase"][];
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			completed_at?: string | null;
-			/** Format: date-time */
-			next_attempt_at?: string | null;
+	};
+	cancelUserOrder: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		ProviderOperationEnvelope: {
-			operation: components["schemas"]["ProviderOperation"];
+		requestBody?: never;
+		responses: {
+			/** @description Cancelled order */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Order"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProviderOperationAcceptedEnvelope: {
-			message: string;
-			operation_key: string;
-			status: components["schemas"]["ProviderOperationStatus"];
+	};
+	claimUserOrderGiftCards: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		ProviderOperationPage: {
-			data: components["schemas"]["ProviderOperation"][];
-			pagination: components["schemas"]["Pagination"];
+		requestBody?: never;
+		responses: {
+			/** @description Gift cards issued by this call and their one-time visible codes */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["GiftCardIssueListResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProviderReconciliationRunRequest: {
-			/** @enum {string} */
-			provider_type: "payment" | "shipping" | "tax";
-			provider_id: string;
+	};
+	listAdminProducts: {
+		parameters: {
+			query?: {
+				q?: string;
+				min_price?: number;
+				max_price?: number;
+				brand_slug?: string;
+				category_slug?: string[];
+				category_id?: number[];
+				include_inactive_categories?: boolean;
+				has_variant_stock?: boolean;
+				attribute?: {
+					[key: string]: string;
+				};
+				sort?: "price" | "name" | "created_at";
+				order?: "asc" | "desc";
+				page?: number;
+				limit?: number;
+			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProviderReconciliationDrift: {
-			id: number;
-			entity_type: string;
-			entity_id: number;
-			provider_reference: string;
-			/** @enum {string} */
-			severity: "ERROR" | "WARN";
-			field_name: string;
-			expected_value: string;
-			actual_value: string;
-			message: string;
+		requestBody?: never;
+		responses: {
+			/** @description Paginated admin products */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ProductPage"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ProviderReconciliationRun: {
-			id: number;
-			/** @enum {string} */
-			provider_type: "payment" | "shipping" | "tax";
-			provider_id: string;
-			/** @enum {string} */
-			environment: "sandbox" | "production";
-			/** @enum {string} */
-			trigger: "MANUAL" | "SCHEDULED";
-			/** @enum {string} */
-			status: "SUCCEEDED" | "FAILED";
-			checked_count: number;
-			drift_count: number;
-			error_count: number;
-			/** Format: date-time */
-			started_at: string;
-			/** Format: date-time */
-			finished_at?: string | null;
-			drifts?: components["schemas"]["ProviderReconciliationDrift"][];
+	};
+	createProduct: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		ProviderReconciliationRunEnvelope: {
-			run: components["schemas"]["ProviderReconciliationRun"];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["ProductUpsertInput"];
+			};
 		};
-		ProviderReconciliationRunPage: {
-			data: components["schemas"]["ProviderReconciliationRun"][];
-			pagination: components["schemas"]["Pagination"];
+		responses: {
+			/** @description Created product */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["Product"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		ClaimGuestOrderResponse: {
-			message: string;
-			order: components["schemas"]["Order"];
+	};
+	listAdminDiscountCampaigns: {
+		parameters: {
+			query?: {
+				status?: "active" | "scheduled" | "disabled" | "archived";
+			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CheckoutPluginFieldOption: {
-			value: string;
-			label: string;
+		requestBody?: never;
+		responses: {
+			/** @description Product discount campaigns */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountCampaignListResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CheckoutPluginField: {
-			key: string;
-			label: string;
-			/** @enum {string} */
-			type: "text" | "number" | "checkbox" | "select";
-			required: boolean;
-			placeholder?: string;
-			help_text?: string;
-			options?: components["schemas"]["CheckoutPluginFieldOption"][];
+	};
+	createAdminDiscountCampaign: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CheckoutPluginState: {
-			code: string;
-			/** @enum {string} */
-			severity: "info" | "success" | "warning" | "error";
-			message: string;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["ProductDiscountInput"];
+			};
 		};
-		CheckoutPlugin: {
-			id: string;
-			/** @enum {string} */
-			type: "payment" | "shipping" | "tax";
-			name: string;
-			description: string;
-			status: string;
-			enabled: boolean;
-			fields: components["schemas"]["CheckoutPluginField"][];
-			states: components["schemas"]["CheckoutPluginState"][];
+		responses: {
+			/** @description Created product discount campaign */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountCampaign"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CheckoutPluginCatalog: {
-			payment: components["schemas"]["CheckoutPlugin"][];
-			shipping: components["schemas"]["CheckoutPlugin"][];
-			tax: components["schemas"]["CheckoutPlugin"][];
+	};
+	createAdminPromotionCampaign: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		CheckoutQuoteRequest: {
-			payment_provider_id: string;
-			shipping_provider_id: string;
-			tax_provider_id: string;
-			payment_data?: {
-				[key: string]: string;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["PromotionInput"];
 			};
-			shipping_data?: {
-				[key: string]: string;
+		};
+		responses: {
+			/** @description Created promotion campaign */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountCampaign"];
+				};
 			};
-			tax_data?: {
-				[key: string]: string;
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	previewAdminPromotion: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["PromotionEvaluationRequest"];
 			};
 		};
-		CheckoutQuoteResponse: {
-			snapshot_id?: number | null;
-			/** Format: date-time */
-			expires_at?: string | null;
-			currency: string;
-			/** Format: double */
-			subtotal: number;
-			/** Format: double */
-			discount_total?: number;
-			/** Format: double */
-			shipping: number;
-			/** Format: double */
-			tax: number;
-			/** Format: double */
-			total: number;
-			valid: boolean;
-			payment_states: components["schemas"]["CheckoutPluginState"][];
-			shipping_states: components["schemas"]["CheckoutPluginState"][];
-			tax_states: components["schemas"]["CheckoutPluginState"][];
+		responses: {
+			/** @description Promotion evaluation preview */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["PromotionEvaluationResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		UpdateCheckoutPluginRequest: {
-			enabled: boolean;
+	};
+	simulateAdminPromotion: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		UpdateOrderStatusRequest: {
-			/** @enum {string} */
-			status: "PENDING" | "PAID" | "FAILED" | "SHIPPED" | "DELIVERED" | "CANCELLED" | "REFUNDED";
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["PromotionSimulationRequest"];
+			};
 		};
-		SavedPaymentMethod: {
-			id: number;
-			user_id: number;
-			type: string;
-			brand: string;
-			last4: string;
-			exp_month: number;
-			exp_year: number;
-			cardholder_name: string;
-			nickname: string;
-			is_default: boolean;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			deleted_at?: string | null;
+		responses: {
+			/** @description Promotion simulation report */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["PromotionSimulationResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CreateSavedPaymentMethodRequest: {
-			cardholder_name: string;
-			card_number: string;
-			exp_month: number;
-			exp_year: number;
-			nickname?: string;
-			set_default?: boolean;
+	};
+	listAdminPromotionTemplates: {
+		parameters: {
+			query?: {
+				active?: boolean;
+			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		SavedAddress: {
-			id: number;
-			user_id: number;
-			label: string;
-			full_name: string;
-			line1: string;
-			line2: string;
-			city: string;
-			state: string;
-			postal_code: string;
-			country: string;
-			phone: string;
-			is_default: boolean;
-			/** Format: date-time */
-			created_at: string;
-			/** Format: date-time */
-			updated_at: string;
-			/** Format: date-time */
-			deleted_at?: string | null;
+		requestBody?: never;
+		responses: {
+			/** @description Promotion templates */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["PromotionTemplateListResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		CreateSavedAddressRequest: {
-			label?: string;
-			full_name: string;
-			line1: string;
-			line2?: string;
-			city: string;
-			state?: string;
-			postal_code: string;
-			country: string;
-			phone?: string;
-			set_default?: boolean;
+	};
+	createAdminPromotionTemplate: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		UserPage: {
-			data: components["schemas"]["User"][];
-			pagination: components["schemas"]["Pagination"];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["PromotionTemplateInput"];
+			};
 		};
-		UpdateUserRoleRequest: {
-			/** @enum {string} */
-			role: "admin" | "customer";
+		responses: {
+			/** @description Created promotion template */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["PromotionTemplate"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		UpdateRelatedRequest: {
-			related_ids: number[];
+	};
+	instantiateAdminPromotionTemplate: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		MediaIDsRequest: {
-			media_ids: string[];
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["PromotionTemplateInstantiateInput"];
+			};
 		};
-		WebsiteSettings: {
-			site_title: string;
-			allow_guest_checkout: boolean;
-			/** @description Controls whether coupon-code-gated campaigns can be applied. */
-			coupon_codes_enabled: boolean;
-			oidc_provider: string;
-			oidc_client_id: string;
-			/** @description Write-only client secret. Responses always return an empty string. */
-			oidc_client_secret: string;
-			/** @description True when an encrypted OIDC client secret is stored. */
-			oidc_client_secret_configured: boolean;
-			/** @description Set true on update to remove the stored OIDC client secret. */
-			clear_oidc_client_secret: boolean;
-			oidc_redirect_uri: string;
+		responses: {
+			/** @description Created promotion campaign */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountCampaign"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		WebsiteSettingsRequest: {
-			settings: components["schemas"]["WebsiteSettings"];
+	};
+	updateAdminDiscountCampaign: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
 		};
-		WebsiteSettingsResponse: {
-			settings: components["schemas"]["WebsiteSettings"];
-			/** Format: date-time */
-			updated_at: string;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["ProductDiscountInput"];
+			};
+		};
+		responses: {
+			/** @description Updated product discount campaign */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountCampaign"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	responses: {
-		/** @description The request is malformed or does not satisfy the operation contract. */
-		BadRequestProblem: {
-			headers: {
-				[name: string]: unknown;
+	disableAdminDiscountCampaign: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Disabled product discount campaign */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountCampaign"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	scheduleAdminDiscountCampaign: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
 			};
+			cookie?: never;
+		};
+		requestBody: {
 			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+				"application/json": components["schemas"]["DiscountScheduleInput"];
 			};
 		};
-		/** @description Authentication is required or the supplied credentials are invalid. */
-		AuthenticationRequiredProblem: {
-			headers: {
-				[name: string]: unknown;
+		responses: {
+			/** @description Saved discount schedule */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountSchedule"];
+				};
 			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	archiveAdminDiscountCampaign: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
-		/** @description The authenticated principal is not allowed to perform this operation. */
-		ForbiddenProblem: {
-			headers: {
-				[name: string]: unknown;
+		requestBody?: never;
+		responses: {
+			/** @description Archived discount campaign */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountCampaign"];
+				};
 			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	getAdminDiscountCampaignBudget: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
-		/** @description The requested resource does not exist or is not visible to the caller. */
-		NotFoundProblem: {
-			headers: {
-				[name: string]: unknown;
+		requestBody?: never;
+		responses: {
+			/** @description Discount campaign budget spend and alerts */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountBudgetStatus"];
+				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	previewAdminDiscountSchedule: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody: {
 			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+				"application/json": components["schemas"]["DiscountSchedulePreviewRequest"];
 			};
 		};
-		/** @description The request conflicts with resource state, version, or idempotency history. */
-		ConflictProblem: {
-			headers: {
-				[name: string]: unknown;
-			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+		responses: {
+			/** @description Upcoming windows of the schedule */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountSchedulePreviewResponse"];
+				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		/** @description A required capability or configuration is unavailable. */
-		PreconditionFailedProblem: {
-			headers: {
-				[name: string]: unknown;
+	};
+	runAdminDiscountLifecycle: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Discount lifecycle run summary */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountLifecycleRunResponse"];
+				};
 			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	listAdminDiscountHistory: {
+		parameters: {
+			query?: {
+				campaign_id?: number;
 			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		/** @description The request is well-formed but fails semantic validation. */
-		ValidationProblem: {
-			headers: {
-				[name: string]: unknown;
+		requestBody?: never;
+		responses: {
+			/** @description Discount lifecycle history */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountStateHistoryListResponse"];
+				};
 			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	listAdminDiscountAudit: {
+		parameters: {
+			query?: {
+				campaign_id?: number;
 			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		/** @description The request payload exceeds the operation's size limit. */
-		PayloadTooLargeProblem: {
-			headers: {
-				[name: string]: unknown;
+		requestBody?: never;
+		responses: {
+			/** @description Discount campaign audit entries */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountCampaignAuditListResponse"];
+				};
 			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	getAdminDiscountMetrics: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Discount evaluation metrics snapshot */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountEvaluationMetrics"];
+				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		/** @description The caller has exceeded an applicable request limit. */
-		TooManyRequestsProblem: {
-			headers: {
-				[name: string]: unknown;
+	};
+	runAdminDiscountReconciliation: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Discount schedule reconciliation report */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DiscountReconciliationReport"];
+				};
 			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	listAdminCustomerSegments: {
+		parameters: {
+			query?: {
+				active?: boolean;
 			};
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
-		/** @description An unexpected internal error occurred. */
-		InternalServerErrorProblem: {
-			headers: {
-				[name: string]: unknown;
+		requestBody?: never;
+		responses: {
+			/** @description Customer segments */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CustomerSegmentListResponse"];
+				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	createAdminCustomerSegment: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		requestBody: {
 			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+				"application/json": components["schemas"]["CustomerSegmentInput"];
 			};
 		};
-		/** @description An upstream service returned an invalid or unsuccessful response. */
-		BadGatewayProblem: {
-			headers: {
-				[name: string]: unknown;
-			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+		responses: {
+			/** @description Created customer segment */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CustomerSegment"];
+				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		/** @description A required service or capability is temporarily unavailable. */
-		ServiceUnavailableProblem: {
-			headers: {
-				[name: string]: unknown;
+	};
+	getAdminCustomerSegment: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
 			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Customer segment */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CustomerSegment"];
+				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
-		/** @description A required upstream operation did not complete before its deadline. */
-		GatewayTimeoutProblem: {
-			headers: {
-				[name: string]: unknown;
+	};
+	deleteAdminCustomerSegment: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
 			};
-			content: {
-				"application/problem+json": components["schemas"]["Problem"];
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Customer segment deleted */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["MessageResponse"];
+				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	parameters: never;
-	requestBodies: never;
-	headers: never;
-	pathItems: never;
-}
-export type $defs = Record<string, never>;
-export interface operations {
-	register: {
+	updateAdminCustomerSegment: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["RegisterRequest"];
+				"application/json": components["schemas"]["CustomerSegmentInput"];
 			};
 		};
 		responses: {
-			/** @description Registered */
-			201: {
+			/** @description Updated customer segment */
+			200: {
 				headers: {
-					/** @description Session and CSRF cookies. */
-					"Set-Cookie"?: string[];
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["AuthResponse"];
+					"application/json": components["schemas"]["CustomerSegment"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	login: {
+	refreshAdminCustomerSegment: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["LoginRequest"];
+		requestBody?: never;
+		responses: {
+			/** @description Customer segment membership refresh summary */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CustomerSegmentRefreshResult"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	listAdminCustomerSegmentMembers: {
+		parameters: {
+			query?: {
+				page?: number;
+				limit?: number;
+			};
+			header?: never;
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Authenticated */
+			/** @description Materialized customer segment members */
 			200: {
 				headers: {
-					/** @description Session and CSRF cookies. */
-					"Set-Cookie"?: string[];
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["AuthResponse"];
+					"application/json": components["schemas"]["CustomerSegmentMemberListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	logout: {
+	listAdminAbandonedCarts: {
 		parameters: {
-			query?: never;
+			query?: {
+				status?: string;
+				page?: number;
+				limit?: number;
+			};
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Logged out */
+			/** @description Abandoned carts */
 			200: {
 				headers: {
-					/** @description Expired session and CSRF cookies. */
-					"Set-Cookie"?: string[];
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["AbandonedCartListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAuthConfig: {
+	getAdminAbandonedCartReport: {
 		parameters: {
-			query?: never;
+			query?: {
+				/** @description Start of the detection window; defaults to 30 days before `to`. */
+				from?: string;
+				/** @description End of the detection window; defaults to now. */
+				to?: string;
+			};
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Authentication configuration */
+			/** @description Abandoned cart recovery report */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["AuthConfigResponse"];
+					"application/json": components["schemas"]["AbandonedCartReport"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	oidcLogin: {
+	listAdminSavedCarts: {
 		parameters: {
 			query?: {
-				redirect?: string;
-				response_format?: "json";
+				customer_user_id?: number;
+				status?: string;
+				page?: number;
+				limit?: number;
 			};
 			header?: never;
 			path?: never;
@@ -5238,69 +10651,109 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Redirect to provider */
-			302: {
+			/** @description Saved carts */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
-				content?: never;
+				content: {
+					"application/json": components["schemas"]["SavedCartListResponse"];
+				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	oidcCallback: {
+	createAdminSavedCart: {
 		parameters: {
-			query?: {
-				code?: string;
-				state?: string;
-				format?: "json";
-			};
+			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["SavedCartCreateRequest"];
+			};
+		};
+		responses: {
+			/** @description Saved cart */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedCart"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	getAdminSavedCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Saved cart */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["SavedCart"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	archiveAdminSavedCart: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
+		};
 		requestBody?: never;
 		responses: {
-			/** @description Callback as JSON */
+			/** @description Archived saved cart */
 			200: {
 				headers: {
-					/** @description Session and CSRF cookies. */
-					"Set-Cookie"?: string[];
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["AuthResponse"];
-				};
-			};
-			/** @description Callback as redirect */
-			302: {
-				headers: {
-					/** @description Session and CSRF cookies. */
-					"Set-Cookie"?: string[];
-					[name: string]: unknown;
+					"application/json": components["schemas"]["SavedCart"];
 				};
-				content?: never;
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listProducts: {
+	listAdminGiftCards: {
 		parameters: {
 			query?: {
-				q?: string;
-				min_price?: number;
-				max_price?: number;
-				brand_slug?: string;
-				category_slug?: string[];
-				has_variant_stock?: boolean;
-				attribute?: {
-					[key: string]: string;
-				};
-				sort?: "price" | "name" | "created_at";
-				order?: "asc" | "desc";
+				status?: string;
+				source?: string;
+				user_id?: number;
 				page?: number;
 				limit?: number;
 			};
@@ -5310,42 +10763,50 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Paginated products */
+			/** @description Gift cards */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProductPage"];
+					"application/json": components["schemas"]["GiftCardListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listBrands: {
+	issueAdminGiftCard: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["GiftCardIssueInput"];
+			};
+		};
 		responses: {
-			/** @description Active brands */
-			200: {
+			/** @description Issued gift card and its one-time visible code */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["BrandListResponse"];
+					"application/json": components["schemas"]["GiftCardIssueResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listCategories: {
+	reconcileAdminGiftCards: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -5354,129 +10815,141 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Active categories */
+			/** @description Gift card ledger reconciliation report */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CategoryListResponse"];
+					"application/json": components["schemas"]["GiftCardReconciliationReport"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listProductAttributes: {
+	getAdminGiftCard: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Filterable product attribute definitions */
+			/** @description Gift card with its ledger */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProductAttributeDefinitionListResponse"];
+					"application/json": components["schemas"]["GiftCard"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getProduct: {
+	adjustAdminGiftCard: {
 		parameters: {
 			query?: never;
-			header?: never;
+			header?: {
+				"Idempotency-Key"?: string;
+			};
 			path: {
 				id: number;
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["GiftCardAdjustmentInput"];
+			};
+		};
 		responses: {
-			/** @description Product */
+			/** @description Adjusted gift card */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["GiftCard"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	resolveContentHomepage: {
+	updateAdminGiftCardStatus: {
 		parameters: {
-			query?: {
-				market?: string;
-				locale?: string;
-				device?: "desktop" | "mobile" | "tablet";
-				segment?: string;
-				utm_source?: string;
-				assignment_key?: string;
-			};
+			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["GiftCardStatusInput"];
+			};
+		};
 		responses: {
-			/** @description Published CMS homepage */
+			/** @description Updated gift card */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CmsPageResponse"];
+					"application/json": components["schemas"]["GiftCard"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	resolveContentPage: {
+	listAdminBrands: {
 		parameters: {
 			query?: {
-				market?: string;
-				locale?: string;
-				device?: "desktop" | "mobile" | "tablet";
-				segment?: string;
-				utm_source?: string;
-				assignment_key?: string;
+				q?: string;
 			};
 			header?: never;
-			path: {
-				path: string;
-			};
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Published CMS page */
+			/** @description Available brands */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CmsPageResponse"];
+					"application/json": components["schemas"]["BrandListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
-			404: components["responses"]["NotFoundProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	recordContentEvent: {
+	createAdminBrand: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -5485,136 +10958,154 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["CmsContentEventRequest"];
+				"application/json": components["schemas"]["BrandInput"];
 			};
 		};
 		responses: {
-			/** @description Content event accepted */
-			202: {
+			/** @description Created brand */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["Brand"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	resolveContentRedirect: {
+	deleteAdminBrand: {
 		parameters: {
-			query: {
-				path: string;
-			};
+			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Matching redirect */
+			/** @description Deleted brand */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CmsRedirectResolution"];
+					"application/json": components["schemas"]["MessageResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
-			404: components["responses"]["NotFoundProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getContentSitemap: {
+	updateAdminBrand: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["BrandInput"];
+			};
+		};
 		responses: {
-			/** @description Storefront sitemap */
+			/** @description Updated brand */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/xml": string;
+					"application/json": components["schemas"]["Brand"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getContentNavigation: {
+	listAdminCategories: {
 		parameters: {
-			query?: never;
-			header?: never;
-			path: {
-				location: string;
+			query?: {
+				q?: string;
+				include_inactive?: boolean;
 			};
+			header?: never;
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Published CMS navigation menu */
+			/** @description Available categories */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CmsNavigationResponse"];
+					"application/json": components["schemas"]["CategoryListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
-			404: components["responses"]["NotFoundProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getContentGlobalRegion: {
+	createAdminCategory: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				region: string;
-			};
+			path?: never;
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CategoryInput"];
+			};
+		};
 		responses: {
-			/** @description Published CMS global region */
-			200: {
+			/** @description Created category */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CmsGlobalRegionResponse"];
+					"application/json": components["schemas"]["Category"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
-			404: components["responses"]["NotFoundProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getProfile: {
+	deleteAdminCategory: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Profile */
+			/** @description Deleted category */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["User"];
+					"application/json": components["schemas"]["MessageResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5623,26 +11114,28 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateProfile: {
+	updateAdminCategory: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["UpdateProfileRequest"];
+				"application/json": components["schemas"]["CategoryInput"];
 			};
 		};
 		responses: {
-			/** @description Updated profile */
+			/** @description Updated category */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["User"];
+					"application/json": components["schemas"]["Category"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5651,54 +11144,50 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	setProfilePhoto: {
+	listAdminProductAttributes: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": {
-					media_id: string;
-				};
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Updated profile */
+			/** @description Product attribute definitions */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["User"];
+					"application/json": components["schemas"]["ProductAttributeDefinitionListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			409: components["responses"]["ConflictProblem"];
-			413: components["responses"]["PayloadTooLargeProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteProfilePhoto: {
+	createAdminProductAttribute: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["ProductAttributeDefinitionInput"];
+			};
+		};
 		responses: {
-			/** @description Updated profile */
-			200: {
+			/** @description Created product attribute definition */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["User"];
+					"application/json": components["schemas"]["ProductAttributeDefinition"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5707,22 +11196,24 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listSavedPaymentMethods: {
+	deleteAdminProductAttribute: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Saved payment methods */
+			/** @description Deleted product attribute definition */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["SavedPaymentMethod"][];
+					"application/json": components["schemas"]["MessageResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5731,26 +11222,28 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createSavedPaymentMethod: {
+	updateAdminProductAttribute: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["CreateSavedPaymentMethodRequest"];
+				"application/json": components["schemas"]["ProductAttributeDefinitionInput"];
 			};
 		};
 		responses: {
-			/** @description Created payment method */
-			201: {
+			/** @description Updated product attribute definition */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["SavedPaymentMethod"];
+					"application/json": components["schemas"]["ProductAttributeDefinition"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5759,7 +11252,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteSavedPaymentMethod: {
+	getAdminProduct: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -5770,13 +11263,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Deleted */
+			/** @description Admin product */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5786,7 +11279,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	setDefaultPaymentMethod: {
+	deleteProduct: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -5797,13 +11290,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Updated payment method */
+			/** @description Deleted */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["SavedPaymentMethod"];
+					"application/json": components["schemas"]["MessageResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5812,22 +11305,28 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listSavedAddresses: {
+	updateProduct: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["ProductUpsertInput"];
+			};
+		};
 		responses: {
-			/** @description Saved addresses */
+			/** @description Updated product */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["SavedAddress"][];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5836,26 +11335,28 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createSavedAddress: {
+	attachProductMedia: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["CreateSavedAddressRequest"];
+				"application/json": components["schemas"]["MediaIDsRequest"];
 			};
 		};
 		responses: {
-			/** @description Created address */
-			201: {
+			/** @description Updated product */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["SavedAddress"];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5864,7 +11365,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteSavedAddress: {
+	publishProduct: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -5875,13 +11376,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Deleted */
+			/** @description Published product */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5891,7 +11392,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	setDefaultAddress: {
+	unpublishProduct: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -5902,65 +11403,71 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Updated address */
+			/** @description Unpublished product */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["SavedAddress"];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getCart: {
+	discardProductDraft: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Cart */
+			/** @description Product after draft discard */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Cart"];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	addCartItem: {
+	updateProductMediaOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["AddCartItemRequest"];
+				"application/json": components["schemas"]["MediaIDsRequest"];
 			};
 		};
 		responses: {
-			/** @description Cart */
+			/** @description Updated product */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Cart"];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -5969,46 +11476,25 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getCheckoutCart: {
+	detachProductMedia: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody?: never;
-		responses: {
-			/** @description Cart */
-			200: {
-				headers: {
-					[name: string]: unknown;
-				};
-				content: {
-					"application/json": components["schemas"]["Cart"];
-				};
+			path: {
+				id: number;
+				mediaId: string;
 			};
-			400: components["responses"]["BadRequestProblem"];
-			401: components["responses"]["AuthenticationRequiredProblem"];
-			403: components["responses"]["ForbiddenProblem"];
-			500: components["responses"]["InternalServerErrorProblem"];
-		};
-	};
-	getCheckoutCartSummary: {
-		parameters: {
-			query?: never;
-			header?: never;
-			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Cart summary */
+			/** @description Updated product */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutCartSummary"];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6017,26 +11503,28 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	addCheckoutCartItem: {
+	updateProductRelated: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["AddCartItemRequest"];
+				"application/json": components["schemas"]["UpdateRelatedRequest"];
 			};
 		};
 		responses: {
-			/** @description Cart */
+			/** @description Updated product */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Cart"];
+					"application/json": components["schemas"]["Product"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6045,24 +11533,22 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteCheckoutCartItem: {
+	listAdminOrderViews: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				itemId: number;
-			};
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Deleted */
+			/** @description Order list views saved by the signed-in admin */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["OrderViewList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6071,28 +11557,26 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateCheckoutCartItem: {
+	createAdminOrderView: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				itemId: number;
-			};
+			path?: never;
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["UpdateCartItemRequest"];
+				"application/json": components["schemas"]["OrderViewRequest"];
 			};
 		};
 		responses: {
-			/** @description Updated item */
-			200: {
+			/** @description Saved view */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CartItem"];
+					"application/json": components["schemas"]["OrderView"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6101,102 +11585,142 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteCartItem: {
+	updateAdminOrderView: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path: {
-				itemId: number;
+				id: number;
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["OrderViewRequest"];
+			};
+		};
 		responses: {
-			/** @description Deleted */
+			/** @description Updated view */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["OrderView"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateCartItem: {
+	deleteAdminOrderView: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path: {
-				itemId: number;
+				id: number;
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["UpdateCartItemRequest"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Updated item */
-			200: {
+			/** @description View deleted */
+			204: {
 				headers: {
 					[name: string]: unknown;
 				};
-				content: {
-					"application/json": components["schemas"]["CartItem"];
-				};
+				content?: never;
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listCheckoutPlugins: {
+	listAdminOrders: {
 		parameters: {
-			query?: never;
+			query?: {
+				page?: number;
+				limit?: number;
+				/** @description Matches part of the order id, guest email or status. */
+				q?: string;
+				/** @description Matches part of the guest or account email. */
+				email?: string;
+				/** @description Matches part of the account name or username, or of the shipping address. */
+				name?: string;
+				/** @description Matches part of any line's SKU. */
+				sku?: string;
+				confirmation_token?: string;
+				/** @description Tracking number of any of the order's shipments. */
+				tracking_number?: string;
+				/** @description Payment provider transaction reference of any of the order's payments. */
+				payment_reference?: string;
+				tag?: string;
+				status?:
+					| "PENDING"
+					| "PAID"
+					| "FAILED"
+					| "PARTIALLY_SHIPPED"
+					| "SHIPPED"
+					| "DELIVERED"
+					| "CANCELLED"
+					| "REFUNDED";
+				/** @description First day of the placement date range, as YYYY-MM-DD. */
+				start_date?: string;
+				/** @description Last day of the placement date range, as YYYY-MM-DD. */
+				end_date?: string;
+				min_total?: number;
+				max_total?: number;
+				/** @description Saved view whose filters apply. Filters given alongside it take precedence over the view's. */
+				view?: number;
+			};
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Available checkout provider plugins */
+			/** @description Orders page */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutPluginCatalog"];
+					"application/json": components["schemas"]["OrderPage"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listCheckoutSessionPlugins: {
+	exportAdminOrders: {
 		parameters: {
-			query?: never;
+			query?: {
+				start_date?: string;
+				end_date?: string;
+				/** @description Only export orders in this status. */
+				status?: string;
+			};
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Available checkout provider plugins */
+			/** @description Order export */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutPluginCatalog"];
+					"text/csv": string;
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6205,26 +11729,24 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	quoteCheckout: {
+	getAdminOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["CheckoutQuoteRequest"];
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Quote */
+			/** @description Order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutQuoteResponse"];
+					"application/json": components["schemas"]["Order"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6233,93 +11755,95 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	quoteCheckoutSession: {
+	getAdminOrderPayments: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["CheckoutQuoteRequest"];
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Quote */
+			/** @description Order payment ledger */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutQuoteResponse"];
+					"application/json": components["schemas"]["OrderPaymentLedger"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createCheckoutOrder: {
+	createAdminOrderShippingLabel: {
 		parameters: {
 			query?: never;
-			header?: {
-				"Idempotency-Key"?: string;
+			header: {
+				"Idempotency-Key": string;
+			};
+			path: {
+				id: number;
 			};
-			path?: never;
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["CreateCheckoutOrderRequest"];
+				"application/json": components["schemas"]["AdminOrderShippingLabelRequest"];
 			};
 		};
 		responses: {
-			/** @description Created order */
-			201: {
+			/** @description Shipping label purchased */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Order"];
+					"application/json": components["schemas"]["AdminOrderShippingLabelResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			409: components["responses"]["ConflictProblem"];
-			429: components["responses"]["TooManyRequestsProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	authorizeCheckoutOrderPayment: {
+	captureAdminOrderPayment: {
 		parameters: {
 			query?: never;
-			header?: {
-				"Idempotency-Key"?: string;
+			header: {
+				"Idempotency-Key": string;
 			};
 			path: {
 				id: number;
+				intentId: number;
 			};
 			cookie?: never;
 		};
 		requestBody?: {
 			content: {
-				"application/json": components["schemas"]["AuthorizeCheckoutOrderPaymentRequest"];
+				"application/json": components["schemas"]["AdminOrderPaymentAmountRequest"];
 			};
 		};
 		responses: {
-			/** @description Payment result */
+			/** @description Capture result */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProcessPaymentResponse"];
+					"application/json": components["schemas"]["AdminOrderPaymentLifecycleResponse"];
 				};
 			};
-			/** @description Payment authorization is durably recorded and requires reconciliation */
+			/** @description Capture is durably recorded and requires reconciliation */
 			202: {
 				headers: {
 					[name: string]: unknown;
@@ -6333,34 +11857,39 @@ export interface operations {
 			403: components["responses"]["ForbiddenProblem"];
 			404: components["responses"]["NotFoundProblem"];
 			409: components["responses"]["ConflictProblem"];
-			429: components["responses"]["TooManyRequestsProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	quoteCheckoutOrderShippingRates: {
+	voidAdminOrderPayment: {
 		parameters: {
 			query?: never;
-			header?: {
-				"Idempotency-Key"?: string;
+			header: {
+				"Idempotency-Key": string;
 			};
 			path: {
 				id: number;
+				intentId: number;
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["CheckoutOrderShippingRatesRequest"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Persisted shipping rates for the checkout snapshot */
+			/** @description Void result */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutOrderShippingRatesResponse"];
+					"application/json": components["schemas"]["AdminOrderPaymentLifecycleResponse"];
+				};
+			};
+			/** @description Void is durably recorded and requires reconciliation */
+			202: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ProviderOperationAcceptedEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6371,39 +11900,54 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getCheckoutOrderShippingTracking: {
+	refundAdminOrderPayment: {
 		parameters: {
 			query?: never;
-			header?: never;
+			header: {
+				"Idempotency-Key": string;
+			};
 			path: {
 				id: number;
+				intentId: number;
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["AdminOrderPaymentAmountRequest"];
+			};
+		};
 		responses: {
-			/** @description Shipment tracking timeline for the order */
+			/** @description Refund result */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutOrderTrackingResponse"];
+					"application/json": components["schemas"]["AdminOrderPaymentLifecycleResponse"];
+				};
+			};
+			/** @description Refund is durably recorded and requires reconciliation */
+			202: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ProviderOperationAcceptedEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
 			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	finalizeCheckoutOrderTax: {
+	updateOrderStatus: {
 		parameters: {
 			query?: never;
-			header?: {
-				"Idempotency-Key"?: string;
-			};
+			header?: never;
 			path: {
 				id: number;
 			};
@@ -6411,17 +11955,17 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["CheckoutOrderTaxFinalizeRequest"];
+				"application/json": components["schemas"]["UpdateOrderStatusRequest"];
 			};
 		};
 		responses: {
-			/** @description Tax finalization result */
+			/** @description Updated order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutOrderTaxFinalizeResponse"];
+					"application/json": components["schemas"]["Order"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6432,12 +11976,11 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listUserOrders: {
+	listAdminWebhookEvents: {
 		parameters: {
 			query?: {
-				status?: "PENDING" | "PAID" | "FAILED" | "SHIPPED" | "DELIVERED" | "CANCELLED" | "REFUNDED";
-				start_date?: string;
-				end_date?: string;
+				provider?: string;
+				status?: "PENDING" | "PROCESSED" | "DEAD_LETTER" | "REJECTED";
 				page?: number;
 				limit?: number;
 			};
@@ -6447,13 +11990,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Orders page */
+			/** @description Webhook event page */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["OrderPage"];
+					"application/json": components["schemas"]["WebhookEventPage"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6462,150 +12005,106 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createOrder: {
+	exportAdminTaxReport: {
 		parameters: {
-			query?: never;
-			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["CreateOrderRequest"];
-			};
-		};
-		responses: {
-			/** @description Created order */
-			201: {
-				headers: {
-					[name: string]: unknown;
-				};
-				content: {
-					"application/json": components["schemas"]["Order"];
-				};
+			query?: {
+				provider?: string;
+				start_date?: string;
+				end_date?: string;
+				format?: "csv";
 			};
-			400: components["responses"]["BadRequestProblem"];
-			401: components["responses"]["AuthenticationRequiredProblem"];
-			403: components["responses"]["ForbiddenProblem"];
-			500: components["responses"]["InternalServerErrorProblem"];
-		};
-	};
-	claimGuestOrder: {
-		parameters: {
-			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["ClaimGuestOrderRequest"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Guest order linked to authenticated user */
+			/** @description Tax export */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ClaimGuestOrderResponse"];
+					"text/csv": string;
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
-			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getUserOrder: {
+	listAdminProviderCredentials: {
 		parameters: {
-			query?: never;
-			header?: never;
-			path: {
-				id: number;
+			query?: {
+				provider_type?: "payment" | "shipping" | "tax";
 			};
+			header?: never;
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Order */
+			/** @description Provider credential metadata */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Order"];
+					"application/json": components["schemas"]["ProviderCredentialListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	cancelUserOrder: {
+	upsertAdminProviderCredential: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				id: number;
-			};
+			path?: never;
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["ProviderCredentialRequest"];
+			};
+		};
 		responses: {
-			/** @description Cancelled order */
+			/** @description Stored provider credential metadata */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Order"];
+					"application/json": components["schemas"]["ProviderCredentialEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
+			412: components["responses"]["PreconditionFailedProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminProducts: {
+	getAdminProviderOperationsOverview: {
 		parameters: {
-			query?: {
-				q?: string;
-				min_price?: number;
-				max_price?: number;
-				brand_slug?: string;
-				category_slug?: string[];
-				category_id?: number[];
-				include_inactive_categories?: boolean;
-				has_variant_stock?: boolean;
-				attribute?: {
-					[key: string]: string;
-				};
-				sort?: "price" | "name" | "created_at";
-				order?: "asc" | "desc";
-				page?: number;
-				limit?: number;
-			};
+			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Paginated admin products */
+			/** @description Provider operations overview */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProductPage"];
+					"application/json": components["schemas"]["ProviderOperationsOverview"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6614,38 +12113,46 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createProduct: {
+	rotateAdminProviderCredential: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["ProductUpsertInput"];
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Created product */
-			201: {
+			/** @description Rotated provider credential metadata */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["ProviderCredentialEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			412: components["responses"]["PreconditionFailedProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminDiscountCampaigns: {
+	listAdminProviderOperations: {
 		parameters: {
 			query?: {
-				status?: "active" | "scheduled" | "disabled" | "archived";
+				provider_type?: "payment" | "shipping" | "tax";
+				provider_id?: string;
+				environment?: "sandbox" | "production";
+				operation?: string;
+				status?: components["schemas"]["ProviderOperationStatus"];
+				entity_type?: string;
+				entity_id?: number;
+				page?: number;
+				limit?: number;
 			};
 			header?: never;
 			path?: never;
@@ -6653,13 +12160,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Product discount campaigns */
+			/** @description Durable provider operations */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountCampaignListResponse"];
+					"application/json": components["schemas"]["ProviderOperationPage"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6668,136 +12175,150 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminDiscountCampaign: {
+	getAdminProviderOperation: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["ProductDiscountInput"];
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Created product discount campaign */
-			201: {
+			/** @description Provider operation with attempt and reconciliation case detail */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountCampaign"];
+					"application/json": components["schemas"]["ProviderOperationEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminPromotionCampaign: {
+	queryAdminProviderOperationOutcome: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["PromotionInput"];
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Created promotion campaign */
-			201: {
+			/** @description Provider outcome was resolved */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountCampaign"];
+					"application/json": components["schemas"]["ProviderOperationEnvelope"];
+				};
+			};
+			/** @description Provider outcome remains unknown and requires reconciliation */
+			202: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["ProviderOperationEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			412: components["responses"]["PreconditionFailedProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	previewAdminPromotion: {
+	retryFinalizeAdminProviderOperation: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["PromotionEvaluationRequest"];
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Promotion evaluation preview */
-			200: {
+			/** @description Local finalization retry was safely queued */
+			202: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["PromotionEvaluationResponse"];
+					"application/json": components["schemas"]["ProviderOperationEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminPromotionTemplates: {
+	retryCompensationAdminProviderOperation: {
 		parameters: {
-			query?: {
-				active?: boolean;
-			};
+			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Promotion templates */
-			200: {
+			/** @description Compensation retry was safely queued */
+			202: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["PromotionTemplateListResponse"];
+					"application/json": components["schemas"]["ProviderOperationEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminPromotionTemplate: {
+	listAdminProviderReconciliationCases: {
 		parameters: {
-			query?: never;
+			query?: {
+				status?: components["schemas"]["ProviderReconciliationCaseStatus"];
+				provider_type?: "payment" | "shipping" | "tax";
+				provider_id?: string;
+				case_type?: string;
+				page?: number;
+				limit?: number;
+			};
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["PromotionTemplateInput"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Created promotion template */
-			201: {
+			/** @description Provider reconciliation cases */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["PromotionTemplate"];
+					"application/json": components["schemas"]["ProviderReconciliationCasePage"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6806,7 +12327,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	instantiateAdminPromotionTemplate: {
+	getAdminProviderReconciliationCase: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -6815,19 +12336,15 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["PromotionTemplateInstantiateInput"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Created promotion campaign */
-			201: {
+			/** @description Provider reconciliation case detail */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountCampaign"];
+					"application/json": components["schemas"]["ProviderReconciliationCaseEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6837,7 +12354,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateAdminDiscountCampaign: {
+	updateAdminProviderReconciliationCase: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -6848,85 +12365,85 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["ProductDiscountInput"];
+				"application/json": components["schemas"]["ProviderReconciliationCaseUpdate"];
 			};
 		};
 		responses: {
-			/** @description Updated product discount campaign */
+			/** @description Updated provider reconciliation case */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountCampaign"];
+					"application/json": components["schemas"]["ProviderReconciliationCaseEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
 			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	disableAdminDiscountCampaign: {
+	listAdminProviderReconciliationRuns: {
 		parameters: {
-			query?: never;
-			header?: never;
-			path: {
-				id: number;
+			query?: {
+				provider_type?: "payment" | "shipping" | "tax";
+				provider_id?: string;
+				page?: number;
+				limit?: number;
 			};
+			header?: never;
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Disabled product discount campaign */
+			/** @description Provider reconciliation runs */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountCampaign"];
+					"application/json": components["schemas"]["ProviderReconciliationRunPage"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	scheduleAdminDiscountCampaign: {
+	createAdminProviderReconciliationRun: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				id: number;
-			};
+			path?: never;
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["DiscountScheduleInput"];
+				"application/json": components["schemas"]["ProviderReconciliationRunRequest"];
 			};
 		};
 		responses: {
-			/** @description Saved discount schedule */
-			200: {
+			/** @description Provider reconciliation run */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountSchedule"];
+					"application/json": components["schemas"]["ProviderReconciliationRunEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	archiveAdminDiscountCampaign: {
+	getAdminProviderReconciliationRun: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -6937,13 +12454,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Archived discount campaign */
+			/** @description Provider reconciliation run details */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountCampaign"];
+					"application/json": components["schemas"]["ProviderReconciliationRunEnvelope"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -6953,60 +12470,43 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	runAdminDiscountLifecycle: {
+	receiveWebhookEvent: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				provider: string;
+			};
 			cookie?: never;
 		};
-		requestBody?: never;
-		responses: {
-			/** @description Discount lifecycle run summary */
-			200: {
-				headers: {
-					[name: string]: unknown;
-				};
-				content: {
-					"application/json": components["schemas"]["DiscountLifecycleRunResponse"];
+		requestBody: {
+			content: {
+				"application/json": {
+					[key: string]: unknown;
 				};
 			};
-			400: components["responses"]["BadRequestProblem"];
-			401: components["responses"]["AuthenticationRequiredProblem"];
-			403: components["responses"]["ForbiddenProblem"];
-			500: components["responses"]["InternalServerErrorProblem"];
-		};
-	};
-	listAdminDiscountHistory: {
-		parameters: {
-			query?: {
-				campaign_id?: number;
-			};
-			header?: never;
-			path?: never;
-			cookie?: never;
 		};
-		requestBody?: never;
 		responses: {
-			/** @description Discount lifecycle history */
+			/** @description Webhook accepted */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountStateHistoryListResponse"];
+					"application/json": components["schemas"]["WebhookIngestResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
-			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminDiscountAudit: {
+	listUsers: {
 		parameters: {
 			query?: {
-				campaign_id?: number;
+				page?: number;
+				limit?: number;
+				q?: string;
 			};
 			header?: never;
 			path?: never;
@@ -7014,13 +12514,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Discount campaign audit entries */
+			/** @description Users page */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountCampaignAuditListResponse"];
+					"application/json": components["schemas"]["UserPage"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7029,22 +12529,28 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAdminDiscountMetrics: {
+	updateUserRole: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["UpdateUserRoleRequest"];
+			};
+		};
 		responses: {
-			/** @description Discount evaluation metrics snapshot */
+			/** @description Updated user */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountEvaluationMetrics"];
+					"application/json": components["schemas"]["User"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7053,7 +12559,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	runAdminDiscountReconciliation: {
+	listAdminCheckoutFields: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7062,13 +12568,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Discount schedule reconciliation report */
+			/** @description Checkout fields including inactive ones */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["DiscountReconciliationReport"];
+					"application/json": components["schemas"]["CheckoutFieldList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7077,24 +12583,26 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminBrands: {
+	createAdminCheckoutField: {
 		parameters: {
-			query?: {
-				q?: string;
-			};
+			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutFieldRequest"];
+			};
+		};
 		responses: {
-			/** @description Available brands */
-			200: {
+			/** @description Created checkout field */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["BrandListResponse"];
+					"application/json": components["schemas"]["CheckoutField"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7103,35 +12611,34 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminBrand: {
+	getAdminCheckoutField: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["BrandInput"];
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Created brand */
-			201: {
+			/** @description Checkout field */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Brand"];
+					"application/json": components["schemas"]["CheckoutField"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteAdminBrand: {
+	updateAdminCheckoutField: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7140,24 +12647,29 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["CheckoutFieldRequest"];
+			};
+		};
 		responses: {
-			/** @description Deleted brand */
+			/** @description Updated checkout field */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["CheckoutField"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateAdminBrand: {
+	deleteAdminCheckoutField: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7166,46 +12678,38 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["BrandInput"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Updated brand */
-			200: {
+			/** @description Checkout field deleted */
+			204: {
 				headers: {
 					[name: string]: unknown;
 				};
-				content: {
-					"application/json": components["schemas"]["Brand"];
-				};
+				content?: never;
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminCategories: {
+	getAdminRiskSettings: {
 		parameters: {
-			query?: {
-				q?: string;
-				include_inactive?: boolean;
-			};
+			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Available categories */
+			/** @description Order risk screening settings */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CategoryListResponse"];
+					"application/json": components["schemas"]["RiskSettings"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7214,7 +12718,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminCategory: {
+	updateAdminRiskSettings: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7223,17 +12727,17 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["CategoryInput"];
+				"application/json": components["schemas"]["RiskSettingsInput"];
 			};
 		};
 		responses: {
-			/** @description Created category */
-			201: {
+			/** @description Updated order risk screening settings */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Category"];
+					"application/json": components["schemas"]["RiskSettings"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7242,24 +12746,28 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteAdminCategory: {
+	listAdminFulfillmentOrders: {
 		parameters: {
-			query?: never;
-			header?: never;
-			path: {
-				id: number;
+			query?: {
+				/** @description Fulfillment status to list, one of UNFULFILLED, PICKING, PACKED, LABEL_PURCHASED, SHIPPED, DELIVERED or CANCELLED. Defaults to every fulfillment order not yet shipped whose order is not held for risk review. */
+				status?: string;
+				location?: string;
+				page?: number;
+				limit?: number;
 			};
+			header?: never;
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Deleted category */
+			/** @description Warehouse queue, oldest first */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["FulfillmentOrderListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7268,7 +12776,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateAdminCategory: {
+	getAdminFulfillmentOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7277,80 +12785,89 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["CategoryInput"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Updated category */
+			/** @description Fulfillment order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Category"];
+					"application/json": components["schemas"]["FulfillmentOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminProductAttributes: {
+	pickAdminFulfillmentOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["FulfillmentStepInput"];
+			};
+		};
 		responses: {
-			/** @description Product attribute definitions */
+			/** @description Fulfillment order being picked */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProductAttributeDefinitionListResponse"];
+					"application/json": components["schemas"]["FulfillmentOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminProductAttribute: {
+	packAdminFulfillmentOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody: {
+		requestBody?: {
 			content: {
-				"application/json": components["schemas"]["ProductAttributeDefinitionInput"];
+				"application/json": components["schemas"]["FulfillmentPackingInput"];
 			};
 		};
 		responses: {
-			/** @description Created product attribute definition */
-			201: {
+			/** @description Packed fulfillment order */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProductAttributeDefinition"];
+					"application/json": components["schemas"]["FulfillmentOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteAdminProductAttribute: {
+	shipAdminFulfillmentOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7359,24 +12876,30 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["FulfillmentStepInput"];
+			};
+		};
 		responses: {
-			/** @description Deleted product attribute definition */
+			/** @description Shipped fulfillment order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["FulfillmentOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateAdminProductAttribute: {
+	routeAdminFulfillmentOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7387,26 +12910,28 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["ProductAttributeDefinitionInput"];
+				"application/json": components["schemas"]["FulfillmentRerouteInput"];
 			};
 		};
 		responses: {
-			/** @description Updated product attribute definition */
+			/** @description Rerouted fulfillment order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProductAttributeDefinition"];
+					"application/json": components["schemas"]["FulfillmentOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAdminProduct: {
+	getAdminOrderTimeline: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7417,13 +12942,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Admin product */
+			/** @description Order timeline, oldest event first */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["OrderTimeline"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7433,7 +12958,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteProduct: {
+	setAdminOrderTags: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7442,24 +12967,29 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["OrderTags"];
+			};
+		};
 		responses: {
-			/** @description Deleted */
+			/** @description Order tags */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["OrderTags"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateProduct: {
+	listAdminOrderNotes: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7468,28 +12998,25 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["ProductUpsertInput"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Updated product */
+			/** @description Internal notes on the order, oldest first */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["OrderNoteList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	attachProductMedia: {
+	createAdminOrderNote: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7500,44 +13027,44 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["MediaIDsRequest"];
+				"application/json": components["schemas"]["OrderNoteRequest"];
 			};
 		};
 		responses: {
-			/** @description Updated product */
-			200: {
+			/** @description Note */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["OrderNote"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	publishProduct: {
+	deleteAdminOrderNote: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path: {
 				id: number;
+				noteId: number;
 			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Published product */
-			200: {
+			/** @description Note deleted */
+			204: {
 				headers: {
 					[name: string]: unknown;
 				};
-				content: {
-					"application/json": components["schemas"]["Product"];
-				};
+				content?: never;
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
@@ -7546,7 +13073,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	unpublishProduct: {
+	listAdminOrderDocuments: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7557,13 +13084,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Unpublished product */
+			/** @description Documents issued for the order, newest first */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["OrderDocumentList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7573,7 +13100,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	discardProductDraft: {
+	issueAdminOrderDocument: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7582,140 +13109,149 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["OrderDocumentRequest"];
+			};
+		};
 		responses: {
-			/** @description Product after draft discard */
-			200: {
+			/** @description Issued document */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["OrderDocument"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
 			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateProductMediaOrder: {
+	downloadAdminOrderDocument: {
 		parameters: {
-			query?: never;
+			query?: {
+				/** @description pdf (the default) or html. */
+				format?: "pdf" | "html";
+			};
 			header?: never;
 			path: {
 				id: number;
+				documentId: number;
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["MediaIDsRequest"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Updated product */
+			/** @description The document as issued */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/pdf": string;
+					"text/html": string;
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	detachProductMedia: {
+	listAdminOrderFulfillmentOrders: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path: {
 				id: number;
-				mediaId: string;
 			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Updated product */
+			/** @description Fulfillment orders for the order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["FulfillmentOrder"][];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateProductRelated: {
+	cancelAdminOrderItem: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path: {
 				id: number;
+				itemId: number;
 			};
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["UpdateRelatedRequest"];
+				"application/json": components["schemas"]["CancelOrderItemRequest"];
 			};
 		};
 		responses: {
-			/** @description Updated product */
+			/** @description Updated order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Product"];
+					"application/json": components["schemas"]["Order"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminOrders: {
+	listAdminOrderEdits: {
 		parameters: {
-			query?: {
-				page?: number;
-				limit?: number;
-				q?: string;
-			};
+			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Orders page */
+			/** @description Edits of the order, newest first */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["OrderPage"];
+					"application/json": components["schemas"]["OrderEditList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAdminOrder: {
+	beginAdminOrderEdit: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7724,24 +13260,30 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["OrderEditRequest"];
+			};
+		};
 		responses: {
-			/** @description Order */
+			/** @description Opened order edit */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Order"];
+					"application/json": components["schemas"]["OrderEdit"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAdminOrderPayments: {
+	getAdminOrderEdit: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7752,13 +13294,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Order payment ledger */
+			/** @description Order edit with its lines and history */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["OrderPaymentLedger"];
+					"application/json": components["schemas"]["OrderEdit"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7768,12 +13310,10 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminOrderShippingLabel: {
+	stageAdminOrderEditChange: {
 		parameters: {
 			query?: never;
-			header: {
-				"Idempotency-Key": string;
-			};
+			header?: never;
 			path: {
 				id: number;
 			};
@@ -7781,17 +13321,17 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["AdminOrderShippingLabelRequest"];
+				"application/json": components["schemas"]["OrderEditChangeRequest"];
 			};
 		};
 		responses: {
-			/** @description Shipping label purchased */
+			/** @description Order edit with the change staged and totals recalculated */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["AdminOrderShippingLabelResponse"];
+					"application/json": components["schemas"]["OrderEdit"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7802,40 +13342,64 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	captureAdminOrderPayment: {
+	commitAdminOrderEdit: {
 		parameters: {
 			query?: never;
-			header: {
-				"Idempotency-Key": string;
+			header?: {
+				/** @description Reused when a commit is retried so the payment adjustment runs once. Defaults to one key per edit. */
+				"Idempotency-Key"?: string;
 			};
 			path: {
 				id: number;
-				intentId: number;
 			};
 			cookie?: never;
 		};
-		requestBody?: {
-			content: {
-				"application/json": components["schemas"]["AdminOrderPaymentAmountRequest"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Capture result */
+			/** @description Committed edit and the updated order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["AdminOrderPaymentLifecycleResponse"];
+					"application/json": components["schemas"]["OrderEditCommitResponse"];
 				};
 			};
-			/** @description Capture is durably recorded and requires reconciliation */
+			/** @description Payment adjustment is durably recorded and requires reconciliation; commit again with the same key to finish */
 			202: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationAcceptedEnvelope"];
+					"application/json": components["schemas"]["ProviderOperationAcceptedEnvelope"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	cancelAdminOrderEdit: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
+		};
+		requestBody?: never;
+		responses: {
+			/** @description Cancelled order edit */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["OrderEdit"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7846,91 +13410,59 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	voidAdminOrderPayment: {
+	listAdminLegalEntities: {
 		parameters: {
 			query?: never;
-			header: {
-				"Idempotency-Key": string;
-			};
-			path: {
-				id: number;
-				intentId: number;
-			};
+			header?: never;
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Void result */
+			/** @description Legal entities */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["AdminOrderPaymentLifecycleResponse"];
-				};
-			};
-			/** @description Void is durably recorded and requires reconciliation */
-			202: {
-				headers: {
-					[name: string]: unknown;
-				};
-				content: {
-					"application/json": components["schemas"]["ProviderOperationAcceptedEnvelope"];
+					"application/json": components["schemas"]["LegalEntityList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
-			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	refundAdminOrderPayment: {
+	createAdminLegalEntity: {
 		parameters: {
 			query?: never;
-			header: {
-				"Idempotency-Key": string;
-			};
-			path: {
-				id: number;
-				intentId: number;
-			};
+			header?: never;
+			path?: never;
 			cookie?: never;
 		};
-		requestBody?: {
+		requestBody: {
 			content: {
-				"application/json": components["schemas"]["AdminOrderPaymentAmountRequest"];
+				"application/json": components["schemas"]["LegalEntityRequest"];
 			};
 		};
 		responses: {
-			/** @description Refund result */
-			200: {
-				headers: {
-					[name: string]: unknown;
-				};
-				content: {
-					"application/json": components["schemas"]["AdminOrderPaymentLifecycleResponse"];
-				};
-			};
-			/** @description Refund is durably recorded and requires reconciliation */
-			202: {
+			/** @description Legal entity */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationAcceptedEnvelope"];
+					"application/json": components["schemas"]["LegalEntity"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
-			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateOrderStatus: {
+	updateAdminLegalEntity: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -7941,30 +13473,31 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["UpdateOrderStatusRequest"];
+				"application/json": components["schemas"]["LegalEntityRequest"];
 			};
 		};
 		responses: {
-			/** @description Updated order */
+			/** @description Updated legal entity */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["Order"];
+					"application/json": components["schemas"]["LegalEntity"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminWebhookEvents: {
+	listAdminDraftOrders: {
 		parameters: {
 			query?: {
-				provider?: string;
-				status?: "PENDING" | "PROCESSED" | "DEAD_LETTER" | "REJECTED";
+				/** @description OPEN, INVOICED, COMPLETED or CANCELLED. */
+				status?: string;
 				page?: number;
 				limit?: number;
 			};
@@ -7974,13 +13507,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Webhook event page */
+			/** @description Draft orders, newest first */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["WebhookEventPage"];
+					"application/json": components["schemas"]["DraftOrderListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -7989,27 +13522,26 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	exportAdminTaxReport: {
+	createAdminDraftOrder: {
 		parameters: {
-			query?: {
-				provider?: string;
-				start_date?: string;
-				end_date?: string;
-				format?: "csv";
-			};
+			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["DraftOrderRequest"];
+			};
+		};
 		responses: {
-			/** @description Tax export */
-			200: {
+			/** @description Draft order */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"text/csv": string;
+					"application/json": components["schemas"]["DraftOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8018,86 +13550,130 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminProviderCredentials: {
+	getAdminDraftOrder: {
 		parameters: {
-			query?: {
-				provider_type?: "payment" | "shipping" | "tax";
-			};
+			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Provider credential metadata */
+			/** @description Draft order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderCredentialListResponse"];
+					"application/json": components["schemas"]["DraftOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	upsertAdminProviderCredential: {
+	updateAdminDraftOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["ProviderCredentialRequest"];
+				"application/json": components["schemas"]["DraftOrderRequest"];
 			};
 		};
 		responses: {
-			/** @description Stored provider credential metadata */
+			/** @description Updated draft order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderCredentialEnvelope"];
+					"application/json": components["schemas"]["DraftOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			412: components["responses"]["PreconditionFailedProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAdminProviderOperationsOverview: {
+	sendAdminDraftOrderPaymentLink: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["DraftOrderPaymentLinkRequest"];
+			};
+		};
 		responses: {
-			/** @description Provider operations overview */
+			/** @description Invoiced draft order with its payment link */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationsOverview"];
+					"application/json": components["schemas"]["DraftOrderPaymentLink"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	rotateAdminProviderCredential: {
+	markAdminDraftOrderPaid: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
+		};
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["DraftOrderMarkPaidRequest"];
+			};
+		};
+		responses: {
+			/** @description Completed draft order and the paid order */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["DraftOrderPaidResponse"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	cancelAdminDraftOrder: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8108,33 +13684,28 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Rotated provider credential metadata */
+			/** @description Cancelled draft order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderCredentialEnvelope"];
+					"application/json": components["schemas"]["DraftOrder"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
 			404: components["responses"]["NotFoundProblem"];
-			412: components["responses"]["PreconditionFailedProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminProviderOperations: {
+	listAdminRiskReviews: {
 		parameters: {
 			query?: {
-				provider_type?: "payment" | "shipping" | "tax";
-				provider_id?: string;
-				environment?: "sandbox" | "production";
-				operation?: string;
-				status?: components["schemas"]["ProviderOperationStatus"];
-				entity_type?: string;
-				entity_id?: number;
+				/** @description Review state to list, one of PENDING, APPROVED or REJECTED. Defaults to PENDING. */
+				status?: string;
 				page?: number;
 				limit?: number;
 			};
@@ -8144,13 +13715,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Durable provider operations */
+			/** @description Orders held by risk screening, oldest first */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationPage"];
+					"application/json": components["schemas"]["OrderRiskListResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8159,7 +13730,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAdminProviderOperation: {
+	getAdminOrderRisk: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8170,13 +13741,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Provider operation with attempt and reconciliation case detail */
+			/** @description Risk state and screening history for the order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationEnvelope"];
+					"application/json": components["schemas"]["OrderRisk"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8186,7 +13757,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	queryAdminProviderOperationOutcome: {
+	approveAdminOrderRisk: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8195,24 +13766,51 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["RiskReviewDecisionInput"];
+			};
+		};
 		responses: {
-			/** @description Provider outcome was resolved */
+			/** @description Approved order */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationEnvelope"];
+					"application/json": components["schemas"]["OrderRisk"];
 				};
 			};
-			/** @description Provider outcome remains unknown and requires reconciliation */
-			202: {
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	rejectAdminOrderRisk: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path: {
+				id: number;
+			};
+			cookie?: never;
+		};
+		requestBody?: {
+			content: {
+				"application/json": components["schemas"]["RiskReviewDecisionInput"];
+			};
+		};
+		responses: {
+			/** @description Rejected order */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationEnvelope"];
+					"application/json": components["schemas"]["OrderRisk"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8220,74 +13818,95 @@ export interface operations {
 			403: components["responses"]["ForbiddenProblem"];
 			404: components["responses"]["NotFoundProblem"];
 			409: components["responses"]["ConflictProblem"];
-			412: components["responses"]["PreconditionFailedProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	retryFinalizeAdminProviderOperation: {
+	listAdminCheckoutPlugins: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				id: number;
-			};
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Local finalization retry was safely queued */
-			202: {
+			/** @description Checkout providers including disabled ones */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationEnvelope"];
+					"application/json": components["schemas"]["CheckoutPluginCatalog"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
-			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	retryCompensationAdminProviderOperation: {
+	updateAdminCheckoutPlugin: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path: {
-				id: number;
+				type: "payment" | "shipping" | "tax";
+				id: string;
+			};
+			cookie?: never;
+		};
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["UpdateCheckoutPluginRequest"];
+			};
+		};
+		responses: {
+			/** @description Updated checkout provider catalog */
+			200: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["CheckoutPluginCatalog"];
+				};
+			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	listAdminInventoryReservations: {
+		parameters: {
+			query?: {
+				status?: ("ACTIVE" | "CONSUMED" | "RELEASED" | "EXPIRED")[];
+				limit?: number;
 			};
+			header?: never;
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Compensation retry was safely queued */
-			202: {
+			/** @description Inventory reservations */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderOperationEnvelope"];
+					"application/json": components["schemas"]["InventoryReservationList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
-			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminProviderReconciliationCases: {
+	listAdminInventoryAlerts: {
 		parameters: {
 			query?: {
-				status?: components["schemas"]["ProviderReconciliationCaseStatus"];
-				provider_type?: "payment" | "shipping" | "tax";
-				provider_id?: string;
-				case_type?: string;
-				page?: number;
+				status?: ("OPEN" | "ACKED" | "RESOLVED")[];
 				limit?: number;
 			};
 			header?: never;
@@ -8296,13 +13915,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Provider reconciliation cases */
+			/** @description Inventory alerts */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderReconciliationCasePage"];
+					"application/json": components["schemas"]["InventoryAlertList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8311,7 +13930,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAdminProviderReconciliationCase: {
+	ackAdminInventoryAlert: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8322,23 +13941,22 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Provider reconciliation case detail */
+			/** @description Acknowledged inventory alert */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderReconciliationCaseEnvelope"];
+					"application/json": components["schemas"]["InventoryAlert"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateAdminProviderReconciliationCase: {
+	resolveAdminInventoryAlert: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8347,36 +13965,27 @@ export interface operations {
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["ProviderReconciliationCaseUpdate"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Updated provider reconciliation case */
+			/** @description Resolved inventory alert */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderReconciliationCaseEnvelope"];
+					"application/json": components["schemas"]["InventoryAlert"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
-			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminProviderReconciliationRuns: {
+	listAdminInventoryThresholds: {
 		parameters: {
 			query?: {
-				provider_type?: "payment" | "shipping" | "tax";
-				provider_id?: string;
-				page?: number;
-				limit?: number;
+				product_variant_id?: number;
 			};
 			header?: never;
 			path?: never;
@@ -8384,13 +13993,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Provider reconciliation runs */
+			/** @description Inventory thresholds */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderReconciliationRunPage"];
+					"application/json": components["schemas"]["InventoryThresholdList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8399,7 +14008,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminProviderReconciliationRun: {
+	upsertAdminInventoryThreshold: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8408,17 +14017,17 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["ProviderReconciliationRunRequest"];
+				"application/json": components["schemas"]["InventoryThresholdRequest"];
 			};
 		};
 		responses: {
-			/** @description Provider reconciliation run */
-			201: {
+			/** @description Inventory threshold */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderReconciliationRunEnvelope"];
+					"application/json": components["schemas"]["InventoryThreshold"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8427,7 +14036,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	getAdminProviderReconciliationRun: {
+	deleteAdminInventoryThreshold: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8438,73 +14047,94 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Provider reconciliation run details */
+			/** @description Inventory threshold deleted */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["ProviderReconciliationRunEnvelope"];
+					"application/json": components["schemas"]["MessageResponse"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
-			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	receiveWebhookEvent: {
+	createAdminInventoryAdjustment: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				provider: string;
-			};
+			path?: never;
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": {
-					[key: string]: unknown;
+				"application/json": components["schemas"]["InventoryAdjustmentRequest"];
+			};
+		};
+		responses: {
+			/** @description Inventory adjustment created */
+			201: {
+				headers: {
+					[name: string]: unknown;
+				};
+				content: {
+					"application/json": components["schemas"]["InventoryAdjustmentResponse"];
 				};
 			};
+			400: components["responses"]["BadRequestProblem"];
+			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			500: components["responses"]["InternalServerErrorProblem"];
+		};
+	};
+	runAdminInventoryReconciliation: {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Webhook accepted */
+			/** @description Inventory reconciliation report */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["WebhookIngestResponse"];
+					"application/json": components["schemas"]["InventoryReconciliationReport"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
+			403: components["responses"]["ForbiddenProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listUsers: {
+	getAdminInventoryTimeline: {
 		parameters: {
 			query?: {
-				page?: number;
 				limit?: number;
-				q?: string;
 			};
 			header?: never;
-			path?: never;
+			path: {
+				product_variant_id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Users page */
+			/** @description Inventory timeline */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["UserPage"];
+					"application/json": components["schemas"]["InventoryTimeline"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8513,28 +14143,24 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateUserRole: {
+	listAdminInventoryLevels: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path: {
-				id: number;
+				product_variant_id: number;
 			};
 			cookie?: never;
 		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["UpdateUserRoleRequest"];
-			};
-		};
+		requestBody?: never;
 		responses: {
-			/** @description Updated user */
+			/** @description Inventory levels per stock location */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["User"];
+					"application/json": components["schemas"]["InventoryLocationLevelList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8543,7 +14169,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminCheckoutPlugins: {
+	listAdminStockLocations: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8552,13 +14178,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Checkout providers including disabled ones */
+			/** @description Stock locations */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutPluginCatalog"];
+					"application/json": components["schemas"]["StockLocationList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8567,68 +14193,72 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	updateAdminCheckoutPlugin: {
+	createAdminStockLocation: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				type: "payment" | "shipping" | "tax";
-				id: string;
-			};
+			path?: never;
 			cookie?: never;
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["UpdateCheckoutPluginRequest"];
+				"application/json": components["schemas"]["StockLocationRequest"];
 			};
 		};
 		responses: {
-			/** @description Updated checkout provider catalog */
-			200: {
+			/** @description Stock location created */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["CheckoutPluginCatalog"];
+					"application/json": components["schemas"]["StockLocation"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminInventoryReservations: {
+	updateAdminStockLocation: {
 		parameters: {
-			query?: {
-				status?: ("ACTIVE" | "CONSUMED" | "RELEASED" | "EXPIRED")[];
-				limit?: number;
-			};
+			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["StockLocationUpdateRequest"];
+			};
+		};
 		responses: {
-			/** @description Inventory reservations */
+			/** @description Stock location updated */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["InventoryReservationList"];
+					"application/json": components["schemas"]["StockLocation"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminInventoryAlerts: {
+	listAdminInventoryTransfers: {
 		parameters: {
 			query?: {
-				status?: ("OPEN" | "ACKED" | "RESOLVED")[];
+				/** @description Filter by transfer status (DRAFT, IN_TRANSIT, RECEIVED or CANCELLED). */
+				status?: string[];
 				limit?: number;
 			};
 			header?: never;
@@ -8637,13 +14267,13 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Inventory alerts */
+			/** @description Inventory transfers */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["InventoryAlertList"];
+					"application/json": components["schemas"]["InventoryTransferList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8652,33 +14282,36 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	ackAdminInventoryAlert: {
+	createAdminInventoryTransfer: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				id: number;
-			};
+			path?: never;
 			cookie?: never;
 		};
-		requestBody?: never;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["InventoryTransferRequest"];
+			};
+		};
 		responses: {
-			/** @description Acknowledged inventory alert */
-			200: {
+			/** @description Inventory transfer drafted */
+			201: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["InventoryAlert"];
+					"application/json": components["schemas"]["InventoryTransfer"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	resolveAdminInventoryAlert: {
+	shipAdminInventoryTransfer: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8689,93 +14322,95 @@ export interface operations {
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Resolved inventory alert */
+			/** @description Inventory transfer shipped */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["InventoryAlert"];
+					"application/json": components["schemas"]["InventoryTransfer"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	listAdminInventoryThresholds: {
+	receiveAdminInventoryTransfer: {
 		parameters: {
-			query?: {
-				product_variant_id?: number;
-			};
+			query?: never;
 			header?: never;
-			path?: never;
+			path: {
+				id: number;
+			};
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Inventory thresholds */
+			/** @description Inventory transfer received */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["InventoryThresholdList"];
+					"application/json": components["schemas"]["InventoryTransfer"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	upsertAdminInventoryThreshold: {
+	cancelAdminInventoryTransfer: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		requestBody: {
-			content: {
-				"application/json": components["schemas"]["InventoryThresholdRequest"];
+			path: {
+				id: number;
 			};
+			cookie?: never;
 		};
+		requestBody?: never;
 		responses: {
-			/** @description Inventory threshold */
+			/** @description Inventory transfer cancelled */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["InventoryThreshold"];
+					"application/json": components["schemas"]["InventoryTransfer"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
+			409: components["responses"]["ConflictProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	deleteAdminInventoryThreshold: {
+	listAdminOrderRoutingRules: {
 		parameters: {
 			query?: never;
 			header?: never;
-			path: {
-				id: number;
-			};
+			path?: never;
 			cookie?: never;
 		};
 		requestBody?: never;
 		responses: {
-			/** @description Inventory threshold deleted */
+			/** @description Order routing rules in the order they are applied */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["MessageResponse"];
+					"application/json": components["schemas"]["OrderRoutingRuleList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8784,7 +14419,7 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	createAdminInventoryAdjustment: {
+	setAdminOrderRoutingRules: {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -8793,17 +14428,17 @@ export interface operations {
 		};
 		requestBody: {
 			content: {
-				"application/json": components["schemas"]["InventoryAdjustmentRequest"];
+				"application/json": components["schemas"]["OrderRoutingRulesRequest"];
 			};
 		};
 		responses: {
-			/** @description Inventory adjustment created */
-			201: {
+			/** @description Order routing rules replaced */
+			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["InventoryAdjustmentResponse"];
+					"application/json": components["schemas"]["OrderRoutingRuleList"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8812,50 +14447,26 @@ export interface operations {
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
-	runAdminInventoryReconciliation: {
+	simulateAdminOrderRouting: {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		requestBody?: never;
-		responses: {
-			/** @description Inventory reconciliation report */
-			200: {
-				headers: {
-					[name: string]: unknown;
-				};
-				content: {
-					"application/json": components["schemas"]["InventoryReconciliationReport"];
-				};
-			};
-			400: components["responses"]["BadRequestProblem"];
-			401: components["responses"]["AuthenticationRequiredProblem"];
-			403: components["responses"]["ForbiddenProblem"];
-			500: components["responses"]["InternalServerErrorProblem"];
-		};
-	};
-	getAdminInventoryTimeline: {
-		parameters: {
-			query?: {
-				limit?: number;
-			};
-			header?: never;
-			path: {
-				product_variant_id: number;
+		requestBody: {
+			content: {
+				"application/json": components["schemas"]["OrderRoutingSimulationRequest"];
 			};
-			cookie?: never;
 		};
-		requestBody?: never;
 		responses: {
-			/** @description Inventory timeline */
+			/** @description Routing plan for the cart */
 			200: {
 				headers: {
 					[name: string]: unknown;
 				};
 				content: {
-					"application/json": components["schemas"]["InventoryTimeline"];
+					"application/json": components["schemas"]["OrderRoutingPlan"];
 				};
 			};
 			400: components["responses"]["BadRequestProblem"];
@@ -8997,6 +14608,7 @@ export interface operations {
 			400: components["responses"]["BadRequestProblem"];
 			401: components["responses"]["AuthenticationRequiredProblem"];
 			403: components["responses"]["ForbiddenProblem"];
+			404: components["responses"]["NotFoundProblem"];
 			500: components["responses"]["InternalServerErrorProblem"];
 		};
 	};
@@ -94,6 +94,9 @@ func (m *Manager) LoadExternalPluginsFromDir(dir string) (int, error) {
 		if err != nil {
 			return registered, err
 		}
+		if manifest.Type == ProviderTypeRisk {
+			continue
+		}
 		if err := m.registerExternalManifest(manifest); err != nil {
 			return registered, err
 		}
@@ -125,7 +128,7 @@ func readExternalPluginManifest(path string) (externalPluginManifest, error) {
 	if manifest.ID == "" || manifest.Name == "" || manifest.Command == "" {
 		return externalPluginManifest{}, fmt.Errorf("invalid checkout plugin manifest %s: id, name, and command are required", path)
 	}
-	if manifest.Type != ProviderTypePayment && manifest.Type != ProviderTypeShipping && manifest.Type != ProviderTypeTax {
+	if manifest.Type != ProviderTypePayment && manifest.Type != ProviderTypeShipping && manifest.Type != ProviderTypeTax && manifest.Type != ProviderTypeRisk {
 		return externalPluginManifest{}, fmt.Errorf("invalid checkout plugin manifest %s: unsupported type %q", path, manifest.Type)
 	}
 
@@ -17,6 +17,9 @@ const (
 	ProviderTypePayment  ProviderType = "payment"
 	ProviderTypeShipping ProviderType = "shipping"
 	ProviderTypeTax      ProviderType = "tax"
+	// ProviderTypeRisk manifests supply order risk scorers. They share the
+	// provider plugin directory but are never offered as checkout options.
+	ProviderTypeRisk ProviderType = "risk"
 )
 
 type FieldType string
@@ -26,6 +29,7 @@ const (
 	FieldTypeNumber   FieldType = "number"
 	FieldTypeCheckbox FieldType = "checkbox"
 	FieldTypeSelect   FieldType = "select"
+	FieldTypeDate     FieldType = "date"
 )
 
 type Severity string
@@ -506,10 +510,6 @@ func (m *Manager) ResolveCheckoutDetails(req QuoteRequest) (CheckoutDetails, err
 	if err != nil {
 		return CheckoutDetails{}, err
 	}
-	shippingProvider, err := m.resolveProvider(ProviderTypeShipping, req.ShippingID, req.ShippingData, "shipping")
-	if err != nil {
-		return CheckoutDetails{}, err
-	}
 
 	if paymentProvider.external == nil {
 		details.PaymentDisplay = paymentDisplayFromProvider(paymentProvider.def, paymentProvider.data)
@@ -521,26 +521,41 @@ func (m *Manager) ResolveCheckoutDetails(req QuoteRequest) (CheckoutDetails, err
 		details.PaymentDisplay = strings.TrimSpace(response.PaymentDisplay)
 	}
 
-	if shippingProvider.external == nil {
-		details.ShippingAddress = shippingAddressFromProvider(shippingProvider.def, shippingProvider.data)
-	} else {
-		response, err := shippingProvider.external.resolve(req.Subtotal.Float64(), req.Subtotal.Float64(), "USD", shippingProvider.data)
-		if err != nil {
-			return CheckoutDetails{}, err
-		}
-		details.ShippingAddress = strings.TrimSpace(response.ShippingAddress)
-	}
-
 	if details.PaymentDisplay == "" {
 		return CheckoutDetails{}, fmt.Errorf("unable to resolve payment display")
 	}
-	if details.ShippingAddress == "" {
-		return CheckoutDetails{}, fmt.Errorf("unable to resolve shipping address")
+	details.ShippingAddress, err = m.ShippingAddress(req.Subtotal, req.ShippingID, req.ShippingData)
+	if err != nil {
+		return CheckoutDetails{}, err
 	}
 
 	return details, nil
 }
 
+// ShippingAddress formats the address a shipping selection delivers to. It
+// needs no payment, so orders priced before the customer pays can show where
+// they ship.
+func (m *Manager) ShippingAddress(subtotal models.Money, shippingID string, data map[string]string) (string, error) {
+	shippingProvider, err := m.resolveProvider(ProviderTypeShipping, shippingID, data, "shipping")
+	if err != nil {
+		return "", err
+	}
+	address := ""
+	if shippingProvider.external == nil {
+		address = shippingAddressFromProvider(shippingProvider.def, shippingProvider.data)
+	} else {
+		response, err := shippingProvider.external.resolve(subtotal.Float64(), subtotal.Float64(), "USD", shippingProvider.data)
+		if err != nil {
+			return "", err
+		}
+		address = strings.TrimSpace(response.ShippingAddress)
+	}
+	if address == "" {
+		return "", fmt.Errorf("unable to resolve shipping address")
+	}
+	return address, nil
+}
+
 func (m *Manager) registerPayment(def Definition) {
 	m.payments[def.ID] = def
 	m.enabled[providerSettingKey(ProviderTypePayment, def.ID)] = true
@@ -0,0 +1,103 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	checkoutservice "ecommerce/internal/services/checkout"
+	"ecommerce/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const abandonedCartReportWindow = 30 * 24 * time.Hour
+
+func (e *CheckoutProviderEndpoints) RestoreCheckoutCart(ctx context.Context, r apicontract.RestoreCheckoutCartRequestObject) (apicontract.RestoreCheckoutCartResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A recovery token is required", nil)
+	}
+	restored, err := e.checkout.RestoreAbandonedCart(ctx, e.recovery, r.Body.Token, time.Now().UTC())
+	if errors.Is(err, checkoutservice.ErrInvalidRecoveryLink) {
+		return nil, problemError(http.StatusBadRequest, "invalid_recovery_link", "This cart link is invalid or has expired.", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		setCheckoutCookie(ginCtx, checkoutSessionCookieName, restored.Session.PublicToken, true)
+		if csrf, _ := ginCtx.Cookie(checkoutCSRFCookieName); csrf == "" {
+			setCheckoutCookie(ginCtx, checkoutCSRFCookieName, uuid.NewString(), false)
+		}
+	}
+	cart, err := e.checkout.CartForSession(ctx, restored.Session.ID)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]apicontract.CartMergeLine, 0, len(restored.Lines))
+	for _, line := range restored.Lines {
+		lines = append(lines, cartMergeLineContract(line))
+	}
+	return apicontract.RestoreCheckoutCart200JSONResponse{Cart: cartContract(cart), Lines: lines}, nil
+}
+
+func (e *CheckoutProviderEndpoints) ListAdminAbandonedCarts(ctx context.Context, r apicontract.ListAdminAbandonedCartsRequestObject) (apicontract.ListAdminAbandonedCartsResponseObject, error) {
+	page, limit := cmsPagination(r.Params.Page, r.Params.Limit)
+	values, total, err := e.checkout.ListAbandonedCarts(ctx, checkoutservice.ListAbandonedCartsInput{Status: derefString(r.Params.Status), Limit: limit, Offset: (page - 1) * limit})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]apicontract.AbandonedCart, 0, len(values))
+	for _, value := range values {
+		item, err := abandonedCartContract(value)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return apicontract.ListAdminAbandonedCarts200JSONResponse{Data: items, Pagination: apicontract.Pagination{Page: page, Limit: limit, Total: int(total), TotalPages: totalPages(total, limit)}}, nil
+}
+
+func (e *CheckoutProviderEndpoints) GetAdminAbandonedCartReport(ctx context.Context, r apicontract.GetAdminAbandonedCartReportRequestObject) (apicontract.GetAdminAbandonedCartReportResponseObject, error) {
+	to := time.Now().UTC()
+	if r.Params.To != nil {
+		to = r.Params.To.UTC()
+	}
+	from := to.Add(-abandonedCartReportWindow)
+	if r.Params.From != nil {
+		from = r.Params.From.UTC()
+	}
+	if !from.Before(to) {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "from must be before to", nil)
+	}
+	report, err := e.checkout.AbandonedCartReport(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.GetAdminAbandonedCartReport200JSONResponse{
+		From: report.From, To: report.To,
+		Detected: int(report.Detected), Notified: int(report.Notified), Restored: int(report.Restored), Recovered: int(report.Recovered),
+		AbandonedValue: report.AbandonedValue.Float64(), RecoveredRevenue: report.RecoveredRevenue.Float64(),
+	}, nil
+}
+
+func abandonedCartContract(value models.AbandonedCart) (apicontract.AbandonedCart, error) {
+	var snapshot []models.AbandonedCartItem
+	if err := json.Unmarshal([]byte(value.ItemsJSON), &snapshot); err != nil {
+		return apicontract.AbandonedCart{}, err
+	}
+	items := make([]apicontract.AbandonedCartItem, 0, len(snapshot))
+	for _, item := range snapshot {
+		items = append(items, apicontract.AbandonedCartItem{ProductVariantId: int(item.ProductVariantID), Sku: item.SKU, Title: item.Title, ProductName: item.ProductName, Quantity: item.Quantity, Price: item.Price.Float64()})
+	}
+	return apicontract.AbandonedCart{
+		Id: int(value.ID), CheckoutSessionId: int(value.CheckoutSessionID), UserId: optionalUint(value.UserID), Email: value.Email,
+		Items: items, ItemCount: value.ItemCount, Subtotal: value.Subtotal.Float64(), Status: value.Status, DetectedAt: value.DetectedAt,
+		Attempts: value.Attempts, LastError: optionalString(value.LastError), NotifiedAt: value.NotifiedAt, RestoreCount: value.RestoreCount,
+		RestoredAt: value.RestoredAt, RecoveredOrderId: optionalUint(value.RecoveredOrderID), RecoveredAt: value.RecoveredAt,
+	}, nil
+}
@@ -17,14 +17,15 @@ import (
 	catalogadminservice "ecommerce/internal/services/catalogadmin"
 	discountservice "ecommerce/internal/services/discounts"
 	inventoryservice "ecommerce/internal/services/inventory"
+	segmentservice "ecommerce/internal/services/segments"
 	"ecommerce/models"
 
 	"gorm.io/gorm"
 )
 
 // CatalogEndpoints is an embeddable strict endpoint family for storefront
-// catalog, administrative catalog, inventory, purchase order, and discount
-// operations. It contains no Gin handlers and can be embedded in a composed
+// catalog, administrative catalog, inventory, purchase order, discount, and
+// customer segment operations. It contains no Gin handlers and can be embedded in a composed
 // StrictServerInterface implementation.
 type CatalogEndpoints struct {
 	db           *gorm.DB
@@ -33,6 +34,7 @@ type CatalogEndpoints struct {
 	catalogAdmin *catalogadminservice.Service
 	inventory    *inventoryservice.Service
 	discounts    *discountservice.Service
+	segments     *segmentservice.Service
 }
 
 func NewCatalogEndpoints(db *gorm.DB, mediaService *media.Service) (*CatalogEndpoints, error) {
@@ -46,6 +48,7 @@ func NewCatalogEndpoints(db *gorm.DB, mediaService *media.Service) (*CatalogEndp
 		catalogAdmin: catalogadminservice.NewService(db, mediaService),
 		inventory:    inventoryservice.NewService(db),
 		discounts:    discountservice.NewService(db),
+		segments:     segmentservice.NewService(db),
 	}, nil
 }
 
@@ -262,7 +265,7 @@ func (e *CatalogEndpoints) productToContract(ctx context.Context, product models
 			converted := value.CompareAtPrice.Float64()
 			compareAt = &converted
 		}
-		variants = append(variants, apicontract.ProductVariant{Id: &id, Sku: value.SKU, Title: value.Title, Price: price, CompareAtPrice: compareAt, Stock: value.Stock, Position: value.Position, IsPublished: value.IsPublished, WeightGrams: value.WeightGrams, LengthCm: value.LengthCm, WidthCm: value.WidthCm, HeightCm: value.HeightCm, Selections: []apicontract.ProductVariantSelection{}})
+		variants = append(variants, apicontract.ProductVariant{Id: &id, Sku: value.SKU, Title: value.Title, Price: price, CompareAtPrice: compareAt, Stock: value.Stock, Position: value.Position, IsPublished: value.IsPublished, WeightGrams: value.WeightGrams, LengthCm: value.LengthCm, WidthCm: value.WidthCm, HeightCm: value.HeightCm, MinQuantity: value.MinQuantity, MaxQuantity: value.MaxQuantity, QuantityStep: value.QuantityStep, PurchaseLimit: value.PurchaseLimit, InventoryPolicy: value.InventoryPolicy, BackorderLimit: value.BackorderLimit, PreorderReleaseAt: value.PreorderReleaseAt, PromisedShipAt: value.PromisedShipDate(), IsGiftCard: value.IsGiftCard, Selections: []apicontract.ProductVariantSelection{}})
 	}
 	var defaultVariantID *int
 	if product.DefaultVariantID != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"time"
 
 	"ecommerce/internal/apicontract"
@@ -70,18 +71,46 @@ func (e *CatalogEndpoints) ScheduleAdminDiscountCampaign(ctx context.Context, re
 	if request.Id < 1 || request.Body == nil {
 		return nil, errors.New("valid discount campaign id and schedule body are required")
 	}
-	recurrence, timezone := "", ""
-	if request.Body.Recurrence != nil {
-		recurrence = string(*request.Body.Recurrence)
+	value, err := e.discounts.UpsertSchedule(ctx, uint(request.Id), discountScheduleInput(*request.Body), time.Now().UTC())
+	if err != nil {
+		return nil, err
 	}
-	if request.Body.Timezone != nil {
-		timezone = *request.Body.Timezone
+	return apicontract.ScheduleAdminDiscountCampaign200JSONResponse(discountScheduleContract(value)), nil
+}
+func (e *CatalogEndpoints) GetAdminDiscountCampaignBudget(ctx context.Context, request apicontract.GetAdminDiscountCampaignBudgetRequestObject) (apicontract.GetAdminDiscountCampaignBudgetResponseObject, error) {
+	if request.Id < 1 {
+		return nil, errors.New("discount campaign id must be positive")
 	}
-	value, err := e.discounts.UpsertSchedule(ctx, uint(request.Id), discountservice.ScheduleInput{ScheduleType: string(request.Body.ScheduleType), Recurrence: recurrence, WindowStart: request.Body.WindowStart, WindowEnd: request.Body.WindowEnd, UntilAt: request.Body.UntilAt, Timezone: timezone}, time.Now().UTC())
+	value, err := e.discounts.BudgetStatus(ctx, uint(request.Id))
 	if err != nil {
 		return nil, err
 	}
-	return apicontract.ScheduleAdminDiscountCampaign200JSONResponse(discountScheduleContract(value)), nil
+	return apicontract.GetAdminDiscountCampaignBudget200JSONResponse(discountBudgetStatusContract(value)), nil
+}
+func (e *CatalogEndpoints) PreviewAdminDiscountSchedule(ctx context.Context, request apicontract.PreviewAdminDiscountScheduleRequestObject) (apicontract.PreviewAdminDiscountScheduleResponseObject, error) {
+	if request.Body == nil {
+		return nil, errors.New("schedule preview body is required")
+	}
+	from := time.Now().UTC()
+	if request.Body.From != nil {
+		from = request.Body.From.UTC()
+	}
+	count := 10
+	if request.Body.Count != nil {
+		count = *request.Body.Count
+	}
+	values, err := e.discounts.PreviewSchedule(ctx, discountScheduleInput(request.Body.Schedule), from, count)
+	if errors.Is(err, discountservice.ErrInvalidCampaign) {
+		return nil, problemError(http.StatusBadRequest, "invalid_discount_schedule", err.Error(), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	windows := make([]apicontract.DiscountScheduleOccurrence, 0, len(values))
+	for _, value := range values {
+		windows = append(windows, apicontract.DiscountScheduleOccurrence{Start: value.Start, End: value.End})
+	}
+	return apicontract.PreviewAdminDiscountSchedule200JSONResponse{Windows: windows}, nil
 }
 func (e *CatalogEndpoints) RunAdminDiscountLifecycle(ctx context.Context, _ apicontract.RunAdminDiscountLifecycleRequestObject) (apicontract.RunAdminDiscountLifecycleResponseObject, error) {
 	value, err := e.discounts.RunLifecycle(ctx, time.Now().UTC())
@@ -181,12 +210,33 @@ func (e *CatalogEndpoints) PreviewAdminPromotion(ctx context.Context, request ap
 	if request.Body.CustomerSegment != nil {
 		options.CustomerSegment = *request.Body.CustomerSegment
 	}
+	if request.Body.CustomerId != nil {
+		customerID := uint(*request.Body.CustomerId)
+		options.CustomerID = &customerID
+	}
 	value, err := e.discounts.Preview(ctx, lines, time.Now().UTC(), options)
 	if err != nil {
 		return nil, err
 	}
 	return apicontract.PreviewAdminPromotion200JSONResponse(promotionPreviewContract(value)), nil
 }
+func (e *CatalogEndpoints) SimulateAdminPromotion(ctx context.Context, request apicontract.SimulateAdminPromotionRequestObject) (apicontract.SimulateAdminPromotionResponseObject, error) {
+	if request.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A promotion simulation body is required.", nil)
+	}
+	input := discountservice.SimulationInput{Campaign: promotionInput(request.Body.Campaign), From: request.Body.From, To: request.Body.To}
+	if request.Body.Channel != nil {
+		input.Channel = *request.Body.Channel
+	}
+	value, err := e.discounts.Simulate(ctx, input)
+	if errors.Is(err, discountservice.ErrInvalidCampaign) {
+		return nil, problemError(http.StatusBadRequest, "invalid_promotion_simulation", err.Error(), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.SimulateAdminPromotion200JSONResponse{From: value.From, To: value.To, OrdersEvaluated: value.OrdersEvaluated, AffectedOrders: value.AffectedOrders, DiscountTotal: value.DiscountTotal.Float64(), ActualRevenue: value.ActualRevenue.Float64(), SimulatedRevenue: value.SimulatedRevenue.Float64(), ActualAov: value.ActualAOV.Float64(), SimulatedAov: value.SimulatedAOV.Float64(), AovChange: value.AOVChange.Float64(), AovChangePercent: value.AOVChangePercent}, nil
+}
 func (e *CatalogEndpoints) ListAdminPromotionTemplates(ctx context.Context, request apicontract.ListAdminPromotionTemplatesRequestObject) (apicontract.ListAdminPromotionTemplatesResponseObject, error) {
 	active := true
 	if request.Params.Active != nil {
@@ -239,6 +289,9 @@ func optionalPositiveID(value *int) (*uint, error) {
 	converted := uint(*value)
 	return &converted, nil
 }
+func discountScheduleInput(value apicontract.DiscountScheduleInput) discountservice.ScheduleInput {
+	return discountservice.ScheduleInput{ScheduleType: string(value.ScheduleType), Recurrence: derefString(value.Recurrence), WindowStart: value.WindowStart, WindowEnd: value.WindowEnd, UntilAt: value.UntilAt, Timezone: derefString(value.Timezone)}
+}
 func productDiscountInput(value apicontract.ProductDiscountInput) discountservice.ProductDiscountInput {
 	result := discountservice.ProductDiscountInput{Name: value.Name, ProductIDs: uints(value.ProductIds), DiscountMode: string(value.DiscountMode), DiscountValue: models.MoneyFromFloat(value.DiscountValue), StartsAt: value.StartsAt, EndsAt: value.EndsAt, CouponCode: value.CouponCode, GlobalUsageCap: value.GlobalUsageCap, PerCustomerUsageCap: value.PerCustomerUsageCap}
 	if value.Priority != nil {
@@ -261,6 +314,13 @@ func productDiscountInput(value apicontract.ProductDiscountInput) discountservic
 			result.Channels = append(result.Channels, string(item))
 		}
 	}
+	if value.BudgetAmount != nil {
+		amount := models.MoneyFromFloat(*value.BudgetAmount)
+		result.BudgetAmount = &amount
+	}
+	if value.BudgetAlertThresholds != nil {
+		result.BudgetThresholds = *value.BudgetAlertThresholds
+	}
 	return result
 }
 func promotionInput(value apicontract.PromotionInput) discountservice.CreatePromotionInput {
@@ -285,6 +345,13 @@ func promotionInput(value apicontract.PromotionInput) discountservice.CreateProm
 			result.Channels = append(result.Channels, string(item))
 		}
 	}
+	if value.BudgetAmount != nil {
+		amount := models.MoneyFromFloat(*value.BudgetAmount)
+		result.BudgetAmount = &amount
+	}
+	if value.BudgetAlertThresholds != nil {
+		result.BudgetThresholds = *value.BudgetAlertThresholds
+	}
 	if value.Rules != nil {
 		for _, item := range *value.Rules {
 			stack := ""
@@ -386,7 +453,8 @@ func discountCampaignContract(value models.DiscountCampaign) apicontract.Discoun
 	}
 	metadata := map[string]any{}
 	_ = json.Unmarshal([]byte(value.MetadataJSON), &metadata)
-	return apicontract.DiscountCampaign{Id: int(value.ID), Name: value.Name, Type: apicontract.DiscountCampaignType(value.Type), Status: apicontract.DiscountCampaignStatus(value.Status), StartsAt: value.StartsAt, EndsAt: value.EndsAt, Priority: value.Priority, IsExclusive: value.IsExclusive, DiscountMode: apicontract.DiscountCampaignDiscountMode(value.DiscountMode), DiscountValue: value.DiscountValue.Float64(), Targets: targets, Metadata: &metadata, CouponCode: value.CouponCode, Channels: &channels, CustomerSegment: optionalString(value.CustomerSegment), GlobalUsageCap: value.GlobalUsageCap, PerCustomerUsageCap: value.PerCustomerUsageCap, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
+	thresholds := discountservice.BudgetThresholds(value)
+	return apicontract.DiscountCampaign{Id: int(value.ID), Name: value.Name, Type: apicontract.DiscountCampaignType(value.Type), Status: apicontract.DiscountCampaignStatus(value.Status), StartsAt: value.StartsAt, EndsAt: value.EndsAt, Priority: value.Priority, IsExclusive: value.IsExclusive, DiscountMode: apicontract.DiscountCampaignDiscountMode(value.DiscountMode), DiscountValue: value.DiscountValue.Float64(), Targets: targets, Metadata: &metadata, CouponCode: value.CouponCode, Channels: &channels, CustomerSegment: optionalString(value.CustomerSegment), GlobalUsageCap: value.GlobalUsageCap, PerCustomerUsageCap: value.PerCustomerUsageCap, BudgetAmount: optionalMoney(value.BudgetAmount), BudgetAlertThresholds: &thresholds, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
 }
 func discountScheduleContract(value models.DiscountSchedule) apicontract.DiscountSchedule {
 	return apicontract.DiscountSchedule{Id: int(value.ID), CampaignId: int(value.CampaignID), ScheduleType: apicontract.DiscountScheduleScheduleType(value.ScheduleType), Recurrence: optionalString(value.RRule), WindowStart: value.WindowStart, WindowEnd: value.WindowEnd, UntilAt: value.UntilAt, Timezone: value.Timezone, LastRunAt: value.LastRunAt, NextRunAt: value.NextRunAt}
@@ -394,6 +462,20 @@ func discountScheduleContract(value models.DiscountSchedule) apicontract.Discoun
 func discountHistoryContract(value models.DiscountStateHistory) apicontract.DiscountStateHistory {
 	return apicontract.DiscountStateHistory{Id: int(value.ID), CampaignId: int(value.CampaignID), FromStatus: value.FromStatus, ToStatus: value.ToStatus, Source: value.Source, Actor: value.Actor, Reason: value.Reason, ChangedAt: value.ChangedAt}
 }
+func discountBudgetStatusContract(value discountservice.BudgetStatus) apicontract.DiscountBudgetStatus {
+	alerts := make([]apicontract.DiscountBudgetAlert, 0, len(value.Alerts))
+	for _, alert := range value.Alerts {
+		alerts = append(alerts, apicontract.DiscountBudgetAlert{Id: int(alert.ID), CampaignId: int(alert.CampaignID), ThresholdPercent: alert.ThresholdPercent, BudgetAmount: alert.BudgetAmount.Float64(), SpentAmount: alert.SpentAmount.Float64(), TriggeredAt: alert.TriggeredAt})
+	}
+	return apicontract.DiscountBudgetStatus{CampaignId: int(value.CampaignID), Status: value.Status, BudgetAmount: optionalMoney(value.BudgetAmount), SpentAmount: value.SpentAmount.Float64(), RemainingAmount: optionalMoney(value.RemainingAmount), AlertThresholds: value.AlertThresholds, Alerts: alerts}
+}
+func optionalMoney(value *models.Money) *float64 {
+	if value == nil {
+		return nil
+	}
+	amount := value.Float64()
+	return &amount
+}
 func discountAuditContract(value models.DiscountCampaignAudit) apicontract.DiscountCampaignAudit {
 	return apicontract.DiscountCampaignAudit{Id: int(value.ID), CampaignId: int(value.CampaignID), EventType: value.EventType, Source: value.Source, Actor: value.Actor, Summary: value.Summary, BeforeJson: value.BeforeJSON, AfterJson: value.AfterJSON, ChangedAt: value.ChangedAt}
 }
@@ -132,6 +132,13 @@ func (e *CatalogEndpoints) CreateAdminInventoryAdjustment(ctx context.Context, r
 		value := uint(*request.Body.ApprovedById)
 		input.ApprovedByID = &value
 	}
+	if request.Body.LocationCode != nil {
+		location, err := e.inventory.ResolveLocation(ctx, *request.Body.LocationCode)
+		if err != nil {
+			return nil, inventoryEndpointError(err)
+		}
+		input.StockLocationID = location.ID
+	}
 	adjustment, availability, err := e.inventory.CreateAdjustment(ctx, input, inventoryservice.AdjustmentPolicy{})
 	if err != nil {
 		return nil, err
@@ -221,11 +228,14 @@ func (e *CatalogEndpoints) ReceiveAdminPurchaseOrder(ctx context.Context, reques
 	if request.Id < 1 || request.Body == nil {
 		return nil, errors.New("valid purchase order id and receipt body are required")
 	}
-	input := inventoryservice.ReceivePurchaseOrderInput{Notes: derefString(request.Body.Notes), ActorType: "admin"}
+	input := inventoryservice.ReceivePurchaseOrderInput{Notes: derefString(request.Body.Notes), LocationCode: derefString(request.Body.LocationCode), ActorType: "admin"}
 	for _, item := range request.Body.Items {
 		input.Items = append(input.Items, inventoryservice.ReceiveItemInput{PurchaseOrderItemID: uint(item.PurchaseOrderItemId), QuantityReceived: item.QuantityReceived})
 	}
 	receipt, order, err := e.inventory.ReceivePurchaseOrder(ctx, uint(request.Id), input)
+	if errors.Is(err, inventoryservice.ErrStockLocationNotFound) {
+		return nil, inventoryEndpointError(err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -253,7 +263,7 @@ func derefString(value *string) string {
 }
 
 func inventoryReservationContract(value models.InventoryReservation) apicontract.InventoryReservation {
-	return apicontract.InventoryReservation{Id: int(value.ID), ProductVariantId: int(value.ProductVariantID), Quantity: value.Quantity, Status: apicontract.InventoryReservationStatus(value.Status), ExpiresAt: value.ExpiresAt, OwnerType: value.OwnerType, OwnerId: optionalUint(value.OwnerID), CheckoutSessionId: optionalUint(value.CheckoutSessionID), OrderId: optionalUint(value.OrderID), CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
+	return apicontract.InventoryReservation{Id: int(value.ID), ProductVariantId: int(value.ProductVariantID), Quantity: value.Quantity, Status: apicontract.InventoryReservationStatus(value.Status), ExpiresAt: value.ExpiresAt, OwnerType: value.OwnerType, OwnerId: optionalUint(value.OwnerID), CheckoutSessionId: optionalUint(value.CheckoutSessionID), OrderId: optionalUint(value.OrderID), Backordered: &value.Backordered, StockLocationId: stockLocationID(value.StockLocationID), AllocatedAt: value.AllocatedAt, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
 }
 func inventoryAlertContract(value models.InventoryAlert) apicontract.InventoryAlert {
 	return apicontract.InventoryAlert{Id: int(value.ID), ProductVariantId: int(value.ProductVariantID), AlertType: apicontract.InventoryAlertAlertType(value.AlertType), Status: apicontract.InventoryAlertStatus(value.Status), Available: value.Available, Threshold: value.Threshold, OpenedAt: value.OpenedAt, AckedAt: value.AckedAt, AckedByType: optionalString(value.AckedByType), AckedById: optionalUint(value.AckedByID), ResolvedAt: value.ResolvedAt, ResolvedByType: optionalString(value.ResolvedByType), ResolvedById: optionalUint(value.ResolvedByID), CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
@@ -268,13 +278,13 @@ func inventoryThresholdContract(value models.InventoryThreshold) apicontract.Inv
 	return apicontract.InventoryThreshold{Id: int(value.ID), ProductVariantId: optionalUint(value.ProductVariantID), LowStockQuantity: value.LowStockQuantity, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
 }
 func inventoryAdjustmentContract(value models.InventoryAdjustment) apicontract.InventoryAdjustment {
-	return apicontract.InventoryAdjustment{Id: int(value.ID), InventoryItemId: int(value.InventoryItemID), ProductVariantId: int(value.ProductVariantID), QuantityDelta: value.QuantityDelta, ReasonCode: apicontract.InventoryAdjustmentReason(value.ReasonCode), Notes: value.Notes, ActorType: value.ActorType, ActorId: optionalUint(value.ActorID), ApprovedByType: value.ApprovedByType, ApprovedById: optionalUint(value.ApprovedByID), ApprovedAt: value.ApprovedAt, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
+	return apicontract.InventoryAdjustment{Id: int(value.ID), InventoryItemId: int(value.InventoryItemID), ProductVariantId: int(value.ProductVariantID), QuantityDelta: value.QuantityDelta, ReasonCode: apicontract.InventoryAdjustmentReason(value.ReasonCode), Notes: value.Notes, ActorType: value.ActorType, ActorId: optionalUint(value.ActorID), ApprovedByType: value.ApprovedByType, ApprovedById: optionalUint(value.ApprovedByID), ApprovedAt: value.ApprovedAt, StockLocationId: stockLocationID(value.StockLocationID), CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
 }
 func inventoryAvailabilityContract(value inventoryservice.Availability) apicontract.InventoryAvailability {
 	return apicontract.InventoryAvailability{ProductVariantId: int(value.ProductVariantID), OnHand: value.OnHand, Reserved: value.Reserved, Available: value.Available}
 }
 func inventoryMovementContract(value models.InventoryMovement) apicontract.InventoryMovement {
-	return apicontract.InventoryMovement{Id: int(value.ID), InventoryItemId: int(value.InventoryItemID), MovementType: value.MovementType, QuantityDelta: value.QuantityDelta, ReferenceType: value.ReferenceType, ReferenceId: optionalUint(value.ReferenceID), ReasonCode: value.ReasonCode, ActorType: value.ActorType, ActorId: optionalUint(value.ActorID), CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
+	return apicontract.InventoryMovement{Id: int(value.ID), InventoryItemId: int(value.InventoryItemID), MovementType: value.MovementType, QuantityDelta: value.QuantityDelta, ReferenceType: value.ReferenceType, ReferenceId: optionalUint(value.ReferenceID), ReasonCode: value.ReasonCode, ActorType: value.ActorType, ActorId: optionalUint(value.ActorID), StockLocationId: stockLocationID(value.StockLocationID), CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
 }
 
 func purchaseOrderInput(request apicontract.PurchaseOrderRequest) inventoryservice.PurchaseOrderInput {
@@ -311,5 +321,5 @@ func receiptContract(value models.InventoryReceipt) apicontract.InventoryReceipt
 	for _, item := range value.Items {
 		items = append(items, apicontract.InventoryReceiptItem{Id: int(item.ID), PurchaseOrderItemId: int(item.PurchaseOrderItemID), ProductVariantId: int(item.ProductVariantID), QuantityReceived: item.QuantityReceived})
 	}
-	return apicontract.InventoryReceipt{Id: int(value.ID), PurchaseOrderId: int(value.PurchaseOrderID), ReceivedAt: value.ReceivedAt, Notes: value.Notes, Items: items}
+	return apicontract.InventoryReceipt{Id: int(value.ID), PurchaseOrderId: int(value.PurchaseOrderID), ReceivedAt: value.ReceivedAt, Notes: value.Notes, StockLocationId: stockLocationID(value.StockLocationID), Items: items}
 }
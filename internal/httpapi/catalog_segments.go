@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	segmentservice "ecommerce/internal/services/segments"
+	"ecommerce/models"
+)
+
+func (e *CatalogEndpoints) ListAdminCustomerSegments(ctx context.Context, request apicontract.ListAdminCustomerSegmentsRequestObject) (apicontract.ListAdminCustomerSegmentsResponseObject, error) {
+	activeOnly := request.Params.Active != nil && *request.Params.Active
+	values, err := e.segments.List(ctx, activeOnly)
+	if err != nil {
+		return nil, segmentEndpointError(err)
+	}
+	items := make([]apicontract.CustomerSegment, 0, len(values))
+	for _, value := range values {
+		items = append(items, customerSegmentContract(value))
+	}
+	return apicontract.ListAdminCustomerSegments200JSONResponse{Segments: items}, nil
+}
+func (e *CatalogEndpoints) CreateAdminCustomerSegment(ctx context.Context, request apicontract.CreateAdminCustomerSegmentRequestObject) (apicontract.CreateAdminCustomerSegmentResponseObject, error) {
+	if request.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A customer segment body is required.", errors.New("customer segment body is required"))
+	}
+	value, err := e.segments.Create(ctx, customerSegmentInput(ctx, *request.Body))
+	if err != nil {
+		return nil, segmentEndpointError(err)
+	}
+	return apicontract.CreateAdminCustomerSegment201JSONResponse(customerSegmentContract(value)), nil
+}
+func (e *CatalogEndpoints) GetAdminCustomerSegment(ctx context.Context, request apicontract.GetAdminCustomerSegmentRequestObject) (apicontract.GetAdminCustomerSegmentResponseObject, error) {
+	value, err := e.segments.Get(ctx, uint(request.Id))
+	if err != nil {
+		return nil, segmentEndpointError(err)
+	}
+	return apicontract.GetAdminCustomerSegment200JSONResponse(customerSegmentContract(value)), nil
+}
+func (e *CatalogEndpoints) UpdateAdminCustomerSegment(ctx context.Context, request apicontract.UpdateAdminCustomerSegmentRequestObject) (apicontract.UpdateAdminCustomerSegmentResponseObject, error) {
+	if request.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A customer segment body is required.", errors.New("customer segment body is required"))
+	}
+	value, err := e.segments.Update(ctx, uint(request.Id), customerSegmentInput(ctx, *request.Body))
+	if err != nil {
+		return nil, segmentEndpointError(err)
+	}
+	return apicontract.UpdateAdminCustomerSegment200JSONResponse(customerSegmentContract(value)), nil
+}
+func (e *CatalogEndpoints) DeleteAdminCustomerSegment(ctx context.Context, request apicontract.DeleteAdminCustomerSegmentRequestObject) (apicontract.DeleteAdminCustomerSegmentResponseObject, error) {
+	if err := e.segments.Delete(ctx, uint(request.Id)); err != nil {
+		return nil, segmentEndpointError(err)
+	}
+	return apicontract.DeleteAdminCustomerSegment200JSONResponse{Message: "Customer segment deleted"}, nil
+}
+func (e *CatalogEndpoints) RefreshAdminCustomerSegment(ctx context.Context, request apicontract.RefreshAdminCustomerSegmentRequestObject) (apicontract.RefreshAdminCustomerSegmentResponseObject, error) {
+	value, err := e.segments.Refresh(ctx, uint(request.Id), time.Now().UTC())
+	if err != nil {
+		return nil, segmentEndpointError(err)
+	}
+	return apicontract.RefreshAdminCustomerSegment200JSONResponse{SegmentId: int(value.SegmentID), Key: value.Key, Added: value.Added, Removed: value.Removed, Members: value.Members}, nil
+}
+func (e *CatalogEndpoints) ListAdminCustomerSegmentMembers(ctx context.Context, request apicontract.ListAdminCustomerSegmentMembersRequestObject) (apicontract.ListAdminCustomerSegmentMembersResponseObject, error) {
+	page, limit := cmsPagination(request.Params.Page, request.Params.Limit)
+	values, total, err := e.segments.ListMembers(ctx, uint(request.Id), limit, (page-1)*limit)
+	if err != nil {
+		return nil, segmentEndpointError(err)
+	}
+	items := make([]apicontract.CustomerSegmentMember, 0, len(values))
+	for _, value := range values {
+		items = append(items, apicontract.CustomerSegmentMember{UserId: int(value.UserID), MatchedAt: value.MatchedAt})
+	}
+	return apicontract.ListAdminCustomerSegmentMembers200JSONResponse{Data: items, Pagination: apicontract.Pagination{Page: page, Limit: limit, Total: int(total), TotalPages: totalPages(total, limit)}}, nil
+}
+
+func segmentEndpointError(err error) error {
+	switch {
+	case errors.Is(err, segmentservice.ErrInvalidSegment):
+		return problemError(http.StatusBadRequest, "invalid_customer_segment", err.Error(), err)
+	case errors.Is(err, segmentservice.ErrSegmentNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Customer segment not found", err)
+	case errors.Is(err, segmentservice.ErrDuplicateSegmentKey):
+		return problemError(http.StatusConflict, "customer_segment_key_conflict", err.Error(), err)
+	}
+	return err
+}
+
+func customerSegmentInput(ctx context.Context, value apicontract.CustomerSegmentInput) segmentservice.SegmentInput {
+	_, actorID := cmsActor(ctx)
+	return segmentservice.SegmentInput{Key: value.Key, Name: value.Name, Description: derefString(value.Description), Rules: customerSegmentRules(value.Rules), IsActive: value.IsActive, ActorID: actorID}
+}
+func customerSegmentRules(value apicontract.CustomerSegmentRules) segmentservice.Rules {
+	rules := segmentservice.Rules{MinOrderCount: value.MinOrderCount, MaxOrderCount: value.MaxOrderCount, FirstOrderAfter: value.FirstOrderAfter, FirstOrderBefore: value.FirstOrderBefore, FirstOrderWithinDays: value.FirstOrderWithinDays, SignedUpAfter: value.SignedUpAfter, SignedUpBefore: value.SignedUpBefore, SignedUpWithinDays: value.SignedUpWithinDays}
+	if value.MinLifetimeSpend != nil {
+		amount := models.MoneyFromFloat(*value.MinLifetimeSpend)
+		rules.MinLifetimeSpend = &amount
+	}
+	if value.MaxLifetimeSpend != nil {
+		amount := models.MoneyFromFloat(*value.MaxLifetimeSpend)
+		rules.MaxLifetimeSpend = &amount
+	}
+	if value.Countries != nil {
+		rules.Countries = *value.Countries
+	}
+	if value.Roles != nil {
+		rules.Roles = *value.Roles
+	}
+	return rules
+}
+func customerSegmentContract(value models.CustomerSegment) apicontract.CustomerSegment {
+	rules, _ := segmentservice.DecodeRules(value.RulesJSON)
+	contractRules := apicontract.CustomerSegmentRules{MinOrderCount: rules.MinOrderCount, MaxOrderCount: rules.MaxOrderCount, FirstOrderAfter: rules.FirstOrderAfter, FirstOrderBefore: rules.FirstOrderBefore, FirstOrderWithinDays: rules.FirstOrderWithinDays, SignedUpAfter: rules.SignedUpAfter, SignedUpBefore: rules.SignedUpBefore, SignedUpWithinDays: rules.SignedUpWithinDays}
+	if rules.MinLifetimeSpend != nil {
+		amount := rules.MinLifetimeSpend.Float64()
+		contractRules.MinLifetimeSpend = &amount
+	}
+	if rules.MaxLifetimeSpend != nil {
+		amount := rules.MaxLifetimeSpend.Float64()
+		contractRules.MaxLifetimeSpend = &amount
+	}
+	if len(rules.Countries) > 0 {
+		contractRules.Countries = &rules.Countries
+	}
+	if len(rules.Roles) > 0 {
+		contractRules.Roles = &rules.Roles
+	}
+	return apicontract.CustomerSegment{Id: int(value.ID), Key: value.Key, Name: value.Name, Description: value.Description, Rules: contractRules, IsActive: value.IsActive, MemberCount: value.MemberCount, LastRefreshedAt: value.LastRefreshedAt, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
+}
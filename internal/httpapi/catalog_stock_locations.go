@@ -0,0 +1,264 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"ecommerce/internal/apicontract"
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+)
+
+func (e *CatalogEndpoints) ListAdminStockLocations(ctx context.Context, _ apicontract.ListAdminStockLocationsRequestObject) (apicontract.ListAdminStockLocationsResponseObject, error) {
+	values, err := e.inventory.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]apicontract.StockLocation, 0, len(values))
+	for _, value := range values {
+		items = append(items, stockLocationContract(value))
+	}
+	return apicontract.ListAdminStockLocations200JSONResponse{Items: items}, nil
+}
+
+func (e *CatalogEndpoints) CreateAdminStockLocation(ctx context.Context, request apicontract.CreateAdminStockLocationRequestObject) (apicontract.CreateAdminStockLocationResponseObject, error) {
+	if request.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_stock_location", "stock location body is required", nil)
+	}
+	value, err := e.inventory.CreateLocation(ctx, inventoryservice.LocationInput{
+		Code: request.Body.Code, Name: request.Body.Name, Kind: derefString(request.Body.Kind),
+		Sellable: request.Body.Sellable, Active: request.Body.Active, Position: request.Body.Position,
+		Country: request.Body.Country, State: request.Body.State,
+	})
+	if err != nil {
+		return nil, inventoryEndpointError(err)
+	}
+	return apicontract.CreateAdminStockLocation201JSONResponse(stockLocationContract(value)), nil
+}
+
+func (e *CatalogEndpoints) UpdateAdminStockLocation(ctx context.Context, request apicontract.UpdateAdminStockLocationRequestObject) (apicontract.UpdateAdminStockLocationResponseObject, error) {
+	if request.Id < 1 || request.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_stock_location", "valid stock location id and body are required", nil)
+	}
+	value, err := e.inventory.UpdateLocation(ctx, uint(request.Id), inventoryservice.LocationInput{
+		Name: derefString(request.Body.Name), Kind: derefString(request.Body.Kind),
+		Sellable: request.Body.Sellable, Active: request.Body.Active, Position: request.Body.Position,
+		Country: request.Body.Country, State: request.Body.State,
+	})
+	if err != nil {
+		return nil, inventoryEndpointError(err)
+	}
+	return apicontract.UpdateAdminStockLocation200JSONResponse(stockLocationContract(value)), nil
+}
+
+func (e *CatalogEndpoints) ListAdminInventoryLevels(ctx context.Context, request apicontract.ListAdminInventoryLevelsRequestObject) (apicontract.ListAdminInventoryLevelsResponseObject, error) {
+	if request.ProductVariantId < 1 {
+		return nil, errors.New("product variant id must be positive")
+	}
+	values, err := e.inventory.GetLocationLevels(ctx, uint(request.ProductVariantId))
+	if err != nil {
+		return nil, inventoryEndpointError(err)
+	}
+	items := make([]apicontract.InventoryLocationLevel, 0, len(values))
+	for _, value := range values {
+		items = append(items, apicontract.InventoryLocationLevel{Location: stockLocationContract(value.Location), OnHand: value.OnHand, Reserved: value.Reserved, Available: value.Available})
+	}
+	return apicontract.ListAdminInventoryLevels200JSONResponse{ProductVariantId: request.ProductVariantId, Items: items}, nil
+}
+
+func (e *CatalogEndpoints) ListAdminInventoryTransfers(ctx context.Context, request apicontract.ListAdminInventoryTransfersRequestObject) (apicontract.ListAdminInventoryTransfersResponseObject, error) {
+	statuses := []string{}
+	if request.Params.Status != nil {
+		statuses = *request.Params.Status
+	}
+	values, err := e.inventory.ListTransfers(ctx, statuses, limitValue(request.Params.Limit, 100, 200))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]apicontract.InventoryTransfer, 0, len(values))
+	for _, value := range values {
+		items = append(items, inventoryTransferContract(value))
+	}
+	return apicontract.ListAdminInventoryTransfers200JSONResponse{Items: items}, nil
+}
+
+func (e *CatalogEndpoints) CreateAdminInventoryTransfer(ctx context.Context, request apicontract.CreateAdminInventoryTransferRequestObject) (apicontract.CreateAdminInventoryTransferResponseObject, error) {
+	if request.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_inventory_transfer", "inventory transfer body is required", nil)
+	}
+	input := inventoryservice.TransferInput{
+		FromLocationCode: request.Body.FromLocationCode, ToLocationCode: request.Body.ToLocationCode,
+		Notes: derefString(request.Body.Notes), ActorType: "admin",
+	}
+	for _, item := range request.Body.Items {
+		if item.ProductVariantId < 1 {
+			return nil, inventoryEndpointError(inventoryservice.ErrInvalidTransfer)
+		}
+		input.Items = append(input.Items, inventoryservice.TransferItemInput{ProductVariantID: uint(item.ProductVariantId), Quantity: item.Quantity})
+	}
+	value, err := e.inventory.CreateTransfer(ctx, input)
+	if err != nil {
+		return nil, inventoryEndpointError(err)
+	}
+	return apicontract.CreateAdminInventoryTransfer201JSONResponse(inventoryTransferContract(value)), nil
+}
+
+func (e *CatalogEndpoints) ShipAdminInventoryTransfer(ctx context.Context, request apicontract.ShipAdminInventoryTransferRequestObject) (apicontract.ShipAdminInventoryTransferResponseObject, error) {
+	value, err := e.stepInventoryTransfer(ctx, request.Id, e.inventory.ShipTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ShipAdminInventoryTransfer200JSONResponse(inventoryTransferContract(value)), nil
+}
+
+func (e *CatalogEndpoints) ReceiveAdminInventoryTransfer(ctx context.Context, request apicontract.ReceiveAdminInventoryTransferRequestObject) (apicontract.ReceiveAdminInventoryTransferResponseObject, error) {
+	value, err := e.stepInventoryTransfer(ctx, request.Id, e.inventory.ReceiveTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ReceiveAdminInventoryTransfer200JSONResponse(inventoryTransferContract(value)), nil
+}
+
+func (e *CatalogEndpoints) CancelAdminInventoryTransfer(ctx context.Context, request apicontract.CancelAdminInventoryTransferRequestObject) (apicontract.CancelAdminInventoryTransferResponseObject, error) {
+	value, err := e.stepInventoryTransfer(ctx, request.Id, e.inventory.CancelTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.CancelAdminInventoryTransfer200JSONResponse(inventoryTransferContract(value)), nil
+}
+
+func (e *CatalogEndpoints) stepInventoryTransfer(ctx context.Context, id int, step func(context.Context, uint, inventoryservice.TransferActionInput) (models.InventoryTransfer, error)) (models.InventoryTransfer, error) {
+	if id < 1 {
+		return models.InventoryTransfer{}, errors.New("inventory transfer id must be positive")
+	}
+	value, err := step(ctx, uint(id), inventoryservice.TransferActionInput{ActorType: "admin"})
+	if err != nil {
+		return models.InventoryTransfer{}, inventoryEndpointError(err)
+	}
+	return value, nil
+}
+
+func (e *CatalogEndpoints) ListAdminOrderRoutingRules(ctx context.Context, _ apicontract.ListAdminOrderRoutingRulesRequestObject) (apicontract.ListAdminOrderRoutingRulesResponseObject, error) {
+	values, err := e.inventory.ListRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ListAdminOrderRoutingRules200JSONResponse(orderRoutingRulesContract(values)), nil
+}
+
+func (e *CatalogEndpoints) SetAdminOrderRoutingRules(ctx context.Context, request apicontract.SetAdminOrderRoutingRulesRequestObject) (apicontract.SetAdminOrderRoutingRulesResponseObject, error) {
+	if request.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_routing_rule", "routing rules body is required", nil)
+	}
+	inputs := make([]inventoryservice.RoutingRuleInput, 0, len(request.Body.Rules))
+	for _, rule := range request.Body.Rules {
+		inputs = append(inputs, inventoryservice.RoutingRuleInput{Rule: rule.Rule, Active: rule.Active})
+	}
+	values, err := e.inventory.SetRoutingRules(ctx, inputs)
+	if err != nil {
+		return nil, inventoryEndpointError(err)
+	}
+	return apicontract.SetAdminOrderRoutingRules200JSONResponse(orderRoutingRulesContract(values)), nil
+}
+
+func (e *CatalogEndpoints) SimulateAdminOrderRouting(ctx context.Context, request apicontract.SimulateAdminOrderRoutingRequestObject) (apicontract.SimulateAdminOrderRoutingResponseObject, error) {
+	if request.Body == nil || len(request.Body.Items) == 0 {
+		return nil, problemError(http.StatusBadRequest, "invalid_routing_simulation", "at least one item is required", nil)
+	}
+	routeRequest := inventoryservice.RouteRequest{}
+	for _, item := range request.Body.Items {
+		if item.ProductVariantId < 1 || item.Quantity < 1 {
+			return nil, problemError(http.StatusBadRequest, "invalid_routing_simulation", "items need a product variant and a positive quantity", nil)
+		}
+		routeRequest.Lines = append(routeRequest.Lines, inventoryservice.RouteLine{ProductVariantID: uint(item.ProductVariantId), Quantity: item.Quantity})
+	}
+	if request.Body.Address != nil {
+		routeRequest.Country = derefString(request.Body.Address.Country)
+		routeRequest.State = derefString(request.Body.Address.State)
+	}
+	plan, err := e.inventory.PlanRoute(ctx, routeRequest)
+	if err != nil {
+		return nil, inventoryEndpointError(err)
+	}
+	assignments := make([]apicontract.OrderRoutingAssignment, 0, len(plan.Assignments))
+	for _, assignment := range plan.Assignments {
+		assignments = append(assignments, apicontract.OrderRoutingAssignment{
+			Location: stockLocationContract(assignment.Location), Lines: routeLinesContract(assignment.Lines), Explanation: assignment.Explanation,
+		})
+	}
+	return apicontract.SimulateAdminOrderRouting200JSONResponse{
+		Rules: plan.Rules, Assignments: assignments, Unrouted: routeLinesContract(plan.Unrouted), Split: len(plan.Assignments) > 1,
+	}, nil
+}
+
+// inventoryEndpointError maps stock location and transfer failures to
+// problems, leaving anything else to catalogEndpointError.
+func inventoryEndpointError(err error) error {
+	var availabilityErr *inventoryservice.InsufficientAvailabilityError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, inventoryservice.ErrInvalidStockLocation):
+		return problemError(http.StatusBadRequest, "invalid_stock_location", err.Error(), err)
+	case errors.Is(err, inventoryservice.ErrInvalidRoutingRule):
+		return problemError(http.StatusBadRequest, "invalid_routing_rule", err.Error(), err)
+	case errors.Is(err, inventoryservice.ErrInvalidTransfer):
+		return problemError(http.StatusBadRequest, "invalid_inventory_transfer", err.Error(), err)
+	case errors.Is(err, inventoryservice.ErrStockLocationNotFound):
+		return problemError(http.StatusNotFound, "stock_location_not_found", "Stock location not found", err)
+	case errors.Is(err, inventoryservice.ErrTransferNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Inventory transfer not found", err)
+	case errors.Is(err, inventoryservice.ErrStockLocationExists):
+		return problemError(http.StatusConflict, "stock_location_exists", err.Error(), err)
+	case errors.Is(err, inventoryservice.ErrDefaultLocationRequired):
+		return problemError(http.StatusConflict, "default_stock_location_required", err.Error(), err)
+	case errors.Is(err, inventoryservice.ErrTransferNotAllowed):
+		return problemError(http.StatusConflict, "inventory_transfer_not_allowed", err.Error(), err)
+	case errors.As(err, &availabilityErr):
+		return ErrorProblem(Problem{
+			Type: TypeConflict, Title: http.StatusText(http.StatusConflict), Status: http.StatusConflict,
+			Code: "insufficient_stock", Detail: "Insufficient stock at the source location",
+			ProductVariantID: availabilityErr.ProductVariantID, Requested: availabilityErr.Requested, Available: availabilityErr.Available,
+		}, err)
+	default:
+		return catalogEndpointError(err)
+	}
+}
+
+func stockLocationContract(value models.StockLocation) apicontract.StockLocation {
+	return apicontract.StockLocation{Id: int(value.ID), Code: value.Code, Name: value.Name, Kind: value.Kind, Sellable: value.Sellable, Active: value.Active, Position: value.Position, Country: value.Country, State: value.State, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
+}
+
+func orderRoutingRulesContract(values []models.OrderRoutingRule) apicontract.OrderRoutingRuleList {
+	items := make([]apicontract.OrderRoutingRule, 0, len(values))
+	for _, value := range values {
+		items = append(items, apicontract.OrderRoutingRule{Rule: value.Rule, Position: value.Position, Active: value.Active})
+	}
+	return apicontract.OrderRoutingRuleList{Items: items}
+}
+
+func routeLinesContract(lines []inventoryservice.RouteLine) []apicontract.OrderRoutingLine {
+	items := make([]apicontract.OrderRoutingLine, 0, len(lines))
+	for _, line := range lines {
+		items = append(items, apicontract.OrderRoutingLine{ProductVariantId: int(line.ProductVariantID), Quantity: line.Quantity})
+	}
+	return items
+}
+
+func inventoryTransferContract(value models.InventoryTransfer) apicontract.InventoryTransfer {
+	items := make([]apicontract.InventoryTransferItem, 0, len(value.Items))
+	for _, item := range value.Items {
+		items = append(items, apicontract.InventoryTransferItem{Id: int(item.ID), ProductVariantId: int(item.ProductVariantID), Quantity: item.Quantity})
+	}
+	return apicontract.InventoryTransfer{Id: int(value.ID), FromLocationId: int(value.FromLocationID), ToLocationId: int(value.ToLocationID), Status: value.Status, Notes: value.Notes, ActorType: value.ActorType, ActorId: optionalUint(value.ActorID), ShippedAt: value.ShippedAt, ReceivedAt: value.ReceivedAt, CancelledAt: value.CancelledAt, Items: items, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
+}
+
+// stockLocationID reports a location id, or nothing for records that predate
+// locations or a backorder still waiting for stock.
+func stockLocationID(id uint) *int {
+	if id == 0 {
+		return nil
+	}
+	return optionalUint(&id)
+}
@@ -42,6 +42,9 @@ func TestCatalogEndpointsCoversStrictCatalogFamily(t *testing.T) {
 		"RunAdminInventoryReconciliation", "ListAdminInventoryReservations", "ListAdminInventoryThresholds", "UpsertAdminInventoryThreshold",
 		"DeleteAdminInventoryThreshold", "GetAdminInventoryTimeline", "ListAdminPurchaseOrders", "CreateAdminPurchaseOrder",
 		"CancelAdminPurchaseOrder", "IssueAdminPurchaseOrder", "ReceiveAdminPurchaseOrder",
+		"ListAdminStockLocations", "CreateAdminStockLocation", "UpdateAdminStockLocation", "ListAdminInventoryLevels",
+		"ListAdminInventoryTransfers", "CreateAdminInventoryTransfer", "ShipAdminInventoryTransfer", "ReceiveAdminInventoryTransfer",
+		"CancelAdminInventoryTransfer", "ListAdminOrderRoutingRules", "SetAdminOrderRoutingRules", "SimulateAdminOrderRouting",
 		"ListAdminDiscountAudit", "ListAdminDiscountCampaigns", "CreateAdminDiscountCampaign", "UpdateAdminDiscountCampaign",
 		"ArchiveAdminDiscountCampaign", "DisableAdminDiscountCampaign", "ScheduleAdminDiscountCampaign", "ListAdminDiscountHistory",
 		"RunAdminDiscountLifecycle", "GetAdminDiscountMetrics", "CreateAdminPromotionCampaign", "PreviewAdminPromotion",
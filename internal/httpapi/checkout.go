@@ -15,9 +15,11 @@ import (
 	"ecommerce/internal/media"
 	"ecommerce/internal/requestctx"
 	checkoutservice "ecommerce/internal/services/checkout"
+	giftcardservice "ecommerce/internal/services/giftcards"
 	orderservice "ecommerce/internal/services/orders"
 	paymentservice "ecommerce/internal/services/payments"
 	providerops "ecommerce/internal/services/providerops"
+	riskservice "ecommerce/internal/services/risk"
 	shippingservice "ecommerce/internal/services/shipping"
 	taxservice "ecommerce/internal/services/tax"
 	webhookservice "ecommerce/internal/services/webhooks"
@@ -34,6 +36,10 @@ type CheckoutProviderEndpointsOptions struct {
 	ProviderRuntime *providerops.Runtime
 	Webhooks        *webhookservice.Service
 	Renderer        Renderer
+	// CartRecovery signs and verifies abandoned cart restore links.
+	CartRecovery checkoutservice.RecoveryLinks
+	// RiskScorers resolves the external scorer named in the risk settings.
+	RiskScorers riskservice.ScorerRegistry
 }
 
 // CheckoutProviderEndpoints is the embeddable generated-strict endpoint family
@@ -47,6 +53,7 @@ type CheckoutProviderEndpoints struct {
 	checkout *checkoutservice.Service
 	orders   *orderservice.Service
 	payments *paymentservice.Service
+	giftcard *giftcardservice.Service
 	shipping *shippingservice.Service
 	tax      *taxservice.Service
 	catalog  *providerops.CatalogService
@@ -55,6 +62,8 @@ type CheckoutProviderEndpoints struct {
 	cases    *providerops.CaseService
 	overview *providerops.OverviewService
 	renderer Renderer
+	recovery checkoutservice.RecoveryLinks
+	risk     *riskservice.Service
 }
 
 func NewCheckoutProviderEndpoints(options CheckoutProviderEndpointsOptions) (*CheckoutProviderEndpoints, error) {
@@ -79,21 +88,46 @@ func NewCheckoutProviderEndpoints(options CheckoutProviderEndpointsOptions) (*Ch
 	return &CheckoutProviderEndpoints{
 		db: options.DB, media: options.Media, plugins: plugins, runtime: runtime, webhooks: webhooks,
 		checkout: checkoutservice.NewService(options.DB), orders: orderservice.NewService(options.DB), payments: paymentservice.NewService(options.DB),
+		giftcard: giftcardservice.NewService(options.DB),
 		shipping: shippingservice.NewService(options.DB), tax: taxservice.NewService(options.DB), catalog: providerops.NewCatalogService(options.DB, plugins),
 		queries: providerops.NewQueryService(options.DB), admin: providerops.NewAdminService(options.DB, runtime.Executor, resolver), cases: providerops.NewCaseService(options.DB),
 		overview: providerops.NewOverviewService(options.DB, runtime.Environment, runtime.Credentials, webhooks.MaxAttempts), renderer: options.Renderer,
+		recovery: options.CartRecovery, risk: riskservice.NewService(options.DB, options.RiskScorers),
 	}, nil
 }
 
+var quantityRuleProblemCodes = map[string]string{
+	checkoutservice.QuantityRuleMinimum:       "quantity_below_minimum",
+	checkoutservice.QuantityRuleMaximum:       "quantity_above_maximum",
+	checkoutservice.QuantityRuleStep:          "quantity_step_mismatch",
+	checkoutservice.QuantityRulePurchaseLimit: "purchase_limit_exceeded",
+}
+
 func checkoutEndpointError(err error) error {
 	var stockErr *orderservice.InsufficientStockError
+	var ruleErr *checkoutservice.QuantityRuleError
 	switch {
+	case errors.As(err, &ruleErr):
+		problem := Problem{
+			Type: TypeInvalidRequest, Title: http.StatusText(http.StatusBadRequest), Status: http.StatusBadRequest,
+			Code: quantityRuleProblemCodes[ruleErr.Rule], Detail: ruleErr.Error(), ProductVariantID: ruleErr.ProductVariantID, Requested: ruleErr.Requested,
+		}
+		if ruleErr.Rule == checkoutservice.QuantityRulePurchaseLimit {
+			problem.Available = max(ruleErr.Limit-ruleErr.Purchased, 0)
+		}
+		return ErrorProblem(problem, err)
 	case errors.Is(err, checkoutservice.ErrInvalidQuantity):
 		return problemError(http.StatusBadRequest, "invalid_quantity", err.Error(), err)
 	case errors.Is(err, checkoutservice.ErrVariantNotFound), errors.Is(err, checkoutservice.ErrCartItemNotFound):
 		return problemError(http.StatusNotFound, "not_found", err.Error(), err)
+	case errors.Is(err, checkoutservice.ErrExpressCheckoutNotFound):
+		return problemError(http.StatusNotFound, "express_checkout_not_found", "Express checkout not found or expired", err)
+	case errors.Is(err, checkoutservice.ErrDraftOrderCartLocked):
+		return problemError(http.StatusConflict, "draft_order_cart_locked", err.Error(), err)
 	case errors.Is(err, orderservice.ErrOrderNotFound):
 		return problemError(http.StatusNotFound, "not_found", "Order not found", err)
+	case errors.Is(err, orderservice.ErrOrderItemNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Order item not found", err)
 	case errors.Is(err, checkoutservice.ErrIdempotencyConflict):
 		return problemError(http.StatusConflict, "idempotency_key_conflict", err.Error(), err)
 	case errors.Is(err, checkoutservice.ErrIdempotencyInProgress):
@@ -106,12 +140,28 @@ func checkoutEndpointError(err error) error {
 		return problemError(http.StatusConflict, "checkout_state_conflict", err.Error(), err)
 	case errors.Is(err, orderservice.ErrOrderPaymentSubmitted):
 		return problemError(http.StatusBadRequest, "payment_already_submitted", err.Error(), err)
+	case errors.Is(err, orderservice.ErrStatusTransitionNotAllowed):
+		return problemError(http.StatusConflict, "invalid_status_transition", err.Error(), err)
+	case errors.Is(err, orderservice.ErrStatusTransitionWrongPath):
+		return problemError(http.StatusConflict, "status_transition_wrong_path", err.Error(), err)
+	case errors.Is(err, orderservice.ErrOrderPaymentNotCaptured):
+		return problemError(http.StatusConflict, "payment_not_captured", err.Error(), err)
+	case errors.Is(err, orderservice.ErrPreorderNotReleased):
+		return problemError(http.StatusConflict, "preorder_not_released", err.Error(), err)
+	case errors.Is(err, orderservice.ErrItemQuantityNotCancellable):
+		return problemError(http.StatusConflict, "item_quantity_not_cancellable", err.Error(), err)
+	case errors.Is(err, orderservice.ErrOrderRiskReviewPending):
+		return problemError(http.StatusConflict, "order_risk_review_pending", "Order is held for risk review", err)
 	case errors.Is(err, paymentservice.ErrSnapshotExpired), errors.Is(err, paymentservice.ErrSnapshotNotFound):
 		return problemError(http.StatusBadRequest, "invalid_checkout_snapshot", err.Error(), err)
 	case errors.Is(err, paymentservice.ErrSnapshotAlreadyBound):
 		return problemError(http.StatusConflict, "checkout_snapshot_conflict", "checkout snapshot is already bound to another order", err)
 	case errors.Is(err, paymentservice.ErrSnapshotOrderMismatch), errors.Is(err, paymentservice.ErrActivePaymentIntentExists):
 		return problemError(http.StatusConflict, "checkout_snapshot_conflict", err.Error(), err)
+	case errors.Is(err, giftcardservice.ErrInvalidGiftCard), errors.Is(err, giftcardservice.ErrGiftCardNotFound),
+		errors.Is(err, giftcardservice.ErrGiftCardUnavailable), errors.Is(err, giftcardservice.ErrInsufficientBalance),
+		errors.Is(err, giftcardservice.ErrCurrencyMismatch):
+		return giftCardEndpointError(err)
 	case errors.As(err, &stockErr):
 		return ErrorProblem(Problem{
 			Type: TypeInvalidRequest, Title: http.StatusText(http.StatusBadRequest), Status: http.StatusBadRequest,
@@ -147,7 +197,7 @@ func (e *CheckoutProviderEndpoints) GetCheckoutCart(ctx context.Context, _ apico
 	if err != nil {
 		return nil, err
 	}
-	return apicontract.GetCheckoutCart200JSONResponse(cartContract(cart)), nil
+	return apicontract.GetCheckoutCart200JSONResponse(checkoutCartContract(ctx, cart)), nil
 }
 func (e *CheckoutProviderEndpoints) AddCheckoutCartItem(ctx context.Context, request apicontract.AddCheckoutCartItemRequestObject) (apicontract.AddCheckoutCartItemResponseObject, error) {
 	id, err := principalAccountID(ctx)
@@ -161,7 +211,7 @@ func (e *CheckoutProviderEndpoints) AddCheckoutCartItem(ctx context.Context, req
 	if err != nil {
 		return nil, checkoutEndpointError(err)
 	}
-	return apicontract.AddCheckoutCartItem200JSONResponse(cartContract(cart)), nil
+	return apicontract.AddCheckoutCartItem200JSONResponse(checkoutCartContract(ctx, cart)), nil
 }
 func (e *CheckoutProviderEndpoints) UpdateCheckoutCartItem(ctx context.Context, request apicontract.UpdateCheckoutCartItemRequestObject) (apicontract.UpdateCheckoutCartItemResponseObject, error) {
 	id, err := principalAccountID(ctx)
@@ -211,7 +261,7 @@ func (e *CheckoutProviderEndpoints) GetCart(ctx context.Context, _ apicontract.G
 	if err != nil {
 		return nil, err
 	}
-	return apicontract.GetCart200JSONResponse(cartContract(cart)), nil
+	return apicontract.GetCart200JSONResponse(checkoutCartContract(ctx, cart)), nil
 }
 func (e *CheckoutProviderEndpoints) AddCartItem(ctx context.Context, r apicontract.AddCartItemRequestObject) (apicontract.AddCartItemResponseObject, error) {
 	id, err := principalAccountID(ctx)
@@ -225,7 +275,7 @@ func (e *CheckoutProviderEndpoints) AddCartItem(ctx context.Context, r apicontra
 	if err != nil {
 		return nil, checkoutEndpointError(err)
 	}
-	return apicontract.AddCartItem200JSONResponse(cartContract(cart)), nil
+	return apicontract.AddCartItem200JSONResponse(checkoutCartContract(ctx, cart)), nil
 }
 func (e *CheckoutProviderEndpoints) UpdateCartItem(ctx context.Context, r apicontract.UpdateCartItemRequestObject) (apicontract.UpdateCartItemResponseObject, error) {
 	id, err := principalAccountID(ctx)
@@ -322,11 +372,17 @@ func (e *CheckoutProviderEndpoints) quote(ctx context.Context, body *apicontract
 	if err != nil {
 		return apicontract.CheckoutQuoteResponse{}, err
 	}
+	now := time.Now().UTC()
 	subtotal := models.Money(0)
 	items := make([]paymentservice.SnapshotItemInput, 0, len(cart.Items))
 	for _, item := range cart.Items {
-		subtotal += item.ProductVariant.Price.Mul(item.Quantity)
-		items = append(items, paymentservice.SnapshotItemInput{ProductVariantID: item.ProductVariantID, VariantSKU: item.ProductVariant.SKU, VariantTitle: item.ProductVariant.Title, Quantity: item.Quantity, Price: item.ProductVariant.Price})
+		price := item.UnitPrice(now)
+		subtotal += price.Mul(item.Quantity)
+		items = append(items, paymentservice.SnapshotItemInput{ProductVariantID: item.ProductVariantID, VariantSKU: item.ProductVariant.SKU, VariantTitle: item.ProductVariant.Title, Quantity: item.Quantity, Price: price})
+	}
+	attributes, err := e.checkout.ValidateCheckoutAttributes(ctx, derefString(body.Channel), mapValue(body.Attributes))
+	if err != nil {
+		return apicontract.CheckoutQuoteResponse{}, checkoutAttributeEndpointError(err)
 	}
 	paymentData, shippingData, taxData := mapValue(body.PaymentData), mapValue(body.ShippingData), mapValue(body.TaxData)
 	quote := e.plugins.Quote(checkoutplugins.QuoteRequest{Subtotal: subtotal, PaymentID: body.PaymentProviderId, ShippingID: body.ShippingProviderId, TaxID: body.TaxProviderId, PaymentData: paymentData, ShippingData: shippingData, TaxData: taxData})
@@ -340,7 +396,7 @@ func (e *CheckoutProviderEndpoints) quote(ctx context.Context, body *apicontract
 		if err != nil {
 			return response, err
 		}
-		snapshot, err := e.payments.CreateCheckoutSnapshot(ctx, paymentservice.CreateCheckoutSnapshotInput{CheckoutSessionID: session.ID, Currency: quote.Currency, Subtotal: quote.Subtotal, ShippingAmount: quote.Shipping, TaxAmount: quote.Tax, Total: quote.Total, PaymentProviderID: body.PaymentProviderId, ShippingProviderID: body.ShippingProviderId, TaxProviderID: body.TaxProviderId, PaymentData: paymentData, ShippingData: shippingData, TaxData: taxData, PaymentMethodDisplay: resolved.PaymentDisplay, ShippingAddressPretty: resolved.ShippingAddress, Items: items, Now: time.Now().UTC()})
+		snapshot, err := e.payments.CreateCheckoutSnapshot(ctx, paymentservice.CreateCheckoutSnapshotInput{CheckoutSessionID: session.ID, Currency: quote.Currency, Subtotal: quote.Subtotal, ShippingAmount: quote.Shipping, TaxAmount: quote.Tax, Total: quote.Total, PaymentProviderID: body.PaymentProviderId, ShippingProviderID: body.ShippingProviderId, TaxProviderID: body.TaxProviderId, PaymentData: paymentData, ShippingData: shippingData, TaxData: taxData, PaymentMethodDisplay: resolved.PaymentDisplay, ShippingAddressPretty: resolved.ShippingAddress, Attributes: attributes, Items: items, Now: time.Now().UTC()})
 		if err != nil {
 			return response, err
 		}
@@ -352,10 +408,14 @@ func (e *CheckoutProviderEndpoints) quote(ctx context.Context, body *apicontract
 }
 
 func (e *CheckoutProviderEndpoints) ListAdminOrders(ctx context.Context, r apicontract.ListAdminOrdersRequestObject) (apicontract.ListAdminOrdersResponseObject, error) {
-	page, err := e.orders.List(ctx, orderservice.ListInput{Query: stringPtr(r.Params.Q), Page: intPtr(r.Params.Page), Limit: intPtr(r.Params.Limit)})
+	input, err := adminOrderListInput(ctx, r.Params)
 	if err != nil {
 		return nil, err
 	}
+	page, err := e.orders.List(ctx, input)
+	if err != nil {
+		return nil, orderSearchEndpointError(err)
+	}
 	return apicontract.ListAdminOrders200JSONResponse(orderPageContract(page, nil)), nil
 }
 func (e *CheckoutProviderEndpoints) GetAdminOrder(ctx context.Context, r apicontract.GetAdminOrderRequestObject) (apicontract.GetAdminOrderResponseObject, error) {
@@ -363,7 +423,13 @@ func (e *CheckoutProviderEndpoints) GetAdminOrder(ctx context.Context, r apicont
 	if err != nil {
 		return nil, checkoutEndpointError(err)
 	}
-	return apicontract.GetAdminOrder200JSONResponse(orderContract(o, nil)), nil
+	tags, err := e.orders.Tags(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	contract := orderContract(o, nil)
+	contract.Tags = orderTagsContract(tags[o.ID])
+	return apicontract.GetAdminOrder200JSONResponse(contract), nil
 }
 func (e *CheckoutProviderEndpoints) ListUserOrders(ctx context.Context, r apicontract.ListUserOrdersRequestObject) (apicontract.ListUserOrdersResponseObject, error) {
 	id, err := principalAccountID(ctx)
@@ -407,6 +473,9 @@ func (e *CheckoutProviderEndpoints) CancelUserOrder(ctx context.Context, r apico
 	if err != nil {
 		return nil, checkoutEndpointError(err)
 	}
+	if err := e.releaseUnpaidOrderTenders(ctx, o.ID); err != nil {
+		return nil, err
+	}
 	return apicontract.CancelUserOrder200JSONResponse(orderContract(o, &id)), nil
 }
 func (e *CheckoutProviderEndpoints) ClaimGuestOrder(ctx context.Context, r apicontract.ClaimGuestOrderRequestObject) (apicontract.ClaimGuestOrderResponseObject, error) {
@@ -463,10 +532,20 @@ func (e *CheckoutProviderEndpoints) CreateCheckoutOrder(ctx context.Context, r a
 	if id != 0 {
 		userID = &id
 	} else {
-		if r.Body == nil || r.Body.GuestEmail == nil || strings.TrimSpace(string(*r.Body.GuestEmail)) == "" {
+		email := ""
+		if r.Body != nil && r.Body.GuestEmail != nil {
+			email = strings.TrimSpace(string(*r.Body.GuestEmail))
+		}
+		if email == "" {
+			// Single-page checkout collects the email in its contact step.
+			if state, stateErr := e.checkout.LoadCheckoutState(ctx, session.ID); stateErr == nil && state.Contact != nil {
+				email = state.Contact.Email
+			}
+		}
+		if email == "" {
 			return nil, problemError(http.StatusBadRequest, "guest_email_required", "Guest email is required", nil)
 		}
-		address, parseErr := mail.ParseAddress(strings.ToLower(strings.TrimSpace(string(*r.Body.GuestEmail))))
+		address, parseErr := mail.ParseAddress(strings.ToLower(email))
 		if parseErr != nil {
 			return nil, problemError(http.StatusBadRequest, "invalid_guest_email", "Guest email is invalid", parseErr)
 		}
@@ -474,6 +553,21 @@ func (e *CheckoutProviderEndpoints) CreateCheckoutOrder(ctx context.Context, r a
 		guestEmail = &normalized
 	}
 
+	channel, submitted := "", map[string]string{}
+	if r.Body != nil {
+		channel = derefString(r.Body.Channel)
+	}
+	if r.Body != nil && r.Body.Attributes != nil {
+		submitted = *r.Body.Attributes
+	} else if state, stateErr := e.checkout.LoadCheckoutState(ctx, session.ID); stateErr == nil && state.Attributes != nil {
+		// Single-page checkout confirms the attributes with its review step.
+		submitted = state.Attributes
+	}
+	attributes, err := e.checkout.ValidateCheckoutAttributes(ctx, channel, submitted)
+	if err != nil {
+		return nil, checkoutAttributeEndpointError(err)
+	}
+
 	idempotency, err := e.checkout.BeginIdempotency(ctx, session.ID, "checkout_order_create", stringPtr(r.Params.IdempotencyKey), r.Body, correlationID(ctx))
 	if err != nil {
 		return nil, checkoutEndpointError(err)
@@ -485,29 +579,27 @@ func (e *CheckoutProviderEndpoints) CreateCheckoutOrder(ctx context.Context, r a
 		}
 		return apicontract.CreateCheckoutOrder201JSONResponse(replay), nil
 	}
-	client := ""
-	if request, ok := ctx.(interface{ ClientIP() string }); ok {
-		client = request.ClientIP()
-	}
-	if !allowCheckoutSubmission("create_order", session.PublicToken, client, time.Now().UTC()) {
-		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
-		return nil, problemError(http.StatusTooManyRequests, "checkout_rate_limited", "Too many checkout attempts. Please wait and try again.", nil)
-	}
 
 	cart, err := e.checkout.Cart(ctx, id)
 	if err != nil {
 		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
 		return nil, err
 	}
+	now := time.Now().UTC()
 	items := make([]orderservice.CreateItemInput, 0, len(cart.Items))
 	for _, item := range cart.Items {
-		items = append(items, orderservice.CreateItemInput{ProductVariantID: item.ProductVariantID, Quantity: item.Quantity})
+		price := item.UnitPrice(now)
+		items = append(items, orderservice.CreateItemInput{ProductVariantID: item.ProductVariantID, Quantity: item.Quantity, UnitPrice: &price})
 	}
 	o, created, err := e.orders.CreateOrReplaceOpen(ctx, cart.CheckoutSessionID, userID, guestEmail, items)
 	if err != nil {
 		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
 		return nil, checkoutEndpointError(err)
 	}
+	if err := e.orders.SetAttributes(ctx, &o, attributes); err != nil {
+		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
+		return nil, err
+	}
 	if guestEmail != nil {
 		if err := e.db.WithContext(ctx).Model(&models.CheckoutSession{}).Where("id = ?", session.ID).Updates(map[string]any{"guest_email": *guestEmail, "last_seen_at": time.Now().UTC()}).Error; err != nil {
 			return nil, err
@@ -538,13 +630,33 @@ func (e *CheckoutProviderEndpoints) UpdateOrderStatus(ctx context.Context, r api
 	if r.Body == nil {
 		return nil, errors.New("order status body is required")
 	}
-	o, err := e.orders.UpdateStatus(ctx, uint(r.Id), string(r.Body.Status))
+	actor, _ := cmsActor(ctx)
+	o, err := e.orders.UpdateStatus(ctx, uint(r.Id), orderservice.StatusUpdateInput{
+		Status: string(r.Body.Status), Reason: derefString(r.Body.Reason), Actor: actor, CorrelationID: correlationID(ctx),
+	})
 	if err != nil {
 		return nil, checkoutEndpointError(err)
 	}
+	if err := e.releaseUnpaidOrderTenders(ctx, o.ID); err != nil {
+		return nil, err
+	}
 	return apicontract.UpdateOrderStatus200JSONResponse(orderContract(o, nil)), nil
 }
 
+func (e *CheckoutProviderEndpoints) CancelAdminOrderItem(ctx context.Context, r apicontract.CancelAdminOrderItemRequestObject) (apicontract.CancelAdminOrderItemResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "item cancellation body is required", nil)
+	}
+	actor, _ := cmsActor(ctx)
+	o, err := e.orders.CancelItemQuantity(ctx, uint(r.Id), uint(r.ItemId), orderservice.ItemCancelInput{
+		Quantity: r.Body.Quantity, Reason: derefString(r.Body.Reason), Actor: actor, CorrelationID: correlationID(ctx),
+	})
+	if err != nil {
+		return nil, checkoutEndpointError(err)
+	}
+	return apicontract.CancelAdminOrderItem200JSONResponse(orderContract(o, nil)), nil
+}
+
 func cartContract(cart models.Cart) apicontract.Cart {
 	items := make([]apicontract.CartItem, 0, len(cart.Items))
 	for _, item := range cart.Items {
@@ -556,20 +668,44 @@ func cartContract(cart models.Cart) apicontract.Cart {
 	}
 	return apicontract.Cart{Id: int(cart.ID), UserId: userID, Items: items, CreatedAt: cart.CreatedAt, UpdatedAt: cart.UpdatedAt, DeletedAt: deletedAt(cart.DeletedAt)}
 }
+func checkoutCartContract(ctx context.Context, cart models.Cart) apicontract.Cart {
+	out := cartContract(cart)
+	merge, ok := checkoutservice.CartMergeFromContext(ctx)
+	if !ok || merge.TargetSessionID != cart.CheckoutSessionID {
+		return out
+	}
+	lines := make([]apicontract.CartMergeLine, 0, len(merge.Lines))
+	for _, line := range merge.Lines {
+		lines = append(lines, cartMergeLineContract(line))
+	}
+	out.Merge = &apicontract.CartMerge{MergedAt: merge.MergedAt, Lines: lines}
+	return out
+}
+func cartMergeLineContract(line checkoutservice.CartMergeLine) apicontract.CartMergeLine {
+	return apicontract.CartMergeLine{ProductVariantId: int(line.ProductVariantID), GuestQuantity: line.GuestQuantity, PreviousQuantity: line.PreviousQuantity, Quantity: line.Quantity, Outcome: line.Outcome, Notice: optionalString(line.Notice)}
+}
 func cartItemContract(item models.CartItem) apicontract.CartItem {
-	return apicontract.CartItem{Id: int(item.ID), CartId: int(item.CartID), ProductVariantId: int(item.ProductVariantID), Quantity: item.Quantity, Product: basicProductContract(item.ProductVariant.Product), ProductVariant: basicVariantContract(item.ProductVariant), CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt, DeletedAt: deletedAt(item.DeletedAt)}
+	return apicontract.CartItem{Id: int(item.ID), CartId: int(item.CartID), ProductVariantId: int(item.ProductVariantID), Quantity: item.Quantity, Product: basicProductContract(item.ProductVariant.Product), ProductVariant: basicVariantContract(item.ProductVariant), LockedPrice: optionalMoney(item.LockedPrice), LockedUntil: item.LockedUntil, LockedQuantity: lockedQuantity(item), SavedCartId: optionalUint(item.SavedCartID), CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt, DeletedAt: deletedAt(item.DeletedAt)}
+}
+
+func lockedQuantity(item models.CartItem) *int {
+	if item.LockedPrice == nil {
+		return nil
+	}
+	return &item.LockedQuantity
 }
 func basicProductContract(p models.Product) apicontract.Product {
 	return apicontract.Product{Id: int(p.ID), Sku: p.SKU, Name: p.Name, Description: p.Description, Price: p.Price.Float64(), Stock: p.Stock, Images: append([]string(nil), p.Images...), Attributes: []apicontract.ProductAttributeValue{}, Categories: []apicontract.Category{}, Options: []apicontract.ProductOption{}, RelatedProducts: []apicontract.RelatedProduct{}, Variants: []apicontract.ProductVariant{}, CreatedAt: p.CreatedAt, UpdatedAt: p.UpdatedAt, DeletedAt: deletedAt(p.DeletedAt)}
 }
 func basicVariantContract(v models.ProductVariant) apicontract.ProductVariant {
 	id := int(v.ID)
-	return apicontract.ProductVariant{Id: &id, Sku: v.SKU, Title: v.Title, Price: v.Price.Float64(), Stock: v.Stock, Position: v.Position, IsPublished: v.IsPublished}
+	return apicontract.ProductVariant{Id: &id, Sku: v.SKU, Title: v.Title, Price: v.Price.Float64(), Stock: v.Stock, Position: v.Position, IsPublished: v.IsPublished, MinQuantity: v.MinQuantity, MaxQuantity: v.MaxQuantity, QuantityStep: v.QuantityStep, PurchaseLimit: v.PurchaseLimit, InventoryPolicy: v.InventoryPolicy, BackorderLimit: v.BackorderLimit, PreorderReleaseAt: v.PreorderReleaseAt, PromisedShipAt: v.PromisedShipDate(), IsGiftCard: v.IsGiftCard}
 }
 func orderContract(o models.Order, owner *uint) apicontract.Order {
 	items := make([]apicontract.OrderItem, 0, len(o.Items))
 	for _, item := range o.Items {
-		items = append(items, apicontract.OrderItem{Id: int(item.ID), OrderId: int(item.OrderID), ProductVariantId: int(item.ProductVariantID), VariantSku: item.VariantSKU, VariantTitle: item.VariantTitle, Quantity: item.Quantity, Price: item.Price.Float64(), ProductVariant: basicVariantContract(item.ProductVariant), Product: basicProductContract(item.ProductVariant.Product), CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt, DeletedAt: deletedAt(item.DeletedAt)})
+		remaining := item.RemainingQuantity()
+		items = append(items, apicontract.OrderItem{Id: int(item.ID), OrderId: int(item.OrderID), ProductVariantId: int(item.ProductVariantID), VariantSku: item.VariantSKU, VariantTitle: item.VariantTitle, Quantity: item.Quantity, Price: item.Price.Float64(), InventoryPolicy: optionalString(item.InventoryPolicy), PromisedShipAt: item.PromisedShipAt, QuantityFulfilled: &item.QuantityFulfilled, QuantityCancelled: &item.QuantityCancelled, QuantityRemaining: &remaining, ProductVariant: basicVariantContract(item.ProductVariant), Product: basicProductContract(item.ProductVariant.Product), CreatedAt: item.CreatedAt, UpdatedAt: item.UpdatedAt, DeletedAt: deletedAt(item.DeletedAt)})
 	}
 	var uid *int
 	if o.UserID != nil {
@@ -581,12 +717,17 @@ func orderContract(o models.Order, owner *uint) apicontract.Order {
 		v := openapi_types.Email(*o.GuestEmail)
 		email = &v
 	}
-	return apicontract.Order{Id: int(o.ID), UserId: uid, CheckoutSessionId: int(o.CheckoutSessionID), GuestEmail: email, ConfirmationToken: o.ConfirmationToken, Total: o.Total.Float64(), Status: apicontract.OrderStatus(o.Status), Items: items, PaymentMethodDisplay: optionalString(o.PaymentMethodDisplay), ShippingAddressPretty: optionalString(o.ShippingAddressPretty), CanCancel: owner != nil && o.UserID != nil && *owner == *o.UserID && models.IsUserCancelableOrderStatus(o.Status), CreatedAt: o.CreatedAt, UpdatedAt: o.UpdatedAt, DeletedAt: deletedAt(o.DeletedAt)}
+	attributes := o.Attributes()
+	return apicontract.Order{Id: int(o.ID), UserId: uid, CheckoutSessionId: int(o.CheckoutSessionID), GuestEmail: email, ConfirmationToken: o.ConfirmationToken, Total: o.Total.Float64(), Status: apicontract.OrderStatus(o.Status), Items: items, PaymentMethodDisplay: optionalString(o.PaymentMethodDisplay), ShippingAddressPretty: optionalString(o.ShippingAddressPretty), Attributes: &attributes, CanCancel: owner != nil && o.UserID != nil && *owner == *o.UserID && models.IsUserCancelableOrderStatus(o.Status), CreatedAt: o.CreatedAt, UpdatedAt: o.UpdatedAt, DeletedAt: deletedAt(o.DeletedAt)}
 }
 func orderPageContract(p orderservice.Page, owner *uint) apicontract.OrderPage {
 	data := make([]apicontract.Order, 0, len(p.Orders))
 	for _, o := range p.Orders {
-		data = append(data, orderContract(o, owner))
+		contract := orderContract(o, owner)
+		if owner == nil {
+			contract.Tags = orderTagsContract(p.Tags[o.ID])
+		}
+		data = append(data, contract)
 	}
 	return apicontract.OrderPage{Data: data, Pagination: apicontract.Pagination{Page: p.Page, Limit: p.Limit, Total: int(p.Total), TotalPages: p.TotalPages}}
 }
@@ -0,0 +1,31 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	checkoutservice "ecommerce/internal/services/checkout"
+)
+
+func (e *CheckoutProviderEndpoints) CreateExpressCheckout(ctx context.Context, r apicontract.CreateExpressCheckoutRequestObject) (apicontract.CreateExpressCheckoutResponseObject, error) {
+	parent, ok := checkoutservice.SessionFromContext(ctx)
+	if !ok {
+		return nil, problemError(http.StatusBadRequest, "checkout_session_required", "A checkout session is required", nil)
+	}
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A product variant and quantity are required.", nil)
+	}
+	session, err := e.checkout.StartExpressCheckout(ctx, checkoutservice.StartExpressCheckoutInput{
+		ParentSessionID: parent.ID, ProductVariantID: uint(r.Body.ProductVariantId), Quantity: r.Body.Quantity,
+	}, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	cart, err := e.checkout.CartForSession(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.CreateExpressCheckout201JSONResponse{Token: session.PublicToken, ExpiresAt: session.ExpiresAt, Cart: cartContract(cart)}, nil
+}
@@ -0,0 +1,156 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"ecommerce/internal/apicontract"
+	"ecommerce/internal/checkoutplugins"
+	checkoutservice "ecommerce/internal/services/checkout"
+	orderservice "ecommerce/internal/services/orders"
+	"ecommerce/models"
+)
+
+func (e *CheckoutProviderEndpoints) ListCheckoutFields(ctx context.Context, r apicontract.ListCheckoutFieldsRequestObject) (apicontract.ListCheckoutFieldsResponseObject, error) {
+	fields, err := e.checkout.VisibleCheckoutFields(ctx, derefString(r.Params.Channel))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]apicontract.CheckoutPluginField, 0, len(fields))
+	for _, field := range fields {
+		out = append(out, pluginFieldContract(checkoutservice.CheckoutFieldDefinition(field)))
+	}
+	return apicontract.ListCheckoutFields200JSONResponse{Data: out}, nil
+}
+
+func (e *CheckoutProviderEndpoints) ListAdminCheckoutFields(ctx context.Context, _ apicontract.ListAdminCheckoutFieldsRequestObject) (apicontract.ListAdminCheckoutFieldsResponseObject, error) {
+	fields, err := e.checkout.ListCheckoutFields(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]apicontract.CheckoutField, 0, len(fields))
+	for _, field := range fields {
+		out = append(out, checkoutFieldContract(field))
+	}
+	return apicontract.ListAdminCheckoutFields200JSONResponse{Data: out}, nil
+}
+
+func (e *CheckoutProviderEndpoints) CreateAdminCheckoutField(ctx context.Context, r apicontract.CreateAdminCheckoutFieldRequestObject) (apicontract.CreateAdminCheckoutFieldResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A checkout field body is required.", nil)
+	}
+	field, err := e.checkout.CreateCheckoutField(ctx, checkoutFieldInput(*r.Body))
+	if err != nil {
+		return nil, checkoutFieldEndpointError(err)
+	}
+	return apicontract.CreateAdminCheckoutField201JSONResponse(checkoutFieldContract(field)), nil
+}
+
+func (e *CheckoutProviderEndpoints) GetAdminCheckoutField(ctx context.Context, r apicontract.GetAdminCheckoutFieldRequestObject) (apicontract.GetAdminCheckoutFieldResponseObject, error) {
+	field, err := e.checkout.GetCheckoutField(ctx, uint(r.Id))
+	if err != nil {
+		return nil, checkoutFieldEndpointError(err)
+	}
+	return apicontract.GetAdminCheckoutField200JSONResponse(checkoutFieldContract(field)), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateAdminCheckoutField(ctx context.Context, r apicontract.UpdateAdminCheckoutFieldRequestObject) (apicontract.UpdateAdminCheckoutFieldResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A checkout field body is required.", nil)
+	}
+	field, err := e.checkout.UpdateCheckoutField(ctx, uint(r.Id), checkoutFieldInput(*r.Body))
+	if err != nil {
+		return nil, checkoutFieldEndpointError(err)
+	}
+	return apicontract.UpdateAdminCheckoutField200JSONResponse(checkoutFieldContract(field)), nil
+}
+
+func (e *CheckoutProviderEndpoints) DeleteAdminCheckoutField(ctx context.Context, r apicontract.DeleteAdminCheckoutFieldRequestObject) (apicontract.DeleteAdminCheckoutFieldResponseObject, error) {
+	if err := e.checkout.DeleteCheckoutField(ctx, uint(r.Id)); err != nil {
+		return nil, checkoutFieldEndpointError(err)
+	}
+	return apicontract.DeleteAdminCheckoutField204Response{}, nil
+}
+
+func (e *CheckoutProviderEndpoints) ExportAdminOrders(ctx context.Context, r apicontract.ExportAdminOrdersRequestObject) (apicontract.ExportAdminOrdersResponseObject, error) {
+	if r.Params.StartDate != nil && r.Params.EndDate != nil && r.Params.EndDate.Before(*r.Params.StartDate) {
+		return nil, problemError(http.StatusBadRequest, "invalid_date_range", "end_date must be on or after start_date", nil)
+	}
+	contents, err := e.orders.ExportCSV(ctx, orderservice.ExportInput{Status: derefString(r.Params.Status), StartDate: r.Params.StartDate, EndDate: r.Params.EndDate})
+	if err != nil {
+		return nil, checkoutEndpointError(err)
+	}
+	return apicontract.ExportAdminOrders200TextcsvResponse{Body: bytes.NewReader(contents), ContentLength: int64(len(contents))}, nil
+}
+
+func checkoutFieldInput(body apicontract.CheckoutFieldRequest) checkoutservice.CheckoutFieldInput {
+	input := checkoutservice.CheckoutFieldInput{
+		Key: body.Key, Label: body.Label, Type: body.Type, Required: body.Required != nil && *body.Required,
+		Placeholder: derefString(body.Placeholder), HelpText: derefString(body.HelpText), Active: body.Active == nil || *body.Active,
+	}
+	if body.MaxLength != nil {
+		input.MaxLength = *body.MaxLength
+	}
+	if body.Position != nil {
+		input.Position = *body.Position
+	}
+	if body.Channels != nil {
+		input.Channels = *body.Channels
+	}
+	if body.Options != nil {
+		for _, option := range *body.Options {
+			input.Options = append(input.Options, checkoutplugins.FieldOption{Value: option.Value, Label: option.Label})
+		}
+	}
+	return input
+}
+
+func checkoutFieldEndpointError(err error) error {
+	switch {
+	case errors.Is(err, checkoutservice.ErrInvalidCheckoutField):
+		return problemError(http.StatusBadRequest, "invalid_checkout_field", err.Error(), err)
+	case errors.Is(err, checkoutservice.ErrCheckoutFieldNotFound):
+		return problemError(http.StatusNotFound, "checkout_field_not_found", "Checkout field not found.", err)
+	default:
+		return err
+	}
+}
+
+func checkoutAttributeEndpointError(err error) error {
+	var attributeErr *checkoutservice.CheckoutAttributeError
+	if !errors.As(err, &attributeErr) {
+		return err
+	}
+	keys := make([]string, 0, len(attributeErr.Problems))
+	for key := range attributeErr.Problems {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	issues := make([]FieldError, 0, len(keys))
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		issues = append(issues, FieldError{Field: "attributes." + key, Code: "invalid", Message: attributeErr.Problems[key]})
+		messages = append(messages, attributeErr.Problems[key])
+	}
+	return ErrorProblem(Problem{Status: http.StatusBadRequest, Code: "invalid_checkout_attributes", Detail: strings.Join(messages, " "), Errors: issues}, err)
+}
+
+func checkoutFieldContract(field models.CheckoutField) apicontract.CheckoutField {
+	options := make([]apicontract.CheckoutPluginFieldOption, 0)
+	for _, option := range checkoutservice.CheckoutFieldOptions(field) {
+		options = append(options, apicontract.CheckoutPluginFieldOption{Value: option.Value, Label: option.Label})
+	}
+	channels := checkoutservice.CheckoutFieldChannels(field)
+	if channels == nil {
+		channels = []string{}
+	}
+	return apicontract.CheckoutField{
+		Id: int(field.ID), Key: field.Key, Label: field.Label, Type: field.Type, Required: field.Required,
+		Placeholder: optionalString(field.Placeholder), HelpText: optionalString(field.HelpText), Options: options, Channels: channels,
+		MaxLength: field.MaxLength, Position: field.Position, Active: field.Active, CreatedAt: field.CreatedAt, UpdatedAt: field.UpdatedAt,
+	}
+}
@@ -9,10 +9,13 @@ import (
 	"time"
 
 	"ecommerce/internal/apicontract"
+	"ecommerce/internal/requestctx"
 	checkoutservice "ecommerce/internal/services/checkout"
+	giftcardservice "ecommerce/internal/services/giftcards"
 	inventoryservice "ecommerce/internal/services/inventory"
 	paymentservice "ecommerce/internal/services/payments"
 	providerops "ecommerce/internal/services/providerops"
+	riskservice "ecommerce/internal/services/risk"
 	"ecommerce/models"
 
 	"gorm.io/gorm"
@@ -44,14 +47,6 @@ func (e *CheckoutProviderEndpoints) authorizeCheckoutOrderPayment(
 		}
 		return apicontract.AuthorizeCheckoutOrderPayment200JSONResponse(replay), nil
 	}
-	client := ""
-	if request, ok := ctx.(interface{ ClientIP() string }); ok {
-		client = request.ClientIP()
-	}
-	if !allowCheckoutSubmission("authorize_payment", session.PublicToken, client, time.Now().UTC()) {
-		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
-		return nil, problemError(429, "checkout_rate_limited", "Too many checkout attempts. Please wait and try again.", nil)
-	}
 
 	order, err := e.orders.PayableCheckoutOrder(ctx, uint(r.Id), session)
 	if err != nil {
@@ -71,6 +66,18 @@ func (e *CheckoutProviderEndpoints) authorizeCheckoutOrderPayment(
 		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
 		return nil, checkoutEndpointError(err)
 	}
+	metadata, _ := requestctx.MetadataFrom(ctx)
+	assessment, err := e.risk.Assess(ctx, riskservice.AssessInput{
+		Order: order, Snapshot: snapshot, ClientIP: metadata.ClientIP, UserAgent: metadata.Headers["User-Agent"], CorrelationID: correlationID(ctx),
+	}, time.Now().UTC())
+	if err != nil {
+		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
+		return nil, err
+	}
+	if assessment.Action() == models.RiskActionReject {
+		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
+		return nil, problemError(403, "order_rejected", "This order could not be accepted. Please contact support.", nil)
+	}
 	providerKey := strings.TrimSpace(key)
 	if providerKey == "" {
 		providerKey = fmt.Sprintf("authorize-order-%d-snapshot-%d", order.ID, snapshot.ID)
@@ -78,13 +85,34 @@ func (e *CheckoutProviderEndpoints) authorizeCheckoutOrderPayment(
 
 	var intent models.PaymentIntent
 	var transaction models.PaymentTransaction
+	prepaid := false
 	err = e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkoutservice.CheckOrderPurchaseLimits(tx, order); err != nil {
+			return err
+		}
 		if err := paymentservice.BindSnapshotToOrder(tx, &snapshot, order.ID, time.Now().UTC()); err != nil {
 			return err
 		}
 		if err := inventoryservice.ReserveOrderItems(tx, order, "checkout-payment:"+providerKey, time.Now().UTC().Add(checkoutReservationTTL)); err != nil {
 			return err
 		}
+		if r.Body.GiftCards != nil {
+			if _, err := giftcardservice.ApplyTenders(tx, order, snapshot, giftCardTenders(r.Body.GiftCards), providerKey, time.Now().UTC()); err != nil {
+				return err
+			}
+		}
+		due, err := paymentservice.AmountDue(tx, order.ID, snapshot)
+		if err != nil {
+			return err
+		}
+		if due == 0 {
+			prepaid = true
+			var locked models.Order
+			if err := tx.Preload("Items").First(&locked, order.ID).Error; err != nil {
+				return err
+			}
+			return paymentservice.ApplyPrepaidCheckoutState(tx, &locked, snapshot, correlationID(ctx))
+		}
 		var prepareErr error
 		intent, transaction, prepareErr = paymentservice.PrepareAuthorizedPaymentIntent(tx, order.ID, snapshot, providerKey)
 		return prepareErr
@@ -93,6 +121,9 @@ func (e *CheckoutProviderEndpoints) authorizeCheckoutOrderPayment(
 		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
 		return nil, checkoutEndpointError(err)
 	}
+	if prepaid {
+		return e.completeCheckoutOrderPayment(ctx, idempotency.Record, order)
+	}
 	providerRequest, err := paymentservice.PreparedAuthorizationRequest(intent, transaction, snapshot, correlationID(ctx))
 	if err != nil {
 		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
@@ -135,6 +166,9 @@ func (e *CheckoutProviderEndpoints) authorizeCheckoutOrderPayment(
 			if err := paymentservice.MarkPreparedAuthorizationFailed(tx, intent.ID, executeErr.Error()); err != nil {
 				return err
 			}
+			if err := giftcardservice.ReverseTenders(tx, order.ID, providerKey); err != nil {
+				return err
+			}
 			return inventoryservice.ReleaseReservationsForOrder(tx, order.ID, "payment-authorize-failed")
 		})
 		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
@@ -144,8 +178,16 @@ func (e *CheckoutProviderEndpoints) authorizeCheckoutOrderPayment(
 		_ = e.checkout.ReleaseIdempotency(ctx, idempotency.Record)
 		return apicontract.AuthorizeCheckoutOrderPayment202JSONResponse(providerAccepted(operation, "Payment authorization pending reconciliation")), nil
 	}
+	return e.completeCheckoutOrderPayment(ctx, idempotency.Record, order)
+}
+
+func (e *CheckoutProviderEndpoints) completeCheckoutOrderPayment(
+	ctx context.Context,
+	record *models.IdempotencyKey,
+	order models.Order,
+) (apicontract.AuthorizeCheckoutOrderPaymentResponseObject, error) {
 	owner := order.UserID
-	order, err = e.orders.Get(ctx, order.ID, func() *uint {
+	order, err := e.orders.Get(ctx, order.ID, func() *uint {
 		if owner != nil {
 			return owner
 		}
@@ -156,7 +198,7 @@ func (e *CheckoutProviderEndpoints) authorizeCheckoutOrderPayment(
 		return nil, checkoutEndpointError(err)
 	}
 	response := apicontract.ProcessPaymentResponse{Message: "Order submitted and pending confirmation", Order: orderContract(order, owner)}
-	if err := e.checkout.CompleteIdempotency(ctx, idempotency.Record, 200, response); err != nil {
+	if err := e.checkout.CompleteIdempotency(ctx, record, 200, response); err != nil {
 		return nil, err
 	}
 	return apicontract.AuthorizeCheckoutOrderPayment200JSONResponse(response), nil
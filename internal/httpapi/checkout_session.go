@@ -10,6 +10,7 @@ import (
 	"ecommerce/internal/requestctx"
 	checkoutservice "ecommerce/internal/services/checkout"
 	paymentservice "ecommerce/internal/services/payments"
+	"ecommerce/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -18,32 +19,48 @@ import (
 const (
 	checkoutSessionCookieName = "checkout_session"
 	checkoutCSRFCookieName    = "csrf_token"
+	expressCheckoutHeader     = "X-Express-Checkout"
 )
 
 type checkoutSessionMode struct {
 	create         bool
 	allowConverted bool
+	// express operations act on the buy-now session named by the
+	// X-Express-Checkout header instead of the cookie session.
+	express bool
+	// mutatesCart operations are refused in sessions opened from a draft
+	// order, whose cart is fixed by staff.
+	mutatesCart bool
 }
 
 var checkoutSessionOperations = map[string]checkoutSessionMode{
-	"GetCheckoutCart":                  {create: true},
-	"GetCheckoutCartSummary":           {},
+	"GetCheckoutCart":                  {create: true, express: true},
+	"GetCheckoutCartSummary":           {express: true},
 	"AddCheckoutCartItem":              {create: true},
-	"UpdateCheckoutCartItem":           {create: true},
-	"DeleteCheckoutCartItem":           {create: true},
-	"ListCheckoutSessionPlugins":       {},
-	"QuoteCheckoutSession":             {create: true},
-	"CreateCheckoutOrder":              {create: true},
-	"AuthorizeCheckoutOrderPayment":    {create: true, allowConverted: true},
-	"QuoteCheckoutOrderShippingRates":  {create: true, allowConverted: true},
-	"GetCheckoutOrderShippingTracking": {create: true, allowConverted: true},
-	"FinalizeCheckoutOrderTax":         {create: true, allowConverted: true},
+	"UpdateCheckoutCartItem":           {create: true, express: true, mutatesCart: true},
+	"DeleteCheckoutCartItem":           {create: true, express: true, mutatesCart: true},
+	"ListCheckoutSessionPlugins":       {express: true},
+	"QuoteCheckoutSession":             {create: true, express: true},
+	"CreateCheckoutOrder":              {create: true, express: true},
+	"AuthorizeCheckoutOrderPayment":    {create: true, allowConverted: true, express: true},
+	"QuoteCheckoutOrderShippingRates":  {create: true, allowConverted: true, express: true},
+	"GetCheckoutOrderShippingTracking": {create: true, allowConverted: true, express: true},
+	"FinalizeCheckoutOrderTax":         {create: true, allowConverted: true, express: true},
+	"CheckGiftCardBalance":             {create: true, express: true},
 	"GetCart":                          {create: true},
 	"AddCartItem":                      {create: true},
 	"UpdateCartItem":                   {create: true},
 	"DeleteCartItem":                   {create: true},
 	"QuoteCheckout":                    {create: true},
 	"CreateOrder":                      {create: true},
+	"SaveMyCart":                       {},
+	"ConvertCheckoutSavedCart":         {create: true},
+	"GetCheckoutState":                 {create: true, express: true},
+	"UpdateCheckoutContact":            {create: true, express: true},
+	"UpdateCheckoutShippingAddress":    {create: true, express: true},
+	"UpdateCheckoutShippingMethod":     {create: true, express: true},
+	"UpdateCheckoutPayment":            {create: true, express: true},
+	"ConfirmCheckoutReview":            {create: true, express: true},
 }
 
 func (e *CheckoutProviderEndpoints) CheckoutSessionMiddleware() apicontract.StrictMiddlewareFunc {
@@ -58,6 +75,19 @@ func (e *CheckoutProviderEndpoints) CheckoutSessionMiddleware() apicontract.Stri
 				userID = principal.AccountID
 			}
 			metadata, _ := requestctx.MetadataFrom(ctx.Request.Context())
+			if token := metadata.Headers[expressCheckoutHeader]; mode.express && token != "" {
+				session, err := e.checkout.ResolveExpressSession(ctx.Request.Context(), checkoutservice.ResolveExpressSessionInput{
+					UserID: userID, Token: token, AllowConverted: mode.allowConverted,
+				}, time.Now().UTC())
+				if err != nil {
+					return nil, checkoutEndpointError(err)
+				}
+				if mode.mutatesCart && session.Kind == models.CheckoutSessionKindDraftOrder {
+					return nil, checkoutEndpointError(checkoutservice.ErrDraftOrderCartLocked)
+				}
+				ctx.Request = ctx.Request.WithContext(checkoutservice.WithSession(ctx.Request.Context(), session))
+				return e.handleCheckoutSessionOperation(ctx, request, operationID, next)
+			}
 			resolved, err := e.checkout.ResolveSession(ctx.Request.Context(), checkoutservice.ResolveSessionInput{
 				UserID: userID, Token: metadata.Cookies[checkoutSessionCookieName], Create: mode.create, AllowConverted: mode.allowConverted,
 			})
@@ -69,6 +99,9 @@ func (e *CheckoutProviderEndpoints) CheckoutSessionMiddleware() apicontract.Stri
 			}
 			if resolved.Session != nil {
 				requestContext := checkoutservice.WithSession(ctx.Request.Context(), *resolved.Session)
+				if resolved.Merge != nil {
+					requestContext = checkoutservice.WithCartMerge(requestContext, *resolved.Merge)
+				}
 				ctx.Request = ctx.Request.WithContext(requestContext)
 				if resolved.SetCookie {
 					setCheckoutCookie(ctx, checkoutSessionCookieName, resolved.Session.PublicToken, true)
@@ -77,37 +110,41 @@ func (e *CheckoutProviderEndpoints) CheckoutSessionMiddleware() apicontract.Stri
 					}
 				}
 			}
-			switch operationID {
-			case "AuthorizeCheckoutOrderPayment":
-				typed, ok := request.(apicontract.AuthorizeCheckoutOrderPaymentRequestObject)
-				if !ok {
-					return nil, errors.New("invalid payment authorization request")
-				}
-				return e.authorizeCheckoutOrderPayment(ctx, typed)
-			case "QuoteCheckoutOrderShippingRates":
-				typed, ok := request.(apicontract.QuoteCheckoutOrderShippingRatesRequestObject)
-				if !ok || typed.Body == nil {
-					return nil, problemError(http.StatusBadRequest, "invalid_request", "Shipping quote body is required", nil)
-				}
-				if err := e.validateCheckoutSnapshot(ctx, uint(typed.Id), uint(typed.Body.SnapshotId)); err != nil {
-					return nil, checkoutEndpointError(err)
-				}
-			case "FinalizeCheckoutOrderTax":
-				typed, ok := request.(apicontract.FinalizeCheckoutOrderTaxRequestObject)
-				if !ok || typed.Body == nil {
-					return nil, problemError(http.StatusBadRequest, "invalid_request", "Tax finalization body is required", nil)
-				}
-				if err := e.validateCheckoutSnapshot(ctx, uint(typed.Id), uint(typed.Body.SnapshotId)); err != nil {
-					return nil, checkoutEndpointError(err)
-				}
-			}
-			response, err := next(ctx, request)
-			if err != nil {
-				return nil, checkoutEndpointError(err)
-			}
-			return response, nil
+			return e.handleCheckoutSessionOperation(ctx, request, operationID, next)
+		}
+	}
+}
+
+func (e *CheckoutProviderEndpoints) handleCheckoutSessionOperation(ctx *gin.Context, request interface{}, operationID string, next apicontract.StrictHandlerFunc) (interface{}, error) {
+	switch operationID {
+	case "AuthorizeCheckoutOrderPayment":
+		typed, ok := request.(apicontract.AuthorizeCheckoutOrderPaymentRequestObject)
+		if !ok {
+			return nil, errors.New("invalid payment authorization request")
 		}
+		return e.authorizeCheckoutOrderPayment(ctx, typed)
+	case "QuoteCheckoutOrderShippingRates":
+		typed, ok := request.(apicontract.QuoteCheckoutOrderShippingRatesRequestObject)
+		if !ok || typed.Body == nil {
+			return nil, problemError(http.StatusBadRequest, "invalid_request", "Shipping quote body is required", nil)
+		}
+		if err := e.validateCheckoutSnapshot(ctx, uint(typed.Id), uint(typed.Body.SnapshotId)); err != nil {
+			return nil, checkoutEndpointError(err)
+		}
+	case "FinalizeCheckoutOrderTax":
+		typed, ok := request.(apicontract.FinalizeCheckoutOrderTaxRequestObject)
+		if !ok || typed.Body == nil {
+			return nil, problemError(http.StatusBadRequest, "invalid_request", "Tax finalization body is required", nil)
+		}
+		if err := e.validateCheckoutSnapshot(ctx, uint(typed.Id), uint(typed.Body.SnapshotId)); err != nil {
+			return nil, checkoutEndpointError(err)
+		}
+	}
+	response, err := next(ctx, request)
+	if err != nil {
+		return nil, checkoutEndpointError(err)
 	}
+	return response, nil
 }
 
 func (e *CheckoutProviderEndpoints) validateCheckoutSnapshot(ctx context.Context, orderID, snapshotID uint) error {
@@ -0,0 +1,222 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	checkoutservice "ecommerce/internal/services/checkout"
+	"ecommerce/models"
+)
+
+func (e *CheckoutProviderEndpoints) GetCheckoutState(ctx context.Context, _ apicontract.GetCheckoutStateRequestObject) (apicontract.GetCheckoutStateResponseObject, error) {
+	state, err := e.checkoutState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.GetCheckoutState200JSONResponse(state), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateCheckoutContact(ctx context.Context, r apicontract.UpdateCheckoutContactRequestObject) (apicontract.UpdateCheckoutContactResponseObject, error) {
+	session, err := checkoutStateSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "Contact details are required.", nil)
+	}
+	if err := e.checkout.UpdateCheckoutContact(ctx, session.ID, checkoutservice.CheckoutContact{Email: string(r.Body.Email), Phone: derefString(r.Body.Phone)}); err != nil {
+		return nil, checkoutStateEndpointError(err)
+	}
+	state, err := e.checkoutState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.UpdateCheckoutContact200JSONResponse(state), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateCheckoutShippingAddress(ctx context.Context, r apicontract.UpdateCheckoutShippingAddressRequestObject) (apicontract.UpdateCheckoutShippingAddressResponseObject, error) {
+	session, err := checkoutStateSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A shipping address is required.", nil)
+	}
+	address := map[string]string{
+		"full_name": r.Body.FullName, "company": derefString(r.Body.Company), "line1": r.Body.Line1, "line2": derefString(r.Body.Line2),
+		"city": r.Body.City, "state": derefString(r.Body.State), "postal_code": r.Body.PostalCode, "country": r.Body.Country,
+	}
+	if err := e.checkout.UpdateCheckoutShippingAddress(ctx, session.ID, address); err != nil {
+		return nil, checkoutStateEndpointError(err)
+	}
+	state, err := e.checkoutState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.UpdateCheckoutShippingAddress200JSONResponse(state), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateCheckoutShippingMethod(ctx context.Context, r apicontract.UpdateCheckoutShippingMethodRequestObject) (apicontract.UpdateCheckoutShippingMethodResponseObject, error) {
+	session, err := checkoutStateSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A shipping method is required.", nil)
+	}
+	method := checkoutservice.CheckoutProvider{ProviderID: r.Body.ProviderId, Data: mapValue(r.Body.Data)}
+	if err := e.checkout.UpdateCheckoutShippingMethod(ctx, e.plugins, session.ID, method, time.Now().UTC()); err != nil {
+		return nil, checkoutStateEndpointError(err)
+	}
+	state, err := e.checkoutState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.UpdateCheckoutShippingMethod200JSONResponse(state), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateCheckoutPayment(ctx context.Context, r apicontract.UpdateCheckoutPaymentRequestObject) (apicontract.UpdateCheckoutPaymentResponseObject, error) {
+	session, err := checkoutStateSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "Payment details are required.", nil)
+	}
+	payment := checkoutservice.CheckoutPayment{ProviderID: r.Body.ProviderId, Data: mapValue(r.Body.Data), TaxData: mapValue(r.Body.TaxData)}
+	if err := e.checkout.UpdateCheckoutPayment(ctx, e.plugins, session.ID, payment, time.Now().UTC()); err != nil {
+		return nil, checkoutStateEndpointError(err)
+	}
+	state, err := e.checkoutState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.UpdateCheckoutPayment200JSONResponse(state), nil
+}
+
+func (e *CheckoutProviderEndpoints) ConfirmCheckoutReview(ctx context.Context, r apicontract.ConfirmCheckoutReviewRequestObject) (apicontract.ConfirmCheckoutReviewResponseObject, error) {
+	session, err := checkoutStateSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	view, err := e.checkout.CheckoutState(ctx, e.plugins, session.ID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if view.ItemCount == 0 {
+		return nil, problemError(http.StatusConflict, "checkout_step_out_of_order", "The cart is empty.", nil)
+	}
+	for _, step := range view.Steps {
+		if step.Step != checkoutservice.CheckoutStepReview && step.Status != checkoutservice.CheckoutStepStatusComplete {
+			return nil, checkoutStateEndpointError(checkoutservice.ErrCheckoutStepOutOfOrder)
+		}
+	}
+	selection := view.State.Selection()
+	request := &apicontract.CheckoutQuoteRequest{
+		PaymentProviderId: selection.PaymentProviderID, ShippingProviderId: selection.ShippingProviderID,
+		PaymentData: &selection.PaymentData, ShippingData: &selection.ShippingData, TaxData: &selection.TaxData,
+	}
+	if r.Body != nil {
+		request.Channel, request.Attributes = r.Body.Channel, r.Body.Attributes
+	}
+	quote, err := e.quote(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if !quote.Valid || quote.SnapshotId == nil || quote.ExpiresAt == nil {
+		return nil, problemError(http.StatusConflict, "checkout_quote_invalid", "The checkout could not be quoted with the selected providers.", nil)
+	}
+	if err := e.checkout.RecordCheckoutReview(ctx, session.ID, view.State.Revision, uint(*quote.SnapshotId), *quote.ExpiresAt, mapValue(request.Attributes), time.Now().UTC()); err != nil {
+		return nil, checkoutStateEndpointError(err)
+	}
+	state, err := e.checkoutState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ConfirmCheckoutReview200JSONResponse(state), nil
+}
+
+func (e *CheckoutProviderEndpoints) checkoutState(ctx context.Context) (apicontract.CheckoutState, error) {
+	session, err := checkoutStateSession(ctx)
+	if err != nil {
+		return apicontract.CheckoutState{}, err
+	}
+	now := time.Now().UTC()
+	view, err := e.checkout.CheckoutState(ctx, e.plugins, session.ID, now)
+	if err != nil {
+		return apicontract.CheckoutState{}, err
+	}
+	return checkoutStateContract(view, now), nil
+}
+
+func checkoutStateSession(ctx context.Context) (models.CheckoutSession, error) {
+	session, ok := checkoutservice.SessionFromContext(ctx)
+	if !ok {
+		return models.CheckoutSession{}, problemError(http.StatusBadRequest, "checkout_session_required", "A checkout session is required", nil)
+	}
+	return session, nil
+}
+
+func checkoutStateEndpointError(err error) error {
+	var stepErr *checkoutservice.CheckoutStepError
+	switch {
+	case errors.As(err, &stepErr):
+		issues := make([]FieldError, 0, len(stepErr.Problems))
+		for _, message := range stepErr.Problems {
+			issues = append(issues, FieldError{Field: stepErr.Step, Code: "invalid", Message: message})
+		}
+		return ErrorProblem(Problem{Status: http.StatusBadRequest, Code: "invalid_checkout_step", Detail: strings.Join(stepErr.Problems, " "), Errors: issues}, err)
+	case errors.Is(err, checkoutservice.ErrCheckoutStepOutOfOrder):
+		return problemError(http.StatusConflict, "checkout_step_out_of_order", "Complete the earlier checkout steps first.", err)
+	default:
+		return err
+	}
+}
+
+func checkoutStateContract(view checkoutservice.CheckoutStateView, now time.Time) apicontract.CheckoutState {
+	out := apicontract.CheckoutState{
+		Revision: view.State.Revision, NextAction: apicontract.CheckoutStateNextAction(view.NextAction),
+		ItemCount: view.ItemCount, Subtotal: view.Subtotal.Float64(), Steps: make([]apicontract.CheckoutStateStep, 0, len(view.Steps)),
+	}
+	for _, step := range view.Steps {
+		problems := append([]string{}, step.Problems...)
+		out.Steps = append(out.Steps, apicontract.CheckoutStateStep{Step: apicontract.CheckoutStateStepStep(step.Step), Status: apicontract.CheckoutStateStepStatus(step.Status), Problems: problems})
+	}
+	if contact := view.State.Contact; contact != nil {
+		out.Contact = &apicontract.CheckoutStateContact{Email: contact.Email, Phone: optionalString(contact.Phone)}
+	}
+	if address := view.State.ShippingAddress; len(address) > 0 {
+		out.ShippingAddress = &apicontract.CheckoutAddress{
+			FullName: address["full_name"], Company: optionalString(address["company"]), Line1: address["line1"], Line2: optionalString(address["line2"]),
+			City: address["city"], State: optionalString(address["state"]), PostalCode: address["postal_code"], Country: address["country"],
+		}
+	}
+	if method := view.State.ShippingMethod; method != nil {
+		out.ShippingMethod = &apicontract.CheckoutStateShippingMethod{ProviderId: method.ProviderID, Data: mapValueOrEmpty(method.Data)}
+	}
+	if payment := view.State.Payment; payment != nil {
+		out.Payment = &apicontract.CheckoutStatePayment{ProviderId: payment.ProviderID, Display: payment.Display}
+	}
+	if quote := view.Quote; quote != nil {
+		out.Totals = &apicontract.CheckoutStateTotals{Currency: quote.Currency, Subtotal: quote.Subtotal.Float64(), Shipping: quote.Shipping.Float64(), Tax: quote.Tax.Float64(), Total: quote.Total.Float64()}
+	}
+	if len(view.State.Attributes) > 0 {
+		attributes := view.State.Attributes
+		out.Attributes = &attributes
+	}
+	if review := view.State.Review; review != nil && view.Step(checkoutservice.CheckoutStepReview).Status == checkoutservice.CheckoutStepStatusComplete && now.Before(review.ExpiresAt) {
+		out.Review = &apicontract.CheckoutStateReview{SnapshotId: int(review.SnapshotID), ExpiresAt: review.ExpiresAt}
+	}
+	return out
+}
+
+func mapValueOrEmpty(value map[string]string) map[string]string {
+	if value == nil {
+		return map[string]string{}
+	}
+	return value
+}
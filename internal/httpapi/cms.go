@@ -13,6 +13,7 @@ import (
 	"ecommerce/internal/media"
 	"ecommerce/internal/requestctx"
 	"ecommerce/internal/services/cms"
+	segmentservice "ecommerce/internal/services/segments"
 	"ecommerce/models"
 
 	"gorm.io/gorm"
@@ -28,6 +29,7 @@ type CmsMediaEndpoints struct {
 	navigation    *cms.NavigationService
 	globals       *cms.GlobalRegionService
 	redirects     *cms.RedirectService
+	segments      *segmentservice.Service
 	sitemapOrigin string
 }
 
@@ -42,6 +44,7 @@ func NewCmsMediaEndpoints(db *gorm.DB, mediaService *media.Service) (*CmsMediaEn
 		navigation:    cms.NewNavigationService(db),
 		globals:       cms.NewGlobalRegionService(db, mediaService),
 		redirects:     cms.NewRedirectService(db),
+		segments:      segmentservice.NewService(db),
 		sitemapOrigin: normalizeCMSOrigin(os.Getenv("PUBLIC_URL")),
 	}, nil
 }
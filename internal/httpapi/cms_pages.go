@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"ecommerce/internal/apicontract"
+	"ecommerce/internal/requestctx"
 	"ecommerce/internal/services/cms"
 )
 
@@ -162,7 +163,14 @@ func (e *CmsMediaEndpoints) resolveContentPage(ctx context.Context, path, locale
 	}
 	record.Localization = localization
 	if !preview {
-		decision, visible, err := service.ResolveDelivery(ctx, record, cms.RequestContext{Market: market, DeviceClass: device, SegmentKey: segment, UTMSource: utmSource, AssignmentKey: assignmentKey}, time.Now().UTC())
+		var segmentKeys []string
+		if principal, ok := requestctx.PrincipalFrom(ctx); ok && principal.AccountID != 0 {
+			segmentKeys, err = e.segments.KeysForCustomer(ctx, principal.AccountID)
+			if err != nil {
+				return apicontract.CmsPageResponse{}, false, cmsEndpointError(err)
+			}
+		}
+		decision, visible, err := service.ResolveDelivery(ctx, record, cms.RequestContext{Market: market, DeviceClass: device, SegmentKey: segment, SegmentKeys: segmentKeys, UTMSource: utmSource, AssignmentKey: assignmentKey}, time.Now().UTC())
 		if err != nil {
 			return apicontract.CmsPageResponse{}, false, cmsEndpointError(err)
 		}
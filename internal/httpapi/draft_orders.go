@@ -0,0 +1,199 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	"ecommerce/internal/requestctx"
+	checkoutservice "ecommerce/internal/services/checkout"
+	orderservice "ecommerce/internal/services/orders"
+	paymentservice "ecommerce/internal/services/payments"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+func (e *CheckoutProviderEndpoints) ListAdminDraftOrders(ctx context.Context, r apicontract.ListAdminDraftOrdersRequestObject) (apicontract.ListAdminDraftOrdersResponseObject, error) {
+	page, limit := cmsPagination(r.Params.Page, r.Params.Limit)
+	drafts, total, err := e.orders.ListDrafts(ctx, orderservice.ListDraftOrdersInput{Status: derefString(r.Params.Status), Limit: limit, Offset: (page - 1) * limit})
+	if err != nil {
+		return nil, err
+	}
+	data := make([]apicontract.DraftOrder, 0, len(drafts))
+	for _, draft := range drafts {
+		data = append(data, draftOrderContract(draft))
+	}
+	return apicontract.ListAdminDraftOrders200JSONResponse{Data: data, Pagination: apicontract.Pagination{Page: page, Limit: limit, Total: int(total), TotalPages: totalPages(total, limit)}}, nil
+}
+
+func (e *CheckoutProviderEndpoints) CreateAdminDraftOrder(ctx context.Context, r apicontract.CreateAdminDraftOrderRequestObject) (apicontract.CreateAdminDraftOrderResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_draft_order", "A draft order body is required.", nil)
+	}
+	draft, err := e.orders.CreateDraft(ctx, draftOrderInput(ctx, *r.Body), e.plugins)
+	if err != nil {
+		return nil, draftOrderEndpointError(err)
+	}
+	return apicontract.CreateAdminDraftOrder201JSONResponse(draftOrderContract(draft)), nil
+}
+
+func (e *CheckoutProviderEndpoints) GetAdminDraftOrder(ctx context.Context, r apicontract.GetAdminDraftOrderRequestObject) (apicontract.GetAdminDraftOrderResponseObject, error) {
+	draft, err := e.orders.GetDraft(ctx, uint(r.Id))
+	if err != nil {
+		return nil, draftOrderEndpointError(err)
+	}
+	return apicontract.GetAdminDraftOrder200JSONResponse(draftOrderContract(draft)), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateAdminDraftOrder(ctx context.Context, r apicontract.UpdateAdminDraftOrderRequestObject) (apicontract.UpdateAdminDraftOrderResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_draft_order", "A draft order body is required.", nil)
+	}
+	draft, err := e.orders.UpdateDraft(ctx, uint(r.Id), draftOrderInput(ctx, *r.Body), e.plugins)
+	if err != nil {
+		return nil, draftOrderEndpointError(err)
+	}
+	return apicontract.UpdateAdminDraftOrder200JSONResponse(draftOrderContract(draft)), nil
+}
+
+func (e *CheckoutProviderEndpoints) SendAdminDraftOrderPaymentLink(ctx context.Context, r apicontract.SendAdminDraftOrderPaymentLinkRequestObject) (apicontract.SendAdminDraftOrderPaymentLinkResponseObject, error) {
+	var expiresAt *time.Time
+	if r.Body != nil {
+		expiresAt = r.Body.ExpiresAt
+	}
+	draft, err := e.orders.SendDraftPaymentLink(ctx, uint(r.Id), expiresAt)
+	if err != nil {
+		return nil, draftOrderEndpointError(err)
+	}
+	return apicontract.SendAdminDraftOrderPaymentLink200JSONResponse{
+		Url:        strings.TrimRight(e.recovery.BaseURL, "/") + "/checkout/draft-orders/" + draft.PaymentToken,
+		ExpiresAt:  *draft.PaymentLinkExpiresAt,
+		DraftOrder: draftOrderContract(draft),
+	}, nil
+}
+
+func (e *CheckoutProviderEndpoints) MarkAdminDraftOrderPaid(ctx context.Context, r apicontract.MarkAdminDraftOrderPaidRequestObject) (apicontract.MarkAdminDraftOrderPaidResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_draft_order", "payment_method is required", nil)
+	}
+	actor, _ := cmsActor(ctx)
+	input := paymentservice.OfflinePaymentInput{
+		Method: r.Body.PaymentMethod, Reference: derefString(r.Body.PaymentReference), Actor: actor, CorrelationID: correlationID(ctx),
+	}
+	var orderID uint
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, order, err := paymentservice.MarkDraftOrderPaid(tx, uint(r.Id), input, time.Now().UTC())
+		orderID = order.ID
+		return err
+	})
+	if err != nil {
+		return nil, draftOrderEndpointError(err)
+	}
+	draft, err := e.orders.GetDraft(ctx, uint(r.Id))
+	if err != nil {
+		return nil, err
+	}
+	order, err := e.orders.Get(ctx, orderID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.MarkAdminDraftOrderPaid200JSONResponse{DraftOrder: draftOrderContract(draft), Order: orderContract(order, nil)}, nil
+}
+
+func (e *CheckoutProviderEndpoints) CancelAdminDraftOrder(ctx context.Context, r apicontract.CancelAdminDraftOrderRequestObject) (apicontract.CancelAdminDraftOrderResponseObject, error) {
+	draft, err := e.orders.CancelDraft(ctx, uint(r.Id))
+	if err != nil {
+		return nil, draftOrderEndpointError(err)
+	}
+	return apicontract.CancelAdminDraftOrder200JSONResponse(draftOrderContract(draft)), nil
+}
+
+func (e *CheckoutProviderEndpoints) StartDraftOrderCheckout(ctx context.Context, r apicontract.StartDraftOrderCheckoutRequestObject) (apicontract.StartDraftOrderCheckoutResponseObject, error) {
+	userID := uint(0)
+	if principal, ok := requestctx.PrincipalFrom(ctx); ok {
+		userID = principal.AccountID
+	}
+	session, err := e.orders.OpenDraftCheckout(ctx, r.Token, userID)
+	if err != nil {
+		return nil, draftOrderEndpointError(err)
+	}
+	cart, err := e.checkout.CartForSession(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.StartDraftOrderCheckout200JSONResponse{Token: session.PublicToken, ExpiresAt: session.ExpiresAt, Cart: cartContract(cart)}, nil
+}
+
+func draftOrderInput(ctx context.Context, body apicontract.DraftOrderRequest) orderservice.DraftOrderInput {
+	actor, _ := cmsActor(ctx)
+	input := orderservice.DraftOrderInput{
+		Note: derefString(body.Note), ShippingProviderID: body.ShippingProviderId, Actor: actor,
+		Lines: make([]orderservice.DraftOrderLineInput, 0, len(body.Lines)),
+	}
+	if body.CustomerUserId != nil {
+		id := uint(*body.CustomerUserId)
+		input.CustomerUserID = &id
+	}
+	if body.GuestEmail != nil {
+		input.GuestEmail = string(*body.GuestEmail)
+	}
+	if body.ShippingData != nil {
+		input.ShippingData = *body.ShippingData
+	}
+	for _, line := range body.Lines {
+		value := orderservice.DraftOrderLineInput{ProductVariantID: uint(line.ProductVariantId), Quantity: line.Quantity}
+		if line.UnitPrice != nil {
+			price := models.MoneyFromFloat(*line.UnitPrice)
+			value.UnitPrice = &price
+		}
+		if line.DiscountPercent != nil {
+			value.DiscountPercent = models.MoneyFromFloat(*line.DiscountPercent)
+		}
+		input.Lines = append(input.Lines, value)
+	}
+	return input
+}
+
+func draftOrderEndpointError(err error) error {
+	switch {
+	case errors.Is(err, orderservice.ErrDraftOrderNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Draft order not found", err)
+	case errors.Is(err, orderservice.ErrInvalidDraftOrder), errors.Is(err, checkoutservice.ErrVariantNotFound):
+		return problemError(http.StatusBadRequest, "invalid_draft_order", err.Error(), err)
+	case errors.Is(err, orderservice.ErrDraftOrderClosed), errors.Is(err, orderservice.ErrDraftOrderNotInvoiced):
+		return problemError(http.StatusConflict, "draft_order_state_conflict", err.Error(), err)
+	case errors.Is(err, orderservice.ErrDraftOrderLinkExpired):
+		return problemError(http.StatusConflict, "draft_order_link_expired", "This payment link has expired.", err)
+	case errors.Is(err, orderservice.ErrDraftOrderRestricted):
+		return problemError(http.StatusForbidden, "draft_order_restricted", "This payment link was sent to another customer. Sign in with that account, or sign out for a guest order, to use it.", err)
+	}
+	return checkoutEndpointError(err)
+}
+
+func draftOrderContract(v models.DraftOrder) apicontract.DraftOrder {
+	lines := make([]apicontract.DraftOrderLine, 0, len(v.Lines))
+	for _, line := range v.Lines {
+		lines = append(lines, apicontract.DraftOrderLine{
+			Id: int(line.ID), ProductVariantId: int(line.ProductVariantID), VariantSku: line.VariantSKU, VariantTitle: line.VariantTitle,
+			Quantity: line.Quantity, ListPrice: line.ListPrice.Float64(), UnitPrice: line.UnitPrice.Float64(),
+			DiscountPercent: line.DiscountPercent.Float64(), FinalUnitPrice: line.FinalUnitPrice.Float64(),
+		})
+	}
+	shippingData := map[string]string{}
+	if strings.TrimSpace(v.ShippingDataJSON) != "" {
+		_ = json.Unmarshal([]byte(v.ShippingDataJSON), &shippingData)
+	}
+	return apicontract.DraftOrder{
+		Id: int(v.ID), Status: v.Status, CustomerUserId: optionalUint(v.CustomerUserID), GuestEmail: v.GuestEmail, Note: v.Note, CreatedBy: v.CreatedBy,
+		ShippingProviderId: v.ShippingProviderID, ShippingData: shippingData, ShippingAddressPretty: v.ShippingAddressPretty, Currency: v.Currency,
+		Subtotal: v.Subtotal.Float64(), ShippingAmount: v.ShippingAmount.Float64(), TaxAmount: v.TaxAmount.Float64(), Total: v.Total.Float64(),
+		PaymentLinkExpiresAt: v.PaymentLinkExpiresAt, CheckoutSessionId: optionalUint(v.CheckoutSessionID), OrderId: optionalUint(v.OrderID),
+		PaymentMethod: v.PaymentMethod, PaymentReference: v.PaymentReference, InvoicedAt: v.InvoicedAt, CompletedAt: v.CompletedAt, CancelledAt: v.CancelledAt,
+		Lines: lines, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt,
+	}
+}
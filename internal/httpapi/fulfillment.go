@@ -0,0 +1,150 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	inventoryservice "ecommerce/internal/services/inventory"
+	shippingservice "ecommerce/internal/services/shipping"
+	"ecommerce/models"
+)
+
+func (e *CheckoutProviderEndpoints) ListAdminFulfillmentOrders(ctx context.Context, r apicontract.ListAdminFulfillmentOrdersRequestObject) (apicontract.ListAdminFulfillmentOrdersResponseObject, error) {
+	page, limit := cmsPagination(r.Params.Page, r.Params.Limit)
+	fulfillments, total, err := e.shipping.ListFulfillmentQueue(ctx, shippingservice.FulfillmentQueueInput{
+		Status: derefString(r.Params.Status), LocationCode: derefString(r.Params.Location), Limit: limit, Offset: (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fulfillmentEndpointError(err)
+	}
+	return apicontract.ListAdminFulfillmentOrders200JSONResponse{Data: fulfillmentOrdersContract(fulfillments), Pagination: apicontract.Pagination{Page: page, Limit: limit, Total: int(total), TotalPages: totalPages(total, limit)}}, nil
+}
+
+func (e *CheckoutProviderEndpoints) GetAdminFulfillmentOrder(ctx context.Context, r apicontract.GetAdminFulfillmentOrderRequestObject) (apicontract.GetAdminFulfillmentOrderResponseObject, error) {
+	fulfillment, err := e.shipping.GetFulfillmentOrder(ctx, uint(r.Id))
+	if err != nil {
+		return nil, fulfillmentEndpointError(err)
+	}
+	return apicontract.GetAdminFulfillmentOrder200JSONResponse(fulfillmentOrderContract(fulfillment)), nil
+}
+
+func (e *CheckoutProviderEndpoints) PickAdminFulfillmentOrder(ctx context.Context, r apicontract.PickAdminFulfillmentOrderRequestObject) (apicontract.PickAdminFulfillmentOrderResponseObject, error) {
+	fulfillment, err := e.shipping.StartPicking(ctx, uint(r.Id), fulfillmentStepInput(ctx, r.Body), time.Now().UTC())
+	if err != nil {
+		return nil, fulfillmentEndpointError(err)
+	}
+	return apicontract.PickAdminFulfillmentOrder200JSONResponse(fulfillmentOrderContract(fulfillment)), nil
+}
+
+func (e *CheckoutProviderEndpoints) PackAdminFulfillmentOrder(ctx context.Context, r apicontract.PackAdminFulfillmentOrderRequestObject) (apicontract.PackAdminFulfillmentOrderResponseObject, error) {
+	input := fulfillmentStepInput(ctx, nil)
+	if r.Body != nil {
+		input.Note = derefString(r.Body.Note)
+		if r.Body.Lines != nil {
+			for _, line := range *r.Body.Lines {
+				input.Lines = append(input.Lines, shippingservice.PackedLineInput{LineID: uint(line.LineId), Quantity: line.Quantity})
+			}
+		}
+	}
+	fulfillment, err := e.shipping.ConfirmPacking(ctx, uint(r.Id), input, time.Now().UTC())
+	if err != nil {
+		return nil, fulfillmentEndpointError(err)
+	}
+	return apicontract.PackAdminFulfillmentOrder200JSONResponse(fulfillmentOrderContract(fulfillment)), nil
+}
+
+func (e *CheckoutProviderEndpoints) ShipAdminFulfillmentOrder(ctx context.Context, r apicontract.ShipAdminFulfillmentOrderRequestObject) (apicontract.ShipAdminFulfillmentOrderResponseObject, error) {
+	fulfillment, err := e.shipping.MarkFulfillmentShipped(ctx, uint(r.Id), fulfillmentStepInput(ctx, r.Body), time.Now().UTC())
+	if err != nil {
+		return nil, fulfillmentEndpointError(err)
+	}
+	return apicontract.ShipAdminFulfillmentOrder200JSONResponse(fulfillmentOrderContract(fulfillment)), nil
+}
+
+func (e *CheckoutProviderEndpoints) RouteAdminFulfillmentOrder(ctx context.Context, r apicontract.RouteAdminFulfillmentOrderRequestObject) (apicontract.RouteAdminFulfillmentOrderResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_fulfillment_request", "location_code is required", nil)
+	}
+	actor, _ := cmsActor(ctx)
+	fulfillment, err := e.shipping.RerouteFulfillmentOrder(ctx, uint(r.Id), shippingservice.RerouteInput{
+		LocationCode: r.Body.LocationCode, Reason: derefString(r.Body.Reason), Actor: actor,
+	}, time.Now().UTC())
+	if err != nil {
+		return nil, fulfillmentEndpointError(err)
+	}
+	return apicontract.RouteAdminFulfillmentOrder200JSONResponse(fulfillmentOrderContract(fulfillment)), nil
+}
+
+func (e *CheckoutProviderEndpoints) ListAdminOrderFulfillmentOrders(ctx context.Context, r apicontract.ListAdminOrderFulfillmentOrdersRequestObject) (apicontract.ListAdminOrderFulfillmentOrdersResponseObject, error) {
+	if _, err := e.orders.Get(ctx, uint(r.Id), nil); err != nil {
+		return nil, checkoutEndpointError(err)
+	}
+	fulfillments, err := e.shipping.GetOrderFulfillmentOrders(ctx, uint(r.Id))
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ListAdminOrderFulfillmentOrders200JSONResponse(fulfillmentOrdersContract(fulfillments)), nil
+}
+
+func fulfillmentStepInput(ctx context.Context, body *apicontract.FulfillmentStepInput) shippingservice.FulfillmentStepInput {
+	actor, _ := cmsActor(ctx)
+	input := shippingservice.FulfillmentStepInput{Actor: actor, CorrelationID: correlationID(ctx)}
+	if body != nil {
+		input.Note = derefString(body.Note)
+	}
+	return input
+}
+
+func fulfillmentEndpointError(err error) error {
+	var availabilityErr *inventoryservice.InsufficientAvailabilityError
+	switch {
+	case errors.Is(err, shippingservice.ErrInvalidFulfillmentQuery):
+		return problemError(http.StatusBadRequest, "invalid_fulfillment_request", err.Error(), err)
+	case errors.Is(err, shippingservice.ErrFulfillmentOrderNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Fulfillment order not found", err)
+	case errors.Is(err, shippingservice.ErrFulfillmentStepNotAllowed):
+		return problemError(http.StatusConflict, "fulfillment_step_not_allowed", err.Error(), err)
+	case errors.Is(err, shippingservice.ErrFulfillmentPackingMismatch):
+		return problemError(http.StatusConflict, "fulfillment_packing_mismatch", err.Error(), err)
+	case errors.Is(err, shippingservice.ErrInvalidRerouteLocation):
+		return problemError(http.StatusConflict, "invalid_reroute_location", err.Error(), err)
+	case errors.Is(err, inventoryservice.ErrStockLocationNotFound):
+		return problemError(http.StatusNotFound, "stock_location_not_found", "Stock location not found", err)
+	case errors.As(err, &availabilityErr):
+		return ErrorProblem(Problem{
+			Type: TypeConflict, Title: http.StatusText(http.StatusConflict), Status: http.StatusConflict,
+			Code: "insufficient_stock", Detail: "Insufficient stock at the new location",
+			ProductVariantID: availabilityErr.ProductVariantID, Requested: availabilityErr.Requested, Available: availabilityErr.Available,
+		}, err)
+	}
+	return checkoutEndpointError(err)
+}
+
+func fulfillmentOrdersContract(fulfillments []models.FulfillmentOrder) []apicontract.FulfillmentOrder {
+	values := make([]apicontract.FulfillmentOrder, 0, len(fulfillments))
+	for _, fulfillment := range fulfillments {
+		values = append(values, fulfillmentOrderContract(fulfillment))
+	}
+	return values
+}
+
+func fulfillmentOrderContract(v models.FulfillmentOrder) apicontract.FulfillmentOrder {
+	lines := make([]apicontract.FulfillmentOrderLine, 0, len(v.Lines))
+	for _, line := range v.Lines {
+		lines = append(lines, apicontract.FulfillmentOrderLine{
+			Id: int(line.ID), OrderItemId: int(line.OrderItemID), ProductVariantId: int(line.ProductVariantID),
+			VariantSku: line.VariantSKU, VariantTitle: line.VariantTitle,
+			Quantity: line.Quantity, QuantityPicked: line.QuantityPicked, QuantityPacked: line.QuantityPacked,
+		})
+	}
+	return apicontract.FulfillmentOrder{
+		Id: int(v.ID), OrderId: int(v.OrderID), LocationCode: v.LocationCode, Status: v.Status,
+		PickListAt: v.PickListAt, PickedBy: v.PickedBy, PackedAt: v.PackedAt, PackedBy: v.PackedBy, PackingNote: v.PackingNote,
+		LabelPurchasedAt: v.LabelPurchasedAt, ShippedAt: v.ShippedAt, DeliveredAt: v.DeliveredAt, CancelledAt: v.CancelledAt,
+		RoutingExplanation: v.RoutingExplanation,
+		Lines:              lines, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt,
+	}
+}
@@ -0,0 +1,191 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	giftcardservice "ecommerce/internal/services/giftcards"
+	"ecommerce/models"
+)
+
+func (e *CheckoutProviderEndpoints) ListAdminGiftCards(ctx context.Context, r apicontract.ListAdminGiftCardsRequestObject) (apicontract.ListAdminGiftCardsResponseObject, error) {
+	page, limit := cmsPagination(r.Params.Page, r.Params.Limit)
+	input := giftcardservice.ListInput{Status: derefString(r.Params.Status), Source: derefString(r.Params.Source), Limit: limit, Offset: (page - 1) * limit}
+	if r.Params.UserId != nil {
+		userID := uint(*r.Params.UserId)
+		input.UserID = &userID
+	}
+	values, total, err := e.giftcard.List(ctx, input)
+	if err != nil {
+		return nil, giftCardEndpointError(err)
+	}
+	items := make([]apicontract.GiftCard, 0, len(values))
+	for _, value := range values {
+		items = append(items, giftCardContract(value))
+	}
+	return apicontract.ListAdminGiftCards200JSONResponse{Data: items, Pagination: apicontract.Pagination{Page: page, Limit: limit, Total: int(total), TotalPages: totalPages(total, limit)}}, nil
+}
+func (e *CheckoutProviderEndpoints) IssueAdminGiftCard(ctx context.Context, r apicontract.IssueAdminGiftCardRequestObject) (apicontract.IssueAdminGiftCardResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A gift card body is required.", errors.New("gift card body is required"))
+	}
+	actor, _ := cmsActor(ctx)
+	input := giftcardservice.IssueInput{
+		Amount:    models.MoneyFromFloat(r.Body.Amount),
+		Currency:  derefString(r.Body.Currency),
+		Source:    derefString(r.Body.Source),
+		ExpiresAt: r.Body.ExpiresAt,
+		Note:      derefString(r.Body.Note),
+		Actor:     actor,
+	}
+	if r.Body.UserId != nil {
+		userID := uint(*r.Body.UserId)
+		input.UserID = &userID
+	}
+	if r.Body.OrderId != nil {
+		orderID := uint(*r.Body.OrderId)
+		input.OrderID = &orderID
+	}
+	issued, err := e.giftcard.Issue(ctx, input, time.Now().UTC())
+	if err != nil {
+		return nil, giftCardEndpointError(err)
+	}
+	return apicontract.IssueAdminGiftCard201JSONResponse{GiftCard: giftCardContract(issued.Card), Code: issued.Code}, nil
+}
+func (e *CheckoutProviderEndpoints) ReconcileAdminGiftCards(ctx context.Context, _ apicontract.ReconcileAdminGiftCardsRequestObject) (apicontract.ReconcileAdminGiftCardsResponseObject, error) {
+	report, err := e.giftcard.Reconcile(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	drifts := make([]apicontract.GiftCardReconciliationDrift, 0, len(report.Drifts))
+	for _, drift := range report.Drifts {
+		value := apicontract.GiftCardReconciliationDrift{LedgerEntryId: optionalUint(drift.LedgerEntryID), PaymentTransactionId: optionalUint(drift.PaymentTransactionID), Field: drift.Field, Expected: drift.Expected, Actual: drift.Actual, Message: drift.Message}
+		if drift.GiftCardID != 0 {
+			cardID := int(drift.GiftCardID)
+			value.GiftCardId = &cardID
+		}
+		drifts = append(drifts, value)
+	}
+	return apicontract.ReconcileAdminGiftCards200JSONResponse{CheckedAt: report.CheckedAt, CardsChecked: report.CardsChecked, TransactionsChecked: report.TransactionsChecked, Drifts: drifts}, nil
+}
+func (e *CheckoutProviderEndpoints) GetAdminGiftCard(ctx context.Context, r apicontract.GetAdminGiftCardRequestObject) (apicontract.GetAdminGiftCardResponseObject, error) {
+	value, err := e.giftcard.Get(ctx, uint(r.Id))
+	if err != nil {
+		return nil, giftCardEndpointError(err)
+	}
+	return apicontract.GetAdminGiftCard200JSONResponse(giftCardContract(value)), nil
+}
+func (e *CheckoutProviderEndpoints) AdjustAdminGiftCard(ctx context.Context, r apicontract.AdjustAdminGiftCardRequestObject) (apicontract.AdjustAdminGiftCardResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A gift card adjustment body is required.", errors.New("gift card adjustment body is required"))
+	}
+	actor, _ := cmsActor(ctx)
+	value, err := e.giftcard.Adjust(ctx, uint(r.Id), giftcardservice.AdjustInput{
+		Amount:         models.MoneyFromFloat(r.Body.Amount),
+		Reason:         r.Body.Reason,
+		Actor:          actor,
+		IdempotencyKey: derefString(r.Params.IdempotencyKey),
+	}, time.Now().UTC())
+	if err != nil {
+		return nil, giftCardEndpointError(err)
+	}
+	return apicontract.AdjustAdminGiftCard200JSONResponse(giftCardContract(value)), nil
+}
+func (e *CheckoutProviderEndpoints) UpdateAdminGiftCardStatus(ctx context.Context, r apicontract.UpdateAdminGiftCardStatusRequestObject) (apicontract.UpdateAdminGiftCardStatusResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A gift card status body is required.", errors.New("gift card status body is required"))
+	}
+	value, err := e.giftcard.SetStatus(ctx, uint(r.Id), r.Body.Status)
+	if err != nil {
+		return nil, giftCardEndpointError(err)
+	}
+	return apicontract.UpdateAdminGiftCardStatus200JSONResponse(giftCardContract(value)), nil
+}
+
+func (e *CheckoutProviderEndpoints) CheckGiftCardBalance(ctx context.Context, r apicontract.CheckGiftCardBalanceRequestObject) (apicontract.CheckGiftCardBalanceResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A gift card code is required", nil)
+	}
+	card, err := e.giftcard.Lookup(ctx, r.Body.Code, time.Now().UTC())
+	if err != nil {
+		return nil, giftCardEndpointError(err)
+	}
+	return apicontract.CheckGiftCardBalance200JSONResponse{CodeLast4: card.CodeLast4, Balance: card.Balance.Float64(), Currency: card.Currency, ExpiresAt: card.ExpiresAt}, nil
+}
+
+func (e *CheckoutProviderEndpoints) ListMyGiftCards(ctx context.Context, _ apicontract.ListMyGiftCardsRequestObject) (apicontract.ListMyGiftCardsResponseObject, error) {
+	userID, err := principalAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values, err := e.giftcard.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	items := make(apicontract.ListMyGiftCards200JSONResponse, 0, len(values))
+	for _, value := range values {
+		items = append(items, giftCardContract(value))
+	}
+	return items, nil
+}
+
+func (e *CheckoutProviderEndpoints) ClaimUserOrderGiftCards(ctx context.Context, r apicontract.ClaimUserOrderGiftCardsRequestObject) (apicontract.ClaimUserOrderGiftCardsResponseObject, error) {
+	userID, err := principalAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issued, err := e.giftcard.ClaimPurchasedCards(ctx, uint(r.Id), userID, time.Now().UTC())
+	if err != nil {
+		return nil, giftCardEndpointError(err)
+	}
+	items := make([]apicontract.GiftCardIssueResponse, 0, len(issued))
+	for _, value := range issued {
+		items = append(items, apicontract.GiftCardIssueResponse{GiftCard: giftCardContract(value.Card), Code: value.Code})
+	}
+	return apicontract.ClaimUserOrderGiftCards200JSONResponse{Data: items}, nil
+}
+
+func giftCardEndpointError(err error) error {
+	switch {
+	case errors.Is(err, giftcardservice.ErrInvalidGiftCard):
+		return problemError(http.StatusBadRequest, "invalid_gift_card", err.Error(), err)
+	case errors.Is(err, giftcardservice.ErrGiftCardNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Gift card not found", err)
+	case errors.Is(err, giftcardservice.ErrOrderNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Order not found", err)
+	case errors.Is(err, giftcardservice.ErrGiftCardUnavailable), errors.Is(err, giftcardservice.ErrInsufficientBalance), errors.Is(err, giftcardservice.ErrCurrencyMismatch):
+		return problemError(http.StatusConflict, "gift_card_conflict", err.Error(), err)
+	}
+	return err
+}
+
+func giftCardTenders(values *[]apicontract.GiftCardTender) []giftcardservice.TenderInput {
+	if values == nil {
+		return nil
+	}
+	tenders := make([]giftcardservice.TenderInput, 0, len(*values))
+	for _, value := range *values {
+		tender := giftcardservice.TenderInput{Code: value.Code}
+		if value.Amount != nil {
+			amount := models.MoneyFromFloat(*value.Amount)
+			tender.Amount = &amount
+		}
+		tenders = append(tenders, tender)
+	}
+	return tenders
+}
+
+func giftCardContract(value models.GiftCard) apicontract.GiftCard {
+	out := apicontract.GiftCard{Id: int(value.ID), CodeLast4: value.CodeLast4, Currency: value.Currency, InitialAmount: value.InitialAmount.Float64(), Balance: value.Balance.Float64(), Status: value.Status, Source: value.Source, UserId: optionalUint(value.UserID), OrderId: optionalUint(value.OrderID), OrderItemId: optionalUint(value.OrderItemID), ExpiresAt: value.ExpiresAt, Note: value.Note, IssuedBy: value.IssuedBy, CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt}
+	if value.Ledger != nil {
+		ledger := make([]apicontract.GiftCardLedgerEntry, 0, len(value.Ledger))
+		for _, entry := range value.Ledger {
+			ledger = append(ledger, apicontract.GiftCardLedgerEntry{Id: int(entry.ID), Operation: entry.Operation, Amount: entry.Amount.Float64(), BalanceAfter: entry.BalanceAfter.Float64(), PaymentIntentId: optionalUint(entry.PaymentIntentID), OrderId: optionalUint(entry.OrderID), Actor: entry.Actor, Reason: entry.Reason, CreatedAt: entry.CreatedAt})
+		}
+		out.Ledger = &ledger
+	}
+	return out
+}
@@ -58,6 +58,7 @@ func RequestContextMiddleware(options RequestContextOptions) gin.HandlerFunc {
 			CorrelationID: correlationID,
 			Method:        ctx.Request.Method,
 			Path:          ctx.Request.URL.Path,
+			ClientIP:      ctx.ClientIP(),
 			StartedAt:     time.Now().UTC(),
 			Cookies:       cookies,
 			Headers:       headers,
@@ -0,0 +1,178 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+
+	"ecommerce/internal/apicontract"
+	orderservice "ecommerce/internal/services/orders"
+	"ecommerce/models"
+)
+
+func (e *CheckoutProviderEndpoints) ListAdminLegalEntities(ctx context.Context, _ apicontract.ListAdminLegalEntitiesRequestObject) (apicontract.ListAdminLegalEntitiesResponseObject, error) {
+	entities, err := e.orders.ListLegalEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]apicontract.LegalEntity, 0, len(entities))
+	for _, entity := range entities {
+		items = append(items, legalEntityContract(entity))
+	}
+	return apicontract.ListAdminLegalEntities200JSONResponse{Items: items}, nil
+}
+
+func (e *CheckoutProviderEndpoints) CreateAdminLegalEntity(ctx context.Context, r apicontract.CreateAdminLegalEntityRequestObject) (apicontract.CreateAdminLegalEntityResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_legal_entity", "legal entity body is required", nil)
+	}
+	entity, err := e.orders.CreateLegalEntity(ctx, legalEntityInput(*r.Body))
+	if err != nil {
+		return nil, orderDocumentEndpointError(err)
+	}
+	return apicontract.CreateAdminLegalEntity201JSONResponse(legalEntityContract(entity)), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateAdminLegalEntity(ctx context.Context, r apicontract.UpdateAdminLegalEntityRequestObject) (apicontract.UpdateAdminLegalEntityResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_legal_entity", "legal entity body is required", nil)
+	}
+	entity, err := e.orders.UpdateLegalEntity(ctx, uint(r.Id), legalEntityInput(*r.Body))
+	if err != nil {
+		return nil, orderDocumentEndpointError(err)
+	}
+	return apicontract.UpdateAdminLegalEntity200JSONResponse(legalEntityContract(entity)), nil
+}
+
+func (e *CheckoutProviderEndpoints) ListAdminOrderDocuments(ctx context.Context, r apicontract.ListAdminOrderDocumentsRequestObject) (apicontract.ListAdminOrderDocumentsResponseObject, error) {
+	items, err := e.orderDocuments(ctx, uint(r.Id), nil)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ListAdminOrderDocuments200JSONResponse{Items: items}, nil
+}
+
+func (e *CheckoutProviderEndpoints) IssueAdminOrderDocument(ctx context.Context, r apicontract.IssueAdminOrderDocumentRequestObject) (apicontract.IssueAdminOrderDocumentResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_order_document", "type is required", nil)
+	}
+	actor, _ := cmsActor(ctx)
+	input := orderservice.IssueOrderDocumentInput{Type: string(r.Body.Type), Actor: actor}
+	if r.Body.FulfillmentOrderId != nil {
+		id := uint(*r.Body.FulfillmentOrderId)
+		input.FulfillmentOrderID = &id
+	}
+	if r.Body.PaymentTransactionId != nil {
+		id := uint(*r.Body.PaymentTransactionId)
+		input.PaymentTransactionID = &id
+	}
+	document, err := e.orders.IssueDocument(ctx, uint(r.Id), input)
+	if err != nil {
+		return nil, orderDocumentEndpointError(err)
+	}
+	return apicontract.IssueAdminOrderDocument201JSONResponse(orderDocumentContract(document)), nil
+}
+
+func (e *CheckoutProviderEndpoints) DownloadAdminOrderDocument(ctx context.Context, r apicontract.DownloadAdminOrderDocumentRequestObject) (apicontract.DownloadAdminOrderDocumentResponseObject, error) {
+	document, err := e.orders.GetDocument(ctx, uint(r.Id), uint(r.DocumentId))
+	if err != nil {
+		return nil, orderDocumentEndpointError(err)
+	}
+	if r.Params.Format != nil && *r.Params.Format == apicontract.DownloadAdminOrderDocumentParamsFormatHtml {
+		return apicontract.DownloadAdminOrderDocument200TexthtmlResponse{Body: bytes.NewReader([]byte(document.HTML)), ContentLength: int64(len(document.HTML))}, nil
+	}
+	return apicontract.DownloadAdminOrderDocument200ApplicationpdfResponse{Body: bytes.NewReader(document.PDF), ContentLength: int64(len(document.PDF))}, nil
+}
+
+func (e *CheckoutProviderEndpoints) ListUserOrderDocuments(ctx context.Context, r apicontract.ListUserOrderDocumentsRequestObject) (apicontract.ListUserOrderDocumentsResponseObject, error) {
+	id, err := principalAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, err := e.orderDocuments(ctx, uint(r.Id), &id)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ListUserOrderDocuments200JSONResponse{Items: items}, nil
+}
+
+func (e *CheckoutProviderEndpoints) DownloadUserOrderDocument(ctx context.Context, r apicontract.DownloadUserOrderDocumentRequestObject) (apicontract.DownloadUserOrderDocumentResponseObject, error) {
+	id, err := principalAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := e.orders.Get(ctx, uint(r.Id), &id); err != nil {
+		return nil, checkoutEndpointError(err)
+	}
+	document, err := e.orders.GetDocument(ctx, uint(r.Id), uint(r.DocumentId))
+	if err != nil {
+		return nil, orderDocumentEndpointError(err)
+	}
+	if r.Params.Format != nil && *r.Params.Format == apicontract.DownloadUserOrderDocumentParamsFormatHtml {
+		return apicontract.DownloadUserOrderDocument200TexthtmlResponse{Body: bytes.NewReader([]byte(document.HTML)), ContentLength: int64(len(document.HTML))}, nil
+	}
+	return apicontract.DownloadUserOrderDocument200ApplicationpdfResponse{Body: bytes.NewReader(document.PDF), ContentLength: int64(len(document.PDF))}, nil
+}
+
+// orderDocuments lists an order's documents once the order is known to exist
+// and, when userID is set, to belong to that account.
+func (e *CheckoutProviderEndpoints) orderDocuments(ctx context.Context, orderID uint, userID *uint) ([]apicontract.OrderDocument, error) {
+	if _, err := e.orders.Get(ctx, orderID, userID); err != nil {
+		return nil, checkoutEndpointError(err)
+	}
+	documents, err := e.orders.ListDocuments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]apicontract.OrderDocument, 0, len(documents))
+	for _, document := range documents {
+		items = append(items, orderDocumentContract(document))
+	}
+	return items, nil
+}
+
+func legalEntityInput(body apicontract.LegalEntityRequest) orderservice.LegalEntityInput {
+	input := orderservice.LegalEntityInput{
+		Code: derefString(body.Code), Name: body.Name, Address: derefString(body.Address), TaxID: derefString(body.TaxId),
+		Country: derefString(body.Country), InvoicePrefix: derefString(body.InvoicePrefix), CreditNotePrefix: derefString(body.CreditNotePrefix),
+	}
+	if body.IsDefault != nil {
+		input.IsDefault = *body.IsDefault
+	}
+	return input
+}
+
+func orderDocumentEndpointError(err error) error {
+	switch {
+	case errors.Is(err, orderservice.ErrLegalEntityNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Legal entity not found", err)
+	case errors.Is(err, orderservice.ErrOrderDocumentNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Document not found", err)
+	case errors.Is(err, orderservice.ErrInvalidLegalEntity):
+		return problemError(http.StatusBadRequest, "invalid_legal_entity", err.Error(), err)
+	case errors.Is(err, orderservice.ErrInvalidOrderDocument):
+		return problemError(http.StatusBadRequest, "invalid_order_document", err.Error(), err)
+	case errors.Is(err, orderservice.ErrNoLegalEntity), errors.Is(err, orderservice.ErrOrderNotInvoiceable), errors.Is(err, orderservice.ErrOrderInvoiceMissing):
+		return problemError(http.StatusConflict, "order_document_conflict", err.Error(), err)
+	}
+	return checkoutEndpointError(err)
+}
+
+func legalEntityContract(v models.LegalEntity) apicontract.LegalEntity {
+	return apicontract.LegalEntity{
+		Id: int(v.ID), Code: v.Code, Name: v.Name, Address: v.Address, TaxId: v.TaxID, Country: v.Country, IsDefault: v.IsDefault,
+		InvoicePrefix: v.InvoicePrefix, CreditNotePrefix: v.CreditNotePrefix,
+		NextInvoiceNumber: v.NextInvoiceNumber, NextCreditNoteNumber: v.NextCreditNoteNumber,
+		CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt,
+	}
+}
+
+func orderDocumentContract(v models.OrderDocument) apicontract.OrderDocument {
+	return apicontract.OrderDocument{
+		Id: int(v.ID), OrderId: int(v.OrderID), Type: v.Type, Number: v.Number,
+		LegalEntityId: optionalUint(v.LegalEntityID), FulfillmentOrderId: optionalUint(v.FulfillmentOrderID),
+		PaymentTransactionId: optionalUint(v.PaymentTransactionID), GiftCardId: optionalUint(v.GiftCardID), InvoiceId: optionalUint(v.InvoiceID),
+		Currency: v.Currency, Total: v.Total.Float64(), Checksum: v.Checksum, IssuedBy: v.IssuedBy, IssuedAt: v.IssuedAt,
+	}
+}
@@ -0,0 +1,263 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	orderservice "ecommerce/internal/services/orders"
+	paymentservice "ecommerce/internal/services/payments"
+	"ecommerce/internal/services/providerops"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+func (e *CheckoutProviderEndpoints) ListAdminOrderEdits(ctx context.Context, r apicontract.ListAdminOrderEditsRequestObject) (apicontract.ListAdminOrderEditsResponseObject, error) {
+	edits, err := e.orders.ListEdits(ctx, uint(r.Id))
+	if err != nil {
+		return nil, orderEditEndpointError(err)
+	}
+	items := make([]apicontract.OrderEdit, 0, len(edits))
+	for _, edit := range edits {
+		items = append(items, orderEditContract(edit))
+	}
+	return apicontract.ListAdminOrderEdits200JSONResponse{Items: items}, nil
+}
+
+func (e *CheckoutProviderEndpoints) BeginAdminOrderEdit(ctx context.Context, r apicontract.BeginAdminOrderEditRequestObject) (apicontract.BeginAdminOrderEditResponseObject, error) {
+	actor, _ := cmsActor(ctx)
+	input := orderservice.OrderEditInput{Actor: actor}
+	if r.Body != nil {
+		input.Reason = strings.TrimSpace(derefString(r.Body.Reason))
+	}
+	edit, err := e.orders.BeginEdit(ctx, uint(r.Id), input, e.plugins)
+	if err != nil {
+		return nil, orderEditEndpointError(err)
+	}
+	return apicontract.BeginAdminOrderEdit200JSONResponse(orderEditContract(edit)), nil
+}
+
+func (e *CheckoutProviderEndpoints) GetAdminOrderEdit(ctx context.Context, r apicontract.GetAdminOrderEditRequestObject) (apicontract.GetAdminOrderEditResponseObject, error) {
+	edit, err := e.orders.GetEdit(ctx, uint(r.Id))
+	if err != nil {
+		return nil, orderEditEndpointError(err)
+	}
+	return apicontract.GetAdminOrderEdit200JSONResponse(orderEditContract(edit)), nil
+}
+
+func (e *CheckoutProviderEndpoints) StageAdminOrderEditChange(ctx context.Context, r apicontract.StageAdminOrderEditChangeRequestObject) (apicontract.StageAdminOrderEditChangeResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_order_edit_change", "kind is required", nil)
+	}
+	actor, _ := cmsActor(ctx)
+	input := orderservice.OrderEditChangeInput{Kind: strings.ToUpper(strings.TrimSpace(r.Body.Kind)), Actor: actor}
+	if r.Body.LineId != nil {
+		input.LineID = uint(*r.Body.LineId)
+	}
+	if r.Body.ProductVariantId != nil {
+		input.ProductVariantID = uint(*r.Body.ProductVariantId)
+	}
+	if r.Body.Quantity != nil {
+		input.Quantity = *r.Body.Quantity
+	}
+	if r.Body.ShippingData != nil {
+		input.ShippingData = *r.Body.ShippingData
+	}
+	edit, err := e.orders.StageEditChange(ctx, uint(r.Id), input, e.plugins)
+	if err != nil {
+		return nil, orderEditEndpointError(err)
+	}
+	return apicontract.StageAdminOrderEditChange200JSONResponse(orderEditContract(edit)), nil
+}
+
+func (e *CheckoutProviderEndpoints) CancelAdminOrderEdit(ctx context.Context, r apicontract.CancelAdminOrderEditRequestObject) (apicontract.CancelAdminOrderEditResponseObject, error) {
+	actor, _ := cmsActor(ctx)
+	edit, err := e.orders.CancelEdit(ctx, uint(r.Id), actor)
+	if err != nil {
+		return nil, orderEditEndpointError(err)
+	}
+	return apicontract.CancelAdminOrderEdit200JSONResponse(orderEditContract(edit)), nil
+}
+
+// CommitAdminOrderEdit settles the edit's balance with the order's payment
+// provider and applies the edit in the same transaction that finalizes the
+// payment, so the order never shows edited totals that were not paid for.
+func (e *CheckoutProviderEndpoints) CommitAdminOrderEdit(ctx context.Context, r apicontract.CommitAdminOrderEditRequestObject) (apicontract.CommitAdminOrderEditResponseObject, error) {
+	editID := uint(r.Id)
+	actor, _ := cmsActor(ctx)
+	commit := orderservice.OrderEditCommitInput{Actor: actor, CorrelationID: correlationID(ctx)}
+	idempotencyKey := fmt.Sprintf("order-edit-%d", editID)
+	if r.Params.IdempotencyKey != nil && strings.TrimSpace(*r.Params.IdempotencyKey) != "" {
+		idempotencyKey = strings.TrimSpace(*r.Params.IdempotencyKey)
+	}
+	operationKey := providerops.PaymentOperationKey(fmt.Sprintf("order_edit:%d", editID), idempotencyKey)
+
+	var edit models.OrderEdit
+	var intent models.PaymentIntent
+	var transaction models.PaymentTransaction
+	var providerRequest any
+	operationName := ""
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		edit, err = orderservice.PrepareOrderEditCommit(tx, editID, operationKey)
+		if err != nil {
+			return err
+		}
+		switch {
+		case edit.BalanceDue > 0:
+			var snapshot models.OrderCheckoutSnapshot
+			if err := tx.First(&snapshot, edit.SnapshotID).Error; err != nil {
+				return err
+			}
+			intent, transaction, err = paymentservice.PrepareSupplementalAuthorization(tx, edit.OrderID, snapshot, edit.BalanceDue, idempotencyKey)
+			if err != nil {
+				return err
+			}
+			request, err := paymentservice.PreparedAuthorizationRequest(intent, transaction, snapshot, correlationID(ctx))
+			providerRequest, operationName = request, "authorize"
+			return err
+		case edit.BalanceDue < 0:
+			// Editable orders have usually not been captured yet, so the
+			// difference comes off the authorization before anything is refunded.
+			amount := -edit.BalanceDue
+			intent, err = paymentservice.ReducibleIntentForAmount(tx, edit.OrderID, amount)
+			if err == nil {
+				var request paymentservice.VoidRequest
+				transaction, request, err = paymentservice.PrepareAuthorizationReduction(tx, &intent, amount, idempotencyKey, correlationID(ctx))
+				providerRequest, operationName = request, "void"
+				return err
+			}
+			if !errors.Is(err, paymentservice.ErrAmountExceedsAvailable) {
+				return err
+			}
+			intent, err = paymentservice.RefundableIntentForAmount(tx, edit.OrderID, amount)
+			if err != nil {
+				return err
+			}
+			var request paymentservice.RefundRequest
+			transaction, request, err = paymentservice.PrepareRefundPaymentIntent(tx, &intent, &amount, idempotencyKey, correlationID(ctx))
+			providerRequest, operationName = request, "refund"
+			return err
+		}
+		return orderservice.ApplyOrderEdit(tx, editID, commit, time.Now().UTC())
+	})
+	if err != nil {
+		return nil, orderEditEndpointError(err)
+	}
+	message := "Order edit committed"
+	if operationName != "" {
+		fingerprint, err := providerops.PaymentRequestFingerprint(providerRequest)
+		if err != nil {
+			return nil, err
+		}
+		input := providerops.PaymentMutationInput{
+			Prepare:             providerops.PrepareOperationInput{OperationKey: operationKey, ProviderType: models.ProviderTypePayment, ProviderID: intent.Provider, Environment: e.runtime.Environment, Operation: operationName, IdempotencyKey: idempotencyKey, RequestFingerprint: fingerprint, Request: providerRequest, CorrelationID: correlationID(ctx), EntityType: "payment_intent", EntityID: intent.ID},
+			Registry:            e.runtime.PaymentProviders,
+			Request:             providerRequest,
+			DomainTransactionID: transaction.ID,
+			Finalize: func(finalizeCtx context.Context, _ models.ProviderOperation, result paymentservice.ProviderOperationResult) error {
+				return e.db.WithContext(finalizeCtx).Transaction(func(tx *gorm.DB) error {
+					var err error
+					switch operationName {
+					case "authorize":
+						_, _, err = paymentservice.FinalizePreparedAuthorization(tx, intent.ID, result)
+					case "void":
+						_, _, err = paymentservice.FinalizeAuthorizationReduction(tx, intent.ID, transaction.ID, result)
+					default:
+						_, _, err = paymentservice.FinalizeRefundPaymentIntent(tx, intent.ID, transaction.ID, result)
+					}
+					if err != nil {
+						return err
+					}
+					return orderservice.ApplyOrderEdit(tx, editID, commit, time.Now().UTC())
+				})
+			},
+		}
+		var operation models.ProviderOperation
+		var executeErr error
+		switch operationName {
+		case "authorize":
+			operation, executeErr = e.runtime.Executor.ExecutePaymentAuthorize(ctx, input)
+			message = "Order edit committed; balance authorized"
+		case "void":
+			operation, executeErr = e.runtime.Executor.ExecutePaymentVoid(ctx, input)
+			message = "Order edit committed; authorization reduced"
+		default:
+			operation, executeErr = e.runtime.Executor.ExecutePaymentRefund(ctx, input)
+			message = "Order edit committed; balance refunded"
+		}
+		if providerops.PaymentOperationRecoverable(operation) {
+			return apicontract.CommitAdminOrderEdit202JSONResponse(providerAccepted(operation, "Order edit payment pending reconciliation")), nil
+		}
+		if executeErr != nil {
+			if operationName == "authorize" && operation.Status != models.ProviderOperationStatusFinalizeRetry && !errors.Is(executeErr, providerops.ErrPaymentEffectCompensated) {
+				_ = e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+					return paymentservice.MarkPreparedAuthorizationFailed(tx, intent.ID, executeErr.Error())
+				})
+			}
+			return nil, orderEditEndpointError(executeErr)
+		}
+	}
+	edit, err = e.orders.GetEdit(ctx, editID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := e.orders.Get(ctx, edit.OrderID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.CommitAdminOrderEdit200JSONResponse{Message: message, Edit: orderEditContract(edit), Order: orderContract(order, nil)}, nil
+}
+
+func orderEditEndpointError(err error) error {
+	switch {
+	case errors.Is(err, orderservice.ErrOrderEditNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Order edit not found", err)
+	case errors.Is(err, orderservice.ErrInvalidOrderEditChange), errors.Is(err, orderservice.ErrOrderEditEmpty):
+		return problemError(http.StatusBadRequest, "invalid_order_edit_change", err.Error(), err)
+	case errors.Is(err, orderservice.ErrOrderNotEditable):
+		return problemError(http.StatusConflict, "order_not_editable", err.Error(), err)
+	case errors.Is(err, orderservice.ErrOrderEditInProgress), errors.Is(err, orderservice.ErrOrderEditClosed):
+		return problemError(http.StatusConflict, "order_edit_state_conflict", err.Error(), err)
+	case errors.Is(err, orderservice.ErrOrderEditStale):
+		return problemError(http.StatusConflict, "order_edit_stale", err.Error(), err)
+	case errors.Is(err, orderservice.ErrOrderEditQuoteInvalid):
+		return problemError(http.StatusConflict, "order_edit_quote_invalid", err.Error(), err)
+	case errors.Is(err, paymentservice.ErrAmountExceedsAvailable), errors.Is(err, paymentservice.ErrRefundNotAllowed), errors.Is(err, paymentservice.ErrVoidNotAllowed), errors.Is(err, providerops.ErrIdempotencyFingerprintConflict):
+		return problemError(http.StatusConflict, "payment_lifecycle_conflict", err.Error(), err)
+	}
+	return checkoutEndpointError(err)
+}
+
+func orderEditContract(v models.OrderEdit) apicontract.OrderEdit {
+	lines := make([]apicontract.OrderEditLine, 0, len(v.Lines))
+	for _, line := range v.Lines {
+		var itemID *int
+		if line.OrderItemID != nil {
+			id := int(*line.OrderItemID)
+			itemID = &id
+		}
+		lines = append(lines, apicontract.OrderEditLine{
+			Id: int(line.ID), OrderItemId: itemID, ProductVariantId: int(line.ProductVariantID),
+			VariantSku: line.VariantSKU, VariantTitle: line.VariantTitle, Quantity: line.Quantity,
+			UnitPrice: line.UnitPrice.Float64(), DiscountAmount: line.DiscountAmount.Float64(),
+		})
+	}
+	changes := make([]apicontract.OrderEditChange, 0, len(v.Changes))
+	for _, change := range v.Changes {
+		changes = append(changes, apicontract.OrderEditChange{Id: int(change.ID), Kind: change.Kind, Summary: change.Summary, Actor: change.Actor, CreatedAt: change.CreatedAt})
+	}
+	return apicontract.OrderEdit{
+		Id: int(v.ID), OrderId: int(v.OrderID), Status: v.Status, Reason: v.Reason, Actor: v.Actor,
+		ShippingAddressPretty: v.ShippingAddressPretty, Currency: v.Currency,
+		Subtotal: v.Subtotal.Float64(), DiscountAmount: v.DiscountAmount.Float64(), ShippingAmount: v.ShippingAmount.Float64(),
+		TaxAmount: v.TaxAmount.Float64(), Total: v.Total.Float64(), OriginalTotal: v.OriginalTotal.Float64(), BalanceDue: v.BalanceDue.Float64(),
+		PaymentOperationKey: v.PaymentOperationKey, CommittedAt: v.CommittedAt, CancelledAt: v.CancelledAt,
+		Lines: lines, Changes: changes, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt,
+	}
+}
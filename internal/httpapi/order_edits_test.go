@@ -0,0 +1,85 @@
+package httpapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	"ecommerce/internal/checkoutplugins"
+	"ecommerce/internal/httpapi"
+	orderservice "ecommerce/internal/services/orders"
+	paymentservice "ecommerce/internal/services/payments"
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCommitAdminOrderEditReducesUncapturedAuthorization(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{}, &models.CheckoutSession{}, &models.Product{}, &models.ProductVariant{},
+		&models.InventoryItem{}, &models.InventoryLevel{}, &models.StockLocation{}, &models.InventoryMovement{},
+		&models.InventoryReservation{}, &models.InventoryThreshold{}, &models.InventoryAlert{},
+		&models.Order{}, &models.OrderItem{}, &models.OrderStatusHistory{}, &models.OrderCheckoutSnapshot{}, &models.OrderTag{},
+		&models.OrderEdit{}, &models.OrderEditLine{}, &models.OrderEditChange{}, &models.OrderTaxLine{},
+		&models.FulfillmentOrder{}, &models.FulfillmentOrderLine{}, &models.Shipment{},
+		&models.PaymentIntent{}, &models.PaymentTransaction{}, &models.DiscountCampaign{}, &models.DiscountRedemption{},
+		&models.ProviderOperation{}, &models.ProviderOperationAttempt{}, &models.ProviderReconciliationCase{},
+	))
+	plugins := checkoutplugins.NewDefaultManager()
+	now := time.Now().UTC()
+
+	product := models.Product{SKU: "EDIT", Name: "Edit", Price: models.MoneyFromFloat(10), Stock: 5, IsPublished: true}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "EDIT-default", Title: "Edit", Price: models.MoneyFromFloat(10), Stock: 5, Position: 1, IsPublished: true}
+	require.NoError(t, db.Create(&variant).Error)
+	session := models.CheckoutSession{PublicToken: "edit-session", Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(time.Hour), LastSeenAt: now}
+	require.NoError(t, db.Create(&session).Error)
+	shipping := map[string]string{"full_name": "Sam Buyer", "line1": "1 Main St", "city": "Fresno", "state": "CA", "postal_code": "93650", "country": "US", "service_level": "standard"}
+	payment := map[string]string{"cardholder_name": "Sam Buyer", "card_number": "4242424242424242", "exp_month": "12", "exp_year": "2030"}
+	quote := plugins.Quote(checkoutplugins.QuoteRequest{Subtotal: models.MoneyFromFloat(30), PaymentID: "dummy-card", ShippingID: "dummy-ground", PaymentData: payment, ShippingData: shipping})
+	require.True(t, quote.Valid)
+	order := models.Order{CheckoutSessionID: session.ID, Total: quote.Total, Status: models.StatusPaid}
+	require.NoError(t, db.Create(&order).Error)
+	require.NoError(t, db.Create(&models.OrderItem{OrderID: order.ID, ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title, Quantity: 3, Price: models.MoneyFromFloat(10)}).Error)
+	paymentJSON, _ := json.Marshal(payment)
+	shippingJSON, _ := json.Marshal(shipping)
+	snapshot := models.OrderCheckoutSnapshot{
+		CheckoutSessionID: session.ID, OrderID: &order.ID, Currency: "USD", PaymentProviderID: "dummy-card", ShippingProviderID: "dummy-ground",
+		PaymentDataJSON: string(paymentJSON), ShippingDataJSON: string(shippingJSON), Subtotal: models.MoneyFromFloat(30), Total: quote.Total, ExpiresAt: now.Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&snapshot).Error)
+	intent := models.PaymentIntent{OrderID: order.ID, SnapshotID: snapshot.ID, Provider: "dummy-card", Status: models.PaymentIntentStatusAuthorized, AuthorizedAmount: order.Total, Currency: "USD", Version: 1}
+	require.NoError(t, db.Create(&intent).Error)
+	require.NoError(t, db.Create(&models.PaymentTransaction{PaymentIntentID: intent.ID, Operation: models.PaymentTransactionOperationAuthorize, ProviderTxnID: "auth-1", IdempotencyKey: "auth-1", Amount: order.Total, Status: models.PaymentTransactionStatusSucceeded}).Error)
+
+	edit, err := orderservice.BeginOrderEdit(db, order.ID, orderservice.OrderEditInput{Actor: "ops"}, plugins, now)
+	require.NoError(t, err)
+	edit, err = orderservice.StageOrderEditChange(db, edit.ID, orderservice.OrderEditChangeInput{Kind: models.OrderEditChangeSetQuantity, LineID: edit.Lines[0].ID, Quantity: 1, Actor: "ops"}, plugins, now)
+	require.NoError(t, err)
+	require.Negative(t, edit.BalanceDue)
+
+	endpoints, err := httpapi.NewCheckoutProviderEndpoints(httpapi.CheckoutProviderEndpointsOptions{DB: db})
+	require.NoError(t, err)
+	response, err := endpoints.CommitAdminOrderEdit(context.Background(), apicontract.CommitAdminOrderEditRequestObject{Id: int(edit.ID)})
+	require.NoError(t, err)
+	committed, ok := response.(apicontract.CommitAdminOrderEdit200JSONResponse)
+	require.True(t, ok, "got %T", response)
+	assert.Equal(t, models.OrderEditStatusCommitted, committed.Edit.Status)
+	assert.Equal(t, edit.Total.Float64(), committed.Order.Total)
+
+	require.NoError(t, db.Preload("Transactions").First(&intent, intent.ID).Error)
+	assert.Equal(t, models.PaymentIntentStatusAuthorized, intent.Status)
+	require.Len(t, intent.Transactions, 2)
+	assert.Equal(t, models.PaymentTransactionOperationVoid, intent.Transactions[1].Operation)
+	assert.Equal(t, -edit.BalanceDue, intent.Transactions[1].Amount)
+	assert.Equal(t, models.PaymentTransactionStatusSucceeded, intent.Transactions[1].Status)
+	assert.Equal(t, edit.Total, paymentservice.OpenAuthorizationAmount(intent), "only the edited total is left to capture")
+}
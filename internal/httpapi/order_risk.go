@@ -0,0 +1,169 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	orderservice "ecommerce/internal/services/orders"
+	riskservice "ecommerce/internal/services/risk"
+	"ecommerce/models"
+)
+
+func (e *CheckoutProviderEndpoints) GetAdminRiskSettings(ctx context.Context, _ apicontract.GetAdminRiskSettingsRequestObject) (apicontract.GetAdminRiskSettingsResponseObject, error) {
+	settings, err := e.risk.Settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.GetAdminRiskSettings200JSONResponse(riskSettingsContract(settings)), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateAdminRiskSettings(ctx context.Context, r apicontract.UpdateAdminRiskSettingsRequestObject) (apicontract.UpdateAdminRiskSettingsResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A risk settings body is required.", errors.New("risk settings body is required"))
+	}
+	input := riskservice.SettingsInput{
+		Enabled: r.Body.Enabled, ReviewScore: r.Body.ReviewScore, RejectScore: r.Body.RejectScore,
+		HighValueThreshold:    models.MoneyFromFloat(r.Body.HighValueThreshold),
+		VelocityWindowMinutes: r.Body.VelocityWindowMinutes, VelocityLimit: r.Body.VelocityLimit,
+		ExternalScorerID: derefString(r.Body.ExternalScorerId),
+	}
+	if r.Body.DisposableDomains != nil {
+		input.DisposableDomains = strings.Join(*r.Body.DisposableDomains, "\n")
+	}
+	settings, err := e.risk.UpdateSettings(ctx, input)
+	if err != nil {
+		return nil, riskEndpointError(err)
+	}
+	return apicontract.UpdateAdminRiskSettings200JSONResponse(riskSettingsContract(settings)), nil
+}
+
+func (e *CheckoutProviderEndpoints) ListAdminRiskReviews(ctx context.Context, r apicontract.ListAdminRiskReviewsRequestObject) (apicontract.ListAdminRiskReviewsResponseObject, error) {
+	page, limit := cmsPagination(r.Params.Page, r.Params.Limit)
+	orders, total, err := e.risk.ListReviews(ctx, riskservice.ReviewListInput{Status: derefString(r.Params.Status), Limit: limit, Offset: (page - 1) * limit})
+	if err != nil {
+		return nil, riskEndpointError(err)
+	}
+	items := make([]apicontract.OrderRisk, 0, len(orders))
+	for _, order := range orders {
+		items = append(items, orderRiskContract(order, nil))
+	}
+	return apicontract.ListAdminRiskReviews200JSONResponse{Data: items, Pagination: apicontract.Pagination{Page: page, Limit: limit, Total: int(total), TotalPages: totalPages(total, limit)}}, nil
+}
+
+func (e *CheckoutProviderEndpoints) GetAdminOrderRisk(ctx context.Context, r apicontract.GetAdminOrderRiskRequestObject) (apicontract.GetAdminOrderRiskResponseObject, error) {
+	value, err := e.orderRisk(ctx, uint(r.Id))
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.GetAdminOrderRisk200JSONResponse(value), nil
+}
+
+func (e *CheckoutProviderEndpoints) ApproveAdminOrderRisk(ctx context.Context, r apicontract.ApproveAdminOrderRiskRequestObject) (apicontract.ApproveAdminOrderRiskResponseObject, error) {
+	order, err := e.risk.Approve(ctx, uint(r.Id), riskReviewInput(ctx, r.Body), time.Now().UTC())
+	if err != nil {
+		return nil, riskEndpointError(err)
+	}
+	value, err := e.orderRisk(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ApproveAdminOrderRisk200JSONResponse(value), nil
+}
+
+func (e *CheckoutProviderEndpoints) RejectAdminOrderRisk(ctx context.Context, r apicontract.RejectAdminOrderRiskRequestObject) (apicontract.RejectAdminOrderRiskResponseObject, error) {
+	order, err := e.risk.Reject(ctx, uint(r.Id), riskReviewInput(ctx, r.Body), time.Now().UTC())
+	if err != nil {
+		return nil, riskEndpointError(err)
+	}
+	if err := e.releaseUnpaidOrderTenders(ctx, order.ID); err != nil {
+		return nil, err
+	}
+	value, err := e.orderRisk(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.RejectAdminOrderRisk200JSONResponse(value), nil
+}
+
+func (e *CheckoutProviderEndpoints) orderRisk(ctx context.Context, orderID uint) (apicontract.OrderRisk, error) {
+	order, err := e.orders.Get(ctx, orderID, nil)
+	if err != nil {
+		return apicontract.OrderRisk{}, checkoutEndpointError(err)
+	}
+	assessments, err := e.risk.ListAssessments(ctx, orderID)
+	if err != nil {
+		return apicontract.OrderRisk{}, err
+	}
+	if assessments == nil {
+		assessments = []models.OrderRiskAssessment{}
+	}
+	return orderRiskContract(order, assessments), nil
+}
+
+func riskReviewInput(ctx context.Context, body *apicontract.RiskReviewDecisionInput) riskservice.ReviewInput {
+	actor, _ := cmsActor(ctx)
+	input := riskservice.ReviewInput{Actor: actor, CorrelationID: correlationID(ctx)}
+	if body != nil {
+		input.Note = derefString(body.Note)
+	}
+	return input
+}
+
+func riskEndpointError(err error) error {
+	switch {
+	case errors.Is(err, riskservice.ErrInvalidRiskSettings), errors.Is(err, riskservice.ErrInvalidReview):
+		return problemError(http.StatusBadRequest, "invalid_risk_request", err.Error(), err)
+	case errors.Is(err, riskservice.ErrReviewNotPending):
+		return problemError(http.StatusConflict, "risk_review_not_pending", "Order is not awaiting risk review", err)
+	case errors.Is(err, orderservice.ErrOrderNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Order not found", err)
+	}
+	return err
+}
+
+func riskSettingsContract(settings models.RiskSettings) apicontract.RiskSettings {
+	domains := strings.Fields(settings.DisposableDomains)
+	value := apicontract.RiskSettings{
+		Enabled: settings.Enabled, ReviewScore: settings.ReviewScore, RejectScore: settings.RejectScore,
+		HighValueThreshold: settings.HighValueThreshold.Float64(), VelocityWindowMinutes: settings.VelocityWindowMinutes,
+		VelocityLimit: settings.VelocityLimit, DisposableDomains: domains, ExternalScorerId: settings.ExternalScorerID,
+	}
+	if !settings.UpdatedAt.IsZero() {
+		value.UpdatedAt = &settings.UpdatedAt
+	}
+	return value
+}
+
+func orderRiskContract(order models.Order, assessments []models.OrderRiskAssessment) apicontract.OrderRisk {
+	value := apicontract.OrderRisk{
+		Order: orderContract(order, nil), Score: order.RiskScore, Action: order.RiskAction, ReviewStatus: order.RiskReviewStatus,
+		ReviewedAt: order.RiskReviewedAt, Reasons: riskSignalsContract(riskservice.Reasons(order.RiskReasonsJSON)),
+	}
+	if order.RiskReviewedBy != "" {
+		value.ReviewedBy = &order.RiskReviewedBy
+	}
+	if assessments != nil {
+		history := make([]apicontract.RiskAssessment, 0, len(assessments))
+		for _, assessment := range assessments {
+			history = append(history, apicontract.RiskAssessment{
+				Id: int(assessment.ID), Score: assessment.Score, Action: assessment.Action, ScorerId: assessment.ScorerID, CreatedAt: assessment.CreatedAt,
+				Reasons: riskSignalsContract(riskservice.Reasons(assessment.ReasonsJSON)),
+				Email:   optionalString(assessment.Email), ClientIp: optionalString(assessment.ClientIP), CardBin: optionalString(assessment.CardBIN),
+			})
+		}
+		value.Assessments = &history
+	}
+	return value
+}
+
+func riskSignalsContract(signals []riskservice.Signal) []apicontract.RiskSignal {
+	values := make([]apicontract.RiskSignal, 0, len(signals))
+	for _, signal := range signals {
+		values = append(values, apicontract.RiskSignal{Code: signal.Code, Score: signal.Score, Message: signal.Message})
+	}
+	return values
+}
@@ -0,0 +1,214 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	orderservice "ecommerce/internal/services/orders"
+	"ecommerce/models"
+)
+
+func (e *CheckoutProviderEndpoints) SetAdminOrderTags(ctx context.Context, r apicontract.SetAdminOrderTagsRequestObject) (apicontract.SetAdminOrderTagsResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_order_tag", "tags are required", nil)
+	}
+	actor, _ := cmsActor(ctx)
+	tags, err := e.orders.SetTags(ctx, uint(r.Id), r.Body.Tags, actor)
+	if err != nil {
+		return nil, orderSearchEndpointError(err)
+	}
+	return apicontract.SetAdminOrderTags200JSONResponse{Tags: tags}, nil
+}
+
+func (e *CheckoutProviderEndpoints) ListAdminOrderNotes(ctx context.Context, r apicontract.ListAdminOrderNotesRequestObject) (apicontract.ListAdminOrderNotesResponseObject, error) {
+	notes, err := e.orders.ListNotes(ctx, uint(r.Id))
+	if err != nil {
+		return nil, orderSearchEndpointError(err)
+	}
+	items := make([]apicontract.OrderNote, 0, len(notes))
+	for _, note := range notes {
+		items = append(items, orderNoteContract(note))
+	}
+	return apicontract.ListAdminOrderNotes200JSONResponse{Items: items}, nil
+}
+
+func (e *CheckoutProviderEndpoints) CreateAdminOrderNote(ctx context.Context, r apicontract.CreateAdminOrderNoteRequestObject) (apicontract.CreateAdminOrderNoteResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_order_note", "note body is required", nil)
+	}
+	author, _ := cmsActor(ctx)
+	note, err := e.orders.AddNote(ctx, uint(r.Id), r.Body.Body, author)
+	if err != nil {
+		return nil, orderSearchEndpointError(err)
+	}
+	return apicontract.CreateAdminOrderNote201JSONResponse(orderNoteContract(note)), nil
+}
+
+func (e *CheckoutProviderEndpoints) DeleteAdminOrderNote(ctx context.Context, r apicontract.DeleteAdminOrderNoteRequestObject) (apicontract.DeleteAdminOrderNoteResponseObject, error) {
+	if err := e.orders.DeleteNote(ctx, uint(r.Id), uint(r.NoteId)); err != nil {
+		return nil, orderSearchEndpointError(err)
+	}
+	return apicontract.DeleteAdminOrderNote204Response{}, nil
+}
+
+func (e *CheckoutProviderEndpoints) ListAdminOrderViews(ctx context.Context, _ apicontract.ListAdminOrderViewsRequestObject) (apicontract.ListAdminOrderViewsResponseObject, error) {
+	views, err := e.orders.ListViews(ctx, orderViewOwner(ctx))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]apicontract.OrderView, 0, len(views))
+	for _, view := range views {
+		items = append(items, orderViewContract(view))
+	}
+	return apicontract.ListAdminOrderViews200JSONResponse{Items: items}, nil
+}
+
+func (e *CheckoutProviderEndpoints) CreateAdminOrderView(ctx context.Context, r apicontract.CreateAdminOrderViewRequestObject) (apicontract.CreateAdminOrderViewResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_order_view", "view body is required", nil)
+	}
+	filters, err := orderViewFilters(r.Body.Filters)
+	if err != nil {
+		return nil, err
+	}
+	view, err := e.orders.CreateView(ctx, orderViewOwner(ctx), r.Body.Name, filters)
+	if err != nil {
+		return nil, orderSearchEndpointError(err)
+	}
+	return apicontract.CreateAdminOrderView201JSONResponse(orderViewContract(view)), nil
+}
+
+func (e *CheckoutProviderEndpoints) UpdateAdminOrderView(ctx context.Context, r apicontract.UpdateAdminOrderViewRequestObject) (apicontract.UpdateAdminOrderViewResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_order_view", "view body is required", nil)
+	}
+	filters, err := orderViewFilters(r.Body.Filters)
+	if err != nil {
+		return nil, err
+	}
+	view, err := e.orders.UpdateView(ctx, orderViewOwner(ctx), uint(r.Id), r.Body.Name, filters)
+	if err != nil {
+		return nil, orderSearchEndpointError(err)
+	}
+	return apicontract.UpdateAdminOrderView200JSONResponse(orderViewContract(view)), nil
+}
+
+func (e *CheckoutProviderEndpoints) DeleteAdminOrderView(ctx context.Context, r apicontract.DeleteAdminOrderViewRequestObject) (apicontract.DeleteAdminOrderViewResponseObject, error) {
+	if err := e.orders.DeleteView(ctx, orderViewOwner(ctx), uint(r.Id)); err != nil {
+		return nil, orderSearchEndpointError(err)
+	}
+	return apicontract.DeleteAdminOrderView204Response{}, nil
+}
+
+// adminOrderListInput turns the admin order list query into a search. Saved
+// views are looked up for the calling admin.
+func adminOrderListInput(ctx context.Context, p apicontract.ListAdminOrdersParams) (orderservice.ListInput, error) {
+	start, end, err := orderDateRange(p.StartDate, p.EndDate)
+	if err != nil {
+		return orderservice.ListInput{}, err
+	}
+	input := orderservice.ListInput{
+		Query: stringPtr(p.Q), Email: stringPtr(p.Email), Name: stringPtr(p.Name), SKU: stringPtr(p.Sku),
+		ConfirmationToken: stringPtr(p.ConfirmationToken), TrackingNumber: stringPtr(p.TrackingNumber),
+		PaymentReference: stringPtr(p.PaymentReference), Tag: stringPtr(p.Tag), Status: enumString(p.Status),
+		StartDate: start, EndDate: end, MinTotal: moneyPtr(p.MinTotal), MaxTotal: moneyPtr(p.MaxTotal),
+		Page: intPtr(p.Page), Limit: intPtr(p.Limit), ViewOwnerID: orderViewOwner(ctx),
+	}
+	if p.View != nil {
+		id := uint(*p.View)
+		input.ViewID = &id
+	}
+	return input, nil
+}
+
+func orderDateRange(rawStart, rawEnd *string) (start, end *time.Time, err error) {
+	if start, err = datePtr(rawStart, false); err != nil {
+		return nil, nil, problemError(http.StatusBadRequest, "invalid_start_date", "Invalid start_date, expected YYYY-MM-DD", err)
+	}
+	if end, err = datePtr(rawEnd, true); err != nil {
+		return nil, nil, problemError(http.StatusBadRequest, "invalid_end_date", "Invalid end_date, expected YYYY-MM-DD", err)
+	}
+	return start, end, nil
+}
+
+// orderViewOwner is the account saved views belong to. The local CLI runs
+// without a principal and keeps its views under account 0.
+func orderViewOwner(ctx context.Context) uint {
+	if _, actorID := cmsActor(ctx); actorID != nil {
+		return *actorID
+	}
+	return 0
+}
+
+func orderViewFilters(body apicontract.OrderViewFilters) (orderservice.ViewFilters, error) {
+	start, end, err := orderDateRange(body.StartDate, body.EndDate)
+	if err != nil {
+		return orderservice.ViewFilters{}, err
+	}
+	return orderservice.ViewFilters{
+		Query: derefString(body.Q), Email: derefString(body.Email), Name: derefString(body.Name), SKU: derefString(body.Sku),
+		ConfirmationToken: derefString(body.ConfirmationToken), TrackingNumber: derefString(body.TrackingNumber),
+		PaymentReference: derefString(body.PaymentReference), Tag: derefString(body.Tag), Status: derefString(body.Status),
+		StartDate: start, EndDate: end, MinTotal: moneyPtr(body.MinTotal), MaxTotal: moneyPtr(body.MaxTotal),
+	}, nil
+}
+
+func moneyPtr(v *float64) *models.Money {
+	if v == nil {
+		return nil
+	}
+	money := models.MoneyFromFloat(*v)
+	return &money
+}
+
+func orderSearchEndpointError(err error) error {
+	switch {
+	case errors.Is(err, orderservice.ErrOrderNoteNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Note not found", err)
+	case errors.Is(err, orderservice.ErrOrderViewNotFound):
+		return problemError(http.StatusNotFound, "not_found", "Saved view not found", err)
+	case errors.Is(err, orderservice.ErrInvalidOrderSearch):
+		return problemError(http.StatusBadRequest, "invalid_order_search", err.Error(), err)
+	case errors.Is(err, orderservice.ErrInvalidOrderTag):
+		return problemError(http.StatusBadRequest, "invalid_order_tag", err.Error(), err)
+	case errors.Is(err, orderservice.ErrInvalidOrderNote):
+		return problemError(http.StatusBadRequest, "invalid_order_note", err.Error(), err)
+	case errors.Is(err, orderservice.ErrInvalidOrderView):
+		return problemError(http.StatusBadRequest, "invalid_order_view", err.Error(), err)
+	}
+	return checkoutEndpointError(err)
+}
+
+func orderTagsContract(tags []string) *[]string {
+	if tags == nil {
+		tags = []string{}
+	}
+	return &tags
+}
+
+func orderNoteContract(v models.OrderNote) apicontract.OrderNote {
+	return apicontract.OrderNote{Id: int(v.ID), OrderId: int(v.OrderID), Body: v.Body, Author: v.Author, CreatedAt: v.CreatedAt}
+}
+
+func orderViewContract(v models.OrderSavedView) apicontract.OrderView {
+	f := orderservice.DecodeViewFilters(v)
+	date := func(t *time.Time) *string {
+		if t == nil {
+			return nil
+		}
+		formatted := t.Format("2006-01-02")
+		return &formatted
+	}
+	return apicontract.OrderView{
+		Id: int(v.ID), Name: v.Name, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt,
+		Filters: apicontract.OrderViewFilters{
+			Q: optionalString(f.Query), Email: optionalString(f.Email), Name: optionalString(f.Name), Sku: optionalString(f.SKU),
+			ConfirmationToken: optionalString(f.ConfirmationToken), TrackingNumber: optionalString(f.TrackingNumber),
+			PaymentReference: optionalString(f.PaymentReference), Tag: optionalString(f.Tag), Status: optionalString(f.Status),
+			StartDate: date(f.StartDate), EndDate: date(f.EndDate), MinTotal: optionalMoney(f.MinTotal), MaxTotal: optionalMoney(f.MaxTotal),
+		},
+	}
+}
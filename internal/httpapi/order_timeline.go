@@ -0,0 +1,31 @@
+package httpapi
+
+import (
+	"context"
+
+	"ecommerce/internal/apicontract"
+	orderservice "ecommerce/internal/services/orders"
+)
+
+func (e *CheckoutProviderEndpoints) GetAdminOrderTimeline(ctx context.Context, r apicontract.GetAdminOrderTimelineRequestObject) (apicontract.GetAdminOrderTimelineResponseObject, error) {
+	events, err := e.orders.Timeline(ctx, uint(r.Id))
+	if err != nil {
+		return nil, checkoutEndpointError(err)
+	}
+	items := make([]apicontract.OrderTimelineEvent, 0, len(events))
+	for _, event := range events {
+		items = append(items, orderTimelineEventContract(event))
+	}
+	return apicontract.GetAdminOrderTimeline200JSONResponse{OrderId: r.Id, Events: items}, nil
+}
+
+func orderTimelineEventContract(v orderservice.TimelineEvent) apicontract.OrderTimelineEvent {
+	details := v.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	return apicontract.OrderTimelineEvent{
+		Type: v.Type, OccurredAt: v.OccurredAt, Source: v.Source, SourceId: int(v.SourceID),
+		Actor: v.Actor, CorrelationId: v.CorrelationID, Summary: v.Summary, Details: details,
+	}
+}
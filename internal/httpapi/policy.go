@@ -32,14 +32,16 @@ const (
 )
 
 // OperationPolicy is explicit security and request-boundary metadata for one
-// generated operation. OperationSecurityMiddleware enforces CSRF and body limits
-// before generated binding; completeness validation makes omission detectable.
+// generated operation. OperationSecurityMiddleware enforces CSRF, body limits,
+// and rate limits before generated binding; completeness validation makes
+// omission detectable.
 type OperationPolicy struct {
 	OperationID  string
 	Access       Access
 	Roles        []string
 	CSRF         CSRFPolicy
 	MaxBodyBytes int64
+	RateLimits   []RateLimitPolicy
 }
 
 func (policy OperationPolicy) validate() error {
@@ -63,6 +65,16 @@ func (policy OperationPolicy) validate() error {
 			return fmt.Errorf("operation %q contains an empty role", policy.OperationID)
 		}
 	}
+	buckets := make(map[string]struct{}, len(policy.RateLimits))
+	for _, limit := range policy.RateLimits {
+		if err := limit.validate(policy.OperationID); err != nil {
+			return err
+		}
+		if _, duplicate := buckets[limit.Bucket]; duplicate {
+			return fmt.Errorf("operation %q declares rate limit bucket %q twice", policy.OperationID, limit.Bucket)
+		}
+		buckets[limit.Bucket] = struct{}{}
+	}
 	return nil
 }
 
@@ -80,6 +92,7 @@ func NewPolicySet(policies ...OperationPolicy) (PolicySet, error) {
 			return PolicySet{}, fmt.Errorf("duplicate policy for operation %q", policy.OperationID)
 		}
 		policy.Roles = slices.Clone(policy.Roles)
+		policy.RateLimits = slices.Clone(policy.RateLimits)
 		set.byOperation[policy.OperationID] = policy
 	}
 	return set, nil
@@ -88,6 +101,7 @@ func NewPolicySet(policies ...OperationPolicy) (PolicySet, error) {
 func (set PolicySet) Lookup(operationID string) (OperationPolicy, bool) {
 	policy, ok := set.byOperation[operationID]
 	policy.Roles = slices.Clone(policy.Roles)
+	policy.RateLimits = slices.Clone(policy.RateLimits)
 	return policy, ok
 }
 
@@ -156,11 +170,13 @@ func (set PolicySet) ValidateContract() error {
 const operationPolicyExtension = "x-operation-policy"
 
 type contractPolicyMetadata struct {
-	DefaultMaxBodyBytes int64            `json:"default-max-body-bytes"`
-	AdminPathPrefix     string           `json:"admin-path-prefix"`
-	AdminRoles          []string         `json:"admin-roles"`
-	CSRFMode            string           `json:"csrf-mode"`
-	BodyLimits          map[string]int64 `json:"body-limits"`
+	DefaultMaxBodyBytes int64                        `json:"default-max-body-bytes"`
+	AdminPathPrefix     string                       `json:"admin-path-prefix"`
+	AdminRoles          []string                     `json:"admin-roles"`
+	CSRFMode            string                       `json:"csrf-mode"`
+	BodyLimits          map[string]int64             `json:"body-limits"`
+	DefaultRateLimit    *contractRateLimit           `json:"default-rate-limit"`
+	RateLimits          map[string]contractRateLimit `json:"rate-limits"`
 }
 
 func parseContractPolicyMetadata(value any) (contractPolicyMetadata, error) {
@@ -186,12 +202,23 @@ func parseContractPolicyMetadata(value any) (contractPolicyMetadata, error) {
 			return contractPolicyMetadata{}, fmt.Errorf("%s contains invalid body limit %q=%d", operationPolicyExtension, route, limit)
 		}
 	}
+	if metadata.DefaultRateLimit != nil {
+		if err := metadata.DefaultRateLimit.policy(globalRateLimitBucket).validate("default"); err != nil {
+			return contractPolicyMetadata{}, fmt.Errorf("%s default-rate-limit: %w", operationPolicyExtension, err)
+		}
+	}
+	for route, limit := range metadata.RateLimits {
+		if err := limit.policy(route).validate(route); err != nil {
+			return contractPolicyMetadata{}, fmt.Errorf("%s rate-limits: %w", operationPolicyExtension, err)
+		}
+	}
 	return metadata, nil
 }
 
 // ContractPolicySet derives every operation policy from the generated OpenAPI
 // document. Standard security declarations control access, while the required
-// x-operation-policy extension declares role, CSRF, and body-limit rules.
+// x-operation-policy extension declares role, CSRF, body-limit, and rate-limit
+// rules.
 func ContractPolicySet() (PolicySet, error) {
 	spec, err := apicontract.GetSwagger()
 	if err != nil {
@@ -209,6 +236,10 @@ func ContractPolicySet() (PolicySet, error) {
 	for route := range metadata.BodyLimits {
 		unusedBodyLimits[route] = struct{}{}
 	}
+	unusedRateLimits := make(map[string]struct{}, len(metadata.RateLimits))
+	for route := range metadata.RateLimits {
+		unusedRateLimits[route] = struct{}{}
+	}
 	policies := make([]OperationPolicy, 0, len(spec.Paths.Map()))
 	for path, item := range spec.Paths.Map() {
 		for method, operation := range item.Operations() {
@@ -236,6 +267,13 @@ func ContractPolicySet() (PolicySet, error) {
 				policy.MaxBodyBytes = limit
 				delete(unusedBodyLimits, route)
 			}
+			if metadata.DefaultRateLimit != nil {
+				policy.RateLimits = append(policy.RateLimits, metadata.DefaultRateLimit.policy(globalRateLimitBucket))
+			}
+			if limit, declared := metadata.RateLimits[route]; declared {
+				policy.RateLimits = append(policy.RateLimits, limit.policy(operation.OperationID))
+				delete(unusedRateLimits, route)
+			}
 			policies = append(policies, policy)
 		}
 	}
@@ -247,6 +285,14 @@ func ContractPolicySet() (PolicySet, error) {
 		sort.Strings(unknown)
 		return PolicySet{}, fmt.Errorf("%s contains unknown body-limit routes: %v", operationPolicyExtension, unknown)
 	}
+	if len(unusedRateLimits) != 0 {
+		unknown := make([]string, 0, len(unusedRateLimits))
+		for route := range unusedRateLimits {
+			unknown = append(unknown, route)
+		}
+		sort.Strings(unknown)
+		return PolicySet{}, fmt.Errorf("%s contains unknown rate-limit routes: %v", operationPolicyExtension, unknown)
+	}
 	set, err := NewPolicySet(policies...)
 	if err != nil {
 		return PolicySet{}, err
@@ -2,6 +2,7 @@ package httpapi_test
 
 import (
 	"testing"
+	"time"
 
 	"ecommerce/internal/httpapi"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +33,12 @@ func TestPolicySetRejectsImplicitSecurityDecisions(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "unspecified access")
 
+	limited := policy("limitedThing")
+	limited.RateLimits = []httpapi.RateLimitPolicy{{Bucket: "limitedThing", Limit: 1, Window: time.Minute, Scope: "tenant"}}
+	_, err = httpapi.NewPolicySet(limited)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported scope")
+
 	public := policy("publicThing")
 	public.Access = httpapi.AccessPublic
 	public.Roles = []string{"admin"}
@@ -60,6 +67,15 @@ func TestContractPolicySetIsCompleteAndConservative(t *testing.T) {
 	upload, ok := set.Lookup("PatchMediaUpload")
 	require.True(t, ok)
 	assert.EqualValues(t, 500<<20, upload.MaxBodyBytes)
+	require.Len(t, upload.RateLimits, 1)
+	assert.Equal(t, "global", upload.RateLimits[0].Bucket)
+
+	checkout, ok := set.Lookup("CreateCheckoutOrder")
+	require.True(t, ok)
+	require.Len(t, checkout.RateLimits, 2)
+	assert.Equal(t, httpapi.RateLimitPolicy{
+		Bucket: "CreateCheckoutOrder", Limit: 6, Window: time.Minute, Scope: httpapi.RateLimitScopeCheckoutSession, Code: "checkout_rate_limited",
+	}, checkout.RateLimits[1])
 }
 
 func TestContractOperationIDsAreGeneratedAndUnique(t *testing.T) {
@@ -21,6 +21,7 @@ const (
 	TypeNotFound               = "https://ecommerce.local/problems/not-found"
 	TypeConflict               = "https://ecommerce.local/problems/conflict"
 	TypeValidation             = "https://ecommerce.local/problems/validation"
+	TypeRateLimited            = "https://ecommerce.local/problems/rate-limited"
 	TypeInternal               = "https://ecommerce.local/problems/internal"
 )
 
@@ -162,6 +163,8 @@ func defaultProblem(status int) Problem {
 		problem.Type, problem.Code = TypeConflict, "state_conflict"
 	case http.StatusUnprocessableEntity:
 		problem.Type, problem.Code = TypeValidation, "validation_failed"
+	case http.StatusTooManyRequests:
+		problem.Type, problem.Code = TypeRateLimited, "rate_limited"
 	default:
 		problem.Type, problem.Code = TypeInternal, "internal_error"
 	}
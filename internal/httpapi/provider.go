@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"ecommerce/internal/apicontract"
 	"ecommerce/internal/checkoutplugins"
 	"ecommerce/internal/requestctx"
+	checkoutservice "ecommerce/internal/services/checkout"
+	giftcardservice "ecommerce/internal/services/giftcards"
 	orderservice "ecommerce/internal/services/orders"
 	paymentservice "ecommerce/internal/services/payments"
 	providerops "ecommerce/internal/services/providerops"
@@ -53,26 +56,29 @@ func pluginDefinitions(values []checkoutplugins.Definition) []apicontract.Checko
 	for _, v := range values {
 		fields := make([]apicontract.CheckoutPluginField, 0, len(v.Fields))
 		for _, f := range v.Fields {
-			field := apicontract.CheckoutPluginField{Key: f.Key, Label: f.Label, Type: apicontract.CheckoutPluginFieldType(f.Type), Required: f.Required}
-			if f.Placeholder != "" {
-				field.Placeholder = &f.Placeholder
-			}
-			if f.HelpText != "" {
-				field.HelpText = &f.HelpText
-			}
-			if len(f.Options) > 0 {
-				opts := make([]apicontract.CheckoutPluginFieldOption, 0, len(f.Options))
-				for _, o := range f.Options {
-					opts = append(opts, apicontract.CheckoutPluginFieldOption{Value: o.Value, Label: o.Label})
-				}
-				field.Options = &opts
-			}
-			fields = append(fields, field)
+			fields = append(fields, pluginFieldContract(f))
 		}
 		out = append(out, apicontract.CheckoutPlugin{Id: v.ID, Type: apicontract.CheckoutPluginType(v.Type), Name: v.Name, Description: v.Description, Status: v.Status, Enabled: v.Enabled, Fields: fields, States: pluginStates(v.States)})
 	}
 	return out
 }
+func pluginFieldContract(f checkoutplugins.FieldDefinition) apicontract.CheckoutPluginField {
+	field := apicontract.CheckoutPluginField{Key: f.Key, Label: f.Label, Type: apicontract.CheckoutPluginFieldType(f.Type), Required: f.Required}
+	if f.Placeholder != "" {
+		field.Placeholder = &f.Placeholder
+	}
+	if f.HelpText != "" {
+		field.HelpText = &f.HelpText
+	}
+	if len(f.Options) > 0 {
+		opts := make([]apicontract.CheckoutPluginFieldOption, 0, len(f.Options))
+		for _, o := range f.Options {
+			opts = append(opts, apicontract.CheckoutPluginFieldOption{Value: o.Value, Label: o.Label})
+		}
+		field.Options = &opts
+	}
+	return field
+}
 func pluginStates(values []checkoutplugins.State) []apicontract.CheckoutPluginState {
 	out := make([]apicontract.CheckoutPluginState, 0, len(values))
 	for _, v := range values {
@@ -191,13 +197,21 @@ func shipmentContract(v models.Shipment) apicontract.Shipment {
 	rates := shipmentRatesContract(v.Rates)
 	packages := make([]apicontract.ShipmentPackage, 0, len(v.Packages))
 	for _, p := range v.Packages {
-		packages = append(packages, apicontract.ShipmentPackage{Id: int(p.ID), Reference: p.Reference, WeightGrams: p.WeightGrams, LengthCm: p.LengthCM, WidthCm: p.WidthCM, HeightCm: p.HeightCM})
+		pkg := apicontract.ShipmentPackage{Id: int(p.ID), Reference: p.Reference, WeightGrams: p.WeightGrams, LengthCm: p.LengthCM, WidthCm: p.WidthCM, HeightCm: p.HeightCM}
+		if len(p.Lines) > 0 {
+			lines := make([]apicontract.ShipmentPackageLine, 0, len(p.Lines))
+			for _, line := range p.Lines {
+				lines = append(lines, apicontract.ShipmentPackageLine{FulfillmentOrderLineId: int(line.FulfillmentOrderLineID), Quantity: line.Quantity})
+			}
+			pkg.Lines = &lines
+		}
+		packages = append(packages, pkg)
 	}
 	events := make([]apicontract.TrackingEvent, 0, len(v.TrackingEvents))
 	for _, x := range v.TrackingEvents {
 		events = append(events, apicontract.TrackingEvent{Id: int(x.ID), Provider: x.Provider, ProviderEventId: x.ProviderEventID, Status: apicontract.TrackingEventStatus(x.Status), TrackingNumber: x.TrackingNumber, Location: x.Location, Description: x.Description, OccurredAt: x.OccurredAt})
 	}
-	return apicontract.Shipment{Id: int(v.ID), OrderId: int(v.OrderID), SnapshotId: int(v.SnapshotID), Provider: v.Provider, ShipmentRateId: int(v.ShipmentRateID), ProviderShipmentId: v.ProviderShipmentID, Status: apicontract.ShipmentStatus(v.Status), Currency: v.Currency, ServiceCode: v.ServiceCode, ServiceName: v.ServiceName, Amount: v.Amount.Float64(), ShippingAddressPretty: v.ShippingAddressPretty, TrackingNumber: v.TrackingNumber, TrackingUrl: v.TrackingURL, LabelUrl: v.LabelURL, PurchasedAt: v.PurchasedAt, FinalizedAt: v.FinalizedAt, DeliveredAt: v.DeliveredAt, Rates: rates, Packages: packages, TrackingEvents: events}
+	return apicontract.Shipment{Id: int(v.ID), OrderId: int(v.OrderID), SnapshotId: int(v.SnapshotID), Provider: v.Provider, ShipmentRateId: int(v.ShipmentRateID), FulfillmentOrderId: optionalUint(v.FulfillmentOrderID), ProviderShipmentId: v.ProviderShipmentID, Status: apicontract.ShipmentStatus(v.Status), Currency: v.Currency, ServiceCode: v.ServiceCode, ServiceName: v.ServiceName, Amount: v.Amount.Float64(), ShippingAddressPretty: v.ShippingAddressPretty, TrackingNumber: v.TrackingNumber, TrackingUrl: v.TrackingURL, LabelUrl: v.LabelURL, PurchasedAt: v.PurchasedAt, FinalizedAt: v.FinalizedAt, DeliveredAt: v.DeliveredAt, Rates: rates, Packages: packages, TrackingEvents: events}
 }
 
 func (e *CheckoutProviderEndpoints) CreateAdminOrderShippingLabel(ctx context.Context, r apicontract.CreateAdminOrderShippingLabelRequestObject) (apicontract.CreateAdminOrderShippingLabelResponseObject, error) {
@@ -223,11 +237,28 @@ func (e *CheckoutProviderEndpoints) CreateAdminOrderShippingLabel(ctx context.Co
 			pkg.HeightCM = *p.HeightCm
 		}
 	}
-	prepared, err := e.shipping.PrepareLabelPurchase(ctx, uint(r.Id), uint(r.Body.RateId), pkg, r.Params.IdempotencyKey)
+	var fulfillmentOrderID *uint
+	if r.Body.FulfillmentOrderId != nil {
+		id := uint(*r.Body.FulfillmentOrderId)
+		fulfillmentOrderID = &id
+	}
+	prepared, err := e.shipping.PrepareLabelPurchase(ctx, uint(r.Id), uint(r.Body.RateId), pkg, fulfillmentOrderID, r.Params.IdempotencyKey)
 	if errors.Is(err, shippingservice.ErrShipmentServiceImmutable) {
 		problem := providerAdminProblem(ctx, e.renderer, http.StatusConflict, "shipment_service_immutable", err.Error(), err)
 		return apicontract.CreateAdminOrderShippingLabel409ApplicationProblemPlusJSONResponse{ConflictProblemApplicationProblemPlusJSONResponse: apicontract.ConflictProblemApplicationProblemPlusJSONResponse(problem)}, nil
 	}
+	if errors.Is(err, shippingservice.ErrFulfillmentStepNotAllowed) {
+		problem := providerAdminProblem(ctx, e.renderer, http.StatusConflict, "fulfillment_step_not_allowed", err.Error(), err)
+		return apicontract.CreateAdminOrderShippingLabel409ApplicationProblemPlusJSONResponse{ConflictProblemApplicationProblemPlusJSONResponse: apicontract.ConflictProblemApplicationProblemPlusJSONResponse(problem)}, nil
+	}
+	if errors.Is(err, orderservice.ErrOrderRiskReviewPending) {
+		problem := providerAdminProblem(ctx, e.renderer, http.StatusConflict, "order_risk_review_pending", "Order is held for risk review", err)
+		return apicontract.CreateAdminOrderShippingLabel409ApplicationProblemPlusJSONResponse{ConflictProblemApplicationProblemPlusJSONResponse: apicontract.ConflictProblemApplicationProblemPlusJSONResponse(problem)}, nil
+	}
+	if errors.Is(err, shippingservice.ErrFulfillmentOrderNotFound) {
+		problem := providerAdminProblem(ctx, e.renderer, http.StatusNotFound, "not_found", "Fulfillment order not found", err)
+		return apicontract.CreateAdminOrderShippingLabel404ApplicationProblemPlusJSONResponse{NotFoundProblemApplicationProblemPlusJSONResponse: apicontract.NotFoundProblemApplicationProblemPlusJSONResponse(problem)}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -433,6 +464,9 @@ func (e *CheckoutProviderEndpoints) AuthorizeCheckoutOrderPayment(ctx context.Co
 	var intent models.PaymentIntent
 	var transaction models.PaymentTransaction
 	err = e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkoutservice.CheckOrderPurchaseLimits(tx, order); err != nil {
+			return err
+		}
 		var prepareErr error
 		intent, transaction, prepareErr = paymentservice.PrepareAuthorizedPaymentIntent(tx, order.ID, snapshot, idempotencyKey)
 		if prepareErr != nil {
@@ -440,6 +474,10 @@ func (e *CheckoutProviderEndpoints) AuthorizeCheckoutOrderPayment(ctx context.Co
 		}
 		return paymentservice.BindSnapshotToOrder(tx, &snapshot, order.ID, time.Now().UTC())
 	})
+	var ruleErr *checkoutservice.QuantityRuleError
+	if errors.As(err, &ruleErr) {
+		return nil, checkoutEndpointError(err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -505,6 +543,7 @@ func (e *CheckoutProviderEndpoints) runAdminPayment(ctx context.Context, orderID
 	}
 	result := adminPaymentResult{}
 	var providerRequest any
+	settled := false
 	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.First(&result.order, orderID).Error; err != nil {
 			return err
@@ -519,10 +558,21 @@ func (e *CheckoutProviderEndpoints) runAdminPayment(ctx context.Context, orderID
 			value := models.MoneyFromFloat(*amount)
 			requested = &value
 		}
+		if intent.Provider == models.PaymentProviderGiftCard {
+			settled = true
+			return settleGiftCardPayment(tx, &result, operation, requested, idempotencyKey, correlationID(ctx))
+		}
 		switch operation {
 		case "capture":
+			if result.order.RiskReviewStatus == models.RiskReviewPending {
+				return orderservice.ErrOrderRiskReviewPending
+			}
+			capturable, err := orderservice.CapturableAmount(tx, result.order)
+			if err != nil {
+				return err
+			}
 			var request paymentservice.CaptureRequest
-			result.transaction, request, err = paymentservice.PrepareCapturePaymentIntent(tx, &result.intent, requested, idempotencyKey, correlationID(ctx))
+			result.transaction, request, err = paymentservice.PrepareCapturePaymentIntent(tx, &result.intent, requested, capturable, idempotencyKey, correlationID(ctx))
 			providerRequest = request
 		case "void":
 			var request paymentservice.VoidRequest
@@ -540,6 +590,10 @@ func (e *CheckoutProviderEndpoints) runAdminPayment(ctx context.Context, orderID
 	if err != nil {
 		return result, err
 	}
+	if settled {
+		result.order, err = e.orders.Get(ctx, orderID, nil)
+		return result, err
+	}
 	operationKey := providerops.PaymentOperationKey(fmt.Sprintf("admin_%s:%d:%d", operation, orderID, intentID), idempotencyKey)
 	fingerprint, err := providerops.PaymentRequestFingerprint(providerRequest)
 	if err != nil {
@@ -559,25 +613,14 @@ func (e *CheckoutProviderEndpoints) runAdminPayment(ctx context.Context, orderID
 				targetStatus, reason = models.StatusCancelled, "payment_voided"
 			case "refund":
 				result.intent, result.transaction, err = paymentservice.FinalizeRefundPaymentIntent(tx, intentID, result.transaction.ID, providerResult)
-				if result.intent.Status == models.PaymentIntentStatusRefunded {
-					targetStatus, reason = models.StatusRefunded, "payment_refunded"
+				if err == nil {
+					targetStatus, reason, err = refundedOrderTarget(tx, orderID)
 				}
 			}
 			if err != nil || targetStatus == "" {
 				return err
 			}
-			var order models.Order
-			if err := tx.First(&order, orderID).Error; err != nil {
-				return err
-			}
-			if order.Status == targetStatus {
-				return nil
-			}
-			from := order.Status
-			if err := orderservice.ApplyStatusTransition(tx, &order, targetStatus); err != nil {
-				return err
-			}
-			return paymentservice.AppendOrderStatusHistory(tx, order.ID, from, targetStatus, reason, "admin", "admin", correlationID(finalizeCtx))
+			return transitionPaymentOrder(tx, orderID, targetStatus, reason, correlationID(finalizeCtx))
 		})
 	}
 	input := providerops.PaymentMutationInput{Prepare: prepare, Registry: e.runtime.PaymentProviders, Request: providerRequest, DomainTransactionID: result.transaction.ID, Finalize: finalize}
@@ -613,6 +656,69 @@ func (e *CheckoutProviderEndpoints) runAdminPayment(ctx context.Context, orderID
 	return result, err
 }
 
+// settleGiftCardPayment runs admin lifecycle operations on gift card tenders.
+// Their funds are captured at checkout, so only refunds apply, and those are
+// settled against the card ledger instead of a payment provider.
+func settleGiftCardPayment(tx *gorm.DB, result *adminPaymentResult, operation string, requested *models.Money, idempotencyKey, correlationID string) error {
+	switch operation {
+	case "capture":
+		return paymentservice.ErrCaptureNotAllowed
+	case "void":
+		return paymentservice.ErrVoidNotAllowed
+	case "refund":
+	default:
+		return errors.New("unsupported payment operation")
+	}
+	var err error
+	result.intent, result.transaction, err = giftcardservice.RefundTender(tx, &result.intent, requested, idempotencyKey, "admin")
+	if err != nil {
+		return err
+	}
+	targetStatus, reason, err := refundedOrderTarget(tx, result.intent.OrderID)
+	if err != nil || targetStatus == "" {
+		return err
+	}
+	return transitionPaymentOrder(tx, result.intent.OrderID, targetStatus, reason, correlationID)
+}
+
+// refundedOrderTarget moves an order to refunded only once every tender on
+// it, provider and gift card alike, has been refunded in full.
+func refundedOrderTarget(tx *gorm.DB, orderID uint) (string, string, error) {
+	refunded, err := paymentservice.OrderFullyRefunded(tx, orderID)
+	if err != nil || !refunded {
+		return "", "", err
+	}
+	return models.StatusRefunded, "payment_refunded", nil
+}
+
+func transitionPaymentOrder(tx *gorm.DB, orderID uint, targetStatus, reason, correlationID string) error {
+	var order models.Order
+	if err := tx.First(&order, orderID).Error; err != nil {
+		return err
+	}
+	// A capture on an order that is already shipping leaves its status alone.
+	if order.Status == targetStatus || (targetStatus == models.StatusPaid && slices.Contains(models.PaidOrderStatuses, order.Status)) {
+		return nil
+	}
+	if err := orderservice.ApplyStatusTransition(tx, &order, orderservice.StatusTransition{
+		To: targetStatus, Path: orderservice.PathPayment, Source: "admin", Reason: reason,
+		Actor: "admin", CorrelationID: correlationID,
+	}); err != nil {
+		return err
+	}
+	_, err := giftcardservice.ReleaseUnpaidOrderTenders(tx, orderID)
+	return err
+}
+
+// releaseUnpaidOrderTenders returns gift card funds held by an order that was
+// just cancelled or failed before it was paid.
+func (e *CheckoutProviderEndpoints) releaseUnpaidOrderTenders(ctx context.Context, orderID uint) error {
+	return e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := giftcardservice.ReleaseUnpaidOrderTenders(tx, orderID)
+		return err
+	})
+}
+
 func providerAccepted(operation models.ProviderOperation, message string) apicontract.ProviderOperationAcceptedEnvelope {
 	return apicontract.ProviderOperationAcceptedEnvelope{Message: message, OperationKey: operation.OperationKey, Status: apicontract.ProviderOperationStatus(operation.Status)}
 }
@@ -626,7 +732,7 @@ func (e *CheckoutProviderEndpoints) CaptureAdminOrderPayment(ctx context.Context
 		amount = r.Body.Amount
 	}
 	v, err := e.runAdminPayment(ctx, uint(r.Id), uint(r.IntentId), "capture", r.Params.IdempotencyKey, amount)
-	if errors.Is(err, paymentservice.ErrCaptureNotAllowed) || errors.Is(err, paymentservice.ErrAmountExceedsAvailable) || errors.Is(err, providerops.ErrIdempotencyFingerprintConflict) {
+	if errors.Is(err, paymentservice.ErrCaptureNotAllowed) || errors.Is(err, paymentservice.ErrAmountExceedsAvailable) || errors.Is(err, providerops.ErrIdempotencyFingerprintConflict) || errors.Is(err, orderservice.ErrOrderRiskReviewPending) {
 		problem := providerAdminProblem(ctx, e.renderer, http.StatusConflict, "payment_lifecycle_conflict", err.Error(), err)
 		return apicontract.CaptureAdminOrderPayment409ApplicationProblemPlusJSONResponse{ConflictProblemApplicationProblemPlusJSONResponse: apicontract.ConflictProblemApplicationProblemPlusJSONResponse(problem)}, nil
 	}
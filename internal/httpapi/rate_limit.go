@@ -0,0 +1,132 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ecommerce/internal/ratelimit"
+	"ecommerce/internal/requestctx"
+	"github.com/gin-gonic/gin"
+)
+
+type RateLimitScope string
+
+const (
+	// RateLimitScopeClient counts hits per client address.
+	RateLimitScopeClient RateLimitScope = "client"
+	// RateLimitScopePrincipal counts hits per authenticated subject and falls
+	// back to the client address for anonymous callers.
+	RateLimitScopePrincipal RateLimitScope = "principal"
+	// RateLimitScopeCheckoutSession counts hits per client address and
+	// checkout session cookie, so shoppers behind one NAT do not share a budget.
+	RateLimitScopeCheckoutSession RateLimitScope = "checkout-session"
+)
+
+const globalRateLimitBucket = "global"
+
+// RateLimitPolicy is one counter an operation draws from. Operations sharing a
+// Bucket share a budget; the contract default uses a single global bucket while
+// route overrides get a bucket per operation.
+type RateLimitPolicy struct {
+	Bucket string
+	Limit  int
+	Window time.Duration
+	Scope  RateLimitScope
+	Code   string
+}
+
+func (policy RateLimitPolicy) validate(operationID string) error {
+	if strings.TrimSpace(policy.Bucket) == "" {
+		return fmt.Errorf("operation %q has a rate limit without a bucket", operationID)
+	}
+	if policy.Limit <= 0 || policy.Window < time.Second {
+		return fmt.Errorf("operation %q rate limit %q must allow at least one hit per window of one second or more", operationID, policy.Bucket)
+	}
+	switch policy.Scope {
+	case RateLimitScopeClient, RateLimitScopePrincipal, RateLimitScopeCheckoutSession:
+		return nil
+	default:
+		return fmt.Errorf("operation %q rate limit %q has unsupported scope %q", operationID, policy.Bucket, policy.Scope)
+	}
+}
+
+func (policy RateLimitPolicy) rule() ratelimit.Rule {
+	return ratelimit.Rule{Limit: policy.Limit, Window: policy.Window}
+}
+
+type contractRateLimit struct {
+	Limit         int    `json:"limit"`
+	WindowSeconds int    `json:"window-seconds"`
+	Scope         string `json:"scope"`
+	Code          string `json:"code"`
+}
+
+func (limit contractRateLimit) policy(bucket string) RateLimitPolicy {
+	return RateLimitPolicy{
+		Bucket: bucket, Limit: limit.Limit, Window: time.Duration(limit.WindowSeconds) * time.Second,
+		Scope: RateLimitScope(limit.Scope), Code: limit.Code,
+	}
+}
+
+// enforceRateLimits counts the request against every policy of the operation.
+// Headers describe the most constrained counter; a store failure fails open so
+// a database hiccup degrades to unlimited rather than rejecting all traffic.
+func enforceRateLimits(ctx *gin.Context, store ratelimit.Store, renderer Renderer, policy OperationPolicy, now time.Time) bool {
+	if store == nil || len(policy.RateLimits) == 0 {
+		return true
+	}
+	requestContext := ctx.Request.Context()
+	var tightest *ratelimit.Decision
+	var rejected *RateLimitPolicy
+	for index := range policy.RateLimits {
+		limit := policy.RateLimits[index]
+		decision, err := store.Take(requestContext, limit.Bucket+"|"+rateLimitSubject(ctx, limit.Scope), limit.rule(), now)
+		if err != nil {
+			if renderer.Report != nil && !errors.Is(err, context.Canceled) {
+				renderer.Report(requestContext, fmt.Errorf("rate limit %q: %w", limit.Bucket, err), Problem{Status: http.StatusInternalServerError, Code: "rate_limit_unavailable"})
+			}
+			continue
+		}
+		if !decision.Allowed && rejected == nil {
+			rejected = &limit
+			tightest = &decision
+		}
+		if rejected == nil && (tightest == nil || decision.Remaining < tightest.Remaining) {
+			tightest = &decision
+		}
+	}
+	if tightest != nil {
+		ratelimit.WriteHeaders(ctx.Writer.Header(), *tightest, now)
+	}
+	if rejected == nil {
+		return true
+	}
+	code := rejected.Code
+	if code == "" {
+		code = "rate_limited"
+	}
+	renderer.Render(ctx.Writer, requestContext, http.StatusTooManyRequests, ErrorProblem(Problem{
+		Type: TypeRateLimited, Title: http.StatusText(http.StatusTooManyRequests), Status: http.StatusTooManyRequests,
+		Code: code, Detail: "Too many requests. Please wait and try again.",
+	}, nil))
+	return false
+}
+
+func rateLimitSubject(ctx *gin.Context, scope RateLimitScope) string {
+	client := "client:" + ctx.ClientIP()
+	switch scope {
+	case RateLimitScopePrincipal:
+		if principal, ok := requestctx.PrincipalFrom(ctx.Request.Context()); ok && principal.Authenticated() {
+			return "principal:" + principal.Subject
+		}
+	case RateLimitScopeCheckoutSession:
+		if cookie, err := ctx.Request.Cookie(checkoutSessionCookieName); err == nil && cookie.Value != "" {
+			return client + "|session:" + cookie.Value
+		}
+	}
+	return client
+}
@@ -0,0 +1,198 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"ecommerce/internal/apicontract"
+	checkoutservice "ecommerce/internal/services/checkout"
+	"ecommerce/models"
+)
+
+func (e *CheckoutProviderEndpoints) ListMySavedCarts(ctx context.Context, r apicontract.ListMySavedCartsRequestObject) (apicontract.ListMySavedCartsResponseObject, error) {
+	userID, err := principalAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, limit := cmsPagination(r.Params.Page, r.Params.Limit)
+	values, total, err := e.checkout.ListSavedCarts(ctx, checkoutservice.ListSavedCartsInput{UserID: &userID, Status: derefString(r.Params.Status), Limit: limit, Offset: (page - 1) * limit})
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ListMySavedCarts200JSONResponse(savedCartListContract(values, total, page, limit, time.Now().UTC())), nil
+}
+
+func (e *CheckoutProviderEndpoints) SaveMyCart(ctx context.Context, r apicontract.SaveMyCartRequestObject) (apicontract.SaveMyCartResponseObject, error) {
+	userID, err := principalAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A saved cart body is required.", nil)
+	}
+	session, ok := checkoutservice.SessionFromContext(ctx)
+	if !ok {
+		return nil, problemError(http.StatusBadRequest, "invalid_saved_cart", "The cart is empty.", nil)
+	}
+	now := time.Now().UTC()
+	saved, err := e.checkout.SaveCart(ctx, checkoutservice.SaveCartInput{SessionID: session.ID, OwnerUserID: &userID, Name: r.Body.Name, Note: derefString(r.Body.Note), ExpiresAt: r.Body.ExpiresAt}, now)
+	if err != nil {
+		return nil, savedCartEndpointError(err)
+	}
+	return apicontract.SaveMyCart201JSONResponse(savedCartContract(saved, now)), nil
+}
+
+func (e *CheckoutProviderEndpoints) ArchiveMySavedCart(ctx context.Context, r apicontract.ArchiveMySavedCartRequestObject) (apicontract.ArchiveMySavedCartResponseObject, error) {
+	userID, err := principalAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := e.checkout.ArchiveSavedCart(ctx, uint(r.Id), &userID)
+	if err != nil {
+		return nil, savedCartEndpointError(err)
+	}
+	return apicontract.ArchiveMySavedCart200JSONResponse(savedCartContract(saved, time.Now().UTC())), nil
+}
+
+func (e *CheckoutProviderEndpoints) GetCheckoutSavedCart(ctx context.Context, r apicontract.GetCheckoutSavedCartRequestObject) (apicontract.GetCheckoutSavedCartResponseObject, error) {
+	saved, err := e.checkout.GetSavedCartByToken(ctx, r.Token)
+	if err != nil {
+		return nil, savedCartEndpointError(err)
+	}
+	return apicontract.GetCheckoutSavedCart200JSONResponse(savedCartContract(saved, time.Now().UTC())), nil
+}
+
+func (e *CheckoutProviderEndpoints) ConvertCheckoutSavedCart(ctx context.Context, r apicontract.ConvertCheckoutSavedCartRequestObject) (apicontract.ConvertCheckoutSavedCartResponseObject, error) {
+	userID, err := principalAccountID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, ok := checkoutservice.SessionFromContext(ctx)
+	if !ok {
+		return nil, problemError(http.StatusBadRequest, "checkout_session_required", "A checkout session is required", nil)
+	}
+	now := time.Now().UTC()
+	conversion, err := e.checkout.ConvertSavedCart(ctx, r.Token, session.ID, userID, now)
+	if err != nil {
+		return nil, savedCartEndpointError(err)
+	}
+	cart, err := e.checkout.CartForSession(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]apicontract.CartMergeLine, 0, len(conversion.Lines))
+	for _, line := range conversion.Lines {
+		lines = append(lines, cartMergeLineContract(line))
+	}
+	return apicontract.ConvertCheckoutSavedCart200JSONResponse{Cart: cartContract(cart), SavedCart: savedCartContract(conversion.SavedCart, now), Lines: lines}, nil
+}
+
+func (e *CheckoutProviderEndpoints) ListAdminSavedCarts(ctx context.Context, r apicontract.ListAdminSavedCartsRequestObject) (apicontract.ListAdminSavedCartsResponseObject, error) {
+	page, limit := cmsPagination(r.Params.Page, r.Params.Limit)
+	input := checkoutservice.ListSavedCartsInput{Status: derefString(r.Params.Status), Limit: limit, Offset: (page - 1) * limit}
+	if r.Params.CustomerUserId != nil {
+		customerID := uint(*r.Params.CustomerUserId)
+		input.CustomerUserID = &customerID
+	}
+	values, total, err := e.checkout.ListSavedCarts(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return apicontract.ListAdminSavedCarts200JSONResponse(savedCartListContract(values, total, page, limit, time.Now().UTC())), nil
+}
+
+func (e *CheckoutProviderEndpoints) CreateAdminSavedCart(ctx context.Context, r apicontract.CreateAdminSavedCartRequestObject) (apicontract.CreateAdminSavedCartResponseObject, error) {
+	if r.Body == nil {
+		return nil, problemError(http.StatusBadRequest, "invalid_request", "A saved cart body is required.", nil)
+	}
+	actor, actorID := cmsActor(ctx)
+	input := checkoutservice.CreateSavedCartInput{
+		Name:        r.Body.Name,
+		Note:        derefString(r.Body.Note),
+		OwnerUserID: actorID,
+		CreatedBy:   actor,
+		LockPrices:  r.Body.LockPrices != nil && *r.Body.LockPrices,
+		ExpiresAt:   r.Body.ExpiresAt,
+	}
+	if r.Body.CustomerUserId != nil {
+		customerID := uint(*r.Body.CustomerUserId)
+		input.CustomerUserID = &customerID
+	}
+	for _, item := range r.Body.Items {
+		line := checkoutservice.SavedCartItemInput{ProductVariantID: uint(item.ProductVariantId), Quantity: item.Quantity}
+		if item.UnitPrice != nil {
+			price := models.MoneyFromFloat(*item.UnitPrice)
+			line.UnitPrice = &price
+		}
+		input.Items = append(input.Items, line)
+	}
+	now := time.Now().UTC()
+	saved, err := e.checkout.CreateSavedCart(ctx, input, now)
+	if err != nil {
+		return nil, savedCartEndpointError(err)
+	}
+	return apicontract.CreateAdminSavedCart201JSONResponse(savedCartContract(saved, now)), nil
+}
+
+func (e *CheckoutProviderEndpoints) GetAdminSavedCart(ctx context.Context, r apicontract.GetAdminSavedCartRequestObject) (apicontract.GetAdminSavedCartResponseObject, error) {
+	saved, err := e.checkout.GetSavedCart(ctx, uint(r.Id))
+	if err != nil {
+		return nil, savedCartEndpointError(err)
+	}
+	return apicontract.GetAdminSavedCart200JSONResponse(savedCartContract(saved, time.Now().UTC())), nil
+}
+
+func (e *CheckoutProviderEndpoints) ArchiveAdminSavedCart(ctx context.Context, r apicontract.ArchiveAdminSavedCartRequestObject) (apicontract.ArchiveAdminSavedCartResponseObject, error) {
+	saved, err := e.checkout.ArchiveSavedCart(ctx, uint(r.Id), nil)
+	if err != nil {
+		return nil, savedCartEndpointError(err)
+	}
+	return apicontract.ArchiveAdminSavedCart200JSONResponse(savedCartContract(saved, time.Now().UTC())), nil
+}
+
+func savedCartEndpointError(err error) error {
+	switch {
+	case errors.Is(err, checkoutservice.ErrInvalidSavedCart), errors.Is(err, checkoutservice.ErrVariantNotFound):
+		return problemError(http.StatusBadRequest, "invalid_saved_cart", err.Error(), err)
+	case errors.Is(err, checkoutservice.ErrSavedCartNotFound):
+		return problemError(http.StatusNotFound, "saved_cart_not_found", "Saved cart not found.", err)
+	case errors.Is(err, checkoutservice.ErrSavedCartExpired):
+		return problemError(http.StatusConflict, "saved_cart_expired", "This saved cart has expired.", err)
+	case errors.Is(err, checkoutservice.ErrSavedCartConverted):
+		return problemError(http.StatusConflict, "saved_cart_converted", "This quote was already added to a cart and cannot be used again.", err)
+	case errors.Is(err, checkoutservice.ErrSavedCartRestricted):
+		return problemError(http.StatusForbidden, "saved_cart_restricted", "This saved cart was prepared for another customer. Sign in with that account to use it.", err)
+	default:
+		return err
+	}
+}
+
+func savedCartListContract(values []models.SavedCart, total int64, page, limit int, now time.Time) apicontract.SavedCartListResponse {
+	items := make([]apicontract.SavedCart, 0, len(values))
+	for _, value := range values {
+		items = append(items, savedCartContract(value, now))
+	}
+	return apicontract.SavedCartListResponse{Data: items, Pagination: apicontract.Pagination{Page: page, Limit: limit, Total: int(total), TotalPages: totalPages(total, limit)}}
+}
+
+func savedCartContract(value models.SavedCart, now time.Time) apicontract.SavedCart {
+	subtotal := models.Money(0)
+	items := make([]apicontract.SavedCartItem, 0, len(value.Items))
+	for _, item := range value.Items {
+		subtotal += item.UnitPrice.Mul(item.Quantity)
+		items = append(items, apicontract.SavedCartItem{
+			Id: int(item.ID), ProductVariantId: int(item.ProductVariantID), Quantity: item.Quantity,
+			UnitPrice: item.UnitPrice.Float64(), CurrentPrice: item.ProductVariant.Price.Float64(),
+			ProductVariant: basicVariantContract(item.ProductVariant), Product: basicProductContract(item.ProductVariant.Product),
+		})
+	}
+	return apicontract.SavedCart{
+		Id: int(value.ID), Name: value.Name, Note: value.Note, ShareToken: value.ShareToken,
+		OwnerUserId: optionalUint(value.OwnerUserID), CustomerUserId: optionalUint(value.CustomerUserID), CreatedBy: value.CreatedBy,
+		LockPrices: value.LockPrices, ExpiresAt: value.ExpiresAt, Status: value.Status, Expired: value.Expired(now),
+		ConversionCount: value.ConversionCount, LastConvertedAt: value.LastConvertedAt, Subtotal: subtotal.Float64(), Items: items,
+		CreatedAt: value.CreatedAt, UpdatedAt: value.UpdatedAt,
+	}
+}
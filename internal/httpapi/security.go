@@ -9,8 +9,10 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"ecommerce/internal/apicontract"
+	"ecommerce/internal/ratelimit"
 	"ecommerce/internal/requestctx"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -94,11 +96,15 @@ type SecurityOptions struct {
 	CSRFHeaderName string
 	BaseURL        string
 	PreviewSecret  string
+	RateLimiter    ratelimit.Store
+	Now            func() time.Time
 }
 
 // OperationSecurityMiddleware executes before generated binding. It applies the
-// operation body limit, resolves optional credentials, authorizes access/roles,
-// and enforces CSRF for cookie-based unsafe requests.
+// operation body limit, resolves optional credentials, counts the request
+// against the operation rate limits (including requests whose credentials are
+// rejected), authorizes access/roles, and enforces CSRF for cookie-based unsafe
+// requests.
 func OperationSecurityMiddleware(set PolicySet, renderer Renderer, options SecurityOptions) (gin.HandlerFunc, error) {
 	routes, err := contractOperationRoutes(options.BaseURL)
 	if err != nil {
@@ -112,6 +118,10 @@ func OperationSecurityMiddleware(set PolicySet, renderer Renderer, options Secur
 	if csrfHeaderName == "" {
 		csrfHeaderName = "X-CSRF-Token"
 	}
+	now := options.Now
+	if now == nil {
+		now = time.Now
+	}
 
 	return func(ctx *gin.Context) {
 		routePath := ctx.FullPath()
@@ -166,6 +176,12 @@ func OperationSecurityMiddleware(set PolicySet, renderer Renderer, options Secur
 		if options.Authenticator != nil {
 			principal, present, authErr := options.Authenticator.Authenticate(ctx.Request)
 			if authErr != nil {
+				// A rejected credential still counts against the operation
+				// limits, keyed by client, so guessing tokens is throttled.
+				if !enforceRateLimits(ctx, options.RateLimiter, renderer, policy, now().UTC()) {
+					ctx.Abort()
+					return
+				}
 				renderer.Render(ctx.Writer, requestContext, http.StatusUnauthorized, ErrorProblem(Problem{
 					Type: TypeAuthenticationRequired, Title: http.StatusText(http.StatusUnauthorized),
 					Status: http.StatusUnauthorized, Code: "authentication_invalid", Detail: "The authentication credential is invalid or expired.",
@@ -178,6 +194,10 @@ func OperationSecurityMiddleware(set PolicySet, renderer Renderer, options Secur
 				ctx.Request = ctx.Request.WithContext(requestContext)
 			}
 		}
+		if !enforceRateLimits(ctx, options.RateLimiter, renderer, policy, now().UTC()) {
+			ctx.Abort()
+			return
+		}
 		if err := authorizePolicy(requestContext, policy); err != nil {
 			renderer.Render(ctx.Writer, requestContext, http.StatusInternalServerError, err)
 			ctx.Abort()
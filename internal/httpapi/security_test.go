@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"ecommerce/internal/httpapi"
+	"ecommerce/internal/ratelimit"
 	"ecommerce/internal/requestctx"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -145,6 +146,87 @@ func TestOperationSecurityRejectsCookieMutationWithoutCSRF(t *testing.T) {
 	assert.Equal(t, "csrf_failed", problem.Code)
 }
 
+func TestOperationSecurityAppliesOperationRateLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policies, err := httpapi.NewPolicySet(httpapi.OperationPolicy{
+		OperationID: "CreateCheckoutOrder", Access: httpapi.AccessPublic, CSRF: httpapi.CSRFExempt, MaxBodyBytes: 1024,
+		RateLimits: []httpapi.RateLimitPolicy{
+			{Bucket: "global", Limit: 100, Window: time.Second, Scope: httpapi.RateLimitScopeClient},
+			{Bucket: "CreateCheckoutOrder", Limit: 2, Window: time.Minute, Scope: httpapi.RateLimitScopeCheckoutSession, Code: "checkout_rate_limited"},
+		},
+	})
+	require.NoError(t, err)
+	now := time.Date(2026, 8, 19, 10, 0, 30, 0, time.UTC)
+	security, err := httpapi.OperationSecurityMiddleware(policies, httpapi.Renderer{}, httpapi.SecurityOptions{
+		RateLimiter: ratelimit.NewMemoryStore(), Now: func() time.Time { return now },
+	})
+	require.NoError(t, err)
+	router := gin.New()
+	router.Use(httpapi.RequestContextMiddleware(httpapi.RequestContextOptions{NewID: func() string { return "req-1" }}), security)
+	router.POST("/api/v1/checkout/orders", func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+
+	submit := func(session string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/checkout/orders", nil)
+		request.Header.Set("Authorization", "Bearer token")
+		request.AddCookie(&http.Cookie{Name: "checkout_session", Value: session})
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+	recorder := submit("session-a")
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", recorder.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", recorder.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, http.StatusCreated, submit("session-a").Code)
+
+	recorder = submit("session-a")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "30", recorder.Header().Get("Retry-After"))
+	var problem httpapi.Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, "checkout_rate_limited", problem.Code)
+	assert.Equal(t, httpapi.TypeRateLimited, problem.Type)
+
+	// Another checkout session from the same address keeps its own budget.
+	assert.Equal(t, http.StatusCreated, submit("session-b").Code)
+}
+
+func TestOperationSecurityRateLimitsRejectedCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policies, err := httpapi.NewPolicySet(httpapi.OperationPolicy{
+		OperationID: "ListUserOrders", Access: httpapi.AccessAuthenticated, CSRF: httpapi.CSRFExempt, MaxBodyBytes: 1024,
+		RateLimits: []httpapi.RateLimitPolicy{
+			{Bucket: "ListUserOrders", Limit: 2, Window: time.Minute, Scope: httpapi.RateLimitScopePrincipal},
+		},
+	})
+	require.NoError(t, err)
+	security, err := httpapi.OperationSecurityMiddleware(policies, httpapi.Renderer{}, httpapi.SecurityOptions{
+		Authenticator: httpapi.JWTAuthenticator{Secret: []byte("secret")},
+		RateLimiter:   ratelimit.NewMemoryStore(),
+	})
+	require.NoError(t, err)
+	router := gin.New()
+	router.Use(httpapi.RequestContextMiddleware(httpapi.RequestContextOptions{NewID: func() string { return "req-1" }}), security)
+	router.GET("/api/v1/me/orders", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	guess := func() *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/me/orders", nil)
+		request.Header.Set("Authorization", "Bearer "+signedToken(t, "wrong-secret", "subject-1", "customer"))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+	assert.Equal(t, http.StatusUnauthorized, guess().Code)
+	assert.Equal(t, http.StatusUnauthorized, guess().Code)
+
+	recorder := guess()
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code, recorder.Body.String())
+	var problem httpapi.Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, httpapi.TypeRateLimited, problem.Type)
+}
+
 func mustMetadata(t *testing.T, ctx *gin.Context) requestctx.Metadata {
 	t.Helper()
 	metadata, ok := requestctx.MetadataFrom(ctx.Request.Context())
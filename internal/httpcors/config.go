@@ -10,6 +10,7 @@ var allowHeaders = []string{
 	"Authorization",
 	"X-CSRF-Token",
 	"Idempotency-Key",
+	"X-Express-Checkout",
 	"Tus-Resumable",
 	"Upload-Length",
 	"Upload-Metadata",
@@ -20,6 +21,10 @@ var exposeHeaders = []string{
 	"Content-Length",
 	"Location",
 	"Upload-Offset",
+	"RateLimit-Limit",
+	"RateLimit-Remaining",
+	"RateLimit-Reset",
+	"Retry-After",
 }
 
 func AllowMethods() []string {
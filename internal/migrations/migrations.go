@@ -109,6 +109,31 @@ const brandLogoMediaReferencesVersion = "2026071001_brand_logo_media_references"
 const brandLogoMediaContractVersion = "2026071002_remove_brand_logo_media_id"
 const providerOperationLedgerVersion = "2026073101_provider_operation_ledger"
 const providerOperationBackfillVersion = "2026073102_backfill_provider_operations"
+const customerSegmentsVersion = "2026081001_customer_segments"
+const discountBudgetCapsVersion = "2026081002_discount_budget_caps"
+const giftCardsVersion = "2026081201_gift_cards"
+const checkoutSessionMergeVersion = "2026081301_checkout_session_merge"
+const abandonedCartsVersion = "2026081401_abandoned_carts"
+const savedCartsVersion = "2026081501_saved_carts"
+const checkoutStateVersion = "2026081601_checkout_state"
+const expressCheckoutVersion = "2026081701_express_checkout"
+const checkoutFieldsVersion = "2026081801_checkout_fields"
+const rateLimitCountersVersion = "2026081901_rate_limit_counters"
+const orderRiskVersion = "2026082001_order_risk"
+const variantQuantityRulesVersion = "2026082101_variant_quantity_rules"
+const backordersVersion = "2026082201_backorders"
+const fulfillmentOrdersVersion = "2026082301_fulfillment_orders"
+const partialShipmentsVersion = "2026082401_partial_shipments"
+const stockLocationsVersion = "2026082501_stock_locations"
+const orderRoutingVersion = "2026082601_order_routing"
+const orderEditsVersion = "2026082701_order_edits"
+const draftOrdersVersion = "2026082801_draft_orders"
+const orderDocumentsVersion = "2026082901_order_documents"
+const orderSearchVersion = "2026083001_order_tags_notes_views"
+const orderTimelineVersion = "2026083101_order_timeline"
+const cartLockedQuantityVersion = "2026090101_cart_item_locked_quantity"
+const giftCardProductsVersion = "2026090201_gift_card_products"
+const storeCreditNotesVersion = "2026090301_store_credit_notes"
 const migrationStepAlertThresholdEnvVar = "MIGRATIONS_STEP_ALERT_THRESHOLD_MS"
 
 var versionPattern = regexp.MustCompile(`^\d{10}_[a-z0-9_]+$`)
@@ -1749,6 +1774,843 @@ var orderedMigrations = []Migration{
 		}},
 		Up: backfillProviderOperations,
 	},
+	{
+		Version:         customerSegmentsVersion,
+		Name:            "add customer segments and materialized membership",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "segments"},
+		PostChecks: []PostCheck{{
+			Name: "customer_segment_structures_exist",
+			Check: func(tx *gorm.DB) error {
+				for _, model := range []any{&models.CustomerSegment{}, &models.CustomerSegmentMembership{}} {
+					if !tx.Migrator().HasTable(model) {
+						return fmt.Errorf("missing table for %T", model)
+					}
+				}
+				if !tx.Migrator().HasIndex(&models.CustomerSegmentMembership{}, "idx_customer_segment_memberships_member") {
+					return errors.New("missing index idx_customer_segment_memberships_member")
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []any{&models.CustomerSegment{}, &models.CustomerSegmentMembership{}} {
+				if err := ops.CreateTableIfNotExists(tx, model); err != nil {
+					return err
+				}
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.CustomerSegmentMembership{}, "idx_customer_segment_memberships_member")
+		},
+	},
+	{
+		Version:         discountBudgetCapsVersion,
+		Name:            "add discount budget caps and budget alerts",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "discounts", "budgets"},
+		PostChecks: []PostCheck{{
+			Name: "discount_budget_structures_exist",
+			Check: func(tx *gorm.DB) error {
+				for _, column := range []string{"budget_amount", "budget_thresholds_json"} {
+					if !tx.Migrator().HasColumn(&models.DiscountCampaign{}, column) {
+						return fmt.Errorf("discount_campaigns.%s column missing", column)
+					}
+				}
+				if !tx.Migrator().HasTable(&models.DiscountBudgetAlert{}) {
+					return fmt.Errorf("missing migrated table for %T", &models.DiscountBudgetAlert{})
+				}
+				if !tx.Migrator().HasIndex(&models.DiscountBudgetAlert{}, "idx_discount_budget_alerts_threshold") {
+					return errors.New("missing index idx_discount_budget_alerts_threshold")
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.AddColumnIfNotExists(tx, "discount_campaigns", "budget_amount", "NUMERIC(12,2)"); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "discount_campaigns", "budget_thresholds_json", "TEXT NOT NULL DEFAULT '[]'"); err != nil {
+				return err
+			}
+			if err := ops.CreateTableIfNotExists(tx, &models.DiscountBudgetAlert{}); err != nil {
+				return err
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.DiscountBudgetAlert{}, "idx_discount_budget_alerts_threshold")
+		},
+	},
+	{
+		Version:         giftCardsVersion,
+		Name:            "add gift cards and gift card ledger",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "payments", "gift_cards"},
+		PostChecks: []PostCheck{{
+			Name: "gift_card_structures_exist",
+			Check: func(tx *gorm.DB) error {
+				for _, model := range []any{&models.GiftCard{}, &models.GiftCardLedgerEntry{}} {
+					if !tx.Migrator().HasTable(model) {
+						return fmt.Errorf("missing migrated table for %T", model)
+					}
+				}
+				if !tx.Migrator().HasIndex(&models.GiftCard{}, "idx_gift_cards_code_hash") {
+					return errors.New("missing index idx_gift_cards_code_hash")
+				}
+				if !tx.Migrator().HasIndex(&models.GiftCardLedgerEntry{}, "idx_gift_card_ledger_card_operation_key") {
+					return errors.New("missing index idx_gift_card_ledger_card_operation_key")
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.CreateTableIfNotExists(tx, &models.GiftCard{}); err != nil {
+				return err
+			}
+			if err := ops.CreateIndexIfNotExists(tx, &models.GiftCard{}, "idx_gift_cards_code_hash"); err != nil {
+				return err
+			}
+			if err := ops.CreateTableIfNotExists(tx, &models.GiftCardLedgerEntry{}); err != nil {
+				return err
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.GiftCardLedgerEntry{}, "idx_gift_card_ledger_card_operation_key")
+		},
+	},
+	{
+		Version:         checkoutSessionMergeVersion,
+		Name:            "track guest checkout sessions merged into account sessions",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "checkout"},
+		PostChecks: []PostCheck{{
+			Name: "checkout_session_merge_columns_exist",
+			Check: func(tx *gorm.DB) error {
+				for _, column := range []string{"merged_into_session_id", "merged_at"} {
+					if !tx.Migrator().HasColumn(&models.CheckoutSession{}, column) {
+						return fmt.Errorf("checkout_sessions.%s column missing", column)
+					}
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.AddColumnIfNotExists(tx, "checkout_sessions", "merged_into_session_id", "BIGINT"); err != nil {
+				return err
+			}
+			return ops.AddColumnIfNotExists(tx, "checkout_sessions", "merged_at", "TIMESTAMPTZ")
+		},
+	},
+	{
+		Version:         abandonedCartsVersion,
+		Name:            "add abandoned cart recovery tracking",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "checkout", "abandoned_carts"},
+		PostChecks: []PostCheck{{
+			Name: "abandoned_cart_structures_exist",
+			Check: func(tx *gorm.DB) error {
+				if !tx.Migrator().HasTable(&models.AbandonedCart{}) {
+					return fmt.Errorf("missing migrated table for %T", &models.AbandonedCart{})
+				}
+				if !tx.Migrator().HasIndex(&models.AbandonedCart{}, "idx_abandoned_carts_checkout_session_id") {
+					return errors.New("missing index idx_abandoned_carts_checkout_session_id")
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.CreateTableIfNotExists(tx, &models.AbandonedCart{}); err != nil {
+				return err
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.AbandonedCart{}, "idx_abandoned_carts_checkout_session_id")
+		},
+	},
+	{
+		Version:         savedCartsVersion,
+		Name:            "add shareable saved carts and cart line price locks",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "checkout", "saved_carts"},
+		PostChecks: []PostCheck{{
+			Name: "saved_cart_structures_exist",
+			Check: func(tx *gorm.DB) error {
+				for _, model := range []any{&models.SavedCart{}, &models.SavedCartItem{}} {
+					if !tx.Migrator().HasTable(model) {
+						return fmt.Errorf("missing migrated table for %T", model)
+					}
+				}
+				if !tx.Migrator().HasIndex(&models.SavedCart{}, "idx_saved_carts_share_token") {
+					return errors.New("missing index idx_saved_carts_share_token")
+				}
+				for _, column := range []string{"locked_price", "locked_until", "saved_cart_id"} {
+					if !tx.Migrator().HasColumn(&models.CartItem{}, column) {
+						return fmt.Errorf("cart_items.%s column missing", column)
+					}
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.CreateTableIfNotExists(tx, &models.SavedCart{}); err != nil {
+				return err
+			}
+			if err := ops.CreateIndexIfNotExists(tx, &models.SavedCart{}, "idx_saved_carts_share_token"); err != nil {
+				return err
+			}
+			if err := ops.CreateTableIfNotExists(tx, &models.SavedCartItem{}); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "cart_items", "locked_price", "NUMERIC(12,2)"); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "cart_items", "locked_until", "TIMESTAMPTZ"); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "cart_items", "saved_cart_id", "BIGINT"); err != nil {
+				return err
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.CartItem{}, "idx_cart_items_saved_cart_id")
+		},
+	},
+	{
+		Version:         checkoutStateVersion,
+		Name:            "store single-page checkout state on checkout sessions",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "checkout"},
+		PostChecks: []PostCheck{{
+			Name: "checkout_state_column_exists",
+			Check: func(tx *gorm.DB) error {
+				if !tx.Migrator().HasColumn(&models.CheckoutSession{}, "checkout_state_json") {
+					return errors.New("checkout_sessions.checkout_state_json column missing")
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			return ops.AddColumnIfNotExists(tx, "checkout_sessions", "checkout_state_json", "TEXT NOT NULL DEFAULT '{}'")
+		},
+	},
+	{
+		Version:         expressCheckoutVersion,
+		Name:            "separate buy-now checkout sessions from regular carts",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "checkout"},
+		PostChecks: []PostCheck{{
+			Name: "checkout_session_kind_columns_exist",
+			Check: func(tx *gorm.DB) error {
+				for _, column := range []string{"kind", "parent_session_id"} {
+					if !tx.Migrator().HasColumn(&models.CheckoutSession{}, column) {
+						return fmt.Errorf("checkout_sessions.%s column missing", column)
+					}
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.AddColumnIfNotExists(tx, "checkout_sessions", "kind", "TEXT NOT NULL DEFAULT 'STANDARD'"); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "checkout_sessions", "parent_session_id", "BIGINT"); err != nil {
+				return err
+			}
+			if err := ops.CreateIndexIfNotExists(tx, &models.CheckoutSession{}, "idx_checkout_sessions_kind"); err != nil {
+				return err
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.CheckoutSession{}, "idx_checkout_sessions_parent_session_id")
+		},
+	},
+	{
+		Version:         checkoutFieldsVersion,
+		Name:            "add merchant checkout fields and order attributes",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "checkout", "orders"},
+		PostChecks: []PostCheck{
+			{
+				Name: "checkout_fields_table_exists",
+				Check: func(tx *gorm.DB) error {
+					if !tx.Migrator().HasTable(&models.CheckoutField{}) {
+						return errors.New("checkout_fields table missing")
+					}
+					return nil
+				},
+			},
+			{
+				Name: "attribute_columns_exist",
+				Check: func(tx *gorm.DB) error {
+					if !tx.Migrator().HasColumn(&models.Order{}, "attributes_json") {
+						return errors.New("orders.attributes_json column missing")
+					}
+					if !tx.Migrator().HasColumn(&models.OrderCheckoutSnapshot{}, "attributes_json") {
+						return errors.New("order_checkout_snapshots.attributes_json column missing")
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.CreateTableIfNotExists(tx, &models.CheckoutField{}); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "orders", "attributes_json", "TEXT NOT NULL DEFAULT '{}'"); err != nil {
+				return err
+			}
+			return ops.AddColumnIfNotExists(tx, "order_checkout_snapshots", "attributes_json", "TEXT NOT NULL DEFAULT '{}'")
+		},
+	},
+	{
+		Version:         rateLimitCountersVersion,
+		Name:            "add shared rate limit counters",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "http"},
+		PostChecks: []PostCheck{
+			{
+				Name: "rate_limit_counters_table_exists",
+				Check: func(tx *gorm.DB) error {
+					if !tx.Migrator().HasTable(&models.RateLimitCounter{}) {
+						return errors.New("rate_limit_counters table missing")
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			return ops.CreateTableIfNotExists(tx, &models.RateLimitCounter{})
+		},
+	},
+	{
+		Version:         orderRiskVersion,
+		Name:            "add order fraud risk screening",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "orders", "payments"},
+		PostChecks: []PostCheck{
+			{
+				Name: "risk_tables_exist",
+				Check: func(tx *gorm.DB) error {
+					if !tx.Migrator().HasTable(&models.RiskSettings{}) {
+						return errors.New("risk_settings table missing")
+					}
+					if !tx.Migrator().HasTable(&models.OrderRiskAssessment{}) {
+						return errors.New("order_risk_assessments table missing")
+					}
+					return nil
+				},
+			},
+			{
+				Name: "order_risk_columns_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, column := range []string{"risk_score", "risk_action", "risk_reasons_json", "risk_review_status", "risk_reviewed_by", "risk_reviewed_at"} {
+						if !tx.Migrator().HasColumn(&models.Order{}, column) {
+							return fmt.Errorf("orders.%s column missing", column)
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.CreateTableIfNotExists(tx, &models.RiskSettings{}); err != nil {
+				return err
+			}
+			if err := ops.CreateTableIfNotExists(tx, &models.OrderRiskAssessment{}); err != nil {
+				return err
+			}
+			for _, column := range []struct{ name, definition string }{
+				{"risk_score", "INTEGER"},
+				{"risk_action", "TEXT NOT NULL DEFAULT ''"},
+				{"risk_reasons_json", "TEXT NOT NULL DEFAULT '[]'"},
+				{"risk_review_status", "TEXT NOT NULL DEFAULT ''"},
+				{"risk_reviewed_by", "TEXT NOT NULL DEFAULT ''"},
+				{"risk_reviewed_at", "TIMESTAMPTZ"},
+			} {
+				if err := ops.AddColumnIfNotExists(tx, "orders", column.name, column.definition); err != nil {
+					return err
+				}
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.Order{}, "idx_orders_risk_review_status")
+		},
+	},
+	{
+		Version:         variantQuantityRulesVersion,
+		Name:            "add variant quantity rules and purchase limits",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "catalog", "checkout"},
+		PostChecks: []PostCheck{
+			{
+				Name: "variant_quantity_columns_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, model := range []any{&models.ProductVariant{}, &models.ProductVariantDraft{}} {
+						for _, column := range []string{"min_quantity", "max_quantity", "quantity_step", "purchase_limit"} {
+							if !tx.Migrator().HasColumn(model, column) {
+								return fmt.Errorf("%T.%s column missing", model, column)
+							}
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, table := range []string{"product_variants", "product_variant_drafts"} {
+				for _, column := range []struct{ name, definition string }{
+					{"min_quantity", "INTEGER NOT NULL DEFAULT 1"},
+					{"max_quantity", "INTEGER"},
+					{"quantity_step", "INTEGER NOT NULL DEFAULT 1"},
+					{"purchase_limit", "INTEGER"},
+				} {
+					if err := ops.AddColumnIfNotExists(tx, table, column.name, column.definition); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:         backordersVersion,
+		Name:            "add backorder and pre-order inventory policies",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "catalog", "inventory", "orders"},
+		PostChecks: []PostCheck{
+			{
+				Name: "backorder_columns_exist",
+				Check: func(tx *gorm.DB) error {
+					columns := map[any][]string{
+						&models.ProductVariant{}:       {"inventory_policy", "backorder_limit", "preorder_release_at", "promised_ship_at"},
+						&models.ProductVariantDraft{}:  {"inventory_policy", "backorder_limit", "preorder_release_at", "promised_ship_at"},
+						&models.OrderItem{}:            {"inventory_policy", "promised_ship_at"},
+						&models.InventoryReservation{}: {"backordered", "allocated_at"},
+					}
+					for model, names := range columns {
+						for _, column := range names {
+							if !tx.Migrator().HasColumn(model, column) {
+								return fmt.Errorf("%T.%s column missing", model, column)
+							}
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, table := range []string{"product_variants", "product_variant_drafts"} {
+				for _, column := range []struct{ name, definition string }{
+					{"inventory_policy", "TEXT NOT NULL DEFAULT 'DENY'"},
+					{"backorder_limit", "INTEGER"},
+					{"preorder_release_at", "TIMESTAMPTZ"},
+					{"promised_ship_at", "TIMESTAMPTZ"},
+				} {
+					if err := ops.AddColumnIfNotExists(tx, table, column.name, column.definition); err != nil {
+						return err
+					}
+				}
+			}
+			if err := ops.AddColumnIfNotExists(tx, "order_items", "inventory_policy", "TEXT NOT NULL DEFAULT ''"); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "order_items", "promised_ship_at", "TIMESTAMPTZ"); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "inventory_reservations", "backordered", "BOOLEAN NOT NULL DEFAULT FALSE"); err != nil {
+				return err
+			}
+			if err := ops.AddColumnIfNotExists(tx, "inventory_reservations", "allocated_at", "TIMESTAMPTZ"); err != nil {
+				return err
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.InventoryReservation{}, "idx_inventory_reservations_backordered")
+		},
+	},
+	{
+		Version:         fulfillmentOrdersVersion,
+		Name:            "add fulfillment orders with pick and pack workflow",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "orders", "shipping"},
+		PostChecks: []PostCheck{
+			{
+				Name: "fulfillment_tables_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, model := range []any{&models.FulfillmentOrder{}, &models.FulfillmentOrderLine{}, &models.ShipmentPackageLine{}} {
+						if !tx.Migrator().HasTable(model) {
+							return fmt.Errorf("%T table missing", model)
+						}
+					}
+					if !tx.Migrator().HasColumn(&models.Shipment{}, "fulfillment_order_id") {
+						return errors.New("shipments.fulfillment_order_id column missing")
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []any{&models.FulfillmentOrder{}, &models.FulfillmentOrderLine{}, &models.ShipmentPackageLine{}} {
+				if err := ops.CreateTableIfNotExists(tx, model); err != nil {
+					return err
+				}
+			}
+			if err := ops.AddColumnIfNotExists(tx, "shipments", "fulfillment_order_id", "BIGINT"); err != nil {
+				return err
+			}
+			if err := ops.CreateIndexIfNotExists(tx, &models.Shipment{}, "idx_shipments_fulfillment_order_id"); err != nil {
+				return err
+			}
+			// Paid orders waiting to ship join the warehouse queue.
+			if err := tx.Exec(`
+				INSERT INTO fulfillment_orders (created_at, updated_at, order_id, location_code, status)
+				SELECT CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, o.id, 'default', 'UNFULFILLED'
+				FROM orders o
+				WHERE o.status = 'PAID' AND o.deleted_at IS NULL
+					AND NOT EXISTS (SELECT 1 FROM fulfillment_orders f WHERE f.order_id = o.id)`).Error; err != nil {
+				return err
+			}
+			return tx.Exec(`
+				INSERT INTO fulfillment_order_lines (created_at, updated_at, fulfillment_order_id, order_item_id, product_variant_id, variant_sku, variant_title, quantity)
+				SELECT CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, f.id, i.id, i.product_variant_id, COALESCE(i.variant_sku, ''), COALESCE(i.variant_title, ''), i.quantity
+				FROM fulfillment_orders f
+				JOIN order_items i ON i.order_id = f.order_id AND i.deleted_at IS NULL
+				WHERE NOT EXISTS (SELECT 1 FROM fulfillment_order_lines l WHERE l.fulfillment_order_id = f.id)`).Error
+		},
+	},
+	{
+		Version:         partialShipmentsVersion,
+		Name:            "track fulfilled and cancelled quantities per order line",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "orders", "shipping"},
+		PostChecks: []PostCheck{
+			{
+				Name: "order_item_quantity_columns_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, column := range []string{"quantity_fulfilled", "quantity_cancelled"} {
+						if !tx.Migrator().HasColumn(&models.OrderItem{}, column) {
+							return fmt.Errorf("order_items.%s column missing", column)
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, column := range []string{"quantity_fulfilled", "quantity_cancelled"} {
+				if err := ops.AddColumnIfNotExists(tx, "order_items", column, "INTEGER NOT NULL DEFAULT 0"); err != nil {
+					return err
+				}
+			}
+			// Orders that already went out shipped every unit in one parcel.
+			return tx.Exec(`
+				UPDATE order_items SET quantity_fulfilled = quantity
+				WHERE quantity_fulfilled = 0
+					AND order_id IN (SELECT id FROM orders WHERE status IN ('SHIPPED', 'DELIVERED'))`).Error
+		},
+	},
+	{
+		Version:         stockLocationsVersion,
+		Name:            "add stock locations with per-location inventory and transfers",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "inventory"},
+		PostChecks: []PostCheck{
+			{
+				Name: "stock_location_tables_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, model := range []any{&models.StockLocation{}, &models.InventoryTransfer{}, &models.InventoryTransferItem{}} {
+						if !tx.Migrator().HasTable(model) {
+							return fmt.Errorf("%T table missing", model)
+						}
+					}
+					if !tx.Migrator().HasIndex(&models.InventoryLevel{}, "idx_inventory_levels_item_location") {
+						return errors.New("inventory_levels item/location index missing")
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []any{&models.StockLocation{}, &models.InventoryTransfer{}, &models.InventoryTransferItem{}} {
+				if err := ops.CreateTableIfNotExists(tx, model); err != nil {
+					return err
+				}
+			}
+			if err := tx.Exec(`
+				INSERT INTO stock_locations (created_at, updated_at, code, name, kind, sellable, active, position)
+				SELECT CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'default', 'Default', 'WAREHOUSE', TRUE, TRUE, 0
+				WHERE NOT EXISTS (SELECT 1 FROM stock_locations WHERE code = 'default')`).Error; err != nil {
+				return err
+			}
+			tables := []string{"inventory_levels", "inventory_movements", "inventory_adjustments", "inventory_reservations", "inventory_receipts"}
+			for _, table := range tables {
+				if err := ops.AddColumnIfNotExists(tx, table, "stock_location_id", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return err
+				}
+				// Everything recorded so far happened in the one warehouse,
+				// except backorders that never drew any stock.
+				condition := "stock_location_id = 0"
+				if table == "inventory_reservations" {
+					condition += " AND NOT (backordered = TRUE AND status IN ('ACTIVE', 'BACKORDERED'))"
+				}
+				if err := tx.Exec(`UPDATE ` + table + ` SET stock_location_id = (SELECT id FROM stock_locations WHERE code = 'default') WHERE ` + condition).Error; err != nil {
+					return err
+				}
+			}
+			// An item now has one level per location, so the item index can
+			// no longer be unique.
+			if err := tx.Exec(`DROP INDEX IF EXISTS "idx_inventory_levels_inventory_item_id"`).Error; err != nil {
+				return err
+			}
+			for _, index := range []struct {
+				model any
+				name  string
+			}{
+				{model: &models.InventoryLevel{}, name: "idx_inventory_levels_inventory_item_id"},
+				{model: &models.InventoryLevel{}, name: "idx_inventory_levels_item_location"},
+				{model: &models.InventoryMovement{}, name: "idx_inventory_movements_stock_location_id"},
+				{model: &models.InventoryAdjustment{}, name: "idx_inventory_adjustments_stock_location_id"},
+				{model: &models.InventoryReservation{}, name: "idx_inventory_reservations_stock_location_id"},
+				{model: &models.InventoryReceipt{}, name: "idx_inventory_receipts_stock_location_id"},
+			} {
+				if err := ops.CreateIndexIfNotExists(tx, index.model, index.name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:         orderRoutingVersion,
+		Name:            "add order routing rules and location addresses",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "inventory", "orders"},
+		PostChecks: []PostCheck{
+			{
+				Name: "order_routing_rules_table_exists",
+				Check: func(tx *gorm.DB) error {
+					if !tx.Migrator().HasTable(&models.OrderRoutingRule{}) {
+						return errors.New("order_routing_rules table missing")
+					}
+					if !tx.Migrator().HasColumn(&models.FulfillmentOrder{}, "routing_explanation") {
+						return errors.New("fulfillment_orders.routing_explanation column missing")
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, column := range []struct {
+				table      string
+				name       string
+				definition string
+			}{
+				{table: "stock_locations", name: "country", definition: "VARCHAR(2) NOT NULL DEFAULT ''"},
+				{table: "stock_locations", name: "state", definition: "VARCHAR(64) NOT NULL DEFAULT ''"},
+				{table: "fulfillment_orders", name: "routing_explanation", definition: "TEXT NOT NULL DEFAULT ''"},
+			} {
+				if err := ops.AddColumnIfNotExists(tx, column.table, column.name, column.definition); err != nil {
+					return err
+				}
+			}
+			return ops.CreateTableIfNotExists(tx, &models.OrderRoutingRule{})
+		},
+	},
+	{
+		Version:         orderEditsVersion,
+		Name:            "add order edit sessions",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "orders"},
+		PostChecks: []PostCheck{
+			{
+				Name: "order_edit_tables_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, model := range []any{&models.OrderEdit{}, &models.OrderEditLine{}, &models.OrderEditChange{}} {
+						if !tx.Migrator().HasTable(model) {
+							return fmt.Errorf("missing table for %T", model)
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []any{&models.OrderEdit{}, &models.OrderEditLine{}, &models.OrderEditChange{}} {
+				if err := ops.CreateTableIfNotExists(tx, model); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, {
+		Version:         draftOrdersVersion,
+		Name:            "add draft orders",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "orders"},
+		PostChecks: []PostCheck{
+			{
+				Name: "draft_order_tables_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, model := range []any{&models.DraftOrder{}, &models.DraftOrderLine{}} {
+						if !tx.Migrator().HasTable(model) {
+							return fmt.Errorf("missing table for %T", model)
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []any{&models.DraftOrder{}, &models.DraftOrderLine{}} {
+				if err := ops.CreateTableIfNotExists(tx, model); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, {
+		Version:         orderDocumentsVersion,
+		Name:            "add legal entities and order documents",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "orders"},
+		PostChecks: []PostCheck{
+			{
+				Name: "order_document_tables_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, model := range []any{&models.LegalEntity{}, &models.OrderDocument{}} {
+						if !tx.Migrator().HasTable(model) {
+							return fmt.Errorf("missing table for %T", model)
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []any{&models.LegalEntity{}, &models.OrderDocument{}} {
+				if err := ops.CreateTableIfNotExists(tx, model); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, {
+		Version:         orderSearchVersion,
+		Name:            "add order tags, notes and saved views",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "orders"},
+		PostChecks: []PostCheck{
+			{
+				Name: "order_search_tables_exist",
+				Check: func(tx *gorm.DB) error {
+					for _, model := range []any{&models.OrderTag{}, &models.OrderNote{}, &models.OrderSavedView{}} {
+						if !tx.Migrator().HasTable(model) {
+							return fmt.Errorf("missing table for %T", model)
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []any{&models.OrderTag{}, &models.OrderNote{}, &models.OrderSavedView{}} {
+				if err := ops.CreateTableIfNotExists(tx, model); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, {
+		Version:         orderTimelineVersion,
+		Name:            "link webhook events to orders",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"backfill", "expand", "orders"},
+		PostChecks: []PostCheck{
+			{
+				Name: "webhook_event_order_column_exists",
+				Check: func(tx *gorm.DB) error {
+					if !tx.Migrator().HasColumn(&models.WebhookEvent{}, "order_id") {
+						return errors.New("webhook_events.order_id column missing")
+					}
+					return nil
+				},
+			},
+		},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.AddColumnIfNotExists(tx, "webhook_events", "order_id", "BIGINT"); err != nil {
+				return err
+			}
+			if err := ops.CreateIndexIfNotExists(tx, &models.WebhookEvent{}, "idx_webhook_events_order_id"); err != nil {
+				return err
+			}
+			// Processed events already left their id behind as the correlation
+			// ID of the status changes they caused.
+			return tx.Exec(`
+				UPDATE webhook_events SET order_id = (
+					SELECT MIN(h.order_id) FROM order_status_histories h
+					WHERE h.correlation_id = 'webhook:' || CAST(webhook_events.id AS TEXT)
+				)
+				WHERE order_id IS NULL`).Error
+		},
+	},
+	{
+		Version:         cartLockedQuantityVersion,
+		Name:            "limit cart line price locks to the quoted quantity",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"backfill", "expand", "checkout", "saved_carts"},
+		PostChecks: []PostCheck{{
+			Name: "cart_item_locked_quantity_exists",
+			Check: func(tx *gorm.DB) error {
+				if !tx.Migrator().HasColumn(&models.CartItem{}, "locked_quantity") {
+					return errors.New("cart_items.locked_quantity column missing")
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.AddColumnIfNotExists(tx, "cart_items", "locked_quantity", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+				return err
+			}
+			// Existing locks were quoted for the line as it stands.
+			return tx.Exec("UPDATE cart_items SET locked_quantity = quantity WHERE locked_price IS NOT NULL").Error
+		},
+	},
+	{
+		Version:         giftCardProductsVersion,
+		Name:            "sell gift cards as catalog variants",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "catalog", "gift_cards"},
+		PostChecks: []PostCheck{{
+			Name: "gift_card_product_columns_exist",
+			Check: func(tx *gorm.DB) error {
+				for _, model := range []any{&models.ProductVariant{}, &models.ProductVariantDraft{}} {
+					if !tx.Migrator().HasColumn(model, "is_gift_card") {
+						return fmt.Errorf("%T is_gift_card column missing", model)
+					}
+				}
+				if !tx.Migrator().HasColumn(&models.GiftCard{}, "order_item_id") {
+					return errors.New("gift_cards.order_item_id column missing")
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			for _, table := range []string{"product_variants", "product_variant_drafts"} {
+				if err := ops.AddColumnIfNotExists(tx, table, "is_gift_card", "BOOLEAN NOT NULL DEFAULT FALSE"); err != nil {
+					return err
+				}
+			}
+			if err := ops.AddColumnIfNotExists(tx, "gift_cards", "order_item_id", "BIGINT"); err != nil {
+				return err
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.GiftCard{}, "idx_gift_cards_order_item_id")
+		},
+	},
+	{
+		Version:         storeCreditNotesVersion,
+		Name:            "credit store credit issued in place of refunds",
+		TransactionMode: TransactionModeRequired,
+		Tags:            []string{"expand", "documents", "gift_cards"},
+		PostChecks: []PostCheck{{
+			Name: "order_document_gift_card_exists",
+			Check: func(tx *gorm.DB) error {
+				if !tx.Migrator().HasColumn(&models.OrderDocument{}, "gift_card_id") {
+					return errors.New("order_documents.gift_card_id column missing")
+				}
+				return nil
+			},
+		}},
+		Up: func(tx *gorm.DB) error {
+			if err := ops.AddColumnIfNotExists(tx, "order_documents", "gift_card_id", "BIGINT"); err != nil {
+				return err
+			}
+			return ops.CreateIndexIfNotExists(tx, &models.OrderDocument{}, "idx_order_documents_gift_card_id")
+		},
+	},
 }
 
 type legacyProviderPaymentTransaction struct {
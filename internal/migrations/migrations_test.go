@@ -566,7 +566,7 @@ func TestRunWithoutContractSkipsContractMigrations(t *testing.T) {
 
 	status, err := statusForMigrations(db, orderedMigrations)
 	require.NoError(t, err)
-	require.Equal(t, providerOperationBackfillVersion, status.LatestAppliedVersion)
+	require.Equal(t, storeCreditNotesVersion, status.LatestAppliedVersion)
 	require.Equal(t, 3, status.PendingCount)
 }
 
@@ -634,6 +634,24 @@ func TestProviderOperationLedgerMigrationCreatesTablesAndIndexes(t *testing.T) {
 	require.NotEmpty(t, migration.PostChecks)
 }
 
+func TestCustomerSegmentsMigrationCreatesMembershipTables(t *testing.T) {
+	db := newTestDB(t)
+	migrationIndex := slices.IndexFunc(orderedMigrations, func(migration Migration) bool {
+		return migration.Version == customerSegmentsVersion
+	})
+	require.Greater(t, migrationIndex, 0)
+	require.NoError(t, runWithMigrations(db, orderedMigrations[:migrationIndex]))
+	require.False(t, db.Migrator().HasTable(&models.CustomerSegment{}))
+
+	require.NoError(t, runWithMigrations(db, orderedMigrations[:migrationIndex+1]))
+	require.True(t, db.Migrator().HasTable(&models.CustomerSegment{}))
+	require.True(t, db.Migrator().HasTable(&models.CustomerSegmentMembership{}))
+	for _, column := range []string{"key", "rules_json", "is_active", "member_count", "last_refreshed_at"} {
+		require.True(t, db.Migrator().HasColumn(&models.CustomerSegment{}, column), column)
+	}
+	require.True(t, db.Migrator().HasIndex(&models.CustomerSegmentMembership{}, "idx_customer_segment_memberships_member"))
+}
+
 func TestProductAttributeEnumsMigrationBackfillsExistingValues(t *testing.T) {
 	db := newTestDB(t)
 	t.Setenv(contractGuardEnvVar, "true")
@@ -1241,3 +1259,27 @@ func TestEcommerceCMSFooterBackfillRepairsPublishedEmptyFooter(t *testing.T) {
 	require.False(t, cmsPayloadBlocksEmpty(reloaded.PayloadJSON))
 	require.Contains(t, reloaded.PayloadJSON, `"type":"footer"`)
 }
+
+func TestOrderTimelineLinksProcessedWebhooksToOrders(t *testing.T) {
+	db := newTestDB(t)
+
+	timelineIndex := slices.IndexFunc(orderedMigrations, func(m Migration) bool {
+		return m.Version == orderTimelineVersion
+	})
+	require.NotEqual(t, -1, timelineIndex)
+	require.NoError(t, runWithMigrations(db, orderedMigrations[:timelineIndex]))
+
+	for id, eventID := range []string{"evt_paid", "evt_unrelated"} {
+		require.NoError(t, db.Exec(`INSERT INTO webhook_events (id, created_at, updated_at, provider, provider_event_id, event_type, signature_valid, payload, received_at, attempt_count, last_error)
+			VALUES (?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'dummy-card', ?, 'payment.captured', true, '{}', CURRENT_TIMESTAMP, 1, '')`, id+1, eventID).Error)
+	}
+	require.NoError(t, db.Create(&models.OrderStatusHistory{OrderID: 42, FromStatus: "PENDING", ToStatus: "PAID", Reason: "captured", Source: "webhook", Actor: "webhook", CorrelationID: "webhook:1"}).Error)
+	require.NoError(t, runWithMigrations(db, orderedMigrations[:timelineIndex+1]))
+
+	var events []models.WebhookEvent
+	require.NoError(t, db.Order("id").Find(&events).Error)
+	require.Len(t, events, 2)
+	require.NotNil(t, events[0].OrderID)
+	assert.Equal(t, uint(42), *events[0].OrderID)
+	assert.Nil(t, events[1].OrderID)
+}
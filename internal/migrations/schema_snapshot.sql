@@ -1,3 +1,31 @@
+TABLE abandoned_carts
+  COLUMN attempts
+  COLUMN checkout_session_id
+  COLUMN created_at
+  COLUMN detected_at
+  COLUMN email
+  COLUMN id
+  COLUMN item_count
+  COLUMN items_json
+  COLUMN last_error
+  COLUMN notified_at
+  COLUMN recovered_at
+  COLUMN recovered_order_id
+  COLUMN restore_count
+  COLUMN restored_at
+  COLUMN restored_session_id
+  COLUMN status
+  COLUMN subtotal
+  COLUMN updated_at
+  COLUMN user_id
+  INDEX idx_abandoned_carts_checkout_session_id columns=checkout_session_id unique=true option=
+  INDEX idx_abandoned_carts_detected_at columns=detected_at unique=false option=
+  INDEX idx_abandoned_carts_notified_at columns=notified_at unique=false option=
+  INDEX idx_abandoned_carts_recovered_at columns=recovered_at unique=false option=
+  INDEX idx_abandoned_carts_recovered_order_id columns=recovered_order_id unique=false option=
+  INDEX idx_abandoned_carts_restored_session_id columns=restored_session_id unique=false option=
+  INDEX idx_abandoned_carts_status columns=status unique=false option=
+  INDEX idx_abandoned_carts_user_id columns=user_id unique=false option=
 TABLE brands
   COLUMN created_at
   COLUMN deleted_at
@@ -15,11 +43,16 @@ TABLE cart_items
   COLUMN created_at
   COLUMN deleted_at
   COLUMN id
+  COLUMN locked_price
+  COLUMN locked_quantity
+  COLUMN locked_until
   COLUMN product_id
   COLUMN product_variant_id
   COLUMN quantity
+  COLUMN saved_cart_id
   COLUMN updated_at
   INDEX idx_cart_items_deleted_at columns=deleted_at unique=false option=
+  INDEX idx_cart_items_saved_cart_id columns=saved_cart_id unique=false option=
 TABLE carts
   COLUMN checkout_session_id
   COLUMN created_at
@@ -48,6 +81,23 @@ TABLE categories
   INDEX idx_categories_path columns=path unique=false option=
   INDEX idx_categories_slug columns=slug unique=true option=
   INDEX idx_categories_sort_order columns=sort_order unique=false option=
+TABLE checkout_fields
+  COLUMN active
+  COLUMN channels_json
+  COLUMN created_at
+  COLUMN help_text
+  COLUMN id
+  COLUMN key
+  COLUMN label
+  COLUMN max_length
+  COLUMN options_json
+  COLUMN placeholder
+  COLUMN position
+  COLUMN required
+  COLUMN type
+  COLUMN updated_at
+  INDEX idx_checkout_fields_active columns=active unique=false option=
+  INDEX idx_checkout_fields_key columns=key unique=true option=
 TABLE checkout_provider_settings
   COLUMN created_at
   COLUMN deleted_at
@@ -59,17 +109,24 @@ TABLE checkout_provider_settings
   INDEX idx_checkout_provider_settings_deleted_at columns=deleted_at unique=false option=
   INDEX idx_checkout_provider_settings_type_id columns=provider_type,provider_id unique=true option=
 TABLE checkout_sessions
+  COLUMN checkout_state_json
   COLUMN created_at
   COLUMN deleted_at
   COLUMN expires_at
   COLUMN guest_email
   COLUMN id
+  COLUMN kind
   COLUMN last_seen_at
+  COLUMN merged_at
+  COLUMN merged_into_session_id
+  COLUMN parent_session_id
   COLUMN public_token
   COLUMN status
   COLUMN updated_at
   COLUMN user_id
   INDEX idx_checkout_sessions_deleted_at columns=deleted_at unique=false option=
+  INDEX idx_checkout_sessions_kind columns=kind unique=false option=
+  INDEX idx_checkout_sessions_parent_session_id columns=parent_session_id unique=false option=
   INDEX idx_checkout_sessions_public_token columns=public_token unique=true option=
 TABLE cms_audit_events
   COLUMN action
@@ -414,6 +471,45 @@ TABLE cms_targeting_rules
   INDEX idx_cms_targeting_rules_is_enabled columns=is_enabled unique=false option=
   INDEX idx_cms_targeting_rules_priority columns=priority unique=false option=
   INDEX idx_cms_targeting_rules_version_id columns=version_id unique=false option=
+TABLE customer_segment_memberships
+  COLUMN id
+  COLUMN matched_at
+  COLUMN segment_id
+  COLUMN user_id
+  INDEX idx_customer_segment_memberships_member columns=segment_id,user_id unique=true option=
+  INDEX idx_customer_segment_memberships_user_id columns=user_id unique=false option=
+TABLE customer_segments
+  COLUMN created_at
+  COLUMN created_by
+  COLUMN deleted_at
+  COLUMN description
+  COLUMN id
+  COLUMN is_active
+  COLUMN key
+  COLUMN last_refreshed_at
+  COLUMN member_count
+  COLUMN name
+  COLUMN rules_json
+  COLUMN updated_at
+  COLUMN updated_by
+  INDEX idx_customer_segments_created_by columns=created_by unique=false option=
+  INDEX idx_customer_segments_deleted_at columns=deleted_at unique=false option=
+  INDEX idx_customer_segments_is_active columns=is_active unique=false option=
+  INDEX idx_customer_segments_key columns=key unique=true option=
+  INDEX idx_customer_segments_updated_by columns=updated_by unique=false option=
+TABLE discount_budget_alerts
+  COLUMN budget_amount
+  COLUMN campaign_id
+  COLUMN created_at
+  COLUMN deleted_at
+  COLUMN id
+  COLUMN spent_amount
+  COLUMN threshold_percent
+  COLUMN triggered_at
+  COLUMN updated_at
+  INDEX idx_discount_budget_alerts_deleted_at columns=deleted_at unique=false option=
+  INDEX idx_discount_budget_alerts_threshold columns=campaign_id,threshold_percent,budget_amount unique=true option=
+  INDEX idx_discount_budget_alerts_triggered_at columns=triggered_at unique=false option=
 TABLE discount_campaign_audits
   COLUMN actor
   COLUMN after_json
@@ -433,6 +529,8 @@ TABLE discount_campaign_audits
   INDEX idx_discount_campaign_audits_deleted_at columns=deleted_at unique=false option=
   INDEX idx_discount_campaign_audits_event_type columns=event_type unique=false option=
 TABLE discount_campaigns
+  COLUMN budget_amount
+  COLUMN budget_thresholds_json
   COLUMN channels_json
   COLUMN coupon_code
   COLUMN created_at
@@ -564,6 +662,127 @@ TABLE discount_targets
   INDEX idx_discount_targets_level_id columns=level_id unique=false option=
   INDEX idx_discount_targets_level_lookup columns=level_id,target_type,target_id unique=false option=
   INDEX idx_discount_targets_product_lookup columns=target_type,target_id,campaign_id unique=false option=
+TABLE draft_order_lines
+  COLUMN created_at
+  COLUMN discount_percent
+  COLUMN draft_order_id
+  COLUMN final_unit_price
+  COLUMN id
+  COLUMN list_price
+  COLUMN position
+  COLUMN product_variant_id
+  COLUMN quantity
+  COLUMN unit_price
+  COLUMN updated_at
+  COLUMN variant_sku
+  COLUMN variant_title
+  INDEX idx_draft_order_lines_draft_order_id columns=draft_order_id unique=false option=
+TABLE draft_orders
+  COLUMN cancelled_at
+  COLUMN checkout_session_id
+  COLUMN completed_at
+  COLUMN created_at
+  COLUMN created_by
+  COLUMN currency
+  COLUMN customer_user_id
+  COLUMN guest_email
+  COLUMN id
+  COLUMN invoiced_at
+  COLUMN note
+  COLUMN order_id
+  COLUMN payment_link_expires_at
+  COLUMN payment_method
+  COLUMN payment_reference
+  COLUMN payment_token
+  COLUMN shipping_address_pretty
+  COLUMN shipping_amount
+  COLUMN shipping_data_json
+  COLUMN shipping_provider_id
+  COLUMN status
+  COLUMN subtotal
+  COLUMN tax_amount
+  COLUMN total
+  COLUMN updated_at
+  INDEX idx_draft_orders_checkout_session_id columns=checkout_session_id unique=false option=
+  INDEX idx_draft_orders_customer_user_id columns=customer_user_id unique=false option=
+  INDEX idx_draft_orders_order_id columns=order_id unique=false option=
+  INDEX idx_draft_orders_payment_token columns=payment_token unique=true option=
+  INDEX idx_draft_orders_status columns=status unique=false option=
+TABLE fulfillment_order_lines
+  COLUMN created_at
+  COLUMN fulfillment_order_id
+  COLUMN id
+  COLUMN order_item_id
+  COLUMN product_variant_id
+  COLUMN quantity
+  COLUMN quantity_packed
+  COLUMN quantity_picked
+  COLUMN updated_at
+  COLUMN variant_sku
+  COLUMN variant_title
+  INDEX idx_fulfillment_order_lines_fulfillment_order_id columns=fulfillment_order_id unique=false option=
+  INDEX idx_fulfillment_order_lines_order_item_id columns=order_item_id unique=false option=
+  INDEX idx_fulfillment_order_lines_product_variant_id columns=product_variant_id unique=false option=
+TABLE fulfillment_orders
+  COLUMN cancelled_at
+  COLUMN created_at
+  COLUMN delivered_at
+  COLUMN id
+  COLUMN label_purchased_at
+  COLUMN location_code
+  COLUMN order_id
+  COLUMN packed_at
+  COLUMN packed_by
+  COLUMN packing_note
+  COLUMN pick_list_at
+  COLUMN picked_by
+  COLUMN routing_explanation
+  COLUMN shipped_at
+  COLUMN status
+  COLUMN updated_at
+  INDEX idx_fulfillment_orders_location_code columns=location_code unique=false option=
+  INDEX idx_fulfillment_orders_order_id columns=order_id unique=false option=
+  INDEX idx_fulfillment_orders_status columns=status unique=false option=
+TABLE gift_card_ledger_entries
+  COLUMN actor
+  COLUMN amount
+  COLUMN balance_after
+  COLUMN created_at
+  COLUMN gift_card_id
+  COLUMN id
+  COLUMN idempotency_key
+  COLUMN operation
+  COLUMN order_id
+  COLUMN payment_intent_id
+  COLUMN reason
+  INDEX idx_gift_card_ledger_card_operation_key columns=gift_card_id,operation,idempotency_key unique=true option=
+  INDEX idx_gift_card_ledger_entries_order_id columns=order_id unique=false option=
+  INDEX idx_gift_card_ledger_entries_payment_intent_id columns=payment_intent_id unique=false option=
+TABLE gift_cards
+  COLUMN balance
+  COLUMN code_hash
+  COLUMN code_last4
+  COLUMN created_at
+  COLUMN currency
+  COLUMN expires_at
+  COLUMN id
+  COLUMN initial_amount
+  COLUMN issued_by
+  COLUMN note
+  COLUMN order_id
+  COLUMN order_item_id
+  COLUMN source
+  COLUMN status
+  COLUMN updated_at
+  COLUMN user_id
+  COLUMN version
+  INDEX idx_gift_cards_code_hash columns=code_hash unique=true option=
+  INDEX idx_gift_cards_expires_at columns=expires_at unique=false option=
+  INDEX idx_gift_cards_order_id columns=order_id unique=false option=
+  INDEX idx_gift_cards_order_item_id columns=order_item_id unique=false option=
+  INDEX idx_gift_cards_source columns=source unique=false option=
+  INDEX idx_gift_cards_status columns=status unique=false option=
+  INDEX idx_gift_cards_user_id columns=user_id unique=false option=
 TABLE idempotency_keys
   COLUMN checkout_session_id
   COLUMN correlation_id
@@ -596,11 +815,13 @@ TABLE inventory_adjustments
   COLUMN product_variant_id
   COLUMN quantity_delta
   COLUMN reason_code
+  COLUMN stock_location_id
   COLUMN updated_at
   INDEX idx_inventory_adjustments_deleted_at columns=deleted_at unique=false option=
   INDEX idx_inventory_adjustments_inventory_item_id columns=inventory_item_id unique=false option=
   INDEX idx_inventory_adjustments_product_variant_id columns=product_variant_id unique=false option=
   INDEX idx_inventory_adjustments_reason_code columns=reason_code unique=false option=
+  INDEX idx_inventory_adjustments_stock_location_id columns=stock_location_id unique=false option=
 TABLE inventory_alerts
   COLUMN acked_at
   COLUMN acked_by_id
@@ -641,9 +862,11 @@ TABLE inventory_levels
   COLUMN inventory_item_id
   COLUMN on_hand
   COLUMN reserved
+  COLUMN stock_location_id
   COLUMN updated_at
   INDEX idx_inventory_levels_deleted_at columns=deleted_at unique=false option=
-  INDEX idx_inventory_levels_inventory_item_id columns=inventory_item_id unique=true option=
+  INDEX idx_inventory_levels_inventory_item_id columns=inventory_item_id unique=false option=
+  INDEX idx_inventory_levels_item_location columns=inventory_item_id,stock_location_id unique=true option=
 TABLE inventory_movements
   COLUMN actor_id
   COLUMN actor_type
@@ -656,12 +879,14 @@ TABLE inventory_movements
   COLUMN reason_code
   COLUMN reference_id
   COLUMN reference_type
+  COLUMN stock_location_id
   COLUMN updated_at
   INDEX idx_inventory_movements_deleted_at columns=deleted_at unique=false option=
   INDEX idx_inventory_movements_inventory_item_id columns=inventory_item_id unique=false option=
   INDEX idx_inventory_movements_movement_type columns=movement_type unique=false option=
   INDEX idx_inventory_movements_reference_id columns=reference_id unique=false option=
   INDEX idx_inventory_movements_reference_type columns=reference_type unique=false option=
+  INDEX idx_inventory_movements_stock_location_id columns=stock_location_id unique=false option=
 TABLE inventory_receipt_items
   COLUMN created_at
   COLUMN deleted_at
@@ -684,11 +909,15 @@ TABLE inventory_receipts
   COLUMN notes
   COLUMN purchase_order_id
   COLUMN received_at
+  COLUMN stock_location_id
   COLUMN updated_at
   INDEX idx_inventory_receipts_deleted_at columns=deleted_at unique=false option=
   INDEX idx_inventory_receipts_purchase_order_id columns=purchase_order_id unique=false option=
   INDEX idx_inventory_receipts_received_at columns=received_at unique=false option=
+  INDEX idx_inventory_receipts_stock_location_id columns=stock_location_id unique=false option=
 TABLE inventory_reservations
+  COLUMN allocated_at
+  COLUMN backordered
   COLUMN checkout_session_id
   COLUMN consumed_at
   COLUMN created_at
@@ -705,7 +934,9 @@ TABLE inventory_reservations
   COLUMN quantity
   COLUMN released_at
   COLUMN status
+  COLUMN stock_location_id
   COLUMN updated_at
+  INDEX idx_inventory_reservations_backordered columns=backordered unique=false option=
   INDEX idx_inventory_reservations_checkout_session_id columns=checkout_session_id unique=false option=
   INDEX idx_inventory_reservations_deleted_at columns=deleted_at unique=false option=
   INDEX idx_inventory_reservations_expires_at columns=expires_at unique=false option=
@@ -716,6 +947,7 @@ TABLE inventory_reservations
   INDEX idx_inventory_reservations_owner_type columns=owner_type unique=false option=
   INDEX idx_inventory_reservations_product_variant_id columns=product_variant_id unique=false option=
   INDEX idx_inventory_reservations_status columns=status unique=false option=
+  INDEX idx_inventory_reservations_stock_location_id columns=stock_location_id unique=false option=
 TABLE inventory_thresholds
   COLUMN created_at
   COLUMN deleted_at
@@ -725,6 +957,53 @@ TABLE inventory_thresholds
   COLUMN updated_at
   INDEX idx_inventory_thresholds_deleted_at columns=deleted_at unique=false option=
   INDEX idx_inventory_thresholds_product_variant_id columns=product_variant_id unique=true option=
+TABLE inventory_transfer_items
+  COLUMN created_at
+  COLUMN deleted_at
+  COLUMN id
+  COLUMN inventory_transfer_id
+  COLUMN product_variant_id
+  COLUMN quantity
+  COLUMN updated_at
+  INDEX idx_inventory_transfer_items_deleted_at columns=deleted_at unique=false option=
+  INDEX idx_inventory_transfer_items_inventory_transfer_id columns=inventory_transfer_id unique=false option=
+  INDEX idx_inventory_transfer_items_product_variant_id columns=product_variant_id unique=false option=
+TABLE inventory_transfers
+  COLUMN actor_id
+  COLUMN actor_type
+  COLUMN cancelled_at
+  COLUMN created_at
+  COLUMN deleted_at
+  COLUMN from_location_id
+  COLUMN id
+  COLUMN notes
+  COLUMN received_at
+  COLUMN shipped_at
+  COLUMN status
+  COLUMN to_location_id
+  COLUMN updated_at
+  INDEX idx_inventory_transfers_deleted_at columns=deleted_at unique=false option=
+  INDEX idx_inventory_transfers_from_location_id columns=from_location_id unique=false option=
+  INDEX idx_inventory_transfers_status columns=status unique=false option=
+  INDEX idx_inventory_transfers_to_location_id columns=to_location_id unique=false option=
+TABLE legal_entities
+  COLUMN address
+  COLUMN code
+  COLUMN country
+  COLUMN created_at
+  COLUMN credit_note_prefix
+  COLUMN deleted_at
+  COLUMN id
+  COLUMN invoice_prefix
+  COLUMN is_default
+  COLUMN name
+  COLUMN next_credit_note_number
+  COLUMN next_invoice_number
+  COLUMN tax_id
+  COLUMN updated_at
+  INDEX idx_legal_entities_code columns=code unique=true option=
+  INDEX idx_legal_entities_country columns=country unique=false option=
+  INDEX idx_legal_entities_deleted_at columns=deleted_at unique=false option=
 TABLE media_objects
   COLUMN created_at
   COLUMN id
@@ -768,6 +1047,7 @@ TABLE order_checkout_snapshot_items
   COLUMN variant_title
   INDEX idx_order_checkout_snapshot_items_snapshot_id columns=snapshot_id unique=false option=
 TABLE order_checkout_snapshots
+  COLUMN attributes_json
   COLUMN authorized_at
   COLUMN checkout_session_id
   COLUMN created_at
@@ -791,19 +1071,135 @@ TABLE order_checkout_snapshots
   INDEX idx_order_checkout_snapshots_checkout_session_id columns=checkout_session_id unique=false option=
   INDEX idx_order_checkout_snapshots_expires_at columns=expires_at unique=false option=
   INDEX idx_order_checkout_snapshots_order_id columns=order_id unique=false option=
+TABLE order_documents
+  COLUMN checksum
+  COLUMN created_at
+  COLUMN currency
+  COLUMN fulfillment_order_id
+  COLUMN gift_card_id
+  COLUMN html
+  COLUMN id
+  COLUMN invoice_id
+  COLUMN issued_at
+  COLUMN issued_by
+  COLUMN legal_entity_id
+  COLUMN number
+  COLUMN order_id
+  COLUMN payment_transaction_id
+  COLUMN pdf
+  COLUMN total
+  COLUMN type
+  INDEX idx_order_documents_fulfillment_order_id columns=fulfillment_order_id unique=false option=
+  INDEX idx_order_documents_gift_card_id columns=gift_card_id unique=true option=
+  INDEX idx_order_documents_invoice_id columns=invoice_id unique=false option=
+  INDEX idx_order_documents_issued_at columns=issued_at unique=false option=
+  INDEX idx_order_documents_legal_entity_id columns=legal_entity_id unique=false option=
+  INDEX idx_order_documents_number columns=number unique=false option=
+  INDEX idx_order_documents_order_id columns=order_id unique=false option=
+  INDEX idx_order_documents_payment_transaction_id columns=payment_transaction_id unique=true option=
+  INDEX idx_order_documents_type columns=type unique=false option=
+TABLE order_edit_changes
+  COLUMN actor
+  COLUMN created_at
+  COLUMN id
+  COLUMN kind
+  COLUMN order_edit_id
+  COLUMN summary
+  INDEX idx_order_edit_changes_order_edit_id columns=order_edit_id unique=false option=
+TABLE order_edit_lines
+  COLUMN created_at
+  COLUMN discount_amount
+  COLUMN id
+  COLUMN order_edit_id
+  COLUMN order_item_id
+  COLUMN product_variant_id
+  COLUMN quantity
+  COLUMN unit_price
+  COLUMN updated_at
+  COLUMN variant_sku
+  COLUMN variant_title
+  INDEX idx_order_edit_lines_order_edit_id columns=order_edit_id unique=false option=
+  INDEX idx_order_edit_lines_order_item_id columns=order_item_id unique=false option=
+TABLE order_edits
+  COLUMN actor
+  COLUMN balance_due
+  COLUMN cancelled_at
+  COLUMN committed_at
+  COLUMN created_at
+  COLUMN currency
+  COLUMN discount_amount
+  COLUMN id
+  COLUMN order_id
+  COLUMN original_total
+  COLUMN payment_operation_key
+  COLUMN reason
+  COLUMN shipping_address_pretty
+  COLUMN shipping_amount
+  COLUMN shipping_data_json
+  COLUMN snapshot_id
+  COLUMN status
+  COLUMN subtotal
+  COLUMN tax_amount
+  COLUMN total
+  COLUMN updated_at
+  INDEX idx_order_edits_order_id columns=order_id unique=false option=
+  INDEX idx_order_edits_status columns=status unique=false option=
 TABLE order_items
   COLUMN created_at
   COLUMN deleted_at
   COLUMN id
+  COLUMN inventory_policy
   COLUMN order_id
   COLUMN price
   COLUMN product_id
   COLUMN product_variant_id
+  COLUMN promised_ship_at
   COLUMN quantity
+  COLUMN quantity_cancelled
+  COLUMN quantity_fulfilled
   COLUMN updated_at
   COLUMN variant_sku
   COLUMN variant_title
   INDEX idx_order_items_deleted_at columns=deleted_at unique=false option=
+TABLE order_notes
+  COLUMN author
+  COLUMN body
+  COLUMN created_at
+  COLUMN id
+  COLUMN order_id
+  INDEX idx_order_notes_order_id columns=order_id unique=false option=
+TABLE order_risk_assessments
+  COLUMN action
+  COLUMN card_bin
+  COLUMN client_ip
+  COLUMN created_at
+  COLUMN email
+  COLUMN id
+  COLUMN order_id
+  COLUMN reasons_json
+  COLUMN score
+  COLUMN scorer_id
+  INDEX idx_order_risk_assessments_card_bin columns=card_bin unique=false option=
+  INDEX idx_order_risk_assessments_client_ip columns=client_ip unique=false option=
+  INDEX idx_order_risk_assessments_created_at columns=created_at unique=false option=
+  INDEX idx_order_risk_assessments_email columns=email unique=false option=
+  INDEX idx_order_risk_assessments_order_id columns=order_id unique=false option=
+TABLE order_routing_rules
+  COLUMN active
+  COLUMN created_at
+  COLUMN id
+  COLUMN position
+  COLUMN rule
+  COLUMN updated_at
+  INDEX idx_order_routing_rules_rule columns=rule unique=true option=
+TABLE order_saved_views
+  COLUMN created_at
+  COLUMN filters_json
+  COLUMN id
+  COLUMN name
+  COLUMN owner_user_id
+  COLUMN updated_at
+  INDEX idx_order_saved_views_owner_name columns=owner_user_id,name unique=true option=
 TABLE order_status_histories
   COLUMN actor
   COLUMN correlation_id
@@ -816,6 +1212,14 @@ TABLE order_status_histories
   COLUMN to_status
   INDEX idx_order_status_histories_correlation_id columns=correlation_id unique=false option=
   INDEX idx_order_status_histories_order_id columns=order_id unique=false option=
+TABLE order_tags
+  COLUMN created_at
+  COLUMN created_by
+  COLUMN id
+  COLUMN order_id
+  COLUMN tag
+  INDEX idx_order_tags_order_tag columns=order_id,tag unique=true option=
+  INDEX idx_order_tags_tag columns=tag unique=false option=
 TABLE order_tax_lines
   COLUMN created_at
   COLUMN finalized_at
@@ -842,6 +1246,7 @@ TABLE order_tax_lines
   INDEX idx_order_tax_lines_snapshot_item_id columns=snapshot_item_id unique=false option=
   INDEX idx_order_tax_lines_tax_provider_id columns=tax_provider_id unique=false option=
 TABLE orders
+  COLUMN attributes_json
   COLUMN checkout_session_id
   COLUMN claimed_at
   COLUMN confirmation_token
@@ -850,6 +1255,12 @@ TABLE orders
   COLUMN guest_email
   COLUMN id
   COLUMN payment_method_display
+  COLUMN risk_action
+  COLUMN risk_reasons_json
+  COLUMN risk_review_status
+  COLUMN risk_reviewed_at
+  COLUMN risk_reviewed_by
+  COLUMN risk_score
   COLUMN shipping_address_pretty
   COLUMN status
   COLUMN total
@@ -858,6 +1269,7 @@ TABLE orders
   INDEX idx_orders_checkout_session_id columns=checkout_session_id unique=false option=
   INDEX idx_orders_confirmation_token columns=confirmation_token unique=true option=
   INDEX idx_orders_deleted_at columns=deleted_at unique=false option=
+  INDEX idx_orders_risk_review_status columns=risk_review_status unique=false option=
 TABLE payment_intents
   COLUMN authorized_amount
   COLUMN captured_amount
@@ -1044,17 +1456,26 @@ TABLE product_related_drafts
   INDEX idx_product_related_drafts_product_draft_id columns=product_draft_id unique=false option=
   INDEX idx_product_related_drafts_related_product_id columns=related_product_id unique=false option=
 TABLE product_variant_drafts
+  COLUMN backorder_limit
   COLUMN compare_at_price
   COLUMN created_at
   COLUMN deleted_at
   COLUMN height_cm
   COLUMN id
+  COLUMN inventory_policy
   COLUMN is_deleted
+  COLUMN is_gift_card
   COLUMN is_published
   COLUMN length_cm
+  COLUMN max_quantity
+  COLUMN min_quantity
   COLUMN position
+  COLUMN preorder_release_at
   COLUMN price
   COLUMN product_draft_id
+  COLUMN promised_ship_at
+  COLUMN purchase_limit
+  COLUMN quantity_step
   COLUMN sku
   COLUMN source_product_variant_id
   COLUMN stock
@@ -1092,16 +1513,25 @@ TABLE product_variant_option_values
   INDEX idx_product_variant_option_values_product_variant_id columns=product_variant_id unique=false option=
   INDEX idx_product_variant_option_values_variant_value_unique columns=product_variant_id,product_option_value_id unique=true option=
 TABLE product_variants
+  COLUMN backorder_limit
   COLUMN compare_at_price
   COLUMN created_at
   COLUMN deleted_at
   COLUMN height_cm
   COLUMN id
+  COLUMN inventory_policy
+  COLUMN is_gift_card
   COLUMN is_published
   COLUMN length_cm
+  COLUMN max_quantity
+  COLUMN min_quantity
   COLUMN position
+  COLUMN preorder_release_at
   COLUMN price
   COLUMN product_id
+  COLUMN promised_ship_at
+  COLUMN purchase_limit
+  COLUMN quantity_step
   COLUMN sku
   COLUMN stock
   COLUMN title
@@ -1343,6 +1773,24 @@ TABLE purchase_orders
   INDEX idx_purchase_orders_deleted_at columns=deleted_at unique=false option=
   INDEX idx_purchase_orders_status columns=status unique=false option=
   INDEX idx_purchase_orders_supplier_id columns=supplier_id unique=false option=
+TABLE rate_limit_counters
+  COLUMN bucket_key
+  COLUMN expires_at
+  COLUMN hits
+  COLUMN window_start
+  INDEX idx_rate_limit_counters_expires_at columns=expires_at unique=false option=
+TABLE risk_settings
+  COLUMN created_at
+  COLUMN disposable_domains
+  COLUMN enabled
+  COLUMN external_scorer_id
+  COLUMN high_value_threshold
+  COLUMN id
+  COLUMN reject_score
+  COLUMN review_score
+  COLUMN updated_at
+  COLUMN velocity_limit
+  COLUMN velocity_window_minutes
 TABLE saved_addresses
   COLUMN city
   COLUMN country
@@ -1361,6 +1809,35 @@ TABLE saved_addresses
   COLUMN user_id
   INDEX idx_saved_addresses_deleted_at columns=deleted_at unique=false option=
   INDEX idx_saved_addresses_user_id columns=user_id unique=false option=
+TABLE saved_cart_items
+  COLUMN created_at
+  COLUMN id
+  COLUMN position
+  COLUMN product_variant_id
+  COLUMN quantity
+  COLUMN saved_cart_id
+  COLUMN unit_price
+  INDEX idx_saved_cart_items_saved_cart_id columns=saved_cart_id unique=false option=
+TABLE saved_carts
+  COLUMN conversion_count
+  COLUMN created_at
+  COLUMN created_by
+  COLUMN customer_user_id
+  COLUMN expires_at
+  COLUMN id
+  COLUMN last_converted_at
+  COLUMN lock_prices
+  COLUMN name
+  COLUMN note
+  COLUMN owner_user_id
+  COLUMN share_token
+  COLUMN status
+  COLUMN updated_at
+  INDEX idx_saved_carts_customer_user_id columns=customer_user_id unique=false option=
+  INDEX idx_saved_carts_expires_at columns=expires_at unique=false option=
+  INDEX idx_saved_carts_owner_user_id columns=owner_user_id unique=false option=
+  INDEX idx_saved_carts_share_token columns=share_token unique=true option=
+  INDEX idx_saved_carts_status columns=status unique=false option=
 TABLE saved_payment_methods
   COLUMN brand
   COLUMN cardholder_name
@@ -1407,6 +1884,14 @@ TABLE seo_metadata
   INDEX idx_seo_entity columns=entity_type,entity_id unique=true option=
   INDEX idx_seo_metadata_canonical_path columns=canonical_path unique=true option=
   INDEX idx_seo_metadata_deleted_at columns=deleted_at unique=false option=
+TABLE shipment_package_lines
+  COLUMN created_at
+  COLUMN fulfillment_order_line_id
+  COLUMN id
+  COLUMN quantity
+  COLUMN shipment_package_id
+  INDEX idx_shipment_package_lines_fulfillment_order_line_id columns=fulfillment_order_line_id unique=false option=
+  INDEX idx_shipment_package_lines_shipment_package_id columns=shipment_package_id unique=false option=
 TABLE shipment_packages
   COLUMN created_at
   COLUMN height_cm
@@ -1443,6 +1928,7 @@ TABLE shipments
   COLUMN currency
   COLUMN delivered_at
   COLUMN finalized_at
+  COLUMN fulfillment_order_id
   COLUMN id
   COLUMN label_url
   COLUMN order_id
@@ -1459,12 +1945,29 @@ TABLE shipments
   COLUMN tracking_url
   COLUMN updated_at
   INDEX idx_shipments_finalized_at columns=finalized_at unique=false option=
+  INDEX idx_shipments_fulfillment_order_id columns=fulfillment_order_id unique=false option=
   INDEX idx_shipments_order_id columns=order_id unique=false option=
   INDEX idx_shipments_provider columns=provider unique=false option=
   INDEX idx_shipments_provider_shipment_id columns=provider_shipment_id unique=false option=
   INDEX idx_shipments_shipment_rate_id columns=shipment_rate_id unique=true option=
   INDEX idx_shipments_snapshot_id columns=snapshot_id unique=false option=
   INDEX idx_shipments_status columns=status unique=false option=
+TABLE stock_locations
+  COLUMN active
+  COLUMN code
+  COLUMN country
+  COLUMN created_at
+  COLUMN deleted_at
+  COLUMN id
+  COLUMN kind
+  COLUMN name
+  COLUMN position
+  COLUMN sellable
+  COLUMN state
+  COLUMN updated_at
+  INDEX idx_stock_locations_active columns=active unique=false option=
+  INDEX idx_stock_locations_code columns=code unique=true option=
+  INDEX idx_stock_locations_deleted_at columns=deleted_at unique=false option=
 TABLE suppliers
   COLUMN created_at
   COLUMN deleted_at
@@ -1537,6 +2040,7 @@ TABLE webhook_events
   COLUMN event_type
   COLUMN id
   COLUMN last_error
+  COLUMN order_id
   COLUMN payload
   COLUMN processed_at
   COLUMN provider
@@ -1545,6 +2049,7 @@ TABLE webhook_events
   COLUMN signature_valid
   COLUMN updated_at
   INDEX idx_webhook_events_event_type columns=event_type unique=false option=
+  INDEX idx_webhook_events_order_id columns=order_id unique=false option=
   INDEX idx_webhook_events_processed_at columns=processed_at unique=false option=
   INDEX idx_webhook_events_provider_event columns=provider,provider_event_id unique=true option=
   INDEX idx_webhook_events_received_at columns=received_at unique=false option=
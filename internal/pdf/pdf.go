@@ -0,0 +1,206 @@
+// Package pdf writes plain text documents, such as invoices and packing
+// slips, as PDF. It only knows the standard Helvetica fonts, which every
+// viewer ships, so no font files or external renderer are needed.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 in points, with the margin kept clear on every side.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+	Margin     = 50.0
+
+	// ContentWidth is the width left between the margins.
+	ContentWidth = PageWidth - 2*Margin
+
+	lineSpacing = 1.35
+)
+
+// Cell is one column of a Row. X is measured from the left margin; for a
+// right-aligned cell it is where the text ends.
+type Cell struct {
+	X          float64
+	Text       string
+	AlignRight bool
+}
+
+// Document lays text out top to bottom, starting a new page whenever the
+// current one is full.
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+// Text writes text at the left margin, wrapping it to the content width.
+// Newlines start new lines.
+func (d *Document) Text(text string, size float64, bold bool) {
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrap(paragraph, size, ContentWidth) {
+			d.Row(size, bold, Cell{Text: line})
+		}
+	}
+}
+
+// Row writes cells on a single line. Cells are not wrapped.
+func (d *Document) Row(size float64, bold bool, cells ...Cell) {
+	d.reserve(size * lineSpacing)
+	d.y -= size
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	page := d.pages[len(d.pages)-1]
+	for _, cell := range cells {
+		if cell.Text == "" {
+			continue
+		}
+		x := Margin + cell.X
+		if cell.AlignRight {
+			x -= textWidth(cell.Text, size)
+		}
+		fmt.Fprintf(page, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, number(size), number(x), number(d.y), escape(cell.Text))
+	}
+	d.y -= size * (lineSpacing - 1)
+}
+
+// Rule draws a thin line across the content width.
+func (d *Document) Rule() {
+	d.reserve(8)
+	d.y -= 4
+	fmt.Fprintf(d.pages[len(d.pages)-1], "0.5 w %s %s m %s %s l S\n", number(Margin), number(d.y), number(PageWidth-Margin), number(d.y))
+	d.y -= 4
+}
+
+// Space leaves points of vertical space, or ends the page if less is left.
+func (d *Document) Space(points float64) {
+	if d.y-points < Margin {
+		d.newPage()
+		return
+	}
+	d.y -= points
+}
+
+// Bytes returns the finished PDF. The output depends only on what was
+// written, so rendering the same document twice gives the same bytes.
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page then takes
+	// a page object followed by its content stream.
+	kids := make([]string, len(d.pages))
+	for index := range d.pages {
+		kids[index] = fmt.Sprintf("%d 0 R", 5+2*index)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for index, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			number(PageWidth), number(PageHeight), 6+2*index))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = PageHeight - Margin
+}
+
+func (d *Document) reserve(height float64) {
+	if d.y-height < Margin {
+		d.newPage()
+	}
+}
+
+func number(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
+
+// escape encodes text as WinAnsi for a PDF string literal. Characters the
+// encoding lacks print as "?".
+func escape(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			out.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&out, "\\%03o", r)
+		case r == '\t':
+			out.WriteByte(' ')
+		default:
+			out.WriteByte('?')
+		}
+	}
+	return out.String()
+}
+
+// helveticaWidths are the advance widths of printable ASCII in Helvetica, in
+// thousandths of the font size. Helvetica-Bold is close enough for aligning
+// figures, whose digits are the same width in both.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+func textWidth(text string, size float64) float64 {
+	total := 0
+	for _, r := range text {
+		if r >= 0x20 && r < 0x7f {
+			total += helveticaWidths[r-0x20]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+func wrap(text string, size, width float64) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	var lines []string
+	line := words[0]
+	for _, word := range words[1:] {
+		if textWidth(line+" "+word, size) > width {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line += " " + word
+	}
+	return append(lines, line)
+}
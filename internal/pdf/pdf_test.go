@@ -0,0 +1,42 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentWritesValidCrossReferenceTable(t *testing.T) {
+	doc := New()
+	doc.Text("Invoice", 20, true)
+	for i := 0; i < 120; i++ {
+		doc.Row(10, false, Cell{Text: fmt.Sprintf("Line %d (widget)", i)}, Cell{X: ContentWidth, Text: "10.00", AlignRight: true})
+	}
+	out := doc.Bytes()
+	assert.Equal(t, out, doc.Bytes(), "rendering is deterministic")
+	assert.Contains(t, string(out), "/Count 3", "rows past the bottom margin start a new page")
+	assert.Contains(t, string(out), `(Line 0 \(widget\)) Tj`)
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(out)
+	require.NotNil(t, match)
+	xref, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	require.Len(t, entries, 4+2*3)
+	for index, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj", index+1))), "object %d", index+1)
+	}
+}
+
+func TestEscapeEncodesWinAnsi(t *testing.T) {
+	assert.Equal(t, `Caf\351 \(r\351sum\351\) ?`, escape("Café (résumé) €"))
+	assert.Equal(t, []string{"one two", "three"}, wrap("one two three", 10, textWidth("one two", 10)))
+}
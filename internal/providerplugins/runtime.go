@@ -17,6 +17,7 @@ import (
 	"ecommerce/internal/checkoutplugins"
 	"ecommerce/internal/providercontext"
 	paymentservice "ecommerce/internal/services/payments"
+	riskservice "ecommerce/internal/services/risk"
 	shippingservice "ecommerce/internal/services/shipping"
 	taxservice "ecommerce/internal/services/tax"
 	"ecommerce/models"
@@ -62,6 +63,7 @@ type LoadedRegistries struct {
 	PaymentProviders  paymentservice.ProviderRegistry
 	ShippingProviders shippingservice.ProviderRegistry
 	TaxProviders      taxservice.ProviderRegistry
+	RiskScorers       riskservice.ScorerRegistry
 }
 
 type mergedPaymentRegistry struct {
@@ -79,6 +81,8 @@ type mergedTaxRegistry struct {
 	providers map[string]taxservice.TaxProvider
 }
 
+type riskScorerRegistry map[string]riskservice.Scorer
+
 type externalRunner struct {
 	providerID   string
 	providerType checkoutplugins.ProviderType
@@ -143,6 +147,10 @@ type taxProvider struct {
 	runner externalRunner
 }
 
+type riskScorer struct {
+	runner externalRunner
+}
+
 func LoadRegistriesFromDir(
 	dir string,
 	basePayments paymentservice.ProviderRegistry,
@@ -155,6 +163,7 @@ func LoadRegistriesFromDir(
 			PaymentProviders:  basePayments,
 			ShippingProviders: baseShippings,
 			TaxProviders:      baseTaxes,
+			RiskScorers:       riskScorerRegistry{},
 		}, nil
 	}
 
@@ -166,6 +175,7 @@ func LoadRegistriesFromDir(
 	payments := make(map[string]paymentservice.PaymentProvider)
 	shippings := make(map[string]shippingservice.ShippingProvider)
 	taxes := make(map[string]taxservice.TaxProvider)
+	scorers := make(riskScorerRegistry)
 
 	for _, manifest := range manifests {
 		runner := externalRunnerFromManifest(manifest)
@@ -185,6 +195,11 @@ func LoadRegistriesFromDir(
 				return LoadedRegistries{}, err
 			}
 			taxes[manifest.ID] = taxProvider{runner: runner}
+		case checkoutplugins.ProviderTypeRisk:
+			if _, exists := scorers[manifest.ID]; exists {
+				return LoadedRegistries{}, fmt.Errorf("risk scorer %q is already registered", manifest.ID)
+			}
+			scorers[manifest.ID] = riskScorer{runner: runner}
 		default:
 			return LoadedRegistries{}, fmt.Errorf("unsupported provider type %q", manifest.Type)
 		}
@@ -195,6 +210,7 @@ func LoadRegistriesFromDir(
 		PaymentProviders:  mergedPaymentRegistry{base: basePayments, providers: payments},
 		ShippingProviders: mergedShippingRegistry{base: baseShippings, providers: shippings},
 		TaxProviders:      mergedTaxRegistry{base: baseTaxes, providers: taxes},
+		RiskScorers:       scorers,
 	}, nil
 }
 
@@ -245,7 +261,8 @@ func readManifest(path string) (Manifest, error) {
 	}
 	if manifest.Type != checkoutplugins.ProviderTypePayment &&
 		manifest.Type != checkoutplugins.ProviderTypeShipping &&
-		manifest.Type != checkoutplugins.ProviderTypeTax {
+		manifest.Type != checkoutplugins.ProviderTypeTax &&
+		manifest.Type != checkoutplugins.ProviderTypeRisk {
 		return Manifest{}, fmt.Errorf("invalid provider plugin manifest %s: unsupported type %q", path, manifest.Type)
 	}
 
@@ -589,6 +606,21 @@ func (p taxProvider) ExportReport(ctx context.Context, req taxservice.ExportRepo
 	return io.NopCloser(strings.NewReader(response.Content)), nil
 }
 
+func (r riskScorerRegistry) Scorer(id string) (riskservice.Scorer, error) {
+	if scorer, ok := r[strings.TrimSpace(id)]; ok {
+		return scorer, nil
+	}
+	return nil, riskservice.ErrUnknownScorer
+}
+
+func (s riskScorer) Score(ctx context.Context, input riskservice.Input) ([]riskservice.Signal, error) {
+	var response struct {
+		Signals []riskservice.Signal `json:"signals"`
+	}
+	err := s.runner.run(ctx, "risk.score", input, &response)
+	return response.Signals, err
+}
+
 func requireOperationIdentity(idempotencyKey, operationKey string) error {
 	if strings.TrimSpace(idempotencyKey) == "" {
 		return fmt.Errorf("idempotency key is required")
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// DatabaseStore keeps counters in the rate_limit_counters table so every API
+// replica draws from the same budget. Each hit is a single upsert; expired
+// rows are removed by Cleanup.
+type DatabaseStore struct {
+	db *gorm.DB
+}
+
+func NewDatabaseStore(db *gorm.DB) *DatabaseStore {
+	return &DatabaseStore{db: db}
+}
+
+func (s *DatabaseStore) Take(ctx context.Context, key string, rule Rule, now time.Time) (Decision, error) {
+	if !rule.Enabled() {
+		return Decision{}, ErrInvalidRule
+	}
+	start := rule.windowStart(now)
+	var hits int
+	err := s.db.WithContext(ctx).Raw(
+		`INSERT INTO rate_limit_counters (bucket_key, window_start, hits, expires_at) VALUES (?, ?, 1, ?)
+		ON CONFLICT (bucket_key, window_start) DO UPDATE SET hits = rate_limit_counters.hits + 1
+		RETURNING hits`,
+		counterKey(key), start, start.Add(rule.Window),
+	).Scan(&hits).Error
+	if err != nil {
+		return Decision{}, err
+	}
+	return decide(rule, hits, start), nil
+}
+
+// Cleanup deletes counters whose window has closed.
+func (s *DatabaseStore) Cleanup(ctx context.Context, now time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", now.UTC()).Delete(&models.RateLimitCounter{})
+	return result.RowsAffected, result.Error
+}
+
+func counterKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
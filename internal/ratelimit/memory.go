@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memorySweepInterval = time.Minute
+
+type memoryCounter struct {
+	windowStart time.Time
+	hits        int
+	expiresAt   time.Time
+}
+
+// MemoryStore keeps counters in process memory. Limits are per replica, so it
+// suits single-instance deployments and tests. Expired counters are swept
+// periodically, keeping the map bounded by the keys seen in open windows.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*memoryCounter
+	nextSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]*memoryCounter)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, rule Rule, now time.Time) (Decision, error) {
+	if !rule.Enabled() {
+		return Decision{}, ErrInvalidRule
+	}
+	start := rule.windowStart(now)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !now.Before(s.nextSweep) {
+		for counterKey, counter := range s.counters {
+			if !now.Before(counter.expiresAt) {
+				delete(s.counters, counterKey)
+			}
+		}
+		s.nextSweep = now.Add(memorySweepInterval)
+	}
+	counter, ok := s.counters[key]
+	if !ok || !counter.windowStart.Equal(start) {
+		counter = &memoryCounter{windowStart: start, expiresAt: start.Add(rule.Window)}
+		s.counters[key] = counter
+	}
+	if counter.hits <= rule.Limit {
+		counter.hits++
+	}
+	return decide(rule, counter.hits, start), nil
+}
+
+// Len reports how many counters are currently held.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.counters)
+}
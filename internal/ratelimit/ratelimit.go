@@ -0,0 +1,74 @@
+// Package ratelimit provides fixed-window request counters behind a Store
+// interface so limits can be kept per process or shared across API replicas.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("invalid rate limit rule")
+
+// Rule allows Limit hits per Window for one key.
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+func (r Rule) Enabled() bool {
+	return r.Limit > 0 && r.Window > 0
+}
+
+// windowStart aligns now to the rule's window so every replica agrees on which
+// counter a hit belongs to without coordination.
+func (r Rule) windowStart(now time.Time) time.Time {
+	return now.UTC().Truncate(r.Window)
+}
+
+// Decision is the outcome of counting one hit against a rule.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+// RetryAfter is the time until the current window closes, rounded up to whole
+// seconds as required by the RateLimit-Reset and Retry-After headers.
+func (d Decision) RetryAfter(now time.Time) time.Duration {
+	wait := d.ResetAt.Sub(now)
+	if wait <= 0 {
+		return 0
+	}
+	return (wait + time.Second - 1).Truncate(time.Second)
+}
+
+// Store counts one hit for key under rule and reports whether it fits.
+// Implementations must be safe for concurrent use and return ErrInvalidRule
+// for rules that are not Enabled.
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule, now time.Time) (Decision, error)
+}
+
+func decide(rule Rule, hits int, windowStart time.Time) Decision {
+	remaining := rule.Limit - hits
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Decision{Allowed: hits <= rule.Limit, Limit: rule.Limit, Remaining: remaining, ResetAt: windowStart.Add(rule.Window)}
+}
+
+// WriteHeaders sets the IETF RateLimit-* fields, and Retry-After when the hit
+// was rejected.
+func WriteHeaders(header http.Header, decision Decision, now time.Time) {
+	reset := strconv.FormatInt(int64(decision.RetryAfter(now)/time.Second), 10)
+	header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	header.Set("RateLimit-Reset", reset)
+	if !decision.Allowed {
+		header.Set("Retry-After", reset)
+	}
+}
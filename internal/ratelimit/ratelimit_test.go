@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestStoresShareFixedWindowBudgets(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.RateLimitCounter{}))
+
+	stores := map[string][2]Store{
+		"memory": {NewMemoryStore(), nil},
+		// Two database stores stand in for two API replicas.
+		"database": {NewDatabaseStore(db), NewDatabaseStore(db)},
+	}
+	rule := Rule{Limit: 2, Window: time.Minute}
+	now := time.Date(2026, 8, 19, 10, 0, 15, 0, time.UTC)
+	for name, pair := range stores {
+		t.Run(name, func(t *testing.T) {
+			first, second := pair[0], pair[1]
+			if second == nil {
+				second = first
+			}
+			ctx := context.Background()
+			_, err := first.Take(ctx, "key", Rule{}, now)
+			assert.ErrorIs(t, err, ErrInvalidRule)
+
+			decision, err := first.Take(ctx, "checkout|client:1", rule, now)
+			require.NoError(t, err)
+			assert.Equal(t, Decision{Allowed: true, Limit: 2, Remaining: 1, ResetAt: now.Truncate(time.Minute).Add(time.Minute)}, decision)
+			decision, err = second.Take(ctx, "checkout|client:1", rule, now.Add(time.Second))
+			require.NoError(t, err)
+			assert.True(t, decision.Allowed)
+			assert.Zero(t, decision.Remaining)
+			decision, err = first.Take(ctx, "checkout|client:1", rule, now.Add(2*time.Second))
+			require.NoError(t, err)
+			assert.False(t, decision.Allowed)
+			assert.Equal(t, 43*time.Second, decision.RetryAfter(now.Add(2*time.Second)))
+
+			decision, err = second.Take(ctx, "checkout|client:2", rule, now)
+			require.NoError(t, err)
+			assert.True(t, decision.Allowed)
+
+			decision, err = second.Take(ctx, "checkout|client:1", rule, now.Add(45*time.Second))
+			require.NoError(t, err)
+			assert.True(t, decision.Allowed)
+			assert.Equal(t, 1, decision.Remaining)
+		})
+	}
+
+	removed, err := NewDatabaseStore(db).Cleanup(context.Background(), now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, removed)
+}
+
+func TestMemoryStoreSweepsClosedWindows(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2026, 8, 19, 10, 0, 0, 0, time.UTC)
+	for _, key := range []string{"a", "b", "c"} {
+		_, err := store.Take(context.Background(), key, Rule{Limit: 1, Window: time.Second}, now)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, store.Len())
+	_, err := store.Take(context.Background(), "d", Rule{Limit: 1, Window: time.Second}, now.Add(memorySweepInterval))
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestWriteHeadersReportsRemainingBudget(t *testing.T) {
+	now := time.Date(2026, 8, 19, 10, 0, 0, 500_000_000, time.UTC)
+	header := http.Header{}
+	WriteHeaders(header, Decision{Allowed: true, Limit: 6, Remaining: 4, ResetAt: now.Add(1500 * time.Millisecond)}, now)
+	assert.Equal(t, "6", header.Get("RateLimit-Limit"))
+	assert.Equal(t, "4", header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", header.Get("RateLimit-Reset"))
+	assert.Empty(t, header.Get("Retry-After"))
+
+	WriteHeaders(header, Decision{Limit: 6, ResetAt: now.Add(30 * time.Second)}, now)
+	assert.Equal(t, "0", header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", header.Get("Retry-After"))
+}
@@ -43,6 +43,7 @@ type Metadata struct {
 	OperationID   string
 	Method        string
 	Path          string
+	ClientIP      string
 	StartedAt     time.Time
 	Cookies       map[string]string
 	Headers       map[string]string
@@ -137,19 +137,29 @@ func LoadLiveProductUpsertInput(db *gorm.DB, mediaService *media.Service, produc
 
 		position := variant.Position
 		isPublished := variant.IsPublished
+		minQuantity, quantityStep, inventoryPolicy, isGiftCard := variant.MinQuantity, variant.QuantityStep, variant.InventoryPolicy, variant.IsGiftCard
 		variantInput := apicontract.ProductVariantInput{
-			CompareAtPrice: moneyFloatPtr(variant.CompareAtPrice),
-			HeightCm:       variant.HeightCm,
-			IsPublished:    &isPublished,
-			LengthCm:       variant.LengthCm,
-			Position:       &position,
-			Price:          variant.Price.Float64(),
-			Selections:     make([]apicontract.ProductVariantSelectionInput, 0, len(variant.OptionValueLinks)),
-			Sku:            variant.SKU,
-			Stock:          variant.Stock,
-			Title:          variant.Title,
-			WeightGrams:    variant.WeightGrams,
-			WidthCm:        variant.WidthCm,
+			BackorderLimit:    variant.BackorderLimit,
+			CompareAtPrice:    moneyFloatPtr(variant.CompareAtPrice),
+			HeightCm:          variant.HeightCm,
+			InventoryPolicy:   &inventoryPolicy,
+			IsGiftCard:        &isGiftCard,
+			IsPublished:       &isPublished,
+			LengthCm:          variant.LengthCm,
+			MaxQuantity:       variant.MaxQuantity,
+			MinQuantity:       &minQuantity,
+			Position:          &position,
+			PreorderReleaseAt: variant.PreorderReleaseAt,
+			Price:             variant.Price.Float64(),
+			PromisedShipAt:    variant.PromisedShipAt,
+			PurchaseLimit:     variant.PurchaseLimit,
+			QuantityStep:      &quantityStep,
+			Selections:        make([]apicontract.ProductVariantSelectionInput, 0, len(variant.OptionValueLinks)),
+			Sku:               variant.SKU,
+			Stock:             variant.Stock,
+			Title:             variant.Title,
+			WeightGrams:       variant.WeightGrams,
+			WidthCm:           variant.WidthCm,
 		}
 
 		for _, link := range variant.OptionValueLinks {
@@ -245,20 +255,40 @@ func ProductContractToUpsertInput(product apicontract.Product) apicontract.Produ
 
 	for _, variant := range product.Variants {
 		position := variant.Position
-		isPublished := variant.IsPublished
+		isPublished, isGiftCard := variant.IsPublished, variant.IsGiftCard
 		entry := apicontract.ProductVariantInput{
-			CompareAtPrice: variant.CompareAtPrice,
-			HeightCm:       variant.HeightCm,
-			IsPublished:    &isPublished,
-			LengthCm:       variant.LengthCm,
-			Position:       &position,
-			Price:          variant.Price,
-			Selections:     make([]apicontract.ProductVariantSelectionInput, 0, len(variant.Selections)),
-			Sku:            variant.Sku,
-			Stock:          variant.Stock,
-			Title:          variant.Title,
-			WeightGrams:    variant.WeightGrams,
-			WidthCm:        variant.WidthCm,
+			BackorderLimit:    variant.BackorderLimit,
+			CompareAtPrice:    variant.CompareAtPrice,
+			HeightCm:          variant.HeightCm,
+			IsGiftCard:        &isGiftCard,
+			IsPublished:       &isPublished,
+			LengthCm:          variant.LengthCm,
+			MaxQuantity:       variant.MaxQuantity,
+			Position:          &position,
+			PreorderReleaseAt: variant.PreorderReleaseAt,
+			Price:             variant.Price,
+			PromisedShipAt:    variant.PromisedShipAt,
+			PurchaseLimit:     variant.PurchaseLimit,
+			Selections:        make([]apicontract.ProductVariantSelectionInput, 0, len(variant.Selections)),
+			Sku:               variant.Sku,
+			Stock:             variant.Stock,
+			Title:             variant.Title,
+			WeightGrams:       variant.WeightGrams,
+			WidthCm:           variant.WidthCm,
+		}
+		// Exports written before quantity rules existed carry zero here; leave
+		// them unset so the import falls back to the defaults.
+		if variant.MinQuantity > 0 {
+			minQuantity := variant.MinQuantity
+			entry.MinQuantity = &minQuantity
+		}
+		if variant.QuantityStep > 0 {
+			quantityStep := variant.QuantityStep
+			entry.QuantityStep = &quantityStep
+		}
+		if variant.InventoryPolicy != "" {
+			inventoryPolicy := variant.InventoryPolicy
+			entry.InventoryPolicy = &inventoryPolicy
 		}
 		for _, selection := range variant.Selections {
 			selectionPosition := selection.Position
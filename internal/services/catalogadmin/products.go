@@ -34,7 +34,7 @@ func (s *Service) GetProduct(ctx context.Context, id uint, draft bool) (models.P
 	product.Variants = make([]models.ProductVariant, 0, len(value.VariantDrafts))
 	for _, item := range value.VariantDrafts {
 		if !item.IsDeleted {
-			product.Variants = append(product.Variants, models.ProductVariant{BaseModel: item.BaseModel, ProductID: id, SKU: item.SKU, Title: item.Title, Price: item.Price, CompareAtPrice: item.CompareAtPrice, Stock: item.Stock, Position: item.Position, IsPublished: item.IsPublished, WeightGrams: item.WeightGrams, LengthCm: item.LengthCm, WidthCm: item.WidthCm, HeightCm: item.HeightCm})
+			product.Variants = append(product.Variants, models.ProductVariant{BaseModel: item.BaseModel, ProductID: id, SKU: item.SKU, Title: item.Title, Price: item.Price, CompareAtPrice: item.CompareAtPrice, Stock: item.Stock, Position: item.Position, IsPublished: item.IsPublished, WeightGrams: item.WeightGrams, LengthCm: item.LengthCm, WidthCm: item.WidthCm, HeightCm: item.HeightCm, MinQuantity: item.MinQuantity, MaxQuantity: item.MaxQuantity, QuantityStep: item.QuantityStep, PurchaseLimit: item.PurchaseLimit, InventoryPolicy: item.InventoryPolicy, BackorderLimit: item.BackorderLimit, PreorderReleaseAt: item.PreorderReleaseAt, PromisedShipAt: item.PromisedShipAt, IsGiftCard: item.IsGiftCard})
 		}
 	}
 	categoryIDs := make([]uint, 0, len(value.CategoryDrafts))
@@ -136,6 +136,12 @@ func validateProductInput(input apicontract.ProductUpsertInput) error {
 		if value.Price < 0 || value.Stock < 0 {
 			return invalidInput("invalid_product_variant", "Variant price and stock cannot be negative.")
 		}
+		if err := validateVariantQuantityRules(value); err != nil {
+			return err
+		}
+		if err := validateVariantInventoryPolicy(value); err != nil {
+			return err
+		}
 		if _, ok := seen[sku]; ok {
 			return invalidInput("invalid_product_variant", "Variant SKUs must be unique.")
 		}
@@ -143,6 +149,44 @@ func validateProductInput(input apicontract.ProductUpsertInput) error {
 	}
 	return nil
 }
+func validateVariantQuantityRules(value apicontract.ProductVariantInput) error {
+	minimum, step := 1, 1
+	if value.MinQuantity != nil {
+		minimum = *value.MinQuantity
+	}
+	if value.QuantityStep != nil {
+		step = *value.QuantityStep
+	}
+	if minimum < 1 || step < 1 {
+		return invalidInput("invalid_product_variant", "Variant minimum quantity and quantity step must be at least 1.")
+	}
+	if value.MaxQuantity != nil && *value.MaxQuantity < minimum {
+		return invalidInput("invalid_product_variant", "Variant maximum quantity cannot be below the minimum quantity.")
+	}
+	if value.PurchaseLimit != nil && *value.PurchaseLimit < 1 {
+		return invalidInput("invalid_product_variant", "Variant purchase limit must be at least 1.")
+	}
+	return nil
+}
+func validateVariantInventoryPolicy(value apicontract.ProductVariantInput) error {
+	policy := variantInventoryPolicy(value)
+	if !models.IsValidInventoryPolicy(policy) {
+		return invalidInput("invalid_product_variant", "Variant inventory policy must be DENY, BACKORDER or PREORDER.")
+	}
+	if policy == models.InventoryPolicyPreorder && value.PreorderReleaseAt == nil {
+		return invalidInput("invalid_product_variant", "Pre-order variants need a release date.")
+	}
+	if value.BackorderLimit != nil && *value.BackorderLimit < 1 {
+		return invalidInput("invalid_product_variant", "Variant backorder limit must be at least 1.")
+	}
+	return nil
+}
+func variantInventoryPolicy(value apicontract.ProductVariantInput) string {
+	if value.InventoryPolicy == nil || strings.TrimSpace(*value.InventoryPolicy) == "" {
+		return models.InventoryPolicyDeny
+	}
+	return strings.ToUpper(strings.TrimSpace(*value.InventoryPolicy))
+}
 func productSummary(input apicontract.ProductUpsertInput) (float64, int) {
 	value := input.Variants[0]
 	if input.DefaultVariantSku != nil {
@@ -195,7 +239,13 @@ func replaceDraft(tx *gorm.DB, product models.Product, input apicontract.Product
 			value := models.MoneyFromFloat(*item.CompareAtPrice)
 			compare = &value
 		}
-		value := models.ProductVariantDraft{ProductDraftID: draft.ID, SKU: strings.TrimSpace(item.Sku), Title: strings.TrimSpace(item.Title), Price: models.MoneyFromFloat(item.Price), CompareAtPrice: compare, Stock: item.Stock, Position: position, IsPublished: published, WeightGrams: item.WeightGrams, LengthCm: item.LengthCm, WidthCm: item.WidthCm, HeightCm: item.HeightCm}
+		value := models.ProductVariantDraft{ProductDraftID: draft.ID, SKU: strings.TrimSpace(item.Sku), Title: strings.TrimSpace(item.Title), Price: models.MoneyFromFloat(item.Price), CompareAtPrice: compare, Stock: item.Stock, Position: position, IsPublished: published, WeightGrams: item.WeightGrams, LengthCm: item.LengthCm, WidthCm: item.WidthCm, HeightCm: item.HeightCm, MinQuantity: 1, MaxQuantity: item.MaxQuantity, QuantityStep: 1, PurchaseLimit: item.PurchaseLimit, InventoryPolicy: variantInventoryPolicy(item), BackorderLimit: item.BackorderLimit, PreorderReleaseAt: item.PreorderReleaseAt, PromisedShipAt: item.PromisedShipAt, IsGiftCard: item.IsGiftCard != nil && *item.IsGiftCard}
+		if item.MinQuantity != nil {
+			value.MinQuantity = *item.MinQuantity
+		}
+		if item.QuantityStep != nil {
+			value.QuantityStep = *item.QuantityStep
+		}
 		if err := tx.Select("*").Create(&value).Error; err != nil {
 			return err
 		}
@@ -274,6 +324,9 @@ func (s *Service) PublishProduct(ctx context.Context, id uint) (models.Product,
 			value.Title, value.Price, value.CompareAtPrice, value.Stock = item.Title, item.Price, item.CompareAtPrice, item.Stock
 			value.Position, value.IsPublished = item.Position, item.IsPublished
 			value.WeightGrams, value.LengthCm, value.WidthCm, value.HeightCm = item.WeightGrams, item.LengthCm, item.WidthCm, item.HeightCm
+			value.MinQuantity, value.MaxQuantity, value.QuantityStep, value.PurchaseLimit = item.MinQuantity, item.MaxQuantity, item.QuantityStep, item.PurchaseLimit
+			value.InventoryPolicy, value.BackorderLimit, value.PreorderReleaseAt, value.PromisedShipAt = item.InventoryPolicy, item.BackorderLimit, item.PreorderReleaseAt, item.PromisedShipAt
+			value.IsGiftCard = item.IsGiftCard
 			if exists {
 				if err := tx.Select("*").Save(value).Error; err != nil {
 					return err
@@ -0,0 +1,508 @@
+package checkout
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DefaultAbandonedCartIdleAfter = 4 * time.Hour
+	DefaultRecoveryLinkTTL        = 7 * 24 * time.Hour
+
+	// abandonedCartLookback bounds how old an idle session may be and still be
+	// considered; stale carts from before the pipeline existed are not mailed.
+	abandonedCartLookback = 7 * 24 * time.Hour
+	// abandonedCartAttributionWindow is how long after detection an order
+	// still counts as recovered; older contacted carts are expired.
+	abandonedCartAttributionWindow = 30 * 24 * time.Hour
+	abandonedCartBatchSize         = 100
+	abandonedCartMaxAttempts       = 3
+	recoveryLinkAudience           = "cart_restore"
+)
+
+var ErrInvalidRecoveryLink = errors.New("cart recovery link is invalid or expired")
+
+// AbandonedCartMessage is what a notifier receives for one recovery email.
+type AbandonedCartMessage struct {
+	AbandonedCartID uint                       `json:"abandoned_cart_id"`
+	Email           string                     `json:"email"`
+	Items           []models.AbandonedCartItem `json:"items"`
+	Subtotal        models.Money               `json:"subtotal"`
+	RestoreURL      string                     `json:"restore_url"`
+	ExpiresAt       time.Time                  `json:"expires_at"`
+}
+
+// AbandonedCartNotifier delivers recovery messages. Implementations must be
+// safe to call again for the same cart after a failure.
+type AbandonedCartNotifier interface {
+	NotifyAbandonedCart(ctx context.Context, message AbandonedCartMessage) error
+}
+
+// RecoveryLinks signs and verifies cart restore tokens.
+type RecoveryLinks struct {
+	Secret  []byte
+	BaseURL string
+	TTL     time.Duration
+}
+
+type recoveryClaims struct {
+	jwt.RegisteredClaims
+}
+
+// DeriveRecoverySecret derives the restore-link key from the application
+// secret so recovery tokens can never be replayed as session JWTs.
+func DeriveRecoverySecret(secret string) []byte {
+	if secret == "" {
+		return nil
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("checkout.cart-recovery.v1"))
+	return mac.Sum(nil)
+}
+
+func (l RecoveryLinks) Sign(abandonedCartID uint, now time.Time) (string, time.Time, error) {
+	if len(l.Secret) == 0 {
+		return "", time.Time{}, errors.New("cart recovery signing secret is not configured")
+	}
+	ttl := l.TTL
+	if ttl <= 0 {
+		ttl = DefaultRecoveryLinkTTL
+	}
+	expiresAt := now.Add(ttl).UTC()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, recoveryClaims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   strconv.FormatUint(uint64(abandonedCartID), 10),
+		Audience:  jwt.ClaimStrings{recoveryLinkAudience},
+		IssuedAt:  jwt.NewNumericDate(now.UTC()),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}})
+	signed, err := token.SignedString(l.Secret)
+	return signed, expiresAt, err
+}
+
+func (l RecoveryLinks) Verify(tokenText string, now time.Time) (uint, error) {
+	if len(l.Secret) == 0 {
+		return 0, errors.New("cart recovery signing secret is not configured")
+	}
+	claims := &recoveryClaims{}
+	token, err := jwt.ParseWithClaims(strings.TrimSpace(tokenText), claims, func(*jwt.Token) (any, error) {
+		return l.Secret, nil
+	}, jwt.WithExpirationRequired(), jwt.WithAudience(recoveryLinkAudience), jwt.WithTimeFunc(func() time.Time { return now }),
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return 0, ErrInvalidRecoveryLink
+	}
+	id, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidRecoveryLink
+	}
+	return uint(id), nil
+}
+
+func (l RecoveryLinks) URL(token string) string {
+	return strings.TrimRight(l.BaseURL, "/") + "/cart/restore?token=" + token
+}
+
+// AbandonedCartPipeline configures detection, delivery, and link signing.
+type AbandonedCartPipeline struct {
+	IdleAfter time.Duration
+	Notifier  AbandonedCartNotifier
+	Links     RecoveryLinks
+}
+
+type AbandonedCartRunSummary struct {
+	Detected  int
+	Sent      int
+	Failed    int
+	Skipped   int
+	Recovered int
+}
+
+// RunAbandonedCartPipeline performs one detection, delivery, and attribution
+// pass.
+func RunAbandonedCartPipeline(ctx context.Context, db *gorm.DB, pipeline AbandonedCartPipeline, now time.Time) (AbandonedCartRunSummary, error) {
+	summary := AbandonedCartRunSummary{}
+	detected, err := DetectAbandonedCarts(db, now, pipeline.IdleAfter)
+	if err != nil {
+		return summary, err
+	}
+	summary.Detected = detected
+	if pipeline.Notifier != nil {
+		delivery, err := DeliverAbandonedCartNotifications(ctx, db, pipeline.Notifier, pipeline.Links, now)
+		summary.Sent, summary.Failed, summary.Skipped = delivery.Sent, delivery.Failed, delivery.Skipped
+		if err != nil {
+			return summary, err
+		}
+	}
+	summary.Recovered, err = AttributeRecoveredOrders(db, now)
+	return summary, err
+}
+
+// DetectAbandonedCarts queues idle sessions that still hold items and have a
+// reachable email address. Each session is queued at most once.
+func DetectAbandonedCarts(db *gorm.DB, now time.Time, idleAfter time.Duration) (int, error) {
+	if idleAfter <= 0 {
+		idleAfter = DefaultAbandonedCartIdleAfter
+	}
+	cutoff := now.Add(-idleAfter)
+	type candidate struct {
+		ID     uint
+		UserID *uint
+		Email  string
+	}
+	var candidates []candidate
+	err := db.Table("checkout_sessions").
+		Select("checkout_sessions.id, checkout_sessions.user_id, COALESCE(users.email, checkout_sessions.guest_email) AS email").
+		Joins("LEFT JOIN users ON users.id = checkout_sessions.user_id AND users.deleted_at IS NULL").
+		Where("checkout_sessions.deleted_at IS NULL AND checkout_sessions.kind = ? AND checkout_sessions.status IN ?", models.CheckoutSessionKindStandard, []string{models.CheckoutSessionStatusActive, models.CheckoutSessionStatusExpired}).
+		Where("checkout_sessions.last_seen_at <= ? AND checkout_sessions.last_seen_at > ?", cutoff, cutoff.Add(-abandonedCartLookback)).
+		Where("COALESCE(users.email, checkout_sessions.guest_email, '') <> ''").
+		Where("EXISTS (SELECT 1 FROM cart_items JOIN carts ON carts.id = cart_items.cart_id WHERE carts.checkout_session_id = checkout_sessions.id AND cart_items.deleted_at IS NULL AND cart_items.quantity > 0)").
+		Where("NOT EXISTS (SELECT 1 FROM abandoned_carts WHERE abandoned_carts.checkout_session_id = checkout_sessions.id OR abandoned_carts.restored_session_id = checkout_sessions.id)").
+		Order("checkout_sessions.id ASC").
+		Limit(abandonedCartBatchSize).
+		Scan(&candidates).Error
+	if err != nil {
+		return 0, err
+	}
+
+	detected := 0
+	for _, candidate := range candidates {
+		var cart models.Cart
+		if err := db.Where("checkout_session_id = ?", candidate.ID).Preload("Items.ProductVariant.Product").First(&cart).Error; err != nil {
+			return detected, err
+		}
+		items, subtotal, count := abandonedCartSnapshot(cart.Items)
+		if count == 0 {
+			continue
+		}
+		payload, err := json.Marshal(items)
+		if err != nil {
+			return detected, err
+		}
+		record := models.AbandonedCart{
+			CheckoutSessionID: candidate.ID,
+			UserID:            candidate.UserID,
+			Email:             strings.TrimSpace(candidate.Email),
+			ItemsJSON:         string(payload),
+			ItemCount:         count,
+			Subtotal:          subtotal,
+			Status:            models.AbandonedCartStatusQueued,
+			DetectedAt:        now,
+		}
+		result := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "checkout_session_id"}}, DoNothing: true}).Create(&record)
+		if result.Error != nil {
+			return detected, result.Error
+		}
+		detected += int(result.RowsAffected)
+	}
+	return detected, nil
+}
+
+func abandonedCartSnapshot(cartItems []models.CartItem) ([]models.AbandonedCartItem, models.Money, int) {
+	items := make([]models.AbandonedCartItem, 0, len(cartItems))
+	var subtotal models.Money
+	count := 0
+	for _, item := range cartItems {
+		if item.Quantity < 1 {
+			continue
+		}
+		variant := item.ProductVariant
+		items = append(items, models.AbandonedCartItem{
+			ProductVariantID: item.ProductVariantID,
+			SKU:              variant.SKU,
+			Title:            variant.Title,
+			ProductName:      variant.Product.Name,
+			Quantity:         item.Quantity,
+			Price:            variant.Price,
+		})
+		subtotal += variant.Price.Mul(item.Quantity)
+		count += item.Quantity
+	}
+	return items, subtotal, count
+}
+
+type AbandonedCartDeliverySummary struct {
+	Sent    int
+	Failed  int
+	Skipped int
+}
+
+// DeliverAbandonedCartNotifications sends queued recovery messages. Carts
+// whose session became active again or converted since detection are skipped.
+func DeliverAbandonedCartNotifications(ctx context.Context, db *gorm.DB, notifier AbandonedCartNotifier, links RecoveryLinks, now time.Time) (AbandonedCartDeliverySummary, error) {
+	summary := AbandonedCartDeliverySummary{}
+	var queued []models.AbandonedCart
+	if err := db.Where("status = ?", models.AbandonedCartStatusQueued).Order("id ASC").Limit(abandonedCartBatchSize).Find(&queued).Error; err != nil {
+		return summary, err
+	}
+	for _, record := range queued {
+		var session models.CheckoutSession
+		err := db.Unscoped().First(&session, record.CheckoutSessionID).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return summary, err
+		}
+		if err == nil && (session.LastSeenAt.After(record.DetectedAt) || session.Status == models.CheckoutSessionStatusConverted || session.Status == models.CheckoutSessionStatusMerged) {
+			if err := db.Model(&record).Update("status", models.AbandonedCartStatusSkipped).Error; err != nil {
+				return summary, err
+			}
+			summary.Skipped++
+			continue
+		}
+
+		var items []models.AbandonedCartItem
+		if err := json.Unmarshal([]byte(record.ItemsJSON), &items); err != nil {
+			return summary, fmt.Errorf("decode abandoned cart %d items: %w", record.ID, err)
+		}
+		token, expiresAt, err := links.Sign(record.ID, now)
+		if err != nil {
+			return summary, err
+		}
+		sendErr := notifier.NotifyAbandonedCart(ctx, AbandonedCartMessage{
+			AbandonedCartID: record.ID,
+			Email:           record.Email,
+			Items:           items,
+			Subtotal:        record.Subtotal,
+			RestoreURL:      links.URL(token),
+			ExpiresAt:       expiresAt,
+		})
+		updates := map[string]any{"attempts": record.Attempts + 1}
+		if sendErr != nil {
+			updates["last_error"] = sendErr.Error()
+			if record.Attempts+1 >= abandonedCartMaxAttempts {
+				updates["status"] = models.AbandonedCartStatusFailed
+				summary.Failed++
+			}
+		} else {
+			updates["status"] = models.AbandonedCartStatusSent
+			updates["notified_at"] = now
+			updates["last_error"] = ""
+			summary.Sent++
+		}
+		if err := db.Model(&record).Updates(updates).Error; err != nil {
+			return summary, err
+		}
+	}
+	return summary, nil
+}
+
+type RestoredCart struct {
+	AbandonedCartID uint
+	Session         models.CheckoutSession
+	Lines           []CartMergeLine
+}
+
+// RestoreAbandonedCart verifies a recovery token and rebuilds the snapshotted
+// cart in a fresh guest session. Lines are re-checked against current stock
+// the same way a guest cart merge is. Restored sessions are never linked to
+// the account; signing in merges them like any other guest cart. A link
+// restores its cart once: after that it is rejected like an expired one.
+func RestoreAbandonedCart(db *gorm.DB, links RecoveryLinks, token string, now time.Time) (RestoredCart, error) {
+	id, err := links.Verify(token, now)
+	if err != nil {
+		return RestoredCart{}, err
+	}
+	restored := RestoredCart{AbandonedCartID: id, Lines: []CartMergeLine{}}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var record models.AbandonedCart
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&record, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRecoveryLink
+		}
+		if err != nil {
+			return err
+		}
+		if record.Status != models.AbandonedCartStatusSent {
+			return ErrInvalidRecoveryLink
+		}
+		var items []models.AbandonedCartItem
+		if err := json.Unmarshal([]byte(record.ItemsJSON), &items); err != nil {
+			return fmt.Errorf("decode abandoned cart %d items: %w", record.ID, err)
+		}
+
+		session := models.CheckoutSession{PublicToken: uuid.NewString(), Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+		if record.UserID == nil && record.Email != "" {
+			email := record.Email
+			session.GuestEmail = &email
+		}
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		cart := models.Cart{CheckoutSessionID: session.ID}
+		if err := tx.Create(&cart).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			line, err := mergeCartLine(tx, cart.ID, customerForSession(session), models.CartItem{ProductVariantID: item.ProductVariantID, Quantity: item.Quantity})
+			if err != nil {
+				return err
+			}
+			restored.Lines = append(restored.Lines, line)
+		}
+		restored.Session = session
+		return tx.Model(&record).Updates(map[string]any{
+			"status":              models.AbandonedCartStatusRestored,
+			"restored_session_id": session.ID,
+			"restored_at":         now,
+			"restore_count":       gorm.Expr("restore_count + 1"),
+		}).Error
+	})
+	if err != nil {
+		return RestoredCart{}, err
+	}
+	return restored, nil
+}
+
+// AttributeRecoveredOrders links contacted carts to the first paid order placed
+// from the original or restored session after detection. Carts detected
+// longer ago than the attribution window are expired instead.
+func AttributeRecoveredOrders(db *gorm.DB, now time.Time) (int, error) {
+	contacted := []string{models.AbandonedCartStatusSent, models.AbandonedCartStatusRestored}
+	since := now.Add(-abandonedCartAttributionWindow)
+	var matches []struct {
+		AbandonedCartID uint
+		OrderID         uint
+	}
+	if err := db.Table("abandoned_carts").
+		Select("abandoned_carts.id AS abandoned_cart_id, MIN(orders.id) AS order_id").
+		Joins("JOIN orders ON orders.checkout_session_id IN (abandoned_carts.checkout_session_id, abandoned_carts.restored_session_id) AND orders.deleted_at IS NULL AND orders.status IN ? AND orders.created_at >= abandoned_carts.detected_at", recoveredOrderStatuses).
+		Where("abandoned_carts.status IN ? AND abandoned_carts.recovered_order_id IS NULL AND abandoned_carts.detected_at >= ?", contacted, since).
+		Group("abandoned_carts.id").Order("abandoned_carts.id ASC").Limit(abandonedCartBatchSize * 5).
+		Scan(&matches).Error; err != nil {
+		return 0, err
+	}
+	recovered := 0
+	for _, match := range matches {
+		if err := db.Model(&models.AbandonedCart{}).Where("id = ?", match.AbandonedCartID).Updates(map[string]any{
+			"status": models.AbandonedCartStatusRecovered, "recovered_order_id": match.OrderID, "recovered_at": now,
+		}).Error; err != nil {
+			return recovered, err
+		}
+		recovered++
+	}
+	err := db.Model(&models.AbandonedCart{}).
+		Where("status IN ? AND recovered_order_id IS NULL AND detected_at < ?", contacted, since).
+		Update("status", models.AbandonedCartStatusExpired).Error
+	return recovered, err
+}
+
+var recoveredOrderStatuses = models.PaidOrderStatuses
+
+type AbandonedCartReport struct {
+	From             time.Time
+	To               time.Time
+	Detected         int64
+	Notified         int64
+	Restored         int64
+	Recovered        int64
+	AbandonedValue   models.Money
+	RecoveredRevenue models.Money
+}
+
+// BuildAbandonedCartReport summarizes carts detected in [from, to). Recovered
+// revenue counts only orders that are still paid, shipped, or delivered.
+func BuildAbandonedCartReport(db *gorm.DB, from, to time.Time) (AbandonedCartReport, error) {
+	report := AbandonedCartReport{From: from, To: to}
+	window := db.Model(&models.AbandonedCart{}).Where("detected_at >= ? AND detected_at < ?", from, to)
+	var totals struct {
+		Detected       int64
+		Notified       int64
+		Restored       int64
+		AbandonedValue models.Money
+	}
+	if err := window.Session(&gorm.Session{}).
+		Select("COUNT(*) AS detected, COUNT(notified_at) AS notified, COALESCE(SUM(CASE WHEN restore_count > 0 THEN 1 ELSE 0 END), 0) AS restored, COALESCE(SUM(subtotal), 0) AS abandoned_value").
+		Scan(&totals).Error; err != nil {
+		return AbandonedCartReport{}, err
+	}
+	var recovered struct {
+		Recovered int64
+		Revenue   models.Money
+	}
+	if err := window.Session(&gorm.Session{}).
+		Joins("JOIN orders ON orders.id = abandoned_carts.recovered_order_id").
+		Where("orders.status IN ?", recoveredOrderStatuses).
+		Select("COUNT(*) AS recovered, COALESCE(SUM(orders.total), 0) AS revenue").
+		Scan(&recovered).Error; err != nil {
+		return AbandonedCartReport{}, err
+	}
+	report.Detected, report.Notified, report.Restored = totals.Detected, totals.Notified, totals.Restored
+	report.AbandonedValue = totals.AbandonedValue
+	report.Recovered, report.RecoveredRevenue = recovered.Recovered, recovered.Revenue
+	return report, nil
+}
+
+type ListAbandonedCartsInput struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+func ListAbandonedCarts(db *gorm.DB, input ListAbandonedCartsInput) ([]models.AbandonedCart, int64, error) {
+	query := db.Model(&models.AbandonedCart{})
+	if status := strings.ToUpper(strings.TrimSpace(input.Status)); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var values []models.AbandonedCart
+	err := query.Order("detected_at DESC, id DESC").Limit(input.Limit).Offset(input.Offset).Find(&values).Error
+	return values, total, err
+}
+
+func StartAbandonedCartWorker(ctx context.Context, db *gorm.DB, pipeline AbandonedCartPipeline, interval time.Duration, logger *log.Logger) {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				summary, err := RunAbandonedCartPipeline(ctx, db, pipeline, time.Now().UTC())
+				if logger == nil {
+					continue
+				}
+				if err != nil {
+					logger.Printf("[ERROR] Abandoned cart pipeline failed: %v", err)
+					continue
+				}
+				if summary != (AbandonedCartRunSummary{}) {
+					logger.Printf("[INFO] Abandoned cart pipeline detected=%d sent=%d failed=%d skipped=%d recovered=%d",
+						summary.Detected, summary.Sent, summary.Failed, summary.Skipped, summary.Recovered)
+				}
+			}
+		}
+	}()
+}
+
+func (s *Service) RestoreAbandonedCart(ctx context.Context, links RecoveryLinks, token string, now time.Time) (RestoredCart, error) {
+	return RestoreAbandonedCart(s.db.WithContext(ctx), links, token, now)
+}
+
+func (s *Service) ListAbandonedCarts(ctx context.Context, input ListAbandonedCartsInput) ([]models.AbandonedCart, int64, error) {
+	return ListAbandonedCarts(s.db.WithContext(ctx), input)
+}
+
+func (s *Service) AbandonedCartReport(ctx context.Context, from, to time.Time) (AbandonedCartReport, error) {
+	return BuildAbandonedCartReport(s.db.WithContext(ctx), from, to)
+}
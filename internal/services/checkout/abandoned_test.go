@@ -0,0 +1,187 @@
+package checkout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"testing"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	messages []AbandonedCartMessage
+	fail     bool
+}
+
+func (n *recordingNotifier) NotifyAbandonedCart(_ context.Context, message AbandonedCartMessage) error {
+	if n.fail {
+		return errors.New("smtp unavailable")
+	}
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestAbandonedCartPipelineNotifiesRestoresAndAttributesRevenue(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Order{}, &models.AbandonedCart{}))
+	ctx := context.Background()
+	now := time.Date(2026, 8, 14, 12, 0, 0, 0, time.UTC)
+
+	product := models.Product{SKU: "abandoned-product", Name: "Lamp", Price: models.MoneyFromFloat(25)}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "abandoned-lamp", Title: "Lamp", Price: product.Price, Stock: 2, IsPublished: true}
+	require.NoError(t, db.Create(&variant).Error)
+
+	email := "guest@example.com"
+	idle := models.CheckoutSession{PublicToken: "idle", GuestEmail: &email, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now.Add(-5 * time.Hour)}
+	anonymous := models.CheckoutSession{PublicToken: "anonymous", Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now.Add(-5 * time.Hour)}
+	recent := models.CheckoutSession{PublicToken: "recent", GuestEmail: &email, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now.Add(-time.Hour)}
+	for _, session := range []*models.CheckoutSession{&idle, &anonymous, &recent} {
+		require.NoError(t, db.Create(session).Error)
+		cart := models.Cart{CheckoutSessionID: session.ID}
+		require.NoError(t, db.Create(&cart).Error)
+		require.NoError(t, db.Create(&models.CartItem{CartID: cart.ID, ProductVariantID: variant.ID, Quantity: 3}).Error)
+	}
+
+	notifier := &recordingNotifier{}
+	links := RecoveryLinks{Secret: DeriveRecoverySecret("test-secret"), BaseURL: "https://shop.example.com/"}
+	pipeline := AbandonedCartPipeline{IdleAfter: 4 * time.Hour, Notifier: notifier, Links: links}
+	summary, err := RunAbandonedCartPipeline(ctx, db, pipeline, now)
+	require.NoError(t, err)
+	assert.Equal(t, AbandonedCartRunSummary{Detected: 1, Sent: 1}, summary)
+	require.Len(t, notifier.messages, 1)
+	message := notifier.messages[0]
+	assert.Equal(t, email, message.Email)
+	assert.Equal(t, models.MoneyFromFloat(75), message.Subtotal)
+
+	summary, err = RunAbandonedCartPipeline(ctx, db, pipeline, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Zero(t, summary.Detected)
+
+	restoreURL, err := url.Parse(message.RestoreURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/cart/restore", restoreURL.Path)
+	token := restoreURL.Query().Get("token")
+
+	_, err = RestoreAbandonedCart(db, links, token+"x", now)
+	assert.ErrorIs(t, err, ErrInvalidRecoveryLink)
+	_, err = RestoreAbandonedCart(db, links, token, now.Add(DefaultRecoveryLinkTTL+time.Minute))
+	assert.ErrorIs(t, err, ErrInvalidRecoveryLink)
+
+	restored, err := RestoreAbandonedCart(db, links, token, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.NotEqual(t, idle.ID, restored.Session.ID)
+	assert.Nil(t, restored.Session.UserID)
+	require.Len(t, restored.Lines, 1)
+	assert.Equal(t, CartMergeOutcomeClamped, restored.Lines[0].Outcome)
+	assert.Equal(t, 2, restored.Lines[0].Quantity)
+	_, err = RestoreAbandonedCart(db, links, token, now.Add(90*time.Minute))
+	assert.ErrorIs(t, err, ErrInvalidRecoveryLink)
+
+	order := models.Order{CheckoutSessionID: restored.Session.ID, GuestEmail: &email, Total: models.MoneyFromFloat(50), Status: models.StatusPaid}
+	require.NoError(t, db.Create(&order).Error)
+	summary, err = RunAbandonedCartPipeline(ctx, db, pipeline, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Recovered)
+
+	report, err := BuildAbandonedCartReport(db, now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Detected)
+	assert.Equal(t, int64(1), report.Notified)
+	assert.Equal(t, int64(1), report.Restored)
+	assert.Equal(t, int64(1), report.Recovered)
+	assert.Equal(t, models.MoneyFromFloat(75), report.AbandonedValue)
+	assert.Equal(t, models.MoneyFromFloat(50), report.RecoveredRevenue)
+
+	_, err = RestoreAbandonedCart(db, links, token, now.Add(3*time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidRecoveryLink)
+}
+
+func TestAbandonedCartDeliverySkipsReturnedShoppersAndRetriesFailures(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Order{}, &models.AbandonedCart{}))
+	ctx := context.Background()
+	now := time.Date(2026, 8, 14, 12, 0, 0, 0, time.UTC)
+
+	email := "shopper@example.com"
+	returned := models.CheckoutSession{PublicToken: "returned", GuestEmail: &email, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	failing := models.CheckoutSession{PublicToken: "failing", GuestEmail: &email, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&returned).Error)
+	require.NoError(t, db.Create(&failing).Error)
+	detectedAt := now.Add(-time.Hour)
+	for _, session := range []models.CheckoutSession{returned, failing} {
+		require.NoError(t, db.Create(&models.AbandonedCart{CheckoutSessionID: session.ID, Email: email, ItemsJSON: "[]", Status: models.AbandonedCartStatusQueued, DetectedAt: detectedAt}).Error)
+	}
+	require.NoError(t, db.Model(&models.CheckoutSession{}).Where("id = ?", failing.ID).Update("last_seen_at", detectedAt.Add(-time.Hour)).Error)
+
+	notifier := &recordingNotifier{fail: true}
+	links := RecoveryLinks{Secret: DeriveRecoverySecret("test-secret")}
+	for attempt := 1; attempt <= abandonedCartMaxAttempts; attempt++ {
+		delivery, err := DeliverAbandonedCartNotifications(ctx, db, notifier, links, now)
+		require.NoError(t, err)
+		if attempt == 1 {
+			assert.Equal(t, 1, delivery.Skipped)
+		}
+	}
+	var failed models.AbandonedCart
+	require.NoError(t, db.Where("checkout_session_id = ?", failing.ID).First(&failed).Error)
+	assert.Equal(t, models.AbandonedCartStatusFailed, failed.Status)
+	assert.Equal(t, abandonedCartMaxAttempts, failed.Attempts)
+	assert.Equal(t, "smtp unavailable", failed.LastError)
+
+	var skipped models.AbandonedCart
+	require.NoError(t, db.Where("checkout_session_id = ?", returned.ID).First(&skipped).Error)
+	assert.Equal(t, models.AbandonedCartStatusSkipped, skipped.Status)
+}
+
+func TestLogAbandonedCartNotifierRedactsEmailAndRestoreToken(t *testing.T) {
+	var output bytes.Buffer
+	notifier := LogAbandonedCartNotifier{Logger: log.New(&output, "", 0)}
+
+	require.NoError(t, notifier.NotifyAbandonedCart(context.Background(), AbandonedCartMessage{
+		AbandonedCartID: 7, Email: "shopper@example.com", Subtotal: models.MoneyFromFloat(20),
+		RestoreURL: "https://shop.example.com/cart/restore?token=secret-token",
+	}))
+	logged := output.String()
+	assert.Contains(t, logged, "email=s***@example.com")
+	assert.Contains(t, logged, "link=https://shop.example.com/cart/restore?token=[REDACTED]")
+	assert.NotContains(t, logged, "shopper@")
+	assert.NotContains(t, logged, "secret-token")
+}
+
+func TestAttributeRecoveredOrdersExpiresCartsPastTheAttributionWindow(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.Order{}, &models.AbandonedCart{}))
+	now := time.Date(2026, 8, 14, 12, 0, 0, 0, time.UTC)
+
+	stale := models.AbandonedCart{CheckoutSessionID: 1, Email: "old@example.com", Status: models.AbandonedCartStatusSent, DetectedAt: now.Add(-abandonedCartAttributionWindow - time.Hour)}
+	fresh := models.AbandonedCart{CheckoutSessionID: 2, Email: "new@example.com", Status: models.AbandonedCartStatusSent, DetectedAt: now.Add(-48 * time.Hour)}
+	waiting := models.AbandonedCart{CheckoutSessionID: 3, Email: "wait@example.com", Status: models.AbandonedCartStatusSent, DetectedAt: now.Add(-24 * time.Hour)}
+	for _, record := range []*models.AbandonedCart{&stale, &fresh, &waiting} {
+		require.NoError(t, db.Create(record).Error)
+	}
+	email := "new@example.com"
+	order := models.Order{CheckoutSessionID: 2, GuestEmail: &email, Total: models.MoneyFromFloat(30), Status: models.StatusPaid}
+	order.CreatedAt = now.Add(-time.Hour)
+	require.NoError(t, db.Create(&order).Error)
+
+	recovered, err := AttributeRecoveredOrders(db, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, recovered)
+
+	require.NoError(t, db.First(&fresh, fresh.ID).Error)
+	assert.Equal(t, models.AbandonedCartStatusRecovered, fresh.Status)
+	require.NotNil(t, fresh.RecoveredOrderID)
+	assert.Equal(t, order.ID, *fresh.RecoveredOrderID)
+	require.NoError(t, db.First(&stale, stale.ID).Error)
+	assert.Equal(t, models.AbandonedCartStatusExpired, stale.Status)
+	require.NoError(t, db.First(&waiting, waiting.ID).Error)
+	assert.Equal(t, models.AbandonedCartStatusSent, waiting.Status)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	inventoryservice "ecommerce/internal/services/inventory"
 	"ecommerce/models"
 
 	"github.com/google/uuid"
@@ -34,6 +35,9 @@ type ResolveSessionInput struct {
 type ResolveSessionResult struct {
 	Session   *models.CheckoutSession
 	SetCookie bool
+	// Merge is set when a guest cart from the cookie was folded into the
+	// signed-in user's existing session on this request.
+	Merge *CartMergeResult
 }
 
 func NewService(db *gorm.DB) *Service { return &Service{db: db} }
@@ -75,7 +79,7 @@ func (s *Service) ResolveSession(ctx context.Context, input ResolveSessionInput)
 	var session *models.CheckoutSession
 	if input.Token != "" {
 		var byToken models.CheckoutSession
-		err := s.db.WithContext(ctx).Where("public_token = ? AND status IN ? AND expires_at > ?", input.Token, statuses, now).First(&byToken).Error
+		err := s.db.WithContext(ctx).Where("public_token = ? AND kind = ? AND status IN ? AND expires_at > ?", input.Token, models.CheckoutSessionKindStandard, statuses, now).First(&byToken).Error
 		if err == nil {
 			session = &byToken
 		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -86,10 +90,11 @@ func (s *Service) ResolveSession(ctx context.Context, input ResolveSessionInput)
 		session = nil
 	}
 
+	guest := session
 	var linked *models.CheckoutSession
 	if input.UserID != 0 {
 		var byUser models.CheckoutSession
-		err := s.db.WithContext(ctx).Where("user_id = ? AND status = ? AND expires_at > ?", input.UserID, models.CheckoutSessionStatusActive, now).
+		err := s.db.WithContext(ctx).Where("user_id = ? AND kind = ? AND status = ? AND expires_at > ?", input.UserID, models.CheckoutSessionKindStandard, models.CheckoutSessionStatusActive, now).
 			Order("last_seen_at DESC, id DESC").First(&byUser).Error
 		if err == nil {
 			linked = &byUser
@@ -101,6 +106,20 @@ func (s *Service) ResolveSession(ctx context.Context, input ResolveSessionInput)
 		}
 	}
 
+	var merge *CartMergeResult
+	if linked != nil && guest != nil && guest.ID != linked.ID && guest.UserID == nil && guest.Status == models.CheckoutSessionStatusActive {
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			result, merged, err := MergeGuestCart(tx, guest.ID, linked.ID, now)
+			if err == nil && merged {
+				merge = &result
+			}
+			return err
+		})
+		if err != nil {
+			return ResolveSessionResult{}, err
+		}
+	}
+
 	setCookie := session != nil && session.PublicToken != input.Token
 	if session == nil && input.Create {
 		created := models.CheckoutSession{PublicToken: uuid.NewString(), Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
@@ -132,7 +151,7 @@ func (s *Service) ResolveSession(ctx context.Context, input ResolveSessionInput)
 		return ResolveSessionResult{}, err
 	}
 	session.LastSeenAt = now
-	return ResolveSessionResult{Session: session, SetCookie: setCookie}, nil
+	return ResolveSessionResult{Session: session, SetCookie: setCookie, Merge: merge}, nil
 }
 
 func (s *Service) SessionForUser(ctx context.Context, userID uint, allowConverted bool) (models.CheckoutSession, error) {
@@ -150,7 +169,7 @@ func (s *Service) SessionForUser(ctx context.Context, userID uint, allowConverte
 		statuses = append(statuses, models.CheckoutSessionStatusConverted)
 	}
 	var session models.CheckoutSession
-	err := s.db.WithContext(ctx).Where("user_id = ? AND status IN ? AND expires_at > ?", userID, statuses, now).Order("last_seen_at DESC, id DESC").First(&session).Error
+	err := s.db.WithContext(ctx).Where("user_id = ? AND kind = ? AND status IN ? AND expires_at > ?", userID, models.CheckoutSessionKindStandard, statuses, now).Order("last_seen_at DESC, id DESC").First(&session).Error
 	if err == nil {
 		return session, nil
 	}
@@ -172,6 +191,11 @@ func (s *Service) Cart(ctx context.Context, userID uint) (models.Cart, error) {
 	return s.cartForSessionContext(ctx, session.ID, true)
 }
 
+// CartForSession loads the cart of a specific checkout session.
+func (s *Service) CartForSession(ctx context.Context, sessionID uint) (models.Cart, error) {
+	return s.cartForSessionContext(ctx, sessionID, true)
+}
+
 func (s *Service) cartForSessionContext(ctx context.Context, sessionID uint, create bool) (models.Cart, error) {
 	var cart models.Cart
 	db := s.db.WithContext(ctx)
@@ -201,19 +225,29 @@ func (s *Service) AddCartItem(ctx context.Context, userID, variantID uint, quant
 		}
 		return models.Cart{}, err
 	}
-	if quantity > variant.Stock {
+	sellable, err := inventoryservice.SellableQuantity(s.db.WithContext(ctx), variant)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	if quantity > sellable {
 		return models.Cart{}, ErrInvalidQuantity
 	}
 	var item models.CartItem
 	err = s.db.WithContext(ctx).Where("cart_id = ? AND product_variant_id = ?", cart.ID, variantID).First(&item).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := checkVariantQuantity(s.db.WithContext(ctx), variant, cartCustomer(ctx, userID), quantity); err != nil {
+			return models.Cart{}, err
+		}
 		item = models.CartItem{CartID: cart.ID, ProductVariantID: variantID, Quantity: quantity}
 		err = s.db.WithContext(ctx).Create(&item).Error
 	} else if err == nil {
 		item.Quantity += quantity
-		if item.Quantity > variant.Stock {
+		if item.Quantity > sellable {
 			return models.Cart{}, ErrInvalidQuantity
 		}
+		if err := checkVariantQuantity(s.db.WithContext(ctx), variant, cartCustomer(ctx, userID), item.Quantity); err != nil {
+			return models.Cart{}, err
+		}
 		err = s.db.WithContext(ctx).Save(&item).Error
 	}
 	if err != nil {
@@ -237,9 +271,16 @@ func (s *Service) UpdateCartItem(ctx context.Context, userID, itemID uint, quant
 		}
 		return models.CartItem{}, err
 	}
-	if quantity > item.ProductVariant.Stock {
+	sellable, err := inventoryservice.SellableQuantity(s.db.WithContext(ctx), item.ProductVariant)
+	if err != nil {
+		return models.CartItem{}, err
+	}
+	if quantity > sellable {
 		return models.CartItem{}, ErrInvalidQuantity
 	}
+	if err := checkVariantQuantity(s.db.WithContext(ctx), item.ProductVariant, cartCustomer(ctx, userID), quantity); err != nil {
+		return models.CartItem{}, err
+	}
 	if err := s.db.WithContext(ctx).Model(&item).Update("quantity", quantity).Error; err != nil {
 		return models.CartItem{}, err
 	}
@@ -247,6 +288,17 @@ func (s *Service) UpdateCartItem(ctx context.Context, userID, itemID uint, quant
 	return item, nil
 }
 
+// cartCustomer identifies the shopper behind the request's checkout session,
+// falling back to the signed-in account when no session is attached.
+func cartCustomer(ctx context.Context, userID uint) Customer {
+	session, _ := SessionFromContext(ctx)
+	customer := customerForSession(session)
+	if customer.UserID == nil && userID != 0 {
+		customer.UserID = &userID
+	}
+	return customer
+}
+
 func (s *Service) DeleteCartItem(ctx context.Context, userID, itemID uint) error {
 	cart, err := s.Cart(ctx, userID)
 	if err != nil {
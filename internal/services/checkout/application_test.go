@@ -60,6 +60,72 @@ func TestResolveSessionRotatesInvalidTokenAndPrefersLinkedSession(t *testing.T)
 	assert.True(t, resolved.SetCookie)
 }
 
+func TestResolveSessionMergesGuestCartIntoLinkedSession(t *testing.T) {
+	db := applicationTestDB(t)
+	service := NewService(db)
+	ctx := context.Background()
+
+	product := models.Product{SKU: "merge-product", Name: "Merge", Price: models.MoneyFromFloat(10)}
+	require.NoError(t, db.Create(&product).Error)
+	summed := models.ProductVariant{ProductID: product.ID, SKU: "merge-summed", Title: "Summed", Price: product.Price, Stock: 10, IsPublished: true}
+	clamped := models.ProductVariant{ProductID: product.ID, SKU: "merge-clamped", Title: "Clamped", Price: product.Price, Stock: 3, IsPublished: true}
+	retired := models.ProductVariant{ProductID: product.ID, SKU: "merge-retired", Title: "Retired", Price: product.Price, Stock: 5, IsPublished: false}
+	for _, variant := range []*models.ProductVariant{&summed, &clamped, &retired} {
+		require.NoError(t, db.Create(variant).Error)
+	}
+	require.NoError(t, db.Model(&retired).Update("is_published", false).Error)
+
+	guest, err := service.ResolveSession(ctx, ResolveSessionInput{Create: true})
+	require.NoError(t, err)
+	guestCart := models.Cart{CheckoutSessionID: guest.Session.ID}
+	require.NoError(t, db.Create(&guestCart).Error)
+	require.NoError(t, db.Create(&[]models.CartItem{
+		{CartID: guestCart.ID, ProductVariantID: summed.ID, Quantity: 2},
+		{CartID: guestCart.ID, ProductVariantID: clamped.ID, Quantity: 2},
+		{CartID: guestCart.ID, ProductVariantID: retired.ID, Quantity: 1},
+	}).Error)
+
+	userID := uint(42)
+	linked := models.CheckoutSession{PublicToken: "linked", UserID: &userID, Status: models.CheckoutSessionStatusActive, ExpiresAt: time.Now().UTC().Add(time.Hour), LastSeenAt: time.Now().UTC()}
+	require.NoError(t, db.Create(&linked).Error)
+	userCart := models.Cart{CheckoutSessionID: linked.ID}
+	require.NoError(t, db.Create(&userCart).Error)
+	require.NoError(t, db.Create(&[]models.CartItem{
+		{CartID: userCart.ID, ProductVariantID: summed.ID, Quantity: 1},
+		{CartID: userCart.ID, ProductVariantID: clamped.ID, Quantity: 2},
+	}).Error)
+
+	resolved, err := service.ResolveSession(ctx, ResolveSessionInput{UserID: userID, Token: guest.Session.PublicToken, Create: true})
+	require.NoError(t, err)
+	require.Equal(t, linked.ID, resolved.Session.ID)
+	require.NotNil(t, resolved.Merge)
+	require.Len(t, resolved.Merge.Lines, 3)
+	assert.Equal(t, CartMergeOutcomeSummed, resolved.Merge.Lines[0].Outcome)
+	assert.Equal(t, 3, resolved.Merge.Lines[0].Quantity)
+	assert.Equal(t, CartMergeOutcomeClamped, resolved.Merge.Lines[1].Outcome)
+	assert.Equal(t, 3, resolved.Merge.Lines[1].Quantity)
+	assert.Equal(t, CartMergeOutcomeDropped, resolved.Merge.Lines[2].Outcome)
+	assert.NotEmpty(t, resolved.Merge.Lines[2].Notice)
+
+	cart, err := service.cartForSessionContext(ctx, linked.ID, false)
+	require.NoError(t, err)
+	quantities := map[uint]int{}
+	for _, item := range cart.Items {
+		quantities[item.ProductVariantID] = item.Quantity
+	}
+	assert.Equal(t, map[uint]int{summed.ID: 3, clamped.ID: 3}, quantities)
+
+	var merged models.CheckoutSession
+	require.NoError(t, db.First(&merged, guest.Session.ID).Error)
+	assert.Equal(t, models.CheckoutSessionStatusMerged, merged.Status)
+	require.NotNil(t, merged.MergedIntoSessionID)
+	assert.Equal(t, linked.ID, *merged.MergedIntoSessionID)
+
+	replayed, err := service.ResolveSession(ctx, ResolveSessionInput{UserID: userID, Token: guest.Session.PublicToken, Create: true})
+	require.NoError(t, err)
+	assert.Nil(t, replayed.Merge)
+}
+
 func TestIdempotencyReplayAndPayloadMismatch(t *testing.T) {
 	db := applicationTestDB(t)
 	service := NewService(db)
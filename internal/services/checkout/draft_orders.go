@@ -0,0 +1,115 @@
+package checkout
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrDraftOrderCartLocked rejects cart changes in a session opened from a
+// draft order: the lines and prices are the ones staff agreed with the
+// customer.
+var ErrDraftOrderCartLocked = errors.New("the cart of a draft order checkout cannot be changed")
+
+type DraftOrderCheckoutLine struct {
+	ProductVariantID uint
+	Quantity         int
+	UnitPrice        models.Money
+}
+
+// StartDraftOrderCheckoutInput describes the checkout a draft order's payment
+// link opens. ShippingData is the draft's shipping selection, address fields
+// included.
+type StartDraftOrderCheckoutInput struct {
+	UserID             *uint
+	GuestEmail         *string
+	ShippingProviderID string
+	ShippingData       map[string]string
+	ExpiresAt          time.Time
+	Lines              []DraftOrderCheckoutLine
+}
+
+// StartDraftOrderCheckout opens a checkout session holding a draft order's
+// lines, locked at the draft's prices until the link expires. The contact,
+// address and shipping steps are filled in from the draft, so the customer
+// goes straight to payment.
+func StartDraftOrderCheckout(db *gorm.DB, input StartDraftOrderCheckoutInput, now time.Time) (models.CheckoutSession, error) {
+	var session models.CheckoutSession
+	err := db.Transaction(func(tx *gorm.DB) error {
+		session = models.CheckoutSession{
+			PublicToken: uuid.NewString(), UserID: input.UserID, GuestEmail: input.GuestEmail,
+			Status: models.CheckoutSessionStatusActive, ExpiresAt: input.ExpiresAt, LastSeenAt: now,
+			Kind: models.CheckoutSessionKindDraftOrder,
+		}
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		cart := models.Cart{CheckoutSessionID: session.ID}
+		if err := tx.Create(&cart).Error; err != nil {
+			return err
+		}
+		lockedUntil := input.ExpiresAt
+		for _, line := range input.Lines {
+			price := line.UnitPrice
+			item := models.CartItem{CartID: cart.ID, ProductVariantID: line.ProductVariantID, Quantity: line.Quantity, LockedPrice: &price, LockedUntil: &lockedUntil, LockedQuantity: line.Quantity}
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+		}
+		fingerprint, _, _, err := checkoutCartFingerprint(tx, session.ID, now)
+		if err != nil {
+			return err
+		}
+
+		state := CheckoutState{Revision: 1, ShippingAddress: map[string]string{}, CartFingerprint: fingerprint}
+		method := CheckoutProvider{ProviderID: input.ShippingProviderID, Data: map[string]string{}}
+		for key, value := range input.ShippingData {
+			if allowedAddressFields[key] {
+				state.ShippingAddress[key] = value
+			} else {
+				method.Data[key] = value
+			}
+		}
+		state.ShippingMethod = &method
+		if input.UserID == nil && input.GuestEmail != nil {
+			state.Contact = &CheckoutContact{Email: *input.GuestEmail}
+		}
+		for _, step := range []string{CheckoutStepContact, CheckoutStepShippingAddress, CheckoutStepShippingMethod} {
+			state.Confirmed = withConfirmed(state.Confirmed, step)
+		}
+		encoded, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		session.CheckoutStateJSON = string(encoded)
+		return tx.Model(&models.CheckoutSession{}).Where("id = ?", session.ID).Update("checkout_state_json", session.CheckoutStateJSON).Error
+	})
+	return session, err
+}
+
+// ActiveDraftOrderSession returns the still-usable session a payment link
+// opened earlier, so opening the link twice resumes the same checkout.
+func ActiveDraftOrderSession(db *gorm.DB, sessionID uint, now time.Time) (models.CheckoutSession, bool, error) {
+	var session models.CheckoutSession
+	err := db.Where("id = ? AND kind = ? AND status = ? AND expires_at > ?", sessionID, models.CheckoutSessionKindDraftOrder, models.CheckoutSessionStatusActive, now).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.CheckoutSession{}, false, nil
+	}
+	if err != nil {
+		return models.CheckoutSession{}, false, err
+	}
+	return session, true, nil
+}
+
+// ExpireDraftOrderSession closes a payment link's session once the draft it
+// was opened for changes or is cancelled.
+func ExpireDraftOrderSession(db *gorm.DB, sessionID uint) error {
+	return db.Model(&models.CheckoutSession{}).
+		Where("id = ? AND kind = ? AND status = ?", sessionID, models.CheckoutSessionKindDraftOrder, models.CheckoutSessionStatusActive).
+		Update("status", models.CheckoutSessionStatusExpired).Error
+}
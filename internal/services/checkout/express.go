@@ -0,0 +1,133 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExpressSessionTTL bounds how long a buy-now checkout stays usable. It is
+// deliberately short: the session only exists to carry one purchase.
+const ExpressSessionTTL = 2 * time.Hour
+
+var ErrExpressCheckoutNotFound = errors.New("express checkout not found")
+
+type StartExpressCheckoutInput struct {
+	// ParentSessionID is the shopper's regular session; its cart is never
+	// read or written by the express checkout.
+	ParentSessionID  uint
+	ProductVariantID uint
+	Quantity         int
+}
+
+type ResolveExpressSessionInput struct {
+	UserID         uint
+	Token          string
+	AllowConverted bool
+}
+
+// StartExpressCheckout opens a separate checkout session holding a single
+// cart line. Orders, snapshots and cart clearing all key off the new session,
+// so completing it leaves the parent session's cart untouched.
+func StartExpressCheckout(db *gorm.DB, input StartExpressCheckoutInput, now time.Time) (models.CheckoutSession, error) {
+	if input.Quantity < 1 {
+		return models.CheckoutSession{}, ErrInvalidQuantity
+	}
+	var session models.CheckoutSession
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var parent models.CheckoutSession
+		if err := tx.Where("id = ? AND kind = ? AND status = ?", input.ParentSessionID, models.CheckoutSessionKindStandard, models.CheckoutSessionStatusActive).First(&parent).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: parent session is not active", ErrExpressCheckoutNotFound)
+			}
+			return err
+		}
+		var variant models.ProductVariant
+		err := tx.Joins("JOIN products ON products.id = product_variants.product_id AND products.deleted_at IS NULL").
+			Where("product_variants.id = ? AND product_variants.is_published = ?", input.ProductVariantID, true).
+			First(&variant).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVariantNotFound
+		}
+		if err != nil {
+			return err
+		}
+		sellable, err := inventoryservice.SellableQuantity(tx, variant)
+		if err != nil {
+			return err
+		}
+		if input.Quantity > sellable {
+			return ErrInvalidQuantity
+		}
+		if err := checkVariantQuantity(tx, variant, customerForSession(parent), input.Quantity); err != nil {
+			return err
+		}
+
+		session = models.CheckoutSession{
+			PublicToken: uuid.NewString(), UserID: parent.UserID, GuestEmail: parent.GuestEmail,
+			Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(ExpressSessionTTL), LastSeenAt: now,
+			Kind: models.CheckoutSessionKindExpress, ParentSessionID: &parent.ID,
+		}
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+		cart := models.Cart{CheckoutSessionID: session.ID}
+		if err := tx.Create(&cart).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.CartItem{CartID: cart.ID, ProductVariantID: variant.ID, Quantity: input.Quantity}).Error
+	})
+	return session, err
+}
+
+// ResolveExpressSession looks up an express session by its token. Sessions
+// opened from a draft order's payment link resolve the same way. A session
+// started by a signed-in shopper only resolves for that shopper, and one
+// started as a guest never resolves for an account.
+func ResolveExpressSession(db *gorm.DB, input ResolveExpressSessionInput, now time.Time) (models.CheckoutSession, error) {
+	token := strings.TrimSpace(input.Token)
+	if token == "" {
+		return models.CheckoutSession{}, ErrExpressCheckoutNotFound
+	}
+	statuses := []string{models.CheckoutSessionStatusActive}
+	if input.AllowConverted {
+		statuses = append(statuses, models.CheckoutSessionStatusConverted)
+	}
+	var session models.CheckoutSession
+	kinds := []string{models.CheckoutSessionKindExpress, models.CheckoutSessionKindDraftOrder}
+	err := db.Where("public_token = ? AND kind IN ? AND status IN ? AND expires_at > ?", token, kinds, statuses, now).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.CheckoutSession{}, ErrExpressCheckoutNotFound
+	}
+	if err != nil {
+		return models.CheckoutSession{}, err
+	}
+	owner := uint(0)
+	if session.UserID != nil {
+		owner = *session.UserID
+	}
+	if owner != input.UserID {
+		return models.CheckoutSession{}, ErrExpressCheckoutNotFound
+	}
+	if err := db.Model(&models.CheckoutSession{}).Where("id = ?", session.ID).Update("last_seen_at", now).Error; err != nil {
+		return models.CheckoutSession{}, err
+	}
+	session.LastSeenAt = now
+	return session, nil
+}
+
+func (s *Service) StartExpressCheckout(ctx context.Context, input StartExpressCheckoutInput, now time.Time) (models.CheckoutSession, error) {
+	return StartExpressCheckout(s.db.WithContext(ctx), input, now)
+}
+
+func (s *Service) ResolveExpressSession(ctx context.Context, input ResolveExpressSessionInput, now time.Time) (models.CheckoutSession, error) {
+	return ResolveExpressSession(s.db.WithContext(ctx), input, now)
+}
@@ -0,0 +1,72 @@
+package checkout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpressCheckoutKeepsTheRegularCartIntact(t *testing.T) {
+	db := applicationTestDB(t)
+	now := time.Now().UTC()
+
+	product := models.Product{SKU: "express-product", Name: "Lamp", Price: models.MoneyFromFloat(25)}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "express-lamp", Title: "Lamp", Price: product.Price, Stock: 5, IsPublished: true}
+	require.NoError(t, db.Create(&variant).Error)
+
+	userID := uint(11)
+	parent := models.CheckoutSession{PublicToken: "express-parent", UserID: &userID, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now.Add(-time.Minute)}
+	require.NoError(t, db.Create(&parent).Error)
+	mainCart := models.Cart{CheckoutSessionID: parent.ID}
+	require.NoError(t, db.Create(&mainCart).Error)
+	require.NoError(t, db.Create(&models.CartItem{CartID: mainCart.ID, ProductVariantID: variant.ID, Quantity: 2}).Error)
+
+	_, err := StartExpressCheckout(db, StartExpressCheckoutInput{ParentSessionID: parent.ID, ProductVariantID: variant.ID, Quantity: 6}, now)
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+	express, err := StartExpressCheckout(db, StartExpressCheckoutInput{ParentSessionID: parent.ID, ProductVariantID: variant.ID, Quantity: 1}, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.CheckoutSessionKindExpress, express.Kind)
+	require.NotNil(t, express.ParentSessionID)
+	assert.Equal(t, parent.ID, *express.ParentSessionID)
+
+	// The newer express session must not replace the user's regular session.
+	service := NewService(db)
+	resolved, err := service.ResolveSession(context.Background(), ResolveSessionInput{UserID: userID, Create: true})
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, resolved.Session.ID)
+	resolved, err = service.ResolveSession(context.Background(), ResolveSessionInput{UserID: userID, Token: express.PublicToken, Create: true})
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, resolved.Session.ID)
+
+	_, err = ResolveExpressSession(db, ResolveExpressSessionInput{UserID: 0, Token: express.PublicToken}, now)
+	assert.ErrorIs(t, err, ErrExpressCheckoutNotFound)
+	_, err = ResolveExpressSession(db, ResolveExpressSessionInput{UserID: userID, Token: parent.PublicToken}, now)
+	assert.ErrorIs(t, err, ErrExpressCheckoutNotFound)
+	found, err := ResolveExpressSession(db, ResolveExpressSessionInput{UserID: userID, Token: express.PublicToken}, now)
+	require.NoError(t, err)
+	assert.Equal(t, express.ID, found.ID)
+
+	expressCart, err := service.CartForSession(context.Background(), express.ID)
+	require.NoError(t, err)
+	require.Len(t, expressCart.Items, 1)
+	assert.Equal(t, 1, expressCart.Items[0].Quantity)
+
+	ordered := []models.OrderItem{{ProductVariantID: variant.ID, Quantity: 1}}
+	require.NoError(t, ClearOrderedItemsFromCart(db, express.ID, ordered))
+	expressCart, err = service.CartForSession(context.Background(), express.ID)
+	require.NoError(t, err)
+	assert.Empty(t, expressCart.Items)
+	main, err := service.CartForSession(context.Background(), parent.ID)
+	require.NoError(t, err)
+	require.Len(t, main.Items, 1)
+	assert.Equal(t, 2, main.Items[0].Quantity)
+
+	_, err = ResolveExpressSession(db, ResolveExpressSessionInput{UserID: userID, Token: express.PublicToken}, now.Add(ExpressSessionTTL))
+	assert.ErrorIs(t, err, ErrExpressCheckoutNotFound)
+}
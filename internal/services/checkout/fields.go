@@ -0,0 +1,377 @@
+package checkout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"ecommerce/internal/checkoutplugins"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxCheckoutFieldLength  = 1000
+	checkoutFieldDateFormat = "2006-01-02"
+)
+
+var (
+	ErrInvalidCheckoutField  = errors.New("invalid checkout field")
+	ErrCheckoutFieldNotFound = errors.New("checkout field not found")
+
+	checkoutFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+	checkoutFieldChannels   = map[string]struct{}{models.DiscountChannelWeb: {}, models.DiscountChannelApp: {}, models.DiscountChannelAdmin: {}}
+)
+
+// CheckoutAttributeError lists every checkout field value that failed
+// validation, keyed by field key.
+type CheckoutAttributeError struct {
+	Problems map[string]string
+}
+
+func (e *CheckoutAttributeError) Error() string {
+	keys := make([]string, 0, len(e.Problems))
+	for key := range e.Problems {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		messages = append(messages, e.Problems[key])
+	}
+	return "invalid checkout attributes: " + strings.Join(messages, " ")
+}
+
+type CheckoutFieldInput struct {
+	Key         string
+	Label       string
+	Type        string
+	Required    bool
+	Placeholder string
+	HelpText    string
+	Options     []checkoutplugins.FieldOption
+	Channels    []string
+	MaxLength   int
+	Position    int
+	Active      bool
+}
+
+func CreateCheckoutField(db *gorm.DB, input CheckoutFieldInput) (models.CheckoutField, error) {
+	field, err := checkoutFieldFromInput(input)
+	if err != nil {
+		return models.CheckoutField{}, err
+	}
+	var existing int64
+	if err := db.Model(&models.CheckoutField{}).Where("key = ?", field.Key).Count(&existing).Error; err != nil {
+		return models.CheckoutField{}, err
+	}
+	if existing > 0 {
+		return models.CheckoutField{}, fmt.Errorf("%w: key %q is already in use", ErrInvalidCheckoutField, field.Key)
+	}
+	active := field.Active
+	if err := db.Create(&field).Error; err != nil {
+		return models.CheckoutField{}, err
+	}
+	// Create skips the zero value in favour of the column default.
+	if !active {
+		if err := db.Model(&field).Update("active", false).Error; err != nil {
+			return models.CheckoutField{}, err
+		}
+		field.Active = false
+	}
+	return field, nil
+}
+
+// UpdateCheckoutField replaces a field definition. The key is immutable so
+// attributes already stored on orders keep pointing at the same field.
+func UpdateCheckoutField(db *gorm.DB, id uint, input CheckoutFieldInput) (models.CheckoutField, error) {
+	current, err := GetCheckoutField(db, id)
+	if err != nil {
+		return models.CheckoutField{}, err
+	}
+	if key := strings.TrimSpace(input.Key); key != "" && key != current.Key {
+		return models.CheckoutField{}, fmt.Errorf("%w: key cannot be changed", ErrInvalidCheckoutField)
+	}
+	input.Key = current.Key
+	field, err := checkoutFieldFromInput(input)
+	if err != nil {
+		return models.CheckoutField{}, err
+	}
+	updates := map[string]any{
+		"label": field.Label, "type": field.Type, "required": field.Required, "placeholder": field.Placeholder, "help_text": field.HelpText,
+		"options_json": field.OptionsJSON, "channels_json": field.ChannelsJSON, "max_length": field.MaxLength, "position": field.Position,
+		"active": field.Active, "updated_at": time.Now().UTC(),
+	}
+	if err := db.Model(&models.CheckoutField{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return models.CheckoutField{}, err
+	}
+	return GetCheckoutField(db, id)
+}
+
+func GetCheckoutField(db *gorm.DB, id uint) (models.CheckoutField, error) {
+	var field models.CheckoutField
+	err := db.First(&field, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.CheckoutField{}, ErrCheckoutFieldNotFound
+	}
+	return field, err
+}
+
+func DeleteCheckoutField(db *gorm.DB, id uint) error {
+	result := db.Delete(&models.CheckoutField{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCheckoutFieldNotFound
+	}
+	return nil
+}
+
+func ListCheckoutFields(db *gorm.DB, includeInactive bool) ([]models.CheckoutField, error) {
+	query := db.Model(&models.CheckoutField{})
+	if !includeInactive {
+		query = query.Where("active = ?", true)
+	}
+	var fields []models.CheckoutField
+	err := query.Order("position ASC, id ASC").Find(&fields).Error
+	return fields, err
+}
+
+// VisibleCheckoutFields returns the active fields shown on channel. An empty
+// channel means the web storefront.
+func VisibleCheckoutFields(db *gorm.DB, channel string) ([]models.CheckoutField, error) {
+	channel = normalizeCheckoutChannel(channel)
+	fields, err := ListCheckoutFields(db, false)
+	if err != nil {
+		return nil, err
+	}
+	visible := fields[:0]
+	for _, field := range fields {
+		if CheckoutFieldVisible(field, channel) {
+			visible = append(visible, field)
+		}
+	}
+	return visible, nil
+}
+
+func CheckoutFieldVisible(field models.CheckoutField, channel string) bool {
+	channels := CheckoutFieldChannels(field)
+	if len(channels) == 0 {
+		return true
+	}
+	channel = normalizeCheckoutChannel(channel)
+	for _, value := range channels {
+		if value == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func CheckoutFieldChannels(field models.CheckoutField) []string {
+	var channels []string
+	_ = json.Unmarshal([]byte(field.ChannelsJSON), &channels)
+	return channels
+}
+
+func CheckoutFieldOptions(field models.CheckoutField) []checkoutplugins.FieldOption {
+	var options []checkoutplugins.FieldOption
+	_ = json.Unmarshal([]byte(field.OptionsJSON), &options)
+	return options
+}
+
+// CheckoutFieldDefinition describes a merchant field with the same shape the
+// checkout plugins use for provider inputs.
+func CheckoutFieldDefinition(field models.CheckoutField) checkoutplugins.FieldDefinition {
+	return checkoutplugins.FieldDefinition{
+		Key: field.Key, Label: field.Label, Type: checkoutplugins.FieldType(field.Type), Required: field.Required,
+		Placeholder: field.Placeholder, HelpText: field.HelpText, Options: CheckoutFieldOptions(field),
+	}
+}
+
+// ValidateCheckoutAttributes checks values against the fields visible on
+// channel and returns the normalized attributes to store. Unknown keys,
+// missing required values and values of the wrong shape are all reported
+// together in a *CheckoutAttributeError.
+func ValidateCheckoutAttributes(db *gorm.DB, channel string, values map[string]string) (map[string]string, error) {
+	fields, err := VisibleCheckoutFields(db, channel)
+	if err != nil {
+		return nil, err
+	}
+	problems := map[string]string{}
+	known := make(map[string]struct{}, len(fields))
+	attributes := map[string]string{}
+	for _, field := range fields {
+		known[field.Key] = struct{}{}
+		value, problem := normalizeCheckoutAttribute(field, values[field.Key])
+		if problem != "" {
+			problems[field.Key] = problem
+			continue
+		}
+		if value != "" {
+			attributes[field.Key] = value
+		}
+	}
+	for key := range values {
+		if _, ok := known[key]; !ok {
+			problems[key] = fmt.Sprintf("%s is not a checkout field.", key)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &CheckoutAttributeError{Problems: problems}
+	}
+	return attributes, nil
+}
+
+func (s *Service) CreateCheckoutField(ctx context.Context, input CheckoutFieldInput) (models.CheckoutField, error) {
+	return CreateCheckoutField(s.db.WithContext(ctx), input)
+}
+
+func (s *Service) UpdateCheckoutField(ctx context.Context, id uint, input CheckoutFieldInput) (models.CheckoutField, error) {
+	return UpdateCheckoutField(s.db.WithContext(ctx), id, input)
+}
+
+func (s *Service) GetCheckoutField(ctx context.Context, id uint) (models.CheckoutField, error) {
+	return GetCheckoutField(s.db.WithContext(ctx), id)
+}
+
+func (s *Service) DeleteCheckoutField(ctx context.Context, id uint) error {
+	return DeleteCheckoutField(s.db.WithContext(ctx), id)
+}
+
+func (s *Service) ListCheckoutFields(ctx context.Context, includeInactive bool) ([]models.CheckoutField, error) {
+	return ListCheckoutFields(s.db.WithContext(ctx), includeInactive)
+}
+
+func (s *Service) VisibleCheckoutFields(ctx context.Context, channel string) ([]models.CheckoutField, error) {
+	return VisibleCheckoutFields(s.db.WithContext(ctx), channel)
+}
+
+func (s *Service) ValidateCheckoutAttributes(ctx context.Context, channel string, values map[string]string) (map[string]string, error) {
+	return ValidateCheckoutAttributes(s.db.WithContext(ctx), channel, values)
+}
+
+func checkoutFieldFromInput(input CheckoutFieldInput) (models.CheckoutField, error) {
+	field := models.CheckoutField{
+		Key: strings.TrimSpace(input.Key), Label: strings.TrimSpace(input.Label), Type: strings.ToLower(strings.TrimSpace(input.Type)),
+		Required: input.Required, Placeholder: strings.TrimSpace(input.Placeholder), HelpText: strings.TrimSpace(input.HelpText),
+		MaxLength: input.MaxLength, Position: input.Position, Active: input.Active,
+	}
+	if !checkoutFieldKeyPattern.MatchString(field.Key) {
+		return models.CheckoutField{}, fmt.Errorf("%w: key must start with a letter and use only lowercase letters, digits and underscores", ErrInvalidCheckoutField)
+	}
+	if field.Label == "" {
+		return models.CheckoutField{}, fmt.Errorf("%w: label is required", ErrInvalidCheckoutField)
+	}
+	switch field.Type {
+	case models.CheckoutFieldTypeText, models.CheckoutFieldTypeCheckbox, models.CheckoutFieldTypeDate:
+		if len(input.Options) > 0 {
+			return models.CheckoutField{}, fmt.Errorf("%w: only select fields have options", ErrInvalidCheckoutField)
+		}
+	case models.CheckoutFieldTypeSelect:
+		if len(input.Options) == 0 {
+			return models.CheckoutField{}, fmt.Errorf("%w: select fields need at least one option", ErrInvalidCheckoutField)
+		}
+	default:
+		return models.CheckoutField{}, fmt.Errorf("%w: type must be text, select, checkbox or date", ErrInvalidCheckoutField)
+	}
+	if field.MaxLength < 0 || field.MaxLength > maxCheckoutFieldLength {
+		return models.CheckoutField{}, fmt.Errorf("%w: max_length must be between 0 and %d", ErrInvalidCheckoutField, maxCheckoutFieldLength)
+	}
+	options := make([]checkoutplugins.FieldOption, 0, len(input.Options))
+	seen := map[string]struct{}{}
+	for _, option := range input.Options {
+		option.Value, option.Label = strings.TrimSpace(option.Value), strings.TrimSpace(option.Label)
+		if option.Value == "" {
+			return models.CheckoutField{}, fmt.Errorf("%w: option values cannot be empty", ErrInvalidCheckoutField)
+		}
+		if _, ok := seen[option.Value]; ok {
+			return models.CheckoutField{}, fmt.Errorf("%w: duplicate option %q", ErrInvalidCheckoutField, option.Value)
+		}
+		seen[option.Value] = struct{}{}
+		if option.Label == "" {
+			option.Label = option.Value
+		}
+		options = append(options, option)
+	}
+	channels := make([]string, 0, len(input.Channels))
+	for _, channel := range input.Channels {
+		channel = strings.ToLower(strings.TrimSpace(channel))
+		if _, ok := checkoutFieldChannels[channel]; !ok {
+			return models.CheckoutField{}, fmt.Errorf("%w: unknown channel %q", ErrInvalidCheckoutField, channel)
+		}
+		channels = append(channels, channel)
+	}
+	encodedOptions, err := json.Marshal(options)
+	if err != nil {
+		return models.CheckoutField{}, err
+	}
+	encodedChannels, err := json.Marshal(channels)
+	if err != nil {
+		return models.CheckoutField{}, err
+	}
+	field.OptionsJSON, field.ChannelsJSON = string(encodedOptions), string(encodedChannels)
+	return field, nil
+}
+
+func normalizeCheckoutAttribute(field models.CheckoutField, raw string) (string, string) {
+	value := strings.TrimSpace(raw)
+	switch field.Type {
+	case models.CheckoutFieldTypeCheckbox:
+		switch strings.ToLower(value) {
+		case "", "false", "0":
+			if field.Required {
+				return "", fmt.Sprintf("%s must be checked.", field.Label)
+			}
+			return "", ""
+		case "true", "1":
+			return "true", ""
+		default:
+			return "", fmt.Sprintf("%s must be true or false.", field.Label)
+		}
+	}
+	if value == "" {
+		if field.Required {
+			return "", fmt.Sprintf("%s is required.", field.Label)
+		}
+		return "", ""
+	}
+	switch field.Type {
+	case models.CheckoutFieldTypeSelect:
+		for _, option := range CheckoutFieldOptions(field) {
+			if option.Value == value {
+				return value, ""
+			}
+		}
+		return "", fmt.Sprintf("%s must be one of the listed options.", field.Label)
+	case models.CheckoutFieldTypeDate:
+		parsed, err := time.Parse(checkoutFieldDateFormat, value)
+		if err != nil {
+			return "", fmt.Sprintf("%s must be a date in YYYY-MM-DD format.", field.Label)
+		}
+		return parsed.Format(checkoutFieldDateFormat), ""
+	}
+	limit := field.MaxLength
+	if limit == 0 {
+		limit = maxCheckoutFieldLength
+	}
+	if len([]rune(value)) > limit {
+		return "", fmt.Sprintf("%s must be at most %d characters.", field.Label, limit)
+	}
+	return value, ""
+}
+
+func normalizeCheckoutChannel(channel string) string {
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	if channel == "" {
+		return models.DiscountChannelWeb
+	}
+	return channel
+}
@@ -0,0 +1,81 @@
+package checkout
+
+import (
+	"testing"
+
+	"ecommerce/internal/checkoutplugins"
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckoutAttributesFollowFieldRulesPerChannel(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.CheckoutField{}))
+
+	_, err := CreateCheckoutField(db, CheckoutFieldInput{Key: "Gift Message", Label: "Gift message", Type: "text", Active: true})
+	assert.ErrorIs(t, err, ErrInvalidCheckoutField)
+	_, err = CreateCheckoutField(db, CheckoutFieldInput{Key: "slot", Label: "Slot", Type: "select", Active: true})
+	assert.ErrorIs(t, err, ErrInvalidCheckoutField)
+
+	_, err = CreateCheckoutField(db, CheckoutFieldInput{Key: "gift_message", Label: "Gift message", Type: "text", MaxLength: 10, Active: true})
+	require.NoError(t, err)
+	_, err = CreateCheckoutField(db, CheckoutFieldInput{Key: "gift_message", Label: "Again", Type: "text", Active: true})
+	assert.ErrorIs(t, err, ErrInvalidCheckoutField)
+	_, err = CreateCheckoutField(db, CheckoutFieldInput{
+		Key: "delivery_slot", Label: "Delivery slot", Type: "select", Required: true, Position: 1, Active: true,
+		Options: []checkoutplugins.FieldOption{{Value: "am", Label: "Morning"}, {Value: "pm"}},
+	})
+	require.NoError(t, err)
+	_, err = CreateCheckoutField(db, CheckoutFieldInput{Key: "po_number", Label: "PO number", Type: "text", Required: true, Channels: []string{"admin"}, Active: true})
+	require.NoError(t, err)
+	_, err = CreateCheckoutField(db, CheckoutFieldInput{Key: "deliver_on", Label: "Deliver on", Type: "date", Active: true})
+	require.NoError(t, err)
+	hidden, err := CreateCheckoutField(db, CheckoutFieldInput{Key: "legacy_note", Label: "Legacy", Type: "text", Active: false})
+	require.NoError(t, err)
+	assert.False(t, hidden.Active)
+
+	web, err := VisibleCheckoutFields(db, "")
+	require.NoError(t, err)
+	keys := []string{}
+	for _, field := range web {
+		keys = append(keys, field.Key)
+	}
+	assert.Equal(t, []string{"gift_message", "deliver_on", "delivery_slot"}, keys)
+
+	_, err = ValidateCheckoutAttributes(db, "web", map[string]string{"gift_message": "Happy birthday!", "deliver_on": "06/01/2026", "po_number": "PO-1"})
+	var attributeErr *CheckoutAttributeError
+	require.ErrorAs(t, err, &attributeErr)
+	assert.Len(t, attributeErr.Problems, 4)
+	assert.Contains(t, attributeErr.Problems, "delivery_slot")
+	assert.Contains(t, attributeErr.Problems, "po_number")
+
+	attributes, err := ValidateCheckoutAttributes(db, "web", map[string]string{"gift_message": " Enjoy ", "delivery_slot": "pm", "deliver_on": "2026-06-01"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"gift_message": "Enjoy", "delivery_slot": "pm", "deliver_on": "2026-06-01"}, attributes)
+
+	_, err = ValidateCheckoutAttributes(db, "admin", map[string]string{"delivery_slot": "am"})
+	require.ErrorAs(t, err, &attributeErr)
+	assert.Equal(t, []string{"po_number"}, mapKeys(attributeErr.Problems))
+
+	_, err = UpdateCheckoutField(db, hidden.ID, CheckoutFieldInput{Key: "renamed", Label: "Legacy", Type: "text"})
+	assert.ErrorIs(t, err, ErrInvalidCheckoutField)
+	updated, err := UpdateCheckoutField(db, hidden.ID, CheckoutFieldInput{Label: "Terms accepted", Type: "checkbox", Required: true, Active: true})
+	require.NoError(t, err)
+	assert.Equal(t, "legacy_note", updated.Key)
+	_, err = ValidateCheckoutAttributes(db, "web", map[string]string{"delivery_slot": "am", "legacy_note": "false"})
+	require.ErrorAs(t, err, &attributeErr)
+	assert.Equal(t, []string{"legacy_note"}, mapKeys(attributeErr.Problems))
+	attributes, err = ValidateCheckoutAttributes(db, "web", map[string]string{"delivery_slot": "am", "legacy_note": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "true", attributes["legacy_note"])
+}
+
+func mapKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	return keys
+}
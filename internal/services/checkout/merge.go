@@ -0,0 +1,191 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CartMergeOutcomeAdded   = "ADDED"
+	CartMergeOutcomeSummed  = "SUMMED"
+	CartMergeOutcomeClamped = "CLAMPED"
+	CartMergeOutcomeDropped = "DROPPED"
+)
+
+// CartMergeLine describes what happened to one guest cart line during a merge.
+type CartMergeLine struct {
+	ProductVariantID uint
+	GuestQuantity    int
+	PreviousQuantity int
+	Quantity         int
+	Outcome          string
+	Notice           string
+}
+
+// CartMergeResult reports a guest cart folded into a signed-in user's cart.
+type CartMergeResult struct {
+	SourceSessionID uint
+	TargetSessionID uint
+	MergedAt        time.Time
+	Lines           []CartMergeLine
+}
+
+type cartMergeContextKey struct{}
+
+func WithCartMerge(ctx context.Context, merge CartMergeResult) context.Context {
+	return context.WithValue(ctx, cartMergeContextKey{}, merge)
+}
+
+func CartMergeFromContext(ctx context.Context) (CartMergeResult, bool) {
+	merge, ok := ctx.Value(cartMergeContextKey{}).(CartMergeResult)
+	return merge, ok
+}
+
+// MergeGuestCart moves the cart of an anonymous session into the target
+// session. Quantities for the same variant are summed and clamped to what
+// may be bought, and variants that can no longer be bought are dropped. The guest session is
+// marked MERGED so the cookie cannot resurrect it. It returns false when the
+// guest session was already merged or is no longer an active guest session.
+func MergeGuestCart(tx *gorm.DB, guestSessionID, targetSessionID uint, now time.Time) (CartMergeResult, bool, error) {
+	if guestSessionID == targetSessionID {
+		return CartMergeResult{}, false, nil
+	}
+	var guest models.CheckoutSession
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND status = ? AND user_id IS NULL", guestSessionID, models.CheckoutSessionStatusActive).
+		First(&guest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return CartMergeResult{}, false, nil
+	}
+	if err != nil {
+		return CartMergeResult{}, false, err
+	}
+	result := CartMergeResult{SourceSessionID: guest.ID, TargetSessionID: targetSessionID, MergedAt: now, Lines: []CartMergeLine{}}
+
+	var guestItems []models.CartItem
+	if err := tx.Joins("JOIN carts ON carts.id = cart_items.cart_id").
+		Where("carts.checkout_session_id = ?", guest.ID).
+		Order("cart_items.id ASC").
+		Find(&guestItems).Error; err != nil {
+		return CartMergeResult{}, false, err
+	}
+	if len(guestItems) > 0 {
+		var session models.CheckoutSession
+		if err := tx.First(&session, targetSessionID).Error; err != nil {
+			return CartMergeResult{}, false, err
+		}
+		var target models.Cart
+		err := tx.Where("checkout_session_id = ?", targetSessionID).First(&target).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			target = models.Cart{CheckoutSessionID: targetSessionID}
+			err = tx.Create(&target).Error
+		}
+		if err != nil {
+			return CartMergeResult{}, false, err
+		}
+		for _, item := range guestItems {
+			line, err := mergeCartLine(tx, target.ID, customerForSession(session), item)
+			if err != nil {
+				return CartMergeResult{}, false, err
+			}
+			result.Lines = append(result.Lines, line)
+		}
+	}
+
+	updated := tx.Model(&models.CheckoutSession{}).
+		Where("id = ? AND status = ?", guest.ID, models.CheckoutSessionStatusActive).
+		Updates(map[string]any{"status": models.CheckoutSessionStatusMerged, "merged_into_session_id": targetSessionID, "merged_at": now})
+	if updated.Error != nil {
+		return CartMergeResult{}, false, updated.Error
+	}
+	if updated.RowsAffected == 0 {
+		return CartMergeResult{}, false, nil
+	}
+	return result, true, nil
+}
+
+// mergeCartLine adds a line's units to the cart. The summed quantity is
+// clamped down to the largest one the variant's stock, maximum, step and the
+// customer's purchase limit allow; when none is left the units are dropped.
+func mergeCartLine(tx *gorm.DB, targetCartID uint, customer Customer, guestItem models.CartItem) (CartMergeLine, error) {
+	line := CartMergeLine{ProductVariantID: guestItem.ProductVariantID, GuestQuantity: guestItem.Quantity}
+	var existing models.CartItem
+	err := tx.Where("cart_id = ? AND product_variant_id = ?", targetCartID, guestItem.ProductVariantID).First(&existing).Error
+	found := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return CartMergeLine{}, err
+	}
+	line.PreviousQuantity = existing.Quantity
+
+	var variant models.ProductVariant
+	err = tx.Joins("JOIN products ON products.id = product_variants.product_id AND products.deleted_at IS NULL").
+		Where("product_variants.id = ? AND product_variants.is_published = ?", guestItem.ProductVariantID, true).
+		First(&variant).Error
+	sellable := 0
+	if err == nil {
+		sellable, err = inventoryservice.SellableQuantity(tx, variant)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && sellable < 1) {
+		line.Quantity = existing.Quantity
+		line.Outcome = CartMergeOutcomeDropped
+		line.Notice = "This item is no longer available and was removed from your cart."
+		return line, nil
+	}
+	if err != nil {
+		return CartMergeLine{}, err
+	}
+
+	requested := existing.Quantity + guestItem.Quantity
+	ceiling := sellable
+	notice := "Quantity was reduced to the amount currently in stock."
+	if variant.MaxQuantity != nil && *variant.MaxQuantity < ceiling {
+		ceiling = *variant.MaxQuantity
+		notice = "Quantity was reduced to the most you can buy at once."
+	}
+	if variant.PurchaseLimit != nil {
+		purchased, err := PurchasedQuantity(tx, variant.ID, customer)
+		if err != nil {
+			return CartMergeLine{}, err
+		}
+		if remaining := *variant.PurchaseLimit - purchased; remaining < ceiling {
+			ceiling = remaining
+			notice = "Quantity was reduced to what is left of your purchase limit."
+		}
+	}
+	step := max(variant.QuantityStep, 1)
+	quantity := min(requested, ceiling)
+	quantity -= quantity % step
+	if quantity < 1 || CheckQuantityRules(variant, quantity) != nil || CheckPurchaseLimit(tx, variant, customer, quantity) != nil {
+		line.Quantity = existing.Quantity
+		line.Outcome = CartMergeOutcomeDropped
+		line.Notice = "This item cannot be bought in a quantity that is still available and was removed from your cart."
+		return line, nil
+	}
+
+	line.Outcome = CartMergeOutcomeAdded
+	if found {
+		line.Outcome = CartMergeOutcomeSummed
+	}
+	if quantity < requested {
+		line.Outcome = CartMergeOutcomeClamped
+		line.Notice = notice
+		if requested <= ceiling {
+			line.Notice = "Quantity was reduced to a multiple the item is sold in."
+		}
+	}
+	line.Quantity = quantity
+	if !found {
+		return line, tx.Create(&models.CartItem{CartID: targetCartID, ProductVariantID: guestItem.ProductVariantID, Quantity: quantity, LockedPrice: guestItem.LockedPrice, LockedUntil: guestItem.LockedUntil, LockedQuantity: guestItem.LockedQuantity, SavedCartID: guestItem.SavedCartID}).Error
+	}
+	if quantity == existing.Quantity {
+		return line, nil
+	}
+	return line, tx.Model(&existing).Update("quantity", quantity).Error
+}
@@ -0,0 +1,185 @@
+package checkout
+
+import (
+	"fmt"
+	"strings"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	QuantityRuleMinimum       = "minimum"
+	QuantityRuleMaximum       = "maximum"
+	QuantityRuleStep          = "step"
+	QuantityRulePurchaseLimit = "purchase_limit"
+)
+
+// QuantityRuleError reports a quantity a variant's purchase rules do not
+// allow. It unwraps to ErrInvalidQuantity so existing callers keep treating it
+// as a bad quantity.
+type QuantityRuleError struct {
+	ProductVariantID uint
+	Rule             string
+	Limit            int
+	Requested        int
+	// Purchased is how many the customer already bought, for purchase limits.
+	Purchased int
+}
+
+func (e *QuantityRuleError) Error() string {
+	switch e.Rule {
+	case QuantityRuleMinimum:
+		return fmt.Sprintf("quantity must be at least %d", e.Limit)
+	case QuantityRuleMaximum:
+		return fmt.Sprintf("quantity must be at most %d", e.Limit)
+	case QuantityRuleStep:
+		return fmt.Sprintf("quantity must be a multiple of %d", e.Limit)
+	case QuantityRulePurchaseLimit:
+		return fmt.Sprintf("purchase limit of %d per customer exceeded", e.Limit)
+	default:
+		return ErrInvalidQuantity.Error()
+	}
+}
+
+func (e *QuantityRuleError) Unwrap() error { return ErrInvalidQuantity }
+
+// Customer identifies who is buying for per-customer purchase limits. Guests
+// are matched by email; a customer with neither is only held to the
+// quantity in the current cart or order.
+type Customer struct {
+	UserID *uint
+	Email  string
+}
+
+func customerForSession(session models.CheckoutSession) Customer {
+	customer := Customer{UserID: session.UserID}
+	if session.GuestEmail != nil {
+		customer.Email = *session.GuestEmail
+	}
+	return customer
+}
+
+// CheckQuantityRules validates one line's quantity against the variant's
+// minimum, maximum and step.
+func CheckQuantityRules(variant models.ProductVariant, quantity int) error {
+	step := variant.QuantityStep
+	if step < 1 {
+		step = 1
+	}
+	switch {
+	case quantity < variant.MinQuantity:
+		return &QuantityRuleError{ProductVariantID: variant.ID, Rule: QuantityRuleMinimum, Limit: variant.MinQuantity, Requested: quantity}
+	case variant.MaxQuantity != nil && quantity > *variant.MaxQuantity:
+		return &QuantityRuleError{ProductVariantID: variant.ID, Rule: QuantityRuleMaximum, Limit: *variant.MaxQuantity, Requested: quantity}
+	case quantity%step != 0:
+		return &QuantityRuleError{ProductVariantID: variant.ID, Rule: QuantityRuleStep, Limit: step, Requested: quantity}
+	}
+	return nil
+}
+
+// purchaseLimitStatuses are the statuses of orders whose units count toward
+// a purchase limit: orders still awaiting or holding a payment authorization
+// and sales that went through.
+var purchaseLimitStatuses = append([]string{models.StatusPending}, models.PaidOrderStatuses...)
+
+// PurchasedQuantity sums the units of a variant on the customer's pending and
+// paid orders. Failed and cancelled orders never count. A signed-in customer
+// is also charged with the guest orders placed under their account email.
+func PurchasedQuantity(db *gorm.DB, variantID uint, customer Customer) (int, error) {
+	return purchasedQuantity(db, variantID, customer, 0)
+}
+
+func purchasedQuantity(db *gorm.DB, variantID uint, customer Customer, excludeOrderID uint) (int, error) {
+	email := strings.ToLower(strings.TrimSpace(customer.Email))
+	if customer.UserID != nil {
+		var user models.User
+		if err := db.Select("email").Where("id = ?", *customer.UserID).Limit(1).Find(&user).Error; err != nil {
+			return 0, err
+		}
+		email = strings.ToLower(strings.TrimSpace(user.Email))
+	}
+	query := db.Model(&models.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Where("order_items.product_variant_id = ? AND orders.status IN ?", variantID, purchaseLimitStatuses)
+	if excludeOrderID != 0 {
+		query = query.Where("orders.id <> ?", excludeOrderID)
+	}
+	switch {
+	case customer.UserID != nil && email != "":
+		query = query.Where("(orders.user_id = ? OR (orders.user_id IS NULL AND LOWER(orders.guest_email) = ?))", *customer.UserID, email)
+	case customer.UserID != nil:
+		query = query.Where("orders.user_id = ?", *customer.UserID)
+	case email != "":
+		query = query.Where("orders.user_id IS NULL AND LOWER(orders.guest_email) = ?", email)
+	default:
+		return 0, nil
+	}
+	var purchased int
+	err := query.Select("COALESCE(SUM(order_items.quantity), 0)").Scan(&purchased).Error
+	return purchased, err
+}
+
+// CheckPurchaseLimit validates quantity plus the customer's earlier purchases
+// against the variant's per-customer cap.
+func CheckPurchaseLimit(db *gorm.DB, variant models.ProductVariant, customer Customer, quantity int) error {
+	return checkPurchaseLimit(db, variant, customer, quantity, 0)
+}
+
+func checkPurchaseLimit(db *gorm.DB, variant models.ProductVariant, customer Customer, quantity int, excludeOrderID uint) error {
+	if variant.PurchaseLimit == nil {
+		return nil
+	}
+	purchased, err := purchasedQuantity(db, variant.ID, customer, excludeOrderID)
+	if err != nil {
+		return err
+	}
+	if purchased+quantity > *variant.PurchaseLimit {
+		return &QuantityRuleError{ProductVariantID: variant.ID, Rule: QuantityRulePurchaseLimit, Limit: *variant.PurchaseLimit, Requested: quantity, Purchased: purchased}
+	}
+	return nil
+}
+
+// CheckOrderPurchaseLimits rechecks an order's lines against their purchase
+// limits when its payment is authorized. The limited variant rows stay locked
+// until tx ends, so two checkouts by the same customer cannot both pass on
+// the count taken before the other was placed.
+func CheckOrderPurchaseLimits(tx *gorm.DB, order models.Order) error {
+	requested := map[uint]int{}
+	var variantIDs []uint
+	for _, item := range order.Items {
+		if _, seen := requested[item.ProductVariantID]; !seen {
+			variantIDs = append(variantIDs, item.ProductVariantID)
+		}
+		requested[item.ProductVariantID] += item.Quantity
+	}
+	if len(variantIDs) == 0 {
+		return nil
+	}
+	var variants []models.ProductVariant
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ? AND purchase_limit IS NOT NULL", variantIDs).
+		Order("id ASC").
+		Find(&variants).Error; err != nil {
+		return err
+	}
+	customer := Customer{UserID: order.UserID}
+	if order.GuestEmail != nil {
+		customer.Email = *order.GuestEmail
+	}
+	for _, variant := range variants {
+		if err := checkPurchaseLimit(tx, variant, customer, requested[variant.ID], order.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkVariantQuantity(db *gorm.DB, variant models.ProductVariant, customer Customer, quantity int) error {
+	if err := CheckQuantityRules(variant, quantity); err != nil {
+		return err
+	}
+	return CheckPurchaseLimit(db, variant, customer, quantity)
+}
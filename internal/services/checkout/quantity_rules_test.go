@@ -0,0 +1,205 @@
+package checkout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartEnforcesVariantQuantityRules(t *testing.T) {
+	db := applicationTestDB(t)
+	service := NewService(db)
+	ctx := context.Background()
+
+	product := models.Product{SKU: "rules-product", Name: "Screws", Price: models.MoneyFromFloat(2)}
+	require.NoError(t, db.Create(&product).Error)
+	maxQuantity := 12
+	variant := models.ProductVariant{
+		ProductID: product.ID, SKU: "rules-screws", Title: "Screws", Price: product.Price, Stock: 100, IsPublished: true,
+		MinQuantity: 4, QuantityStep: 2, MaxQuantity: &maxQuantity,
+	}
+	require.NoError(t, db.Create(&variant).Error)
+
+	var ruleErr *QuantityRuleError
+	_, err := service.AddCartItem(ctx, 7, variant.ID, 2)
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, QuantityRuleMinimum, ruleErr.Rule)
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+
+	_, err = service.AddCartItem(ctx, 7, variant.ID, 5)
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, QuantityRuleStep, ruleErr.Rule)
+
+	cart, err := service.AddCartItem(ctx, 7, variant.ID, 8)
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 1)
+
+	// Adding more merges into the line, so the maximum applies to the total.
+	_, err = service.AddCartItem(ctx, 7, variant.ID, 6)
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, QuantityRuleMaximum, ruleErr.Rule)
+	assert.Equal(t, 14, ruleErr.Requested)
+
+	_, err = service.UpdateCartItem(ctx, 7, cart.Items[0].ID, 14)
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, QuantityRuleMaximum, ruleErr.Rule)
+	item, err := service.UpdateCartItem(ctx, 7, cart.Items[0].ID, 12)
+	require.NoError(t, err)
+	assert.Equal(t, 12, item.Quantity)
+}
+
+func TestPurchaseLimitCountsPaidOrdersPerCustomer(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Order{}, &models.OrderItem{}))
+	service := NewService(db)
+	ctx := context.Background()
+
+	product := models.Product{SKU: "limit-product", Name: "Sneakers", Price: models.MoneyFromFloat(120)}
+	require.NoError(t, db.Create(&product).Error)
+	limit := 2
+	variant := models.ProductVariant{
+		ProductID: product.ID, SKU: "limit-sneakers", Title: "Sneakers", Price: product.Price, Stock: 50, IsPublished: true,
+		MinQuantity: 1, QuantityStep: 1, PurchaseLimit: &limit,
+	}
+	require.NoError(t, db.Create(&variant).Error)
+
+	userID := uint(9)
+	guestEmail := "Guest@Example.com"
+	for _, order := range []models.Order{
+		{CheckoutSessionID: 1, UserID: &userID, Status: models.StatusPaid},
+		{CheckoutSessionID: 2, UserID: &userID, Status: models.StatusCancelled},
+		{CheckoutSessionID: 3, GuestEmail: &guestEmail, Status: models.StatusDelivered},
+	} {
+		require.NoError(t, db.Create(&order).Error)
+		require.NoError(t, db.Create(&models.OrderItem{OrderID: order.ID, ProductVariantID: variant.ID, Quantity: 1, Price: variant.Price}).Error)
+	}
+
+	purchased, err := PurchasedQuantity(db, variant.ID, Customer{UserID: &userID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, purchased)
+	purchased, err = PurchasedQuantity(db, variant.ID, Customer{Email: "guest@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, purchased)
+	purchased, err = PurchasedQuantity(db, variant.ID, Customer{})
+	require.NoError(t, err)
+	assert.Zero(t, purchased)
+
+	_, err = service.AddCartItem(ctx, userID, variant.ID, 2)
+	var ruleErr *QuantityRuleError
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, QuantityRulePurchaseLimit, ruleErr.Rule)
+	assert.Equal(t, 1, ruleErr.Purchased)
+	_, err = service.AddCartItem(ctx, userID, variant.ID, 1)
+	require.NoError(t, err)
+
+	// Another shopper has their own allowance.
+	_, err = service.AddCartItem(ctx, 10, variant.ID, 2)
+	require.NoError(t, err)
+}
+
+func TestPurchaseLimitCountsPendingAndGuestOrdersOfSignedInCustomer(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Order{}, &models.OrderItem{}))
+
+	product := models.Product{SKU: "drop-product", Name: "Drop", Price: models.MoneyFromFloat(80)}
+	require.NoError(t, db.Create(&product).Error)
+	limit := 3
+	variant := models.ProductVariant{
+		ProductID: product.ID, SKU: "drop-variant", Title: "Drop", Price: product.Price, Stock: 50, IsPublished: true,
+		MinQuantity: 1, QuantityStep: 1, PurchaseLimit: &limit,
+	}
+	require.NoError(t, db.Create(&variant).Error)
+	user := models.User{Username: "shopper", Email: "shopper@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+
+	guestEmail := "Shopper@Example.com"
+	place := func(order models.Order, quantity int) models.Order {
+		t.Helper()
+		require.NoError(t, db.Create(&order).Error)
+		item := models.OrderItem{OrderID: order.ID, ProductVariantID: variant.ID, Quantity: quantity, Price: variant.Price}
+		require.NoError(t, db.Create(&item).Error)
+		order.Items = []models.OrderItem{item}
+		return order
+	}
+	place(models.Order{CheckoutSessionID: 1, GuestEmail: &guestEmail, Status: models.StatusPaid}, 1)
+	place(models.Order{CheckoutSessionID: 2, UserID: &user.ID, Status: models.StatusPending}, 1)
+	place(models.Order{CheckoutSessionID: 3, UserID: &user.ID, Status: models.StatusFailed}, 5)
+
+	purchased, err := PurchasedQuantity(db, variant.ID, Customer{UserID: &user.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, purchased)
+
+	// The order being authorized is left out of its own count, but a second
+	// checkout placed in the meantime uses up the allowance.
+	order := place(models.Order{CheckoutSessionID: 4, UserID: &user.ID, Status: models.StatusPending}, 1)
+	require.NoError(t, CheckOrderPurchaseLimits(db, order))
+	place(models.Order{CheckoutSessionID: 5, UserID: &user.ID, Status: models.StatusPending}, 1)
+	err = CheckOrderPurchaseLimits(db, order)
+	var ruleErr *QuantityRuleError
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, QuantityRulePurchaseLimit, ruleErr.Rule)
+	assert.Equal(t, 3, ruleErr.Purchased)
+}
+
+func TestMergeGuestCartClampsToQuantityRulesAndPurchaseLimit(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Order{}, &models.OrderItem{}))
+	now := time.Now().UTC()
+
+	product := models.Product{SKU: "merge-rules", Name: "Cans", Price: models.MoneyFromFloat(1)}
+	require.NoError(t, db.Create(&product).Error)
+	maxQuantity, limit := 4, 3
+	packs := models.ProductVariant{ProductID: product.ID, SKU: "merge-six-pack", Title: "Six pack", Price: product.Price, Stock: 14, IsPublished: true, MinQuantity: 6, QuantityStep: 6}
+	scarce := models.ProductVariant{ProductID: product.ID, SKU: "merge-scarce-pack", Title: "Scarce pack", Price: product.Price, Stock: 5, IsPublished: true, MinQuantity: 6, QuantityStep: 6}
+	capped := models.ProductVariant{ProductID: product.ID, SKU: "merge-capped", Title: "Capped", Price: product.Price, Stock: 50, IsPublished: true, MinQuantity: 1, QuantityStep: 1, MaxQuantity: &maxQuantity}
+	limited := models.ProductVariant{ProductID: product.ID, SKU: "merge-limited", Title: "Limited", Price: product.Price, Stock: 50, IsPublished: true, MinQuantity: 1, QuantityStep: 1, PurchaseLimit: &limit}
+	for _, variant := range []*models.ProductVariant{&packs, &scarce, &capped, &limited} {
+		require.NoError(t, db.Create(variant).Error)
+	}
+	user := models.User{Username: "merger", Email: "merger@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	order := models.Order{CheckoutSessionID: 99, UserID: &user.ID, Status: models.StatusPaid}
+	require.NoError(t, db.Create(&order).Error)
+	require.NoError(t, db.Create(&models.OrderItem{OrderID: order.ID, ProductVariantID: limited.ID, Quantity: 2, Price: limited.Price}).Error)
+
+	guest := models.CheckoutSession{PublicToken: "merge-guest", Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	target := models.CheckoutSession{PublicToken: "merge-target", UserID: &user.ID, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&guest).Error)
+	require.NoError(t, db.Create(&target).Error)
+	cart := models.Cart{CheckoutSessionID: guest.ID}
+	require.NoError(t, db.Create(&cart).Error)
+	for _, item := range []models.CartItem{
+		{CartID: cart.ID, ProductVariantID: packs.ID, Quantity: 18},
+		{CartID: cart.ID, ProductVariantID: scarce.ID, Quantity: 6},
+		{CartID: cart.ID, ProductVariantID: capped.ID, Quantity: 6},
+		{CartID: cart.ID, ProductVariantID: limited.ID, Quantity: 3},
+	} {
+		require.NoError(t, db.Create(&item).Error)
+	}
+
+	result, merged, err := MergeGuestCart(db, guest.ID, target.ID, now)
+	require.NoError(t, err)
+	require.True(t, merged)
+	require.Len(t, result.Lines, 4)
+	outcomes := map[uint]CartMergeLine{}
+	for _, line := range result.Lines {
+		outcomes[line.ProductVariantID] = line
+	}
+	assert.Equal(t, CartMergeOutcomeClamped, outcomes[packs.ID].Outcome)
+	assert.Equal(t, 12, outcomes[packs.ID].Quantity)
+	assert.Equal(t, CartMergeOutcomeDropped, outcomes[scarce.ID].Outcome)
+	assert.NotEmpty(t, outcomes[scarce.ID].Notice)
+	assert.Equal(t, CartMergeOutcomeClamped, outcomes[capped.ID].Outcome)
+	assert.Equal(t, 4, outcomes[capped.ID].Quantity)
+	assert.Equal(t, CartMergeOutcomeClamped, outcomes[limited.ID].Outcome)
+	assert.Equal(t, 1, outcomes[limited.ID].Quantity)
+
+	var items []models.CartItem
+	require.NoError(t, db.Joins("JOIN carts ON carts.id = cart_items.cart_id").Where("carts.checkout_session_id = ?", target.ID).Find(&items).Error)
+	assert.Len(t, items, 3)
+}
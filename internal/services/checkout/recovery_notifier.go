@@ -0,0 +1,83 @@
+package checkout
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// NewAbandonedCartNotifier returns the local stand-in notifier: messages are
+// appended as JSON lines to outboxPath, or logged when no path is set. A real
+// email integration implements AbandonedCartNotifier instead.
+func NewAbandonedCartNotifier(outboxPath string, logger *log.Logger) AbandonedCartNotifier {
+	if outboxPath != "" {
+		return &FileAbandonedCartNotifier{Path: outboxPath}
+	}
+	return LogAbandonedCartNotifier{Logger: logger}
+}
+
+// LogAbandonedCartNotifier writes recovery messages to the application log.
+// The restore link is a bearer credential for the cart, so only its path is
+// logged, and the email address is masked.
+type LogAbandonedCartNotifier struct {
+	Logger *log.Logger
+}
+
+func (n LogAbandonedCartNotifier) NotifyAbandonedCart(_ context.Context, message AbandonedCartMessage) error {
+	logger := n.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	logger.Printf("[INFO] Abandoned cart recovery cart=%d email=%s items=%d subtotal=%s link=%s",
+		message.AbandonedCartID, maskEmail(message.Email), len(message.Items), message.Subtotal, redactRestoreURL(message.RestoreURL))
+	return nil
+}
+
+// maskEmail keeps the first character of the local part and the domain.
+func maskEmail(email string) string {
+	local, domain, found := strings.Cut(strings.TrimSpace(email), "@")
+	if !found || local == "" {
+		return "[REDACTED]"
+	}
+	return local[:1] + "***@" + domain
+}
+
+// redactRestoreURL drops the token from a restore link.
+func redactRestoreURL(link string) string {
+	base, _, found := strings.Cut(link, "?")
+	if !found {
+		return link
+	}
+	return base + "?token=[REDACTED]"
+}
+
+// FileAbandonedCartNotifier appends recovery messages to a JSON-lines outbox.
+type FileAbandonedCartNotifier struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (n *FileAbandonedCartNotifier) NotifyAbandonedCart(_ context.Context, message AbandonedCartMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(n.Path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(n.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(payload, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
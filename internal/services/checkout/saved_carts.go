@@ -0,0 +1,335 @@
+package checkout
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxSavedCartItems = 100
+
+var (
+	ErrInvalidSavedCart    = errors.New("invalid saved cart")
+	ErrSavedCartNotFound   = errors.New("saved cart not found")
+	ErrSavedCartExpired    = errors.New("saved cart has expired")
+	ErrSavedCartRestricted = errors.New("saved cart is reserved for another customer")
+	ErrSavedCartConverted  = errors.New("price-locked saved cart was already converted")
+)
+
+type SavedCartItemInput struct {
+	ProductVariantID uint
+	Quantity         int
+	// UnitPrice overrides the current variant price. Only valid together with
+	// LockPrices.
+	UnitPrice *models.Money
+}
+
+// CreateSavedCartInput builds a saved cart from explicit lines, typically by
+// an admin on behalf of CustomerUserID.
+type CreateSavedCartInput struct {
+	Name           string
+	Note           string
+	OwnerUserID    *uint
+	CustomerUserID *uint
+	CreatedBy      string
+	LockPrices     bool
+	ExpiresAt      *time.Time
+	Items          []SavedCartItemInput
+}
+
+// SaveCartInput copies a live checkout session cart into a saved cart.
+type SaveCartInput struct {
+	SessionID   uint
+	OwnerUserID *uint
+	Name        string
+	Note        string
+	ExpiresAt   *time.Time
+}
+
+type ListSavedCartsInput struct {
+	// UserID matches carts the user owns or that were prepared for them.
+	UserID         *uint
+	CustomerUserID *uint
+	Status         string
+	Limit          int
+	Offset         int
+}
+
+type SavedCartConversion struct {
+	SavedCart models.SavedCart
+	Lines     []CartMergeLine
+}
+
+func CreateSavedCart(db *gorm.DB, input CreateSavedCartInput, now time.Time) (models.SavedCart, error) {
+	if len(input.Items) == 0 {
+		return models.SavedCart{}, fmt.Errorf("%w: at least one item is required", ErrInvalidSavedCart)
+	}
+	if len(input.Items) > maxSavedCartItems {
+		return models.SavedCart{}, fmt.Errorf("%w: at most %d items are allowed", ErrInvalidSavedCart, maxSavedCartItems)
+	}
+	if input.LockPrices && input.ExpiresAt == nil {
+		return models.SavedCart{}, fmt.Errorf("%w: locked prices require an expiry", ErrInvalidSavedCart)
+	}
+	cart, err := newSavedCart(input.Name, input.Note, input.ExpiresAt, now)
+	if err != nil {
+		return models.SavedCart{}, err
+	}
+	cart.OwnerUserID, cart.CustomerUserID = input.OwnerUserID, input.CustomerUserID
+	cart.CreatedBy, cart.LockPrices = input.CreatedBy, input.LockPrices
+
+	positions := map[uint]int{}
+	for _, item := range input.Items {
+		if item.ProductVariantID == 0 || item.Quantity < 1 {
+			return models.SavedCart{}, fmt.Errorf("%w: items need a variant and a positive quantity", ErrInvalidSavedCart)
+		}
+		if item.UnitPrice != nil && (!input.LockPrices || *item.UnitPrice < 0) {
+			return models.SavedCart{}, fmt.Errorf("%w: unit prices can only be set on price-locked carts and cannot be negative", ErrInvalidSavedCart)
+		}
+		var variant models.ProductVariant
+		if err := db.Where("id = ? AND is_published = ?", item.ProductVariantID, true).First(&variant).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return models.SavedCart{}, fmt.Errorf("%w: variant %d", ErrVariantNotFound, item.ProductVariantID)
+			}
+			return models.SavedCart{}, err
+		}
+		price := variant.Price
+		if item.UnitPrice != nil {
+			price = *item.UnitPrice
+		}
+		if index, ok := positions[variant.ID]; ok {
+			cart.Items[index].Quantity += item.Quantity
+			cart.Items[index].UnitPrice = price
+			continue
+		}
+		positions[variant.ID] = len(cart.Items)
+		cart.Items = append(cart.Items, models.SavedCartItem{ProductVariantID: variant.ID, Quantity: item.Quantity, UnitPrice: price, Position: len(cart.Items)})
+	}
+	if err := db.Create(&cart).Error; err != nil {
+		return models.SavedCart{}, err
+	}
+	return GetSavedCart(db, cart.ID)
+}
+
+// SaveCart snapshots the items of a live session cart at their current prices.
+func SaveCart(db *gorm.DB, input SaveCartInput, now time.Time) (models.SavedCart, error) {
+	var source models.Cart
+	err := db.Where("checkout_session_id = ?", input.SessionID).Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("Items.ProductVariant").First(&source).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.SavedCart{}, err
+	}
+	if len(source.Items) == 0 {
+		return models.SavedCart{}, fmt.Errorf("%w: the cart is empty", ErrInvalidSavedCart)
+	}
+	cart, err := newSavedCart(input.Name, input.Note, input.ExpiresAt, now)
+	if err != nil {
+		return models.SavedCart{}, err
+	}
+	cart.OwnerUserID = input.OwnerUserID
+	for index, item := range source.Items {
+		cart.Items = append(cart.Items, models.SavedCartItem{ProductVariantID: item.ProductVariantID, Quantity: item.Quantity, UnitPrice: item.UnitPrice(now), Position: index})
+	}
+	if err := db.Create(&cart).Error; err != nil {
+		return models.SavedCart{}, err
+	}
+	return GetSavedCart(db, cart.ID)
+}
+
+func newSavedCart(name, note string, expiresAt *time.Time, now time.Time) (models.SavedCart, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 120 {
+		return models.SavedCart{}, fmt.Errorf("%w: name is required and must be at most 120 characters", ErrInvalidSavedCart)
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return models.SavedCart{}, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidSavedCart)
+	}
+	token, err := newShareToken()
+	if err != nil {
+		return models.SavedCart{}, err
+	}
+	if expiresAt != nil {
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+	return models.SavedCart{Name: name, Note: strings.TrimSpace(note), ShareToken: token, ExpiresAt: expiresAt, Status: models.SavedCartStatusActive}, nil
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func GetSavedCart(db *gorm.DB, id uint) (models.SavedCart, error) {
+	return loadSavedCart(db.Where("id = ?", id))
+}
+
+func GetSavedCartByToken(db *gorm.DB, token string) (models.SavedCart, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return models.SavedCart{}, ErrSavedCartNotFound
+	}
+	return loadSavedCart(db.Where("share_token = ? AND status = ?", token, models.SavedCartStatusActive))
+}
+
+func loadSavedCart(query *gorm.DB) (models.SavedCart, error) {
+	var cart models.SavedCart
+	err := query.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC, id ASC")
+	}).Preload("Items.ProductVariant.Product").First(&cart).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.SavedCart{}, ErrSavedCartNotFound
+	}
+	return cart, err
+}
+
+func ListSavedCarts(db *gorm.DB, input ListSavedCartsInput) ([]models.SavedCart, int64, error) {
+	query := db.Model(&models.SavedCart{})
+	if input.UserID != nil {
+		query = query.Where("owner_user_id = ? OR customer_user_id = ?", *input.UserID, *input.UserID)
+	}
+	if input.CustomerUserID != nil {
+		query = query.Where("customer_user_id = ?", *input.CustomerUserID)
+	}
+	if status := strings.ToUpper(strings.TrimSpace(input.Status)); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var values []models.SavedCart
+	err := query.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC, id ASC")
+	}).Preload("Items.ProductVariant.Product").Order("created_at DESC, id DESC").Limit(input.Limit).Offset(input.Offset).Find(&values).Error
+	return values, total, err
+}
+
+// ArchiveSavedCart disables the share link. When ownerUserID is set only that
+// user's carts can be archived.
+func ArchiveSavedCart(db *gorm.DB, id uint, ownerUserID *uint) (models.SavedCart, error) {
+	query := db.Model(&models.SavedCart{}).Where("id = ?", id)
+	if ownerUserID != nil {
+		query = query.Where("owner_user_id = ?", *ownerUserID)
+	}
+	result := query.Update("status", models.SavedCartStatusArchived)
+	if result.Error != nil {
+		return models.SavedCart{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.SavedCart{}, ErrSavedCartNotFound
+	}
+	return GetSavedCart(db, id)
+}
+
+// ConvertSavedCart adds a saved cart's lines to the cart of a live checkout
+// session. Lines are checked against current stock like a guest cart merge;
+// price-locked carts pin the resulting lines to the saved unit price, for the
+// saved quantity, until the saved cart expires. A price-locked cart is a quote
+// for a single order, so its share token converts only once.
+func ConvertSavedCart(db *gorm.DB, token string, sessionID uint, userID uint, now time.Time) (SavedCartConversion, error) {
+	conversion := SavedCartConversion{Lines: []CartMergeLine{}}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		saved, err := GetSavedCartByToken(tx.Clauses(clause.Locking{Strength: "UPDATE"}), token)
+		if err != nil {
+			return err
+		}
+		if saved.Expired(now) {
+			return ErrSavedCartExpired
+		}
+		if saved.CustomerUserID != nil && userID != *saved.CustomerUserID && (saved.OwnerUserID == nil || userID != *saved.OwnerUserID) {
+			return ErrSavedCartRestricted
+		}
+		if saved.LockPrices && saved.ConversionCount > 0 {
+			return ErrSavedCartConverted
+		}
+
+		var session models.CheckoutSession
+		if err := tx.First(&session, sessionID).Error; err != nil {
+			return err
+		}
+		var cart models.Cart
+		err = tx.Where("checkout_session_id = ?", sessionID).First(&cart).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			cart = models.Cart{CheckoutSessionID: sessionID}
+			err = tx.Create(&cart).Error
+		}
+		if err != nil {
+			return err
+		}
+		for _, item := range saved.Items {
+			line, err := mergeCartLine(tx, cart.ID, customerForSession(session), models.CartItem{ProductVariantID: item.ProductVariantID, Quantity: item.Quantity})
+			if err != nil {
+				return err
+			}
+			conversion.Lines = append(conversion.Lines, line)
+			if line.Outcome == CartMergeOutcomeDropped {
+				continue
+			}
+			updates := map[string]any{"saved_cart_id": saved.ID}
+			if saved.LockPrices {
+				price := item.UnitPrice
+				updates["locked_price"] = &price
+				updates["locked_until"] = saved.ExpiresAt
+				updates["locked_quantity"] = item.Quantity
+			}
+			if err := tx.Model(&models.CartItem{}).Where("cart_id = ? AND product_variant_id = ?", cart.ID, item.ProductVariantID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&models.SavedCart{}).Where("id = ?", saved.ID).Updates(map[string]any{
+			"conversion_count":  gorm.Expr("conversion_count + 1"),
+			"last_converted_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		saved.ConversionCount++
+		saved.LastConvertedAt = &now
+		conversion.SavedCart = saved
+		return nil
+	})
+	if err != nil {
+		return SavedCartConversion{}, err
+	}
+	return conversion, nil
+}
+
+func (s *Service) CreateSavedCart(ctx context.Context, input CreateSavedCartInput, now time.Time) (models.SavedCart, error) {
+	return CreateSavedCart(s.db.WithContext(ctx), input, now)
+}
+
+func (s *Service) SaveCart(ctx context.Context, input SaveCartInput, now time.Time) (models.SavedCart, error) {
+	return SaveCart(s.db.WithContext(ctx), input, now)
+}
+
+func (s *Service) GetSavedCart(ctx context.Context, id uint) (models.SavedCart, error) {
+	return GetSavedCart(s.db.WithContext(ctx), id)
+}
+
+func (s *Service) GetSavedCartByToken(ctx context.Context, token string) (models.SavedCart, error) {
+	return GetSavedCartByToken(s.db.WithContext(ctx), token)
+}
+
+func (s *Service) ListSavedCarts(ctx context.Context, input ListSavedCartsInput) ([]models.SavedCart, int64, error) {
+	return ListSavedCarts(s.db.WithContext(ctx), input)
+}
+
+func (s *Service) ArchiveSavedCart(ctx context.Context, id uint, ownerUserID *uint) (models.SavedCart, error) {
+	return ArchiveSavedCart(s.db.WithContext(ctx), id, ownerUserID)
+}
+
+func (s *Service) ConvertSavedCart(ctx context.Context, token string, sessionID uint, userID uint, now time.Time) (SavedCartConversion, error) {
+	return ConvertSavedCart(s.db.WithContext(ctx), token, sessionID, userID, now)
+}
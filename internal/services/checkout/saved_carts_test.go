@@ -0,0 +1,123 @@
+package checkout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedCartQuoteConvertsWithLockedPricesForTheCustomer(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.SavedCart{}, &models.SavedCartItem{}))
+	now := time.Date(2026, 8, 15, 9, 0, 0, 0, time.UTC)
+
+	product := models.Product{SKU: "quote-product", Name: "Desk", Price: models.MoneyFromFloat(40)}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "quote-desk", Title: "Desk", Price: product.Price, Stock: 5, IsPublished: true}
+	require.NoError(t, db.Create(&variant).Error)
+
+	quoted := models.MoneyFromFloat(30)
+	customerID := uint(7)
+	expiresAt := now.Add(48 * time.Hour)
+	_, err := CreateSavedCart(db, CreateSavedCartInput{Name: "Office fit-out", Items: []SavedCartItemInput{{ProductVariantID: variant.ID, Quantity: 2, UnitPrice: &quoted}}}, now)
+	assert.ErrorIs(t, err, ErrInvalidSavedCart)
+
+	saved, err := CreateSavedCart(db, CreateSavedCartInput{
+		Name: "Office fit-out", CustomerUserID: &customerID, CreatedBy: "rep@example.com", LockPrices: true, ExpiresAt: &expiresAt,
+		Items: []SavedCartItemInput{{ProductVariantID: variant.ID, Quantity: 2, UnitPrice: &quoted}},
+	}, now)
+	require.NoError(t, err)
+	assert.Len(t, saved.ShareToken, 64)
+	require.Len(t, saved.Items, 1)
+	assert.Equal(t, quoted, saved.Items[0].UnitPrice)
+
+	guest := models.CheckoutSession{PublicToken: "quote-guest", Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&guest).Error)
+	_, err = ConvertSavedCart(db, saved.ShareToken, guest.ID, 0, now)
+	assert.ErrorIs(t, err, ErrSavedCartRestricted)
+
+	session := models.CheckoutSession{PublicToken: "quote-customer", UserID: &customerID, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&session).Error)
+	conversion, err := ConvertSavedCart(db, saved.ShareToken, session.ID, customerID, now)
+	require.NoError(t, err)
+	require.Len(t, conversion.Lines, 1)
+	assert.Equal(t, CartMergeOutcomeAdded, conversion.Lines[0].Outcome)
+	assert.Equal(t, 1, conversion.SavedCart.ConversionCount)
+
+	cart, err := NewService(db).CartForSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 1)
+	assert.Equal(t, quoted, cart.Items[0].UnitPrice(now))
+	assert.Equal(t, variant.Price, cart.Items[0].UnitPrice(expiresAt))
+	require.NotNil(t, cart.Items[0].SavedCartID)
+	assert.Equal(t, saved.ID, *cart.Items[0].SavedCartID)
+
+	other := models.CheckoutSession{PublicToken: "quote-customer-again", UserID: &customerID, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&other).Error)
+	_, err = ConvertSavedCart(db, saved.ShareToken, other.ID, customerID, now)
+	assert.ErrorIs(t, err, ErrSavedCartConverted)
+
+	// The quote covers two desks; a third one drops the line to list price.
+	line := cart.Items[0]
+	assert.Equal(t, 2, line.LockedQuantity)
+	line.Quantity = 3
+	assert.Equal(t, variant.Price, line.UnitPrice(now))
+
+	_, err = ConvertSavedCart(db, saved.ShareToken, session.ID, customerID, expiresAt)
+	assert.ErrorIs(t, err, ErrSavedCartExpired)
+
+	_, err = ArchiveSavedCart(db, saved.ID, &customerID)
+	assert.ErrorIs(t, err, ErrSavedCartNotFound)
+	_, err = ArchiveSavedCart(db, saved.ID, nil)
+	require.NoError(t, err)
+	_, err = GetSavedCartByToken(db, saved.ShareToken)
+	assert.ErrorIs(t, err, ErrSavedCartNotFound)
+}
+
+func TestSaveCartSnapshotsSessionCartForSharing(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.SavedCart{}, &models.SavedCartItem{}))
+	now := time.Date(2026, 8, 15, 9, 0, 0, 0, time.UTC)
+
+	product := models.Product{SKU: "share-product", Name: "Chair", Price: models.MoneyFromFloat(12)}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "share-chair", Title: "Chair", Price: product.Price, Stock: 10, IsPublished: true}
+	require.NoError(t, db.Create(&variant).Error)
+
+	ownerID := uint(3)
+	owner := models.CheckoutSession{PublicToken: "share-owner", UserID: &ownerID, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&owner).Error)
+	_, err := SaveCart(db, SaveCartInput{SessionID: owner.ID, OwnerUserID: &ownerID, Name: "Chairs"}, now)
+	assert.ErrorIs(t, err, ErrInvalidSavedCart)
+
+	cart := models.Cart{CheckoutSessionID: owner.ID}
+	require.NoError(t, db.Create(&cart).Error)
+	require.NoError(t, db.Create(&models.CartItem{CartID: cart.ID, ProductVariantID: variant.ID, Quantity: 4}).Error)
+	saved, err := SaveCart(db, SaveCartInput{SessionID: owner.ID, OwnerUserID: &ownerID, Name: " Chairs "}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "Chairs", saved.Name)
+	assert.False(t, saved.LockPrices)
+
+	friend := models.CheckoutSession{PublicToken: "share-friend", Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&friend).Error)
+	conversion, err := ConvertSavedCart(db, saved.ShareToken, friend.ID, 0, now)
+	require.NoError(t, err)
+	require.Len(t, conversion.Lines, 1)
+	assert.Equal(t, 4, conversion.Lines[0].Quantity)
+
+	friendCart, err := NewService(db).CartForSession(context.Background(), friend.ID)
+	require.NoError(t, err)
+	require.Len(t, friendCart.Items, 1)
+	assert.Nil(t, friendCart.Items[0].LockedPrice)
+
+	values, total, err := ListSavedCarts(db, ListSavedCartsInput{UserID: &ownerID, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, values, 1)
+	assert.Equal(t, 1, values[0].ConversionCount)
+}
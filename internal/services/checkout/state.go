@@ -0,0 +1,550 @@
+package checkout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"ecommerce/internal/checkoutplugins"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CheckoutStepContact         = "contact"
+	CheckoutStepShippingAddress = "shipping_address"
+	CheckoutStepShippingMethod  = "shipping_method"
+	CheckoutStepPayment         = "payment"
+	CheckoutStepReview          = "review"
+)
+
+// CheckoutSteps lists the single-page checkout steps in order. A change to a
+// step invalidates every step after it.
+var CheckoutSteps = []string{CheckoutStepContact, CheckoutStepShippingAddress, CheckoutStepShippingMethod, CheckoutStepPayment, CheckoutStepReview}
+
+const (
+	CheckoutStepStatusComplete   = "COMPLETE"
+	CheckoutStepStatusIncomplete = "INCOMPLETE"
+	CheckoutStepStatusStale      = "STALE"
+)
+
+const (
+	CheckoutActionAddItems               = "ADD_ITEMS"
+	CheckoutActionProvideContact         = "PROVIDE_CONTACT"
+	CheckoutActionProvideShippingAddress = "PROVIDE_SHIPPING_ADDRESS"
+	CheckoutActionSelectShippingMethod   = "SELECT_SHIPPING_METHOD"
+	CheckoutActionProvidePayment         = "PROVIDE_PAYMENT"
+	CheckoutActionConfirmReview          = "CONFIRM_REVIEW"
+	CheckoutActionPlaceOrder             = "PLACE_ORDER"
+)
+
+var checkoutStepActions = map[string]string{
+	CheckoutStepContact:         CheckoutActionProvideContact,
+	CheckoutStepShippingAddress: CheckoutActionProvideShippingAddress,
+	CheckoutStepShippingMethod:  CheckoutActionSelectShippingMethod,
+	CheckoutStepPayment:         CheckoutActionProvidePayment,
+	CheckoutStepReview:          CheckoutActionConfirmReview,
+}
+
+var (
+	ErrInvalidCheckoutStep    = errors.New("invalid checkout step")
+	ErrCheckoutStepOutOfOrder = errors.New("complete the earlier checkout steps first")
+)
+
+var (
+	requiredAddressFields = []string{"full_name", "line1", "city", "postal_code", "country"}
+	allowedAddressFields  = map[string]bool{"full_name": true, "company": true, "line1": true, "line2": true, "city": true, "state": true, "postal_code": true, "country": true}
+)
+
+// CheckoutStepError lists the validation problems for one step.
+type CheckoutStepError struct {
+	Step     string
+	Problems []string
+}
+
+func (e *CheckoutStepError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Step, strings.Join(e.Problems, "; "))
+}
+
+func (e *CheckoutStepError) Unwrap() error { return ErrInvalidCheckoutStep }
+
+// CheckoutState is the single-page checkout progress stored on a session.
+type CheckoutState struct {
+	// Revision increases whenever a step value changes; a review is only valid
+	// for the revision it was confirmed at.
+	Revision        int               `json:"revision"`
+	Contact         *CheckoutContact  `json:"contact,omitempty"`
+	ShippingAddress map[string]string `json:"shipping_address,omitempty"`
+	ShippingMethod  *CheckoutProvider `json:"shipping_method,omitempty"`
+	Payment         *CheckoutPayment  `json:"payment,omitempty"`
+	Review          *CheckoutReview   `json:"review,omitempty"`
+	// Confirmed marks steps submitted since the last change to an earlier step.
+	Confirmed map[string]bool `json:"confirmed,omitempty"`
+	// CartFingerprint identifies the cart contents the shipping method was
+	// chosen for.
+	CartFingerprint string `json:"cart_fingerprint,omitempty"`
+	// Attributes are the merchant checkout field values confirmed with the
+	// review.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+type CheckoutContact struct {
+	Email string `json:"email"`
+	Phone string `json:"phone,omitempty"`
+}
+
+type CheckoutProvider struct {
+	ProviderID string            `json:"provider_id"`
+	Data       map[string]string `json:"data,omitempty"`
+}
+
+type CheckoutPayment struct {
+	ProviderID string            `json:"provider_id"`
+	Data       map[string]string `json:"data,omitempty"`
+	TaxData    map[string]string `json:"tax_data,omitempty"`
+	Display    string            `json:"display,omitempty"`
+}
+
+type CheckoutReview struct {
+	SnapshotID      uint      `json:"snapshot_id"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	Revision        int       `json:"revision"`
+	CartFingerprint string    `json:"cart_fingerprint"`
+}
+
+// Selection returns the provider selection described by the shipping method
+// and payment steps. Address fields take precedence over method data.
+func (s CheckoutState) Selection() ProviderSelection {
+	selection := ProviderSelection{ShippingData: map[string]string{}}
+	if s.ShippingMethod != nil {
+		selection.ShippingProviderID = s.ShippingMethod.ProviderID
+		for key, value := range s.ShippingMethod.Data {
+			selection.ShippingData[key] = value
+		}
+	}
+	for key, value := range s.ShippingAddress {
+		selection.ShippingData[key] = value
+	}
+	if s.Payment != nil {
+		selection.PaymentProviderID = s.Payment.ProviderID
+		selection.PaymentData = s.Payment.Data
+		selection.TaxData = s.Payment.TaxData
+	}
+	return selection
+}
+
+type CheckoutStepView struct {
+	Step     string
+	Status   string
+	Problems []string
+}
+
+// CheckoutStateView is the evaluated checkout state returned to clients.
+type CheckoutStateView struct {
+	SessionID  uint
+	State      CheckoutState
+	Steps      []CheckoutStepView
+	NextAction string
+	ItemCount  int
+	Subtotal   models.Money
+	// Quote is set once a shipping method has been chosen.
+	Quote *checkoutplugins.QuoteResult
+}
+
+func (v CheckoutStateView) Step(step string) CheckoutStepView {
+	for _, value := range v.Steps {
+		if value.Step == step {
+			return value
+		}
+	}
+	return CheckoutStepView{Step: step, Status: CheckoutStepStatusIncomplete}
+}
+
+// EvaluateCheckoutState re-validates every step against the current cart and
+// provider configuration and works out the next required action.
+func EvaluateCheckoutState(db *gorm.DB, manager *checkoutplugins.Manager, sessionID uint, now time.Time) (CheckoutStateView, error) {
+	var session models.CheckoutSession
+	if err := db.Preload("User").First(&session, sessionID).Error; err != nil {
+		return CheckoutStateView{}, err
+	}
+	state, err := decodeCheckoutState(session.CheckoutStateJSON)
+	if err != nil {
+		return CheckoutStateView{}, err
+	}
+	view := CheckoutStateView{SessionID: session.ID, State: state}
+	fingerprint, subtotal, count, err := checkoutCartFingerprint(db, session.ID, now)
+	if err != nil {
+		return CheckoutStateView{}, err
+	}
+	view.Subtotal, view.ItemCount = subtotal, count
+
+	if state.Contact == nil && session.User != nil && session.User.Email != "" {
+		view.State.Contact = &CheckoutContact{Email: session.User.Email}
+		view.State.Confirmed = withConfirmed(view.State.Confirmed, CheckoutStepContact)
+	}
+	problems := map[string][]string{}
+	if state.ShippingMethod != nil && fingerprint != state.CartFingerprint {
+		problems[CheckoutStepShippingMethod] = append(problems[CheckoutStepShippingMethod], "Your cart changed. Confirm the shipping method again.")
+	}
+	if state.ShippingMethod != nil && manager != nil {
+		selection := view.State.Selection()
+		quote := manager.Quote(checkoutplugins.QuoteRequest{Subtotal: subtotal, PaymentID: selection.PaymentProviderID, ShippingID: selection.ShippingProviderID, PaymentData: selection.PaymentData, ShippingData: selection.ShippingData, TaxData: selection.TaxData})
+		view.Quote = &quote
+		problems[CheckoutStepShippingMethod] = append(problems[CheckoutStepShippingMethod], stateErrors(quote.ShippingStates)...)
+		if state.Payment != nil {
+			problems[CheckoutStepPayment] = append(stateErrors(quote.PaymentStates), stateErrors(quote.TaxStates)...)
+		}
+	}
+	if review := state.Review; review != nil {
+		switch {
+		case review.Revision != state.Revision || review.CartFingerprint != fingerprint:
+			problems[CheckoutStepReview] = []string{"Checkout details changed since the review. Review the order again."}
+		case !now.Before(review.ExpiresAt):
+			problems[CheckoutStepReview] = []string{"The review has expired. Review the order again."}
+		}
+	}
+
+	present := map[string]bool{
+		CheckoutStepContact:         view.State.Contact != nil,
+		CheckoutStepShippingAddress: len(state.ShippingAddress) > 0,
+		CheckoutStepShippingMethod:  state.ShippingMethod != nil,
+		CheckoutStepPayment:         state.Payment != nil,
+		CheckoutStepReview:          state.Review != nil,
+	}
+	blocked := false
+	for _, step := range CheckoutSteps {
+		status := CheckoutStepStatusIncomplete
+		switch {
+		case !present[step]:
+		case blocked || !view.State.Confirmed[step] || len(problems[step]) > 0:
+			status = CheckoutStepStatusStale
+		default:
+			status = CheckoutStepStatusComplete
+		}
+		if status != CheckoutStepStatusComplete {
+			blocked = true
+			if view.NextAction == "" {
+				view.NextAction = checkoutStepActions[step]
+			}
+		}
+		view.Steps = append(view.Steps, CheckoutStepView{Step: step, Status: status, Problems: problems[step]})
+	}
+	switch {
+	case count == 0:
+		view.NextAction = CheckoutActionAddItems
+	case view.NextAction == "":
+		view.NextAction = CheckoutActionPlaceOrder
+	}
+	return view, nil
+}
+
+func UpdateCheckoutContact(db *gorm.DB, sessionID uint, contact CheckoutContact) error {
+	var problems []string
+	address, err := mail.ParseAddress(strings.TrimSpace(contact.Email))
+	if err != nil {
+		problems = append(problems, "A valid email address is required.")
+	} else {
+		contact.Email = strings.ToLower(address.Address)
+	}
+	contact.Phone = strings.TrimSpace(contact.Phone)
+	if len(contact.Phone) > 32 || strings.Trim(contact.Phone, "0123456789+-(). ") != "" {
+		problems = append(problems, "Phone number is invalid.")
+	}
+	if len(problems) > 0 {
+		return &CheckoutStepError{Step: CheckoutStepContact, Problems: problems}
+	}
+	return mutateCheckoutState(db, sessionID, func(tx *gorm.DB, session models.CheckoutSession, state *CheckoutState) error {
+		changed := state.Contact == nil || *state.Contact != contact
+		state.Contact = &contact
+		confirmCheckoutStep(state, CheckoutStepContact, changed)
+		if session.UserID == nil {
+			return tx.Model(&models.CheckoutSession{}).Where("id = ?", session.ID).Update("guest_email", contact.Email).Error
+		}
+		return nil
+	})
+}
+
+func UpdateCheckoutShippingAddress(db *gorm.DB, sessionID uint, address map[string]string) error {
+	normalized := make(map[string]string, len(address))
+	var problems []string
+	for key, value := range address {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !allowedAddressFields[key] {
+			problems = append(problems, fmt.Sprintf("Unknown address field %q.", key))
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			normalized[key] = value
+		}
+		if len(value) > 200 {
+			problems = append(problems, fmt.Sprintf("%s must be at most 200 characters.", key))
+		}
+	}
+	for _, key := range requiredAddressFields {
+		if normalized[key] == "" {
+			problems = append(problems, fmt.Sprintf("%s is required.", key))
+		}
+	}
+	if country := strings.ToUpper(normalized["country"]); country != "" {
+		if len(country) != 2 {
+			problems = append(problems, "country must be a two-letter ISO code.")
+		}
+		normalized["country"] = country
+	}
+	if state := normalized["state"]; state != "" {
+		normalized["state"] = strings.ToUpper(state)
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return &CheckoutStepError{Step: CheckoutStepShippingAddress, Problems: problems}
+	}
+	return mutateCheckoutState(db, sessionID, func(_ *gorm.DB, session models.CheckoutSession, state *CheckoutState) error {
+		if err := requireCheckoutSteps(session, state, CheckoutStepContact); err != nil {
+			return err
+		}
+		changed := !equalStringMaps(state.ShippingAddress, normalized)
+		state.ShippingAddress = normalized
+		confirmCheckoutStep(state, CheckoutStepShippingAddress, changed)
+		return nil
+	})
+}
+
+func UpdateCheckoutShippingMethod(db *gorm.DB, manager *checkoutplugins.Manager, sessionID uint, method CheckoutProvider, now time.Time) error {
+	method.ProviderID = strings.TrimSpace(method.ProviderID)
+	return mutateCheckoutState(db, sessionID, func(tx *gorm.DB, session models.CheckoutSession, state *CheckoutState) error {
+		if err := requireCheckoutSteps(session, state, CheckoutStepContact, CheckoutStepShippingAddress); err != nil {
+			return err
+		}
+		fingerprint, subtotal, count, err := checkoutCartFingerprint(tx, session.ID, now)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return &CheckoutStepError{Step: CheckoutStepShippingMethod, Problems: []string{"The cart is empty."}}
+		}
+		candidate := *state
+		candidate.ShippingMethod = &method
+		selection := candidate.Selection()
+		quote := manager.Quote(checkoutplugins.QuoteRequest{Subtotal: subtotal, ShippingID: selection.ShippingProviderID, ShippingData: selection.ShippingData})
+		if problems := stateErrors(quote.ShippingStates); len(problems) > 0 {
+			return &CheckoutStepError{Step: CheckoutStepShippingMethod, Problems: problems}
+		}
+		changed := state.ShippingMethod == nil || state.ShippingMethod.ProviderID != method.ProviderID || !equalStringMaps(state.ShippingMethod.Data, method.Data) || state.CartFingerprint != fingerprint
+		state.ShippingMethod = &method
+		state.CartFingerprint = fingerprint
+		confirmCheckoutStep(state, CheckoutStepShippingMethod, changed)
+		return nil
+	})
+}
+
+func UpdateCheckoutPayment(db *gorm.DB, manager *checkoutplugins.Manager, sessionID uint, payment CheckoutPayment, now time.Time) error {
+	payment.ProviderID = strings.TrimSpace(payment.ProviderID)
+	payment.Display = ""
+	return mutateCheckoutState(db, sessionID, func(tx *gorm.DB, session models.CheckoutSession, state *CheckoutState) error {
+		if err := requireCheckoutSteps(session, state, CheckoutStepContact, CheckoutStepShippingAddress, CheckoutStepShippingMethod); err != nil {
+			return err
+		}
+		fingerprint, subtotal, _, err := checkoutCartFingerprint(tx, session.ID, now)
+		if err != nil {
+			return err
+		}
+		if fingerprint != state.CartFingerprint {
+			return ErrCheckoutStepOutOfOrder
+		}
+		candidate := *state
+		candidate.Payment = &payment
+		selection := candidate.Selection()
+		quote := manager.Quote(checkoutplugins.QuoteRequest{Subtotal: subtotal, PaymentID: selection.PaymentProviderID, ShippingID: selection.ShippingProviderID, PaymentData: selection.PaymentData, ShippingData: selection.ShippingData, TaxData: selection.TaxData})
+		if problems := append(stateErrors(quote.PaymentStates), stateErrors(quote.TaxStates)...); len(problems) > 0 {
+			return &CheckoutStepError{Step: CheckoutStepPayment, Problems: problems}
+		}
+		resolved, err := ResolveProviderSelection(manager, subtotal, selection)
+		if err != nil {
+			return &CheckoutStepError{Step: CheckoutStepPayment, Problems: []string{err.Error()}}
+		}
+		payment.Display = resolved.PaymentDisplay
+		changed := state.Payment == nil || state.Payment.ProviderID != payment.ProviderID || !equalStringMaps(state.Payment.Data, payment.Data) || !equalStringMaps(state.Payment.TaxData, payment.TaxData)
+		state.Payment = &payment
+		confirmCheckoutStep(state, CheckoutStepPayment, changed)
+		return nil
+	})
+}
+
+// RecordCheckoutReview completes the review step with the checkout snapshot
+// that was quoted for the given state revision.
+func RecordCheckoutReview(db *gorm.DB, sessionID uint, revision int, snapshotID uint, expiresAt time.Time, attributes map[string]string, now time.Time) error {
+	return mutateCheckoutState(db, sessionID, func(tx *gorm.DB, session models.CheckoutSession, state *CheckoutState) error {
+		if state.Revision != revision {
+			return ErrCheckoutStepOutOfOrder
+		}
+		if err := requireCheckoutSteps(session, state, CheckoutStepContact, CheckoutStepShippingAddress, CheckoutStepShippingMethod, CheckoutStepPayment); err != nil {
+			return err
+		}
+		fingerprint, _, _, err := checkoutCartFingerprint(tx, session.ID, now)
+		if err != nil {
+			return err
+		}
+		state.Review = &CheckoutReview{SnapshotID: snapshotID, ExpiresAt: expiresAt.UTC(), Revision: state.Revision, CartFingerprint: fingerprint}
+		state.Attributes = attributes
+		state.Confirmed = withConfirmed(state.Confirmed, CheckoutStepReview)
+		return nil
+	})
+}
+
+func LoadCheckoutState(db *gorm.DB, sessionID uint) (CheckoutState, error) {
+	var session models.CheckoutSession
+	if err := db.Select("id", "checkout_state_json").First(&session, sessionID).Error; err != nil {
+		return CheckoutState{}, err
+	}
+	return decodeCheckoutState(session.CheckoutStateJSON)
+}
+
+func mutateCheckoutState(db *gorm.DB, sessionID uint, apply func(tx *gorm.DB, session models.CheckoutSession, state *CheckoutState) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var session models.CheckoutSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&session, sessionID).Error; err != nil {
+			return err
+		}
+		state, err := decodeCheckoutState(session.CheckoutStateJSON)
+		if err != nil {
+			return err
+		}
+		if err := apply(tx, session, &state); err != nil {
+			return err
+		}
+		encoded, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.CheckoutSession{}).Where("id = ?", session.ID).Update("checkout_state_json", string(encoded)).Error
+	})
+}
+
+// confirmCheckoutStep marks step as confirmed. When its value changed, every
+// later step has to be confirmed again and any review is discarded.
+func confirmCheckoutStep(state *CheckoutState, step string, changed bool) {
+	state.Confirmed = withConfirmed(state.Confirmed, step)
+	if !changed {
+		return
+	}
+	state.Revision++
+	after := false
+	for _, candidate := range CheckoutSteps {
+		if after {
+			delete(state.Confirmed, candidate)
+		}
+		after = after || candidate == step
+	}
+	state.Review = nil
+}
+
+func requireCheckoutSteps(session models.CheckoutSession, state *CheckoutState, steps ...string) error {
+	for _, step := range steps {
+		if step == CheckoutStepContact && state.Contact == nil && session.UserID != nil {
+			continue
+		}
+		if !state.Confirmed[step] {
+			return ErrCheckoutStepOutOfOrder
+		}
+	}
+	return nil
+}
+
+func withConfirmed(confirmed map[string]bool, step string) map[string]bool {
+	if confirmed == nil {
+		confirmed = map[string]bool{}
+	}
+	confirmed[step] = true
+	return confirmed
+}
+
+func decodeCheckoutState(raw string) (CheckoutState, error) {
+	var state CheckoutState
+	if strings.TrimSpace(raw) == "" {
+		return state, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		return CheckoutState{}, fmt.Errorf("decode checkout state: %w", err)
+	}
+	return state, nil
+}
+
+// checkoutCartFingerprint hashes the variant, quantity and unit price of every
+// cart line so later steps can tell when the cart changed underneath them.
+func checkoutCartFingerprint(db *gorm.DB, sessionID uint, now time.Time) (string, models.Money, int, error) {
+	var cart models.Cart
+	err := db.Where("checkout_session_id = ?", sessionID).Preload("Items.ProductVariant").First(&cart).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", 0, 0, err
+	}
+	lines := make([]string, 0, len(cart.Items))
+	subtotal, count := models.Money(0), 0
+	for _, item := range cart.Items {
+		price := item.UnitPrice(now)
+		subtotal += price.Mul(item.Quantity)
+		count += item.Quantity
+		lines = append(lines, fmt.Sprintf("%d:%d:%d", item.ProductVariantID, item.Quantity, price))
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, ",")))
+	return hex.EncodeToString(sum[:16]), subtotal, count, nil
+}
+
+func stateErrors(states []checkoutplugins.State) []string {
+	var problems []string
+	for _, state := range states {
+		if state.Severity == checkoutplugins.SeverityError {
+			problems = append(problems, state.Message)
+		}
+	}
+	return problems
+}
+
+func equalStringMaps(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Service) CheckoutState(ctx context.Context, manager *checkoutplugins.Manager, sessionID uint, now time.Time) (CheckoutStateView, error) {
+	return EvaluateCheckoutState(s.db.WithContext(ctx), manager, sessionID, now)
+}
+
+func (s *Service) LoadCheckoutState(ctx context.Context, sessionID uint) (CheckoutState, error) {
+	return LoadCheckoutState(s.db.WithContext(ctx), sessionID)
+}
+
+func (s *Service) UpdateCheckoutContact(ctx context.Context, sessionID uint, contact CheckoutContact) error {
+	return UpdateCheckoutContact(s.db.WithContext(ctx), sessionID, contact)
+}
+
+func (s *Service) UpdateCheckoutShippingAddress(ctx context.Context, sessionID uint, address map[string]string) error {
+	return UpdateCheckoutShippingAddress(s.db.WithContext(ctx), sessionID, address)
+}
+
+func (s *Service) UpdateCheckoutShippingMethod(ctx context.Context, manager *checkoutplugins.Manager, sessionID uint, method CheckoutProvider, now time.Time) error {
+	return UpdateCheckoutShippingMethod(s.db.WithContext(ctx), manager, sessionID, method, now)
+}
+
+func (s *Service) UpdateCheckoutPayment(ctx context.Context, manager *checkoutplugins.Manager, sessionID uint, payment CheckoutPayment, now time.Time) error {
+	return UpdateCheckoutPayment(s.db.WithContext(ctx), manager, sessionID, payment, now)
+}
+
+func (s *Service) RecordCheckoutReview(ctx context.Context, sessionID uint, revision int, snapshotID uint, expiresAt time.Time, attributes map[string]string, now time.Time) error {
+	return RecordCheckoutReview(s.db.WithContext(ctx), sessionID, revision, snapshotID, expiresAt, attributes, now)
+}
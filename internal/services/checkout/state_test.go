@@ -0,0 +1,120 @@
+package checkout
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"ecommerce/internal/checkoutplugins"
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckoutStateAdvancesStepByStepAndInvalidatesLaterSteps(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.User{}))
+	manager := checkoutplugins.NewDefaultManager()
+	now := time.Date(2026, 8, 16, 10, 0, 0, 0, time.UTC)
+
+	product := models.Product{SKU: "state-product", Name: "Kettle", Price: models.MoneyFromFloat(20)}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "state-kettle", Title: "Kettle", Price: product.Price, Stock: 10, IsPublished: true}
+	require.NoError(t, db.Create(&variant).Error)
+	session := models.CheckoutSession{PublicToken: "state-guest", Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&session).Error)
+
+	view, err := EvaluateCheckoutState(db, manager, session.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckoutActionAddItems, view.NextAction)
+
+	cart := models.Cart{CheckoutSessionID: session.ID}
+	require.NoError(t, db.Create(&cart).Error)
+	line := models.CartItem{CartID: cart.ID, ProductVariantID: variant.ID, Quantity: 2}
+	require.NoError(t, db.Create(&line).Error)
+	view, err = EvaluateCheckoutState(db, manager, session.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckoutActionProvideContact, view.NextAction)
+
+	address := map[string]string{"full_name": "Sam Buyer", "line1": "1 Main St", "city": "Fresno", "state": "ca", "postal_code": "93650", "country": "us"}
+	assert.ErrorIs(t, UpdateCheckoutShippingAddress(db, session.ID, address), ErrCheckoutStepOutOfOrder)
+	var stepErr *CheckoutStepError
+	require.ErrorAs(t, UpdateCheckoutContact(db, session.ID, CheckoutContact{Email: "not-an-email"}), &stepErr)
+	assert.Equal(t, CheckoutStepContact, stepErr.Step)
+
+	require.NoError(t, UpdateCheckoutContact(db, session.ID, CheckoutContact{Email: "Sam@Example.com"}))
+	require.NoError(t, db.First(&session, session.ID).Error)
+	require.NotNil(t, session.GuestEmail)
+	assert.Equal(t, "sam@example.com", *session.GuestEmail)
+
+	require.ErrorIs(t, UpdateCheckoutShippingAddress(db, session.ID, map[string]string{"line1": "1 Main St"}), ErrInvalidCheckoutStep)
+	require.NoError(t, UpdateCheckoutShippingAddress(db, session.ID, address))
+	ground := CheckoutProvider{ProviderID: "dummy-ground", Data: map[string]string{"service_level": "standard"}}
+	require.NoError(t, UpdateCheckoutShippingMethod(db, manager, session.ID, ground, now))
+	card := CheckoutPayment{ProviderID: "dummy-card", Data: map[string]string{"cardholder_name": "Sam Buyer", "card_number": "4242424242424242", "exp_month": "12", "exp_year": strconv.Itoa(now.Year() + 2)}}
+	require.NoError(t, UpdateCheckoutPayment(db, manager, session.ID, card, now))
+
+	view, err = EvaluateCheckoutState(db, manager, session.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckoutActionConfirmReview, view.NextAction)
+	assert.Equal(t, "US", view.State.ShippingAddress["country"])
+	require.NotNil(t, view.Quote)
+	assert.Equal(t, models.MoneyFromFloat(40), view.Quote.Subtotal)
+	assert.Equal(t, models.MoneyFromFloat(5.99), view.Quote.Shipping)
+	assert.NotEmpty(t, view.State.Payment.Display)
+
+	require.NoError(t, RecordCheckoutReview(db, session.ID, view.State.Revision, 99, now.Add(time.Hour), nil, now))
+	view, err = EvaluateCheckoutState(db, manager, session.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckoutActionPlaceOrder, view.NextAction)
+
+	// Re-submitting an unchanged step keeps the review valid.
+	require.NoError(t, UpdateCheckoutContact(db, session.ID, CheckoutContact{Email: "sam@example.com"}))
+	view, err = EvaluateCheckoutState(db, manager, session.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckoutActionPlaceOrder, view.NextAction)
+
+	address["city"] = "Sacramento"
+	require.NoError(t, UpdateCheckoutShippingAddress(db, session.ID, address))
+	view, err = EvaluateCheckoutState(db, manager, session.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckoutActionSelectShippingMethod, view.NextAction)
+	assert.Equal(t, CheckoutStepStatusStale, view.Step(CheckoutStepShippingMethod).Status)
+	assert.Equal(t, CheckoutStepStatusStale, view.Step(CheckoutStepPayment).Status)
+	assert.Nil(t, view.State.Review)
+	assert.ErrorIs(t, UpdateCheckoutPayment(db, manager, session.ID, card, now), ErrCheckoutStepOutOfOrder)
+
+	require.NoError(t, UpdateCheckoutShippingMethod(db, manager, session.ID, ground, now))
+	require.NoError(t, UpdateCheckoutPayment(db, manager, session.ID, card, now))
+	require.NoError(t, db.Model(&line).Update("quantity", 3).Error)
+	view, err = EvaluateCheckoutState(db, manager, session.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckoutActionSelectShippingMethod, view.NextAction)
+	assert.NotEmpty(t, view.Step(CheckoutStepShippingMethod).Problems)
+}
+
+func TestCheckoutStateUsesAccountEmailForSignedInShoppers(t *testing.T) {
+	db := applicationTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.User{}))
+	now := time.Date(2026, 8, 16, 10, 0, 0, 0, time.UTC)
+
+	user := models.User{Username: "state-user", Email: "member@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	session := models.CheckoutSession{PublicToken: "state-member", UserID: &user.ID, Status: models.CheckoutSessionStatusActive, ExpiresAt: now.Add(SessionTTL), LastSeenAt: now}
+	require.NoError(t, db.Create(&session).Error)
+	cart := models.Cart{CheckoutSessionID: session.ID}
+	require.NoError(t, db.Create(&cart).Error)
+	product := models.Product{SKU: "member-product", Name: "Mug", Price: models.MoneyFromFloat(8)}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "member-mug", Title: "Mug", Price: product.Price, Stock: 3, IsPublished: true}
+	require.NoError(t, db.Create(&variant).Error)
+	require.NoError(t, db.Create(&models.CartItem{CartID: cart.ID, ProductVariantID: variant.ID, Quantity: 1}).Error)
+
+	view, err := EvaluateCheckoutState(db, checkoutplugins.NewDefaultManager(), session.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckoutActionProvideShippingAddress, view.NextAction)
+	require.NotNil(t, view.State.Contact)
+	assert.Equal(t, "member@example.com", view.State.Contact.Email)
+	require.NoError(t, UpdateCheckoutShippingAddress(db, session.ID, map[string]string{"full_name": "Member", "line1": "2 Side St", "city": "Austin", "state": "TX", "postal_code": "73301", "country": "US"}))
+}
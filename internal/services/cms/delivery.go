@@ -82,6 +82,9 @@ type RequestContext struct {
 	Referrer      string
 	UTMSource     string
 	SegmentKey    string
+	// SegmentKeys are the customer's resolved segment memberships; a rule's
+	// segment keys match when either SegmentKey or any of these matches.
+	SegmentKeys   []string
 	AssignmentKey string
 	CustomerKey   string
 	CorrelationID string
@@ -394,7 +397,19 @@ func ruleMatches(rule TargetingRule, request RequestContext) bool {
 		matchesValue(rule.AuthStates, authState) &&
 		matchesReferrer(rule.Referrers, request.Referrer) &&
 		matchesValue(rule.UTMSources, request.UTMSource) &&
-		matchesValue(rule.SegmentKeys, request.SegmentKey)
+		matchesSegment(rule.SegmentKeys, request)
+}
+
+func matchesSegment(allowed []string, request RequestContext) bool {
+	if matchesValue(allowed, request.SegmentKey) {
+		return true
+	}
+	for _, key := range request.SegmentKeys {
+		if matchesValue(allowed, key) {
+			return true
+		}
+	}
+	return false
 }
 
 func matchesValue(allowed []string, actual string) bool {
@@ -86,6 +86,15 @@ func TestDeliveryTargetingUsesDeterministicRuleMatching(t *testing.T) {
 	require.False(t, eligible)
 }
 
+func TestDeliveryTargetingMatchesResolvedCustomerSegments(t *testing.T) {
+	rule := TargetingRule{SegmentKeys: []string{"vip"}}
+	require.False(t, ruleMatches(rule, RequestContext{}))
+	require.True(t, ruleMatches(rule, RequestContext{SegmentKey: "VIP"}))
+	require.True(t, ruleMatches(rule, RequestContext{SegmentKeys: []string{"new-customers", "vip"}}))
+	require.False(t, ruleMatches(rule, RequestContext{SegmentKeys: []string{"new-customers"}}))
+	require.True(t, ruleMatches(TargetingRule{}, RequestContext{SegmentKeys: []string{"vip"}}))
+}
+
 func TestDeliveryExperimentAllocationIsStickyAndWithinTolerance(t *testing.T) {
 	service := NewPageService(newServiceTestDB(t))
 	published, draft := createDeliveryTestPage(t, service)
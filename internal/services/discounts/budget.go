@@ -0,0 +1,248 @@
+package discounts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrBudgetExhausted = fmt.Errorf("%w: discount budget exhausted", ErrInvalidCampaign)
+
+const (
+	LifecycleSourceBudget = "budget"
+
+	HistoryReasonBudgetExhausted = "budget_exhausted"
+
+	AuditEventBudgetAlert = "budget_alert"
+)
+
+// DefaultBudgetAlertThresholds are the percent-of-budget levels alerted on
+// when a campaign sets a budget without configuring its own thresholds.
+var DefaultBudgetAlertThresholds = []int{50, 80, 95}
+
+type BudgetStatus struct {
+	CampaignID      uint
+	Status          string
+	BudgetAmount    *models.Money
+	SpentAmount     models.Money
+	RemainingAmount *models.Money
+	AlertThresholds []int
+	Alerts          []models.DiscountBudgetAlert
+}
+
+func validateBudget(amount *models.Money, thresholds []int) error {
+	if amount == nil {
+		if len(thresholds) > 0 {
+			return fmt.Errorf("%w: budget_alert_thresholds require budget_amount", ErrInvalidCampaign)
+		}
+		return nil
+	}
+	if *amount <= 0 {
+		return fmt.Errorf("%w: budget_amount must be positive", ErrInvalidCampaign)
+	}
+	seen := map[int]struct{}{}
+	for _, threshold := range thresholds {
+		if threshold < 1 || threshold > 99 {
+			return fmt.Errorf("%w: budget alert thresholds must be between 1 and 99 percent", ErrInvalidCampaign)
+		}
+		if _, ok := seen[threshold]; ok {
+			return fmt.Errorf("%w: duplicate budget alert threshold", ErrInvalidCampaign)
+		}
+		seen[threshold] = struct{}{}
+	}
+	return nil
+}
+
+// encodeBudgetThresholds stores thresholds in ascending order, falling back to
+// DefaultBudgetAlertThresholds when a budget is set without any.
+func encodeBudgetThresholds(amount *models.Money, thresholds []int) string {
+	if amount == nil {
+		return "[]"
+	}
+	if len(thresholds) == 0 {
+		thresholds = DefaultBudgetAlertThresholds
+	}
+	sorted := append([]int(nil), thresholds...)
+	sort.Ints(sorted)
+	return mustEncodeJSON(sorted, "[]")
+}
+
+func BudgetThresholds(campaign models.DiscountCampaign) []int {
+	thresholds := []int{}
+	_ = json.Unmarshal([]byte(campaign.BudgetThresholdsJSON), &thresholds)
+	return thresholds
+}
+
+func campaignSpend(tx *gorm.DB, campaignID uint) (models.Money, error) {
+	var spent models.Money
+	err := tx.Model(&models.DiscountRedemption{}).
+		Select("COALESCE(SUM(applied_amount), 0)").
+		Where("campaign_id = ?", campaignID).
+		Row().
+		Scan(&spent)
+	return spent, err
+}
+
+// verifyBudget rejects a redemption that would take a campaign past its
+// budget. The caller must hold the campaign row lock.
+func verifyBudget(tx *gorm.DB, campaign models.DiscountCampaign, amount models.Money) error {
+	if campaign.BudgetAmount == nil {
+		return nil
+	}
+	spent, err := campaignSpend(tx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	if spent+amount > *campaign.BudgetAmount {
+		return ErrBudgetExhausted
+	}
+	return nil
+}
+
+// capCampaignToBudget trims the discount campaign just granted on result so
+// it never exceeds what is left of the campaign's budget. Lines are trimmed
+// in order; a line the leftover cannot reach loses the campaign entirely.
+// It reports whether the campaign still applies to any line.
+func capCampaignToBudget(db *gorm.DB, result *EvaluationResult, campaign models.DiscountCampaign) (bool, error) {
+	spent, err := campaignSpend(db, campaign.ID)
+	if err != nil {
+		return false, err
+	}
+	remaining := *campaign.BudgetAmount - spent
+	if remaining < 0 {
+		remaining = 0
+	}
+	applied := false
+	for i := range result.Lines {
+		line := &result.Lines[i]
+		kept := line.AppliedCampaigns[:0]
+		for _, entry := range line.AppliedCampaigns {
+			if entry.ID != campaign.ID {
+				kept = append(kept, entry)
+				continue
+			}
+			allowed := entry.DiscountAmount
+			if total := entry.DiscountAmount.Mul(line.Quantity); total > remaining {
+				allowed = remaining / models.Money(line.Quantity)
+				entry.BudgetLimited = true
+			}
+			line.DiscountAmount -= entry.DiscountAmount - allowed
+			if line.DiscountAmount < 0 {
+				line.DiscountAmount = 0
+			}
+			entry.DiscountAmount = allowed
+			remaining -= allowed.Mul(line.Quantity)
+			if allowed <= 0 {
+				continue
+			}
+			kept = append(kept, entry)
+			applied = true
+		}
+		line.AppliedCampaigns = kept
+	}
+	return applied, nil
+}
+
+// applyBudgetControls raises alerts for every threshold the campaign's spend
+// has crossed and disables the campaign once its budget is used up, or once a
+// redemption had to be trimmed because the leftover budget could not cover it.
+func applyBudgetControls(tx *gorm.DB, campaignID uint, exhausted bool, now time.Time) error {
+	var campaign models.DiscountCampaign
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&campaign, campaignID).Error; err != nil {
+		return err
+	}
+	if campaign.BudgetAmount == nil {
+		return nil
+	}
+	budget := *campaign.BudgetAmount
+	spent, err := campaignSpend(tx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	for _, threshold := range BudgetThresholds(campaign) {
+		if int64(spent)*100 < int64(budget)*int64(threshold) {
+			continue
+		}
+		if err := raiseBudgetAlert(tx, campaign, threshold, spent, now); err != nil {
+			return err
+		}
+	}
+	if spent < budget && !exhausted {
+		return nil
+	}
+	if spent >= budget {
+		if err := raiseBudgetAlert(tx, campaign, 100, spent, now); err != nil {
+			return err
+		}
+	}
+	if campaign.Status == models.DiscountCampaignStatusDisabled || campaign.Status == models.DiscountCampaignStatusArchived {
+		return nil
+	}
+	return transitionCampaign(tx, &campaign, models.DiscountCampaignStatusDisabled, campaign.StartsAt, campaign.EndsAt, campaign.IsArchived, HistoryReasonBudgetExhausted, LifecycleSourceBudget, "", now.UTC())
+}
+
+// raiseBudgetAlert records a threshold crossing once per campaign, threshold
+// and budget amount, so raising the budget re-arms every alert.
+func raiseBudgetAlert(tx *gorm.DB, campaign models.DiscountCampaign, threshold int, spent models.Money, now time.Time) error {
+	budget := *campaign.BudgetAmount
+	var existing models.DiscountBudgetAlert
+	err := tx.Where("campaign_id = ? AND threshold_percent = ? AND budget_amount = ?", campaign.ID, threshold, budget).First(&existing).Error
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	alert := models.DiscountBudgetAlert{
+		CampaignID:       campaign.ID,
+		ThresholdPercent: threshold,
+		BudgetAmount:     budget,
+		SpentAmount:      spent,
+		TriggeredAt:      now.UTC(),
+	}
+	if err := tx.Create(&alert).Error; err != nil {
+		return err
+	}
+	summary := fmt.Sprintf("discount spend %s reached %d%% of budget %s", spent, threshold, budget)
+	log.Printf("discount_budget_alert campaign_id=%d threshold_percent=%d spent=%s budget=%s", campaign.ID, threshold, spent, budget)
+	return createCampaignAudit(tx, campaign.ID, AuditEventBudgetAlert, LifecycleSourceBudget, "", summary, nil, alert, now.UTC())
+}
+
+func GetBudgetStatus(db *gorm.DB, campaignID uint) (BudgetStatus, error) {
+	var campaign models.DiscountCampaign
+	if err := db.First(&campaign, campaignID).Error; err != nil {
+		return BudgetStatus{}, err
+	}
+	spent, err := campaignSpend(db, campaign.ID)
+	if err != nil {
+		return BudgetStatus{}, err
+	}
+	var alerts []models.DiscountBudgetAlert
+	if err := db.Where("campaign_id = ?", campaign.ID).Order("triggered_at DESC").Order("id DESC").Find(&alerts).Error; err != nil {
+		return BudgetStatus{}, err
+	}
+	status := BudgetStatus{
+		CampaignID:      campaign.ID,
+		Status:          campaign.Status,
+		BudgetAmount:    campaign.BudgetAmount,
+		SpentAmount:     spent,
+		AlertThresholds: BudgetThresholds(campaign),
+		Alerts:          alerts,
+	}
+	if campaign.BudgetAmount != nil {
+		remaining := *campaign.BudgetAmount - spent
+		if remaining < 0 {
+			remaining = 0
+		}
+		status.RemainingAmount = &remaining
+	}
+	return status, nil
+}
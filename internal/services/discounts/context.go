@@ -32,6 +32,12 @@ func (s *Service) ArchiveCampaign(ctx context.Context, id uint, actor string, no
 func (s *Service) UpsertSchedule(ctx context.Context, id uint, input ScheduleInput, now time.Time) (models.DiscountSchedule, error) {
 	return UpsertSchedule(s.db.WithContext(ctx), id, input, now)
 }
+func (s *Service) BudgetStatus(ctx context.Context, id uint) (BudgetStatus, error) {
+	return GetBudgetStatus(s.db.WithContext(ctx), id)
+}
+func (s *Service) PreviewSchedule(_ context.Context, input ScheduleInput, from time.Time, count int) ([]ScheduleOccurrence, error) {
+	return PreviewSchedule(input, from, count)
+}
 func (s *Service) RunLifecycle(ctx context.Context, now time.Time) (LifecycleResult, error) {
 	return RunLifecycle(s.db.WithContext(ctx), now)
 }
@@ -59,3 +65,6 @@ func (s *Service) CreateTemplate(ctx context.Context, input TemplateInput) (mode
 func (s *Service) InstantiateTemplate(ctx context.Context, id uint, input InstantiateTemplateInput) (models.DiscountCampaign, error) {
 	return InstantiateTemplate(s.db.WithContext(ctx), id, input)
 }
+func (s *Service) Simulate(ctx context.Context, input SimulationInput) (SimulationResult, error) {
+	return Simulate(s.db.WithContext(ctx), input)
+}
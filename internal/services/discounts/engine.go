@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	segmentservice "ecommerce/internal/services/segments"
 	"ecommerce/models"
 
 	"gorm.io/gorm"
@@ -47,11 +48,21 @@ type EvaluationResult struct {
 	FinalSubtotal models.Money
 }
 
+// EvaluationOptions narrows which campaigns apply. CustomerSegment is an
+// explicit segment key (used by admin previews); otherwise segment-targeted
+// campaigns match only when CustomerID belongs to the segment.
 type EvaluationOptions struct {
 	CouponCode         string
 	Channel            string
 	CustomerSegment    string
+	CustomerID         *uint
 	DisableCouponCodes bool
+
+	customerSegments []string
+	// campaignID restricts evaluation to a single campaign and simulation
+	// suppresses evaluation metrics; both are set only by Simulate.
+	campaignID uint
+	simulation bool
 }
 
 type RuleCondition struct {
@@ -88,6 +99,8 @@ type CreatePromotionInput struct {
 	CustomerSegment     string
 	GlobalUsageCap      *int
 	PerCustomerUsageCap *int
+	BudgetAmount        *models.Money
+	BudgetThresholds    []int
 	Rules               []PromotionRuleInput
 	Levels              []PromotionLevelInput
 	ActorID             *uint
@@ -126,7 +139,9 @@ func EvaluateCartWithOptions(db *gorm.DB, lines []CartLine, now time.Time, optio
 		Lines: make([]EvaluatedLine, 0, len(lines)),
 	}
 	defer func() {
-		recordEvaluationMetric(start, len(lines), candidateCount, result, evalErr)
+		if !options.simulation {
+			recordEvaluationMetric(start, len(lines), candidateCount, result, evalErr)
+		}
 	}()
 	for _, line := range lines {
 		if line.Quantity < 1 {
@@ -148,6 +163,14 @@ func EvaluateCartWithOptions(db *gorm.DB, lines []CartLine, now time.Time, optio
 		return result, nil
 	}
 
+	if strings.TrimSpace(options.CustomerSegment) == "" && options.CustomerID != nil {
+		keys, err := segmentservice.KeysForCustomer(db, *options.CustomerID)
+		if err != nil {
+			evalErr = err
+			return EvaluationResult{}, err
+		}
+		options.customerSegments = keys
+	}
 	campaigns, err := loadActiveCampaigns(db, now, options)
 	if err != nil {
 		evalErr = err
@@ -170,6 +193,13 @@ func EvaluateCartWithOptions(db *gorm.DB, lines []CartLine, now time.Time, optio
 				return EvaluationResult{}, err
 			}
 		}
+		if applied && campaign.BudgetAmount != nil {
+			applied, err = capCampaignToBudget(db, &result, campaign)
+			if err != nil {
+				evalErr = err
+				return EvaluationResult{}, err
+			}
+		}
 		if applied && campaign.IsExclusive {
 			exclusiveApplied = true
 		}
@@ -209,16 +239,18 @@ func CreatePromotion(db *gorm.DB, input CreatePromotionInput) (models.DiscountCa
 		IsExclusive: input.IsExclusive,
 		// Keep legacy P0 columns populated with harmless defaults; promotion
 		// actions live in discount_rules/discount_levels.
-		DiscountMode:        models.DiscountModeFixed,
-		DiscountValue:       0,
-		MetadataJSON:        mustEncodeJSON(input.Metadata, "{}"),
-		CouponCode:          normalizeCouponPtr(input.CouponCode),
-		ChannelsJSON:        mustEncodeJSON(input.Channels, "[]"),
-		CustomerSegment:     strings.TrimSpace(input.CustomerSegment),
-		GlobalUsageCap:      input.GlobalUsageCap,
-		PerCustomerUsageCap: input.PerCustomerUsageCap,
-		CreatedBy:           input.ActorID,
-		UpdatedBy:           input.ActorID,
+		DiscountMode:         models.DiscountModeFixed,
+		DiscountValue:        0,
+		MetadataJSON:         mustEncodeJSON(input.Metadata, "{}"),
+		CouponCode:           normalizeCouponPtr(input.CouponCode),
+		ChannelsJSON:         mustEncodeJSON(input.Channels, "[]"),
+		CustomerSegment:      strings.TrimSpace(input.CustomerSegment),
+		GlobalUsageCap:       input.GlobalUsageCap,
+		PerCustomerUsageCap:  input.PerCustomerUsageCap,
+		BudgetAmount:         input.BudgetAmount,
+		BudgetThresholdsJSON: encodeBudgetThresholds(input.BudgetAmount, input.BudgetThresholds),
+		CreatedBy:            input.ActorID,
+		UpdatedBy:            input.ActorID,
 	}
 	err := db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&campaign).Error; err != nil {
@@ -286,14 +318,17 @@ func CreatePromotion(db *gorm.DB, input CreatePromotionInput) (models.DiscountCa
 
 func loadActiveCampaigns(db *gorm.DB, now time.Time, options EvaluationOptions) ([]models.DiscountCampaign, error) {
 	var campaigns []models.DiscountCampaign
-	err := db.Preload("Targets").
+	query := db.Preload("Targets").
 		Preload("Rules").
 		Preload("Levels").
 		Where("status = ?", models.DiscountCampaignStatusActive).
 		Where("is_archived = ?", false).
 		Where("starts_at <= ?", now.UTC()).
-		Where("(ends_at IS NULL OR ends_at > ?)", now.UTC()).
-		Order("priority DESC").
+		Where("(ends_at IS NULL OR ends_at > ?)", now.UTC())
+	if options.campaignID != 0 {
+		query = query.Where("id = ?", options.campaignID)
+	}
+	err := query.Order("priority DESC").
 		Order("id ASC").
 		Find(&campaigns).Error
 	if err != nil {
@@ -332,11 +367,19 @@ func campaignMatchesOptions(campaign models.DiscountCampaign, options Evaluation
 	if len(channels) > 0 && !containsString(channels, channel) {
 		return false
 	}
-	segment := strings.TrimSpace(options.CustomerSegment)
-	if strings.TrimSpace(campaign.CustomerSegment) != "" && campaign.CustomerSegment != segment {
-		return false
+	required := strings.TrimSpace(campaign.CustomerSegment)
+	if required == "" {
+		return true
 	}
-	return true
+	if segment := strings.TrimSpace(options.CustomerSegment); segment != "" {
+		return strings.EqualFold(required, segment)
+	}
+	for _, segment := range options.customerSegments {
+		if strings.EqualFold(required, segment) {
+			return true
+		}
+	}
+	return false
 }
 
 func applyProductDiscountCampaign(result *EvaluationResult, campaign models.DiscountCampaign) bool {
@@ -590,6 +633,9 @@ func validatePromotion(input CreatePromotionInput) error {
 	if err := validateAdvancedControls(input.CouponCode, input.Channels, input.GlobalUsageCap, input.PerCustomerUsageCap); err != nil {
 		return err
 	}
+	if err := validateBudget(input.BudgetAmount, input.BudgetThresholds); err != nil {
+		return err
+	}
 	return nil
 }
 
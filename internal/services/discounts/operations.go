@@ -172,7 +172,7 @@ func RunReconciliation(db *gorm.DB, now time.Time) (ReconciliationReport, error)
 }
 
 func expectedScheduleState(schedule models.DiscountSchedule, now time.Time) (string, time.Time, *time.Time, bool, error) {
-	window, err := resolveScheduleWindow(schedule.ScheduleType, schedule.RRule, schedule.WindowStart, schedule.WindowEnd, schedule.UntilAt, now)
+	window, err := resolveScheduleWindow(schedule.ScheduleType, schedule.RRule, schedule.Timezone, schedule.WindowStart, schedule.WindowEnd, schedule.UntilAt, now)
 	if err != nil {
 		return "", time.Time{}, nil, false, err
 	}
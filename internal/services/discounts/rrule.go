@@ -0,0 +1,528 @@
+package discounts
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+)
+
+const (
+	rruleFreqDaily   = "DAILY"
+	rruleFreqWeekly  = "WEEKLY"
+	rruleFreqMonthly = "MONTHLY"
+
+	// maxEmptyRecurrencePeriods stops expansion of rules whose remaining
+	// periods can never produce an occurrence, e.g. BYMONTHDAY=30 with
+	// INTERVAL=12 anchored in February.
+	maxEmptyRecurrencePeriods = 400
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// recurrenceRule is the subset of RFC 5545 recurrence supported by discount
+// schedules: an RRULE with FREQ DAILY, WEEKLY or MONTHLY, optional INTERVAL,
+// BYDAY, BYMONTHDAY, BYHOUR, BYSETPOS, COUNT, UNTIL and WKST, plus any number
+// of EXDATE lines. Occurrence times are computed on the wall clock of the
+// schedule timezone so windows keep their local start time across DST changes.
+type recurrenceRule struct {
+	freq       string
+	interval   int
+	byDay      []weekdayNum
+	byMonthDay []int
+	byHour     []int
+	bySetPos   []int
+	count      int
+	until      *time.Time
+	weekStart  time.Weekday
+	exDates    []time.Time
+	exDays     []string
+}
+
+type weekdayNum struct {
+	n   int
+	day time.Weekday
+}
+
+// ScheduleOccurrence is one concrete activation window of a schedule.
+type ScheduleOccurrence struct {
+	Start time.Time
+	End   time.Time
+}
+
+// parseRecurrence accepts the legacy daily/weekly/monthly keywords or RFC 5545
+// content lines ("RRULE:..." and "EXDATE...:..."), separated by newlines. A
+// bare "FREQ=..." line is treated as an RRULE. Floating times in UNTIL and
+// EXDATE are read in loc.
+func parseRecurrence(raw string, loc *time.Location) (recurrenceRule, error) {
+	rule := recurrenceRule{interval: 1, weekStart: time.Monday}
+	lines := strings.FieldsFunc(strings.ReplaceAll(raw, "\r\n", "\n"), func(r rune) bool { return r == '\n' })
+	seenRule := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		upper := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(upper, "EXDATE"):
+			if err := rule.parseExDate(line[len("EXDATE"):], loc); err != nil {
+				return recurrenceRule{}, err
+			}
+			continue
+		case upper == "DAILY", upper == "WEEKLY", upper == "MONTHLY":
+			line = "FREQ=" + upper
+		case strings.HasPrefix(upper, "RRULE:"):
+			line = line[len("RRULE:"):]
+		case !strings.HasPrefix(upper, "FREQ=") && !strings.Contains(upper, ";FREQ="):
+			return recurrenceRule{}, fmt.Errorf("%w: unsupported recurrence line %q", ErrInvalidCampaign, line)
+		}
+		if seenRule {
+			return recurrenceRule{}, fmt.Errorf("%w: only one RRULE is supported", ErrInvalidCampaign)
+		}
+		seenRule = true
+		if err := rule.parseRule(line, loc); err != nil {
+			return recurrenceRule{}, err
+		}
+	}
+	if !seenRule {
+		return recurrenceRule{}, fmt.Errorf("%w: recurrence is required", ErrInvalidCampaign)
+	}
+	return rule, nil
+}
+
+func (r *recurrenceRule) parseRule(body string, loc *time.Location) error {
+	seen := map[string]bool{}
+	for _, part := range strings.Split(body, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return fmt.Errorf("%w: malformed RRULE part %q", ErrInvalidCampaign, part)
+		}
+		if seen[key] {
+			return fmt.Errorf("%w: duplicate RRULE part %s", ErrInvalidCampaign, key)
+		}
+		seen[key] = true
+		var err error
+		switch key {
+		case "FREQ":
+			switch value {
+			case rruleFreqDaily, rruleFreqWeekly, rruleFreqMonthly:
+				r.freq = value
+			default:
+				return fmt.Errorf("%w: unsupported RRULE FREQ %s", ErrInvalidCampaign, value)
+			}
+		case "INTERVAL":
+			r.interval, err = parseRRuleInt(key, value, 1, 1000)
+		case "COUNT":
+			r.count, err = parseRRuleInt(key, value, 1, 10000)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseRRuleTime(value, loc, true)
+			r.until = &until
+		case "BYDAY":
+			r.byDay, err = parseRRuleWeekdays(value)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseRRuleIntList(key, value, -31, 31)
+		case "BYHOUR":
+			r.byHour, err = parseRRuleIntList(key, value, 0, 23)
+		case "BYSETPOS":
+			r.bySetPos, err = parseRRuleIntList(key, value, -366, 366)
+		case "WKST":
+			day, ok := rruleWeekdays[value]
+			if !ok {
+				return fmt.Errorf("%w: invalid RRULE WKST %s", ErrInvalidCampaign, value)
+			}
+			r.weekStart = day
+		default:
+			return fmt.Errorf("%w: unsupported RRULE part %s", ErrInvalidCampaign, key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if r.freq == "" {
+		return fmt.Errorf("%w: RRULE FREQ is required", ErrInvalidCampaign)
+	}
+	if r.count > 0 && r.until != nil {
+		return fmt.Errorf("%w: RRULE cannot combine COUNT and UNTIL", ErrInvalidCampaign)
+	}
+	for _, value := range r.byMonthDay {
+		if value == 0 {
+			return fmt.Errorf("%w: RRULE BYMONTHDAY cannot be 0", ErrInvalidCampaign)
+		}
+	}
+	if len(r.byMonthDay) > 0 && r.freq == rruleFreqWeekly {
+		return fmt.Errorf("%w: RRULE BYMONTHDAY is not allowed with FREQ=WEEKLY", ErrInvalidCampaign)
+	}
+	for _, value := range r.bySetPos {
+		if value == 0 {
+			return fmt.Errorf("%w: RRULE BYSETPOS cannot be 0", ErrInvalidCampaign)
+		}
+	}
+	for _, day := range r.byDay {
+		if day.n != 0 && r.freq != rruleFreqMonthly {
+			return fmt.Errorf("%w: RRULE BYDAY ordinals require FREQ=MONTHLY", ErrInvalidCampaign)
+		}
+	}
+	return nil
+}
+
+// parseExDate parses the remainder of an EXDATE line after the property name,
+// i.e. optional ";TZID=..." / ";VALUE=DATE" parameters, a colon and a comma
+// separated list of values.
+func (r *recurrenceRule) parseExDate(rest string, loc *time.Location) error {
+	params, values, ok := strings.Cut(rest, ":")
+	if !ok || strings.TrimSpace(values) == "" {
+		return fmt.Errorf("%w: malformed EXDATE", ErrInvalidCampaign)
+	}
+	valueLoc := loc
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		switch strings.ToUpper(key) {
+		case "":
+		case "TZID":
+			parsed, err := time.LoadLocation(value)
+			if err != nil {
+				return fmt.Errorf("%w: invalid EXDATE TZID %q", ErrInvalidCampaign, value)
+			}
+			valueLoc = parsed
+		case "VALUE":
+			if upper := strings.ToUpper(value); upper != "DATE" && upper != "DATE-TIME" {
+				return fmt.Errorf("%w: unsupported EXDATE VALUE %q", ErrInvalidCampaign, value)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported EXDATE parameter %q", ErrInvalidCampaign, key)
+		}
+	}
+	for _, value := range strings.Split(values, ",") {
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if len(value) == len("20060102") {
+			if _, err := time.Parse("20060102", value); err != nil {
+				return fmt.Errorf("%w: invalid EXDATE %q", ErrInvalidCampaign, value)
+			}
+			r.exDays = append(r.exDays, value)
+			continue
+		}
+		parsed, err := parseRRuleTime(value, valueLoc, false)
+		if err != nil {
+			return err
+		}
+		r.exDates = append(r.exDates, parsed)
+	}
+	return nil
+}
+
+func parseRRuleTime(value string, loc *time.Location, allowDate bool) (time.Time, error) {
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse("20060102T150405Z", value)
+		if err == nil {
+			return parsed, nil
+		}
+	} else if parsed, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return parsed, nil
+	} else if allowDate {
+		if parsed, err := time.ParseInLocation("20060102", value, loc); err == nil {
+			// A date-only UNTIL is inclusive of the whole day.
+			return parsed.AddDate(0, 0, 1).Add(-time.Second), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: invalid RRULE date-time %q", ErrInvalidCampaign, value)
+}
+
+func parseRRuleInt(key, value string, minimum, maximum int) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < minimum || parsed > maximum {
+		return 0, fmt.Errorf("%w: RRULE %s must be between %d and %d", ErrInvalidCampaign, key, minimum, maximum)
+	}
+	return parsed, nil
+}
+
+func parseRRuleIntList(key, value string, minimum, maximum int) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		parsed, err := parseRRuleInt(key, strings.TrimPrefix(strings.TrimSpace(item), "+"), minimum, maximum)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, parsed)
+	}
+	return values, nil
+}
+
+func parseRRuleWeekdays(value string) ([]weekdayNum, error) {
+	var days []weekdayNum
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: invalid RRULE BYDAY %q", ErrInvalidCampaign, item)
+		}
+		day, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid RRULE BYDAY %q", ErrInvalidCampaign, item)
+		}
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			parsed, err := strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+			if err != nil || parsed == 0 || parsed < -5 || parsed > 5 {
+				return nil, fmt.Errorf("%w: invalid RRULE BYDAY %q", ErrInvalidCampaign, item)
+			}
+			n = parsed
+		}
+		days = append(days, weekdayNum{n: n, day: day})
+	}
+	return days, nil
+}
+
+// each calls fn with every occurrence start, in order, until fn returns false
+// or the rule is exhausted. dtstart anchors the rule and carries the schedule
+// location; candidates before it are skipped.
+func (r recurrenceRule) each(dtstart time.Time, fn func(time.Time) bool) {
+	emitted, empty := 0, 0
+	for period := 0; empty < maxEmptyRecurrencePeriods; period++ {
+		candidates := r.periodCandidates(dtstart, period)
+		if len(candidates) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+		for _, candidate := range candidates {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if r.until != nil && candidate.After(*r.until) {
+				return
+			}
+			if r.count > 0 && emitted >= r.count {
+				return
+			}
+			// COUNT applies to the RRULE set before EXDATE removes instances.
+			emitted++
+			if r.excluded(candidate) {
+				continue
+			}
+			if !fn(candidate) {
+				return
+			}
+		}
+	}
+}
+
+func (r recurrenceRule) excluded(value time.Time) bool {
+	for _, exDate := range r.exDates {
+		if exDate.Equal(value) {
+			return true
+		}
+	}
+	day := value.Format("20060102")
+	for _, exDay := range r.exDays {
+		if exDay == day {
+			return true
+		}
+	}
+	return false
+}
+
+// periodCandidates expands one FREQ period into sorted occurrence starts,
+// applying BYDAY/BYMONTHDAY/BYHOUR and then BYSETPOS. Dates are walked in UTC
+// as civil dates and only converted to the schedule location at the end.
+func (r recurrenceRule) periodCandidates(dtstart time.Time, period int) []time.Time {
+	year, month, dayOfMonth := dtstart.Date()
+	anchor := time.Date(year, month, dayOfMonth, 0, 0, 0, 0, time.UTC)
+	var days []time.Time
+	switch r.freq {
+	case rruleFreqDaily:
+		day := anchor.AddDate(0, 0, period*r.interval)
+		if r.matchesWeekday(day) && r.matchesMonthDay(day) {
+			days = append(days, day)
+		}
+	case rruleFreqWeekly:
+		offset := (int(anchor.Weekday()) - int(r.weekStart) + 7) % 7
+		weekStart := anchor.AddDate(0, 0, period*r.interval*7-offset)
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			if len(r.byDay) == 0 && day.Weekday() != anchor.Weekday() {
+				continue
+			}
+			if r.matchesWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case rruleFreqMonthly:
+		first := time.Date(year, month+time.Month(period*r.interval), 1, 0, 0, 0, 0, time.UTC)
+		daysInMonth := first.AddDate(0, 1, -1).Day()
+		for i := 0; i < daysInMonth; i++ {
+			day := first.AddDate(0, 0, i)
+			switch {
+			case len(r.byDay) == 0 && len(r.byMonthDay) == 0:
+				if day.Day() != dayOfMonth {
+					continue
+				}
+			case !r.matchesMonthDay(day) || !r.matchesMonthlyWeekday(day, daysInMonth):
+				continue
+			}
+			days = append(days, day)
+		}
+	}
+	if len(days) == 0 {
+		return nil
+	}
+	hours := r.byHour
+	if len(hours) == 0 {
+		hours = []int{dtstart.Hour()}
+	}
+	hours = append([]int(nil), hours...)
+	sort.Ints(hours)
+	minute, second := dtstart.Minute(), dtstart.Second()
+	candidates := make([]time.Time, 0, len(days)*len(hours))
+	for _, day := range days {
+		for _, hour := range hours {
+			candidates = append(candidates, time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, dtstart.Location()))
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return r.applySetPos(candidates)
+}
+
+func (r recurrenceRule) matchesWeekday(day time.Time) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	for _, value := range r.byDay {
+		if value.day == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r recurrenceRule) matchesMonthlyWeekday(day time.Time, daysInMonth int) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	for _, value := range r.byDay {
+		if value.day != day.Weekday() {
+			continue
+		}
+		switch {
+		case value.n == 0:
+			return true
+		case value.n > 0 && (day.Day()-1)/7+1 == value.n:
+			return true
+		case value.n < 0 && (daysInMonth-day.Day())/7+1 == -value.n:
+			return true
+		}
+	}
+	return false
+}
+
+func (r recurrenceRule) matchesMonthDay(day time.Time) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, value := range r.byMonthDay {
+		if value == day.Day() || (value < 0 && daysInMonth+value+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r recurrenceRule) applySetPos(candidates []time.Time) []time.Time {
+	if len(r.bySetPos) == 0 {
+		return candidates
+	}
+	selected := make([]time.Time, 0, len(r.bySetPos))
+	for _, position := range r.bySetPos {
+		index := position - 1
+		if position < 0 {
+			index = len(candidates) + position
+		}
+		if index >= 0 && index < len(candidates) {
+			selected = append(selected, candidates[index])
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+	unique := selected[:0]
+	for i, value := range selected {
+		if i == 0 || !value.Equal(selected[i-1]) {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// wallClockDuration measures a window on the local wall clock, so a
+// 17:00-23:00 window stays six local hours on days with a DST shift.
+func wallClockDuration(start, end time.Time) time.Duration {
+	return civilTime(end).Sub(civilTime(start))
+}
+
+func addWallClock(start time.Time, duration time.Duration) time.Time {
+	end := civilTime(start).Add(duration)
+	return time.Date(end.Year(), end.Month(), end.Day(), end.Hour(), end.Minute(), end.Second(), end.Nanosecond(), start.Location())
+}
+
+func civilTime(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), value.Hour(), value.Minute(), value.Second(), value.Nanosecond(), time.UTC)
+}
+
+func loadScheduleLocation(timezone string) (*time.Location, error) {
+	loc, err := time.LoadLocation(normalizeTimezone(timezone))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid timezone", ErrInvalidCampaign)
+	}
+	return loc, nil
+}
+
+// scheduleWindows walks the windows of a schedule in order, stopping when fn
+// returns false. One-time schedules yield their single window; recurring
+// schedules expand their recurrence in the schedule timezone and stop at
+// untilAt.
+func scheduleWindows(scheduleType, recurrence, timezone string, windowStart, windowEnd time.Time, untilAt *time.Time, fn func(ScheduleOccurrence) bool) error {
+	if !windowEnd.After(windowStart) {
+		return fmt.Errorf("%w: window_end must be after window_start", ErrInvalidCampaign)
+	}
+	switch scheduleType {
+	case models.DiscountScheduleTypeOneTime:
+		fn(ScheduleOccurrence{Start: windowStart.UTC(), End: windowEnd.UTC()})
+		return nil
+	case models.DiscountScheduleTypeRecurring:
+	default:
+		return fmt.Errorf("%w: unsupported schedule_type", ErrInvalidCampaign)
+	}
+	loc, err := loadScheduleLocation(timezone)
+	if err != nil {
+		return err
+	}
+	rule, err := parseRecurrence(recurrence, loc)
+	if err != nil {
+		return err
+	}
+	dtstart := windowStart.In(loc)
+	duration := wallClockDuration(dtstart, windowEnd.In(loc))
+	rule.each(dtstart, func(start time.Time) bool {
+		if untilAt != nil && start.After(*untilAt) {
+			return false
+		}
+		return fn(ScheduleOccurrence{Start: start.UTC(), End: addWallClock(start, duration).UTC()})
+	})
+	return nil
+}
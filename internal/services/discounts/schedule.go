@@ -37,6 +37,8 @@ type LifecycleResult struct {
 	Archived    int
 }
 
+const maxSchedulePreviewWindows = 100
+
 type scheduleWindow struct {
 	Start     time.Time
 	End       time.Time
@@ -56,7 +58,7 @@ func UpsertSchedule(db *gorm.DB, campaignID uint, input ScheduleInput, now time.
 			return err
 		}
 
-		window, err := resolveScheduleWindow(input.ScheduleType, input.Recurrence, input.WindowStart.UTC(), input.WindowEnd.UTC(), utcTimePtr(input.UntilAt), now.UTC())
+		window, err := resolveScheduleWindow(input.ScheduleType, input.Recurrence, input.Timezone, input.WindowStart.UTC(), input.WindowEnd.UTC(), utcTimePtr(input.UntilAt), now.UTC())
 		if err != nil {
 			return err
 		}
@@ -130,7 +132,7 @@ func RunLifecycle(db *gorm.DB, now time.Time) (LifecycleResult, error) {
 			if campaign.Status == models.DiscountCampaignStatusDisabled || campaign.Status == models.DiscountCampaignStatusArchived {
 				continue
 			}
-			window, err := resolveScheduleWindow(schedule.ScheduleType, schedule.RRule, schedule.WindowStart, schedule.WindowEnd, schedule.UntilAt, now.UTC())
+			window, err := resolveScheduleWindow(schedule.ScheduleType, schedule.RRule, schedule.Timezone, schedule.WindowStart, schedule.WindowEnd, schedule.UntilAt, now.UTC())
 			if err != nil {
 				return err
 			}
@@ -275,61 +277,55 @@ func transitionCampaign(
 	}, now.UTC())
 }
 
-func resolveScheduleWindow(scheduleType, recurrence string, windowStart, windowEnd time.Time, untilAt *time.Time, now time.Time) (scheduleWindow, error) {
-	if !windowEnd.After(windowStart) {
-		return scheduleWindow{}, fmt.Errorf("%w: window_end must be after window_start", ErrInvalidCampaign)
-	}
-	switch scheduleType {
-	case models.DiscountScheduleTypeOneTime:
-		if now.Before(windowStart) {
-			return scheduleWindow{NextStart: &windowStart, NextEnd: &windowEnd}, nil
+func resolveScheduleWindow(scheduleType, recurrence, timezone string, windowStart, windowEnd time.Time, untilAt *time.Time, now time.Time) (scheduleWindow, error) {
+	var current, next *ScheduleOccurrence
+	err := scheduleWindows(scheduleType, recurrence, timezone, windowStart, windowEnd, untilAt, func(window ScheduleOccurrence) bool {
+		if !window.End.After(now) {
+			return true
 		}
-		if now.Before(windowEnd) {
-			return scheduleWindow{Start: windowStart, End: windowEnd}, nil
+		if current == nil && next == nil && !now.Before(window.Start) {
+			current = &window
+			return true
 		}
-		return scheduleWindow{Expired: true}, nil
-	case models.DiscountScheduleTypeRecurring:
-		return recurringWindow(recurrence, windowStart, windowEnd, untilAt, now)
-	default:
-		return scheduleWindow{}, fmt.Errorf("%w: unsupported schedule_type", ErrInvalidCampaign)
+		next = &window
+		return false
+	})
+	if err != nil {
+		return scheduleWindow{}, err
 	}
+	result := scheduleWindow{}
+	if current != nil {
+		result.Start = current.Start
+		result.End = current.End
+	}
+	if next != nil {
+		result.NextStart = &next.Start
+		result.NextEnd = &next.End
+	}
+	result.Expired = current == nil && next == nil
+	return result, nil
 }
 
-func recurringWindow(recurrence string, windowStart, windowEnd time.Time, untilAt *time.Time, now time.Time) (scheduleWindow, error) {
-	recur := normalizeRecurrence(recurrence)
-	if recur == "" {
-		return scheduleWindow{}, fmt.Errorf("%w: recurrence is required", ErrInvalidCampaign)
-	}
-	duration := windowEnd.Sub(windowStart)
-	start := windowStart
-	for start.Add(duration).Before(now) || start.Add(duration).Equal(now) {
-		start = addRecurrence(start, recur)
-		if untilAt != nil && start.After(*untilAt) {
-			return scheduleWindow{Expired: true}, nil
-		}
+// PreviewSchedule lists up to count windows of a schedule that end after from,
+// without saving anything.
+func PreviewSchedule(input ScheduleInput, from time.Time, count int) ([]ScheduleOccurrence, error) {
+	if err := validateSchedule(input); err != nil {
+		return nil, err
 	}
-	end := start.Add(duration)
-	if untilAt != nil && now.After(*untilAt) && now.After(end) {
-		return scheduleWindow{Expired: true}, nil
+	if count < 1 || count > maxSchedulePreviewWindows {
+		return nil, fmt.Errorf("%w: preview count must be between 1 and %d", ErrInvalidCampaign, maxSchedulePreviewWindows)
 	}
-	if !now.Before(start) && now.Before(end) {
-		nextStart := addRecurrence(start, recur)
-		var nextEnd *time.Time
-		if untilAt == nil || !nextStart.After(*untilAt) {
-			value := nextStart.Add(duration)
-			nextEnd = &value
-		} else {
-			nextStart = time.Time{}
-		}
-		if nextStart.IsZero() {
-			return scheduleWindow{Start: start, End: end}, nil
+	windows := make([]ScheduleOccurrence, 0, count)
+	err := scheduleWindows(input.ScheduleType, input.Recurrence, input.Timezone, input.WindowStart.UTC(), input.WindowEnd.UTC(), utcTimePtr(input.UntilAt), func(window ScheduleOccurrence) bool {
+		if window.End.After(from) {
+			windows = append(windows, window)
 		}
-		return scheduleWindow{Start: start, End: end, NextStart: &nextStart, NextEnd: nextEnd}, nil
-	}
-	if untilAt != nil && start.After(*untilAt) {
-		return scheduleWindow{Expired: true}, nil
+		return len(windows) < count
+	})
+	if err != nil {
+		return nil, err
 	}
-	return scheduleWindow{NextStart: &start, NextEnd: ptrTime(end)}, nil
+	return windows, nil
 }
 
 func validateSchedule(input ScheduleInput) error {
@@ -342,35 +338,27 @@ func validateSchedule(input ScheduleInput) error {
 	if input.UntilAt != nil && input.UntilAt.Before(input.WindowStart) {
 		return fmt.Errorf("%w: until_at must be on or after window_start", ErrInvalidCampaign)
 	}
+	loc, err := loadScheduleLocation(input.Timezone)
+	if err != nil {
+		return err
+	}
 	switch input.ScheduleType {
 	case models.DiscountScheduleTypeOneTime:
 		return nil
 	case models.DiscountScheduleTypeRecurring:
-		switch normalizeRecurrence(input.Recurrence) {
-		case models.DiscountRecurrenceDaily, models.DiscountRecurrenceWeekly, models.DiscountRecurrenceMonthly:
-			return nil
-		default:
-			return fmt.Errorf("%w: unsupported recurrence", ErrInvalidCampaign)
-		}
+		_, err := parseRecurrence(input.Recurrence, loc)
+		return err
 	default:
 		return fmt.Errorf("%w: unsupported schedule_type", ErrInvalidCampaign)
 	}
 }
 
-func addRecurrence(value time.Time, recurrence string) time.Time {
-	switch normalizeRecurrence(recurrence) {
-	case models.DiscountRecurrenceWeekly:
-		return value.AddDate(0, 0, 7)
-	case models.DiscountRecurrenceMonthly:
-		return value.AddDate(0, 1, 0)
-	default:
-		return value.AddDate(0, 0, 1)
-	}
-}
-
+// normalizeRecurrence folds the legacy cadence keywords to their canonical
+// form and otherwise keeps the RRULE text as written, since EXDATE TZIDs are
+// case-sensitive.
 func normalizeRecurrence(value string) string {
-	trimmed := strings.TrimSpace(strings.ToLower(value))
-	switch trimmed {
+	trimmed := strings.TrimSpace(strings.ReplaceAll(value, "\r\n", "\n"))
+	switch strings.ToLower(trimmed) {
 	case "freq=daily", "daily":
 		return models.DiscountRecurrenceDaily
 	case "freq=weekly", "weekly":
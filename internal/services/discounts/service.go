@@ -19,6 +19,9 @@ type AppliedCampaign struct {
 	LevelID        *uint
 	Name           string
 	DiscountAmount models.Money
+	// BudgetLimited reports that the campaign's remaining budget could not
+	// cover its full discount, so DiscountAmount was reduced to fit.
+	BudgetLimited bool
 }
 
 type Price struct {
@@ -44,6 +47,8 @@ type ProductDiscountInput struct {
 	CustomerSegment     string
 	GlobalUsageCap      *int
 	PerCustomerUsageCap *int
+	BudgetAmount        *models.Money
+	BudgetThresholds    []int
 	ActorID             *uint
 }
 
@@ -78,6 +83,9 @@ func ValidateProductDiscount(input ProductDiscountInput) error {
 	if err := validateAdvancedControls(input.CouponCode, input.Channels, input.GlobalUsageCap, input.PerCustomerUsageCap); err != nil {
 		return err
 	}
+	if err := validateBudget(input.BudgetAmount, input.BudgetThresholds); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -90,24 +98,26 @@ func CreateProductDiscount(db *gorm.DB, input ProductDiscountInput) (models.Disc
 		status = models.DiscountCampaignStatusActive
 	}
 	campaign := models.DiscountCampaign{
-		Name:                strings.TrimSpace(input.Name),
-		Type:                models.DiscountCampaignTypeProductDiscount,
-		Status:              status,
-		StartsAt:            input.StartsAt.UTC(),
-		EndsAt:              utcTimePtr(input.EndsAt),
-		Timezone:            "UTC",
-		Priority:            input.Priority,
-		IsExclusive:         input.IsExclusive,
-		DiscountMode:        input.DiscountMode,
-		DiscountValue:       input.DiscountValue,
-		MetadataJSON:        mustEncodeJSON(input.Metadata, "{}"),
-		CouponCode:          normalizeCouponPtr(input.CouponCode),
-		ChannelsJSON:        mustEncodeJSON(input.Channels, "[]"),
-		CustomerSegment:     strings.TrimSpace(input.CustomerSegment),
-		GlobalUsageCap:      input.GlobalUsageCap,
-		PerCustomerUsageCap: input.PerCustomerUsageCap,
-		CreatedBy:           input.ActorID,
-		UpdatedBy:           input.ActorID,
+		Name:                 strings.TrimSpace(input.Name),
+		Type:                 models.DiscountCampaignTypeProductDiscount,
+		Status:               status,
+		StartsAt:             input.StartsAt.UTC(),
+		EndsAt:               utcTimePtr(input.EndsAt),
+		Timezone:             "UTC",
+		Priority:             input.Priority,
+		IsExclusive:          input.IsExclusive,
+		DiscountMode:         input.DiscountMode,
+		DiscountValue:        input.DiscountValue,
+		MetadataJSON:         mustEncodeJSON(input.Metadata, "{}"),
+		CouponCode:           normalizeCouponPtr(input.CouponCode),
+		ChannelsJSON:         mustEncodeJSON(input.Channels, "[]"),
+		CustomerSegment:      strings.TrimSpace(input.CustomerSegment),
+		GlobalUsageCap:       input.GlobalUsageCap,
+		PerCustomerUsageCap:  input.PerCustomerUsageCap,
+		BudgetAmount:         input.BudgetAmount,
+		BudgetThresholdsJSON: encodeBudgetThresholds(input.BudgetAmount, input.BudgetThresholds),
+		CreatedBy:            input.ActorID,
+		UpdatedBy:            input.ActorID,
 	}
 	err := db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&campaign).Error; err != nil {
@@ -153,6 +163,8 @@ func UpdateProductDiscount(db *gorm.DB, id uint, input ProductDiscountInput) (mo
 			"customer_segment":       strings.TrimSpace(input.CustomerSegment),
 			"global_usage_cap":       input.GlobalUsageCap,
 			"per_customer_usage_cap": input.PerCustomerUsageCap,
+			"budget_amount":          input.BudgetAmount,
+			"budget_thresholds_json": encodeBudgetThresholds(input.BudgetAmount, input.BudgetThresholds),
 			"updated_by":             input.ActorID,
 		}
 		result := tx.Model(&models.DiscountCampaign{}).
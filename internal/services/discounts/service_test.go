@@ -28,6 +28,7 @@ func newDiscountTestDB(t *testing.T) *gorm.DB {
 		&models.DiscountCampaignAudit{},
 		&models.DiscountRedemption{},
 		&models.PromotionTemplate{},
+		&models.DiscountBudgetAlert{},
 	))
 	return db
 }
@@ -423,6 +424,101 @@ func TestRunLifecycleRecurringCampaignCyclesAcrossThreeRuns(t *testing.T) {
 	assertCampaignStatus(t, db, campaign.ID, models.DiscountCampaignStatusArchived)
 }
 
+func TestPreviewScheduleKeepsLocalWindowAcrossDST(t *testing.T) {
+	toronto, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+	start := time.Date(2026, 10, 23, 17, 0, 0, 0, toronto)
+	windows, err := PreviewSchedule(ScheduleInput{
+		ScheduleType: models.DiscountScheduleTypeRecurring,
+		Recurrence:   "RRULE:FREQ=WEEKLY;BYDAY=FR;COUNT=4\nEXDATE;TZID=America/Toronto:20261106T170000",
+		WindowStart:  start,
+		WindowEnd:    start.Add(6 * time.Hour),
+		Timezone:     "America/Toronto",
+	}, start, 10)
+	require.NoError(t, err)
+	require.Equal(t, []ScheduleOccurrence{
+		{Start: time.Date(2026, 10, 23, 21, 0, 0, 0, time.UTC), End: time.Date(2026, 10, 24, 3, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2026, 10, 30, 21, 0, 0, 0, time.UTC), End: time.Date(2026, 10, 31, 3, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2026, 11, 13, 22, 0, 0, 0, time.UTC), End: time.Date(2026, 11, 14, 4, 0, 0, 0, time.UTC)},
+	}, windows)
+}
+
+func TestPreviewScheduleExpandsMonthlySetPositionsAndHours(t *testing.T) {
+	start := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	lastWeekend, err := PreviewSchedule(ScheduleInput{
+		ScheduleType: models.DiscountScheduleTypeRecurring,
+		Recurrence:   "FREQ=MONTHLY;BYDAY=SA;BYSETPOS=-1",
+		WindowStart:  start,
+		WindowEnd:    start.Add(48 * time.Hour),
+	}, start, 3)
+	require.NoError(t, err)
+	require.Len(t, lastWeekend, 3)
+	for i, day := range []int{31, 28, 28} {
+		require.Equal(t, time.Date(2026, time.Month(i+1), day, 0, 0, 0, 0, time.UTC), lastWeekend[i].Start)
+		require.Equal(t, time.Saturday, lastWeekend[i].Start.Weekday())
+	}
+
+	happyHours, err := PreviewSchedule(ScheduleInput{
+		ScheduleType: models.DiscountScheduleTypeRecurring,
+		Recurrence:   "RRULE:FREQ=DAILY;BYDAY=MO,TU;BYHOUR=12,18;UNTIL=20260203T235959Z",
+		WindowStart:  time.Date(2026, 2, 2, 12, 30, 0, 0, time.UTC),
+		WindowEnd:    time.Date(2026, 2, 2, 13, 30, 0, 0, time.UTC),
+	}, start, 10)
+	require.NoError(t, err)
+	starts := make([]time.Time, 0, len(happyHours))
+	for _, window := range happyHours {
+		starts = append(starts, window.Start)
+	}
+	require.Equal(t, []time.Time{
+		time.Date(2026, 2, 2, 12, 30, 0, 0, time.UTC),
+		time.Date(2026, 2, 2, 18, 30, 0, 0, time.UTC),
+		time.Date(2026, 2, 3, 12, 30, 0, 0, time.UTC),
+		time.Date(2026, 2, 3, 18, 30, 0, 0, time.UTC),
+	}, starts)
+
+	for _, recurrence := range []string{"FREQ=YEARLY", "FREQ=WEEKLY;BYDAY=1FR", "FREQ=DAILY;COUNT=2;UNTIL=20260301T000000Z", "FREQ=DAILY;BYHOUR=24", "hourly"} {
+		_, err := PreviewSchedule(ScheduleInput{ScheduleType: models.DiscountScheduleTypeRecurring, Recurrence: recurrence, WindowStart: start, WindowEnd: start.Add(time.Hour)}, start, 1)
+		require.ErrorIs(t, err, ErrInvalidCampaign, recurrence)
+	}
+}
+
+func TestRunLifecycleFollowsRRuleInScheduleTimezone(t *testing.T) {
+	db := newDiscountTestDB(t)
+	toronto, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+	start := time.Date(2026, 10, 30, 17, 0, 0, 0, toronto)
+	campaign, err := CreateProductDiscount(db, ProductDiscountInput{
+		Name:          "Friday nights",
+		ProductIDs:    []uint{10},
+		DiscountMode:  models.DiscountModeFixed,
+		DiscountValue: models.MoneyFromFloat(5),
+		StartsAt:      start,
+		Status:        models.DiscountCampaignStatusScheduled,
+	})
+	require.NoError(t, err)
+	_, err = UpsertSchedule(db, campaign.ID, ScheduleInput{
+		ScheduleType: models.DiscountScheduleTypeRecurring,
+		Recurrence:   "RRULE:FREQ=WEEKLY;BYDAY=FR",
+		WindowStart:  start,
+		WindowEnd:    start.Add(6 * time.Hour),
+		Timezone:     "America/Toronto",
+	}, start.Add(-time.Hour))
+	require.NoError(t, err)
+
+	// 21:30 UTC on the first Friday after DST ends is still 16:30 local.
+	_, err = RunLifecycle(db, time.Date(2026, 11, 6, 21, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assertCampaignStatus(t, db, campaign.ID, models.DiscountCampaignStatusScheduled)
+
+	run, err := RunLifecycle(db, time.Date(2026, 11, 6, 22, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, 1, run.Activated)
+	stored, err := LoadCampaign(db, campaign.ID)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 11, 6, 22, 0, 0, 0, time.UTC), stored.StartsAt.UTC())
+	require.Equal(t, time.Date(2026, 11, 7, 4, 0, 0, 0, time.UTC), stored.EndsAt.UTC())
+}
+
 func TestRunLifecycleIsIdempotentForSameState(t *testing.T) {
 	db := newDiscountTestDB(t)
 	now := time.Date(2026, 5, 19, 12, 0, 0, 0, time.UTC)
@@ -543,6 +639,41 @@ func TestEvaluateCartFiltersCouponChannelAndSegmentCampaigns(t *testing.T) {
 	require.Zero(t, disabledCoupons.DiscountTotal)
 }
 
+func TestEvaluateCartResolvesCustomerSegmentMembership(t *testing.T) {
+	db := newDiscountTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.CustomerSegment{}, &models.CustomerSegmentMembership{}))
+	now := time.Date(2026, 5, 19, 12, 0, 0, 0, time.UTC)
+	_, err := CreatePromotion(db, CreatePromotionInput{
+		Name:            "Loyalty reward",
+		StartsAt:        now.Add(-time.Hour),
+		CustomerSegment: "loyal",
+		Rules: []PromotionRuleInput{{
+			Condition: RuleCondition{ProductIDs: []uint{1}, MinQuantity: 1},
+			Action:    RuleAction{Mode: ActionModeFixed, Value: models.MoneyFromFloat(3), TargetType: models.DiscountTargetTypeProduct, TargetIDs: []uint{1}},
+		}},
+	})
+	require.NoError(t, err)
+	segment := models.CustomerSegment{Key: "loyal", Name: "Loyal", RulesJSON: `{"min_order_count":3}`, IsActive: true}
+	require.NoError(t, db.Create(&segment).Error)
+	require.NoError(t, db.Create(&models.CustomerSegmentMembership{SegmentID: segment.ID, UserID: 7, MatchedAt: now}).Error)
+	lines := []CartLine{{ProductID: 1, ProductVariantID: 10, Quantity: 1, UnitPrice: models.MoneyFromFloat(20)}}
+
+	member := uint(7)
+	result, err := EvaluateCartWithOptions(db, lines, now, EvaluationOptions{CustomerID: &member})
+	require.NoError(t, err)
+	require.Equal(t, 3.0, result.DiscountTotal.Float64())
+
+	outsider := uint(8)
+	result, err = EvaluateCartWithOptions(db, lines, now, EvaluationOptions{CustomerID: &outsider})
+	require.NoError(t, err)
+	require.Zero(t, result.DiscountTotal)
+
+	require.NoError(t, db.Model(&segment).Update("is_active", false).Error)
+	result, err = EvaluateCartWithOptions(db, lines, now, EvaluationOptions{CustomerID: &member})
+	require.NoError(t, err)
+	require.Zero(t, result.DiscountTotal)
+}
+
 func TestUsageCapsBlockSubsequentRedemptions(t *testing.T) {
 	db := newDiscountTestDB(t)
 	now := time.Date(2026, 5, 19, 12, 0, 0, 0, time.UTC)
@@ -569,6 +700,115 @@ func TestUsageCapsBlockSubsequentRedemptions(t *testing.T) {
 	require.Equal(t, uint(campaign.ID), result.Lines[0].AppliedCampaigns[0].ID)
 }
 
+func TestBudgetCapAlertsAndDisablesCampaignWhenExhausted(t *testing.T) {
+	db := newDiscountTestDB(t)
+	now := time.Date(2026, 5, 19, 12, 0, 0, 0, time.UTC)
+	budget := models.MoneyFromFloat(25)
+	campaign, err := CreatePromotion(db, CreatePromotionInput{
+		Name:             "Budgeted",
+		StartsAt:         now.Add(-time.Hour),
+		BudgetAmount:     &budget,
+		BudgetThresholds: []int{80, 50},
+		Rules: []PromotionRuleInput{{
+			Condition: RuleCondition{ProductIDs: []uint{1}, MinQuantity: 1},
+			Action:    RuleAction{Mode: ActionModeFixed, Value: models.MoneyFromFloat(5), TargetType: models.DiscountTargetTypeProduct, TargetIDs: []uint{1}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{50, 80}, BudgetThresholds(campaign))
+
+	redeem := func(orderID uint, quantity int) error {
+		result, err := EvaluateCart(db, []CartLine{{ProductID: 1, ProductVariantID: 10, Quantity: quantity, UnitPrice: models.MoneyFromFloat(20)}}, now)
+		require.NoError(t, err)
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := VerifyUsageCaps(tx, result, nil); err != nil {
+				return err
+			}
+			return RecordRedemptions(tx, orderID, nil, result, now)
+		})
+	}
+	require.NoError(t, redeem(1, 3))
+	status, err := GetBudgetStatus(db, campaign.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(15), status.SpentAmount)
+	require.Equal(t, models.MoneyFromFloat(10), *status.RemainingAmount)
+	require.Len(t, status.Alerts, 1)
+	require.Equal(t, 50, status.Alerts[0].ThresholdPercent)
+
+	require.NoError(t, redeem(3, 2))
+	assertCampaignStatus(t, db, campaign.ID, models.DiscountCampaignStatusDisabled)
+
+	status, err = GetBudgetStatus(db, campaign.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.Money(0), *status.RemainingAmount)
+	thresholds := []int{}
+	for _, alert := range status.Alerts {
+		thresholds = append(thresholds, alert.ThresholdPercent)
+	}
+	require.ElementsMatch(t, []int{50, 80, 100}, thresholds)
+
+	history, err := ListHistory(db, &campaign.ID)
+	require.NoError(t, err)
+	require.Equal(t, HistoryReasonBudgetExhausted, history[0].Reason)
+	require.Equal(t, LifecycleSourceBudget, history[0].Source)
+
+	result, err := EvaluateCart(db, []CartLine{{ProductID: 1, ProductVariantID: 10, Quantity: 1, UnitPrice: models.MoneyFromFloat(20)}}, now)
+	require.NoError(t, err)
+	require.Zero(t, result.DiscountTotal)
+
+	_, err = CreatePromotion(db, CreatePromotionInput{Name: "Thresholds only", StartsAt: now, BudgetThresholds: []int{50}, Rules: []PromotionRuleInput{{
+		Condition: RuleCondition{ProductIDs: []uint{1}, MinQuantity: 1},
+		Action:    RuleAction{Mode: ActionModeFixed, Value: models.MoneyFromFloat(5), TargetType: models.DiscountTargetTypeProduct, TargetIDs: []uint{1}},
+	}}})
+	require.ErrorIs(t, err, ErrInvalidCampaign)
+}
+
+func TestBudgetCapTrimsRedemptionLargerThanRemainingBudget(t *testing.T) {
+	db := newDiscountTestDB(t)
+	now := time.Date(2026, 5, 19, 12, 0, 0, 0, time.UTC)
+	budget := models.MoneyFromFloat(12)
+	campaign, err := CreatePromotion(db, CreatePromotionInput{
+		Name:         "Budgeted",
+		StartsAt:     now.Add(-time.Hour),
+		BudgetAmount: &budget,
+		Rules: []PromotionRuleInput{{
+			Condition: RuleCondition{ProductIDs: []uint{1}, MinQuantity: 1},
+			Action:    RuleAction{Mode: ActionModeFixed, Value: models.MoneyFromFloat(5), TargetType: models.DiscountTargetTypeProduct, TargetIDs: []uint{1}},
+		}},
+	})
+	require.NoError(t, err)
+
+	redeem := func(orderID uint, quantity int) EvaluationResult {
+		result, err := EvaluateCart(db, []CartLine{{ProductID: 1, ProductVariantID: 10, Quantity: quantity, UnitPrice: models.MoneyFromFloat(20)}}, now)
+		require.NoError(t, err)
+		require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+			if err := VerifyUsageCaps(tx, result, nil); err != nil {
+				return err
+			}
+			return RecordRedemptions(tx, orderID, nil, result, now)
+		}))
+		return result
+	}
+	require.Equal(t, models.MoneyFromFloat(10), redeem(1, 2).DiscountTotal)
+	assertCampaignStatus(t, db, campaign.ID, models.DiscountCampaignStatusActive)
+
+	// Only 2 of the 5 per-unit discount is left: the checkout still goes
+	// through with the leftover, and the campaign stops instead of failing
+	// every later checkout.
+	result := redeem(2, 1)
+	require.Equal(t, models.MoneyFromFloat(2), result.DiscountTotal)
+	require.True(t, result.Lines[0].AppliedCampaigns[0].BudgetLimited)
+	assertCampaignStatus(t, db, campaign.ID, models.DiscountCampaignStatusDisabled)
+
+	status, err := GetBudgetStatus(db, campaign.ID)
+	require.NoError(t, err)
+	require.Equal(t, budget, status.SpentAmount)
+
+	result, err = EvaluateCart(db, []CartLine{{ProductID: 1, ProductVariantID: 10, Quantity: 1, UnitPrice: models.MoneyFromFloat(20)}}, now)
+	require.NoError(t, err)
+	require.Zero(t, result.DiscountTotal)
+}
+
 func TestCampaignAuditRecordsCreateUpdateDisableAndScheduleChanges(t *testing.T) {
 	db := newDiscountTestDB(t)
 	now := time.Date(2026, 5, 19, 12, 0, 0, 0, time.UTC)
@@ -683,3 +923,63 @@ func timePtr(value time.Time) *time.Time {
 func stringPtr(value string) *string {
 	return &value
 }
+
+func TestSimulateReplaysDraftPromotionWithoutPersisting(t *testing.T) {
+	db := newDiscountTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.Product{}, &models.ProductVariant{}, &models.Order{}, &models.OrderItem{}))
+	from := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	product := models.Product{SKU: "SIM-1", Name: "Simulated"}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "SIM-1-A", Title: "Default", Price: models.MoneyFromFloat(40)}
+	require.NoError(t, db.Create(&variant).Error)
+	other := models.Product{SKU: "SIM-2", Name: "Untargeted"}
+	require.NoError(t, db.Create(&other).Error)
+	otherVariant := models.ProductVariant{ProductID: other.ID, SKU: "SIM-2-A", Title: "Default", Price: models.MoneyFromFloat(60)}
+	require.NoError(t, db.Create(&otherVariant).Error)
+
+	createOrder := func(status string, createdAt time.Time, variantID uint, price float64, quantity int) {
+		order := models.Order{CheckoutSessionID: 1, Total: models.MoneyFromFloat(price).Mul(quantity), Status: status}
+		order.CreatedAt = createdAt
+		order.Items = []models.OrderItem{{ProductVariantID: variantID, Quantity: quantity, Price: models.MoneyFromFloat(price)}}
+		require.NoError(t, db.Create(&order).Error)
+	}
+	createOrder(models.StatusPaid, from.AddDate(0, 0, 1), variant.ID, 40, 2)
+	createOrder(models.StatusDelivered, from.AddDate(0, 0, 10), otherVariant.ID, 60, 1)
+	createOrder(models.StatusCancelled, from.AddDate(0, 0, 11), variant.ID, 40, 1)
+	createOrder(models.StatusPaid, to.AddDate(0, 0, 1), variant.ID, 40, 1)
+
+	code := "LAUNCH"
+	result, err := Simulate(db, SimulationInput{
+		Campaign: CreatePromotionInput{
+			Name:       "Draft launch",
+			StartsAt:   to.AddDate(1, 0, 0),
+			CouponCode: &code,
+			Rules: []PromotionRuleInput{{
+				Condition: RuleCondition{ProductIDs: []uint{product.ID}, MinQuantity: 1},
+				Action:    RuleAction{Mode: ActionModePercent, Value: models.MoneyFromFloat(25), TargetType: models.DiscountTargetTypeProduct, TargetIDs: []uint{product.ID}},
+			}},
+		},
+		From: from,
+		To:   to,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.OrdersEvaluated)
+	require.Equal(t, 1, result.AffectedOrders)
+	require.Equal(t, 20.0, result.DiscountTotal.Float64())
+	require.Equal(t, 140.0, result.ActualRevenue.Float64())
+	require.Equal(t, 120.0, result.SimulatedRevenue.Float64())
+	require.Equal(t, 70.0, result.ActualAOV.Float64())
+	require.Equal(t, 60.0, result.SimulatedAOV.Float64())
+	require.Equal(t, -10.0, result.AOVChange.Float64())
+
+	var campaigns, redemptions int64
+	require.NoError(t, db.Model(&models.DiscountCampaign{}).Count(&campaigns).Error)
+	require.NoError(t, db.Model(&models.DiscountRedemption{}).Count(&redemptions).Error)
+	require.Zero(t, campaigns)
+	require.Zero(t, redemptions)
+
+	_, err = Simulate(db, SimulationInput{Campaign: CreatePromotionInput{Name: "Bad"}, From: to, To: from})
+	require.ErrorIs(t, err, ErrInvalidCampaign)
+}
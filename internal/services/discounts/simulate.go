@@ -0,0 +1,177 @@
+package discounts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+const maxSimulationWindow = 366 * 24 * time.Hour
+
+// simulationBatchSize bounds how many historical orders, with their items, are
+// held in memory at once while a simulation replays the window.
+const simulationBatchSize = 500
+
+var errSimulationRollback = errors.New("discount simulation rollback")
+
+type SimulationInput struct {
+	Campaign CreatePromotionInput
+	From     time.Time
+	To       time.Time
+	Channel  string
+}
+
+type SimulationResult struct {
+	From             time.Time
+	To               time.Time
+	OrdersEvaluated  int
+	AffectedOrders   int
+	DiscountTotal    models.Money
+	ActualRevenue    models.Money
+	SimulatedRevenue models.Money
+	ActualAOV        models.Money
+	SimulatedAOV     models.Money
+	AOVChange        models.Money
+	AOVChangePercent float64
+}
+
+// Simulate replays a draft promotion over paid, shipped and delivered orders
+// placed in [From, To). The draft is created inside a transaction that is
+// always rolled back, so neither the campaign nor any redemption is persisted.
+// The draft is evaluated on its own, as if it had run for the whole window and
+// any coupon code had been presented on every order.
+func Simulate(db *gorm.DB, input SimulationInput) (SimulationResult, error) {
+	from, to := input.From.UTC(), input.To.UTC()
+	if from.IsZero() || to.IsZero() || !to.After(from) {
+		return SimulationResult{}, fmt.Errorf("%w: simulation window requires from before to", ErrInvalidCampaign)
+	}
+	if to.Sub(from) > maxSimulationWindow {
+		return SimulationResult{}, fmt.Errorf("%w: simulation window cannot exceed 366 days", ErrInvalidCampaign)
+	}
+	draft := input.Campaign
+	draft.StartsAt = from
+	draft.EndsAt = &to
+	draft.Status = models.DiscountCampaignStatusActive
+	draft.CouponCode = nil
+	draft.GlobalUsageCap = nil
+	draft.PerCustomerUsageCap = nil
+	draft.BudgetAmount = nil
+	draft.BudgetThresholds = nil
+	if err := validatePromotion(draft); err != nil {
+		return SimulationResult{}, err
+	}
+	channel := strings.TrimSpace(input.Channel)
+	if channel == "" {
+		channel = models.DiscountChannelWeb
+	}
+	if err := validateAdvancedControls(nil, []string{channel}, nil, nil); err != nil {
+		return SimulationResult{}, err
+	}
+
+	result := SimulationResult{From: from, To: to}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		campaign, err := CreatePromotion(tx, draft)
+		if err != nil {
+			return err
+		}
+		catalog := map[uint]CartLine{}
+		var orders []models.Order
+		err = tx.Preload("Items").
+			Where("created_at >= ? AND created_at < ?", from, to).
+			Where("status IN ?", models.PaidOrderStatuses).
+			FindInBatches(&orders, simulationBatchSize, func(*gorm.DB, int) error {
+				if err := loadSimulationCatalog(tx, orders, catalog); err != nil {
+					return err
+				}
+				for _, order := range orders {
+					lines := make([]CartLine, 0, len(order.Items))
+					for _, item := range order.Items {
+						line := catalog[item.ProductVariantID]
+						line.ProductVariantID = item.ProductVariantID
+						if line.SKU == "" {
+							line.SKU = item.VariantSKU
+						}
+						line.Quantity = item.Quantity
+						line.UnitPrice = item.Price
+						lines = append(lines, line)
+					}
+					evaluation, err := EvaluateCartWithOptions(tx, lines, order.CreatedAt, EvaluationOptions{
+						Channel:    channel,
+						CustomerID: order.UserID,
+						campaignID: campaign.ID,
+						simulation: true,
+					})
+					if err != nil {
+						return err
+					}
+					discount := evaluation.DiscountTotal
+					if discount > order.Total {
+						discount = order.Total
+					}
+					result.OrdersEvaluated++
+					result.ActualRevenue += order.Total
+					result.SimulatedRevenue += order.Total - discount
+					if discount > 0 {
+						result.AffectedOrders++
+						result.DiscountTotal += discount
+					}
+				}
+				return nil
+			}).Error
+		if err != nil {
+			return err
+		}
+		return errSimulationRollback
+	})
+	if err != nil && !errors.Is(err, errSimulationRollback) {
+		return SimulationResult{}, err
+	}
+	if result.OrdersEvaluated > 0 {
+		orders := models.Money(result.OrdersEvaluated)
+		result.ActualAOV = result.ActualRevenue / orders
+		result.SimulatedAOV = result.SimulatedRevenue / orders
+		result.AOVChange = result.SimulatedAOV - result.ActualAOV
+		if result.ActualAOV != 0 {
+			result.AOVChangePercent = float64(result.AOVChange) / float64(result.ActualAOV) * 100
+		}
+	}
+	return result, nil
+}
+
+// loadSimulationCatalog resolves product, brand and category targeting data
+// for the variants referenced by historical order items that are not already
+// in lines.
+func loadSimulationCatalog(db *gorm.DB, orders []models.Order, lines map[uint]CartLine) error {
+	ids := map[uint]struct{}{}
+	for _, order := range orders {
+		for _, item := range order.Items {
+			if _, ok := lines[item.ProductVariantID]; !ok {
+				ids[item.ProductVariantID] = struct{}{}
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	variantIDs := make([]uint, 0, len(ids))
+	for id := range ids {
+		variantIDs = append(variantIDs, id)
+	}
+	var variants []models.ProductVariant
+	if err := db.Unscoped().Preload("Product.Categories").Where("id IN ?", variantIDs).Find(&variants).Error; err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		categoryIDs := make([]uint, 0, len(variant.Product.Categories))
+		for _, category := range variant.Product.Categories {
+			categoryIDs = append(categoryIDs, category.ID)
+		}
+		lines[variant.ID] = CartLine{ProductID: variant.ProductID, BrandID: variant.Product.BrandID, CategoryIDs: categoryIDs, SKU: variant.SKU}
+	}
+	return nil
+}
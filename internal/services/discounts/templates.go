@@ -144,6 +144,9 @@ func VerifyUsageCaps(tx *gorm.DB, result EvaluationResult, customerID *uint) err
 				return ErrUsageCapExceeded
 			}
 		}
+		if err := verifyBudget(tx, campaign, campaignAmounts[campaign.ID]); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -153,6 +156,7 @@ func RecordRedemptions(tx *gorm.DB, orderID uint, customerID *uint, result Evalu
 	if len(campaignAmounts) == 0 {
 		return nil
 	}
+	limited := budgetLimitedCampaigns(result)
 	hash := evaluationHash(result)
 	for campaignID, amount := range campaignAmounts {
 		redemption := models.DiscountRedemption{
@@ -167,6 +171,16 @@ func RecordRedemptions(tx *gorm.DB, orderID uint, customerID *uint, result Evalu
 			return err
 		}
 	}
+	ids := make([]uint, 0, len(campaignAmounts))
+	for id := range campaignAmounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		if err := applyBudgetControls(tx, id, limited[id], now); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -180,6 +194,18 @@ func appliedAmounts(result EvaluationResult) map[uint]models.Money {
 	return amounts
 }
 
+func budgetLimitedCampaigns(result EvaluationResult) map[uint]bool {
+	limited := map[uint]bool{}
+	for _, line := range result.Lines {
+		for _, campaign := range line.AppliedCampaigns {
+			if campaign.BudgetLimited {
+				limited[campaign.ID] = true
+			}
+		}
+	}
+	return limited
+}
+
 func evaluationHash(result EvaluationResult) string {
 	type hashLine struct {
 		ProductVariantID uint         `json:"product_variant_id"`
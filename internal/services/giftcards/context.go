@@ -0,0 +1,46 @@
+package giftcards
+
+import (
+	"context"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// Service provides context-first gift card and store credit APIs.
+type Service struct{ db *gorm.DB }
+
+func NewService(db *gorm.DB) *Service { return &Service{db: db} }
+
+func (s *Service) Issue(ctx context.Context, input IssueInput, now time.Time) (IssuedGiftCard, error) {
+	return Issue(s.db.WithContext(ctx), input, now)
+}
+func (s *Service) Lookup(ctx context.Context, code string, now time.Time) (models.GiftCard, error) {
+	return Lookup(s.db.WithContext(ctx), code, now)
+}
+func (s *Service) Get(ctx context.Context, id uint) (models.GiftCard, error) {
+	return Get(s.db.WithContext(ctx), id)
+}
+func (s *Service) List(ctx context.Context, input ListInput) ([]models.GiftCard, int64, error) {
+	return List(s.db.WithContext(ctx), input)
+}
+func (s *Service) ListForUser(ctx context.Context, userID uint) ([]models.GiftCard, error) {
+	return ListForUser(s.db.WithContext(ctx), userID)
+}
+func (s *Service) Adjust(ctx context.Context, id uint, input AdjustInput, now time.Time) (models.GiftCard, error) {
+	return Adjust(s.db.WithContext(ctx), id, input, now)
+}
+func (s *Service) SetStatus(ctx context.Context, id uint, status string) (models.GiftCard, error) {
+	return SetStatus(s.db.WithContext(ctx), id, status)
+}
+func (s *Service) ExpireCards(ctx context.Context, now time.Time) (int, error) {
+	return ExpireCards(s.db.WithContext(ctx), now)
+}
+func (s *Service) Reconcile(ctx context.Context, now time.Time) (ReconciliationReport, error) {
+	return Reconcile(s.db.WithContext(ctx), now)
+}
+func (s *Service) ClaimPurchasedCards(ctx context.Context, orderID, userID uint, now time.Time) ([]IssuedGiftCard, error) {
+	return ClaimPurchasedCards(s.db.WithContext(ctx), orderID, userID, now)
+}
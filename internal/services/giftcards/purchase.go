@@ -0,0 +1,72 @@
+package giftcards
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+
+// ClaimPurchasedCards issues the gift cards bought on a paid order to its
+// customer: one PURCHASED card per unit of every gift card line, worth the
+// unit price the line was sold at. Cards already issued for a line are not
+// issued again, so the call only returns cards still owed, each with the
+// plaintext code that is visible this once.
+func ClaimPurchasedCards(db *gorm.DB, orderID, userID uint, now time.Time) ([]IssuedGiftCard, error) {
+	issued := []IssuedGiftCard{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !isPaidOrderStatus(order.Status) {
+			return fmt.Errorf("%w: gift cards are issued once the order is paid", ErrInvalidGiftCard)
+		}
+		var items []models.OrderItem
+		if err := tx.Joins("JOIN product_variants ON product_variants.id = order_items.product_variant_id").
+			Where("order_items.order_id = ? AND product_variants.is_gift_card = ?", order.ID, true).
+			Order("order_items.id ASC").
+			Find(&items).Error; err != nil {
+			return err
+		}
+		currency := DefaultCurrency
+		var snapshot models.OrderCheckoutSnapshot
+		if err := tx.Where("order_id = ?", order.ID).Order("id DESC").Limit(1).Find(&snapshot).Error; err != nil {
+			return err
+		}
+		if snapshot.Currency != "" {
+			currency = snapshot.Currency
+		}
+		for _, item := range items {
+			var count int64
+			if err := tx.Model(&models.GiftCard{}).Where("order_item_id = ?", item.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			for owed := item.Quantity - item.QuantityCancelled - int(count); owed > 0; owed-- {
+				card, err := Issue(tx, IssueInput{
+					Amount: item.Price, Currency: currency, Source: models.GiftCardSourcePurchased,
+					UserID: &userID, OrderID: &order.ID, OrderItemID: &item.ID, Actor: fmt.Sprintf("user:%d", userID),
+				}, now)
+				if err != nil {
+					return err
+				}
+				issued = append(issued, card)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
@@ -0,0 +1,185 @@
+package giftcards
+
+import (
+	"fmt"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+type Drift struct {
+	GiftCardID           uint
+	LedgerEntryID        *uint
+	PaymentTransactionID *uint
+	Field                string
+	Expected             string
+	Actual               string
+	Message              string
+}
+
+type ReconciliationReport struct {
+	CheckedAt           time.Time
+	CardsChecked        int
+	TransactionsChecked int
+	Drifts              []Drift
+}
+
+// reconcileBatchSize bounds how many cards or payment transactions are held
+// in memory at once while reconciling.
+const reconcileBatchSize = 500
+
+// Reconcile checks that every card balance equals the sum of its ledger and
+// that gift card payment transactions and ledger entries agree one-to-one.
+// Cards and transactions are paged by id and ledger totals are summed in SQL,
+// so the whole ledger is never loaded at once.
+func Reconcile(db *gorm.DB, now time.Time) (ReconciliationReport, error) {
+	report := ReconciliationReport{CheckedAt: now.UTC(), Drifts: []Drift{}}
+
+	var cards []models.GiftCard
+	if err := db.FindInBatches(&cards, reconcileBatchSize, func(*gorm.DB, int) error {
+		drifts, err := reconcileCards(db, cards)
+		if err != nil {
+			return err
+		}
+		report.CardsChecked += len(cards)
+		report.Drifts = append(report.Drifts, drifts...)
+		return nil
+	}).Error; err != nil {
+		return ReconciliationReport{}, err
+	}
+
+	var txns []models.PaymentTransaction
+	if err := settledGiftCardTransactions(db).FindInBatches(&txns, reconcileBatchSize, func(*gorm.DB, int) error {
+		drifts, err := reconcileTransactions(db, txns)
+		if err != nil {
+			return err
+		}
+		report.TransactionsChecked += len(txns)
+		report.Drifts = append(report.Drifts, drifts...)
+		return nil
+	}).Error; err != nil {
+		return ReconciliationReport{}, err
+	}
+	return report, nil
+}
+
+// settledGiftCardTransactions scopes payment transactions to the succeeded
+// captures and refunds of gift card intents, the ones that move card funds.
+func settledGiftCardTransactions(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.PaymentTransaction{}).
+		Where("payment_intent_id IN (?)", db.Model(&models.PaymentIntent{}).Select("id").Where("provider = ?", models.PaymentProviderGiftCard)).
+		Where("status = ? AND operation <> ?", models.PaymentTransactionStatusSucceeded, models.PaymentTransactionOperationAuthorize)
+}
+
+// reconcileCards compares each card's balance with its ledger total and
+// reports redemptions and refunds that no settled payment transaction
+// references.
+func reconcileCards(db *gorm.DB, cards []models.GiftCard) ([]Drift, error) {
+	ids := make([]uint, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	var totals []struct {
+		GiftCardID uint
+		Total      models.Money
+	}
+	if err := db.Model(&models.GiftCardLedgerEntry{}).
+		Select("gift_card_id, COALESCE(SUM(amount), 0) AS total").
+		Where("gift_card_id IN ?", ids).
+		Group("gift_card_id").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	sums := make(map[uint]models.Money, len(totals))
+	for _, total := range totals {
+		sums[total.GiftCardID] = total.Total
+	}
+
+	drifts := []Drift{}
+	for _, card := range cards {
+		sum := sums[card.ID]
+		if sum != card.Balance {
+			drifts = append(drifts, Drift{GiftCardID: card.ID, Field: "balance", Expected: sum.String(), Actual: card.Balance.String(), Message: "balance does not match ledger total"})
+		}
+		if card.Balance < 0 {
+			drifts = append(drifts, Drift{GiftCardID: card.ID, Field: "balance", Expected: "0.00", Actual: card.Balance.String(), Message: "negative gift card balance"})
+		}
+	}
+
+	var entries []models.GiftCardLedgerEntry
+	if err := db.Select("id", "gift_card_id").
+		Where("gift_card_id IN ?", ids).
+		Where("operation IN ?", []string{models.GiftCardLedgerOperationRedeem, models.GiftCardLedgerOperationRefund}).
+		Order("id ASC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return drifts, nil
+	}
+	references := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		references = append(references, LedgerReference(entry.ID))
+	}
+	var settled []string
+	if err := settledGiftCardTransactions(db).Where("provider_txn_id IN ?", references).Pluck("provider_txn_id", &settled).Error; err != nil {
+		return nil, err
+	}
+	matched := make(map[string]struct{}, len(settled))
+	for _, reference := range settled {
+		matched[reference] = struct{}{}
+	}
+	for _, entry := range entries {
+		if _, ok := matched[LedgerReference(entry.ID)]; ok {
+			continue
+		}
+		entryID := entry.ID
+		drifts = append(drifts, Drift{GiftCardID: entry.GiftCardID, LedgerEntryID: &entryID, Field: "payment_transaction", Expected: LedgerReference(entry.ID), Message: "ledger entry has no payment transaction"})
+	}
+	return drifts, nil
+}
+
+// reconcileTransactions checks that each settled gift card transaction has a
+// ledger entry on the same payment intent that moved the matching amount.
+func reconcileTransactions(db *gorm.DB, txns []models.PaymentTransaction) ([]Drift, error) {
+	entryIDs := make([]uint, 0, len(txns))
+	for _, txn := range txns {
+		if entryID, ok := parseLedgerReference(txn.ProviderTxnID); ok {
+			entryIDs = append(entryIDs, entryID)
+		}
+	}
+	entries := map[uint]models.GiftCardLedgerEntry{}
+	if len(entryIDs) > 0 {
+		var found []models.GiftCardLedgerEntry
+		if err := db.Where("id IN ?", entryIDs).Find(&found).Error; err != nil {
+			return nil, err
+		}
+		for _, entry := range found {
+			entries[entry.ID] = entry
+		}
+	}
+
+	drifts := []Drift{}
+	for _, txn := range txns {
+		txnID := txn.ID
+		entryID, ok := parseLedgerReference(txn.ProviderTxnID)
+		entry, found := entries[entryID]
+		if !ok || !found {
+			drifts = append(drifts, Drift{PaymentTransactionID: &txnID, Field: "ledger_entry", Expected: txn.ProviderTxnID, Message: "gift card transaction has no ledger entry"})
+			continue
+		}
+		expected := -txn.Amount
+		if txn.Operation == models.PaymentTransactionOperationRefund {
+			expected = txn.Amount
+		}
+		if entry.Amount != expected {
+			drifts = append(drifts, Drift{GiftCardID: entry.GiftCardID, LedgerEntryID: &entryID, PaymentTransactionID: &txnID, Field: "amount", Expected: expected.String(), Actual: entry.Amount.String(), Message: "ledger amount does not match payment transaction"})
+		}
+		if entry.PaymentIntentID == nil || *entry.PaymentIntentID != txn.PaymentIntentID {
+			drifts = append(drifts, Drift{GiftCardID: entry.GiftCardID, LedgerEntryID: &entryID, PaymentTransactionID: &txnID, Field: "payment_intent_id", Expected: fmt.Sprint(txn.PaymentIntentID), Message: "ledger entry belongs to another payment intent"})
+		}
+	}
+	return drifts, nil
+}
@@ -0,0 +1,491 @@
+package giftcards
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	orderservice "ecommerce/internal/services/orders"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DefaultCurrency = "USD"
+
+	codeGroups    = 4
+	codeGroupSize = 4
+	codeAlphabet  = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+var (
+	ErrInvalidGiftCard     = errors.New("invalid gift card")
+	ErrGiftCardNotFound    = errors.New("gift card not found")
+	ErrGiftCardUnavailable = errors.New("gift card cannot be used")
+	ErrInsufficientBalance = errors.New("gift card balance is insufficient")
+	ErrCurrencyMismatch    = errors.New("gift card currency does not match")
+)
+
+type IssueInput struct {
+	Amount   models.Money
+	Currency string
+	Source   string
+	UserID   *uint
+	OrderID  *uint
+	// OrderItemID links a PURCHASED card to the gift card line it was
+	// bought on.
+	OrderItemID *uint
+	ExpiresAt   *time.Time
+	Note        string
+	Actor       string
+}
+
+// IssuedGiftCard carries the plaintext code, which is only available at issue
+// time.
+type IssuedGiftCard struct {
+	Card models.GiftCard
+	Code string
+}
+
+type ListInput struct {
+	Status string
+	Source string
+	UserID *uint
+	Limit  int
+	Offset int
+}
+
+type AdjustInput struct {
+	Amount         models.Money
+	Reason         string
+	Actor          string
+	IdempotencyKey string
+}
+
+// NormalizeCode folds case and drops the separators customers commonly type,
+// so "abcd-efgh ijkl" and "ABCDEFGHIJKL" resolve to the same card.
+func NormalizeCode(code string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if r == '-' || r == ' ' || r == '\t' {
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(NormalizeCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateCode() (string, error) {
+	groups := make([]string, 0, codeGroups)
+	limit := big.NewInt(int64(len(codeAlphabet)))
+	for range codeGroups {
+		group := make([]byte, codeGroupSize)
+		for i := range group {
+			index, err := rand.Int(rand.Reader, limit)
+			if err != nil {
+				return "", err
+			}
+			group[i] = codeAlphabet[index.Int64()]
+		}
+		groups = append(groups, string(group))
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+func normalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
+
+func validateIssue(db *gorm.DB, input IssueInput) (IssueInput, error) {
+	input.Source = strings.ToUpper(strings.TrimSpace(input.Source))
+	if input.Source == "" {
+		input.Source = models.GiftCardSourceAdmin
+	}
+	input.Currency = normalizeCurrency(input.Currency)
+	input.Note = strings.TrimSpace(input.Note)
+	input.Actor = strings.TrimSpace(input.Actor)
+	if input.Amount <= 0 {
+		return input, fmt.Errorf("%w: amount must be positive", ErrInvalidGiftCard)
+	}
+	if len(input.Currency) != 3 {
+		return input, fmt.Errorf("%w: currency must be a three-letter code", ErrInvalidGiftCard)
+	}
+	switch input.Source {
+	case models.GiftCardSourceAdmin:
+	case models.GiftCardSourcePurchased, models.GiftCardSourceRefundCredit:
+		if input.OrderID == nil {
+			return input, fmt.Errorf("%w: %s gift cards require an order", ErrInvalidGiftCard, strings.ToLower(input.Source))
+		}
+		// The order row lock serialises concurrent refund credits so the
+		// total check below sees every card already issued for the order.
+		var order models.Order
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, *input.OrderID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return input, fmt.Errorf("%w: order not found", ErrInvalidGiftCard)
+			}
+			return input, err
+		}
+		if input.Source == models.GiftCardSourcePurchased && !isPaidOrderStatus(order.Status) {
+			return input, fmt.Errorf("%w: purchased gift cards require a paid order", ErrInvalidGiftCard)
+		}
+		if input.Source == models.GiftCardSourceRefundCredit {
+			issued, err := issuedForOrder(db, order.ID, models.GiftCardSourceRefundCredit)
+			if err != nil {
+				return input, err
+			}
+			if issued+input.Amount > order.Total {
+				return input, fmt.Errorf("%w: refund credit cannot exceed the order total", ErrInvalidGiftCard)
+			}
+		}
+		if input.UserID == nil {
+			input.UserID = order.UserID
+		}
+	default:
+		return input, fmt.Errorf("%w: unsupported source %q", ErrInvalidGiftCard, input.Source)
+	}
+	return input, nil
+}
+
+func isPaidOrderStatus(status string) bool {
+	return slices.Contains(models.PaidOrderStatuses, status)
+}
+
+func issuedForOrder(db *gorm.DB, orderID uint, source string) (models.Money, error) {
+	var issued models.Money
+	err := db.Model(&models.GiftCard{}).
+		Select("COALESCE(SUM(initial_amount), 0)").
+		Where("order_id = ? AND source = ?", orderID, source).
+		Row().
+		Scan(&issued)
+	return issued, err
+}
+
+func Issue(db *gorm.DB, input IssueInput, now time.Time) (IssuedGiftCard, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return IssuedGiftCard{}, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidGiftCard)
+	}
+	code, err := generateCode()
+	if err != nil {
+		return IssuedGiftCard{}, err
+	}
+	normalized := NormalizeCode(code)
+	var card models.GiftCard
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		input, err = validateIssue(tx, input)
+		if err != nil {
+			return err
+		}
+		card = models.GiftCard{
+			CodeHash:      hashCode(code),
+			CodeLast4:     normalized[len(normalized)-4:],
+			Currency:      input.Currency,
+			InitialAmount: input.Amount,
+			Balance:       input.Amount,
+			Status:        models.GiftCardStatusActive,
+			Source:        input.Source,
+			UserID:        input.UserID,
+			OrderID:       input.OrderID,
+			OrderItemID:   input.OrderItemID,
+			ExpiresAt:     input.ExpiresAt,
+			Note:          input.Note,
+			IssuedBy:      input.Actor,
+			Version:       1,
+		}
+		if err := tx.Create(&card).Error; err != nil {
+			return err
+		}
+		entry := models.GiftCardLedgerEntry{
+			GiftCardID:     card.ID,
+			Operation:      models.GiftCardLedgerOperationIssue,
+			IdempotencyKey: "issue",
+			Amount:         input.Amount,
+			BalanceAfter:   input.Amount,
+			OrderID:        input.OrderID,
+			Actor:          input.Actor,
+			Reason:         strings.ToLower(input.Source),
+		}
+		if err := tx.Create(&entry).Error; err != nil {
+			return err
+		}
+		card.Ledger = []models.GiftCardLedgerEntry{entry}
+		if input.Source == models.GiftCardSourceRefundCredit {
+			return orderservice.IssueStoreCreditNote(tx, *input.OrderID, card.ID, card.InitialAmount, now)
+		}
+		return nil
+	})
+	if err != nil {
+		return IssuedGiftCard{}, err
+	}
+	return IssuedGiftCard{Card: card, Code: code}, nil
+}
+
+// Lookup resolves a card by its plaintext code without exposing whether a
+// disabled or expired card exists under that code.
+func Lookup(db *gorm.DB, code string, now time.Time) (models.GiftCard, error) {
+	var card models.GiftCard
+	err := db.Where("code_hash = ?", hashCode(code)).First(&card).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.GiftCard{}, ErrGiftCardNotFound
+	}
+	if err != nil {
+		return models.GiftCard{}, err
+	}
+	if !usable(card, now) {
+		return models.GiftCard{}, ErrGiftCardNotFound
+	}
+	return card, nil
+}
+
+func usable(card models.GiftCard, now time.Time) bool {
+	if card.Status != models.GiftCardStatusActive {
+		return false
+	}
+	return card.ExpiresAt == nil || card.ExpiresAt.After(now)
+}
+
+func Get(db *gorm.DB, id uint) (models.GiftCard, error) {
+	var card models.GiftCard
+	err := db.Preload("Ledger", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).First(&card, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.GiftCard{}, ErrGiftCardNotFound
+	}
+	return card, err
+}
+
+func List(db *gorm.DB, input ListInput) ([]models.GiftCard, int64, error) {
+	query := db.Model(&models.GiftCard{})
+	if status := strings.ToUpper(strings.TrimSpace(input.Status)); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if source := strings.ToUpper(strings.TrimSpace(input.Source)); source != "" {
+		query = query.Where("source = ?", source)
+	}
+	if input.UserID != nil {
+		query = query.Where("user_id = ?", *input.UserID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if input.Limit <= 0 {
+		input.Limit = 20
+	}
+	var cards []models.GiftCard
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(input.Limit).Offset(input.Offset).Find(&cards).Error; err != nil {
+		return nil, 0, err
+	}
+	return cards, total, nil
+}
+
+// ListForUser returns the cards and store credit issued to a customer that
+// still carry a balance.
+func ListForUser(db *gorm.DB, userID uint) ([]models.GiftCard, error) {
+	var cards []models.GiftCard
+	err := db.Where("user_id = ? AND status = ? AND balance > 0", userID, models.GiftCardStatusActive).
+		Order("expires_at IS NULL, expires_at ASC").
+		Order("id ASC").
+		Find(&cards).Error
+	return cards, err
+}
+
+func Adjust(db *gorm.DB, id uint, input AdjustInput, now time.Time) (models.GiftCard, error) {
+	input.Reason = strings.TrimSpace(input.Reason)
+	input.IdempotencyKey = strings.TrimSpace(input.IdempotencyKey)
+	if input.Amount == 0 {
+		return models.GiftCard{}, fmt.Errorf("%w: adjustment amount must not be zero", ErrInvalidGiftCard)
+	}
+	if input.Reason == "" {
+		return models.GiftCard{}, fmt.Errorf("%w: adjustment reason is required", ErrInvalidGiftCard)
+	}
+	if input.IdempotencyKey == "" {
+		input.IdempotencyKey = fmt.Sprintf("adjust-%d", now.UnixNano())
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		card, err := lockGiftCard(tx, id)
+		if err != nil {
+			return err
+		}
+		if card.Status == models.GiftCardStatusExpired {
+			return fmt.Errorf("%w: card has expired", ErrGiftCardUnavailable)
+		}
+		if card.Balance+input.Amount < 0 {
+			return ErrInsufficientBalance
+		}
+		_, err = appendLedger(tx, &card, models.GiftCardLedgerEntry{
+			Operation:      models.GiftCardLedgerOperationAdjust,
+			IdempotencyKey: input.IdempotencyKey,
+			Amount:         input.Amount,
+			Actor:          strings.TrimSpace(input.Actor),
+			Reason:         input.Reason,
+		})
+		return err
+	})
+	if err != nil {
+		return models.GiftCard{}, err
+	}
+	return Get(db, id)
+}
+
+func SetStatus(db *gorm.DB, id uint, status string) (models.GiftCard, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if status != models.GiftCardStatusActive && status != models.GiftCardStatusDisabled {
+		return models.GiftCard{}, fmt.Errorf("%w: status must be ACTIVE or DISABLED", ErrInvalidGiftCard)
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		card, err := lockGiftCard(tx, id)
+		if err != nil {
+			return err
+		}
+		if card.Status == models.GiftCardStatusExpired {
+			return fmt.Errorf("%w: card has expired", ErrGiftCardUnavailable)
+		}
+		return tx.Model(&models.GiftCard{}).Where("id = ?", card.ID).Updates(map[string]any{
+			"status":  status,
+			"version": card.Version + 1,
+		}).Error
+	})
+	if err != nil {
+		return models.GiftCard{}, err
+	}
+	return Get(db, id)
+}
+
+// ExpireCards zeroes the balance of cards past their expiry with an EXPIRE
+// ledger entry and marks them expired.
+func ExpireCards(db *gorm.DB, now time.Time) (int, error) {
+	var ids []uint
+	if err := db.Model(&models.GiftCard{}).
+		Where("status <> ? AND expires_at IS NOT NULL AND expires_at <= ?", models.GiftCardStatusExpired, now.UTC()).
+		Order("id ASC").
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, id := range ids {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			card, err := lockGiftCard(tx, id)
+			if err != nil {
+				return err
+			}
+			if card.Status == models.GiftCardStatusExpired {
+				return nil
+			}
+			if card.Balance != 0 {
+				if _, err := appendLedger(tx, &card, models.GiftCardLedgerEntry{
+					Operation:      models.GiftCardLedgerOperationExpire,
+					IdempotencyKey: "expire",
+					Amount:         -card.Balance,
+					Actor:          "system",
+					Reason:         "expired",
+				}); err != nil {
+					return err
+				}
+			}
+			expired++
+			return tx.Model(&models.GiftCard{}).Where("id = ?", card.ID).Updates(map[string]any{
+				"status":  models.GiftCardStatusExpired,
+				"version": card.Version + 1,
+			}).Error
+		})
+		if err != nil {
+			return expired, err
+		}
+	}
+	return expired, nil
+}
+
+// StartExpiryWorker periodically expires cards past their expiry and returns
+// the tenders of orders that ended unpaid.
+func StartExpiryWorker(ctx context.Context, db *gorm.DB, interval time.Duration, logger *log.Logger) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				expired, err := ExpireCards(db, time.Now().UTC())
+				released, releaseErr := ReleaseUnpaidTenders(db)
+				if logger == nil {
+					continue
+				}
+				if err != nil {
+					logger.Printf("[ERROR] Gift card expiry failed: %v", err)
+				} else if expired > 0 {
+					logger.Printf("[INFO] Gift card expiry expired=%d", expired)
+				}
+				if releaseErr != nil {
+					logger.Printf("[ERROR] Gift card tender release failed: %v", releaseErr)
+				} else if released > 0 {
+					logger.Printf("[INFO] Gift card tender release orders=%d", released)
+				}
+			}
+		}
+	}()
+}
+
+func lockGiftCard(tx *gorm.DB, id uint) (models.GiftCard, error) {
+	var card models.GiftCard
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&card, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.GiftCard{}, ErrGiftCardNotFound
+	}
+	return card, err
+}
+
+// appendLedger writes entry against a locked card and moves the card balance
+// by entry.Amount. Replaying the same operation and idempotency key returns
+// the original entry without moving the balance again.
+func appendLedger(tx *gorm.DB, card *models.GiftCard, entry models.GiftCardLedgerEntry) (models.GiftCardLedgerEntry, error) {
+	var existing models.GiftCardLedgerEntry
+	err := tx.Where("gift_card_id = ? AND operation = ? AND idempotency_key = ?", card.ID, entry.Operation, entry.IdempotencyKey).First(&existing).Error
+	if err == nil {
+		if existing.Amount != entry.Amount {
+			return models.GiftCardLedgerEntry{}, fmt.Errorf("%w: idempotency key reused with a different amount", ErrInvalidGiftCard)
+		}
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.GiftCardLedgerEntry{}, err
+	}
+	entry.GiftCardID = card.ID
+	entry.BalanceAfter = card.Balance + entry.Amount
+	if err := tx.Create(&entry).Error; err != nil {
+		return models.GiftCardLedgerEntry{}, err
+	}
+	card.Balance = entry.BalanceAfter
+	card.Version++
+	if err := tx.Model(&models.GiftCard{}).Where("id = ?", card.ID).Updates(map[string]any{
+		"balance": card.Balance,
+		"version": card.Version,
+	}).Error; err != nil {
+		return models.GiftCardLedgerEntry{}, err
+	}
+	return entry, nil
+}
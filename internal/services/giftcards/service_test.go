@@ -0,0 +1,285 @@
+package giftcards
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	paymentservice "ecommerce/internal/services/payments"
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newGiftCardTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dbName := strings.ReplaceAll(t.Name(), "/", "_")
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", dbName)), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.Order{},
+		&models.OrderCheckoutSnapshot{},
+		&models.PaymentIntent{},
+		&models.PaymentTransaction{},
+		&models.GiftCard{},
+		&models.GiftCardLedgerEntry{},
+		&models.OrderDocument{},
+		&models.LegalEntity{},
+		&models.OrderTaxLine{},
+		&models.OrderStatusHistory{},
+		&models.OrderItem{},
+		&models.ProductVariant{},
+	))
+	return db
+}
+
+func createTenderOrder(t *testing.T, db *gorm.DB, total float64) (models.Order, models.OrderCheckoutSnapshot) {
+	t.Helper()
+	order := models.Order{CheckoutSessionID: 1, Total: models.MoneyFromFloat(total), Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+	snapshot := models.OrderCheckoutSnapshot{
+		CheckoutSessionID: 1, OrderID: &order.ID, Currency: "USD", Subtotal: order.Total, Total: order.Total,
+		PaymentProviderID: "dummy-card", ShippingProviderID: "dummy-ground", ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&snapshot).Error)
+	return order, snapshot
+}
+
+func issueCard(t *testing.T, db *gorm.DB, amount float64, now time.Time) IssuedGiftCard {
+	t.Helper()
+	issued, err := Issue(db, IssueInput{Amount: models.MoneyFromFloat(amount), Actor: "admin"}, now)
+	require.NoError(t, err)
+	return issued
+}
+
+func TestSplitTenderDebitsCardsAndLeavesRemainderForProvider(t *testing.T) {
+	db := newGiftCardTestDB(t)
+	now := time.Date(2026, 8, 12, 9, 0, 0, 0, time.UTC)
+	order, snapshot := createTenderOrder(t, db, 100)
+	first := issueCard(t, db, 30, now)
+	second := issueCard(t, db, 50, now)
+	require.Regexp(t, `^[A-Z2-9]{4}-[A-Z2-9]{4}-[A-Z2-9]{4}-[A-Z2-9]{4}$`, first.Code)
+
+	partial := models.MoneyFromFloat(20)
+	intents, err := ApplyTenders(db, order, snapshot, []TenderInput{
+		{Code: strings.ToLower(first.Code)},
+		{Code: strings.ReplaceAll(second.Code, "-", ""), Amount: &partial},
+	}, "checkout-1", now)
+	require.NoError(t, err)
+	require.Len(t, intents, 2)
+	for _, intent := range intents {
+		require.Equal(t, models.PaymentProviderGiftCard, intent.Provider)
+		require.Equal(t, models.PaymentIntentStatusCaptured, intent.Status)
+		require.Len(t, intent.Transactions, 2)
+	}
+
+	due, err := paymentservice.AmountDue(db, order.ID, snapshot)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(50), due)
+
+	replayed, err := ApplyTenders(db, order, snapshot, []TenderInput{{Code: first.Code}, {Code: second.Code, Amount: &partial}}, "checkout-1", now)
+	require.NoError(t, err)
+	require.Len(t, replayed, 2)
+
+	card, err := Get(db, second.Card.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(30), card.Balance)
+
+	intent, transaction, err := paymentservice.PrepareAuthorizedPaymentIntent(db, order.ID, snapshot, "checkout-1")
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(50), intent.AuthorizedAmount)
+	require.Equal(t, models.MoneyFromFloat(50), transaction.Amount)
+
+	report, err := Reconcile(db, now)
+	require.NoError(t, err)
+	require.Empty(t, report.Drifts)
+	require.Equal(t, 2, report.TransactionsChecked)
+}
+
+func TestRefundAndReverseReturnFundsToCards(t *testing.T) {
+	db := newGiftCardTestDB(t)
+	now := time.Date(2026, 8, 12, 9, 0, 0, 0, time.UTC)
+	order, snapshot := createTenderOrder(t, db, 40)
+	issued := issueCard(t, db, 100, now)
+
+	intents, err := ApplyTenders(db, order, snapshot, []TenderInput{{Code: issued.Code}}, "checkout-2", now)
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	due, err := paymentservice.AmountDue(db, order.ID, snapshot)
+	require.NoError(t, err)
+	require.Zero(t, due)
+	_, _, err = paymentservice.PrepareAuthorizedPaymentIntent(db, order.ID, snapshot, "checkout-2")
+	require.ErrorIs(t, err, paymentservice.ErrNothingDue)
+
+	locked, err := paymentservice.GetPaymentIntentForUpdate(db, order.ID, intents[0].ID)
+	require.NoError(t, err)
+	partial := models.MoneyFromFloat(15)
+	refunded, txn, err := RefundTender(db, &locked, &partial, "refund-1", "admin")
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentTransactionStatusSucceeded, txn.Status)
+	require.Equal(t, models.PaymentIntentStatusCaptured, refunded.Status)
+
+	card, err := Get(db, issued.Card.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(75), card.Balance)
+
+	require.NoError(t, ReverseTenders(db, order.ID, "authorize-failed"))
+	card, err = Get(db, issued.Card.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(100), card.Balance)
+	operations := []string{}
+	for _, entry := range card.Ledger {
+		operations = append(operations, entry.Operation)
+	}
+	require.Equal(t, []string{"ISSUE", "REDEEM", "REFUND", "REFUND"}, operations)
+
+	fullyRefunded, err := paymentservice.OrderFullyRefunded(db, order.ID)
+	require.NoError(t, err)
+	require.True(t, fullyRefunded)
+
+	report, err := Reconcile(db, now)
+	require.NoError(t, err)
+	require.Empty(t, report.Drifts)
+
+	require.NoError(t, db.Model(&models.GiftCard{}).Where("id = ?", card.ID).Update("balance", models.MoneyFromFloat(90)).Error)
+	report, err = Reconcile(db, now)
+	require.NoError(t, err)
+	require.Len(t, report.Drifts, 1)
+	require.Equal(t, "balance", report.Drifts[0].Field)
+
+	require.NoError(t, db.Where("id = ?", txn.ID).Delete(&models.PaymentTransaction{}).Error)
+	report, err = Reconcile(db, now)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.CardsChecked)
+	require.Len(t, report.Drifts, 2)
+	require.Equal(t, "payment_transaction", report.Drifts[1].Field)
+	require.Equal(t, txn.ProviderTxnID, report.Drifts[1].Expected)
+}
+
+func TestReleaseUnpaidTendersReturnsFundsOfOrdersThatEndedUnpaid(t *testing.T) {
+	db := newGiftCardTestDB(t)
+	now := time.Date(2026, 8, 12, 9, 0, 0, 0, time.UTC)
+	abandoned, abandonedSnapshot := createTenderOrder(t, db, 40)
+	shipped, shippedSnapshot := createTenderOrder(t, db, 40)
+	first := issueCard(t, db, 100, now)
+	second := issueCard(t, db, 100, now)
+	_, err := ApplyTenders(db, abandoned, abandonedSnapshot, []TenderInput{{Code: first.Code}}, "checkout-3", now)
+	require.NoError(t, err)
+	_, err = ApplyTenders(db, shipped, shippedSnapshot, []TenderInput{{Code: second.Code}}, "checkout-4", now)
+	require.NoError(t, err)
+
+	// The provider leg was still pending when checkout returned and failed
+	// later; the other order was paid and only then cancelled.
+	require.NoError(t, db.Model(&abandoned).Update("status", models.StatusFailed).Error)
+	require.NoError(t, db.Create(&models.OrderStatusHistory{OrderID: shipped.ID, FromStatus: models.StatusPending, ToStatus: models.StatusPaid}).Error)
+	require.NoError(t, db.Model(&shipped).Update("status", models.StatusCancelled).Error)
+
+	released, err := ReleaseUnpaidTenders(db)
+	require.NoError(t, err)
+	require.Equal(t, 1, released)
+	card, err := Get(db, first.Card.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(100), card.Balance)
+	card, err = Get(db, second.Card.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(60), card.Balance)
+
+	released, err = ReleaseUnpaidTenders(db)
+	require.NoError(t, err)
+	require.Zero(t, released)
+}
+
+func TestIssueValidationAdjustmentAndExpiry(t *testing.T) {
+	db := newGiftCardTestDB(t)
+	now := time.Date(2026, 8, 12, 9, 0, 0, 0, time.UTC)
+
+	_, err := Issue(db, IssueInput{Amount: 0}, now)
+	require.ErrorIs(t, err, ErrInvalidGiftCard)
+	_, err = Issue(db, IssueInput{Amount: models.MoneyFromFloat(10), Source: models.GiftCardSourceRefundCredit}, now)
+	require.ErrorIs(t, err, ErrInvalidGiftCard)
+
+	order, _ := createTenderOrder(t, db, 25)
+	require.NoError(t, db.Create(&models.LegalEntity{Code: "default", Name: "Shop", IsDefault: true, InvoicePrefix: "INV-", CreditNotePrefix: "CN-", NextInvoiceNumber: 1, NextCreditNoteNumber: 1}).Error)
+	credit, err := Issue(db, IssueInput{Amount: models.MoneyFromFloat(25), Source: models.GiftCardSourceRefundCredit, OrderID: &order.ID}, now)
+	require.NoError(t, err)
+	require.Equal(t, models.GiftCardSourceRefundCredit, credit.Card.Source)
+	var creditNote models.OrderDocument
+	require.NoError(t, db.Where("order_id = ? AND type = ?", order.ID, models.OrderDocumentTypeCreditNote).First(&creditNote).Error)
+	require.NotNil(t, creditNote.GiftCardID, "store credit issued in place of a refund is credited")
+	require.Equal(t, credit.Card.ID, *creditNote.GiftCardID)
+	require.Equal(t, models.MoneyFromFloat(25), creditNote.Total)
+	_, err = Issue(db, IssueInput{Amount: models.MoneyFromFloat(1), Source: models.GiftCardSourceRefundCredit, OrderID: &order.ID}, now)
+	require.ErrorIs(t, err, ErrInvalidGiftCard)
+	_, err = Issue(db, IssueInput{Amount: models.MoneyFromFloat(25), Source: models.GiftCardSourcePurchased, OrderID: &order.ID}, now)
+	require.ErrorIs(t, err, ErrInvalidGiftCard)
+
+	expiresAt := now.Add(24 * time.Hour)
+	issued, err := Issue(db, IssueInput{Amount: models.MoneyFromFloat(20), ExpiresAt: &expiresAt}, now)
+	require.NoError(t, err)
+	_, err = Adjust(db, issued.Card.ID, AdjustInput{Amount: models.MoneyFromFloat(-25), Reason: "correction"}, now)
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	adjusted, err := Adjust(db, issued.Card.ID, AdjustInput{Amount: models.MoneyFromFloat(5), Reason: "goodwill", IdempotencyKey: "goodwill-1"}, now)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(25), adjusted.Balance)
+	adjusted, err = Adjust(db, issued.Card.ID, AdjustInput{Amount: models.MoneyFromFloat(5), Reason: "goodwill", IdempotencyKey: "goodwill-1"}, now)
+	require.NoError(t, err)
+	require.Equal(t, models.MoneyFromFloat(25), adjusted.Balance)
+
+	disabled, err := SetStatus(db, issued.Card.ID, "disabled")
+	require.NoError(t, err)
+	require.Equal(t, models.GiftCardStatusDisabled, disabled.Status)
+	_, err = Lookup(db, issued.Code, now)
+	require.ErrorIs(t, err, ErrGiftCardNotFound)
+
+	expired, err := ExpireCards(db, expiresAt.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, expired)
+	card, err := Get(db, issued.Card.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.GiftCardStatusExpired, card.Status)
+	require.Zero(t, card.Balance)
+	require.Equal(t, models.GiftCardLedgerOperationExpire, card.Ledger[len(card.Ledger)-1].Operation)
+}
+
+func TestClaimPurchasedCardsIssuesOneCardPerPaidGiftCardUnit(t *testing.T) {
+	db := newGiftCardTestDB(t)
+	now := time.Date(2026, 8, 12, 9, 0, 0, 0, time.UTC)
+	buyerID := uint(9)
+	giftCard := models.ProductVariant{ProductID: 1, SKU: "gift-25", Title: "Gift card 25", Price: models.MoneyFromFloat(25), IsGiftCard: true}
+	mug := models.ProductVariant{ProductID: 2, SKU: "mug", Title: "Mug", Price: models.MoneyFromFloat(12)}
+	require.NoError(t, db.Create(&giftCard).Error)
+	require.NoError(t, db.Create(&mug).Error)
+	order := models.Order{CheckoutSessionID: 1, UserID: &buyerID, Total: models.MoneyFromFloat(62), Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+	require.NoError(t, db.Create(&models.OrderItem{OrderID: order.ID, ProductVariantID: giftCard.ID, Quantity: 2, Price: giftCard.Price}).Error)
+	require.NoError(t, db.Create(&models.OrderItem{OrderID: order.ID, ProductVariantID: mug.ID, Quantity: 1, Price: mug.Price}).Error)
+
+	_, err := ClaimPurchasedCards(db, order.ID, buyerID, now)
+	require.ErrorIs(t, err, ErrInvalidGiftCard)
+	require.NoError(t, db.Model(&order).Update("status", models.StatusPaid).Error)
+	_, err = ClaimPurchasedCards(db, order.ID, buyerID+1, now)
+	require.ErrorIs(t, err, ErrOrderNotFound)
+
+	issued, err := ClaimPurchasedCards(db, order.ID, buyerID, now)
+	require.NoError(t, err)
+	require.Len(t, issued, 2)
+	for _, card := range issued {
+		require.NotEmpty(t, card.Code)
+		require.Equal(t, models.GiftCardSourcePurchased, card.Card.Source)
+		require.Equal(t, models.MoneyFromFloat(25), card.Card.Balance)
+		require.Equal(t, buyerID, *card.Card.UserID)
+	}
+	looked, err := Lookup(db, issued[1].Code, now)
+	require.NoError(t, err)
+	require.Equal(t, issued[1].Card.ID, looked.ID)
+
+	again, err := ClaimPurchasedCards(db, order.ID, buyerID, now)
+	require.NoError(t, err)
+	require.Empty(t, again)
+	cards, err := ListForUser(db, buyerID)
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+}
@@ -0,0 +1,342 @@
+package giftcards
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	paymentservice "ecommerce/internal/services/payments"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+const maxTendersPerOrder = 5
+
+type TenderInput struct {
+	Code   string
+	Amount *models.Money
+}
+
+// LedgerReference is the provider transaction id recorded on gift card
+// payment transactions; it points at the ledger entry that moved the funds.
+func LedgerReference(entryID uint) string {
+	return fmt.Sprintf("gcl_%d", entryID)
+}
+
+func parseLedgerReference(reference string) (uint, bool) {
+	var id uint
+	if _, err := fmt.Sscanf(reference, "gcl_%d", &id); err != nil || id == 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// ApplyTenders debits each gift card and records the funds as a captured
+// gift_card payment intent on the order, so the payment provider is only asked
+// for what remains. Cards already tendered against the same order and
+// snapshot are left as they are, which makes retried submissions safe.
+func ApplyTenders(
+	tx *gorm.DB,
+	order models.Order,
+	snapshot models.OrderCheckoutSnapshot,
+	tenders []TenderInput,
+	idempotencyKey string,
+	now time.Time,
+) ([]models.PaymentIntent, error) {
+	if len(tenders) > maxTendersPerOrder {
+		return nil, fmt.Errorf("%w: at most %d gift cards can be used per order", ErrInvalidGiftCard, maxTendersPerOrder)
+	}
+	tendered, err := activeTenders(tx, order.ID, snapshot.ID)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := paymentservice.AmountDue(tx, order.ID, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]struct{}{}
+	for _, tender := range tenders {
+		hash := hashCode(tender.Code)
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+		seen[hash] = struct{}{}
+		card, err := Lookup(tx, tender.Code, now)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := tendered[card.ID]; ok {
+			continue
+		}
+		if remaining <= 0 {
+			return nil, fmt.Errorf("%w: the order is already fully covered", ErrInvalidGiftCard)
+		}
+		card, err = lockGiftCard(tx, card.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !usable(card, now) {
+			return nil, ErrGiftCardUnavailable
+		}
+		if card.Currency != snapshot.Currency {
+			return nil, ErrCurrencyMismatch
+		}
+		amount := card.Balance
+		if tender.Amount != nil {
+			if *tender.Amount <= 0 {
+				return nil, fmt.Errorf("%w: tender amount must be positive", ErrInvalidGiftCard)
+			}
+			if *tender.Amount > card.Balance {
+				return nil, ErrInsufficientBalance
+			}
+			amount = *tender.Amount
+		}
+		if amount > remaining {
+			amount = remaining
+		}
+		if amount <= 0 {
+			return nil, ErrInsufficientBalance
+		}
+		if err := redeem(tx, &card, order, snapshot, amount, idempotencyKey); err != nil {
+			return nil, err
+		}
+		remaining -= amount
+	}
+	return tenderIntents(tx, order.ID)
+}
+
+func redeem(tx *gorm.DB, card *models.GiftCard, order models.Order, snapshot models.OrderCheckoutSnapshot, amount models.Money, idempotencyKey string) error {
+	intent := models.PaymentIntent{
+		OrderID:          order.ID,
+		SnapshotID:       snapshot.ID,
+		Provider:         models.PaymentProviderGiftCard,
+		Status:           models.PaymentIntentStatusCaptured,
+		AuthorizedAmount: amount,
+		CapturedAmount:   amount,
+		Currency:         snapshot.Currency,
+		Version:          1,
+	}
+	if err := tx.Create(&intent).Error; err != nil {
+		return err
+	}
+	entry, err := appendLedger(tx, card, models.GiftCardLedgerEntry{
+		Operation:       models.GiftCardLedgerOperationRedeem,
+		IdempotencyKey:  fmt.Sprintf("intent-%d", intent.ID),
+		Amount:          -amount,
+		PaymentIntentID: &intent.ID,
+		OrderID:         &order.ID,
+		Actor:           "checkout",
+		Reason:          "checkout tender",
+	})
+	if err != nil {
+		return err
+	}
+	raw := ledgerResponse(*card, entry)
+	key := strings.TrimSpace(idempotencyKey)
+	for _, operation := range []string{models.PaymentTransactionOperationAuthorize, models.PaymentTransactionOperationCapture} {
+		txn := models.PaymentTransaction{
+			PaymentIntentID:     intent.ID,
+			Operation:           operation,
+			ProviderTxnID:       LedgerReference(entry.ID),
+			IdempotencyKey:      key,
+			Amount:              amount,
+			Status:              models.PaymentTransactionStatusSucceeded,
+			RawResponseRedacted: raw,
+		}
+		if err := tx.Create(&txn).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefundTender returns funds from a gift card intent to the card it was
+// tendered from. It mirrors the provider refund lifecycle: a pending REFUND
+// transaction is prepared and then finalized against the REFUND ledger entry.
+func RefundTender(
+	tx *gorm.DB,
+	intent *models.PaymentIntent,
+	amount *models.Money,
+	idempotencyKey string,
+	actor string,
+) (models.PaymentIntent, models.PaymentTransaction, error) {
+	if intent == nil || intent.Provider != models.PaymentProviderGiftCard {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, paymentservice.ErrRefundNotAllowed
+	}
+	txn, _, err := paymentservice.PrepareRefundPaymentIntent(tx, intent, amount, idempotencyKey, "")
+	if err != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	if txn.Status == models.PaymentTransactionStatusSucceeded {
+		return *intent, txn, nil
+	}
+	var redemption models.GiftCardLedgerEntry
+	if err := tx.Where("payment_intent_id = ? AND operation = ?", intent.ID, models.GiftCardLedgerOperationRedeem).First(&redemption).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.PaymentIntent{}, models.PaymentTransaction{}, ErrGiftCardNotFound
+		}
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	card, err := lockGiftCard(tx, redemption.GiftCardID)
+	if err != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	entry, err := appendLedger(tx, &card, models.GiftCardLedgerEntry{
+		Operation:       models.GiftCardLedgerOperationRefund,
+		IdempotencyKey:  fmt.Sprintf("txn-%d", txn.ID),
+		Amount:          txn.Amount,
+		PaymentIntentID: &intent.ID,
+		OrderID:         &intent.OrderID,
+		Actor:           strings.TrimSpace(actor),
+		Reason:          "refund to gift card",
+	})
+	if err != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	return paymentservice.FinalizeRefundPaymentIntent(tx, intent.ID, txn.ID, paymentservice.ProviderOperationResult{
+		ProviderTxnID:       LedgerReference(entry.ID),
+		RawResponseRedacted: ledgerResponse(card, entry),
+	})
+}
+
+// ReverseTenders refunds every outstanding gift card tender on an order back
+// to its card, used when the provider leg of a split tender fails.
+func ReverseTenders(tx *gorm.DB, orderID uint, reason string) error {
+	intents, err := tenderIntents(tx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, intent := range intents {
+		if intent.CapturedAmount-paymentservice.RefundedAmount(intent) <= 0 {
+			continue
+		}
+		locked, err := paymentservice.GetPaymentIntentForUpdate(tx, orderID, intent.ID)
+		if err != nil {
+			return err
+		}
+		if _, _, err := RefundTender(tx, &locked, nil, "reverse-"+reason, "system"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unpaidOrderStatuses are the statuses an order ends in when its sale never
+// went through.
+var unpaidOrderStatuses = []string{models.StatusCancelled, models.StatusFailed}
+
+// ReleaseUnpaidOrderTenders returns gift card funds to their cards once the
+// order they were tendered against was cancelled or failed without ever being
+// paid, and reports whether it did. Orders that are still payable, or that
+// were paid before being cancelled, are left alone: their tenders are either
+// still in use or are returned through a refund.
+func ReleaseUnpaidOrderTenders(tx *gorm.DB, orderID uint) (bool, error) {
+	var order models.Order
+	if err := tx.Select("id", "status").First(&order, orderID).Error; err != nil {
+		return false, err
+	}
+	if !slices.Contains(unpaidOrderStatuses, order.Status) {
+		return false, nil
+	}
+	var paid int64
+	if err := tx.Model(&models.OrderStatusHistory{}).
+		Where("order_id = ? AND to_status IN ?", orderID, models.PaidOrderStatuses).
+		Count(&paid).Error; err != nil {
+		return false, err
+	}
+	if paid > 0 {
+		return false, nil
+	}
+	intents, err := tenderIntents(tx, orderID)
+	if err != nil {
+		return false, err
+	}
+	held := false
+	for _, intent := range intents {
+		if intent.CapturedAmount-paymentservice.RefundedAmount(intent) > 0 {
+			held = true
+		}
+	}
+	if !held {
+		return false, nil
+	}
+	return true, ReverseTenders(tx, orderID, "order-"+strings.ToLower(order.Status))
+}
+
+// ReleaseUnpaidTenders runs ReleaseUnpaidOrderTenders for every cancelled or
+// failed order still holding gift card funds. It picks up orders that ended
+// outside the checkout flow, such as a provider webhook failing a payment
+// that was still pending when checkout returned.
+func ReleaseUnpaidTenders(db *gorm.DB) (int, error) {
+	var orderIDs []uint
+	if err := db.Model(&models.PaymentIntent{}).
+		Distinct("payment_intents.order_id").
+		Joins("JOIN orders ON orders.id = payment_intents.order_id").
+		Where("payment_intents.provider = ? AND payment_intents.status = ?", models.PaymentProviderGiftCard, models.PaymentIntentStatusCaptured).
+		Where("orders.status IN ?", unpaidOrderStatuses).
+		Where("NOT EXISTS (SELECT 1 FROM order_status_histories h WHERE h.order_id = orders.id AND h.to_status IN ?)", models.PaidOrderStatuses).
+		Order("payment_intents.order_id ASC").
+		Pluck("payment_intents.order_id", &orderIDs).Error; err != nil {
+		return 0, err
+	}
+	released := 0
+	for _, orderID := range orderIDs {
+		var reversed bool
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			reversed, err = ReleaseUnpaidOrderTenders(tx, orderID)
+			return err
+		})
+		if err != nil {
+			return released, err
+		}
+		if reversed {
+			released++
+		}
+	}
+	return released, nil
+}
+
+// activeTenders maps gift card ids to the intents still holding their funds
+// for the order and snapshot.
+func activeTenders(tx *gorm.DB, orderID, snapshotID uint) (map[uint]uint, error) {
+	var entries []models.GiftCardLedgerEntry
+	err := tx.Model(&models.GiftCardLedgerEntry{}).
+		Joins("JOIN payment_intents ON payment_intents.id = gift_card_ledger_entries.payment_intent_id").
+		Where("gift_card_ledger_entries.operation = ?", models.GiftCardLedgerOperationRedeem).
+		Where("payment_intents.order_id = ? AND payment_intents.snapshot_id = ? AND payment_intents.status = ?", orderID, snapshotID, models.PaymentIntentStatusCaptured).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[uint]uint, len(entries))
+	for _, entry := range entries {
+		active[entry.GiftCardID] = *entry.PaymentIntentID
+	}
+	return active, nil
+}
+
+func tenderIntents(tx *gorm.DB, orderID uint) ([]models.PaymentIntent, error) {
+	var intents []models.PaymentIntent
+	err := tx.Preload("Transactions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).
+		Where("order_id = ? AND provider = ?", orderID, models.PaymentProviderGiftCard).
+		Order("id ASC").
+		Find(&intents).Error
+	return intents, err
+}
+
+func ledgerResponse(card models.GiftCard, entry models.GiftCardLedgerEntry) string {
+	raw, _ := json.Marshal(map[string]string{
+		"gift_card_id":  fmt.Sprint(card.ID),
+		"code_last4":    card.CodeLast4,
+		"ledger_entry":  LedgerReference(entry.ID),
+		"balance_after": entry.BalanceAfter.String(),
+	})
+	return string(raw)
+}
@@ -0,0 +1,208 @@
+package inventory
+
+import (
+	"math"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const ReasonBackorderAllocated = "backorder_allocated"
+
+// BackorderedQuantity sums the units of a variant sold without stock that are
+// still waiting for a receipt, whether or not their order has been paid.
+func BackorderedQuantity(db *gorm.DB, productVariantID uint) (int, error) {
+	var total int
+	err := db.Model(&models.InventoryReservation{}).
+		Where("product_variant_id = ? AND backordered = ? AND status IN ?", productVariantID, true, []string{
+			models.InventoryReservationStatusActive,
+			models.InventoryReservationStatusBackordered,
+		}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// SellableQuantity is the largest single line of the variant that can be sold
+// right now. A line is either covered by stock or backordered as a whole, so
+// this is the greater of the available stock and the backorder allowance. An
+// unreleased pre-order sells only against its allowance.
+func SellableQuantity(db *gorm.DB, variant models.ProductVariant) (int, error) {
+	now := time.Now()
+	if variant.Preordering(now) {
+		return backorderAllowance(db, variant.ID)
+	}
+	if !variant.AllowsOverselling(now) {
+		return variant.Stock, nil
+	}
+	allowance, err := backorderAllowance(db, variant.ID)
+	if err != nil {
+		return 0, err
+	}
+	return max(variant.Stock, allowance), nil
+}
+
+// backorderAllowance is how many more units of the variant may be
+// backordered: none under DENY or after a pre-order's release, math.MaxInt
+// without a cap.
+func backorderAllowance(db *gorm.DB, productVariantID uint) (int, error) {
+	var variant models.ProductVariant
+	if err := db.Select("id", "inventory_policy", "backorder_limit", "preorder_release_at").First(&variant, productVariantID).Error; err != nil {
+		return 0, err
+	}
+	if !variant.AllowsOverselling(time.Now()) {
+		return 0, nil
+	}
+	if variant.BackorderLimit == nil {
+		return math.MaxInt, nil
+	}
+	outstanding, err := BackorderedQuantity(db, productVariantID)
+	if err != nil {
+		return 0, err
+	}
+	return max(*variant.BackorderLimit-outstanding, 0), nil
+}
+
+// AllocateBackorders moves stock received at a location onto backordered
+// reservations, oldest first, stopping at the first one the stock cannot fully
+// cover. A checkout still being paid for gets an ordinary reservation; a paid
+// order's units are committed straight away. Stock at a location the
+// storefront does not sell from is left alone. It returns the reservations
+// allocated.
+func AllocateBackorders(tx *gorm.DB, productVariantID, stockLocationID uint, now time.Time) ([]models.InventoryReservation, error) {
+	_, level, err := lockedLevel(tx, productVariantID, stockLocationID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := GetLocation(tx, level.StockLocationID)
+	if err != nil {
+		return nil, err
+	}
+	if !location.CountsTowardsStorefront() {
+		return []models.InventoryReservation{}, nil
+	}
+	var reservations []models.InventoryReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_variant_id = ? AND backordered = ? AND status IN ?", productVariantID, true, []string{
+			models.InventoryReservationStatusActive,
+			models.InventoryReservationStatusBackordered,
+		}).
+		Order("created_at ASC, id ASC").
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+
+	timestamp := now.UTC()
+	availability := Availability{ProductVariantID: productVariantID, OnHand: level.OnHand, Reserved: level.Reserved, Available: level.Available}
+	allocated := make([]models.InventoryReservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		if availability.Available < reservation.Quantity {
+			break
+		}
+		updates := map[string]any{"backordered": false, "stock_location_id": level.StockLocationID, "allocated_at": timestamp, "updated_at": timestamp}
+		if reservation.Status == models.InventoryReservationStatusActive {
+			availability.Reserved += reservation.Quantity
+		} else {
+			referenceID := reservation.ID
+			if err := tx.Create(&models.InventoryMovement{
+				InventoryItemID: reservation.InventoryItemID,
+				StockLocationID: level.StockLocationID,
+				MovementType:    MovementTypeOrderCommit,
+				QuantityDelta:   -reservation.Quantity,
+				ReferenceType:   ReferenceTypeReservation,
+				ReferenceID:     &referenceID,
+				ReasonCode:      ReasonBackorderAllocated,
+				ActorType:       ReferenceTypeOrder,
+			}).Error; err != nil {
+				return nil, err
+			}
+			availability.OnHand -= reservation.Quantity
+			updates["status"] = models.InventoryReservationStatusConsumed
+			updates["consumed_at"] = timestamp
+		}
+		availability.Available = availability.OnHand - availability.Reserved
+		if err := tx.Model(&models.InventoryReservation{}).Where("id = ?", reservation.ID).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+		if err := tx.First(&reservation, reservation.ID).Error; err != nil {
+			return nil, err
+		}
+		allocated = append(allocated, reservation)
+	}
+	if len(allocated) == 0 {
+		return allocated, nil
+	}
+	if err := writeLevel(tx, level, availability.OnHand, availability.Reserved); err != nil {
+		return nil, err
+	}
+	_, err = refreshVariantStock(tx, productVariantID)
+	return allocated, err
+}
+
+// ReleaseBackordersForOrder drops a paid order's units that are still waiting
+// for stock and reports how many were released per variant, so the caller
+// only returns stock that was actually committed.
+func ReleaseBackordersForOrder(tx *gorm.DB, orderID uint, now time.Time) (map[uint]int, error) {
+	var reservations []models.InventoryReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status = ?", orderID, models.InventoryReservationStatusBackordered).
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	released := map[uint]int{}
+	for _, reservation := range reservations {
+		if err := closeBackorderedReservation(tx, &reservation, models.InventoryReservationStatusReleased, now); err != nil {
+			return nil, err
+		}
+		released[reservation.ProductVariantID] += reservation.Quantity
+	}
+	return released, nil
+}
+
+// closeBackorderedReservation ends a reservation that never held stock, so the
+// inventory level is left as it is.
+func closeBackorderedReservation(tx *gorm.DB, reservation *models.InventoryReservation, status string, now time.Time) error {
+	timestamp := now.UTC()
+	updates := map[string]any{"status": status, "updated_at": timestamp}
+	if status == models.InventoryReservationStatusExpired {
+		updates["expired_at"] = timestamp
+	} else {
+		updates["released_at"] = timestamp
+	}
+	return tx.Model(&models.InventoryReservation{}).
+		Where("id = ? AND status IN ?", reservation.ID, []string{models.InventoryReservationStatusActive, models.InventoryReservationStatusBackordered}).
+		Updates(updates).Error
+}
+
+// ReleaseBackorderedUnits drops up to quantity of a paid order's units of one
+// variant that are still waiting for stock, newest first, and reports how
+// many it released. A reservation only partly released keeps the rest.
+func ReleaseBackorderedUnits(tx *gorm.DB, orderID, productVariantID uint, quantity int, now time.Time) (int, error) {
+	var reservations []models.InventoryReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND product_variant_id = ? AND status = ?", orderID, productVariantID, models.InventoryReservationStatusBackordered).
+		Order("created_at DESC, id DESC").
+		Find(&reservations).Error; err != nil {
+		return 0, err
+	}
+	released := 0
+	for _, reservation := range reservations {
+		if released == quantity {
+			break
+		}
+		take := min(reservation.Quantity, quantity-released)
+		if take == reservation.Quantity {
+			if err := closeBackorderedReservation(tx, &reservation, models.InventoryReservationStatusReleased, now); err != nil {
+				return 0, err
+			}
+		} else if err := tx.Model(&models.InventoryReservation{}).Where("id = ?", reservation.ID).
+			Updates(map[string]any{"quantity": reservation.Quantity - take, "updated_at": now.UTC()}).Error; err != nil {
+			return 0, err
+		}
+		released += take
+	}
+	return released, nil
+}
@@ -0,0 +1,106 @@
+package inventory
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func reserveForOrder(db *gorm.DB, variantID, orderID uint, quantity int) (models.InventoryReservation, error) {
+	reservation, _, err := Reserve(db, ReservationInput{
+		ProductVariantID: variantID,
+		Quantity:         quantity,
+		OwnerType:        ReferenceTypeOrder,
+		OrderID:          &orderID,
+		IdempotencyKey:   fmt.Sprintf("backorder-test:%d", orderID),
+	})
+	return reservation, err
+}
+
+func TestBackordersAreCappedAndAllocatedOnReceipt(t *testing.T) {
+	db := newInventoryTestDB(t)
+	variant := seedInventoryVariant(t, db, 1)
+	limit := 3
+	require.NoError(t, db.Model(&variant).Updates(map[string]any{"inventory_policy": models.InventoryPolicyBackorder, "backorder_limit": limit}).Error)
+	require.NoError(t, db.First(&variant, variant.ID).Error)
+
+	sellable, err := SellableQuantity(db, variant)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sellable)
+
+	paid, err := reserveForOrder(db, variant.ID, 101, 2)
+	require.NoError(t, err)
+	assert.True(t, paid.Backordered)
+	_, err = reserveForOrder(db, variant.ID, 102, 2)
+	var availabilityErr *InsufficientAvailabilityError
+	require.ErrorAs(t, err, &availabilityErr)
+	inStock, err := reserveForOrder(db, variant.ID, 103, 1)
+	require.NoError(t, err)
+	assert.False(t, inStock.Backordered)
+
+	consumed, err := ConsumeReservationsForOrder(db, 101, "backorder-paid")
+	require.NoError(t, err)
+	assert.True(t, consumed)
+	require.NoError(t, db.First(&paid, paid.ID).Error)
+	assert.Equal(t, models.InventoryReservationStatusBackordered, paid.Status)
+	pending, err := reserveForOrder(db, variant.ID, 104, 1)
+	require.NoError(t, err)
+	assert.True(t, pending.Backordered)
+
+	availability, err := GetAvailability(db, variant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, Availability{ProductVariantID: variant.ID, OnHand: 1, Reserved: 1, Available: 0}, availability)
+	outstanding, err := BackorderedQuantity(db, variant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, outstanding)
+
+	po, err := CreatePurchaseOrder(db, PurchaseOrderInput{
+		Supplier: &SupplierInput{Name: "Backorder Supplier"},
+		Items:    []PurchaseOrderItemInput{{ProductVariantID: variant.ID, QuantityOrdered: 4, UnitCost: 2}},
+	})
+	require.NoError(t, err)
+	po, err = IssuePurchaseOrder(db, po.ID)
+	require.NoError(t, err)
+	_, _, err = ReceivePurchaseOrder(db, po.ID, ReceivePurchaseOrderInput{
+		Items: []ReceiveItemInput{{PurchaseOrderItemID: po.Items[0].ID, QuantityReceived: 4}},
+	})
+	require.NoError(t, err)
+
+	// The paid backorder is committed, the unpaid one becomes a normal hold.
+	require.NoError(t, db.First(&paid, paid.ID).Error)
+	assert.Equal(t, models.InventoryReservationStatusConsumed, paid.Status)
+	assert.False(t, paid.Backordered)
+	assert.NotNil(t, paid.AllocatedAt)
+	require.NoError(t, db.First(&pending, pending.ID).Error)
+	assert.Equal(t, models.InventoryReservationStatusActive, pending.Status)
+	assert.False(t, pending.Backordered)
+
+	availability, err = GetAvailability(db, variant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, Availability{ProductVariantID: variant.ID, OnHand: 3, Reserved: 2, Available: 1}, availability)
+	outstanding, err = BackorderedQuantity(db, variant.ID)
+	require.NoError(t, err)
+	assert.Zero(t, outstanding)
+
+	report, err := Reconcile(db, time.Now().UTC())
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+}
+
+func TestDenyPolicyStillRejectsReservationsPastStock(t *testing.T) {
+	db := newInventoryTestDB(t)
+	variant := seedInventoryVariant(t, db, 1)
+
+	sellable, err := SellableQuantity(db, variant)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sellable)
+	_, err = reserveForOrder(db, variant.ID, 201, 2)
+	var availabilityErr *InsufficientAvailabilityError
+	require.ErrorAs(t, err, &availabilityErr)
+}
@@ -59,3 +59,42 @@ func (s *Service) CancelPurchaseOrder(ctx context.Context, id uint) (models.Purc
 func (s *Service) ReceivePurchaseOrder(ctx context.Context, id uint, input ReceivePurchaseOrderInput) (models.InventoryReceipt, models.PurchaseOrder, error) {
 	return ReceivePurchaseOrder(s.db.WithContext(ctx), id, input)
 }
+func (s *Service) ListLocations(ctx context.Context) ([]models.StockLocation, error) {
+	return ListLocations(s.db.WithContext(ctx))
+}
+func (s *Service) CreateLocation(ctx context.Context, input LocationInput) (models.StockLocation, error) {
+	return CreateLocation(s.db.WithContext(ctx), input)
+}
+func (s *Service) UpdateLocation(ctx context.Context, id uint, input LocationInput) (models.StockLocation, error) {
+	return UpdateLocation(s.db.WithContext(ctx), id, input)
+}
+func (s *Service) ResolveLocation(ctx context.Context, code string) (models.StockLocation, error) {
+	return ResolveLocation(s.db.WithContext(ctx), code)
+}
+func (s *Service) GetLocationLevels(ctx context.Context, variantID uint) ([]LocationAvailability, error) {
+	return GetLocationLevels(s.db.WithContext(ctx), variantID)
+}
+func (s *Service) ListTransfers(ctx context.Context, statuses []string, limit int) ([]models.InventoryTransfer, error) {
+	return ListTransfers(s.db.WithContext(ctx), statuses, limit)
+}
+func (s *Service) CreateTransfer(ctx context.Context, input TransferInput) (models.InventoryTransfer, error) {
+	return CreateTransfer(s.db.WithContext(ctx), input)
+}
+func (s *Service) ShipTransfer(ctx context.Context, id uint, input TransferActionInput) (models.InventoryTransfer, error) {
+	return ShipTransfer(s.db.WithContext(ctx), id, input)
+}
+func (s *Service) ReceiveTransfer(ctx context.Context, id uint, input TransferActionInput) (models.InventoryTransfer, error) {
+	return ReceiveTransfer(s.db.WithContext(ctx), id, input)
+}
+func (s *Service) CancelTransfer(ctx context.Context, id uint, input TransferActionInput) (models.InventoryTransfer, error) {
+	return CancelTransfer(s.db.WithContext(ctx), id, input)
+}
+func (s *Service) ListRoutingRules(ctx context.Context) ([]models.OrderRoutingRule, error) {
+	return ListRoutingRules(s.db.WithContext(ctx))
+}
+func (s *Service) SetRoutingRules(ctx context.Context, inputs []RoutingRuleInput) ([]models.OrderRoutingRule, error) {
+	return SetRoutingRules(s.db.WithContext(ctx), inputs)
+}
+func (s *Service) PlanRoute(ctx context.Context, request RouteRequest) (RoutePlan, error) {
+	return PlanRoute(s.db.WithContext(ctx), request)
+}
@@ -0,0 +1,372 @@
+package inventory
+
+import (
+	"errors"
+	"strings"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrStockLocationNotFound = errors.New("stock location not found")
+	ErrInvalidStockLocation  = errors.New("stock location requires a code, a name, a WAREHOUSE or STORE kind and a two-letter country code if any")
+	ErrStockLocationExists   = errors.New("stock location code is already in use")
+	// ErrDefaultLocationRequired stops the default location from being
+	// deactivated, since unlocated stock and backorders land there.
+	ErrDefaultLocationRequired = errors.New("the default stock location must stay active")
+)
+
+type LocationInput struct {
+	Code     string
+	Name     string
+	Kind     string
+	Sellable *bool
+	Active   *bool
+	Position *int
+	Country  *string
+	State    *string
+}
+
+// LocationAvailability is a variant's stock at one location.
+type LocationAvailability struct {
+	Location  models.StockLocation
+	OnHand    int
+	Reserved  int
+	Available int
+}
+
+// stockDraw is the part of a quantity taken from one location's level.
+type stockDraw struct {
+	level    models.InventoryLevel
+	quantity int
+}
+
+func ListLocations(db *gorm.DB) ([]models.StockLocation, error) {
+	if _, err := DefaultLocation(db); err != nil {
+		return nil, err
+	}
+	var locations []models.StockLocation
+	err := db.Order("position ASC, id ASC").Find(&locations).Error
+	return locations, err
+}
+
+func CreateLocation(db *gorm.DB, input LocationInput) (models.StockLocation, error) {
+	location := models.StockLocation{
+		Code: strings.ToLower(strings.TrimSpace(input.Code)), Name: strings.TrimSpace(input.Name),
+		Kind: strings.ToUpper(strings.TrimSpace(input.Kind)), Sellable: true, Active: true,
+	}
+	if location.Kind == "" {
+		location.Kind = models.StockLocationKindWarehouse
+	}
+	if input.Position != nil {
+		location.Position = *input.Position
+	}
+	if input.Country != nil {
+		location.Country = strings.ToUpper(strings.TrimSpace(*input.Country))
+	}
+	if input.State != nil {
+		location.State = strings.ToUpper(strings.TrimSpace(*input.State))
+	}
+	if len(location.Country) > 2 {
+		return models.StockLocation{}, ErrInvalidStockLocation
+	}
+	if location.Code == "" || location.Name == "" || !models.IsValidStockLocationKind(location.Kind) {
+		return models.StockLocation{}, ErrInvalidStockLocation
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if _, err := DefaultLocation(tx); err != nil {
+			return err
+		}
+		var existing int64
+		if err := tx.Model(&models.StockLocation{}).Where("code = ?", location.Code).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrStockLocationExists
+		}
+		if err := tx.Create(&location).Error; err != nil {
+			return err
+		}
+		// Sellable and Active default to true in the schema, so false has to
+		// be written after the insert.
+		if (input.Sellable != nil && !*input.Sellable) || (input.Active != nil && !*input.Active) {
+			return tx.Model(&location).Updates(map[string]any{
+				"sellable": input.Sellable == nil || *input.Sellable,
+				"active":   input.Active == nil || *input.Active,
+			}).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return models.StockLocation{}, err
+	}
+	return GetLocation(db, location.ID)
+}
+
+// UpdateLocation edits a location. Taking a location out of sale, or back
+// into it, changes storefront stock for everything held there.
+func UpdateLocation(db *gorm.DB, locationID uint, input LocationInput) (models.StockLocation, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var location models.StockLocation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&location, locationID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrStockLocationNotFound
+			}
+			return err
+		}
+		updates := map[string]any{}
+		if name := strings.TrimSpace(input.Name); name != "" {
+			updates["name"] = name
+		}
+		if kind := strings.ToUpper(strings.TrimSpace(input.Kind)); kind != "" {
+			if !models.IsValidStockLocationKind(kind) {
+				return ErrInvalidStockLocation
+			}
+			updates["kind"] = kind
+		}
+		if input.Position != nil {
+			updates["position"] = *input.Position
+		}
+		if input.Country != nil {
+			country := strings.ToUpper(strings.TrimSpace(*input.Country))
+			if len(country) > 2 {
+				return ErrInvalidStockLocation
+			}
+			updates["country"] = country
+		}
+		if input.State != nil {
+			updates["state"] = strings.ToUpper(strings.TrimSpace(*input.State))
+		}
+		if input.Sellable != nil {
+			updates["sellable"] = *input.Sellable
+		}
+		if input.Active != nil {
+			if !*input.Active && location.Code == models.DefaultStockLocationCode {
+				return ErrDefaultLocationRequired
+			}
+			updates["active"] = *input.Active
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		if err := tx.Model(&location).Updates(updates).Error; err != nil {
+			return err
+		}
+		if _, changed := updates["sellable"]; !changed {
+			if _, changed = updates["active"]; !changed {
+				return nil
+			}
+		}
+		var variantIDs []uint
+		if err := tx.Model(&models.InventoryLevel{}).
+			Joins("JOIN inventory_items ON inventory_items.id = inventory_levels.inventory_item_id").
+			Where("inventory_levels.stock_location_id = ?", location.ID).
+			Pluck("inventory_items.product_variant_id", &variantIDs).Error; err != nil {
+			return err
+		}
+		for _, variantID := range variantIDs {
+			if _, err := refreshVariantStock(tx, variantID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.StockLocation{}, err
+	}
+	return GetLocation(db, locationID)
+}
+
+func GetLocation(db *gorm.DB, locationID uint) (models.StockLocation, error) {
+	var location models.StockLocation
+	err := db.First(&location, locationID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.StockLocation{}, ErrStockLocationNotFound
+	}
+	return location, err
+}
+
+// DefaultLocation returns the default location, creating it on first use so a
+// store that never configured locations keeps a single stock pool. Levels
+// recorded before locations existed are adopted by it when it is created.
+func DefaultLocation(db *gorm.DB) (models.StockLocation, error) {
+	var location models.StockLocation
+	err := db.Where("code = ?", models.DefaultStockLocationCode).First(&location).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return location, err
+	}
+	location = models.StockLocation{
+		Code: models.DefaultStockLocationCode, Name: "Default", Kind: models.StockLocationKindWarehouse, Sellable: true, Active: true,
+	}
+	created := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoNothing: true}).Create(&location)
+	if created.Error != nil {
+		return models.StockLocation{}, created.Error
+	}
+	if err := db.Where("code = ?", models.DefaultStockLocationCode).First(&location).Error; err != nil {
+		return models.StockLocation{}, err
+	}
+	if created.RowsAffected > 0 {
+		if err := db.Model(&models.InventoryLevel{}).Where("stock_location_id = ?", 0).Update("stock_location_id", location.ID).Error; err != nil {
+			return models.StockLocation{}, err
+		}
+	}
+	return location, nil
+}
+
+// ResolveLocation looks a location up by code; an empty code is the default
+// location. Inactive locations cannot take stock.
+func ResolveLocation(db *gorm.DB, code string) (models.StockLocation, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" || code == models.DefaultStockLocationCode {
+		return DefaultLocation(db)
+	}
+	var location models.StockLocation
+	err := db.Where("code = ? AND active = ?", code, true).First(&location).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.StockLocation{}, ErrStockLocationNotFound
+	}
+	return location, err
+}
+
+// GetLocationLevels lists a variant's stock at every location that holds a
+// level for it, in location order.
+func GetLocationLevels(db *gorm.DB, productVariantID uint) ([]LocationAvailability, error) {
+	item, err := ensureItem(db, productVariantID)
+	if err != nil {
+		return nil, err
+	}
+	var levels []models.InventoryLevel
+	if err := db.Joins("JOIN stock_locations ON stock_locations.id = inventory_levels.stock_location_id").
+		Where("inventory_levels.inventory_item_id = ?", item.ID).
+		Order("stock_locations.position ASC, stock_locations.id ASC").
+		Find(&levels).Error; err != nil {
+		return nil, err
+	}
+	values := make([]LocationAvailability, 0, len(levels))
+	for _, level := range levels {
+		location, err := GetLocation(db, level.StockLocationID)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, LocationAvailability{Location: location, OnHand: level.OnHand, Reserved: level.Reserved, Available: level.Available})
+	}
+	return values, nil
+}
+
+// OrderCommitments reports where an order's stock was taken from, as units per
+// location for each variant, net of any units already returned.
+func OrderCommitments(tx *gorm.DB, orderID uint) (map[uint]map[uint]int, error) {
+	type commitment struct {
+		ProductVariantID uint
+		StockLocationID  uint
+		Quantity         int
+	}
+	var reserved []commitment
+	if err := tx.Model(&models.InventoryReservation{}).
+		Select("product_variant_id, stock_location_id, SUM(quantity) AS quantity").
+		Where("order_id = ? AND status = ?", orderID, models.InventoryReservationStatusConsumed).
+		Group("product_variant_id, stock_location_id").
+		Scan(&reserved).Error; err != nil {
+		return nil, err
+	}
+	var moved []commitment
+	if err := tx.Model(&models.InventoryMovement{}).
+		Select("inventory_items.product_variant_id, inventory_movements.stock_location_id, -SUM(inventory_movements.quantity_delta) AS quantity").
+		Joins("JOIN inventory_items ON inventory_items.id = inventory_movements.inventory_item_id").
+		Where("inventory_movements.reference_type = ? AND inventory_movements.reference_id = ? AND inventory_movements.movement_type IN ?",
+			ReferenceTypeOrder, orderID, []string{MovementTypeOrderCommit, MovementTypeOrderRelease}).
+		Group("inventory_items.product_variant_id, inventory_movements.stock_location_id").
+		Scan(&moved).Error; err != nil {
+		return nil, err
+	}
+	commitments := map[uint]map[uint]int{}
+	for _, value := range append(reserved, moved...) {
+		if commitments[value.ProductVariantID] == nil {
+			commitments[value.ProductVariantID] = map[uint]int{}
+		}
+		commitments[value.ProductVariantID][value.StockLocationID] += value.Quantity
+	}
+	return commitments, nil
+}
+
+// sellableLevels locks the item's levels at active, sellable locations in the
+// order stock is drawn from them.
+func sellableLevels(tx *gorm.DB, inventoryItemID uint) ([]models.InventoryLevel, error) {
+	var levels []models.InventoryLevel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN stock_locations ON stock_locations.id = inventory_levels.stock_location_id").
+		Where("inventory_levels.inventory_item_id = ? AND stock_locations.active = ? AND stock_locations.sellable = ?", inventoryItemID, true, true).
+		Order("stock_locations.position ASC, stock_locations.id ASC").
+		Find(&levels).Error
+	return levels, err
+}
+
+// planSellableDraw picks where quantity comes from. A single location that can
+// cover it all is preferred so the units ship together; otherwise the
+// locations are drawn down in order. It returns nil when they cannot cover it.
+func planSellableDraw(levels []models.InventoryLevel, quantity int) []stockDraw {
+	for _, level := range levels {
+		if level.Available >= quantity {
+			return []stockDraw{{level: level, quantity: quantity}}
+		}
+	}
+	var draws []stockDraw
+	remaining := quantity
+	for _, level := range levels {
+		if remaining == 0 {
+			break
+		}
+		take := min(level.Available, remaining)
+		if take <= 0 {
+			continue
+		}
+		draws = append(draws, stockDraw{level: level, quantity: take})
+		remaining -= take
+	}
+	if remaining > 0 {
+		return nil
+	}
+	return draws
+}
+
+func totalAvailable(levels []models.InventoryLevel) int {
+	total := 0
+	for _, level := range levels {
+		total += max(level.Available, 0)
+	}
+	return total
+}
+
+// variantAvailability sums a variant's stock over sellable locations, which is
+// what the storefront offers.
+func variantAvailability(tx *gorm.DB, productVariantID uint) (Availability, error) {
+	availability := Availability{ProductVariantID: productVariantID}
+	err := tx.Model(&models.InventoryLevel{}).
+		Select("COALESCE(SUM(inventory_levels.on_hand), 0) AS on_hand, COALESCE(SUM(inventory_levels.reserved), 0) AS reserved, COALESCE(SUM(inventory_levels.available), 0) AS available").
+		Joins("JOIN inventory_items ON inventory_items.id = inventory_levels.inventory_item_id").
+		Joins("JOIN stock_locations ON stock_locations.id = inventory_levels.stock_location_id").
+		Where("inventory_items.product_variant_id = ? AND stock_locations.active = ? AND stock_locations.sellable = ?", productVariantID, true, true).
+		Scan(&availability).Error
+	availability.ProductVariantID = productVariantID
+	return availability, err
+}
+
+// refreshVariantStock copies the storefront availability onto the variant and
+// raises or clears stock alerts against it.
+func refreshVariantStock(tx *gorm.DB, productVariantID uint) (Availability, error) {
+	availability, err := variantAvailability(tx, productVariantID)
+	if err != nil {
+		return Availability{}, err
+	}
+	if err := tx.Model(&models.ProductVariant{}).Where("id = ?", productVariantID).Update("stock", availability.Available).Error; err != nil {
+		return Availability{}, err
+	}
+	var item models.InventoryItem
+	if err := tx.Where("product_variant_id = ?", productVariantID).First(&item).Error; err != nil {
+		return Availability{}, err
+	}
+	return availability, reconcileAlerts(tx, item.ID, productVariantID, availability.Available)
+}
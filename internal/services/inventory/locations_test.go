@@ -0,0 +1,140 @@
+package inventory
+
+import (
+	"testing"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorefrontStockSumsSellableLocationsAndReservationsSplit(t *testing.T) {
+	db := newInventoryTestDB(t)
+	variant := seedInventoryVariant(t, db, 2)
+	store, err := CreateLocation(db, LocationInput{Code: "store-1", Name: "High Street", Kind: models.StockLocationKindStore, Position: intPointer(1)})
+	require.NoError(t, err)
+	sellable := false
+	quarantine, err := CreateLocation(db, LocationInput{Code: "quarantine", Name: "Quarantine", Sellable: &sellable})
+	require.NoError(t, err)
+	assert.False(t, quarantine.Sellable)
+
+	_, err = ApplyMovement(db, MovementInput{ProductVariantID: variant.ID, StockLocationID: store.ID, MovementType: "RESTOCK", QuantityDelta: 3})
+	require.NoError(t, err)
+	availability, err := ApplyMovement(db, MovementInput{ProductVariantID: variant.ID, StockLocationID: quarantine.ID, MovementType: "RESTOCK", QuantityDelta: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 5, availability.Available, "quarantined stock is not sold")
+	var stored models.ProductVariant
+	require.NoError(t, db.First(&stored, variant.ID).Error)
+	assert.Equal(t, 5, stored.Stock)
+
+	// Neither location covers four units alone, so the line is split.
+	reservation, availability, err := Reserve(db, ReservationInput{ProductVariantID: variant.ID, Quantity: 4, OwnerType: ReferenceTypeOrder, IdempotencyKey: "split"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, availability.Available)
+	var parts []models.InventoryReservation
+	require.NoError(t, db.Order("id ASC").Find(&parts).Error)
+	require.Len(t, parts, 2)
+	assert.Equal(t, reservation.ID, parts[0].ID)
+	assert.Equal(t, 2, parts[0].Quantity)
+	assert.Equal(t, 2, parts[1].Quantity)
+	assert.Equal(t, store.ID, parts[1].StockLocationID)
+	assert.Equal(t, "split#2", parts[1].IdempotencyKey)
+
+	_, _, err = Reserve(db, ReservationInput{ProductVariantID: variant.ID, Quantity: 2, OwnerType: ReferenceTypeOrder, IdempotencyKey: "too-many"})
+	var availabilityErr *InsufficientAvailabilityError
+	require.ErrorAs(t, err, &availabilityErr)
+	assert.Equal(t, 1, availabilityErr.Available)
+
+	// Making the quarantine sellable releases its stock to the storefront.
+	sellable = true
+	_, err = UpdateLocation(db, quarantine.ID, LocationInput{Sellable: &sellable})
+	require.NoError(t, err)
+	require.NoError(t, db.First(&stored, variant.ID).Error)
+	assert.Equal(t, 11, stored.Stock)
+
+	report, err := Reconcile(db, reservation.CreatedAt)
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+}
+
+func TestTransferMovesStockBetweenLocations(t *testing.T) {
+	db := newInventoryTestDB(t)
+	variant := seedInventoryVariant(t, db, 6)
+	store, err := CreateLocation(db, LocationInput{Code: "store-1", Name: "High Street", Kind: models.StockLocationKindStore})
+	require.NoError(t, err)
+
+	transfer, err := CreateTransfer(db, TransferInput{
+		FromLocationCode: models.DefaultStockLocationCode, ToLocationCode: store.Code,
+		Items: []TransferItemInput{{ProductVariantID: variant.ID, Quantity: 4}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.InventoryTransferStatusDraft, transfer.Status)
+	_, err = ReceiveTransfer(db, transfer.ID, TransferActionInput{})
+	require.ErrorIs(t, err, ErrTransferNotAllowed)
+
+	transfer, err = ShipTransfer(db, transfer.ID, TransferActionInput{ActorType: "admin"})
+	require.NoError(t, err)
+	assert.Equal(t, models.InventoryTransferStatusInTransit, transfer.Status)
+	availability, err := GetAvailability(db, variant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, availability.Available, "units in transit are not sellable")
+
+	transfer, err = ReceiveTransfer(db, transfer.ID, TransferActionInput{ActorType: "admin"})
+	require.NoError(t, err)
+	assert.Equal(t, models.InventoryTransferStatusReceived, transfer.Status)
+	levels, err := GetLocationLevels(db, variant.ID)
+	require.NoError(t, err)
+	require.Len(t, levels, 2)
+	assert.Equal(t, models.DefaultStockLocationCode, levels[0].Location.Code)
+	assert.Equal(t, 2, levels[0].OnHand)
+	assert.Equal(t, store.Code, levels[1].Location.Code)
+	assert.Equal(t, 4, levels[1].OnHand)
+
+	_, err = CancelTransfer(db, transfer.ID, TransferActionInput{})
+	require.ErrorIs(t, err, ErrTransferNotAllowed)
+
+	tooMany, err := CreateTransfer(db, TransferInput{
+		FromLocationCode: store.Code, ToLocationCode: models.DefaultStockLocationCode,
+		Items: []TransferItemInput{{ProductVariantID: variant.ID, Quantity: 5}},
+	})
+	require.NoError(t, err)
+	_, err = ShipTransfer(db, tooMany.ID, TransferActionInput{})
+	var availabilityErr *InsufficientAvailabilityError
+	require.ErrorAs(t, err, &availabilityErr)
+}
+
+func TestPurchaseOrderReceiptGoesToChosenLocation(t *testing.T) {
+	db := newInventoryTestDB(t)
+	variant := seedInventoryVariant(t, db, 1)
+	sellable := false
+	backroom, err := CreateLocation(db, LocationInput{Code: "backroom", Name: "Backroom", Sellable: &sellable})
+	require.NoError(t, err)
+	po, err := CreatePurchaseOrder(db, PurchaseOrderInput{Items: []PurchaseOrderItemInput{{ProductVariantID: variant.ID, QuantityOrdered: 5}}})
+	require.NoError(t, err)
+	po, err = IssuePurchaseOrder(db, po.ID)
+	require.NoError(t, err)
+
+	_, _, err = ReceivePurchaseOrder(db, po.ID, ReceivePurchaseOrderInput{
+		LocationCode: "nowhere",
+		Items:        []ReceiveItemInput{{PurchaseOrderItemID: po.Items[0].ID, QuantityReceived: 5}},
+	})
+	require.ErrorIs(t, err, ErrStockLocationNotFound)
+
+	receipt, _, err := ReceivePurchaseOrder(db, po.ID, ReceivePurchaseOrderInput{
+		LocationCode: backroom.Code,
+		Items:        []ReceiveItemInput{{PurchaseOrderItemID: po.Items[0].ID, QuantityReceived: 5}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, backroom.ID, receipt.StockLocationID)
+
+	availability, err := GetAvailability(db, variant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, availability.Available)
+	levels, err := GetLocationLevels(db, variant.ID)
+	require.NoError(t, err)
+	require.Len(t, levels, 2)
+	assert.Equal(t, 5, levels[1].OnHand)
+}
+
+func intPointer(value int) *int { return &value }
@@ -42,10 +42,13 @@ type ReceiveItemInput struct {
 }
 
 type ReceivePurchaseOrderInput struct {
-	Items     []ReceiveItemInput
-	Notes     string
-	ActorType string
-	ActorID   *uint
+	Items []ReceiveItemInput
+	// LocationCode is where the goods arrived; empty means the default
+	// location.
+	LocationCode string
+	Notes        string
+	ActorType    string
+	ActorID      *uint
 }
 
 func ListPurchaseOrders(db *gorm.DB, limit int) ([]models.PurchaseOrder, error) {
@@ -160,9 +163,14 @@ func ReceivePurchaseOrder(db *gorm.DB, purchaseOrderID uint, input ReceivePurcha
 		for i := range po.Items {
 			itemsByID[po.Items[i].ID] = &po.Items[i]
 		}
+		location, err := ResolveLocation(tx, input.LocationCode)
+		if err != nil {
+			return err
+		}
 		now := time.Now().UTC()
 		receipt = models.InventoryReceipt{
 			PurchaseOrderID: po.ID,
+			StockLocationID: location.ID,
 			ReceivedAt:      now,
 			ActorType:       strings.TrimSpace(input.ActorType),
 			ActorID:         input.ActorID,
@@ -199,6 +207,7 @@ func ReceivePurchaseOrder(db *gorm.DB, purchaseOrderID uint, input ReceivePurcha
 			referenceID := receipt.ID
 			if _, err := ApplyMovement(tx, MovementInput{
 				ProductVariantID: item.ProductVariantID,
+				StockLocationID:  location.ID,
 				MovementType:     MovementTypeRestockReceipt,
 				QuantityDelta:    received.QuantityReceived,
 				ReferenceType:    ReferenceTypeReceipt,
@@ -209,6 +218,9 @@ func ReceivePurchaseOrder(db *gorm.DB, purchaseOrderID uint, input ReceivePurcha
 			}); err != nil {
 				return err
 			}
+			if _, err := AllocateBackorders(tx, item.ProductVariantID, location.ID, now); err != nil {
+				return err
+			}
 		}
 		allReceived := true
 		anyReceived := false
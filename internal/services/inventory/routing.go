@@ -0,0 +1,304 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidRoutingRule rejects an unknown or repeated routing rule.
+var ErrInvalidRoutingRule = errors.New("order routing rules must be FULL_ORDER, PROXIMITY or LOCATION_PRIORITY, each listed once")
+
+type RoutingRuleInput struct {
+	Rule   string
+	Active bool
+}
+
+// RouteLine is a quantity of one variant to route, usually an order line.
+type RouteLine struct {
+	OrderItemID      uint
+	ProductVariantID uint
+	Quantity         int
+}
+
+type RouteRequest struct {
+	Lines   []RouteLine
+	Country string
+	State   string
+	// OrderID lets the order's own committed stock count as available, so
+	// routing can move it between locations. Zero routes a hypothetical cart.
+	OrderID uint
+}
+
+// RouteAssignment is the part of an order one location ships, with the
+// reasons the rules chose it.
+type RouteAssignment struct {
+	Location    models.StockLocation
+	Lines       []RouteLine
+	Explanation string
+}
+
+type RoutePlan struct {
+	// Rules are the active rules in the order they were applied.
+	Rules       []string
+	Assignments []RouteAssignment
+	// Unrouted holds the units no sellable location can supply, which wait
+	// on backorder.
+	Unrouted []RouteLine
+}
+
+// ListRoutingRules returns the configured ranking, or the default one when
+// none has been saved.
+func ListRoutingRules(db *gorm.DB) ([]models.OrderRoutingRule, error) {
+	var rules []models.OrderRoutingRule
+	if err := db.Order("position ASC, id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		return rules, nil
+	}
+	for i, rule := range models.DefaultOrderRoutingRules {
+		rules = append(rules, models.OrderRoutingRule{Rule: rule, Position: i, Active: true})
+	}
+	return rules, nil
+}
+
+// SetRoutingRules replaces the ranking with the given rules in order. Rules
+// left out are kept, inactive, after them.
+func SetRoutingRules(db *gorm.DB, inputs []RoutingRuleInput) ([]models.OrderRoutingRule, error) {
+	ordered := make([]RoutingRuleInput, 0, len(models.DefaultOrderRoutingRules))
+	seen := map[string]bool{}
+	for _, input := range inputs {
+		rule := strings.ToUpper(strings.TrimSpace(input.Rule))
+		if !models.IsValidOrderRoutingRule(rule) || seen[rule] {
+			return nil, ErrInvalidRoutingRule
+		}
+		seen[rule] = true
+		ordered = append(ordered, RoutingRuleInput{Rule: rule, Active: input.Active})
+	}
+	for _, rule := range models.DefaultOrderRoutingRules {
+		if !seen[rule] {
+			ordered = append(ordered, RoutingRuleInput{Rule: rule})
+		}
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.OrderRoutingRule{}).Error; err != nil {
+			return err
+		}
+		for i, input := range ordered {
+			rule := models.OrderRoutingRule{Rule: input.Rule, Position: i, Active: true}
+			if err := tx.Create(&rule).Error; err != nil {
+				return err
+			}
+			// Active defaults to true in the schema, so false is written after
+			// the insert.
+			if !input.Active {
+				if err := tx.Model(&rule).Update("active", false).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ListRoutingRules(db)
+}
+
+// PlanRoute picks the locations that ship each line. Sellable locations are
+// ranked by the active rules, and every line is drawn from them in rank
+// order, so with FULL_ORDER first an order is only split when no single
+// location can ship all of it.
+func PlanRoute(db *gorm.DB, request RouteRequest) (RoutePlan, error) {
+	configured, err := ListRoutingRules(db)
+	if err != nil {
+		return RoutePlan{}, err
+	}
+	plan := RoutePlan{Rules: []string{}, Assignments: []RouteAssignment{}, Unrouted: []RouteLine{}}
+	for _, rule := range configured {
+		if rule.Active {
+			plan.Rules = append(plan.Rules, rule.Rule)
+		}
+	}
+
+	var locations []models.StockLocation
+	if err := db.Where("active = ? AND sellable = ?", true, true).Order("id ASC").Find(&locations).Error; err != nil {
+		return RoutePlan{}, err
+	}
+	need := map[uint]int{}
+	variantIDs := []uint{}
+	for _, line := range request.Lines {
+		if line.ProductVariantID == 0 || line.Quantity < 1 {
+			return RoutePlan{}, fmt.Errorf("route lines need a variant and a positive quantity")
+		}
+		if _, ok := need[line.ProductVariantID]; !ok {
+			variantIDs = append(variantIDs, line.ProductVariantID)
+		}
+		need[line.ProductVariantID] += line.Quantity
+	}
+	stock, err := routableStock(db, variantIDs, request.OrderID)
+	if err != nil {
+		return RoutePlan{}, err
+	}
+
+	country := strings.ToUpper(strings.TrimSpace(request.Country))
+	state := strings.ToUpper(strings.TrimSpace(request.State))
+	covers := func(location models.StockLocation) bool {
+		for variantID, quantity := range need {
+			if stock[location.ID][variantID] < quantity {
+				return false
+			}
+		}
+		return true
+	}
+	proximity := func(location models.StockLocation) int {
+		switch {
+		case country == "" || location.Country != country:
+			return 0
+		case state != "" && location.State == state:
+			return 2
+		default:
+			return 1
+		}
+	}
+	sort.SliceStable(locations, func(i, j int) bool {
+		a, b := locations[i], locations[j]
+		for _, rule := range plan.Rules {
+			switch rule {
+			case models.OrderRoutingRuleFullOrder:
+				if covers(a) != covers(b) {
+					return covers(a)
+				}
+			case models.OrderRoutingRuleProximity:
+				if proximity(a) != proximity(b) {
+					return proximity(a) > proximity(b)
+				}
+			case models.OrderRoutingRulePriority:
+				if a.Position != b.Position {
+					return a.Position < b.Position
+				}
+			}
+		}
+		return a.ID < b.ID
+	})
+
+	assigned := map[uint][]RouteLine{}
+	for _, line := range request.Lines {
+		left := line.Quantity
+		for _, location := range locations {
+			take := min(left, stock[location.ID][line.ProductVariantID])
+			if take <= 0 {
+				continue
+			}
+			assigned[location.ID] = append(assigned[location.ID], RouteLine{OrderItemID: line.OrderItemID, ProductVariantID: line.ProductVariantID, Quantity: take})
+			stock[location.ID][line.ProductVariantID] -= take
+			if left -= take; left == 0 {
+				break
+			}
+		}
+		if left > 0 {
+			plan.Unrouted = append(plan.Unrouted, RouteLine{OrderItemID: line.OrderItemID, ProductVariantID: line.ProductVariantID, Quantity: left})
+		}
+	}
+	for _, location := range locations {
+		if lines, ok := assigned[location.ID]; ok {
+			plan.Assignments = append(plan.Assignments, RouteAssignment{Location: location, Lines: lines})
+		}
+	}
+
+	// The reasons are worded against the stock seen before anything was
+	// assigned, which is what the ranking used.
+	stock, err = routableStock(db, variantIDs, request.OrderID)
+	if err != nil {
+		return RoutePlan{}, err
+	}
+	for i := range plan.Assignments {
+		location := plan.Assignments[i].Location
+		reasons := []string{}
+		for _, rule := range plan.Rules {
+			switch rule {
+			case models.OrderRoutingRuleFullOrder:
+				if covers(location) {
+					reasons = append(reasons, "can ship the whole order")
+				} else {
+					reasons = append(reasons, "cannot ship the whole order")
+				}
+			case models.OrderRoutingRuleProximity:
+				switch {
+				case country == "":
+					reasons = append(reasons, "no destination address to compare")
+				case proximity(location) == 2:
+					reasons = append(reasons, fmt.Sprintf("in the destination state %s-%s", country, state))
+				case proximity(location) == 1:
+					reasons = append(reasons, fmt.Sprintf("in the destination country %s", country))
+				default:
+					reasons = append(reasons, fmt.Sprintf("outside the destination country %s", country))
+				}
+			case models.OrderRoutingRulePriority:
+				reasons = append(reasons, fmt.Sprintf("location priority %d", location.Position))
+			}
+		}
+		if len(reasons) == 0 {
+			reasons = append(reasons, "no routing rules are active")
+		}
+		if len(plan.Assignments) > 1 {
+			reasons = append(reasons, fmt.Sprintf("order split across %d locations", len(plan.Assignments)))
+		}
+		plan.Assignments[i].Explanation = fmt.Sprintf("Routed to %s: %s.", location.Code, strings.Join(reasons, "; "))
+	}
+	return plan, nil
+}
+
+// routableStock is the stock each sellable location can give the variants:
+// what is available there plus what the order already holds there.
+func routableStock(db *gorm.DB, variantIDs []uint, orderID uint) (map[uint]map[uint]int, error) {
+	stock := map[uint]map[uint]int{}
+	if len(variantIDs) == 0 {
+		return stock, nil
+	}
+	type row struct {
+		StockLocationID  uint
+		ProductVariantID uint
+		Available        int
+	}
+	var rows []row
+	if err := db.Model(&models.InventoryLevel{}).
+		Select("inventory_levels.stock_location_id, inventory_items.product_variant_id, inventory_levels.available").
+		Joins("JOIN inventory_items ON inventory_items.id = inventory_levels.inventory_item_id").
+		Where("inventory_items.product_variant_id IN ?", variantIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, value := range rows {
+		if stock[value.StockLocationID] == nil {
+			stock[value.StockLocationID] = map[uint]int{}
+		}
+		stock[value.StockLocationID][value.ProductVariantID] += max(value.Available, 0)
+	}
+	if orderID == 0 {
+		return stock, nil
+	}
+	commitments, err := OrderCommitments(db, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for variantID, byLocation := range commitments {
+		if !slices.Contains(variantIDs, variantID) {
+			continue
+		}
+		for locationID, quantity := range byLocation {
+			if stock[locationID] == nil {
+				stock[locationID] = map[uint]int{}
+			}
+			stock[locationID][variantID] += max(quantity, 0)
+		}
+	}
+	return stock, nil
+}
@@ -0,0 +1,69 @@
+package inventory
+
+import (
+	"testing"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRoutePrefersFullOrderThenProximity(t *testing.T) {
+	db := newInventoryTestDB(t)
+	shirt := seedInventoryVariant(t, db, 0)
+	mug := models.ProductVariant{ProductID: shirt.ProductID, SKU: "MUG", Title: "Mug", Price: models.MoneyFromFloat(8), Position: 2, IsPublished: true}
+	require.NoError(t, db.Create(&mug).Error)
+	texas, err := CreateLocation(db, LocationInput{Code: "dallas", Name: "Dallas", Country: stringPointer("us"), State: stringPointer("tx"), Position: intPointer(2)})
+	require.NoError(t, err)
+	california, err := CreateLocation(db, LocationInput{Code: "oakland", Name: "Oakland", Country: stringPointer("US"), State: stringPointer("CA"), Position: intPointer(1)})
+	require.NoError(t, err)
+	assert.Equal(t, "US", texas.Country)
+	assert.Equal(t, "TX", texas.State)
+	for _, level := range []struct {
+		variant  uint
+		location uint
+		quantity int
+	}{
+		{shirt.ID, texas.ID, 2}, {mug.ID, texas.ID, 1},
+		{shirt.ID, california.ID, 2},
+	} {
+		_, err := ApplyMovement(db, MovementInput{ProductVariantID: level.variant, StockLocationID: level.location, MovementType: "RESTOCK", QuantityDelta: level.quantity})
+		require.NoError(t, err)
+	}
+	cart := []RouteLine{{ProductVariantID: shirt.ID, Quantity: 2}, {ProductVariantID: mug.ID, Quantity: 1}}
+
+	// Only Dallas holds the whole cart, so it wins over the closer Oakland.
+	plan, err := PlanRoute(db, RouteRequest{Lines: cart, Country: "US", State: "CA"})
+	require.NoError(t, err)
+	assert.Equal(t, models.DefaultOrderRoutingRules, plan.Rules)
+	require.Len(t, plan.Assignments, 1)
+	assert.Equal(t, texas.Code, plan.Assignments[0].Location.Code)
+	assert.Equal(t, "Routed to dallas: can ship the whole order; in the destination country US; location priority 2.", plan.Assignments[0].Explanation)
+	assert.Empty(t, plan.Unrouted)
+
+	// Put proximity first and the shirts ship from California, splitting the cart.
+	_, err = SetRoutingRules(db, []RoutingRuleInput{{Rule: "proximity", Active: true}, {Rule: models.OrderRoutingRuleFullOrder, Active: true}})
+	require.NoError(t, err)
+	rules, err := ListRoutingRules(db)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	assert.Equal(t, models.OrderRoutingRulePriority, rules[2].Rule)
+	assert.False(t, rules[2].Active)
+	plan, err = PlanRoute(db, RouteRequest{Lines: cart, Country: "US", State: "CA"})
+	require.NoError(t, err)
+	require.Len(t, plan.Assignments, 2)
+	assert.Equal(t, california.Code, plan.Assignments[0].Location.Code)
+	assert.Equal(t, []RouteLine{{ProductVariantID: shirt.ID, Quantity: 2}}, plan.Assignments[0].Lines)
+	assert.Contains(t, plan.Assignments[0].Explanation, "in the destination state US-CA")
+	assert.Contains(t, plan.Assignments[1].Explanation, "order split across 2 locations")
+
+	plan, err = PlanRoute(db, RouteRequest{Lines: []RouteLine{{ProductVariantID: mug.ID, Quantity: 3}}})
+	require.NoError(t, err)
+	assert.Equal(t, []RouteLine{{ProductVariantID: mug.ID, Quantity: 2}}, plan.Unrouted)
+
+	_, err = SetRoutingRules(db, []RoutingRuleInput{{Rule: "NEAREST", Active: true}})
+	require.ErrorIs(t, err, ErrInvalidRoutingRule)
+}
+
+func stringPointer(value string) *string { return &value }
@@ -20,14 +20,22 @@ const (
 	MovementTypeOrderRelease = "ORDER_RELEASE"
 	MovementTypeAdminSync    = "ADMIN_STOCK_SYNC"
 	MovementTypeAdjustment   = "INVENTORY_ADJUSTMENT"
+	MovementTypeTransferOut  = "TRANSFER_OUT"
+	MovementTypeTransferIn   = "TRANSFER_IN"
+	// MovementTypeTransferReturn puts units back at the source of a transfer
+	// cancelled while in transit.
+	MovementTypeTransferReturn = "TRANSFER_RETURN"
 
 	ReferenceTypeOrder       = "ORDER"
 	ReferenceTypeReservation = "INVENTORY_RESERVATION"
 	ReferenceTypeAdjustment  = "INVENTORY_ADJUSTMENT"
+	ReferenceTypeTransfer    = "INVENTORY_TRANSFER"
 
 	ReservationTTL = 15 * time.Minute
 )
 
+// Availability is a variant's stock summed over sellable locations, which is
+// what the storefront can sell.
 type Availability struct {
 	ProductVariantID uint
 	OnHand           int
@@ -35,8 +43,12 @@ type Availability struct {
 	Available        int
 }
 
+// MovementInput changes on-hand stock at StockLocationID. Without a location,
+// units taken out are drawn from sellable locations and units put back go to
+// the default location.
 type MovementInput struct {
 	ProductVariantID uint
+	StockLocationID  uint
 	MovementType     string
 	QuantityDelta    int
 	ReferenceType    string
@@ -81,13 +93,15 @@ type AlertActionInput struct {
 
 type AdjustmentInput struct {
 	ProductVariantID uint
-	QuantityDelta    int
-	ReasonCode       string
-	Notes            string
-	ActorType        string
-	ActorID          *uint
-	ApprovedByType   string
-	ApprovedByID     *uint
+	// StockLocationID is the location counted; zero is the default location.
+	StockLocationID uint
+	QuantityDelta   int
+	ReasonCode      string
+	Notes           string
+	ActorType       string
+	ActorID         *uint
+	ApprovedByType  string
+	ApprovedByID    *uint
 }
 
 type AdjustmentPolicy struct {
@@ -118,17 +132,10 @@ type InventoryTimeline struct {
 }
 
 func GetAvailability(db *gorm.DB, productVariantID uint) (Availability, error) {
-	item, level, err := ensureLevel(db, productVariantID)
-	if err != nil {
+	if _, err := ensureItem(db, productVariantID); err != nil {
 		return Availability{}, err
 	}
-
-	return Availability{
-		ProductVariantID: item.ProductVariantID,
-		OnHand:           level.OnHand,
-		Reserved:         level.Reserved,
-		Available:        level.Available,
-	}, nil
+	return variantAvailability(db, productVariantID)
 }
 
 func Reserve(db *gorm.DB, input ReservationInput) (models.InventoryReservation, Availability, error) {
@@ -157,25 +164,35 @@ func Reserve(db *gorm.DB, input ReservationInput) (models.InventoryReservation,
 			return err
 		}
 
-		item, level, err := lockedLevel(tx, input.ProductVariantID)
+		item, err := ensureItem(tx, input.ProductVariantID)
 		if err != nil {
 			return err
 		}
-		if level.Available < input.Quantity {
-			return &InsufficientAvailabilityError{
-				ProductVariantID: input.ProductVariantID,
-				Requested:        input.Quantity,
-				Available:        level.Available,
-			}
+		levels, err := sellableLevels(tx, item.ID)
+		if err != nil {
+			return err
 		}
-
-		nextReserved := level.Reserved + input.Quantity
-		nextAvailable := level.OnHand - nextReserved
-		if nextReserved < 0 || nextAvailable < 0 {
-			return &InsufficientAvailabilityError{
-				ProductVariantID: input.ProductVariantID,
-				Requested:        input.Quantity,
-				Available:        level.Available,
+		var variant models.ProductVariant
+		if err := tx.Select("id", "inventory_policy", "preorder_release_at").First(&variant, input.ProductVariantID).Error; err != nil {
+			return err
+		}
+		// Stock on hand before a pre-order's release is kept for the launch:
+		// every unit sold until then waits as a backorder.
+		var draws []stockDraw
+		if !variant.Preordering(time.Now()) {
+			draws = planSellableDraw(levels, input.Quantity)
+		}
+		if draws == nil {
+			allowance, err := backorderAllowance(tx, input.ProductVariantID)
+			if err != nil {
+				return err
+			}
+			if allowance < input.Quantity {
+				return &InsufficientAvailabilityError{
+					ProductVariantID: input.ProductVariantID,
+					Requested:        input.Quantity,
+					Available:        totalAvailable(levels),
+				}
 			}
 		}
 
@@ -194,17 +211,37 @@ func Reserve(db *gorm.DB, input ReservationInput) (models.InventoryReservation,
 			CheckoutSessionID: input.CheckoutSessionID,
 			OrderID:           input.OrderID,
 			IdempotencyKey:    idempotencyKey,
+			Backordered:       draws == nil,
 		}
-		if err := tx.Create(&reservation).Error; err != nil {
+		if draws == nil {
+			if err := tx.Create(&reservation).Error; err != nil {
+				return err
+			}
+			availability, err = variantAvailability(tx, input.ProductVariantID)
 			return err
 		}
-		availability = Availability{
-			ProductVariantID: input.ProductVariantID,
-			OnHand:           level.OnHand,
-			Reserved:         nextReserved,
-			Available:        nextAvailable,
+		// A line no single location can cover is split, one reservation per
+		// location; the first keeps the caller's idempotency key.
+		first := reservation
+		for i, draw := range draws {
+			part := first
+			part.StockLocationID = draw.level.StockLocationID
+			part.Quantity = draw.quantity
+			if i > 0 {
+				part.IdempotencyKey = fmt.Sprintf("%s#%d", idempotencyKey, i+1)
+			}
+			if err := tx.Create(&part).Error; err != nil {
+				return err
+			}
+			if i == 0 {
+				reservation = part
+			}
+			if err := writeLevel(tx, draw.level, draw.level.OnHand, draw.level.Reserved+draw.quantity); err != nil {
+				return err
+			}
 		}
-		return updateLevelAndVariant(tx, level.ID, input.ProductVariantID, availability)
+		availability, err = refreshVariantStock(tx, input.ProductVariantID)
+		return err
 	})
 	return reservation, availability, err
 }
@@ -348,7 +385,7 @@ func CreateAdjustment(db *gorm.DB, input AdjustmentInput, policy AdjustmentPolic
 	var adjustment models.InventoryAdjustment
 	var availability Availability
 	err := db.Transaction(func(tx *gorm.DB) error {
-		item, level, err := lockedLevel(tx, input.ProductVariantID)
+		item, level, err := lockedLevel(tx, input.ProductVariantID, input.StockLocationID)
 		if err != nil {
 			return err
 		}
@@ -370,6 +407,7 @@ func CreateAdjustment(db *gorm.DB, input AdjustmentInput, policy AdjustmentPolic
 		adjustment = models.InventoryAdjustment{
 			InventoryItemID:  item.ID,
 			ProductVariantID: input.ProductVariantID,
+			StockLocationID:  level.StockLocationID,
 			QuantityDelta:    input.QuantityDelta,
 			ReasonCode:       reasonCode,
 			Notes:            strings.TrimSpace(input.Notes),
@@ -385,6 +423,7 @@ func CreateAdjustment(db *gorm.DB, input AdjustmentInput, policy AdjustmentPolic
 		referenceID := adjustment.ID
 		movement := models.InventoryMovement{
 			InventoryItemID: item.ID,
+			StockLocationID: level.StockLocationID,
 			MovementType:    MovementTypeAdjustment,
 			QuantityDelta:   input.QuantityDelta,
 			ReferenceType:   ReferenceTypeAdjustment,
@@ -396,13 +435,11 @@ func CreateAdjustment(db *gorm.DB, input AdjustmentInput, policy AdjustmentPolic
 		if err := tx.Create(&movement).Error; err != nil {
 			return err
 		}
-		availability = Availability{
-			ProductVariantID: input.ProductVariantID,
-			OnHand:           nextOnHand,
-			Reserved:         level.Reserved,
-			Available:        nextAvailable,
+		if err := writeLevel(tx, level, nextOnHand, level.Reserved); err != nil {
+			return err
 		}
-		return updateLevelAndVariant(tx, level.ID, input.ProductVariantID, availability)
+		availability, err = refreshVariantStock(tx, input.ProductVariantID)
+		return err
 	})
 	return adjustment, availability, err
 }
@@ -415,9 +452,10 @@ func Reconcile(db *gorm.DB, now time.Time) (ReconciliationReport, error) {
 	report := ReconciliationReport{CheckedAt: checkedAt}
 
 	var levels []models.InventoryLevel
-	if err := db.Preload("InventoryItem").Find(&levels).Error; err != nil {
+	if err := db.Preload("InventoryItem").Order("inventory_item_id ASC, stock_location_id ASC").Find(&levels).Error; err != nil {
 		return report, err
 	}
+	variantIDs := []uint{}
 	for _, level := range levels {
 		variantID := level.InventoryItem.ProductVariantID
 		expectedAvailable := level.OnHand - level.Reserved
@@ -436,7 +474,7 @@ func Reconcile(db *gorm.DB, now time.Time) (ReconciliationReport, error) {
 
 		var activeReservationTotal int64
 		if err := db.Model(&models.InventoryReservation{}).
-			Where("inventory_item_id = ? AND status = ?", level.InventoryItemID, models.InventoryReservationStatusActive).
+			Where("inventory_item_id = ? AND stock_location_id = ? AND status = ? AND backordered = ?", level.InventoryItemID, level.StockLocationID, models.InventoryReservationStatusActive, false).
 			Select("COALESCE(SUM(quantity), 0)").
 			Scan(&activeReservationTotal).Error; err != nil {
 			return report, err
@@ -454,15 +492,29 @@ func Reconcile(db *gorm.DB, now time.Time) (ReconciliationReport, error) {
 			})
 		}
 
+		if !slices.Contains(variantIDs, variantID) {
+			variantIDs = append(variantIDs, variantID)
+		}
+	}
+
+	for _, variantID := range variantIDs {
+		expected, err := variantAvailability(db, variantID)
+		if err != nil {
+			return report, err
+		}
 		var variant models.ProductVariant
-		if err := db.First(&variant, variantID).Error; err == nil && variant.Stock != level.Available {
+		if err := db.First(&variant, variantID).Error; err == nil && variant.Stock != expected.Available {
+			var item models.InventoryItem
+			if err := db.Where("product_variant_id = ?", variantID).First(&item).Error; err != nil {
+				return report, err
+			}
 			report.Issues = append(report.Issues, ReconciliationIssue{
 				IssueType:        "VARIANT_STOCK_MISMATCH",
-				InventoryItemID:  level.InventoryItemID,
+				InventoryItemID:  item.ID,
 				ProductVariantID: variantID,
-				Expected:         level.Available,
+				Expected:         expected.Available,
 				Actual:           variant.Stock,
-				Message:          "product variant stock does not match inventory available",
+				Message:          "product variant stock does not match inventory available at sellable locations",
 				EntityType:       "product_variant",
 				EntityID:         &variant.ID,
 			})
@@ -501,7 +553,7 @@ func GetTimeline(db *gorm.DB, productVariantID uint, limit int) (InventoryTimeli
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
-	item, _, err := ensureLevel(db, productVariantID)
+	item, err := ensureItem(db, productVariantID)
 	if err != nil {
 		return InventoryTimeline{}, err
 	}
@@ -622,58 +674,68 @@ func ApplyMovement(db *gorm.DB, input MovementInput) (Availability, error) {
 
 	var availability Availability
 	err := db.Transaction(func(tx *gorm.DB) error {
-		item, level, err := lockedLevel(tx, input.ProductVariantID)
-		if err != nil {
-			return err
-		}
-
-		nextOnHand := level.OnHand + input.QuantityDelta
-		nextReserved := level.Reserved
-		nextAvailable := nextOnHand - nextReserved
-		if nextOnHand < 0 {
-			return &InsufficientAvailabilityError{
-				ProductVariantID: input.ProductVariantID,
-				Requested:        -input.QuantityDelta,
-				Available:        level.Available,
+		if input.StockLocationID == 0 && input.QuantityDelta < 0 {
+			item, err := ensureItem(tx, input.ProductVariantID)
+			if err != nil {
+				return err
 			}
-		}
-		if nextReserved < 0 {
-			return fmt.Errorf("inventory reserved cannot be negative")
-		}
-		if nextAvailable != nextOnHand-nextReserved {
-			return fmt.Errorf("inventory availability invariant failed")
-		}
-		if nextAvailable < 0 {
-			return &InsufficientAvailabilityError{
-				ProductVariantID: input.ProductVariantID,
-				Requested:        -input.QuantityDelta,
-				Available:        level.Available,
+			levels, err := sellableLevels(tx, item.ID)
+			if err != nil {
+				return err
+			}
+			draws := planSellableDraw(levels, -input.QuantityDelta)
+			if draws == nil {
+				return &InsufficientAvailabilityError{
+					ProductVariantID: input.ProductVariantID,
+					Requested:        -input.QuantityDelta,
+					Available:        totalAvailable(levels),
+				}
+			}
+			for _, draw := range draws {
+				if err := moveLevel(tx, item, draw.level, -draw.quantity, input); err != nil {
+					return err
+				}
+			}
+		} else {
+			item, level, err := lockedLevel(tx, input.ProductVariantID, input.StockLocationID)
+			if err != nil {
+				return err
+			}
+			if err := moveLevel(tx, item, level, input.QuantityDelta, input); err != nil {
+				return err
 			}
 		}
+		var err error
+		availability, err = refreshVariantStock(tx, input.ProductVariantID)
+		return err
+	})
+	return availability, err
+}
 
-		movement := models.InventoryMovement{
-			InventoryItemID: item.ID,
-			MovementType:    input.MovementType,
-			QuantityDelta:   input.QuantityDelta,
-			ReferenceType:   input.ReferenceType,
-			ReferenceID:     input.ReferenceID,
-			ReasonCode:      input.ReasonCode,
-			ActorType:       input.ActorType,
-			ActorID:         input.ActorID,
-		}
-		if err := tx.Create(&movement).Error; err != nil {
-			return err
-		}
-
-		availability = Availability{
+// moveLevel records a movement of delta units at the level's location.
+func moveLevel(tx *gorm.DB, item models.InventoryItem, level models.InventoryLevel, delta int, input MovementInput) error {
+	nextOnHand := level.OnHand + delta
+	if nextOnHand < 0 || nextOnHand-level.Reserved < 0 {
+		return &InsufficientAvailabilityError{
 			ProductVariantID: input.ProductVariantID,
-			OnHand:           nextOnHand,
-			Reserved:         nextReserved,
-			Available:        nextAvailable,
+			Requested:        -delta,
+			Available:        level.Available,
 		}
-		return updateLevelAndVariant(tx, level.ID, input.ProductVariantID, availability)
-	})
-	return availability, err
+	}
+	if err := tx.Create(&models.InventoryMovement{
+		InventoryItemID: item.ID,
+		StockLocationID: level.StockLocationID,
+		MovementType:    input.MovementType,
+		QuantityDelta:   delta,
+		ReferenceType:   input.ReferenceType,
+		ReferenceID:     input.ReferenceID,
+		ReasonCode:      input.ReasonCode,
+		ActorType:       input.ActorType,
+		ActorID:         input.ActorID,
+	}).Error; err != nil {
+		return err
+	}
+	return writeLevel(tx, level, nextOnHand, level.Reserved)
 }
 
 func SetOnHand(db *gorm.DB, productVariantID uint, onHand int, input MovementInput) (Availability, error) {
@@ -690,7 +752,7 @@ func SetOnHand(db *gorm.DB, productVariantID uint, onHand int, input MovementInp
 
 	var availability Availability
 	err := db.Transaction(func(tx *gorm.DB) error {
-		item, level, err := lockedLevel(tx, productVariantID)
+		item, level, err := lockedLevel(tx, productVariantID, input.StockLocationID)
 		if err != nil {
 			return err
 		}
@@ -707,6 +769,7 @@ func SetOnHand(db *gorm.DB, productVariantID uint, onHand int, input MovementInp
 		if delta != 0 {
 			movement := models.InventoryMovement{
 				InventoryItemID: item.ID,
+				StockLocationID: level.StockLocationID,
 				MovementType:    movementType,
 				QuantityDelta:   delta,
 				ReferenceType:   input.ReferenceType,
@@ -720,13 +783,11 @@ func SetOnHand(db *gorm.DB, productVariantID uint, onHand int, input MovementInp
 			}
 		}
 
-		availability = Availability{
-			ProductVariantID: productVariantID,
-			OnHand:           onHand,
-			Reserved:         level.Reserved,
-			Available:        nextAvailable,
+		if err := writeLevel(tx, level, onHand, level.Reserved); err != nil {
+			return err
 		}
-		return updateLevelAndVariant(tx, level.ID, productVariantID, availability)
+		availability, err = refreshVariantStock(tx, productVariantID)
+		return err
 	})
 	return availability, err
 }
@@ -741,46 +802,78 @@ func (e *InsufficientAvailabilityError) Error() string {
 	return "insufficient inventory availability"
 }
 
-func ensureLevel(db *gorm.DB, productVariantID uint) (models.InventoryItem, models.InventoryLevel, error) {
+// ensureItem returns the variant's inventory item. A variant seen for the
+// first time has its current stock put in the default location.
+func ensureItem(db *gorm.DB, productVariantID uint) (models.InventoryItem, error) {
 	if productVariantID == 0 {
-		return models.InventoryItem{}, models.InventoryLevel{}, fmt.Errorf("product variant id is required")
+		return models.InventoryItem{}, fmt.Errorf("product variant id is required")
+	}
+	location, err := DefaultLocation(db)
+	if err != nil {
+		return models.InventoryItem{}, err
 	}
 
 	var item models.InventoryItem
-	err := db.Where("product_variant_id = ?", productVariantID).First(&item).Error
+	err = db.Where("product_variant_id = ?", productVariantID).First(&item).Error
+	if err == nil {
+		return item, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.InventoryItem{}, err
+	}
+	var variant models.ProductVariant
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&variant, productVariantID).Error; err != nil {
+		return models.InventoryItem{}, err
+	}
+	item = models.InventoryItem{ProductVariantID: productVariantID}
+	if err := db.Create(&item).Error; err != nil {
+		return models.InventoryItem{}, err
+	}
+	level := models.InventoryLevel{
+		InventoryItemID: item.ID,
+		StockLocationID: location.ID,
+		OnHand:          variant.Stock,
+		Reserved:        0,
+		Available:       variant.Stock,
+	}
+	if err := db.Create(&level).Error; err != nil {
+		return models.InventoryItem{}, err
+	}
+	return item, nil
+}
+
+// ensureLevel returns the variant's level at a location, zero meaning the
+// default location, starting it empty if the location never held the item.
+func ensureLevel(db *gorm.DB, productVariantID, stockLocationID uint) (models.InventoryItem, models.InventoryLevel, error) {
+	item, err := ensureItem(db, productVariantID)
 	if err != nil {
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.InventoryItem{}, models.InventoryLevel{}, err
-		}
-		var variant models.ProductVariant
-		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&variant, productVariantID).Error; err != nil {
-			return models.InventoryItem{}, models.InventoryLevel{}, err
-		}
-		item = models.InventoryItem{ProductVariantID: productVariantID}
-		if err := db.Create(&item).Error; err != nil {
-			return models.InventoryItem{}, models.InventoryLevel{}, err
-		}
-		level := models.InventoryLevel{
-			InventoryItemID: item.ID,
-			OnHand:          variant.Stock,
-			Reserved:        0,
-			Available:       variant.Stock,
-		}
-		if err := db.Create(&level).Error; err != nil {
+		return models.InventoryItem{}, models.InventoryLevel{}, err
+	}
+	if stockLocationID == 0 {
+		location, err := DefaultLocation(db)
+		if err != nil {
 			return models.InventoryItem{}, models.InventoryLevel{}, err
 		}
-		return item, level, nil
+		stockLocationID = location.ID
 	}
 
 	var level models.InventoryLevel
-	if err := db.Where("inventory_item_id = ?", item.ID).First(&level).Error; err != nil {
+	err = db.Where("inventory_item_id = ? AND stock_location_id = ?", item.ID, stockLocationID).First(&level).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if _, err := GetLocation(db, stockLocationID); err != nil {
+			return models.InventoryItem{}, models.InventoryLevel{}, err
+		}
+		level = models.InventoryLevel{InventoryItemID: item.ID, StockLocationID: stockLocationID}
+		err = db.Create(&level).Error
+	}
+	if err != nil {
 		return models.InventoryItem{}, models.InventoryLevel{}, err
 	}
 	return item, level, nil
 }
 
-func lockedLevel(tx *gorm.DB, productVariantID uint) (models.InventoryItem, models.InventoryLevel, error) {
-	item, level, err := ensureLevel(tx, productVariantID)
+func lockedLevel(tx *gorm.DB, productVariantID, stockLocationID uint) (models.InventoryItem, models.InventoryLevel, error) {
+	item, level, err := ensureLevel(tx, productVariantID, stockLocationID)
 	if err != nil {
 		return models.InventoryItem{}, models.InventoryLevel{}, err
 	}
@@ -790,29 +883,18 @@ func lockedLevel(tx *gorm.DB, productVariantID uint) (models.InventoryItem, mode
 	return item, level, nil
 }
 
-func updateLevelAndVariant(tx *gorm.DB, levelID uint, productVariantID uint, availability Availability) error {
-	if availability.OnHand < 0 || availability.Reserved < 0 || availability.Available != availability.OnHand-availability.Reserved {
+// writeLevel stores new on-hand and reserved counts for one location. The
+// caller refreshes the variant's storefront stock once it is done.
+func writeLevel(tx *gorm.DB, level models.InventoryLevel, onHand, reserved int) error {
+	available := onHand - reserved
+	if onHand < 0 || reserved < 0 || available < 0 {
 		return fmt.Errorf("inventory availability invariant failed")
 	}
-	var level models.InventoryLevel
-	if err := tx.First(&level, levelID).Error; err != nil {
-		return err
-	}
-	updates := map[string]any{
-		"on_hand":   availability.OnHand,
-		"reserved":  availability.Reserved,
-		"available": availability.Available,
-	}
-	if err := tx.Model(&models.InventoryLevel{}).Where("id = ?", levelID).Updates(updates).Error; err != nil {
-		return err
-	}
-	if err := tx.Model(&models.ProductVariant{}).Where("id = ?", productVariantID).Update("stock", availability.Available).Error; err != nil {
-		return err
-	}
-	level.OnHand = availability.OnHand
-	level.Reserved = availability.Reserved
-	level.Available = availability.Available
-	return reconcileAlerts(tx, level.InventoryItemID, productVariantID, availability.Available)
+	return tx.Model(&models.InventoryLevel{}).Where("id = ?", level.ID).Updates(map[string]any{
+		"on_hand":   onHand,
+		"reserved":  reserved,
+		"available": available,
+	}).Error
 }
 
 func updateAlertStatus(db *gorm.DB, alertID uint, input AlertActionInput, status string) (models.InventoryAlert, error) {
@@ -992,9 +1074,13 @@ func closeReservationsForOrder(tx *gorm.DB, orderID uint, idempotencyKey string,
 		return false, err
 	}
 	if len(reservations) == 0 {
+		closedStatuses := []string{status}
+		if status == models.InventoryReservationStatusConsumed {
+			closedStatuses = append(closedStatuses, models.InventoryReservationStatusBackordered)
+		}
 		var closedCount int64
 		if err := tx.Model(&models.InventoryReservation{}).
-			Where("order_id = ? AND status = ?", orderID, status).
+			Where("order_id = ? AND status IN ?", orderID, closedStatuses).
 			Count(&closedCount).Error; err != nil {
 			return false, err
 		}
@@ -1018,7 +1104,13 @@ func closeReservationsForOrder(tx *gorm.DB, orderID uint, idempotencyKey string,
 }
 
 func consumeReservation(tx *gorm.DB, reservation *models.InventoryReservation, idempotencyKey string, now time.Time) error {
-	_, level, err := lockedLevel(tx, reservation.ProductVariantID)
+	if reservation.Backordered {
+		// Nothing to commit yet; AllocateBackorders does it once stock arrives.
+		return tx.Model(&models.InventoryReservation{}).
+			Where("id = ? AND status = ?", reservation.ID, models.InventoryReservationStatusActive).
+			Updates(map[string]any{"status": models.InventoryReservationStatusBackordered, "updated_at": now.UTC()}).Error
+	}
+	_, level, err := lockedLevel(tx, reservation.ProductVariantID, reservation.StockLocationID)
 	if err != nil {
 		return err
 	}
@@ -1036,6 +1128,7 @@ func consumeReservation(tx *gorm.DB, reservation *models.InventoryReservation, i
 	referenceID := reservation.ID
 	movement := models.InventoryMovement{
 		InventoryItemID: reservation.InventoryItemID,
+		StockLocationID: level.StockLocationID,
 		MovementType:    MovementTypeOrderCommit,
 		QuantityDelta:   -reservation.Quantity,
 		ReferenceType:   ReferenceTypeReservation,
@@ -1059,19 +1152,21 @@ func consumeReservation(tx *gorm.DB, reservation *models.InventoryReservation, i
 		}).Error; err != nil {
 		return err
 	}
-	return updateLevelAndVariant(tx, level.ID, reservation.ProductVariantID, Availability{
-		ProductVariantID: reservation.ProductVariantID,
-		OnHand:           nextOnHand,
-		Reserved:         nextReserved,
-		Available:        nextAvailable,
-	})
+	if err := writeLevel(tx, level, nextOnHand, nextReserved); err != nil {
+		return err
+	}
+	_, err = refreshVariantStock(tx, reservation.ProductVariantID)
+	return err
 }
 
 func releaseReservation(tx *gorm.DB, reservation *models.InventoryReservation, status string, now time.Time) error {
 	if !slices.Contains([]string{models.InventoryReservationStatusReleased, models.InventoryReservationStatusExpired}, status) {
 		return fmt.Errorf("unsupported reservation release status")
 	}
-	_, level, err := lockedLevel(tx, reservation.ProductVariantID)
+	if reservation.Backordered {
+		return closeBackorderedReservation(tx, reservation, status, now)
+	}
+	_, level, err := lockedLevel(tx, reservation.ProductVariantID, reservation.StockLocationID)
 	if err != nil {
 		return err
 	}
@@ -1095,10 +1190,9 @@ func releaseReservation(tx *gorm.DB, reservation *models.InventoryReservation, s
 		Updates(updates).Error; err != nil {
 		return err
 	}
-	return updateLevelAndVariant(tx, level.ID, reservation.ProductVariantID, Availability{
-		ProductVariantID: reservation.ProductVariantID,
-		OnHand:           level.OnHand,
-		Reserved:         nextReserved,
-		Available:        nextAvailable,
-	})
+	if err := writeLevel(tx, level, level.OnHand, nextReserved); err != nil {
+		return err
+	}
+	_, err = refreshVariantStock(tx, reservation.ProductVariantID)
+	return err
 }
@@ -21,6 +21,10 @@ func newInventoryTestDB(t *testing.T) *gorm.DB {
 		&models.ProductVariant{},
 		&models.InventoryItem{},
 		&models.InventoryLevel{},
+		&models.StockLocation{},
+		&models.OrderRoutingRule{},
+		&models.InventoryTransfer{},
+		&models.InventoryTransferItem{},
 		&models.InventoryMovement{},
 		&models.InventoryReservation{},
 		&models.CheckoutSession{},
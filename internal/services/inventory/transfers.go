@@ -0,0 +1,202 @@
+package inventory
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTransferNotFound = errors.New("inventory transfer not found")
+	ErrInvalidTransfer  = errors.New("inventory transfer requires two different locations and items with positive quantities")
+	// ErrTransferNotAllowed rejects a step the transfer's status does not
+	// permit, such as receiving a draft that never shipped.
+	ErrTransferNotAllowed = errors.New("inventory transfer cannot make that change in its current status")
+)
+
+type TransferItemInput struct {
+	ProductVariantID uint
+	Quantity         int
+}
+
+type TransferInput struct {
+	FromLocationCode string
+	ToLocationCode   string
+	Notes            string
+	ActorType        string
+	ActorID          *uint
+	Items            []TransferItemInput
+}
+
+type TransferActionInput struct {
+	ActorType string
+	ActorID   *uint
+}
+
+func ListTransfers(db *gorm.DB, statuses []string, limit int) ([]models.InventoryTransfer, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 100
+	}
+	query := db.Preload("Items").Order("created_at DESC, id DESC").Limit(limit)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	var transfers []models.InventoryTransfer
+	err := query.Find(&transfers).Error
+	return transfers, err
+}
+
+// CreateTransfer drafts a transfer. Stock does not move until it ships.
+func CreateTransfer(db *gorm.DB, input TransferInput) (models.InventoryTransfer, error) {
+	if len(input.Items) == 0 {
+		return models.InventoryTransfer{}, ErrInvalidTransfer
+	}
+	var transfer models.InventoryTransfer
+	err := db.Transaction(func(tx *gorm.DB) error {
+		from, err := ResolveLocation(tx, input.FromLocationCode)
+		if err != nil {
+			return err
+		}
+		to, err := ResolveLocation(tx, input.ToLocationCode)
+		if err != nil {
+			return err
+		}
+		if from.ID == to.ID {
+			return ErrInvalidTransfer
+		}
+		transfer = models.InventoryTransfer{
+			FromLocationID: from.ID,
+			ToLocationID:   to.ID,
+			Status:         models.InventoryTransferStatusDraft,
+			Notes:          strings.TrimSpace(input.Notes),
+			ActorType:      strings.TrimSpace(input.ActorType),
+			ActorID:        input.ActorID,
+		}
+		if err := tx.Create(&transfer).Error; err != nil {
+			return err
+		}
+		for _, item := range input.Items {
+			if item.ProductVariantID == 0 || item.Quantity < 1 {
+				return ErrInvalidTransfer
+			}
+			if _, err := ensureItem(tx, item.ProductVariantID); err != nil {
+				return err
+			}
+			if err := tx.Create(&models.InventoryTransferItem{
+				InventoryTransferID: transfer.ID,
+				ProductVariantID:    item.ProductVariantID,
+				Quantity:            item.Quantity,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Preload("Items").First(&transfer, transfer.ID).Error
+	})
+	return transfer, err
+}
+
+// ShipTransfer takes the transfer's units out of the source location. They
+// count nowhere until the transfer is received.
+func ShipTransfer(db *gorm.DB, transferID uint, input TransferActionInput) (models.InventoryTransfer, error) {
+	return stepTransfer(db, transferID, models.InventoryTransferStatusDraft, func(tx *gorm.DB, transfer *models.InventoryTransfer, now time.Time) error {
+		if err := moveTransferItems(tx, *transfer, transfer.FromLocationID, -1, MovementTypeTransferOut, input); err != nil {
+			return err
+		}
+		transfer.Status = models.InventoryTransferStatusInTransit
+		transfer.ShippedAt = &now
+		return nil
+	})
+}
+
+// ReceiveTransfer puts the transfer's units into the destination location and
+// lets them fill backorders there.
+func ReceiveTransfer(db *gorm.DB, transferID uint, input TransferActionInput) (models.InventoryTransfer, error) {
+	return stepTransfer(db, transferID, models.InventoryTransferStatusInTransit, func(tx *gorm.DB, transfer *models.InventoryTransfer, now time.Time) error {
+		if err := moveTransferItems(tx, *transfer, transfer.ToLocationID, 1, MovementTypeTransferIn, input); err != nil {
+			return err
+		}
+		for _, item := range transfer.Items {
+			if _, err := AllocateBackorders(tx, item.ProductVariantID, transfer.ToLocationID, now); err != nil {
+				return err
+			}
+		}
+		transfer.Status = models.InventoryTransferStatusReceived
+		transfer.ReceivedAt = &now
+		return nil
+	})
+}
+
+// CancelTransfer abandons a transfer. Units already shipped go back to the
+// source location.
+func CancelTransfer(db *gorm.DB, transferID uint, input TransferActionInput) (models.InventoryTransfer, error) {
+	return stepTransfer(db, transferID, "", func(tx *gorm.DB, transfer *models.InventoryTransfer, now time.Time) error {
+		switch transfer.Status {
+		case models.InventoryTransferStatusDraft:
+		case models.InventoryTransferStatusInTransit:
+			if err := moveTransferItems(tx, *transfer, transfer.FromLocationID, 1, MovementTypeTransferReturn, input); err != nil {
+				return err
+			}
+		default:
+			return ErrTransferNotAllowed
+		}
+		transfer.Status = models.InventoryTransferStatusCancelled
+		transfer.CancelledAt = &now
+		return nil
+	})
+}
+
+// stepTransfer locks a transfer, checks it is in the status a step starts
+// from, runs the step and saves the result. An empty from skips the check.
+func stepTransfer(db *gorm.DB, transferID uint, from string, step func(*gorm.DB, *models.InventoryTransfer, time.Time) error) (models.InventoryTransfer, error) {
+	var transfer models.InventoryTransfer
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").First(&transfer, transferID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTransferNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if from != "" && transfer.Status != from {
+			return ErrTransferNotAllowed
+		}
+		if err := step(tx, &transfer, time.Now().UTC()); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.InventoryTransfer{}).Where("id = ?", transfer.ID).Updates(map[string]any{
+			"status":       transfer.Status,
+			"shipped_at":   transfer.ShippedAt,
+			"received_at":  transfer.ReceivedAt,
+			"cancelled_at": transfer.CancelledAt,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Items").First(&transfer, transfer.ID).Error
+	})
+	return transfer, err
+}
+
+func moveTransferItems(tx *gorm.DB, transfer models.InventoryTransfer, locationID uint, sign int, movementType string, input TransferActionInput) error {
+	referenceID := transfer.ID
+	for _, item := range transfer.Items {
+		if _, err := ApplyMovement(tx, MovementInput{
+			ProductVariantID: item.ProductVariantID,
+			StockLocationID:  locationID,
+			MovementType:     movementType,
+			QuantityDelta:    sign * item.Quantity,
+			ReferenceType:    ReferenceTypeTransfer,
+			ReferenceID:      &referenceID,
+			ReasonCode:       strings.ToLower(movementType),
+			ActorType:        strings.TrimSpace(input.ActorType),
+			ActorID:          input.ActorID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
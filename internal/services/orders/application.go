@@ -2,12 +2,17 @@ package orders
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/mail"
 	"strings"
 	"time"
 
+	"ecommerce/internal/checkoutplugins"
+	"ecommerce/internal/requestctx"
 	checkoutservice "ecommerce/internal/services/checkout"
+	inventoryservice "ecommerce/internal/services/inventory"
 	"ecommerce/models"
 
 	"github.com/google/uuid"
@@ -26,6 +31,9 @@ var (
 	ErrInvalidOrderStatus    = errors.New("invalid order status")
 	ErrOrderRequiresItems    = errors.New("order must contain at least one item")
 	ErrInvalidOrderItem      = errors.New("invalid order item")
+	// ErrOrderRiskReviewPending blocks fulfillment and capture of an order
+	// held by fraud screening until an admin approves it.
+	ErrOrderRiskReviewPending = errors.New("order is awaiting risk review")
 )
 
 type Service struct{ db *gorm.DB }
@@ -42,8 +50,24 @@ type ListInput struct {
 	Status    string
 	StartDate *time.Time
 	EndDate   *time.Time
-	Page      int
-	Limit     int
+	// Email and Name match part of the customer's email or name; SKU part of
+	// any line's SKU. ConfirmationToken, TrackingNumber, PaymentReference and
+	// Tag must match exactly, ignoring case.
+	Email             string
+	Name              string
+	SKU               string
+	ConfirmationToken string
+	TrackingNumber    string
+	PaymentReference  string
+	Tag               string
+	MinTotal          *models.Money
+	MaxTotal          *models.Money
+	// ViewID applies a saved view owned by ViewOwnerID. Filters set above
+	// take precedence over the view's.
+	ViewID      *uint
+	ViewOwnerID uint
+	Page        int
+	Limit       int
 }
 
 type Page struct {
@@ -52,11 +76,17 @@ type Page struct {
 	Limit      int
 	Total      int64
 	TotalPages int
+	// Tags holds each listed order's tags. It is only loaded for admin
+	// listings, which have no UserID.
+	Tags map[uint][]string
 }
 
 type CreateItemInput struct {
 	ProductVariantID uint
 	Quantity         int
+	// UnitPrice overrides the variant price, e.g. for a cart line locked by a
+	// saved-cart quote.
+	UnitPrice *models.Money
 }
 
 func (s *Service) Create(ctx context.Context, sessionID uint, userID *uint, guestEmail *string, items []CreateItemInput) (models.Order, error) {
@@ -123,28 +153,50 @@ func (s *Service) prepareOrderContext(ctx context.Context, sessionID uint, userI
 		return models.Order{}, ErrOrderRequiresItems
 	}
 	requested := make(map[uint]int, len(items))
+	prices := map[uint]models.Money{}
 	variantIDs := make([]uint, 0, len(items))
 	for _, input := range items {
-		if input.ProductVariantID == 0 || input.Quantity < 1 {
+		if input.ProductVariantID == 0 || input.Quantity < 1 || (input.UnitPrice != nil && *input.UnitPrice < 0) {
 			return models.Order{}, ErrInvalidOrderItem
 		}
 		if _, exists := requested[input.ProductVariantID]; !exists {
 			variantIDs = append(variantIDs, input.ProductVariantID)
 		}
 		requested[input.ProductVariantID] += input.Quantity
+		if input.UnitPrice != nil {
+			prices[input.ProductVariantID] = *input.UnitPrice
+		}
 	}
 	order := models.Order{CheckoutSessionID: sessionID, UserID: userID, GuestEmail: guestEmail, Status: models.StatusPending}
+	customer := checkoutservice.Customer{UserID: userID}
+	if guestEmail != nil {
+		customer.Email = *guestEmail
+	}
 	for _, variantID := range variantIDs {
 		quantity := requested[variantID]
 		var variant models.ProductVariant
 		if err := s.db.WithContext(ctx).Preload("Product").Where("id = ? AND is_published = ?", variantID, true).First(&variant).Error; err != nil {
 			return models.Order{}, err
 		}
-		if variant.Stock < quantity {
-			return models.Order{}, &InsufficientStockError{ProductVariantID: variant.ID, ProductName: variant.Product.Name, Requested: quantity, Available: variant.Stock}
+		sellable, err := inventoryservice.SellableQuantity(s.db.WithContext(ctx), variant)
+		if err != nil {
+			return models.Order{}, err
+		}
+		if sellable < quantity {
+			return models.Order{}, &InsufficientStockError{ProductVariantID: variant.ID, ProductName: variant.Product.Name, Requested: quantity, Available: sellable}
 		}
-		order.Total += variant.Price.Mul(quantity)
-		order.Items = append(order.Items, models.OrderItem{ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title, Quantity: quantity, Price: variant.Price})
+		if err := checkoutservice.CheckQuantityRules(variant, quantity); err != nil {
+			return models.Order{}, err
+		}
+		if err := checkoutservice.CheckPurchaseLimit(s.db.WithContext(ctx), variant, customer, quantity); err != nil {
+			return models.Order{}, err
+		}
+		price, locked := prices[variant.ID]
+		if !locked {
+			price = variant.Price
+		}
+		order.Total += price.Mul(quantity)
+		order.Items = append(order.Items, newOrderItem(variant, quantity, price, time.Now().UTC()))
 	}
 	if userID == nil {
 		token := uuid.NewString()
@@ -153,6 +205,19 @@ func (s *Service) prepareOrderContext(ctx context.Context, sessionID uint, userI
 	return order, nil
 }
 
+// newOrderItem is a line of quantity units of the variant sold at price at
+// now.
+func newOrderItem(variant models.ProductVariant, quantity int, price models.Money, now time.Time) models.OrderItem {
+	item := models.OrderItem{ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title, Quantity: quantity, Price: price}
+	// Every line sold before a pre-order's release waits for it; backorders
+	// only when the line is larger than what is in stock.
+	if variant.Preordering(now) || (variant.InventoryPolicy == models.InventoryPolicyBackorder && quantity > variant.Stock) {
+		item.InventoryPolicy = variant.InventoryPolicy
+		item.PromisedShipAt = variant.PromisedShipDate()
+	}
+	return item
+}
+
 func (s *Service) List(ctx context.Context, input ListInput) (Page, error) {
 	if s == nil || s.db == nil {
 		return Page{}, errors.New("order service is not configured")
@@ -167,14 +232,22 @@ func (s *Service) List(ctx context.Context, input ListInput) (Page, error) {
 	if limit > 100 {
 		limit = 100
 	}
+	if input.ViewID != nil {
+		view, err := orderView(s.db.WithContext(ctx), input.ViewOwnerID, *input.ViewID)
+		if err != nil {
+			return Page{}, err
+		}
+		DecodeViewFilters(view).fill(&input)
+	}
+	filters := ViewFilters{Status: input.Status, StartDate: input.StartDate, EndDate: input.EndDate, MinTotal: input.MinTotal, MaxTotal: input.MaxTotal}
+	if err := filters.validate(); err != nil {
+		return Page{}, err
+	}
 	query := s.db.WithContext(ctx).Model(&models.Order{})
 	if input.UserID != nil {
 		query = query.Where("user_id = ?", *input.UserID)
 	}
 	if status := strings.ToUpper(strings.TrimSpace(input.Status)); status != "" {
-		if !models.IsValidOrderStatus(status) {
-			return Page{}, ErrInvalidOrderStatus
-		}
 		query = query.Where("status = ?", status)
 	}
 	if input.StartDate != nil {
@@ -187,6 +260,7 @@ func (s *Service) List(ctx context.Context, input ListInput) (Page, error) {
 		like := "%" + strings.ToLower(search) + "%"
 		query = query.Where("CAST(orders.id AS TEXT) LIKE ? OR LOWER(COALESCE(orders.guest_email, '')) LIKE ? OR LOWER(orders.status) LIKE ?", like, like, like)
 	}
+	query = applySearchFilters(query, input)
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
 		return Page{}, err
@@ -199,7 +273,19 @@ func (s *Service) List(ctx context.Context, input ListInput) (Page, error) {
 	if int(total)%limit != 0 {
 		totalPages++
 	}
-	return Page{Orders: rows, Page: page, Limit: limit, Total: total, TotalPages: totalPages}, nil
+	result := Page{Orders: rows, Page: page, Limit: limit, Total: total, TotalPages: totalPages}
+	if input.UserID == nil {
+		ids := make([]uint, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		tags, err := OrderTags(s.db.WithContext(ctx), ids...)
+		if err != nil {
+			return Page{}, err
+		}
+		result.Tags = tags
+	}
+	return result, nil
 }
 
 func (s *Service) Get(ctx context.Context, orderID uint, userID *uint) (models.Order, error) {
@@ -228,6 +314,25 @@ func (s *Service) Get(ctx context.Context, orderID uint, userID *uint) (models.O
 	return order, nil
 }
 
+// SetAttributes stores validated checkout field values on an open order.
+func (s *Service) SetAttributes(ctx context.Context, order *models.Order, attributes map[string]string) error {
+	if s == nil || s.db == nil {
+		return errors.New("order service is not configured")
+	}
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	if attributes == nil {
+		encoded = []byte("{}")
+	}
+	if err := s.db.WithContext(ctx).Model(&models.Order{}).Where("id = ?", order.ID).Update("attributes_json", string(encoded)).Error; err != nil {
+		return err
+	}
+	order.AttributesJSON = string(encoded)
+	return nil
+}
+
 func (s *Service) PayableCheckoutOrder(ctx context.Context, orderID uint, session models.CheckoutSession) (models.Order, error) {
 	order, err := s.Get(ctx, orderID, func() *uint { value := uint(0); return &value }())
 	if err != nil {
@@ -252,20 +357,66 @@ func (s *Service) PayableCheckoutOrder(ctx context.Context, orderID uint, sessio
 	return order, nil
 }
 
-func (s *Service) UpdateStatus(ctx context.Context, orderID uint, status string) (models.Order, error) {
+// StatusUpdateInput is an admin's manual status change. Reason defaults to
+// "admin_status_update" in the status history.
+type StatusUpdateInput struct {
+	Status        string
+	Reason        string
+	Actor         string
+	CorrelationID string
+}
+
+func (s *Service) UpdateStatus(ctx context.Context, orderID uint, input StatusUpdateInput) (models.Order, error) {
 	if s == nil || s.db == nil {
 		return models.Order{}, errors.New("order service is not configured")
 	}
-	status = strings.ToUpper(strings.TrimSpace(status))
+	status := strings.ToUpper(strings.TrimSpace(input.Status))
 	if !models.IsValidOrderStatus(status) {
 		return models.Order{}, ErrInvalidOrderStatus
 	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		reason = "admin_status_update"
+	}
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var order models.Order
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
 			return err
 		}
-		return ApplyStatusTransition(tx, &order, status)
+		return ApplyStatusTransition(tx, &order, StatusTransition{
+			To: status, Path: PathAdmin, Source: "admin", Reason: reason, Actor: input.Actor, CorrelationID: input.CorrelationID,
+		})
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Order{}, ErrOrderNotFound
+	}
+	if err != nil {
+		return models.Order{}, err
+	}
+	return s.Get(ctx, orderID, nil)
+}
+
+// ItemCancelInput cancels units of one order line that will not ship.
+// Reason defaults to "item_quantity_cancelled" in the status history.
+type ItemCancelInput struct {
+	Quantity      int
+	Reason        string
+	Actor         string
+	CorrelationID string
+}
+
+func (s *Service) CancelItemQuantity(ctx context.Context, orderID, itemID uint, input ItemCancelInput) (models.Order, error) {
+	if s == nil || s.db == nil {
+		return models.Order{}, errors.New("order service is not configured")
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		reason = "item_quantity_cancelled"
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return CancelItemQuantity(tx, orderID, itemID, input.Quantity, StatusTransition{
+			Path: PathFulfillment, Source: "admin", Reason: reason, Actor: input.Actor, CorrelationID: input.CorrelationID,
+		}, time.Now().UTC())
 	})
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.Order{}, ErrOrderNotFound
@@ -276,6 +427,94 @@ func (s *Service) UpdateStatus(ctx context.Context, orderID uint, status string)
 	return s.Get(ctx, orderID, nil)
 }
 
+func (s *Service) BeginEdit(ctx context.Context, orderID uint, input OrderEditInput, plugins *checkoutplugins.Manager) (models.OrderEdit, error) {
+	return BeginOrderEdit(s.db.WithContext(ctx), orderID, input, plugins, time.Now().UTC())
+}
+func (s *Service) StageEditChange(ctx context.Context, editID uint, input OrderEditChangeInput, plugins *checkoutplugins.Manager) (models.OrderEdit, error) {
+	return StageOrderEditChange(s.db.WithContext(ctx), editID, input, plugins, time.Now().UTC())
+}
+func (s *Service) CancelEdit(ctx context.Context, editID uint, actor string) (models.OrderEdit, error) {
+	return CancelOrderEdit(s.db.WithContext(ctx), editID, actor, time.Now().UTC())
+}
+func (s *Service) GetEdit(ctx context.Context, editID uint) (models.OrderEdit, error) {
+	return GetOrderEdit(s.db.WithContext(ctx), editID)
+}
+func (s *Service) ListEdits(ctx context.Context, orderID uint) ([]models.OrderEdit, error) {
+	return ListOrderEdits(s.db.WithContext(ctx), orderID)
+}
+
+func (s *Service) CreateDraft(ctx context.Context, input DraftOrderInput, plugins *checkoutplugins.Manager) (models.DraftOrder, error) {
+	return CreateDraftOrder(s.db.WithContext(ctx), input, plugins)
+}
+func (s *Service) UpdateDraft(ctx context.Context, draftID uint, input DraftOrderInput, plugins *checkoutplugins.Manager) (models.DraftOrder, error) {
+	return UpdateDraftOrder(s.db.WithContext(ctx), draftID, input, plugins)
+}
+func (s *Service) SendDraftPaymentLink(ctx context.Context, draftID uint, expiresAt *time.Time) (models.DraftOrder, error) {
+	return SendDraftOrderPaymentLink(s.db.WithContext(ctx), draftID, expiresAt, time.Now().UTC())
+}
+func (s *Service) OpenDraftCheckout(ctx context.Context, token string, userID uint) (models.CheckoutSession, error) {
+	return OpenDraftOrderCheckout(s.db.WithContext(ctx), token, userID, time.Now().UTC())
+}
+func (s *Service) CancelDraft(ctx context.Context, draftID uint) (models.DraftOrder, error) {
+	return CancelDraftOrder(s.db.WithContext(ctx), draftID, time.Now().UTC())
+}
+func (s *Service) GetDraft(ctx context.Context, draftID uint) (models.DraftOrder, error) {
+	return GetDraftOrder(s.db.WithContext(ctx), draftID)
+}
+func (s *Service) ListDrafts(ctx context.Context, input ListDraftOrdersInput) ([]models.DraftOrder, int64, error) {
+	return ListDraftOrders(s.db.WithContext(ctx), input)
+}
+
+func (s *Service) ListLegalEntities(ctx context.Context) ([]models.LegalEntity, error) {
+	return ListLegalEntities(s.db.WithContext(ctx))
+}
+func (s *Service) CreateLegalEntity(ctx context.Context, input LegalEntityInput) (models.LegalEntity, error) {
+	return CreateLegalEntity(s.db.WithContext(ctx), input)
+}
+func (s *Service) UpdateLegalEntity(ctx context.Context, entityID uint, input LegalEntityInput) (models.LegalEntity, error) {
+	return UpdateLegalEntity(s.db.WithContext(ctx), entityID, input)
+}
+func (s *Service) IssueDocument(ctx context.Context, orderID uint, input IssueOrderDocumentInput) (models.OrderDocument, error) {
+	return IssueOrderDocument(s.db.WithContext(ctx), orderID, input, time.Now().UTC())
+}
+func (s *Service) ListDocuments(ctx context.Context, orderID uint) ([]models.OrderDocument, error) {
+	return ListOrderDocuments(s.db.WithContext(ctx), orderID)
+}
+func (s *Service) GetDocument(ctx context.Context, orderID, documentID uint) (models.OrderDocument, error) {
+	return GetOrderDocument(s.db.WithContext(ctx), orderID, documentID)
+}
+
+func (s *Service) Tags(ctx context.Context, orderIDs ...uint) (map[uint][]string, error) {
+	return OrderTags(s.db.WithContext(ctx), orderIDs...)
+}
+func (s *Service) SetTags(ctx context.Context, orderID uint, tags []string, actor string) ([]string, error) {
+	return SetOrderTags(s.db.WithContext(ctx), orderID, tags, actor)
+}
+func (s *Service) ListNotes(ctx context.Context, orderID uint) ([]models.OrderNote, error) {
+	return ListOrderNotes(s.db.WithContext(ctx), orderID)
+}
+func (s *Service) AddNote(ctx context.Context, orderID uint, body, author string) (models.OrderNote, error) {
+	return AddOrderNote(s.db.WithContext(ctx), orderID, body, author)
+}
+func (s *Service) DeleteNote(ctx context.Context, orderID, noteID uint) error {
+	return DeleteOrderNote(s.db.WithContext(ctx), orderID, noteID)
+}
+func (s *Service) ListViews(ctx context.Context, ownerUserID uint) ([]models.OrderSavedView, error) {
+	return ListOrderViews(s.db.WithContext(ctx), ownerUserID)
+}
+func (s *Service) CreateView(ctx context.Context, ownerUserID uint, name string, filters ViewFilters) (models.OrderSavedView, error) {
+	return CreateOrderView(s.db.WithContext(ctx), ownerUserID, name, filters)
+}
+func (s *Service) UpdateView(ctx context.Context, ownerUserID, viewID uint, name string, filters ViewFilters) (models.OrderSavedView, error) {
+	return UpdateOrderView(s.db.WithContext(ctx), ownerUserID, viewID, name, filters)
+}
+func (s *Service) DeleteView(ctx context.Context, ownerUserID, viewID uint) error {
+	return DeleteOrderView(s.db.WithContext(ctx), ownerUserID, viewID)
+}
+func (s *Service) Timeline(ctx context.Context, orderID uint) ([]TimelineEvent, error) {
+	return OrderTimeline(s.db.WithContext(ctx), orderID)
+}
+
 func (s *Service) Cancel(ctx context.Context, orderID, userID uint) (models.Order, error) {
 	if s == nil || s.db == nil {
 		return models.Order{}, errors.New("order service is not configured")
@@ -288,7 +527,11 @@ func (s *Service) Cancel(ctx context.Context, orderID, userID uint) (models.Orde
 		if !models.IsUserCancelableOrderStatus(order.Status) {
 			return ErrOrderCannotBeCanceled
 		}
-		return ApplyStatusTransition(tx, &order, models.StatusCancelled)
+		metadata, _ := requestctx.MetadataFrom(ctx)
+		return ApplyStatusTransition(tx, &order, StatusTransition{
+			To: models.StatusCancelled, Path: PathCustomer, Source: "customer", Reason: "customer_cancelled",
+			Actor: fmt.Sprintf("user:%d", userID), CorrelationID: metadata.CorrelationID,
+		})
 	})
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.Order{}, ErrOrderNotFound
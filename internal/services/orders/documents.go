@@ -0,0 +1,403 @@
+package orders
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrLegalEntityNotFound = errors.New("legal entity not found")
+	ErrInvalidLegalEntity  = errors.New("invalid legal entity")
+	// ErrNoLegalEntity rejects issuing an invoice or credit note when no
+	// legal entity covers the order's country and there is no default.
+	ErrNoLegalEntity = errors.New("no legal entity issues documents for this order")
+
+	ErrOrderDocumentNotFound = errors.New("order document not found")
+	ErrInvalidOrderDocument  = errors.New("invalid order document request")
+	// ErrOrderNotInvoiceable rejects invoicing an order whose sale has not
+	// gone through.
+	ErrOrderNotInvoiceable = errors.New("only paid orders can be invoiced")
+	// ErrOrderInvoiceMissing rejects a credit note for an order that was
+	// never invoiced, since there is nothing for it to correct.
+	ErrOrderInvoiceMissing = errors.New("order has no invoice to credit")
+)
+
+type LegalEntityInput struct {
+	Code             string
+	Name             string
+	Address          string
+	TaxID            string
+	Country          string
+	IsDefault        bool
+	InvoicePrefix    string
+	CreditNotePrefix string
+}
+
+// IssueOrderDocumentInput names the document to issue. Packing slips need the
+// fulfillment order they travel with and credit notes the refund transaction
+// they record.
+type IssueOrderDocumentInput struct {
+	Type                 string
+	FulfillmentOrderID   *uint
+	PaymentTransactionID *uint
+	Actor                string
+}
+
+func ListLegalEntities(db *gorm.DB) ([]models.LegalEntity, error) {
+	var entities []models.LegalEntity
+	err := db.Order("code ASC").Find(&entities).Error
+	return entities, err
+}
+
+func CreateLegalEntity(db *gorm.DB, input LegalEntityInput) (models.LegalEntity, error) {
+	entity := models.LegalEntity{Code: strings.ToLower(strings.TrimSpace(input.Code)), NextInvoiceNumber: 1, NextCreditNoteNumber: 1}
+	if entity.Code == "" {
+		return models.LegalEntity{}, fmt.Errorf("%w: code is required", ErrInvalidLegalEntity)
+	}
+	if err := applyLegalEntityInput(&entity, input); err != nil {
+		return models.LegalEntity{}, err
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.LegalEntity{}).Where("code = ?", entity.Code).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return fmt.Errorf("%w: code %q is already used", ErrInvalidLegalEntity, entity.Code)
+		}
+		if err := clearOtherDefaultEntities(tx, entity); err != nil {
+			return err
+		}
+		return tx.Create(&entity).Error
+	})
+	return entity, err
+}
+
+// UpdateLegalEntity changes an entity's details. The code and the number
+// sequences are left alone, so numbers already issued stay unique.
+func UpdateLegalEntity(db *gorm.DB, entityID uint, input LegalEntityInput) (models.LegalEntity, error) {
+	var entity models.LegalEntity
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&entity, entityID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrLegalEntityNotFound
+			}
+			return err
+		}
+		if err := applyLegalEntityInput(&entity, input); err != nil {
+			return err
+		}
+		if err := clearOtherDefaultEntities(tx, entity); err != nil {
+			return err
+		}
+		return tx.Save(&entity).Error
+	})
+	return entity, err
+}
+
+func applyLegalEntityInput(entity *models.LegalEntity, input LegalEntityInput) error {
+	entity.Name = strings.TrimSpace(input.Name)
+	entity.Address = strings.TrimSpace(input.Address)
+	entity.TaxID = strings.TrimSpace(input.TaxID)
+	entity.Country = strings.ToUpper(strings.TrimSpace(input.Country))
+	entity.IsDefault = input.IsDefault
+	entity.InvoicePrefix = strings.TrimSpace(input.InvoicePrefix)
+	if entity.InvoicePrefix == "" {
+		entity.InvoicePrefix = "INV-"
+	}
+	entity.CreditNotePrefix = strings.TrimSpace(input.CreditNotePrefix)
+	if entity.CreditNotePrefix == "" {
+		entity.CreditNotePrefix = "CN-"
+	}
+	switch {
+	case entity.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidLegalEntity)
+	case entity.Country != "" && len(entity.Country) != 2:
+		return fmt.Errorf("%w: country must be a two-letter code", ErrInvalidLegalEntity)
+	case entity.Country == "" && !entity.IsDefault:
+		return fmt.Errorf("%w: an entity needs a country unless it is the default", ErrInvalidLegalEntity)
+	case entity.InvoicePrefix == entity.CreditNotePrefix:
+		return fmt.Errorf("%w: invoices and credit notes need different prefixes", ErrInvalidLegalEntity)
+	}
+	return nil
+}
+
+func clearOtherDefaultEntities(tx *gorm.DB, entity models.LegalEntity) error {
+	if !entity.IsDefault {
+		return nil
+	}
+	return tx.Model(&models.LegalEntity{}).Where("is_default = ? AND code <> ?", true, entity.Code).Update("is_default", false).Error
+}
+
+// ListOrderDocuments returns an order's documents, newest first, without
+// their renderings.
+func ListOrderDocuments(db *gorm.DB, orderID uint) ([]models.OrderDocument, error) {
+	var documents []models.OrderDocument
+	err := db.Omit("html", "pdf").Where("order_id = ?", orderID).Order("issued_at DESC, id DESC").Find(&documents).Error
+	return documents, err
+}
+
+func GetOrderDocument(db *gorm.DB, orderID, documentID uint) (models.OrderDocument, error) {
+	var document models.OrderDocument
+	err := db.Where("order_id = ?", orderID).First(&document, documentID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OrderDocument{}, ErrOrderDocumentNotFound
+	}
+	return document, err
+}
+
+// IssueOrderDocument issues a document for an order. Each order has one
+// invoice, each fulfillment order one packing slip and each refund one
+// credit note; asking again returns the document already issued.
+func IssueOrderDocument(db *gorm.DB, orderID uint, input IssueOrderDocumentInput, now time.Time) (models.OrderDocument, error) {
+	var document models.OrderDocument
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).Preload("User").First(&order, orderID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+		var err error
+		switch strings.ToUpper(strings.TrimSpace(input.Type)) {
+		case models.OrderDocumentTypeInvoice:
+			document, err = issueInvoice(tx, order, input.Actor, now)
+		case models.OrderDocumentTypePackingSlip:
+			if input.FulfillmentOrderID == nil {
+				return fmt.Errorf("%w: a packing slip needs a fulfillment order", ErrInvalidOrderDocument)
+			}
+			document, err = issuePackingSlip(tx, order, *input.FulfillmentOrderID, input.Actor, now)
+		case models.OrderDocumentTypeCreditNote:
+			if input.PaymentTransactionID == nil {
+				return fmt.Errorf("%w: a credit note needs a refund transaction", ErrInvalidOrderDocument)
+			}
+			var refund models.PaymentTransaction
+			err = tx.Joins("JOIN payment_intents ON payment_intents.id = payment_transactions.payment_intent_id").
+				Where("payment_transactions.id = ? AND payment_intents.order_id = ? AND payment_transactions.operation = ? AND payment_transactions.status = ?",
+					*input.PaymentTransactionID, order.ID, models.PaymentTransactionOperationRefund, models.PaymentTransactionStatusSucceeded).
+				First(&refund).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: transaction %d is not a completed refund of this order", ErrInvalidOrderDocument, *input.PaymentTransactionID)
+			}
+			if err != nil {
+				return err
+			}
+			document, err = issueCreditNote(tx, order, refundCredit{Amount: refund.Amount, PaymentTransactionID: &refund.ID}, input.Actor, now)
+		default:
+			return fmt.Errorf("%w: unknown document type %q", ErrInvalidOrderDocument, input.Type)
+		}
+		return err
+	})
+	return document, err
+}
+
+// IssueRefundCreditNote records a completed refund with a credit note. An
+// order refunded before it was invoiced is invoiced first, since the refund
+// shows its sale was captured. Shops with no legal entity issue no documents,
+// and refunds already credited are skipped.
+func IssueRefundCreditNote(tx *gorm.DB, orderID uint, refund models.PaymentTransaction, now time.Time) error {
+	return issueRefundDocuments(tx, orderID, refundCredit{Amount: refund.Amount, PaymentTransactionID: &refund.ID}, now)
+}
+
+// IssueStoreCreditNote records a gift card issued as store credit in place of
+// a refund with a credit note, the same way IssueRefundCreditNote does.
+func IssueStoreCreditNote(tx *gorm.DB, orderID, giftCardID uint, amount models.Money, now time.Time) error {
+	return issueRefundDocuments(tx, orderID, refundCredit{Amount: amount, GiftCardID: &giftCardID}, now)
+}
+
+// refundCredit is what a credit note gives back: a refund transaction, or a
+// gift card issued as store credit instead.
+type refundCredit struct {
+	Amount               models.Money
+	PaymentTransactionID *uint
+	GiftCardID           *uint
+}
+
+func issueRefundDocuments(tx *gorm.DB, orderID uint, credit refundCredit, now time.Time) error {
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("User").First(&order, orderID).Error; err != nil {
+		return err
+	}
+	_, invoiced, err := existingOrderDocument(tx, "order_id = ? AND type = ?", order.ID, models.OrderDocumentTypeInvoice)
+	if err != nil {
+		return err
+	}
+	if !invoiced {
+		if _, err := createInvoice(tx, order, "system", now); err != nil {
+			if errors.Is(err, ErrNoLegalEntity) {
+				return nil
+			}
+			return err
+		}
+	}
+	_, err = issueCreditNote(tx, order, credit, "system", now)
+	return err
+}
+
+func issueInvoice(tx *gorm.DB, order models.Order, actor string, now time.Time) (models.OrderDocument, error) {
+	if existing, ok, err := existingOrderDocument(tx, "order_id = ? AND type = ?", order.ID, models.OrderDocumentTypeInvoice); err != nil || ok {
+		return existing, err
+	}
+	if order.Status != models.StatusRefunded && !slices.Contains(models.PaidOrderStatuses, order.Status) {
+		return models.OrderDocument{}, ErrOrderNotInvoiceable
+	}
+	return createInvoice(tx, order, actor, now)
+}
+
+func createInvoice(tx *gorm.DB, order models.Order, actor string, now time.Time) (models.OrderDocument, error) {
+	entity, err := orderLegalEntity(tx, order.ID)
+	if err != nil {
+		return models.OrderDocument{}, err
+	}
+	number, err := nextDocumentNumber(tx, entity.ID, models.OrderDocumentTypeInvoice)
+	if err != nil {
+		return models.OrderDocument{}, err
+	}
+	view, err := invoiceView(tx, order, entity, number, now)
+	if err != nil {
+		return models.OrderDocument{}, err
+	}
+	return createOrderDocument(tx, models.OrderDocument{
+		OrderID: order.ID, Type: models.OrderDocumentTypeInvoice, Number: number, LegalEntityID: &entity.ID,
+		Currency: view.Currency, Total: view.total, IssuedBy: actor, IssuedAt: now.UTC(),
+	}, view)
+}
+
+func issuePackingSlip(tx *gorm.DB, order models.Order, fulfillmentOrderID uint, actor string, now time.Time) (models.OrderDocument, error) {
+	if existing, ok, err := existingOrderDocument(tx, "fulfillment_order_id = ? AND type = ?", fulfillmentOrderID, models.OrderDocumentTypePackingSlip); err != nil || ok {
+		return existing, err
+	}
+	var fulfillment models.FulfillmentOrder
+	err := tx.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("order_id = ?", order.ID).First(&fulfillment, fulfillmentOrderID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OrderDocument{}, fmt.Errorf("%w: fulfillment order %d does not belong to this order", ErrInvalidOrderDocument, fulfillmentOrderID)
+	}
+	if err != nil {
+		return models.OrderDocument{}, err
+	}
+	if fulfillment.Status == models.FulfillmentStatusCancelled {
+		return models.OrderDocument{}, fmt.Errorf("%w: fulfillment order %d is cancelled", ErrInvalidOrderDocument, fulfillmentOrderID)
+	}
+	number := fmt.Sprintf("PS-%d-%d", order.ID, fulfillment.ID)
+	view := packingSlipView(order, fulfillment, number, now)
+	return createOrderDocument(tx, models.OrderDocument{
+		OrderID: order.ID, Type: models.OrderDocumentTypePackingSlip, Number: number, FulfillmentOrderID: &fulfillment.ID,
+		Currency: view.Currency, IssuedBy: actor, IssuedAt: now.UTC(),
+	}, view)
+}
+
+func issueCreditNote(tx *gorm.DB, order models.Order, credit refundCredit, actor string, now time.Time) (models.OrderDocument, error) {
+	query, source := "payment_transaction_id = ?", credit.PaymentTransactionID
+	if credit.GiftCardID != nil {
+		query, source = "gift_card_id = ?", credit.GiftCardID
+	}
+	if existing, ok, err := existingOrderDocument(tx, query, *source); err != nil || ok {
+		return existing, err
+	}
+	var invoice models.OrderDocument
+	err := tx.Omit("html", "pdf").Where("order_id = ? AND type = ?", order.ID, models.OrderDocumentTypeInvoice).First(&invoice).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OrderDocument{}, ErrOrderInvoiceMissing
+	}
+	if err != nil {
+		return models.OrderDocument{}, err
+	}
+	// The credit note is issued by whoever issued the invoice it corrects,
+	// even if the order's country has since moved to another entity.
+	var entity models.LegalEntity
+	if err := tx.First(&entity, *invoice.LegalEntityID).Error; err != nil {
+		return models.OrderDocument{}, err
+	}
+	number, err := nextDocumentNumber(tx, entity.ID, models.OrderDocumentTypeCreditNote)
+	if err != nil {
+		return models.OrderDocument{}, err
+	}
+	view, err := creditNoteView(tx, order, entity, invoice, credit, number, now)
+	if err != nil {
+		return models.OrderDocument{}, err
+	}
+	return createOrderDocument(tx, models.OrderDocument{
+		OrderID: order.ID, Type: models.OrderDocumentTypeCreditNote, Number: number, LegalEntityID: &entity.ID,
+		PaymentTransactionID: credit.PaymentTransactionID, GiftCardID: credit.GiftCardID, InvoiceID: &invoice.ID,
+		Currency: view.Currency, Total: credit.Amount, IssuedBy: actor, IssuedAt: now.UTC(),
+	}, view)
+}
+
+func existingOrderDocument(tx *gorm.DB, query string, args ...any) (models.OrderDocument, bool, error) {
+	var document models.OrderDocument
+	err := tx.Omit("html", "pdf").Where(query, args...).First(&document).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OrderDocument{}, false, nil
+	}
+	return document, err == nil, err
+}
+
+func createOrderDocument(tx *gorm.DB, document models.OrderDocument, view documentView) (models.OrderDocument, error) {
+	html, err := renderDocumentHTML(view)
+	if err != nil {
+		return models.OrderDocument{}, err
+	}
+	document.HTML = html
+	document.PDF = renderDocumentPDF(view)
+	sum := sha256.Sum256(document.PDF)
+	document.Checksum = hex.EncodeToString(sum[:])
+	if err := tx.Create(&document).Error; err != nil {
+		return models.OrderDocument{}, err
+	}
+	return document, nil
+}
+
+// orderLegalEntity picks the entity registered for the order's shipping
+// country, falling back to the default entity.
+func orderLegalEntity(tx *gorm.DB, orderID uint) (models.LegalEntity, error) {
+	country, _, err := shippingDestination(tx, orderID)
+	if err != nil {
+		return models.LegalEntity{}, err
+	}
+	var entity models.LegalEntity
+	query := tx.Where("is_default = ?", true)
+	if country != "" {
+		query = tx.Where("country = ? OR is_default = ?", country, true)
+	}
+	// Entities for the country sort ahead of the default.
+	err = query.Order("is_default ASC, id ASC").First(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.LegalEntity{}, ErrNoLegalEntity
+	}
+	return entity, err
+}
+
+// nextDocumentNumber takes the next number in the entity's invoice or credit
+// note sequence. The entity row stays locked until the transaction ends, so
+// numbers are handed out in order without gaps.
+func nextDocumentNumber(tx *gorm.DB, entityID uint, documentType string) (string, error) {
+	var entity models.LegalEntity
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&entity, entityID).Error; err != nil {
+		return "", err
+	}
+	column, prefix, next := "next_invoice_number", entity.InvoicePrefix, entity.NextInvoiceNumber
+	if documentType == models.OrderDocumentTypeCreditNote {
+		column, prefix, next = "next_credit_note_number", entity.CreditNotePrefix, entity.NextCreditNoteNumber
+	}
+	if err := tx.Model(&models.LegalEntity{}).Where("id = ?", entity.ID).Update(column, next+1).Error; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%06d", prefix, next), nil
+}
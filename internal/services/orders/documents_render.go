@@ -0,0 +1,357 @@
+package orders
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"ecommerce/internal/pdf"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// documentView is what an order document shows. The HTML template and the
+// PDF layout both render from it, so the two always agree.
+type documentView struct {
+	Title      string
+	Number     string
+	IssuedAt   string
+	Seller     *documentParty
+	OrderID    uint
+	OrderDate  string
+	Customer   string
+	ShipTo     string
+	Currency   string
+	ShowPrices bool
+	Lines      []documentLine
+	Totals     []documentAmount
+	TaxLines   []documentTaxLine
+	Notes      []string
+	total      models.Money
+}
+
+type documentParty struct {
+	Name    string
+	Address string
+	TaxID   string
+}
+
+type documentLine struct {
+	SKU       string
+	Title     string
+	Quantity  int
+	UnitPrice string
+	Amount    string
+}
+
+type documentAmount struct {
+	Label  string
+	Amount string
+	Strong bool
+}
+
+type documentTaxLine struct {
+	Name    string
+	Rate    string
+	Taxable string
+	Amount  string
+}
+
+func invoiceView(tx *gorm.DB, order models.Order, entity models.LegalEntity, number string, now time.Time) (documentView, error) {
+	snapshot, err := latestOrderSnapshot(tx, order.ID)
+	if err != nil {
+		return documentView{}, err
+	}
+	view := baseDocumentView("Invoice", number, order, now)
+	view.Seller = &documentParty{Name: entity.Name, Address: entity.Address, TaxID: entity.TaxID}
+	view.ShowPrices = true
+	if snapshot.Currency != "" {
+		view.Currency = snapshot.Currency
+	}
+	var merchandise models.Money
+	for _, item := range order.Items {
+		quantity := item.Quantity - item.QuantityCancelled
+		if quantity < 1 {
+			continue
+		}
+		amount := item.Price.Mul(quantity)
+		merchandise += amount
+		view.Lines = append(view.Lines, documentLine{SKU: item.VariantSKU, Title: item.VariantTitle, Quantity: quantity, UnitPrice: item.Price.String(), Amount: amount.String()})
+	}
+	view.Totals = append(view.Totals, documentAmount{Label: "Subtotal", Amount: merchandise.String()})
+	if snapshot.ID != 0 {
+		if discount := merchandise - snapshot.Subtotal; discount > 0 {
+			view.Totals = append(view.Totals, documentAmount{Label: "Discounts", Amount: (-discount).String()})
+		}
+		view.Totals = append(view.Totals, documentAmount{Label: "Shipping", Amount: snapshot.ShippingAmount.String()})
+		taxLines, inclusive, err := invoiceTaxLines(tx, order.ID, snapshot.ID)
+		if err != nil {
+			return documentView{}, err
+		}
+		view.TaxLines = taxLines
+		label := "Tax"
+		if inclusive {
+			label = "Tax included in prices"
+		}
+		view.Totals = append(view.Totals, documentAmount{Label: label, Amount: snapshot.TaxAmount.String()})
+	}
+	view.total = order.Total
+	view.Totals = append(view.Totals, documentAmount{Label: "Total", Amount: order.Total.String(), Strong: true})
+	if order.PaymentMethodDisplay != "" {
+		view.Notes = append(view.Notes, "Paid by "+order.PaymentMethodDisplay+".")
+	}
+	return view, nil
+}
+
+func packingSlipView(order models.Order, fulfillment models.FulfillmentOrder, number string, now time.Time) documentView {
+	view := baseDocumentView("Packing slip", number, order, now)
+	for _, line := range fulfillment.Lines {
+		view.Lines = append(view.Lines, documentLine{SKU: line.VariantSKU, Title: line.VariantTitle, Quantity: line.Quantity})
+	}
+	view.Notes = append(view.Notes, "Shipped from "+fulfillment.LocationCode+".")
+	if note := strings.TrimSpace(fulfillment.PackingNote); note != "" {
+		view.Notes = append(view.Notes, note)
+	}
+	return view
+}
+
+func creditNoteView(tx *gorm.DB, order models.Order, entity models.LegalEntity, invoice models.OrderDocument, credit refundCredit, number string, now time.Time) (documentView, error) {
+	snapshot, err := latestOrderSnapshot(tx, order.ID)
+	if err != nil {
+		return documentView{}, err
+	}
+	view := baseDocumentView("Credit note", number, order, now)
+	view.Seller = &documentParty{Name: entity.Name, Address: entity.Address, TaxID: entity.TaxID}
+	view.ShowPrices = true
+	view.Currency = invoice.Currency
+	title := fmt.Sprintf("Refund against invoice %s", invoice.Number)
+	if credit.GiftCardID != nil {
+		title = fmt.Sprintf("Store credit against invoice %s", invoice.Number)
+	}
+	view.Lines = []documentLine{{
+		Title: title, Quantity: 1, UnitPrice: credit.Amount.String(), Amount: credit.Amount.String(),
+	}}
+	// Refunds are not itemised, so the tax they return is shown in
+	// proportion to the order's total.
+	if snapshot.Total > 0 && snapshot.TaxAmount > 0 {
+		tax := models.Money(int64(snapshot.TaxAmount) * int64(credit.Amount) / int64(snapshot.Total))
+		view.Totals = append(view.Totals, documentAmount{Label: "Of which tax", Amount: tax.String()})
+	}
+	view.total = credit.Amount
+	view.Totals = append(view.Totals, documentAmount{Label: "Total credited", Amount: credit.Amount.String(), Strong: true})
+	view.Notes = append(view.Notes, fmt.Sprintf("This credit note corrects invoice %s, issued %s.", invoice.Number, invoice.IssuedAt.UTC().Format("2006-01-02")))
+	return view, nil
+}
+
+func baseDocumentView(title, number string, order models.Order, now time.Time) documentView {
+	view := documentView{
+		Title: title, Number: number, IssuedAt: now.UTC().Format("2006-01-02"), OrderID: order.ID,
+		OrderDate: order.CreatedAt.UTC().Format("2006-01-02"), ShipTo: order.ShippingAddressPretty, Currency: "USD",
+	}
+	switch {
+	case order.User != nil:
+		view.Customer = order.User.Email
+	case order.GuestEmail != nil:
+		view.Customer = *order.GuestEmail
+	}
+	return view
+}
+
+// latestOrderSnapshot returns the order's checkout snapshot, or a zero one for
+// orders placed without checkout.
+func latestOrderSnapshot(tx *gorm.DB, orderID uint) (models.OrderCheckoutSnapshot, error) {
+	var snapshot models.OrderCheckoutSnapshot
+	err := tx.Where("order_id = ?", orderID).Order("id DESC").First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OrderCheckoutSnapshot{}, nil
+	}
+	return snapshot, err
+}
+
+// invoiceTaxLines sums the order's finalized tax lines by tax and rate.
+func invoiceTaxLines(tx *gorm.DB, orderID, snapshotID uint) ([]documentTaxLine, bool, error) {
+	var lines []models.OrderTaxLine
+	if err := tx.Where("order_id = ? AND snapshot_id = ?", orderID, snapshotID).Order("id ASC").Find(&lines).Error; err != nil {
+		return nil, false, err
+	}
+	type taxKey struct {
+		name string
+		rate int
+	}
+	taxable := map[taxKey]models.Money{}
+	amounts := map[taxKey]models.Money{}
+	keys := []taxKey{}
+	inclusive := len(lines) > 0
+	for _, line := range lines {
+		name := strings.TrimSpace(line.TaxName)
+		if name == "" {
+			name = "Tax"
+		}
+		if line.Jurisdiction != "" {
+			name += " (" + line.Jurisdiction + ")"
+		}
+		key := taxKey{name: name, rate: line.TaxRateBasisPoints}
+		if _, ok := amounts[key]; !ok {
+			keys = append(keys, key)
+		}
+		taxable[key] += line.TaxableAmount
+		amounts[key] += line.TaxAmount
+		inclusive = inclusive && line.Inclusive
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].name < keys[j].name })
+	result := make([]documentTaxLine, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, documentTaxLine{
+			Name: key.name, Rate: strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", float64(key.rate)/100), "0"), ".") + "%",
+			Taxable: taxable[key].String(), Amount: amounts[key].String(),
+		})
+	}
+	return result, inclusive, nil
+}
+
+var documentTemplate = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} {{.Number}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 12px; color: #222; margin: 40px; }
+h1 { font-size: 22px; margin: 0 0 4px; }
+table { width: 100%; border-collapse: collapse; margin-top: 16px; }
+th, td { padding: 6px 4px; border-bottom: 1px solid #ddd; text-align: left; vertical-align: top; }
+.num { text-align: right; }
+.parties { display: flex; justify-content: space-between; margin-top: 16px; }
+.address { white-space: pre-line; }
+.totals td { border: none; }
+.strong { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div>No. {{.Number}} &middot; Issued {{.IssuedAt}}</div>
+<div>Order #{{.OrderID}} placed {{.OrderDate}}</div>
+<div class="parties">
+{{- with .Seller}}
+<div><strong>{{.Name}}</strong><div class="address">{{.Address}}</div>{{if .TaxID}}<div>Tax ID: {{.TaxID}}</div>{{end}}</div>
+{{- end}}
+<div>{{if .Customer}}<div>{{.Customer}}</div>{{end}}{{if .ShipTo}}<div><strong>Ship to</strong></div><div class="address">{{.ShipTo}}</div>{{end}}</div>
+</div>
+<table>
+<thead><tr><th>SKU</th><th>Description</th><th class="num">Qty</th>{{if .ShowPrices}}<th class="num">Unit price</th><th class="num">Amount ({{.Currency}})</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Lines}}
+<tr><td>{{.SKU}}</td><td>{{.Title}}</td><td class="num">{{.Quantity}}</td>{{if $.ShowPrices}}<td class="num">{{.UnitPrice}}</td><td class="num">{{.Amount}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- if .Totals}}
+<table class="totals">
+{{- range .Totals}}
+<tr{{if .Strong}} class="strong"{{end}}><td class="num">{{.Label}}</td><td class="num">{{.Amount}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .TaxLines}}
+<table>
+<thead><tr><th>Tax</th><th class="num">Rate</th><th class="num">Taxable</th><th class="num">Tax</th></tr></thead>
+<tbody>
+{{- range .TaxLines}}
+<tr><td>{{.Name}}</td><td class="num">{{.Rate}}</td><td class="num">{{.Taxable}}</td><td class="num">{{.Amount}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- range .Notes}}
+<p>{{.}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+func renderDocumentHTML(view documentView) (string, error) {
+	var out bytes.Buffer
+	if err := documentTemplate.Execute(&out, view); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func renderDocumentPDF(view documentView) []byte {
+	doc := pdf.New()
+	doc.Text(view.Title, 20, true)
+	doc.Text(fmt.Sprintf("No. %s  -  Issued %s", view.Number, view.IssuedAt), 10, false)
+	doc.Text(fmt.Sprintf("Order #%d placed %s", view.OrderID, view.OrderDate), 10, false)
+	doc.Space(12)
+	if view.Seller != nil {
+		doc.Text(view.Seller.Name, 11, true)
+		if view.Seller.Address != "" {
+			doc.Text(view.Seller.Address, 10, false)
+		}
+		if view.Seller.TaxID != "" {
+			doc.Text("Tax ID: "+view.Seller.TaxID, 10, false)
+		}
+		doc.Space(8)
+	}
+	if view.Customer != "" {
+		doc.Text(view.Customer, 10, false)
+	}
+	if view.ShipTo != "" {
+		doc.Text("Ship to", 10, true)
+		doc.Text(view.ShipTo, 10, false)
+	}
+	doc.Space(12)
+
+	right := pdf.ContentWidth
+	if view.ShowPrices {
+		doc.Row(10, true, pdf.Cell{Text: "SKU"}, pdf.Cell{X: 90, Text: "Description"}, pdf.Cell{X: 330, Text: "Qty", AlignRight: true},
+			pdf.Cell{X: 410, Text: "Unit price", AlignRight: true}, pdf.Cell{X: right, Text: "Amount (" + view.Currency + ")", AlignRight: true})
+	} else {
+		doc.Row(10, true, pdf.Cell{Text: "SKU"}, pdf.Cell{X: 110, Text: "Description"}, pdf.Cell{X: right, Text: "Qty", AlignRight: true})
+	}
+	doc.Rule()
+	for _, line := range view.Lines {
+		if view.ShowPrices {
+			doc.Row(10, false, pdf.Cell{Text: clip(line.SKU, 14)}, pdf.Cell{X: 90, Text: clip(line.Title, 38)},
+				pdf.Cell{X: 330, Text: fmt.Sprint(line.Quantity), AlignRight: true}, pdf.Cell{X: 410, Text: line.UnitPrice, AlignRight: true},
+				pdf.Cell{X: right, Text: line.Amount, AlignRight: true})
+		} else {
+			doc.Row(10, false, pdf.Cell{Text: clip(line.SKU, 18)}, pdf.Cell{X: 110, Text: clip(line.Title, 60)},
+				pdf.Cell{X: right, Text: fmt.Sprint(line.Quantity), AlignRight: true})
+		}
+	}
+	doc.Rule()
+	for _, total := range view.Totals {
+		doc.Row(10, total.Strong, pdf.Cell{X: 410, Text: total.Label, AlignRight: true}, pdf.Cell{X: right, Text: total.Amount, AlignRight: true})
+	}
+	if len(view.TaxLines) > 0 {
+		doc.Space(12)
+		doc.Row(10, true, pdf.Cell{Text: "Tax"}, pdf.Cell{X: 330, Text: "Rate", AlignRight: true},
+			pdf.Cell{X: 410, Text: "Taxable", AlignRight: true}, pdf.Cell{X: right, Text: "Tax", AlignRight: true})
+		doc.Rule()
+		for _, line := range view.TaxLines {
+			doc.Row(10, false, pdf.Cell{Text: clip(line.Name, 45)}, pdf.Cell{X: 330, Text: line.Rate, AlignRight: true},
+				pdf.Cell{X: 410, Text: line.Taxable, AlignRight: true}, pdf.Cell{X: right, Text: line.Amount, AlignRight: true})
+		}
+	}
+	if len(view.Notes) > 0 {
+		doc.Space(12)
+		for _, note := range view.Notes {
+			doc.Text(note, 10, false)
+		}
+	}
+	return doc.Bytes()
+}
+
+// clip shortens text that would run into the next column.
+func clip(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-3]) + "..."
+}
@@ -0,0 +1,481 @@
+package orders
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"ecommerce/internal/checkoutplugins"
+	checkoutservice "ecommerce/internal/services/checkout"
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DraftPaymentLinkTTL is how long a payment link stays open when staff do not
+// choose an expiry.
+const DraftPaymentLinkTTL = 7 * 24 * time.Hour
+
+const maxDraftOrderLines = 100
+
+var (
+	ErrDraftOrderNotFound = errors.New("draft order not found")
+	ErrInvalidDraftOrder  = errors.New("invalid draft order")
+	// ErrDraftOrderClosed rejects changing or paying a draft that was already
+	// completed or cancelled.
+	ErrDraftOrderClosed      = errors.New("draft order is completed or cancelled")
+	ErrDraftOrderNotInvoiced = errors.New("draft order has no open payment link")
+	ErrDraftOrderLinkExpired = errors.New("draft order payment link has expired")
+	// ErrDraftOrderRestricted rejects opening a payment link as anyone but the
+	// customer the draft was made for.
+	ErrDraftOrderRestricted = errors.New("draft order is reserved for another customer")
+)
+
+type DraftOrderLineInput struct {
+	ProductVariantID uint
+	Quantity         int
+	// UnitPrice overrides the variant's current price.
+	UnitPrice *models.Money
+	// DiscountPercent takes a share off the unit price, stored like a percent
+	// campaign value (1250 is 12.5%).
+	DiscountPercent models.Money
+}
+
+// DraftOrderInput is the whole content of a draft. Exactly one of
+// CustomerUserID and GuestEmail names who the order is for.
+type DraftOrderInput struct {
+	CustomerUserID     *uint
+	GuestEmail         string
+	Note               string
+	ShippingProviderID string
+	ShippingData       map[string]string
+	Lines              []DraftOrderLineInput
+	Actor              string
+}
+
+type ListDraftOrdersInput struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+// CreateDraftOrder prices and saves a new OPEN draft.
+func CreateDraftOrder(db *gorm.DB, input DraftOrderInput, plugins *checkoutplugins.Manager) (models.DraftOrder, error) {
+	token, err := newDraftPaymentToken()
+	if err != nil {
+		return models.DraftOrder{}, err
+	}
+	draft := models.DraftOrder{Status: models.DraftOrderStatusOpen, CreatedBy: input.Actor, PaymentToken: token}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := fillDraftOrder(tx, &draft, input, plugins); err != nil {
+			return err
+		}
+		return tx.Create(&draft).Error
+	})
+	if err != nil {
+		return models.DraftOrder{}, err
+	}
+	return GetDraftOrder(db, draft.ID)
+}
+
+// UpdateDraftOrder replaces a draft's customer, lines and shipping and prices
+// it again. Changing an invoiced draft takes it back to OPEN and closes its
+// payment link, so the customer never pays for what they were not sent.
+func UpdateDraftOrder(db *gorm.DB, draftID uint, input DraftOrderInput, plugins *checkoutplugins.Manager) (models.DraftOrder, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		draft, err := lockChangeableDraftOrder(tx, draftID)
+		if err != nil {
+			return err
+		}
+		if err := closeDraftOrderPaymentLink(tx, &draft); err != nil {
+			return err
+		}
+		if err := fillDraftOrder(tx, &draft, input, plugins); err != nil {
+			return err
+		}
+		draft.Status, draft.PaymentLinkExpiresAt = models.DraftOrderStatusOpen, nil
+		if err := tx.Where("draft_order_id = ?", draft.ID).Delete(&models.DraftOrderLine{}).Error; err != nil {
+			return err
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(&draft).Error
+	})
+	if err != nil {
+		return models.DraftOrder{}, err
+	}
+	return GetDraftOrder(db, draftID)
+}
+
+// SendDraftOrderPaymentLink marks a draft INVOICED with a payment link open
+// until expiresAt, or DraftPaymentLinkTTL from now. Sending it again renews
+// the link; a checkout opened from the old one is closed.
+func SendDraftOrderPaymentLink(db *gorm.DB, draftID uint, expiresAt *time.Time, now time.Time) (models.DraftOrder, error) {
+	expiry := now.UTC().Add(DraftPaymentLinkTTL)
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return models.DraftOrder{}, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidDraftOrder)
+		}
+		expiry = expiresAt.UTC()
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		draft, err := lockChangeableDraftOrder(tx, draftID)
+		if err != nil {
+			return err
+		}
+		if err := closeDraftOrderPaymentLink(tx, &draft); err != nil {
+			return err
+		}
+		timestamp := now.UTC()
+		return tx.Model(&models.DraftOrder{}).Where("id = ?", draft.ID).Updates(map[string]any{
+			"status": models.DraftOrderStatusInvoiced, "payment_link_expires_at": expiry, "invoiced_at": timestamp,
+			"checkout_session_id": nil, "updated_at": timestamp,
+		}).Error
+	})
+	if err != nil {
+		return models.DraftOrder{}, err
+	}
+	return GetDraftOrder(db, draftID)
+}
+
+// OpenDraftOrderCheckout starts, or resumes, the checkout session a payment
+// link opens. A draft made for a customer only opens for that customer's
+// account, and a guest draft only without one, because the session belongs to
+// whoever the order is for.
+func OpenDraftOrderCheckout(db *gorm.DB, token string, userID uint, now time.Time) (models.CheckoutSession, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return models.CheckoutSession{}, ErrDraftOrderNotFound
+	}
+	var session models.CheckoutSession
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var draft models.DraftOrder
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, id ASC")
+		}).Where("payment_token = ?", token).First(&draft).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDraftOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case draft.Status == models.DraftOrderStatusCompleted || draft.Status == models.DraftOrderStatusCancelled:
+			return ErrDraftOrderClosed
+		case draft.Status != models.DraftOrderStatusInvoiced:
+			return ErrDraftOrderNotInvoiced
+		case draft.PaymentLinkExpired(now):
+			return ErrDraftOrderLinkExpired
+		}
+		owner := uint(0)
+		if draft.CustomerUserID != nil {
+			owner = *draft.CustomerUserID
+		}
+		if owner != userID {
+			return ErrDraftOrderRestricted
+		}
+		if draft.CheckoutSessionID != nil {
+			existing, ok, err := checkoutservice.ActiveDraftOrderSession(tx, *draft.CheckoutSessionID, now)
+			if err != nil {
+				return err
+			}
+			if ok {
+				session = existing
+				return nil
+			}
+		}
+
+		lines := make([]checkoutservice.DraftOrderCheckoutLine, 0, len(draft.Lines))
+		for _, line := range draft.Lines {
+			lines = append(lines, checkoutservice.DraftOrderCheckoutLine{ProductVariantID: line.ProductVariantID, Quantity: line.Quantity, UnitPrice: line.FinalUnitPrice})
+		}
+		session, err = checkoutservice.StartDraftOrderCheckout(tx, checkoutservice.StartDraftOrderCheckoutInput{
+			UserID: draft.CustomerUserID, GuestEmail: draft.GuestEmail, ShippingProviderID: draft.ShippingProviderID,
+			ShippingData: decodeStringMap(draft.ShippingDataJSON), ExpiresAt: *draft.PaymentLinkExpiresAt, Lines: lines,
+		}, now)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.DraftOrder{}).Where("id = ?", draft.ID).Update("checkout_session_id", session.ID).Error
+	})
+	return session, err
+}
+
+// PlaceDraftOrder turns a draft into a PENDING order in its own converted
+// checkout session, for staff recording a payment taken outside checkout.
+// The caller records the payment and completes the draft.
+func PlaceDraftOrder(tx *gorm.DB, draftID uint, paymentDisplay string, now time.Time) (models.DraftOrder, models.Order, error) {
+	draft, err := lockChangeableDraftOrder(tx, draftID)
+	if err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	if err := closeDraftOrderPaymentLink(tx, &draft); err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	if err := tx.Where("draft_order_id = ?", draft.ID).Order("position ASC, id ASC").Find(&draft.Lines).Error; err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	session := models.CheckoutSession{
+		PublicToken: uuid.NewString(), UserID: draft.CustomerUserID, GuestEmail: draft.GuestEmail,
+		Status: models.CheckoutSessionStatusConverted, ExpiresAt: now, LastSeenAt: now, Kind: models.CheckoutSessionKindDraftOrder,
+	}
+	if err := tx.Create(&session).Error; err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	order := models.Order{
+		CheckoutSessionID: session.ID, UserID: draft.CustomerUserID, GuestEmail: draft.GuestEmail, Status: models.StatusPending,
+		Total: draft.Total, PaymentMethodDisplay: paymentDisplay, ShippingAddressPretty: draft.ShippingAddressPretty,
+	}
+	if draft.CustomerUserID == nil {
+		token := uuid.NewString()
+		order.ConfirmationToken = &token
+	}
+	for _, line := range draft.Lines {
+		var variant models.ProductVariant
+		if err := tx.Preload("Product").First(&variant, line.ProductVariantID).Error; err != nil {
+			return models.DraftOrder{}, models.Order{}, err
+		}
+		sellable, err := inventoryservice.SellableQuantity(tx, variant)
+		if err != nil {
+			return models.DraftOrder{}, models.Order{}, err
+		}
+		if sellable < line.Quantity {
+			return models.DraftOrder{}, models.Order{}, &InsufficientStockError{ProductVariantID: variant.ID, ProductName: variant.Product.Name, Requested: line.Quantity, Available: sellable}
+		}
+		order.Items = append(order.Items, newOrderItem(variant, line.Quantity, line.FinalUnitPrice, now))
+	}
+	if err := tx.Create(&order).Error; err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	draft.CheckoutSessionID = &session.ID
+	return draft, order, nil
+}
+
+// CompleteDraftOrder records the order a draft became and how it was paid.
+func CompleteDraftOrder(tx *gorm.DB, draftID, sessionID, orderID uint, method, reference string, now time.Time) error {
+	timestamp := now.UTC()
+	return tx.Model(&models.DraftOrder{}).Where("id = ?", draftID).Updates(map[string]any{
+		"status": models.DraftOrderStatusCompleted, "checkout_session_id": sessionID, "order_id": orderID,
+		"payment_method": method, "payment_reference": reference, "completed_at": timestamp, "updated_at": timestamp,
+	}).Error
+}
+
+// CompleteDraftOrderForSession completes the draft whose payment link opened
+// sessionID, once checkout has placed orderID. Sessions not opened from a
+// draft are left alone.
+func CompleteDraftOrderForSession(tx *gorm.DB, sessionID, orderID uint, method string, now time.Time) error {
+	var draft models.DraftOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("checkout_session_id = ? AND status = ?", sessionID, models.DraftOrderStatusInvoiced).First(&draft).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return CompleteDraftOrder(tx, draft.ID, sessionID, orderID, method, "", now)
+}
+
+// CancelDraftOrder closes a draft that was never paid, along with its payment
+// link.
+func CancelDraftOrder(db *gorm.DB, draftID uint, now time.Time) (models.DraftOrder, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		draft, err := lockChangeableDraftOrder(tx, draftID)
+		if err != nil {
+			return err
+		}
+		if err := closeDraftOrderPaymentLink(tx, &draft); err != nil {
+			return err
+		}
+		timestamp := now.UTC()
+		return tx.Model(&models.DraftOrder{}).Where("id = ?", draft.ID).
+			Updates(map[string]any{"status": models.DraftOrderStatusCancelled, "cancelled_at": timestamp, "updated_at": timestamp}).Error
+	})
+	if err != nil {
+		return models.DraftOrder{}, err
+	}
+	return GetDraftOrder(db, draftID)
+}
+
+func GetDraftOrder(db *gorm.DB, draftID uint) (models.DraftOrder, error) {
+	var draft models.DraftOrder
+	err := db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC, id ASC")
+	}).First(&draft, draftID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DraftOrder{}, ErrDraftOrderNotFound
+	}
+	return draft, err
+}
+
+// ListDraftOrders returns drafts newest first, optionally in one status.
+func ListDraftOrders(db *gorm.DB, input ListDraftOrdersInput) ([]models.DraftOrder, int64, error) {
+	query := db.Model(&models.DraftOrder{})
+	if status := strings.ToUpper(strings.TrimSpace(input.Status)); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var drafts []models.DraftOrder
+	err := query.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC, id ASC")
+	}).Order("created_at DESC, id DESC").Limit(input.Limit).Offset(input.Offset).Find(&drafts).Error
+	return drafts, total, err
+}
+
+// fillDraftOrder validates input onto draft and prices it: each line at its
+// final unit price, then shipping and tax from the providers at the draft's
+// address. No payment is involved yet.
+func fillDraftOrder(tx *gorm.DB, draft *models.DraftOrder, input DraftOrderInput, plugins *checkoutplugins.Manager) error {
+	if plugins == nil {
+		return errors.New("checkout plugins are not configured")
+	}
+	guestEmail := strings.TrimSpace(input.GuestEmail)
+	switch {
+	case input.CustomerUserID != nil && guestEmail != "":
+		return fmt.Errorf("%w: choose either a customer or a guest email", ErrInvalidDraftOrder)
+	case input.CustomerUserID != nil:
+		var user models.User
+		if err := tx.Select("id").First(&user, *input.CustomerUserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: customer %d does not exist", ErrInvalidDraftOrder, *input.CustomerUserID)
+			}
+			return err
+		}
+		draft.CustomerUserID, draft.GuestEmail = input.CustomerUserID, nil
+	case guestEmail != "":
+		address, err := mail.ParseAddress(guestEmail)
+		if err != nil {
+			return fmt.Errorf("%w: guest email is invalid", ErrInvalidDraftOrder)
+		}
+		normalized := strings.ToLower(address.Address)
+		draft.CustomerUserID, draft.GuestEmail = nil, &normalized
+	default:
+		return fmt.Errorf("%w: a customer or a guest email is required", ErrInvalidDraftOrder)
+	}
+	if len(input.Lines) == 0 {
+		return fmt.Errorf("%w: at least one line is required", ErrInvalidDraftOrder)
+	}
+	if len(input.Lines) > maxDraftOrderLines {
+		return fmt.Errorf("%w: at most %d lines are allowed", ErrInvalidDraftOrder, maxDraftOrderLines)
+	}
+
+	draft.Note = strings.TrimSpace(input.Note)
+	draft.Lines = make([]models.DraftOrderLine, 0, len(input.Lines))
+	draft.Subtotal = 0
+	seen := map[uint]bool{}
+	for index, line := range input.Lines {
+		if line.ProductVariantID == 0 || line.Quantity < 1 {
+			return fmt.Errorf("%w: lines need a variant and a positive quantity", ErrInvalidDraftOrder)
+		}
+		if seen[line.ProductVariantID] {
+			return fmt.Errorf("%w: variant %d is on more than one line", ErrInvalidDraftOrder, line.ProductVariantID)
+		}
+		seen[line.ProductVariantID] = true
+		if line.UnitPrice != nil && *line.UnitPrice < 0 {
+			return fmt.Errorf("%w: unit prices cannot be negative", ErrInvalidDraftOrder)
+		}
+		if line.DiscountPercent < 0 || line.DiscountPercent > 10000 {
+			return fmt.Errorf("%w: discount_percent must be between 0 and 100", ErrInvalidDraftOrder)
+		}
+		var variant models.ProductVariant
+		err := tx.Joins("JOIN products ON products.id = product_variants.product_id AND products.deleted_at IS NULL").
+			Where("product_variants.id = ? AND product_variants.is_published = ?", line.ProductVariantID, true).
+			First(&variant).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: variant %d", checkoutservice.ErrVariantNotFound, line.ProductVariantID)
+		}
+		if err != nil {
+			return err
+		}
+		price := variant.Price
+		if line.UnitPrice != nil {
+			price = *line.UnitPrice
+		}
+		final := price - price*line.DiscountPercent/10000
+		draft.Lines = append(draft.Lines, models.DraftOrderLine{
+			ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title, Quantity: line.Quantity,
+			ListPrice: variant.Price, UnitPrice: price, DiscountPercent: line.DiscountPercent, FinalUnitPrice: final, Position: index,
+		})
+		draft.Subtotal += final.Mul(line.Quantity)
+	}
+
+	shippingData := make(map[string]string, len(input.ShippingData))
+	for key, value := range input.ShippingData {
+		shippingData[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	providerID := strings.TrimSpace(input.ShippingProviderID)
+	quote := plugins.Quote(checkoutplugins.QuoteRequest{Subtotal: draft.Subtotal, ShippingID: providerID, ShippingData: shippingData})
+	if problems := draftQuoteProblems(quote); len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidDraftOrder, strings.Join(problems, "; "))
+	}
+	address, err := plugins.ShippingAddress(draft.Subtotal, providerID, shippingData)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDraftOrder, err)
+	}
+	encoded, err := json.Marshal(shippingData)
+	if err != nil {
+		return err
+	}
+	draft.ShippingProviderID, draft.ShippingDataJSON, draft.ShippingAddressPretty = providerID, string(encoded), address
+	draft.Currency, draft.ShippingAmount, draft.TaxAmount = quote.Currency, quote.Shipping, quote.Tax
+	draft.Total = draft.Subtotal + quote.Shipping + quote.Tax
+	return nil
+}
+
+// draftQuoteProblems lists the shipping and tax errors of a quote. Payment
+// errors are expected: the customer has not chosen how to pay yet.
+func draftQuoteProblems(quote checkoutplugins.QuoteResult) []string {
+	var problems []string
+	for _, state := range append(append([]checkoutplugins.State{}, quote.ShippingStates...), quote.TaxStates...) {
+		if state.Severity == checkoutplugins.SeverityError {
+			problems = append(problems, state.Message)
+		}
+	}
+	return problems
+}
+
+// lockChangeableDraftOrder locks a draft that has not been completed or
+// cancelled.
+func lockChangeableDraftOrder(tx *gorm.DB, draftID uint) (models.DraftOrder, error) {
+	var draft models.DraftOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&draft, draftID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DraftOrder{}, ErrDraftOrderNotFound
+		}
+		return models.DraftOrder{}, err
+	}
+	if draft.Status != models.DraftOrderStatusOpen && draft.Status != models.DraftOrderStatusInvoiced {
+		return models.DraftOrder{}, ErrDraftOrderClosed
+	}
+	return draft, nil
+}
+
+// closeDraftOrderPaymentLink expires the checkout a payment link opened, so
+// an order placed there can no longer be paid.
+func closeDraftOrderPaymentLink(tx *gorm.DB, draft *models.DraftOrder) error {
+	if draft.CheckoutSessionID == nil {
+		return nil
+	}
+	if err := checkoutservice.ExpireDraftOrderSession(tx, *draft.CheckoutSessionID); err != nil {
+		return err
+	}
+	draft.CheckoutSessionID = nil
+	return nil
+}
+
+func newDraftPaymentToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
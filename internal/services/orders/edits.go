@@ -0,0 +1,739 @@
+package orders
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ecommerce/internal/checkoutplugins"
+	checkoutservice "ecommerce/internal/services/checkout"
+	discountservice "ecommerce/internal/services/discounts"
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrOrderEditNotFound = errors.New("order edit not found")
+	// ErrOrderNotEditable rejects editing an order that is not paid, was not
+	// placed through checkout, or has units packed or shipped.
+	ErrOrderNotEditable       = errors.New("only paid checkout orders with nothing packed or shipped can be edited")
+	ErrOrderEditInProgress    = errors.New("order already has an open edit")
+	ErrOrderEditClosed        = errors.New("order edit is no longer open")
+	ErrOrderEditStale         = errors.New("order changed after the edit was opened")
+	ErrInvalidOrderEditChange = errors.New("invalid order edit change")
+	ErrOrderEditEmpty         = errors.New("an edited order must keep at least one item")
+	// ErrOrderEditQuoteInvalid reports that the order's shipping and tax
+	// providers reject the edited order, usually because of a new address.
+	ErrOrderEditQuoteInvalid = errors.New("the edited order cannot be quoted with its shipping and tax providers")
+)
+
+type OrderEditInput struct {
+	Reason string
+	Actor  string
+}
+
+// OrderEditChangeInput is one staged change. LineID names the edit line for
+// SET_QUANTITY and SWAP_VARIANT, ProductVariantID the variant for ADD_ITEM
+// and SWAP_VARIANT, and ShippingData the new address for UPDATE_ADDRESS.
+type OrderEditChangeInput struct {
+	Kind             string
+	LineID           uint
+	ProductVariantID uint
+	Quantity         int
+	ShippingData     map[string]string
+	Actor            string
+}
+
+// OrderEditCommitInput records who committed an edit in the order's status
+// history.
+type OrderEditCommitInput struct {
+	Actor         string
+	CorrelationID string
+}
+
+// BeginOrderEdit opens an edit of a paid order with its unshipped lines, priced
+// as they stand. An order has at most one open edit.
+func BeginOrderEdit(db *gorm.DB, orderID uint, input OrderEditInput, plugins *checkoutplugins.Manager, now time.Time) (models.OrderEdit, error) {
+	var editID uint
+	err := db.Transaction(func(tx *gorm.DB) error {
+		order, snapshot, err := lockEditableOrder(tx, orderID)
+		if err != nil {
+			return err
+		}
+		var open int64
+		if err := tx.Model(&models.OrderEdit{}).Where("order_id = ? AND status = ?", orderID, models.OrderEditStatusOpen).Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrOrderEditInProgress
+		}
+		var items []models.OrderItem
+		if err := tx.Where("order_id = ?", orderID).Order("id ASC").Find(&items).Error; err != nil {
+			return err
+		}
+		edit := models.OrderEdit{
+			OrderID: orderID, SnapshotID: snapshot.ID, Status: models.OrderEditStatusOpen,
+			Reason: strings.TrimSpace(input.Reason), Actor: input.Actor,
+			ShippingDataJSON: snapshot.ShippingDataJSON, ShippingAddressPretty: order.ShippingAddressPretty,
+			Currency: snapshot.Currency, OriginalTotal: order.Total,
+		}
+		for _, item := range items {
+			if remaining := item.RemainingQuantity(); remaining > 0 {
+				itemID := item.ID
+				edit.Lines = append(edit.Lines, models.OrderEditLine{
+					OrderItemID: &itemID, ProductVariantID: item.ProductVariantID, VariantSKU: item.VariantSKU,
+					VariantTitle: item.VariantTitle, Quantity: remaining, UnitPrice: item.Price,
+				})
+			}
+		}
+		if err := priceOrderEdit(tx, &edit, order, snapshot, plugins, now); err != nil {
+			return err
+		}
+		if err := tx.Create(&edit).Error; err != nil {
+			return err
+		}
+		editID = edit.ID
+		summary := fmt.Sprintf("Opened an edit of order #%d", orderID)
+		if edit.Reason != "" {
+			summary += ": " + edit.Reason
+		}
+		return recordOrderEditChange(tx, edit.ID, models.OrderEditChangeOpen, summary, input.Actor)
+	})
+	if err != nil {
+		return models.OrderEdit{}, err
+	}
+	return GetOrderEdit(db, editID)
+}
+
+// StageOrderEditChange applies one change to an open edit and prices the
+// edited order again through the discount campaigns and the order's shipping
+// and tax providers. Nothing on the order itself changes until the edit is
+// committed.
+func StageOrderEditChange(db *gorm.DB, editID uint, input OrderEditChangeInput, plugins *checkoutplugins.Manager, now time.Time) (models.OrderEdit, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		edit, err := lockOpenOrderEdit(tx, editID)
+		if err != nil {
+			return err
+		}
+		order, snapshot, err := lockEditableOrder(tx, edit.OrderID)
+		if err != nil {
+			return err
+		}
+		kind := strings.ToUpper(strings.TrimSpace(input.Kind))
+		lineIndex := -1
+		for i, line := range edit.Lines {
+			if line.ID == input.LineID {
+				lineIndex = i
+			}
+		}
+		removed := []uint{}
+		summary := ""
+		switch kind {
+		case models.OrderEditChangeAddItem:
+			if input.Quantity < 1 {
+				return ErrInvalidOrderEditChange
+			}
+			variant, err := editableVariant(tx, input.ProductVariantID)
+			if err != nil {
+				return err
+			}
+			added := false
+			for i := range edit.Lines {
+				if edit.Lines[i].ProductVariantID == variant.ID {
+					edit.Lines[i].Quantity += input.Quantity
+					if err := checkoutservice.CheckQuantityRules(variant, edit.Lines[i].Quantity); err != nil {
+						return err
+					}
+					added = true
+				}
+			}
+			if !added {
+				if err := checkoutservice.CheckQuantityRules(variant, input.Quantity); err != nil {
+					return err
+				}
+				edit.Lines = append(edit.Lines, models.OrderEditLine{
+					OrderEditID: edit.ID, ProductVariantID: variant.ID, VariantSKU: variant.SKU,
+					VariantTitle: variant.Title, Quantity: input.Quantity, UnitPrice: variant.Price,
+				})
+			}
+			summary = fmt.Sprintf("Added %d × %s", input.Quantity, variant.SKU)
+		case models.OrderEditChangeSetQuantity:
+			if lineIndex < 0 || input.Quantity < 0 {
+				return ErrInvalidOrderEditChange
+			}
+			line := &edit.Lines[lineIndex]
+			if input.Quantity == 0 {
+				kind = models.OrderEditChangeRemoveItem
+				summary = fmt.Sprintf("Removed %d × %s", line.Quantity, line.VariantSKU)
+				if line.OrderItemID == nil {
+					removed = append(removed, line.ID)
+					edit.Lines = append(edit.Lines[:lineIndex], edit.Lines[lineIndex+1:]...)
+					break
+				}
+				line.Quantity = 0
+				break
+			}
+			variant, err := editableVariant(tx, line.ProductVariantID)
+			if err != nil {
+				return err
+			}
+			if err := checkoutservice.CheckQuantityRules(variant, input.Quantity); err != nil {
+				return err
+			}
+			summary = fmt.Sprintf("Changed %s from %d to %d", line.VariantSKU, line.Quantity, input.Quantity)
+			line.Quantity = input.Quantity
+		case models.OrderEditChangeSwapVariant:
+			if lineIndex < 0 || edit.Lines[lineIndex].Quantity == 0 {
+				return ErrInvalidOrderEditChange
+			}
+			line := &edit.Lines[lineIndex]
+			var current models.ProductVariant
+			if err := tx.Unscoped().First(&current, line.ProductVariantID).Error; err != nil {
+				return err
+			}
+			variant, err := editableVariant(tx, input.ProductVariantID)
+			if err != nil {
+				return err
+			}
+			// A swap exchanges a size or colour of the same product; anything
+			// else is a removal and an addition.
+			if variant.ProductID != current.ProductID || variant.ID == current.ID {
+				return fmt.Errorf("%w: variant %d is not another option of the same product", ErrInvalidOrderEditChange, variant.ID)
+			}
+			for _, other := range edit.Lines {
+				if other.ProductVariantID == variant.ID {
+					return fmt.Errorf("%w: variant %d is already on the order; change its quantity instead", ErrInvalidOrderEditChange, variant.ID)
+				}
+			}
+			if err := checkoutservice.CheckQuantityRules(variant, line.Quantity); err != nil {
+				return err
+			}
+			summary = fmt.Sprintf("Swapped %d × %s for %s", line.Quantity, line.VariantSKU, variant.SKU)
+			line.ProductVariantID, line.VariantSKU, line.VariantTitle, line.UnitPrice = variant.ID, variant.SKU, variant.Title, variant.Price
+		case models.OrderEditChangeUpdateAddress:
+			if len(input.ShippingData) == 0 {
+				return ErrInvalidOrderEditChange
+			}
+			encoded, err := json.Marshal(input.ShippingData)
+			if err != nil {
+				return err
+			}
+			edit.ShippingDataJSON = string(encoded)
+		default:
+			return ErrInvalidOrderEditChange
+		}
+
+		var items []models.OrderItem
+		if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+			return err
+		}
+		if err := checkOrderEditStock(tx, edit, items); err != nil {
+			return err
+		}
+		if err := priceOrderEdit(tx, &edit, order, snapshot, plugins, now); err != nil {
+			return err
+		}
+		if kind == models.OrderEditChangeUpdateAddress {
+			summary = "Changed the shipping address to " + edit.ShippingAddressPretty
+		}
+		if len(removed) > 0 {
+			if err := tx.Delete(&models.OrderEditLine{}, removed).Error; err != nil {
+				return err
+			}
+		}
+		if err := saveOrderEdit(tx, &edit); err != nil {
+			return err
+		}
+		return recordOrderEditChange(tx, edit.ID, kind, summary, input.Actor)
+	})
+	if err != nil {
+		return models.OrderEdit{}, err
+	}
+	return GetOrderEdit(db, editID)
+}
+
+// CancelOrderEdit discards an open edit, leaving the order as it was.
+func CancelOrderEdit(db *gorm.DB, editID uint, actor string, now time.Time) (models.OrderEdit, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		edit, err := lockOpenOrderEdit(tx, editID)
+		if err != nil {
+			return err
+		}
+		timestamp := now.UTC()
+		if err := tx.Model(&models.OrderEdit{}).Where("id = ?", edit.ID).
+			Updates(map[string]any{"status": models.OrderEditStatusCancelled, "cancelled_at": timestamp, "updated_at": timestamp}).Error; err != nil {
+			return err
+		}
+		return recordOrderEditChange(tx, edit.ID, models.OrderEditChangeCancel, "Cancelled the edit", actor)
+	})
+	if err != nil {
+		return models.OrderEdit{}, err
+	}
+	return GetOrderEdit(db, editID)
+}
+
+// PrepareOrderEditCommit checks an open edit can still be applied before any
+// money moves: the order is unchanged since the edit was opened and the
+// added units are still in stock. When the edit has a balance to settle,
+// operationKey records the provider operation that will settle it.
+func PrepareOrderEditCommit(tx *gorm.DB, editID uint, operationKey string) (models.OrderEdit, error) {
+	edit, err := lockOpenOrderEdit(tx, editID)
+	if err != nil {
+		return models.OrderEdit{}, err
+	}
+	order, _, err := lockEditableOrder(tx, edit.OrderID)
+	if err != nil {
+		return models.OrderEdit{}, err
+	}
+	if order.Total != edit.OriginalTotal {
+		return models.OrderEdit{}, ErrOrderEditStale
+	}
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+		return models.OrderEdit{}, err
+	}
+	if err := checkOrderEditStock(tx, edit, items); err != nil {
+		return models.OrderEdit{}, err
+	}
+	if edit.BalanceDue != 0 && operationKey != "" && edit.PaymentOperationKey != operationKey {
+		edit.PaymentOperationKey = operationKey
+		if err := tx.Model(&models.OrderEdit{}).Where("id = ?", edit.ID).Update("payment_operation_key", operationKey).Error; err != nil {
+			return models.OrderEdit{}, err
+		}
+	}
+	return edit, nil
+}
+
+// ApplyOrderEdit writes a committed edit onto the order once its balance is
+// settled. Units taken off return to stock, added units are committed, the
+// order total and checkout snapshot take the edited amounts, and the order
+// is routed again to its warehouses. It is safe to call again for an edit
+// already committed, as a retried payment finalization does.
+func ApplyOrderEdit(tx *gorm.DB, editID uint, input OrderEditCommitInput, now time.Time) error {
+	var edit models.OrderEdit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&edit, editID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrOrderEditNotFound
+		}
+		return err
+	}
+	if edit.Status == models.OrderEditStatusCommitted {
+		return nil
+	}
+	if edit.Status != models.OrderEditStatusOpen {
+		return ErrOrderEditClosed
+	}
+	order, snapshot, err := lockEditableOrder(tx, edit.OrderID)
+	if err != nil {
+		return err
+	}
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Order("id ASC").Find(&items).Error; err != nil {
+		return err
+	}
+	itemsByID := map[uint]models.OrderItem{}
+	for _, item := range items {
+		itemsByID[item.ID] = item
+	}
+
+	// The warehouses' queue is rebuilt from the edited lines, so it is
+	// cleared before stock moves.
+	if err := cancelOpenFulfillmentOrders(tx, order.ID, now); err != nil {
+		return err
+	}
+	type cancellation struct {
+		item     models.OrderItem
+		quantity int
+	}
+	cancellations := []cancellation{}
+	grown := []models.OrderItem{}
+	created := []models.OrderItem{}
+	for _, line := range edit.Lines {
+		if line.OrderItemID == nil {
+			if line.Quantity > 0 {
+				created = append(created, models.OrderItem{ProductVariantID: line.ProductVariantID, Quantity: line.Quantity, Price: line.UnitPrice})
+			}
+			continue
+		}
+		item, ok := itemsByID[*line.OrderItemID]
+		if !ok {
+			return ErrOrderEditStale
+		}
+		remaining := item.RemainingQuantity()
+		switch {
+		case line.ProductVariantID != item.ProductVariantID:
+			cancellations = append(cancellations, cancellation{item: item, quantity: remaining})
+			created = append(created, models.OrderItem{ProductVariantID: line.ProductVariantID, Quantity: line.Quantity, Price: line.UnitPrice})
+		case line.Quantity < remaining:
+			cancellations = append(cancellations, cancellation{item: item, quantity: remaining - line.Quantity})
+		case line.Quantity > remaining:
+			item.Quantity = line.Quantity - remaining
+			grown = append(grown, item)
+		}
+	}
+
+	for _, cancelled := range cancellations {
+		if cancelled.quantity <= 0 {
+			continue
+		}
+		released, err := inventoryservice.ReleaseBackorderedUnits(tx, order.ID, cancelled.item.ProductVariantID, cancelled.quantity, now)
+		if err != nil {
+			return err
+		}
+		if restock := cancelled.quantity - released; restock > 0 {
+			returned := cancelled.item
+			returned.Quantity = restock
+			if err := ReplenishStockForItems(tx, order.ID, []models.OrderItem{returned}); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&models.OrderItem{}).Where("id = ?", cancelled.item.ID).
+			Update("quantity_cancelled", gorm.Expr("quantity_cancelled + ?", cancelled.quantity)).Error; err != nil {
+			return err
+		}
+	}
+	committed := []models.OrderItem{}
+	for _, item := range grown {
+		if err := tx.Model(&models.OrderItem{}).Where("id = ?", item.ID).
+			Update("quantity", gorm.Expr("quantity + ?", item.Quantity)).Error; err != nil {
+			return err
+		}
+		committed = append(committed, item)
+	}
+	for _, draft := range created {
+		variant, err := editableVariant(tx, draft.ProductVariantID)
+		if err != nil {
+			return err
+		}
+		item := newOrderItem(variant, draft.Quantity, draft.Price, now)
+		item.OrderID = order.ID
+		if err := tx.Create(&item).Error; err != nil {
+			return err
+		}
+		committed = append(committed, item)
+	}
+	if err := DeductStockForItems(tx, order.ID, committed); err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.Order{}).Where("id = ?", order.ID).
+		Updates(map[string]any{"total": edit.Total, "shipping_address_pretty": edit.ShippingAddressPretty}).Error; err != nil {
+		return err
+	}
+	// Shipping, tax reporting and payments read the order's amounts and
+	// destination from its snapshot, so the snapshot follows the edit.
+	if err := tx.Model(&models.OrderCheckoutSnapshot{}).Where("id = ?", snapshot.ID).Updates(map[string]any{
+		"subtotal": edit.Subtotal - edit.DiscountAmount, "shipping_amount": edit.ShippingAmount, "tax_amount": edit.TaxAmount,
+		"total": edit.Total, "shipping_data_json": edit.ShippingDataJSON, "shipping_address_pretty": edit.ShippingAddressPretty,
+	}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("order_id = ?", order.ID).Order("id ASC").Find(&items).Error; err != nil {
+		return err
+	}
+	if err := openFulfillmentOrder(tx, order.ID, unshippedItems(items)); err != nil {
+		return err
+	}
+	if err := tx.Create(&models.OrderStatusHistory{
+		OrderID: order.ID, FromStatus: order.Status, ToStatus: order.Status, Reason: "order_edited",
+		Source: "admin", Actor: input.Actor, CorrelationID: input.CorrelationID,
+	}).Error; err != nil {
+		return err
+	}
+	timestamp := now.UTC()
+	if err := tx.Model(&models.OrderEdit{}).Where("id = ?", edit.ID).
+		Updates(map[string]any{"status": models.OrderEditStatusCommitted, "committed_at": timestamp, "updated_at": timestamp}).Error; err != nil {
+		return err
+	}
+	summary := fmt.Sprintf("Committed: total %s to %s", edit.OriginalTotal, edit.Total)
+	switch {
+	case edit.BalanceDue > 0:
+		summary += fmt.Sprintf(", %s collected", edit.BalanceDue)
+	case edit.BalanceDue < 0:
+		summary += fmt.Sprintf(", %s refunded", -edit.BalanceDue)
+	}
+	return recordOrderEditChange(tx, edit.ID, models.OrderEditChangeCommit, summary, input.Actor)
+}
+
+func GetOrderEdit(db *gorm.DB, editID uint) (models.OrderEdit, error) {
+	var edit models.OrderEdit
+	err := db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("Changes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&edit, editID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OrderEdit{}, ErrOrderEditNotFound
+	}
+	return edit, err
+}
+
+// ListOrderEdits returns an order's edits, newest first.
+func ListOrderEdits(db *gorm.DB, orderID uint) ([]models.OrderEdit, error) {
+	var order models.Order
+	if err := db.Select("id").First(&order, orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	var edits []models.OrderEdit
+	err := db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Preload("Changes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("order_id = ?", orderID).Order("id DESC").Find(&edits).Error
+	return edits, err
+}
+
+// lockEditableOrder locks an order that may be edited and returns the
+// checkout snapshot its providers and payment data come from.
+func lockEditableOrder(tx *gorm.DB, orderID uint) (models.Order, models.OrderCheckoutSnapshot, error) {
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Order{}, models.OrderCheckoutSnapshot{}, ErrOrderNotFound
+		}
+		return models.Order{}, models.OrderCheckoutSnapshot{}, err
+	}
+	if order.Status != models.StatusPaid {
+		return models.Order{}, models.OrderCheckoutSnapshot{}, ErrOrderNotEditable
+	}
+	var boxed int64
+	if err := tx.Model(&models.FulfillmentOrder{}).
+		Where("order_id = ? AND status IN ?", orderID, append([]string{models.FulfillmentStatusShipped, models.FulfillmentStatusDelivered}, packedFulfillmentStatuses...)).
+		Count(&boxed).Error; err != nil {
+		return models.Order{}, models.OrderCheckoutSnapshot{}, err
+	}
+	if boxed > 0 {
+		return models.Order{}, models.OrderCheckoutSnapshot{}, ErrOrderNotEditable
+	}
+	var snapshot models.OrderCheckoutSnapshot
+	err := tx.Where("order_id = ?", orderID).Order("id DESC").First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Order{}, models.OrderCheckoutSnapshot{}, ErrOrderNotEditable
+	}
+	if err != nil {
+		return models.Order{}, models.OrderCheckoutSnapshot{}, err
+	}
+	return order, snapshot, nil
+}
+
+func lockOpenOrderEdit(tx *gorm.DB, editID uint) (models.OrderEdit, error) {
+	var edit models.OrderEdit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&edit, editID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.OrderEdit{}, ErrOrderEditNotFound
+		}
+		return models.OrderEdit{}, err
+	}
+	if edit.Status != models.OrderEditStatusOpen {
+		return models.OrderEdit{}, ErrOrderEditClosed
+	}
+	return edit, nil
+}
+
+// editableVariant loads a variant that may be added to an order.
+func editableVariant(tx *gorm.DB, variantID uint) (models.ProductVariant, error) {
+	var variant models.ProductVariant
+	err := tx.Preload("Product").Where("id = ? AND is_published = ?", variantID, true).First(&variant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ProductVariant{}, fmt.Errorf("%w: product variant %d is not for sale", ErrInvalidOrderEditChange, variantID)
+	}
+	return variant, err
+}
+
+// checkOrderEditStock makes sure every variant the edit needs more of can
+// still be sold in the extra quantity.
+func checkOrderEditStock(tx *gorm.DB, edit models.OrderEdit, items []models.OrderItem) error {
+	extra := map[uint]int{}
+	for _, line := range edit.Lines {
+		extra[line.ProductVariantID] += line.Quantity
+	}
+	for _, item := range items {
+		extra[item.ProductVariantID] -= item.RemainingQuantity()
+	}
+	for variantID, quantity := range extra {
+		if quantity <= 0 {
+			continue
+		}
+		var variant models.ProductVariant
+		if err := tx.Preload("Product").First(&variant, variantID).Error; err != nil {
+			return err
+		}
+		sellable, err := inventoryservice.SellableQuantity(tx, variant)
+		if err != nil {
+			return err
+		}
+		if sellable < quantity {
+			return &InsufficientStockError{ProductVariantID: variant.ID, ProductName: variant.Product.Name, Requested: quantity, Available: sellable}
+		}
+	}
+	return nil
+}
+
+// priceOrderEdit prices the edited lines the way checkout would, then adds
+// shipping and tax from the providers the order was placed with, at the
+// edit's address. Lines keep the unit price they were sold at and, for the
+// units the order already had, the discount it was placed with, so coupons
+// and campaigns that have since ended are not taken back. Added units take
+// the current variant price and the automatic campaigns running now.
+func priceOrderEdit(tx *gorm.DB, edit *models.OrderEdit, order models.Order, snapshot models.OrderCheckoutSnapshot, plugins *checkoutplugins.Manager, now time.Time) error {
+	if plugins == nil {
+		return errors.New("checkout plugins are not configured")
+	}
+	variantIDs := []uint{}
+	for _, line := range edit.Lines {
+		if line.Quantity > 0 {
+			variantIDs = append(variantIDs, line.ProductVariantID)
+		}
+	}
+	if len(variantIDs) == 0 {
+		return ErrOrderEditEmpty
+	}
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Order("id ASC").Find(&items).Error; err != nil {
+		return err
+	}
+	placed := placedItemDiscounts(items, snapshot)
+	kept := map[uint]int{}
+	for _, item := range items {
+		kept[item.ID] = item.Quantity - item.QuantityCancelled
+	}
+
+	var variants []models.ProductVariant
+	if err := tx.Unscoped().Preload("Product.Categories").Where("id IN ?", variantIDs).Find(&variants).Error; err != nil {
+		return err
+	}
+	catalog := map[uint]models.ProductVariant{}
+	for _, variant := range variants {
+		catalog[variant.ID] = variant
+	}
+	edit.Subtotal, edit.DiscountAmount = 0, 0
+	lines := []discountservice.CartLine{}
+	added := []int{}
+	for i := range edit.Lines {
+		line := &edit.Lines[i]
+		line.DiscountAmount = 0
+		if line.Quantity < 1 {
+			continue
+		}
+		edit.Subtotal += line.UnitPrice.Mul(line.Quantity)
+		extra := line.Quantity
+		if line.OrderItemID != nil && kept[*line.OrderItemID] > 0 {
+			placedQuantity := kept[*line.OrderItemID]
+			line.DiscountAmount = placed[*line.OrderItemID] * models.Money(min(line.Quantity, placedQuantity)) / models.Money(placedQuantity)
+			extra = line.Quantity - placedQuantity
+		}
+		if extra < 1 {
+			continue
+		}
+		variant := catalog[line.ProductVariantID]
+		categoryIDs := make([]uint, 0, len(variant.Product.Categories))
+		for _, category := range variant.Product.Categories {
+			categoryIDs = append(categoryIDs, category.ID)
+		}
+		lines = append(lines, discountservice.CartLine{
+			ProductID: variant.ProductID, ProductVariantID: line.ProductVariantID, BrandID: variant.Product.BrandID,
+			CategoryIDs: categoryIDs, SKU: line.VariantSKU, Quantity: extra, UnitPrice: line.UnitPrice,
+		})
+		added = append(added, i)
+	}
+	if len(lines) > 0 {
+		evaluation, err := discountservice.EvaluateCartWithOptions(tx, lines, now, discountservice.EvaluationOptions{CustomerID: order.UserID, DisableCouponCodes: true})
+		if err != nil {
+			return err
+		}
+		for j, evaluated := range evaluation.Lines {
+			if j < len(added) {
+				edit.Lines[added[j]].DiscountAmount += evaluated.DiscountAmount.Mul(lines[j].Quantity)
+			}
+		}
+	}
+	for _, line := range edit.Lines {
+		edit.DiscountAmount += line.DiscountAmount
+	}
+
+	request := checkoutplugins.QuoteRequest{
+		Subtotal: edit.Subtotal - edit.DiscountAmount, PaymentID: snapshot.PaymentProviderID, ShippingID: snapshot.ShippingProviderID, TaxID: snapshot.TaxProviderID,
+		PaymentData: decodeStringMap(snapshot.PaymentDataJSON), ShippingData: decodeStringMap(edit.ShippingDataJSON), TaxData: decodeStringMap(snapshot.TaxDataJSON),
+	}
+	quote := plugins.Quote(request)
+	if !quote.Valid {
+		return ErrOrderEditQuoteInvalid
+	}
+	details, err := plugins.ResolveCheckoutDetails(request)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOrderEditQuoteInvalid, err)
+	}
+	edit.Currency = quote.Currency
+	edit.ShippingAmount = quote.Shipping
+	edit.TaxAmount = quote.Tax
+	edit.Total = quote.Total
+	edit.ShippingAddressPretty = details.ShippingAddress
+	edit.BalanceDue = edit.Total - edit.OriginalTotal
+	return nil
+}
+
+// placedItemDiscounts spreads the discount the order currently carries, what
+// its items are worth less the snapshot subtotal, across the items by value.
+// The cents left over by rounding go to the first items.
+func placedItemDiscounts(items []models.OrderItem, snapshot models.OrderCheckoutSnapshot) map[uint]models.Money {
+	var gross models.Money
+	for _, item := range items {
+		gross += item.Price.Mul(item.Quantity - item.QuantityCancelled)
+	}
+	discounts := map[uint]models.Money{}
+	total := gross - snapshot.Subtotal
+	if total <= 0 || gross <= 0 {
+		return discounts
+	}
+	allocated := models.Money(0)
+	for _, item := range items {
+		share := total * item.Price.Mul(item.Quantity-item.QuantityCancelled) / gross
+		discounts[item.ID] = share
+		allocated += share
+	}
+	for i := 0; allocated < total && i < len(items); i++ {
+		if items[i].Quantity > items[i].QuantityCancelled {
+			discounts[items[i].ID]++
+			allocated++
+		}
+	}
+	return discounts
+}
+
+// saveOrderEdit writes the edit's amounts and lines; new lines are created.
+func saveOrderEdit(tx *gorm.DB, edit *models.OrderEdit) error {
+	if err := tx.Omit("Lines", "Changes").Save(edit).Error; err != nil {
+		return err
+	}
+	for i := range edit.Lines {
+		edit.Lines[i].OrderEditID = edit.ID
+		if err := tx.Save(&edit.Lines[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordOrderEditChange(tx *gorm.DB, editID uint, kind, summary, actor string) error {
+	return tx.Create(&models.OrderEditChange{OrderEditID: editID, Kind: kind, Summary: summary, Actor: actor}).Error
+}
+
+func decodeStringMap(raw string) map[string]string {
+	values := map[string]string{}
+	if strings.TrimSpace(raw) != "" {
+		_ = json.Unmarshal([]byte(raw), &values)
+	}
+	return values
+}
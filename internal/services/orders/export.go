@@ -0,0 +1,115 @@
+package orders
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	checkoutservice "ecommerce/internal/services/checkout"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+const maxExportOrders = 10000
+
+type ExportInput struct {
+	Status    string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// ExportCSV writes matching orders as CSV. Every checkout field gets an
+// "attribute_<key>" column, followed by any keys only found on older orders
+// whose field has since been deleted.
+func ExportCSV(db *gorm.DB, input ExportInput) ([]byte, error) {
+	query := db.Model(&models.Order{})
+	if status := strings.ToUpper(strings.TrimSpace(input.Status)); status != "" {
+		if !models.IsValidOrderStatus(status) {
+			return nil, ErrInvalidOrderStatus
+		}
+		query = query.Where("status = ?", status)
+	}
+	if input.StartDate != nil {
+		query = query.Where("created_at >= ?", input.StartDate.UTC())
+	}
+	if input.EndDate != nil {
+		query = query.Where("created_at <= ?", input.EndDate.UTC())
+	}
+	var rows []models.Order
+	if err := query.Preload("Items").Preload("User").Order("orders.created_at ASC, orders.id ASC").Limit(maxExportOrders).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	fields, err := checkoutservice.ListCheckoutFields(db, true)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(fields))
+	seen := map[string]struct{}{}
+	for _, field := range fields {
+		keys = append(keys, field.Key)
+		seen[field.Key] = struct{}{}
+	}
+	var extra []string
+	attributes := make([]map[string]string, len(rows))
+	for index, row := range rows {
+		attributes[index] = row.Attributes()
+		for key := range attributes[index] {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				extra = append(extra, key)
+			}
+		}
+	}
+	sort.Strings(extra)
+	keys = append(keys, extra...)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	header := []string{"order_id", "created_at", "status", "user_id", "email", "item_count", "total", "payment_method", "shipping_address"}
+	for _, key := range keys {
+		header = append(header, "attribute_"+key)
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for index, row := range rows {
+		userID, email := "", ""
+		if row.UserID != nil {
+			userID = fmt.Sprintf("%d", *row.UserID)
+		}
+		if row.User != nil {
+			email = row.User.Email
+		} else if row.GuestEmail != nil {
+			email = *row.GuestEmail
+		}
+		quantity := 0
+		for _, item := range row.Items {
+			quantity += item.Quantity
+		}
+		record := []string{
+			fmt.Sprintf("%d", row.ID), row.CreatedAt.UTC().Format(time.RFC3339), row.Status, userID, email,
+			fmt.Sprintf("%d", quantity), row.Total.String(), row.PaymentMethodDisplay, row.ShippingAddressPretty,
+		}
+		for _, key := range keys {
+			record = append(record, attributes[index][key])
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+func (s *Service) ExportCSV(ctx context.Context, input ExportInput) ([]byte, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("order service is not configured")
+	}
+	return ExportCSV(s.db.WithContext(ctx), input)
+}
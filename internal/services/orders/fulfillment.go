@@ -0,0 +1,455 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrOrderItemNotFound = errors.New("order item not found")
+	// ErrItemQuantityNotCancellable rejects cancelling more units than are
+	// left to ship outside a packed parcel, or cancelling on an order that is
+	// not being fulfilled.
+	ErrItemQuantityNotCancellable = errors.New("order item quantity cannot be cancelled")
+)
+
+// packedFulfillmentStatuses are the fulfillment states whose units are boxed
+// and no longer free to cancel.
+var packedFulfillmentStatuses = []string{models.FulfillmentStatusPacked, models.FulfillmentStatusLabelPurchased}
+
+// openFulfillmentOrder queues a paid order for the warehouses. The routing
+// rules pick the locations that ship its committed units, one fulfillment
+// order per location, each recording why it was chosen. Units not yet drawn
+// from any location, such as backorders, go to the default location. An
+// order that already has a live fulfillment order, for example one paid
+// again after a late capture, is left alone.
+func openFulfillmentOrder(tx *gorm.DB, orderID uint, items []models.OrderItem) error {
+	var existing int64
+	if err := tx.Model(&models.FulfillmentOrder{}).
+		Where("order_id = ? AND status <> ?", orderID, models.FulfillmentStatusCancelled).
+		Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 || len(items) == 0 {
+		return nil
+	}
+	sources, err := committedLocations(tx, orderID)
+	if err != nil {
+		return err
+	}
+	plan, kept, err := routeOrder(tx, orderID, items, sources)
+	if err != nil {
+		return err
+	}
+
+	itemsByID := map[uint]models.OrderItem{}
+	for _, item := range items {
+		itemsByID[item.ID] = item
+	}
+	fulfillments := []*models.FulfillmentOrder{}
+	byCode := map[string]*models.FulfillmentOrder{}
+	add := func(code, explanation string, item models.OrderItem, quantity int) {
+		fulfillment, ok := byCode[code]
+		if !ok {
+			fulfillment = &models.FulfillmentOrder{OrderID: orderID, LocationCode: code, Status: models.FulfillmentStatusUnfulfilled, RoutingExplanation: explanation}
+			byCode[code] = fulfillment
+			fulfillments = append(fulfillments, fulfillment)
+		} else if !strings.Contains(fulfillment.RoutingExplanation, explanation) {
+			fulfillment.RoutingExplanation += " " + explanation
+		}
+		fulfillment.Lines = append(fulfillment.Lines, models.FulfillmentOrderLine{
+			OrderItemID: item.ID, ProductVariantID: item.ProductVariantID,
+			VariantSKU: item.VariantSKU, VariantTitle: item.VariantTitle, Quantity: quantity,
+		})
+	}
+	routed := map[uint]int{}
+	for _, assignment := range plan.Assignments {
+		for _, line := range assignment.Lines {
+			add(assignment.Location.Code, assignment.Explanation, itemsByID[line.OrderItemID], line.Quantity)
+			routed[line.OrderItemID] += line.Quantity
+		}
+	}
+	for _, item := range items {
+		for _, part := range splitByLocation(kept, item.ProductVariantID, item.Quantity-routed[item.ID]) {
+			if part.location.Code == "" {
+				add(models.DefaultFulfillmentLocation, "Backordered units wait for stock at the default location.", item, part.quantity)
+				continue
+			}
+			add(part.location.Code, fmt.Sprintf("Kept at %s: no sellable location can ship it.", part.location.Code), item, part.quantity)
+		}
+	}
+	for _, fulfillment := range fulfillments {
+		if err := tx.Create(fulfillment).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// locationShare is part of an order line's quantity held at one location. A
+// zero location means none was recorded.
+type locationShare struct {
+	location models.StockLocation
+	quantity int
+}
+
+// committedLocations lists, per variant, the locations an order's stock was
+// taken from, less the units that already shipped from them.
+func committedLocations(tx *gorm.DB, orderID uint) (map[uint][]locationShare, error) {
+	commitments, err := inventoryservice.OrderCommitments(tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	type shippedLine struct {
+		ProductVariantID uint
+		LocationCode     string
+		Quantity         int
+	}
+	var shipped []shippedLine
+	if err := tx.Model(&models.FulfillmentOrderLine{}).
+		Select("fulfillment_order_lines.product_variant_id, fulfillment_orders.location_code, SUM(fulfillment_order_lines.quantity) AS quantity").
+		Joins("JOIN fulfillment_orders ON fulfillment_orders.id = fulfillment_order_lines.fulfillment_order_id").
+		Where("fulfillment_orders.order_id = ? AND fulfillment_orders.status IN ?", orderID, []string{models.FulfillmentStatusShipped, models.FulfillmentStatusDelivered}).
+		Group("fulfillment_order_lines.product_variant_id, fulfillment_orders.location_code").
+		Scan(&shipped).Error; err != nil {
+		return nil, err
+	}
+	var locations []models.StockLocation
+	if err := tx.Order("position ASC, id ASC").Find(&locations).Error; err != nil {
+		return nil, err
+	}
+	shares := map[uint][]locationShare{}
+	for variantID, byLocation := range commitments {
+		for _, location := range locations {
+			quantity := byLocation[location.ID]
+			for _, line := range shipped {
+				if line.ProductVariantID == variantID && line.LocationCode == location.Code {
+					quantity -= line.Quantity
+				}
+			}
+			if quantity > 0 {
+				shares[variantID] = append(shares[variantID], locationShare{location: location, quantity: quantity})
+			}
+		}
+	}
+	return shares, nil
+}
+
+// splitByLocation takes quantity of a variant from the order's committed
+// locations, consuming the shares it uses. Whatever they do not cover is
+// returned with a zero location.
+func splitByLocation(sources map[uint][]locationShare, productVariantID uint, quantity int) []locationShare {
+	parts := []locationShare{}
+	for i := range sources[productVariantID] {
+		source := &sources[productVariantID][i]
+		if quantity == 0 {
+			break
+		}
+		take := min(source.quantity, quantity)
+		if take <= 0 {
+			continue
+		}
+		parts = append(parts, locationShare{location: source.location, quantity: take})
+		source.quantity -= take
+		quantity -= take
+	}
+	if quantity > 0 {
+		parts = append(parts, locationShare{quantity: quantity})
+	}
+	return parts
+}
+
+// cancelOpenFulfillmentOrders pulls an order that is no longer going out from
+// the warehouse queue. Parcels that already left are not touched.
+func cancelOpenFulfillmentOrders(tx *gorm.DB, orderID uint, now time.Time) error {
+	timestamp := now.UTC()
+	return tx.Model(&models.FulfillmentOrder{}).
+		Where("order_id = ? AND status IN ?", orderID, []string{
+			models.FulfillmentStatusUnfulfilled,
+			models.FulfillmentStatusPicking,
+			models.FulfillmentStatusPacked,
+			models.FulfillmentStatusLabelPurchased,
+		}).
+		Updates(map[string]any{"status": models.FulfillmentStatusCancelled, "cancelled_at": timestamp, "updated_at": timestamp}).Error
+}
+
+// SyncFulfillmentStatus derives the order status from what has shipped:
+// PARTIALLY_SHIPPED while units remain, SHIPPED once none do, and DELIVERED
+// when every fulfillment order has been delivered. The transition supplies
+// the path and history details; its To is ignored.
+func SyncFulfillmentStatus(tx *gorm.DB, orderID uint, transition StatusTransition) error {
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+		return err
+	}
+	switch order.Status {
+	case models.StatusPaid, models.StatusPartiallyShipped, models.StatusShipped:
+	default:
+		return nil
+	}
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", orderID).Find(&items).Error; err != nil {
+		return err
+	}
+	fulfilled, remaining := 0, 0
+	for _, item := range items {
+		fulfilled += item.QuantityFulfilled
+		remaining += item.RemainingQuantity()
+	}
+	if fulfilled == 0 {
+		return nil
+	}
+	transition.To = models.StatusPartiallyShipped
+	if remaining == 0 {
+		var undelivered int64
+		if err := tx.Model(&models.FulfillmentOrder{}).
+			Where("order_id = ? AND status NOT IN ?", orderID, []string{models.FulfillmentStatusDelivered, models.FulfillmentStatusCancelled}).
+			Count(&undelivered).Error; err != nil {
+			return err
+		}
+		transition.To = models.StatusShipped
+		if undelivered == 0 {
+			transition.To = models.StatusDelivered
+		}
+	}
+	if transition.To == order.Status {
+		return nil
+	}
+	return ApplyStatusTransition(tx, &order, transition)
+}
+
+// CapturableAmount is how much more may be captured on an order whose
+// fulfillment is tracked: the share of the total for units that have shipped
+// or are packed to go, less what is already captured. Without a fulfillment
+// order it is the share of every unit not waiting for a pre-order release,
+// and nil when there are none waiting, so the order may be captured in full.
+func CapturableAmount(tx *gorm.DB, order models.Order) (*models.Money, error) {
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	unreleased, err := UnreleasedPreorderItems(tx, items, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var fulfillments []models.FulfillmentOrder
+	if err := tx.Preload("Lines").Where("order_id = ? AND status <> ?", order.ID, models.FulfillmentStatusCancelled).
+		Find(&fulfillments).Error; err != nil {
+		return nil, err
+	}
+	if len(fulfillments) == 0 {
+		if len(unreleased) == 0 {
+			return nil, nil
+		}
+		captured, err := capturedAmount(tx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		released := orderValueShare(order.Total, items, func(item models.OrderItem) int {
+			if unreleased[item.ID] {
+				return 0
+			}
+			return item.Quantity - item.QuantityCancelled
+		})
+		available := max(released-captured, 0)
+		return &available, nil
+	}
+	packed := map[uint]int{}
+	for _, fulfillment := range fulfillments {
+		if fulfillment.Status != models.FulfillmentStatusPacked && fulfillment.Status != models.FulfillmentStatusLabelPurchased {
+			continue
+		}
+		for _, line := range fulfillment.Lines {
+			packed[line.OrderItemID] += line.Quantity
+		}
+	}
+	captured, err := capturedAmount(tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	shippable := orderValueShare(order.Total, items, func(item models.OrderItem) int {
+		return item.QuantityFulfilled + packed[item.ID]
+	})
+	available := max(shippable-captured, 0)
+	return &available, nil
+}
+
+// UnreleasedPreorderItems returns the IDs of the lines among items that were
+// sold as pre-orders and whose variant is still not released at now. The
+// variant's current release date counts, so a launch moved after the order
+// was placed moves its lines with it.
+func UnreleasedPreorderItems(tx *gorm.DB, items []models.OrderItem, now time.Time) (map[uint]bool, error) {
+	variantIDs := []uint{}
+	for _, item := range items {
+		if item.InventoryPolicy == models.InventoryPolicyPreorder {
+			variantIDs = append(variantIDs, item.ProductVariantID)
+		}
+	}
+	unreleased := map[uint]bool{}
+	if len(variantIDs) == 0 {
+		return unreleased, nil
+	}
+	var variants []models.ProductVariant
+	if err := tx.Unscoped().Select("id", "inventory_policy", "preorder_release_at").Where("id IN ?", variantIDs).
+		Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	preordering := map[uint]bool{}
+	for _, variant := range variants {
+		preordering[variant.ID] = variant.Preordering(now)
+	}
+	for _, item := range items {
+		if item.InventoryPolicy == models.InventoryPolicyPreorder && preordering[item.ProductVariantID] {
+			unreleased[item.ID] = true
+		}
+	}
+	return unreleased, nil
+}
+
+// CancelItemQuantity cancels units of a line that are still to ship, for
+// example a backorder that will not arrive. Their stock is returned, any open
+// fulfillment order shrinks, and the order status is derived again.
+func CancelItemQuantity(tx *gorm.DB, orderID, itemID uint, quantity int, transition StatusTransition, now time.Time) error {
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+		return err
+	}
+	if order.Status != models.StatusPaid && order.Status != models.StatusPartiallyShipped {
+		return ErrItemQuantityNotCancellable
+	}
+	var item models.OrderItem
+	if err := tx.Where("id = ? AND order_id = ?", itemID, orderID).First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrOrderItemNotFound
+		}
+		return err
+	}
+	var boxed int
+	if err := tx.Model(&models.FulfillmentOrderLine{}).
+		Joins("JOIN fulfillment_orders ON fulfillment_orders.id = fulfillment_order_lines.fulfillment_order_id").
+		Where("fulfillment_order_lines.order_item_id = ? AND fulfillment_orders.status IN ?", item.ID, packedFulfillmentStatuses).
+		Select("COALESCE(SUM(fulfillment_order_lines.quantity), 0)").Scan(&boxed).Error; err != nil {
+		return err
+	}
+	if quantity <= 0 || quantity > item.RemainingQuantity()-boxed {
+		return ErrItemQuantityNotCancellable
+	}
+
+	if err := shrinkOpenFulfillmentLines(tx, item.ID, quantity, now); err != nil {
+		return err
+	}
+	released, err := inventoryservice.ReleaseBackorderedUnits(tx, orderID, item.ProductVariantID, quantity, now)
+	if err != nil {
+		return err
+	}
+	if restock := quantity - released; restock > 0 {
+		returned := item
+		returned.Quantity = restock
+		if err := ReplenishStockForItems(tx, orderID, []models.OrderItem{returned}); err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&models.OrderItem{}).Where("id = ?", item.ID).
+		Update("quantity_cancelled", gorm.Expr("quantity_cancelled + ?", quantity)).Error; err != nil {
+		return err
+	}
+	return SyncFulfillmentStatus(tx, orderID, transition)
+}
+
+// shrinkOpenFulfillmentLines takes cancelled units off fulfillment orders the
+// warehouse has not packed yet, cancelling any left without lines.
+func shrinkOpenFulfillmentLines(tx *gorm.DB, orderItemID uint, quantity int, now time.Time) error {
+	var lines []models.FulfillmentOrderLine
+	if err := tx.Joins("JOIN fulfillment_orders ON fulfillment_orders.id = fulfillment_order_lines.fulfillment_order_id").
+		Where("fulfillment_order_lines.order_item_id = ? AND fulfillment_orders.status IN ?", orderItemID,
+			[]string{models.FulfillmentStatusUnfulfilled, models.FulfillmentStatusPicking}).
+		Order("fulfillment_order_lines.id DESC").
+		Find(&lines).Error; err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if quantity == 0 {
+			break
+		}
+		take := min(line.Quantity, quantity)
+		quantity -= take
+		if take < line.Quantity {
+			if err := tx.Model(&models.FulfillmentOrderLine{}).Where("id = ?", line.ID).
+				Updates(map[string]any{"quantity": line.Quantity - take, "updated_at": now.UTC()}).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tx.Delete(&models.FulfillmentOrderLine{}, line.ID).Error; err != nil {
+			return err
+		}
+		var left int64
+		if err := tx.Model(&models.FulfillmentOrderLine{}).Where("fulfillment_order_id = ?", line.FulfillmentOrderID).Count(&left).Error; err != nil {
+			return err
+		}
+		if left == 0 {
+			if err := tx.Model(&models.FulfillmentOrder{}).Where("id = ?", line.FulfillmentOrderID).
+				Updates(map[string]any{"status": models.FulfillmentStatusCancelled, "cancelled_at": now.UTC(), "updated_at": now.UTC()}).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// orderValueShare apportions the order total, with its discounts, shipping
+// and tax, to the units counted for each line by merchandise value.
+func orderValueShare(total models.Money, items []models.OrderItem, units func(models.OrderItem) int) models.Money {
+	var ordered, counted int64
+	for _, item := range items {
+		ordered += int64(item.Price) * int64(item.Quantity)
+		counted += int64(item.Price) * int64(units(item))
+	}
+	if ordered == 0 {
+		return total
+	}
+	return models.Money(int64(total) * counted / ordered)
+}
+
+func capturedAmount(tx *gorm.DB, orderID uint) (models.Money, error) {
+	var captured models.Money
+	err := tx.Model(&models.PaymentIntent{}).Where("order_id = ?", orderID).
+		Select("COALESCE(SUM(captured_amount), 0)").Scan(&captured).Error
+	return captured, err
+}
+
+// unshippedItems narrows each line to the units that are still in the
+// building, which is all that can be returned to stock.
+func unshippedItems(items []models.OrderItem) []models.OrderItem {
+	unshipped := make([]models.OrderItem, 0, len(items))
+	for _, item := range items {
+		if remaining := item.RemainingQuantity(); remaining > 0 {
+			item.Quantity = remaining
+			unshipped = append(unshipped, item)
+		}
+	}
+	return unshipped
+}
+
+// cancelUnshippedQuantities marks every unit that did not ship as cancelled
+// once the order is cancelled or refunded.
+func cancelUnshippedQuantities(tx *gorm.DB, items []models.OrderItem) error {
+	for _, item := range items {
+		if item.RemainingQuantity() == 0 {
+			continue
+		}
+		if err := tx.Model(&models.OrderItem{}).Where("id = ?", item.ID).
+			Update("quantity_cancelled", item.Quantity-item.QuantityFulfilled).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
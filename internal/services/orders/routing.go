@@ -0,0 +1,168 @@
+package orders
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// routeOrder runs the routing rules over the units an order has committed
+// and moves those commitments to the locations the plan picked. It returns
+// the shares routing left where they were, for units no sellable location
+// can ship.
+func routeOrder(tx *gorm.DB, orderID uint, items []models.OrderItem, sources map[uint][]locationShare) (inventoryservice.RoutePlan, map[uint][]locationShare, error) {
+	committed := map[uint]int{}
+	for variantID, shares := range sources {
+		for _, share := range shares {
+			committed[variantID] += share.quantity
+		}
+	}
+	request := inventoryservice.RouteRequest{OrderID: orderID}
+	for _, item := range items {
+		quantity := min(item.Quantity, committed[item.ProductVariantID])
+		if quantity <= 0 {
+			continue
+		}
+		committed[item.ProductVariantID] -= quantity
+		request.Lines = append(request.Lines, inventoryservice.RouteLine{OrderItemID: item.ID, ProductVariantID: item.ProductVariantID, Quantity: quantity})
+	}
+	if len(request.Lines) == 0 {
+		return inventoryservice.RoutePlan{}, sources, nil
+	}
+	var err error
+	if request.Country, request.State, err = shippingDestination(tx, orderID); err != nil {
+		return inventoryservice.RoutePlan{}, nil, err
+	}
+	plan, err := inventoryservice.PlanRoute(tx, request)
+	if err != nil {
+		return inventoryservice.RoutePlan{}, nil, err
+	}
+
+	target := map[uint]map[uint]int{}
+	for _, assignment := range plan.Assignments {
+		for _, line := range assignment.Lines {
+			if target[line.ProductVariantID] == nil {
+				target[line.ProductVariantID] = map[uint]int{}
+			}
+			target[line.ProductVariantID][assignment.Location.ID] += line.Quantity
+		}
+	}
+	unrouted := map[uint]int{}
+	for _, line := range plan.Unrouted {
+		unrouted[line.ProductVariantID] += line.Quantity
+	}
+	// Surplus is released before anything is committed elsewhere, so stock
+	// the order gives up at one location can be taken back at another.
+	kept := map[uint][]locationShare{}
+	current := map[uint]map[uint]int{}
+	for variantID, shares := range sources {
+		current[variantID] = map[uint]int{}
+		for _, share := range shares {
+			surplus := share.quantity - target[variantID][share.location.ID]
+			if keep := min(unrouted[variantID], max(surplus, 0)); keep > 0 {
+				kept[variantID] = append(kept[variantID], locationShare{location: share.location, quantity: keep})
+				unrouted[variantID] -= keep
+				surplus -= keep
+			}
+			current[variantID][share.location.ID] = share.quantity - max(surplus, 0)
+			if surplus > 0 {
+				if err := moveOrderStock(tx, orderID, variantID, share.location.ID, surplus, "order_routing"); err != nil {
+					return inventoryservice.RoutePlan{}, nil, err
+				}
+			}
+		}
+	}
+	for _, assignment := range plan.Assignments {
+		for _, line := range assignment.Lines {
+			if current[line.ProductVariantID] == nil {
+				current[line.ProductVariantID] = map[uint]int{}
+			}
+			short := target[line.ProductVariantID][assignment.Location.ID] - current[line.ProductVariantID][assignment.Location.ID]
+			if short <= 0 {
+				continue
+			}
+			if err := moveOrderStock(tx, orderID, line.ProductVariantID, assignment.Location.ID, -short, "order_routing"); err != nil {
+				return inventoryservice.RoutePlan{}, nil, err
+			}
+			current[line.ProductVariantID][assignment.Location.ID] += short
+		}
+	}
+	return plan, kept, nil
+}
+
+// RelocateFulfillmentStock moves the stock an order committed for the given
+// lines from one location to another, as when an admin reroutes a
+// fulfillment order. Units the order never committed at the source, such as
+// backorders, are left alone.
+func RelocateFulfillmentStock(tx *gorm.DB, orderID uint, lines []models.FulfillmentOrderLine, from, to models.StockLocation) error {
+	if from.ID == to.ID {
+		return nil
+	}
+	commitments, err := inventoryservice.OrderCommitments(tx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		quantity := min(line.Quantity, commitments[line.ProductVariantID][from.ID])
+		if quantity <= 0 {
+			continue
+		}
+		commitments[line.ProductVariantID][from.ID] -= quantity
+		if err := moveOrderStock(tx, orderID, line.ProductVariantID, from.ID, quantity, "order_reroute"); err != nil {
+			return err
+		}
+		if err := moveOrderStock(tx, orderID, line.ProductVariantID, to.ID, -quantity, "order_reroute"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveOrderStock commits (negative delta) or releases (positive delta) an
+// order's units at one location.
+func moveOrderStock(tx *gorm.DB, orderID, variantID, locationID uint, delta int, reason string) error {
+	movementType := inventoryservice.MovementTypeOrderRelease
+	if delta < 0 {
+		movementType = inventoryservice.MovementTypeOrderCommit
+	}
+	_, err := inventoryservice.ApplyMovement(tx, inventoryservice.MovementInput{
+		ProductVariantID: variantID,
+		StockLocationID:  locationID,
+		MovementType:     movementType,
+		QuantityDelta:    delta,
+		ReferenceType:    inventoryservice.ReferenceTypeOrder,
+		ReferenceID:      &orderID,
+		ReasonCode:       reason,
+	})
+	return err
+}
+
+// shippingDestination reads the country and state the order ships to from
+// its checkout snapshot. Orders without one route on the other rules alone.
+func shippingDestination(tx *gorm.DB, orderID uint) (string, string, error) {
+	var snapshot models.OrderCheckoutSnapshot
+	err := tx.Where("order_id = ?", orderID).Order("id DESC").First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	var data map[string]any
+	if strings.TrimSpace(snapshot.ShippingDataJSON) == "" || json.Unmarshal([]byte(snapshot.ShippingDataJSON), &data) != nil {
+		return "", "", nil
+	}
+	field := func(key string) string {
+		if value, ok := data[key]; ok && value != nil {
+			return strings.ToUpper(strings.TrimSpace(fmt.Sprint(value)))
+		}
+		return ""
+	}
+	return field("country"), field("state"), nil
+}
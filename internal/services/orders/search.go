@@ -0,0 +1,327 @@
+package orders
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxOrderTags      = 50
+	maxOrderTagLength = 64
+)
+
+var (
+	ErrInvalidOrderSearch = errors.New("invalid order search")
+	ErrInvalidOrderTag    = errors.New("invalid order tag")
+	ErrOrderNoteNotFound  = errors.New("order note not found")
+	ErrInvalidOrderNote   = errors.New("invalid order note")
+	ErrOrderViewNotFound  = errors.New("saved order view not found")
+	ErrInvalidOrderView   = errors.New("invalid saved order view")
+)
+
+// ViewFilters are the admin order list filters a saved view remembers. They
+// mirror the filter fields of ListInput.
+type ViewFilters struct {
+	Query             string        `json:"q,omitempty"`
+	Email             string        `json:"email,omitempty"`
+	Name              string        `json:"name,omitempty"`
+	SKU               string        `json:"sku,omitempty"`
+	ConfirmationToken string        `json:"confirmation_token,omitempty"`
+	TrackingNumber    string        `json:"tracking_number,omitempty"`
+	PaymentReference  string        `json:"payment_reference,omitempty"`
+	Tag               string        `json:"tag,omitempty"`
+	Status            string        `json:"status,omitempty"`
+	StartDate         *time.Time    `json:"start_date,omitempty"`
+	EndDate           *time.Time    `json:"end_date,omitempty"`
+	MinTotal          *models.Money `json:"min_total,omitempty"`
+	MaxTotal          *models.Money `json:"max_total,omitempty"`
+}
+
+// DecodeViewFilters returns the filters stored on a saved view.
+func DecodeViewFilters(view models.OrderSavedView) ViewFilters {
+	var filters ViewFilters
+	_ = json.Unmarshal([]byte(view.FiltersJSON), &filters)
+	return filters
+}
+
+// fill copies the view's filters into input wherever input leaves them
+// unset, so filters given with the request override the view's.
+func (f ViewFilters) fill(input *ListInput) {
+	for _, field := range []struct {
+		to   *string
+		from string
+	}{
+		{&input.Query, f.Query}, {&input.Email, f.Email}, {&input.Name, f.Name}, {&input.SKU, f.SKU},
+		{&input.ConfirmationToken, f.ConfirmationToken}, {&input.TrackingNumber, f.TrackingNumber},
+		{&input.PaymentReference, f.PaymentReference}, {&input.Tag, f.Tag}, {&input.Status, f.Status},
+	} {
+		if strings.TrimSpace(*field.to) == "" {
+			*field.to = field.from
+		}
+	}
+	if input.StartDate == nil {
+		input.StartDate = f.StartDate
+	}
+	if input.EndDate == nil {
+		input.EndDate = f.EndDate
+	}
+	if input.MinTotal == nil {
+		input.MinTotal = f.MinTotal
+	}
+	if input.MaxTotal == nil {
+		input.MaxTotal = f.MaxTotal
+	}
+}
+
+func (f ViewFilters) validate() error {
+	if status := strings.ToUpper(strings.TrimSpace(f.Status)); status != "" && !models.IsValidOrderStatus(status) {
+		return ErrInvalidOrderStatus
+	}
+	if f.StartDate != nil && f.EndDate != nil && f.EndDate.Before(*f.StartDate) {
+		return fmt.Errorf("%w: end date is before start date", ErrInvalidOrderSearch)
+	}
+	if (f.MinTotal != nil && *f.MinTotal < 0) || (f.MaxTotal != nil && *f.MaxTotal < 0) {
+		return fmt.Errorf("%w: totals cannot be negative", ErrInvalidOrderSearch)
+	}
+	if f.MinTotal != nil && f.MaxTotal != nil && *f.MaxTotal < *f.MinTotal {
+		return fmt.Errorf("%w: max total is below min total", ErrInvalidOrderSearch)
+	}
+	return nil
+}
+
+// applySearchFilters narrows an order query by the customer, line, shipment,
+// payment, tag and total filters of input.
+func applySearchFilters(query *gorm.DB, input ListInput) *gorm.DB {
+	if email := strings.ToLower(strings.TrimSpace(input.Email)); email != "" {
+		like := "%" + email + "%"
+		query = query.Where("LOWER(COALESCE(orders.guest_email, '')) LIKE ? OR orders.user_id IN (SELECT id FROM users WHERE LOWER(email) LIKE ?)", like, like)
+	}
+	if name := strings.ToLower(strings.TrimSpace(input.Name)); name != "" {
+		like := "%" + name + "%"
+		query = query.Where("LOWER(COALESCE(orders.shipping_address_pretty, '')) LIKE ? OR orders.user_id IN (SELECT id FROM users WHERE LOWER(name) LIKE ? OR LOWER(username) LIKE ?)", like, like, like)
+	}
+	if sku := strings.ToLower(strings.TrimSpace(input.SKU)); sku != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items WHERE order_items.order_id = orders.id AND order_items.deleted_at IS NULL AND LOWER(order_items.variant_sku) LIKE ?)", "%"+sku+"%")
+	}
+	if token := strings.TrimSpace(input.ConfirmationToken); token != "" {
+		query = query.Where("LOWER(COALESCE(orders.confirmation_token, '')) = ?", strings.ToLower(token))
+	}
+	if tracking := strings.ToLower(strings.TrimSpace(input.TrackingNumber)); tracking != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM shipments WHERE shipments.order_id = orders.id AND LOWER(shipments.tracking_number) = ?)", tracking)
+	}
+	if reference := strings.ToLower(strings.TrimSpace(input.PaymentReference)); reference != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM payment_transactions JOIN payment_intents ON payment_intents.id = payment_transactions.payment_intent_id WHERE payment_intents.order_id = orders.id AND LOWER(payment_transactions.provider_txn_id) = ?)", reference)
+	}
+	if tag := strings.ToLower(strings.TrimSpace(input.Tag)); tag != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM order_tags WHERE order_tags.order_id = orders.id AND order_tags.tag = ?)", tag)
+	}
+	if input.MinTotal != nil {
+		query = query.Where("orders.total >= ?", *input.MinTotal)
+	}
+	if input.MaxTotal != nil {
+		query = query.Where("orders.total <= ?", *input.MaxTotal)
+	}
+	return query
+}
+
+// OrderTags returns the tags of each order, sorted. Orders without tags are
+// left out of the map.
+func OrderTags(db *gorm.DB, orderIDs ...uint) (map[uint][]string, error) {
+	tags := map[uint][]string{}
+	if len(orderIDs) == 0 {
+		return tags, nil
+	}
+	var rows []models.OrderTag
+	if err := db.Where("order_id IN ?", orderIDs).Order("tag ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		tags[row.OrderID] = append(tags[row.OrderID], row.Tag)
+	}
+	return tags, nil
+}
+
+// SetOrderTags replaces an order's tags. Tags are trimmed and lowercased, and
+// duplicates collapse into one.
+func SetOrderTags(db *gorm.DB, orderID uint, tags []string, actor string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > maxOrderTagLength || strings.Contains(tag, ",") {
+			return nil, fmt.Errorf("%w: %q must be 1-%d characters without commas", ErrInvalidOrderTag, tag, maxOrderTagLength)
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > maxOrderTags {
+		return nil, fmt.Errorf("%w: an order can have at most %d tags", ErrInvalidOrderTag, maxOrderTags)
+	}
+	slices.Sort(normalized)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := requireOrder(tx, orderID); err != nil {
+			return err
+		}
+		var existing []models.OrderTag
+		if err := tx.Where("order_id = ?", orderID).Find(&existing).Error; err != nil {
+			return err
+		}
+		kept := map[string]bool{}
+		for _, row := range existing {
+			if slices.Contains(normalized, row.Tag) {
+				kept[row.Tag] = true
+				continue
+			}
+			if err := tx.Delete(&row).Error; err != nil {
+				return err
+			}
+		}
+		for _, tag := range normalized {
+			if kept[tag] {
+				continue
+			}
+			if err := tx.Create(&models.OrderTag{OrderID: orderID, Tag: tag, CreatedBy: actor}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func ListOrderNotes(db *gorm.DB, orderID uint) ([]models.OrderNote, error) {
+	if err := requireOrder(db, orderID); err != nil {
+		return nil, err
+	}
+	var notes []models.OrderNote
+	err := db.Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&notes).Error
+	return notes, err
+}
+
+func AddOrderNote(db *gorm.DB, orderID uint, body, author string) (models.OrderNote, error) {
+	note := models.OrderNote{OrderID: orderID, Body: strings.TrimSpace(body), Author: author}
+	if note.Body == "" {
+		return models.OrderNote{}, fmt.Errorf("%w: body is required", ErrInvalidOrderNote)
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := requireOrder(tx, orderID); err != nil {
+			return err
+		}
+		return tx.Create(&note).Error
+	})
+	return note, err
+}
+
+func DeleteOrderNote(db *gorm.DB, orderID, noteID uint) error {
+	result := db.Where("id = ? AND order_id = ?", noteID, orderID).Delete(&models.OrderNote{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOrderNoteNotFound
+	}
+	return nil
+}
+
+func ListOrderViews(db *gorm.DB, ownerUserID uint) ([]models.OrderSavedView, error) {
+	var views []models.OrderSavedView
+	err := db.Where("owner_user_id = ?", ownerUserID).Order("name ASC").Find(&views).Error
+	return views, err
+}
+
+func CreateOrderView(db *gorm.DB, ownerUserID uint, name string, filters ViewFilters) (models.OrderSavedView, error) {
+	view := models.OrderSavedView{OwnerUserID: ownerUserID}
+	if err := applyOrderView(db, &view, name, filters); err != nil {
+		return models.OrderSavedView{}, err
+	}
+	if err := db.Create(&view).Error; err != nil {
+		return models.OrderSavedView{}, err
+	}
+	return view, nil
+}
+
+func UpdateOrderView(db *gorm.DB, ownerUserID, viewID uint, name string, filters ViewFilters) (models.OrderSavedView, error) {
+	view, err := orderView(db, ownerUserID, viewID)
+	if err != nil {
+		return models.OrderSavedView{}, err
+	}
+	if err := applyOrderView(db, &view, name, filters); err != nil {
+		return models.OrderSavedView{}, err
+	}
+	if err := db.Save(&view).Error; err != nil {
+		return models.OrderSavedView{}, err
+	}
+	return view, nil
+}
+
+func DeleteOrderView(db *gorm.DB, ownerUserID, viewID uint) error {
+	result := db.Where("id = ? AND owner_user_id = ?", viewID, ownerUserID).Delete(&models.OrderSavedView{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOrderViewNotFound
+	}
+	return nil
+}
+
+// orderView loads a view only for its owner; other admins' views read as
+// not found.
+func orderView(db *gorm.DB, ownerUserID, viewID uint) (models.OrderSavedView, error) {
+	var view models.OrderSavedView
+	if err := db.Where("id = ? AND owner_user_id = ?", viewID, ownerUserID).First(&view).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.OrderSavedView{}, ErrOrderViewNotFound
+		}
+		return models.OrderSavedView{}, err
+	}
+	return view, nil
+}
+
+func applyOrderView(db *gorm.DB, view *models.OrderSavedView, name string, filters ViewFilters) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 128 {
+		return fmt.Errorf("%w: name must be 1-128 characters", ErrInvalidOrderView)
+	}
+	if err := filters.validate(); err != nil {
+		return err
+	}
+	var clashes int64
+	if err := db.Model(&models.OrderSavedView{}).Where("owner_user_id = ? AND name = ? AND id <> ?", view.OwnerUserID, name, view.ID).Count(&clashes).Error; err != nil {
+		return err
+	}
+	if clashes > 0 {
+		return fmt.Errorf("%w: a view named %q already exists", ErrInvalidOrderView, name)
+	}
+	filters.Status = strings.ToUpper(strings.TrimSpace(filters.Status))
+	filters.Tag = strings.ToLower(strings.TrimSpace(filters.Tag))
+	encoded, err := json.Marshal(filters)
+	if err != nil {
+		return err
+	}
+	view.Name = name
+	view.FiltersJSON = string(encoded)
+	return nil
+}
+
+func requireOrder(db *gorm.DB, orderID uint) error {
+	var count int64
+	if err := db.Model(&models.Order{}).Where("id = ?", orderID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrOrderNotFound
+	}
+	return nil
+}
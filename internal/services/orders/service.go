@@ -2,7 +2,6 @@ package orders
 
 import (
 	"errors"
-	"fmt"
 
 	inventoryservice "ecommerce/internal/services/inventory"
 	"ecommerce/models"
@@ -62,60 +61,42 @@ func DeductStockForItems(tx *gorm.DB, orderID uint, items []models.OrderItem) er
 	return nil
 }
 
-// ReplenishStockForItems restores inventory for every order item.
+// ReplenishStockForItems restores inventory for every order item, returning
+// units to the locations they were committed from.
 func ReplenishStockForItems(tx *gorm.DB, orderID uint, items []models.OrderItem) error {
+	sources, err := committedLocations(tx, orderID)
+	if err != nil {
+		return err
+	}
 	for _, item := range items {
-		if _, err := inventoryservice.ApplyMovement(tx, inventoryservice.MovementInput{
-			ProductVariantID: item.ProductVariantID,
-			MovementType:     inventoryservice.MovementTypeOrderRelease,
-			QuantityDelta:    item.Quantity,
-			ReferenceType:    inventoryservice.ReferenceTypeOrder,
-			ReferenceID:      &orderID,
-			ReasonCode:       "order_stock_release",
-		}); err != nil {
-			return err
+		for _, part := range splitByLocation(sources, item.ProductVariantID, item.Quantity) {
+			if _, err := inventoryservice.ApplyMovement(tx, inventoryservice.MovementInput{
+				ProductVariantID: item.ProductVariantID,
+				StockLocationID:  part.location.ID,
+				MovementType:     inventoryservice.MovementTypeOrderRelease,
+				QuantityDelta:    part.quantity,
+				ReferenceType:    inventoryservice.ReferenceTypeOrder,
+				ReferenceID:      &orderID,
+				ReasonCode:       "order_stock_release",
+			}); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-// ApplyStatusTransition updates an order status and manages stock commitment transitions.
-func ApplyStatusTransition(tx *gorm.DB, order *models.Order, newStatus string) error {
-	if order == nil {
-		return fmt.Errorf("order is required")
-	}
-	if !models.IsValidOrderStatus(newStatus) {
-		return fmt.Errorf("invalid order status")
-	}
-
-	var items []models.OrderItem
-	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
-		return err
-	}
-
-	wasStockCommitted := models.IsStockCommittedOrderStatus(order.Status)
-	willCommitStock := models.IsStockCommittedOrderStatus(newStatus)
-
-	if willCommitStock && !wasStockCommitted {
-		consumed, err := inventoryservice.ConsumeReservationsForOrder(tx, order.ID, fmt.Sprintf("order-status:%d:%s", order.ID, newStatus))
-		if err != nil {
-			return err
-		}
-		if !consumed {
-			if err := DeductStockForItems(tx, order.ID, items); err != nil {
-				return err
-			}
-		}
-	} else if !willCommitStock && wasStockCommitted {
-		if err := ReplenishStockForItems(tx, order.ID, items); err != nil {
-			return err
-		}
-	} else if !willCommitStock && order.Status != newStatus {
-		if err := inventoryservice.ReleaseReservationsForOrder(tx, order.ID, fmt.Sprintf("order-status:%d:%s", order.ID, newStatus)); err != nil {
-			return err
+// committedItems drops the units that were still backordered, which never
+// left stock and so must not be returned to it.
+func committedItems(items []models.OrderItem, backordered map[uint]int) []models.OrderItem {
+	committed := make([]models.OrderItem, 0, len(items))
+	for _, item := range items {
+		pending := min(backordered[item.ProductVariantID], item.Quantity)
+		backordered[item.ProductVariantID] -= pending
+		if item.Quantity > pending {
+			item.Quantity -= pending
+			committed = append(committed, item)
 		}
 	}
-
-	order.Status = newStatus
-	return tx.Save(order).Error
+	return committed
 }
@@ -2,9 +2,13 @@ package orders
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"ecommerce/internal/checkoutplugins"
+	checkoutservice "ecommerce/internal/services/checkout"
+	inventoryservice "ecommerce/internal/services/inventory"
 	"ecommerce/models"
 
 	"github.com/stretchr/testify/assert"
@@ -23,12 +27,40 @@ func newOrdersTestDB(t *testing.T) *gorm.DB {
 		&models.ProductVariant{},
 		&models.InventoryItem{},
 		&models.InventoryLevel{},
+		&models.StockLocation{},
+		&models.OrderRoutingRule{},
+		&models.OrderCheckoutSnapshot{},
 		&models.InventoryMovement{},
 		&models.InventoryReservation{},
 		&models.InventoryThreshold{},
 		&models.InventoryAlert{},
 		&models.Order{},
 		&models.OrderItem{},
+		&models.OrderStatusHistory{},
+		&models.PaymentIntent{},
+		&models.FulfillmentOrder{},
+		&models.FulfillmentOrderLine{},
+		&models.OrderEdit{},
+		&models.OrderEditLine{},
+		&models.OrderEditChange{},
+		&models.DraftOrder{},
+		&models.DraftOrderLine{},
+		&models.Cart{},
+		&models.CartItem{},
+		&models.User{},
+		&models.OrderTaxLine{},
+		&models.PaymentTransaction{},
+		&models.LegalEntity{},
+		&models.OrderDocument{},
+		&models.Shipment{},
+		&models.OrderTag{},
+		&models.OrderNote{},
+		&models.OrderSavedView{},
+		&models.TrackingEvent{},
+		&models.ProviderOperation{},
+		&models.WebhookEvent{},
+		&models.DiscountCampaign{},
+		&models.DiscountRedemption{},
 	))
 	return db
 }
@@ -85,6 +117,134 @@ func TestCreateAggregatesDuplicateVariantStock(t *testing.T) {
 	assert.Zero(t, count)
 }
 
+func TestCreateChargesLockedUnitPrice(t *testing.T) {
+	db := newOrdersTestDB(t)
+	variant := seedVariant(t, db, "SKU-LOCKED", 5)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+	locked := variant.Price - models.MoneyFromFloat(1)
+
+	order, err := NewService(db).Create(context.Background(), session.ID, &userID, nil, []CreateItemInput{
+		{ProductVariantID: variant.ID, Quantity: 2, UnitPrice: &locked},
+	})
+	require.NoError(t, err)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, locked, order.Items[0].Price)
+	assert.Equal(t, locked.Mul(2), order.Total)
+}
+
+func TestCreateEnforcesQuantityRulesAndPurchaseLimit(t *testing.T) {
+	db := newOrdersTestDB(t)
+	variant := seedVariant(t, db, "SKU-LIMITED", 20)
+	limit := 3
+	require.NoError(t, db.Model(&variant).Updates(map[string]any{"quantity_step": 2, "purchase_limit": limit}).Error)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+	service := NewService(db)
+
+	_, err := service.Create(context.Background(), session.ID, &userID, nil, []CreateItemInput{{ProductVariantID: variant.ID, Quantity: 3}})
+	var ruleErr *checkoutservice.QuantityRuleError
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, checkoutservice.QuantityRuleStep, ruleErr.Rule)
+
+	order, err := service.Create(context.Background(), session.ID, &userID, nil, []CreateItemInput{{ProductVariantID: variant.ID, Quantity: 2}})
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&order).Update("status", models.StatusPaid).Error)
+
+	_, err = service.Create(context.Background(), session.ID, &userID, nil, []CreateItemInput{{ProductVariantID: variant.ID, Quantity: 2}})
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, checkoutservice.QuantityRulePurchaseLimit, ruleErr.Rule)
+	assert.Equal(t, 2, ruleErr.Purchased)
+}
+
+func TestCreateSnapshotsPreorderAndCancelDoesNotRestockBackorder(t *testing.T) {
+	db := newOrdersTestDB(t)
+	variant := seedVariant(t, db, "SKU-PREORDER", 0)
+	release := time.Now().UTC().Add(30 * 24 * time.Hour).Truncate(time.Second)
+	require.NoError(t, db.Model(&variant).Updates(map[string]any{"inventory_policy": models.InventoryPolicyPreorder, "preorder_release_at": release}).Error)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+
+	order, err := NewService(db).Create(context.Background(), session.ID, &userID, nil, []CreateItemInput{{ProductVariantID: variant.ID, Quantity: 2}})
+	require.NoError(t, err)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, models.InventoryPolicyPreorder, order.Items[0].InventoryPolicy)
+	require.NotNil(t, order.Items[0].PromisedShipAt)
+	assert.True(t, release.Equal(*order.Items[0].PromisedShipAt))
+
+	require.NoError(t, inventoryservice.ReserveOrderItems(db, order, "preorder", time.Now().Add(time.Hour)))
+	require.NoError(t, ApplyStatusTransition(db, &order, StatusTransition{To: models.StatusPaid, Path: PathPayment}))
+	require.NoError(t, ApplyStatusTransition(db, &order, StatusTransition{To: models.StatusCancelled, Path: PathAdmin}))
+
+	availability, err := inventoryservice.GetAvailability(db, variant.ID)
+	require.NoError(t, err)
+	assert.Zero(t, availability.OnHand)
+	var reservation models.InventoryReservation
+	require.NoError(t, db.Where("order_id = ?", order.ID).First(&reservation).Error)
+	assert.Equal(t, models.InventoryReservationStatusReleased, reservation.Status)
+}
+
+func TestPreorderIsHeldBackFromStockShippingAndCaptureUntilRelease(t *testing.T) {
+	db := newOrdersTestDB(t)
+	variant := seedVariant(t, db, "SKU-LAUNCH", 5)
+	release := time.Now().UTC().Add(30 * 24 * time.Hour)
+	require.NoError(t, db.Model(&variant).Updates(map[string]any{"inventory_policy": models.InventoryPolicyPreorder, "preorder_release_at": release, "backorder_limit": 3}).Error)
+	require.NoError(t, db.First(&variant, variant.ID).Error)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+
+	sellable, err := inventoryservice.SellableQuantity(db, variant)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sellable)
+
+	order, err := NewService(db).Create(context.Background(), session.ID, &userID, nil, []CreateItemInput{{ProductVariantID: variant.ID, Quantity: 2}})
+	require.NoError(t, err)
+	require.NoError(t, inventoryservice.ReserveOrderItems(db, order, "launch", time.Now().Add(time.Hour)))
+	var reservation models.InventoryReservation
+	require.NoError(t, db.Where("order_id = ?", order.ID).First(&reservation).Error)
+	assert.True(t, reservation.Backordered)
+
+	capturable, err := CapturableAmount(db, order)
+	require.NoError(t, err)
+	require.NotNil(t, capturable)
+	assert.Zero(t, *capturable)
+	require.NoError(t, ApplyStatusTransition(db, &order, StatusTransition{To: models.StatusPaid, Path: PathPayment}))
+	err = ApplyStatusTransition(db, &order, StatusTransition{To: models.StatusShipped, Path: PathAdmin})
+	assert.ErrorIs(t, err, ErrPreorderNotReleased)
+
+	require.NoError(t, db.Model(&variant).Update("preorder_release_at", time.Now().UTC().Add(-time.Hour)).Error)
+	require.NoError(t, db.First(&variant, variant.ID).Error)
+	err = ApplyStatusTransition(db, &order, StatusTransition{To: models.StatusShipped, Path: PathAdmin})
+	assert.ErrorIs(t, err, ErrOrderPaymentNotCaptured)
+	sellable, err = inventoryservice.SellableQuantity(db, variant)
+	require.NoError(t, err)
+	assert.Equal(t, 5, sellable)
+}
+
+func TestExportCSVIncludesCheckoutAttributeColumns(t *testing.T) {
+	db := newOrdersTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.CheckoutField{}, &models.User{}))
+	require.NoError(t, db.Create(&models.CheckoutField{Key: "po_number", Label: "PO number", Type: models.CheckoutFieldTypeText, Active: true}).Error)
+	variant := seedVariant(t, db, "SKU-EXPORT", 5)
+	session := seedOrderSession(t, db, nil)
+	guest := "buyer@example.com"
+
+	service := NewService(db)
+	order, err := service.Create(context.Background(), session.ID, nil, &guest, []CreateItemInput{{ProductVariantID: variant.ID, Quantity: 3}})
+	require.NoError(t, err)
+	require.NoError(t, service.SetAttributes(context.Background(), &order, map[string]string{"po_number": "PO-77", "gift_message": "Thanks"}))
+
+	contents, err := service.ExportCSV(context.Background(), ExportInput{Status: models.StatusPending})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "order_id,created_at,status,user_id,email,item_count,total,payment_method,shipping_address,attribute_po_number,attribute_gift_message", lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], ",PENDING,,buyer@example.com,3,30.00,,,PO-77,Thanks"), lines[1])
+
+	_, err = service.ExportCSV(context.Background(), ExportInput{Status: "LOST"})
+	assert.ErrorIs(t, err, ErrInvalidOrderStatus)
+}
+
 func TestApplyStatusTransition_CommitsStock(t *testing.T) {
 	db := newOrdersTestDB(t)
 
@@ -103,7 +263,7 @@ func TestApplyStatusTransition_CommitsStock(t *testing.T) {
 	}).Error)
 
 	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
-		return ApplyStatusTransition(tx, &order, models.StatusPaid)
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusPaid, Path: PathPayment})
 	}))
 
 	var updatedVariant models.ProductVariant
@@ -153,7 +313,7 @@ func TestApplyStatusTransition_ConsumesReservation(t *testing.T) {
 	}).Error)
 
 	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
-		return ApplyStatusTransition(tx, &order, models.StatusPaid)
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusPaid, Path: PathPayment})
 	}))
 
 	var updatedVariant models.ProductVariant
@@ -198,7 +358,7 @@ func TestApplyStatusTransition_PreservesReservationOnNoopPendingTransition(t *te
 	}).Error)
 
 	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
-		return ApplyStatusTransition(tx, &order, models.StatusPending)
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusPending, Path: PathAdmin})
 	}))
 
 	var reservation models.InventoryReservation
@@ -229,7 +389,7 @@ func TestApplyStatusTransition_RestoresStock(t *testing.T) {
 	}).Error)
 
 	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
-		return ApplyStatusTransition(tx, &order, models.StatusCancelled)
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusCancelled, Path: PathAdmin})
 	}))
 
 	var updatedVariant models.ProductVariant
@@ -260,7 +420,7 @@ func TestApplyStatusTransition_InsufficientStock(t *testing.T) {
 	}).Error)
 
 	err := db.Transaction(func(tx *gorm.DB) error {
-		return ApplyStatusTransition(tx, &order, models.StatusPaid)
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusPaid, Path: PathPayment})
 	})
 	require.Error(t, err)
 
@@ -270,3 +430,600 @@ func TestApplyStatusTransition_InsufficientStock(t *testing.T) {
 	assert.Equal(t, 3, stockErr.Requested)
 	assert.Equal(t, 1, stockErr.Available)
 }
+
+func TestPaidOrderFulfillsFromEachLocationItDrewStockFrom(t *testing.T) {
+	db := newOrdersTestDB(t)
+	variant := seedVariant(t, db, "SKU-SPLIT", 2)
+	store, err := inventoryservice.CreateLocation(db, inventoryservice.LocationInput{Code: "store-1", Name: "High Street", Kind: models.StockLocationKindStore})
+	require.NoError(t, err)
+	_, err = inventoryservice.ApplyMovement(db, inventoryservice.MovementInput{ProductVariantID: variant.ID, StockLocationID: store.ID, MovementType: "RESTOCK", QuantityDelta: 3})
+	require.NoError(t, err)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+	order := models.Order{UserID: &userID, CheckoutSessionID: session.ID, Total: models.MoneyFromFloat(40), Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+	require.NoError(t, db.Create(&models.OrderItem{
+		OrderID: order.ID, ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title,
+		Quantity: 4, Price: models.MoneyFromFloat(10),
+	}).Error)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusPaid, Path: PathPayment})
+	}))
+
+	var fulfillments []models.FulfillmentOrder
+	require.NoError(t, db.Preload("Lines").Order("id ASC").Find(&fulfillments).Error)
+	require.Len(t, fulfillments, 2)
+	assert.Equal(t, models.DefaultFulfillmentLocation, fulfillments[0].LocationCode)
+	assert.Equal(t, 2, fulfillments[0].Lines[0].Quantity)
+	assert.Equal(t, store.Code, fulfillments[1].LocationCode)
+	assert.Equal(t, 2, fulfillments[1].Lines[0].Quantity)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusCancelled, Path: PathAdmin})
+	}))
+	levels, err := inventoryservice.GetLocationLevels(db, variant.ID)
+	require.NoError(t, err)
+	require.Len(t, levels, 2)
+	assert.Equal(t, 2, levels[0].OnHand)
+	assert.Equal(t, 3, levels[1].OnHand)
+}
+
+func TestPaidOrderRoutesToTheLocationNearestItsShippingAddress(t *testing.T) {
+	db := newOrdersTestDB(t)
+	variant := seedVariant(t, db, "SKU-ROUTE", 5)
+	country, state := "US", "CA"
+	store, err := inventoryservice.CreateLocation(db, inventoryservice.LocationInput{Code: "oakland", Name: "Oakland", Country: &country, State: &state})
+	require.NoError(t, err)
+	_, err = inventoryservice.ApplyMovement(db, inventoryservice.MovementInput{ProductVariantID: variant.ID, StockLocationID: store.ID, MovementType: "RESTOCK", QuantityDelta: 5})
+	require.NoError(t, err)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+	order := models.Order{UserID: &userID, CheckoutSessionID: session.ID, Total: models.MoneyFromFloat(20), Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+	require.NoError(t, db.Create(&models.OrderItem{
+		OrderID: order.ID, ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title,
+		Quantity: 2, Price: models.MoneyFromFloat(10),
+	}).Error)
+	require.NoError(t, db.Create(&models.OrderCheckoutSnapshot{
+		CheckoutSessionID: session.ID, OrderID: &order.ID, Currency: "USD", PaymentProviderID: "mock", ShippingProviderID: "ship",
+		ShippingDataJSON: `{"country":"us","state":"ca"}`, ExpiresAt: time.Now().Add(time.Hour),
+	}).Error)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusPaid, Path: PathPayment})
+	}))
+
+	var fulfillments []models.FulfillmentOrder
+	require.NoError(t, db.Preload("Lines").Find(&fulfillments).Error)
+	require.Len(t, fulfillments, 1)
+	assert.Equal(t, store.Code, fulfillments[0].LocationCode)
+	assert.Equal(t, "Routed to oakland: can ship the whole order; in the destination state US-CA; location priority 0.", fulfillments[0].RoutingExplanation)
+	// The units were first drawn from the default location and moved with
+	// the routing decision.
+	levels, err := inventoryservice.GetLocationLevels(db, variant.ID)
+	require.NoError(t, err)
+	require.Len(t, levels, 2)
+	assert.Equal(t, 5, levels[0].OnHand)
+	assert.Equal(t, 3, levels[1].OnHand)
+}
+
+func TestOrderEditRepricesAndAppliesQuantityChanges(t *testing.T) {
+	db := newOrdersTestDB(t)
+	plugins := checkoutplugins.NewDefaultManager()
+	now := time.Date(2026, 8, 27, 9, 0, 0, 0, time.UTC)
+	variant := seedVariant(t, db, "SKU-EDIT", 5)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+	shipping := `{"full_name":"Sam Buyer","line1":"1 Main St","city":"Fresno","state":"CA","postal_code":"93650","country":"US","service_level":"standard"}`
+	payment := `{"cardholder_name":"Sam Buyer","card_number":"4242424242424242","exp_month":"12","exp_year":"2030"}`
+	quote := plugins.Quote(checkoutplugins.QuoteRequest{
+		Subtotal: models.MoneyFromFloat(20), PaymentID: "dummy-card", ShippingID: "dummy-ground",
+		PaymentData: decodeStringMap(payment), ShippingData: decodeStringMap(shipping),
+	})
+	require.True(t, quote.Valid)
+	order := models.Order{UserID: &userID, CheckoutSessionID: session.ID, Total: quote.Total, Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+	require.NoError(t, db.Create(&models.OrderItem{
+		OrderID: order.ID, ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title,
+		Quantity: 2, Price: models.MoneyFromFloat(10),
+	}).Error)
+	require.NoError(t, db.Create(&models.OrderCheckoutSnapshot{
+		CheckoutSessionID: session.ID, OrderID: &order.ID, Currency: "USD", PaymentProviderID: "dummy-card", ShippingProviderID: "dummy-ground",
+		PaymentDataJSON: payment, ShippingDataJSON: shipping, Subtotal: models.MoneyFromFloat(20), Total: quote.Total, ExpiresAt: now.Add(time.Hour),
+	}).Error)
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return ApplyStatusTransition(tx, &order, StatusTransition{To: models.StatusPaid, Path: PathPayment})
+	}))
+
+	edit, err := BeginOrderEdit(db, order.ID, OrderEditInput{Reason: "customer called", Actor: "ops"}, plugins, now)
+	require.NoError(t, err)
+	require.Len(t, edit.Lines, 1)
+	assert.Equal(t, models.Money(0), edit.BalanceDue)
+	_, err = BeginOrderEdit(db, order.ID, OrderEditInput{}, plugins, now)
+	assert.ErrorIs(t, err, ErrOrderEditInProgress)
+
+	edit, err = StageOrderEditChange(db, edit.ID, OrderEditChangeInput{Kind: models.OrderEditChangeSetQuantity, LineID: edit.Lines[0].ID, Quantity: 3, Actor: "ops"}, plugins, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.MoneyFromFloat(30), edit.Subtotal)
+	assert.Equal(t, edit.Total-order.Total, edit.BalanceDue)
+	assert.Positive(t, edit.BalanceDue)
+	var stockErr *InsufficientStockError
+	_, err = StageOrderEditChange(db, edit.ID, OrderEditChangeInput{Kind: models.OrderEditChangeSetQuantity, LineID: edit.Lines[0].ID, Quantity: 9}, plugins, now)
+	require.ErrorAs(t, err, &stockErr)
+	assert.Equal(t, 3, stockErr.Available)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		if _, err := PrepareOrderEditCommit(tx, edit.ID, "op-1"); err != nil {
+			return err
+		}
+		return ApplyOrderEdit(tx, edit.ID, OrderEditCommitInput{Actor: "ops"}, now)
+	}))
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return ApplyOrderEdit(tx, edit.ID, OrderEditCommitInput{Actor: "ops"}, now)
+	}), "applying a committed edit again is a no-op")
+
+	edit, err = GetOrderEdit(db, edit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderEditStatusCommitted, edit.Status)
+	assert.Equal(t, "op-1", edit.PaymentOperationKey)
+	require.NoError(t, db.Preload("Items").First(&order, order.ID).Error)
+	assert.Equal(t, edit.Total, order.Total)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, 3, order.Items[0].Quantity)
+	require.NoError(t, db.First(&variant, variant.ID).Error)
+	assert.Equal(t, 2, variant.Stock)
+	var history models.OrderStatusHistory
+	require.NoError(t, db.Where("order_id = ? AND reason = ?", order.ID, "order_edited").First(&history).Error)
+	assert.Equal(t, "ops", history.Actor)
+	var fulfillments []models.FulfillmentOrder
+	require.NoError(t, db.Preload("Lines").Where("order_id = ? AND status = ?", order.ID, models.FulfillmentStatusUnfulfilled).Find(&fulfillments).Error)
+	require.Len(t, fulfillments, 1)
+	assert.Equal(t, 3, fulfillments[0].Lines[0].Quantity)
+
+	// Taking units off produces a refund balance and returns them to stock.
+	edit, err = BeginOrderEdit(db, order.ID, OrderEditInput{Actor: "ops"}, plugins, now)
+	require.NoError(t, err)
+	edit, err = StageOrderEditChange(db, edit.ID, OrderEditChangeInput{Kind: models.OrderEditChangeSetQuantity, LineID: edit.Lines[0].ID, Quantity: 1}, plugins, now)
+	require.NoError(t, err)
+	assert.Negative(t, edit.BalanceDue)
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return ApplyOrderEdit(tx, edit.ID, OrderEditCommitInput{Actor: "ops"}, now)
+	}))
+	require.NoError(t, db.First(&variant, variant.ID).Error)
+	assert.Equal(t, 4, variant.Stock)
+	require.NoError(t, db.Preload("Items").First(&order, order.ID).Error)
+	assert.Equal(t, 1, order.Items[0].RemainingQuantity())
+}
+
+func TestOrderEditKeepsDiscountTheOrderWasPlacedWith(t *testing.T) {
+	db := newOrdersTestDB(t)
+	plugins := checkoutplugins.NewDefaultManager()
+	now := time.Date(2026, 8, 27, 9, 0, 0, 0, time.UTC)
+	variant := seedVariant(t, db, "SKU-COUPON", 5)
+	session := seedOrderSession(t, db, nil)
+	shipping := `{"full_name":"Sam Buyer","line1":"1 Main St","city":"Fresno","state":"CA","postal_code":"93650","country":"US","service_level":"standard"}`
+	payment := `{"cardholder_name":"Sam Buyer","card_number":"4242424242424242","exp_month":"12","exp_year":"2030"}`
+	// The order was placed with a coupon taking 4.00 off two 10.00 units;
+	// the campaign has ended since.
+	code := "SPRING4"
+	ended := now.Add(-time.Hour)
+	campaign := models.DiscountCampaign{Name: "Spring", Type: models.DiscountCampaignTypePromotion, Status: models.DiscountCampaignStatusActive,
+		StartsAt: now.Add(-72 * time.Hour), EndsAt: &ended, DiscountMode: models.DiscountModeFixed, DiscountValue: models.MoneyFromFloat(2), CouponCode: &code}
+	require.NoError(t, db.Create(&campaign).Error)
+	quote := plugins.Quote(checkoutplugins.QuoteRequest{
+		Subtotal: models.MoneyFromFloat(16), PaymentID: "dummy-card", ShippingID: "dummy-ground",
+		PaymentData: decodeStringMap(payment), ShippingData: decodeStringMap(shipping),
+	})
+	require.True(t, quote.Valid)
+	order := models.Order{CheckoutSessionID: session.ID, Total: quote.Total, Status: models.StatusPaid}
+	require.NoError(t, db.Create(&order).Error)
+	require.NoError(t, db.Create(&models.OrderItem{
+		OrderID: order.ID, ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title,
+		Quantity: 2, Price: models.MoneyFromFloat(10),
+	}).Error)
+	require.NoError(t, db.Create(&models.DiscountRedemption{CampaignID: campaign.ID, OrderID: order.ID, AppliedAmount: models.MoneyFromFloat(4), AppliedAt: now.Add(-48 * time.Hour)}).Error)
+	require.NoError(t, db.Create(&models.OrderCheckoutSnapshot{
+		CheckoutSessionID: session.ID, OrderID: &order.ID, Currency: "USD", PaymentProviderID: "dummy-card", ShippingProviderID: "dummy-ground",
+		PaymentDataJSON: payment, ShippingDataJSON: shipping, Subtotal: models.MoneyFromFloat(16), ShippingAmount: quote.Shipping, TaxAmount: quote.Tax, Total: quote.Total,
+		ExpiresAt: now.Add(time.Hour),
+	}).Error)
+
+	edit, err := BeginOrderEdit(db, order.ID, OrderEditInput{Actor: "ops"}, plugins, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.MoneyFromFloat(4), edit.DiscountAmount)
+	assert.Equal(t, order.Total, edit.Total)
+	assert.Equal(t, models.Money(0), edit.BalanceDue, "an edit that changes nothing owes nothing")
+
+	// Dropping a unit gives back that unit's share of the coupon only.
+	edit, err = StageOrderEditChange(db, edit.ID, OrderEditChangeInput{Kind: models.OrderEditChangeSetQuantity, LineID: edit.Lines[0].ID, Quantity: 1}, plugins, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.MoneyFromFloat(2), edit.DiscountAmount)
+	assert.Equal(t, models.MoneyFromFloat(8), edit.Subtotal-edit.DiscountAmount)
+}
+
+func TestDraftOrderPaymentLinkOpensLockedCheckout(t *testing.T) {
+	db := newOrdersTestDB(t)
+	plugins := checkoutplugins.NewDefaultManager()
+	now := time.Date(2026, 8, 28, 9, 0, 0, 0, time.UTC)
+	variant := seedVariant(t, db, "SKU-DRAFT", 5)
+	price := models.MoneyFromFloat(20)
+	input := DraftOrderInput{
+		GuestEmail: "Pat@Example.com", ShippingProviderID: "dummy-ground", Actor: "ops",
+		ShippingData: decodeStringMap(`{"full_name":"Pat Buyer","line1":"1 Main St","city":"Fresno","state":"CA","postal_code":"93650","country":"US","service_level":"standard"}`),
+		Lines:        []DraftOrderLineInput{{ProductVariantID: variant.ID, Quantity: 2, UnitPrice: &price, DiscountPercent: models.MoneyFromFloat(12.5)}},
+	}
+	_, err := CreateDraftOrder(db, DraftOrderInput{Lines: input.Lines, ShippingProviderID: "dummy-ground"}, plugins)
+	require.ErrorIs(t, err, ErrInvalidDraftOrder, "a draft needs a customer or guest email")
+
+	draft, err := CreateDraftOrder(db, input, plugins)
+	require.NoError(t, err)
+	assert.Equal(t, models.DraftOrderStatusOpen, draft.Status)
+	require.NotNil(t, draft.GuestEmail)
+	assert.Equal(t, "pat@example.com", *draft.GuestEmail)
+	require.Len(t, draft.Lines, 1)
+	assert.Equal(t, models.MoneyFromFloat(17.5), draft.Lines[0].FinalUnitPrice)
+	assert.Equal(t, models.MoneyFromFloat(35), draft.Subtotal)
+	assert.Equal(t, draft.Subtotal+draft.ShippingAmount+draft.TaxAmount, draft.Total)
+	assert.Contains(t, draft.ShippingAddressPretty, "Fresno")
+
+	_, err = OpenDraftOrderCheckout(db, draft.PaymentToken, 0, now)
+	require.ErrorIs(t, err, ErrDraftOrderNotInvoiced)
+	draft, err = SendDraftOrderPaymentLink(db, draft.ID, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.DraftOrderStatusInvoiced, draft.Status)
+	_, err = OpenDraftOrderCheckout(db, draft.PaymentToken, 7, now)
+	require.ErrorIs(t, err, ErrDraftOrderRestricted, "a guest draft does not open for an account")
+
+	session, err := OpenDraftOrderCheckout(db, draft.PaymentToken, 0, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.CheckoutSessionKindDraftOrder, session.Kind)
+	var item models.CartItem
+	require.NoError(t, db.Joins("JOIN carts ON carts.id = cart_items.cart_id").Where("carts.checkout_session_id = ?", session.ID).First(&item).Error)
+	require.NotNil(t, item.LockedPrice)
+	assert.Equal(t, models.MoneyFromFloat(17.5), *item.LockedPrice)
+	state, err := checkoutservice.LoadCheckoutState(db, session.ID)
+	require.NoError(t, err)
+	require.NotNil(t, state.Contact)
+	assert.Equal(t, "pat@example.com", state.Contact.Email)
+	assert.Equal(t, "Pat Buyer", state.ShippingAddress["full_name"])
+	require.NotNil(t, state.ShippingMethod)
+	assert.Equal(t, "standard", state.ShippingMethod.Data["service_level"])
+	assert.True(t, state.Confirmed[checkoutservice.CheckoutStepShippingMethod])
+	again, err := OpenDraftOrderCheckout(db, draft.PaymentToken, 0, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, again.ID, "opening the link again resumes the same checkout")
+
+	input.Lines[0].Quantity = 3
+	draft, err = UpdateDraftOrder(db, draft.ID, input, plugins)
+	require.NoError(t, err)
+	assert.Equal(t, models.DraftOrderStatusOpen, draft.Status, "changing an invoiced draft takes it back to OPEN")
+	assert.Nil(t, draft.CheckoutSessionID)
+	require.NoError(t, db.First(&session, session.ID).Error)
+	assert.Equal(t, models.CheckoutSessionStatusExpired, session.Status)
+
+	var order models.Order
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		draft, order, err = PlaceDraftOrder(tx, draft.ID, "Offline payment: cash", now)
+		if err != nil {
+			return err
+		}
+		return CompleteDraftOrder(tx, draft.ID, order.CheckoutSessionID, order.ID, "cash", "R-1", now)
+	}))
+	assert.Equal(t, models.StatusPending, order.Status)
+	assert.Equal(t, draft.Total, order.Total)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, models.MoneyFromFloat(17.5), order.Items[0].Price)
+	draft, err = GetDraftOrder(db, draft.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DraftOrderStatusCompleted, draft.Status)
+	require.NotNil(t, draft.OrderID)
+	assert.Equal(t, order.ID, *draft.OrderID)
+	_, err = CancelDraftOrder(db, draft.ID, now)
+	assert.ErrorIs(t, err, ErrDraftOrderClosed)
+}
+
+func TestOrderDocumentsAreNumberedPerLegalEntity(t *testing.T) {
+	db := newOrdersTestDB(t)
+	now := time.Date(2026, 8, 29, 10, 0, 0, 0, time.UTC)
+	variant := seedVariant(t, db, "SKU-DOCS", 10)
+	session := seedOrderSession(t, db, nil)
+	_, err := CreateLegalEntity(db, LegalEntityInput{Code: "us", Name: "Shop Inc.", Address: "1 Market St\nSan Francisco, CA", TaxID: "US-123", Country: "us", InvoicePrefix: "US-", CreditNotePrefix: "USC-"})
+	require.NoError(t, err)
+	_, err = CreateLegalEntity(db, LegalEntityInput{Code: "eu", Name: "Shop B.V.", IsDefault: true})
+	require.NoError(t, err)
+
+	placeOrder := func(status, country string) models.Order {
+		email := "buyer@example.com"
+		order := models.Order{
+			CheckoutSessionID: session.ID, GuestEmail: &email, Status: status, Total: models.MoneyFromFloat(26.45),
+			ShippingAddressPretty: "Sam Buyer\n1 Main St", PaymentMethodDisplay: "Visa ending 4242",
+			Items: []models.OrderItem{{ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: "Widget (Blue)", Quantity: 2, Price: models.MoneyFromFloat(10)}},
+		}
+		require.NoError(t, db.Create(&order).Error)
+		snapshot := models.OrderCheckoutSnapshot{
+			CheckoutSessionID: session.ID, OrderID: &order.ID, Currency: "USD", Subtotal: models.MoneyFromFloat(20),
+			ShippingAmount: models.MoneyFromFloat(5), TaxAmount: models.MoneyFromFloat(1.45), Total: order.Total,
+			PaymentProviderID: "dummy-card", ShippingProviderID: "dummy-ground", ShippingDataJSON: `{"country":"` + country + `"}`, ExpiresAt: now,
+		}
+		require.NoError(t, db.Create(&snapshot).Error)
+		for _, amount := range []float64{0.75, 0.70} {
+			require.NoError(t, db.Create(&models.OrderTaxLine{
+				OrderID: order.ID, SnapshotID: snapshot.ID, LineType: models.TaxLineTypeItem, TaxProviderID: "dummy-tax", Jurisdiction: "US-CA",
+				TaxName: "Sales tax", TaxableAmount: models.MoneyFromFloat(10), TaxAmount: models.MoneyFromFloat(amount), TaxRateBasisPoints: 725, FinalizedAt: now,
+			}).Error)
+		}
+		return order
+	}
+	usOrder := placeOrder(models.StatusPaid, "US")
+	frOrder := placeOrder(models.StatusShipped, "FR")
+	pendingOrder := placeOrder(models.StatusPending, "US")
+
+	invoice, err := IssueOrderDocument(db, usOrder.ID, IssueOrderDocumentInput{Type: "invoice", Actor: "ops"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "US-000001", invoice.Number)
+	assert.Equal(t, usOrder.Total, invoice.Total)
+	assert.True(t, strings.HasPrefix(string(invoice.PDF), "%PDF-"))
+	assert.Len(t, invoice.Checksum, 64)
+	assert.Contains(t, invoice.HTML, "Shop Inc.")
+	assert.Contains(t, invoice.HTML, "Sales tax (US-CA)")
+	assert.Contains(t, invoice.HTML, "7.25%")
+	assert.Contains(t, invoice.HTML, "1.45", "tax lines at the same rate are summed")
+	again, err := IssueOrderDocument(db, usOrder.ID, IssueOrderDocumentInput{Type: models.OrderDocumentTypeInvoice}, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, invoice.ID, again.ID, "an order is invoiced once")
+
+	frInvoice, err := IssueOrderDocument(db, frOrder.ID, IssueOrderDocumentInput{Type: models.OrderDocumentTypeInvoice}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "INV-000001", frInvoice.Number, "countries without their own entity use the default one")
+	_, err = IssueOrderDocument(db, pendingOrder.ID, IssueOrderDocumentInput{Type: models.OrderDocumentTypeInvoice}, now)
+	require.ErrorIs(t, err, ErrOrderNotInvoiceable)
+
+	fulfillment := models.FulfillmentOrder{OrderID: usOrder.ID, LocationCode: "default", Status: models.FulfillmentStatusPacked,
+		Lines: []models.FulfillmentOrderLine{{OrderItemID: usOrder.Items[0].ID, ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: "Widget (Blue)", Quantity: 2}}}
+	require.NoError(t, db.Create(&fulfillment).Error)
+	slip, err := IssueOrderDocument(db, usOrder.ID, IssueOrderDocumentInput{Type: models.OrderDocumentTypePackingSlip, FulfillmentOrderID: &fulfillment.ID}, now)
+	require.NoError(t, err)
+	assert.Nil(t, slip.LegalEntityID)
+	assert.Contains(t, slip.HTML, "Widget (Blue)")
+	assert.NotContains(t, slip.HTML, "Unit price")
+
+	refund := func(order models.Order, amount float64) models.PaymentTransaction {
+		intent := models.PaymentIntent{OrderID: order.ID, Provider: "dummy-card", Status: models.PaymentIntentStatusCaptured,
+			AuthorizedAmount: order.Total, CapturedAmount: order.Total, Currency: "USD"}
+		require.NoError(t, db.Create(&intent).Error)
+		txn := models.PaymentTransaction{PaymentIntentID: intent.ID, Operation: models.PaymentTransactionOperationRefund,
+			ProviderTxnID: "re_1", IdempotencyKey: "refund-" + order.Status, Amount: models.MoneyFromFloat(amount), Status: models.PaymentTransactionStatusSucceeded}
+		require.NoError(t, db.Create(&txn).Error)
+		return txn
+	}
+	usRefund := refund(usOrder, 10)
+	require.NoError(t, IssueRefundCreditNote(db, usOrder.ID, usRefund, now))
+	require.NoError(t, IssueRefundCreditNote(db, usOrder.ID, usRefund, now), "a refund is credited once")
+	pendingRefund := refund(pendingOrder, 5)
+	require.NoError(t, IssueRefundCreditNote(db, pendingOrder.ID, pendingRefund, now))
+	pendingDocuments, err := ListOrderDocuments(db, pendingOrder.ID)
+	require.NoError(t, err)
+	require.Len(t, pendingDocuments, 2, "an order refunded before it was invoiced is invoiced first")
+	assert.Equal(t, models.OrderDocumentTypeCreditNote, pendingDocuments[0].Type)
+	assert.Equal(t, models.OrderDocumentTypeInvoice, pendingDocuments[1].Type)
+	assert.Equal(t, "US-000002", pendingDocuments[1].Number)
+
+	giftCardID := uint(41)
+	require.NoError(t, IssueStoreCreditNote(db, frOrder.ID, giftCardID, models.MoneyFromFloat(6), now))
+	require.NoError(t, IssueStoreCreditNote(db, frOrder.ID, giftCardID, models.MoneyFromFloat(6), now), "store credit is credited once")
+	frDocuments, err := ListOrderDocuments(db, frOrder.ID)
+	require.NoError(t, err)
+	require.Len(t, frDocuments, 2)
+	storeCredit, err := GetOrderDocument(db, frOrder.ID, frDocuments[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, storeCredit.GiftCardID)
+	assert.Equal(t, giftCardID, *storeCredit.GiftCardID)
+	assert.Nil(t, storeCredit.PaymentTransactionID)
+	assert.Equal(t, models.MoneyFromFloat(6), storeCredit.Total)
+	assert.Contains(t, storeCredit.HTML, "Store credit against invoice INV-000001")
+
+	documents, err := ListOrderDocuments(db, usOrder.ID)
+	require.NoError(t, err)
+	require.Len(t, documents, 3)
+	var credit models.OrderDocument
+	for _, document := range documents {
+		assert.Empty(t, document.PDF, "listings leave the renderings out")
+		if document.Type == models.OrderDocumentTypeCreditNote {
+			credit = document
+		}
+	}
+	assert.Equal(t, "USC-000001", credit.Number)
+	require.NotNil(t, credit.InvoiceID)
+	assert.Equal(t, invoice.ID, *credit.InvoiceID)
+	assert.Equal(t, models.MoneyFromFloat(10), credit.Total)
+	_, err = IssueOrderDocument(db, usOrder.ID, IssueOrderDocumentInput{Type: models.OrderDocumentTypeCreditNote, PaymentTransactionID: &pendingRefund.ID}, now)
+	require.ErrorIs(t, err, ErrInvalidOrderDocument, "the refund must belong to the order")
+
+	stored, err := GetOrderDocument(db, usOrder.ID, invoice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, invoice.PDF, stored.PDF)
+	_, err = GetOrderDocument(db, frOrder.ID, invoice.ID)
+	require.ErrorIs(t, err, ErrOrderDocumentNotFound)
+}
+
+func TestListSearchesOrdersAndAppliesSavedViews(t *testing.T) {
+	db := newOrdersTestDB(t)
+	ctx := context.Background()
+	service := NewService(db)
+	customer := models.User{Username: "jdoe", Email: "jane.doe@example.com", Name: "Jane Doe"}
+	require.NoError(t, db.Create(&customer).Error)
+	session := seedOrderSession(t, db, nil)
+	placeOrder := func(sku string, total float64, userID *uint, guestEmail string) models.Order {
+		token := "tok-" + sku
+		order := models.Order{CheckoutSessionID: session.ID, UserID: userID, ConfirmationToken: &token, Status: models.StatusPaid, Total: models.MoneyFromFloat(total),
+			Items: []models.OrderItem{{VariantSKU: sku, VariantTitle: sku, Quantity: 1, Price: models.MoneyFromFloat(total)}}}
+		if guestEmail != "" {
+			order.GuestEmail = &guestEmail
+		}
+		require.NoError(t, db.Create(&order).Error)
+		return order
+	}
+	janes := placeOrder("MUG-RED", 12.50, &customer.ID, "")
+	guests := placeOrder("TEE-BLUE", 40, nil, "walk.in@example.com")
+	big := placeOrder("SOFA-XL", 899.99, nil, "big.spender@example.com")
+
+	require.NoError(t, db.Create(&models.Shipment{OrderID: guests.ID, SnapshotID: 1, Provider: "dummy", ShipmentRateID: 1, Status: models.ShipmentStatusInTransit,
+		Currency: "USD", ServiceCode: "ground", ServiceName: "Ground", TrackingNumber: "1Z999AA1"}).Error)
+	intent := models.PaymentIntent{OrderID: big.ID, Provider: "dummy-card", Status: models.PaymentIntentStatusCaptured, Currency: "USD"}
+	require.NoError(t, db.Create(&intent).Error)
+	require.NoError(t, db.Create(&models.PaymentTransaction{PaymentIntentID: intent.ID, Operation: models.PaymentTransactionOperationCapture,
+		ProviderTxnID: "ch_3PqXyZ", IdempotencyKey: "capture-big", Amount: big.Total, Status: models.PaymentTransactionStatusSucceeded}).Error)
+
+	tags, err := service.SetTags(ctx, big.ID, []string{" VIP ", "fraud-check", "vip"}, "ops")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fraud-check", "vip"}, tags)
+	_, err = service.SetTags(ctx, big.ID, []string{"vip"}, "ops")
+	require.NoError(t, err)
+	_, err = service.SetTags(ctx, big.ID, []string{""}, "ops")
+	require.ErrorIs(t, err, ErrInvalidOrderTag)
+
+	search := func(input ListInput) []uint {
+		t.Helper()
+		page, err := service.List(ctx, input)
+		require.NoError(t, err)
+		ids := make([]uint, 0, len(page.Orders))
+		for _, order := range page.Orders {
+			ids = append(ids, order.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []uint{janes.ID}, search(ListInput{Email: "JANE.DOE"}))
+	assert.Equal(t, []uint{janes.ID}, search(ListInput{Name: "jane"}))
+	assert.Equal(t, []uint{guests.ID}, search(ListInput{SKU: "tee"}))
+	assert.Equal(t, []uint{guests.ID}, search(ListInput{ConfirmationToken: "TOK-TEE-BLUE"}))
+	assert.Equal(t, []uint{guests.ID}, search(ListInput{TrackingNumber: "1z999aa1"}))
+	assert.Equal(t, []uint{big.ID}, search(ListInput{PaymentReference: "ch_3PqXyZ"}))
+	assert.Equal(t, []uint{big.ID}, search(ListInput{Tag: "VIP"}))
+	assert.Empty(t, search(ListInput{Tag: "fraud-check"}), "replacing tags drops the ones left out")
+	minTotal, maxTotal := models.MoneyFromFloat(20), models.MoneyFromFloat(100)
+	assert.Equal(t, []uint{guests.ID}, search(ListInput{MinTotal: &minTotal, MaxTotal: &maxTotal}))
+	_, err = service.List(ctx, ListInput{MinTotal: &maxTotal, MaxTotal: &minTotal})
+	require.ErrorIs(t, err, ErrInvalidOrderSearch)
+
+	page, err := service.List(ctx, ListInput{})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint][]string{big.ID: {"vip"}}, page.Tags)
+
+	view, err := service.CreateView(ctx, 7, "Large orders", ViewFilters{MinTotal: &maxTotal, Status: "paid"})
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusPaid, DecodeViewFilters(view).Status)
+	_, err = service.CreateView(ctx, 7, "Large orders", ViewFilters{})
+	require.ErrorIs(t, err, ErrInvalidOrderView)
+	assert.Equal(t, []uint{big.ID}, search(ListInput{ViewID: &view.ID, ViewOwnerID: 7}))
+	assert.Empty(t, search(ListInput{ViewID: &view.ID, ViewOwnerID: 7, Status: models.StatusShipped}), "request filters override the view's")
+	_, err = service.List(ctx, ListInput{ViewID: &view.ID, ViewOwnerID: 8})
+	require.ErrorIs(t, err, ErrOrderViewNotFound, "views are private to their owner")
+	require.ErrorIs(t, service.DeleteView(ctx, 8, view.ID), ErrOrderViewNotFound)
+
+	note, err := service.AddNote(ctx, janes.ID, "  Customer called about gift wrap ", "ops")
+	require.NoError(t, err)
+	assert.Equal(t, "Customer called about gift wrap", note.Body)
+	_, err = service.AddNote(ctx, 9999, "lost", "ops")
+	require.ErrorIs(t, err, ErrOrderNotFound)
+	notes, err := service.ListNotes(ctx, janes.ID)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	require.ErrorIs(t, service.DeleteNote(ctx, guests.ID, note.ID), ErrOrderNoteNotFound)
+	require.NoError(t, service.DeleteNote(ctx, janes.ID, note.ID))
+}
+
+func TestOrderTimelineMergesLifecycleEventsChronologically(t *testing.T) {
+	db := newOrdersTestDB(t)
+	start := time.Date(2026, 8, 31, 9, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	session := seedOrderSession(t, db, nil)
+	email := "pat@example.com"
+	order := models.Order{BaseModel: models.BaseModel{CreatedAt: at(0)}, CheckoutSessionID: session.ID, GuestEmail: &email, Status: models.StatusShipped, Total: models.MoneyFromFloat(30)}
+	require.NoError(t, db.Create(&order).Error)
+
+	intent := models.PaymentIntent{OrderID: order.ID, Provider: "dummy-card", Status: models.PaymentIntentStatusCaptured, Currency: "USD"}
+	require.NoError(t, db.Create(&intent).Error)
+	require.NoError(t, db.Create(&models.ProviderOperation{CreatedAt: at(1), OperationKey: "op-1", ProviderType: models.ProviderTypePayment, ProviderID: "dummy-card",
+		Environment: "sandbox", Operation: "capture", IdempotencyKey: "capture-1", CorrelationID: "req-7", EntityType: "payment_intent", EntityID: intent.ID, Status: models.ProviderOperationStatusCompleted}).Error)
+	require.NoError(t, db.Create(&models.PaymentTransaction{CreatedAt: at(2), PaymentIntentID: intent.ID, Operation: models.PaymentTransactionOperationCapture,
+		ProviderTxnID: "ch_1", IdempotencyKey: "capture-1", Amount: order.Total, Status: models.PaymentTransactionStatusSucceeded}).Error)
+	require.NoError(t, db.Create(&models.OrderStatusHistory{CreatedAt: at(3), OrderID: order.ID, FromStatus: models.StatusPending, ToStatus: models.StatusPaid,
+		Reason: "payment_captured", Source: "admin", Actor: "ops@example.com", CorrelationID: "req-7"}).Error)
+	webhookOrderID := order.ID
+	require.NoError(t, db.Create(&models.WebhookEvent{Provider: "dummy-ground", ProviderEventID: "evt-1", EventType: "tracking.updated", SignatureValid: true,
+		Payload: "{}", ReceivedAt: at(6), OrderID: &webhookOrderID}).Error)
+	shipment := models.Shipment{CreatedAt: at(5), OrderID: order.ID, Provider: "dummy-ground", ShipmentRateID: 1, Status: models.ShipmentStatusInTransit,
+		Currency: "USD", ServiceCode: "ground", ServiceName: "Ground", TrackingNumber: "1Z1"}
+	require.NoError(t, db.Create(&shipment).Error)
+	require.NoError(t, db.Create(&models.TrackingEvent{ShipmentID: shipment.ID, Provider: "dummy-ground", ProviderEventID: "evt-1", Status: "IN_TRANSIT",
+		Description: "Departed facility", OccurredAt: at(4)}).Error)
+	campaign := models.DiscountCampaign{Name: "Summer", Type: "automatic", Status: "active", StartsAt: at(-60), DiscountMode: "percentage"}
+	require.NoError(t, db.Create(&campaign).Error)
+	require.NoError(t, db.Create(&models.DiscountRedemption{CampaignID: campaign.ID, OrderID: order.ID, AppliedAmount: models.MoneyFromFloat(3), AppliedAt: at(0)}).Error)
+	require.NoError(t, db.Create(&models.OrderNote{CreatedAt: at(7), OrderID: order.ID, Body: "Customer asked for a gift receipt", Author: "support@example.com"}).Error)
+	require.NoError(t, db.Create(&models.OrderStatusHistory{CreatedAt: at(3), OrderID: order.ID + 1, FromStatus: models.StatusPending, ToStatus: models.StatusPaid, Source: "admin", Actor: "ops"}).Error)
+
+	events, err := NewService(db).Timeline(context.Background(), order.ID)
+	require.NoError(t, err)
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{
+		TimelineOrderPlaced, TimelineDiscountRedeemed, TimelineProviderOperation, "payment.capture", TimelineStatusChanged,
+		TimelineShipmentTracking, TimelineShipmentCreated, TimelineWebhookReceived, TimelineComment,
+	}, types)
+	assert.Equal(t, "guest:pat@example.com", events[0].Actor)
+	assert.Equal(t, "Summer applied, 3.00 off", events[1].Summary)
+	assert.Equal(t, "req-7", events[2].CorrelationID)
+	assert.Equal(t, "ch_1", events[3].Details["provider_reference"])
+	assert.Equal(t, "PENDING → PAID: payment_captured", events[4].Summary)
+	assert.Equal(t, "ops@example.com", events[4].Actor)
+	assert.Equal(t, "webhook:1", events[7].CorrelationID)
+	assert.Equal(t, "support@example.com", events[8].Actor)
+
+	_, err = OrderTimeline(db, order.ID+100)
+	require.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+func TestOrderTimelineIncludesReservationCommitsAndReleases(t *testing.T) {
+	db := newOrdersTestDB(t)
+	variant := seedVariant(t, db, "SKU-TIMELINE", 5)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+	service := NewService(db)
+	items := []CreateItemInput{{ProductVariantID: variant.ID, Quantity: 2}}
+
+	paid, err := service.Create(context.Background(), session.ID, &userID, nil, items)
+	require.NoError(t, err)
+	require.NoError(t, inventoryservice.ReserveOrderItems(db, paid, "timeline-paid", time.Now().Add(time.Hour)))
+	require.NoError(t, ApplyStatusTransition(db, &paid, StatusTransition{To: models.StatusPaid, Path: PathPayment}))
+	cancelled, err := service.Create(context.Background(), session.ID, &userID, nil, items)
+	require.NoError(t, err)
+	require.NoError(t, inventoryservice.ReserveOrderItems(db, cancelled, "timeline-cancelled", time.Now().Add(time.Hour)))
+	require.NoError(t, ApplyStatusTransition(db, &cancelled, StatusTransition{To: models.StatusCancelled, Path: PathAdmin}))
+
+	eventsOf := func(orderID uint, eventType string) []TimelineEvent {
+		t.Helper()
+		events, err := OrderTimeline(db, orderID)
+		require.NoError(t, err)
+		var matching []TimelineEvent
+		for _, event := range events {
+			if event.Type == eventType {
+				matching = append(matching, event)
+			}
+		}
+		return matching
+	}
+	commits := eventsOf(paid.ID, TimelineInventoryMovement)
+	require.Len(t, commits, 1)
+	assert.Equal(t, "ORDER_COMMIT -2 of SKU-TIMELINE-default", commits[0].Summary)
+	assert.Empty(t, eventsOf(paid.ID, TimelineReservationReleased))
+
+	assert.Empty(t, eventsOf(cancelled.ID, TimelineInventoryMovement))
+	releases := eventsOf(cancelled.ID, TimelineReservationReleased)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "Reservation of 2 SKU-TIMELINE-default released", releases[0].Summary)
+}
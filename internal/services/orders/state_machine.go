@@ -0,0 +1,243 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// Transition paths name the part of the system asking for a status change.
+// The transition table allows each change only from the paths that own it, so
+// for example an admin cannot mark an order refunded without refunding it.
+const (
+	PathAdmin       = "admin"
+	PathCustomer    = "customer"
+	PathCheckout    = "checkout"
+	PathPayment     = "payment"
+	PathFulfillment = "fulfillment"
+	PathRisk        = "risk"
+)
+
+var (
+	// ErrStatusTransitionNotAllowed rejects a change the table does not
+	// declare at all, such as DELIVERED back to PENDING.
+	ErrStatusTransitionNotAllowed = errors.New("order status transition is not allowed")
+	// ErrStatusTransitionWrongPath rejects a declared change requested from a
+	// path that does not own it.
+	ErrStatusTransitionWrongPath = errors.New("order status transition must go through another path")
+	// ErrOrderPaymentNotCaptured blocks shipping an order whose payment has
+	// not been captured in full.
+	ErrOrderPaymentNotCaptured = errors.New("order payment has not been captured")
+	// ErrPreorderNotReleased blocks picking or shipping pre-ordered units
+	// before their variant's release date.
+	ErrPreorderNotReleased = errors.New("pre-ordered items have not been released yet")
+)
+
+// statusTransitions maps each status to the statuses it may move to and the
+// paths allowed to make that move. Anything missing is rejected.
+var statusTransitions = map[string]map[string][]string{
+	models.StatusPending: {
+		models.StatusPaid:      {PathPayment, PathCheckout},
+		models.StatusFailed:    {PathPayment, PathRisk},
+		models.StatusCancelled: {PathAdmin, PathCustomer, PathPayment, PathRisk},
+	},
+	models.StatusPaid: {
+		models.StatusPartiallyShipped: {PathFulfillment},
+		models.StatusShipped:          {PathAdmin, PathFulfillment},
+		models.StatusDelivered:        {PathFulfillment},
+		models.StatusCancelled:        {PathAdmin, PathCustomer, PathPayment, PathRisk},
+		models.StatusRefunded:         {PathPayment},
+	},
+	models.StatusPartiallyShipped: {
+		models.StatusShipped:   {PathAdmin, PathFulfillment},
+		models.StatusDelivered: {PathFulfillment},
+		models.StatusRefunded:  {PathPayment},
+	},
+	models.StatusShipped: {
+		models.StatusDelivered: {PathAdmin, PathFulfillment},
+		models.StatusRefunded:  {PathPayment},
+	},
+	models.StatusDelivered: {
+		models.StatusRefunded: {PathPayment},
+	},
+	models.StatusFailed: {
+		models.StatusPaid:      {PathPayment},
+		models.StatusCancelled: {PathAdmin, PathPayment},
+	},
+	// A capture that lands after cancellation still has to be reflected so
+	// the money can be refunded.
+	models.StatusCancelled: {
+		models.StatusPaid: {PathPayment},
+	},
+}
+
+// StatusTransition is one requested status change. Path is checked against
+// the transition table; Source, Reason, Actor and CorrelationID are written
+// to the order's status history.
+type StatusTransition struct {
+	To            string
+	Path          string
+	Source        string
+	Reason        string
+	Actor         string
+	CorrelationID string
+}
+
+// StatusTransitionError reports a rejected status change. It unwraps to one
+// of the ErrStatusTransition* sentinels or the precondition that failed:
+// ErrOrderRiskReviewPending, ErrOrderPaymentNotCaptured or
+// ErrPreorderNotReleased.
+type StatusTransitionError struct {
+	From string
+	To   string
+	Path string
+	Err  error
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s to %s", e.Err, e.From, e.To)
+}
+
+func (e *StatusTransitionError) Unwrap() error { return e.Err }
+
+// AllowedTransitions lists the statuses an order in from may move to through
+// path, in a stable order.
+func AllowedTransitions(from, path string) []string {
+	allowed := []string{}
+	for to, paths := range statusTransitions[from] {
+		if slices.Contains(paths, path) {
+			allowed = append(allowed, to)
+		}
+	}
+	slices.Sort(allowed)
+	return allowed
+}
+
+// CheckStatusTransition validates a change against the transition table and
+// its preconditions without applying it.
+func CheckStatusTransition(tx *gorm.DB, order models.Order, to, path string) error {
+	if !models.IsValidOrderStatus(to) {
+		return ErrInvalidOrderStatus
+	}
+	paths, declared := statusTransitions[order.Status][to]
+	if !declared {
+		return &StatusTransitionError{From: order.Status, To: to, Path: path, Err: ErrStatusTransitionNotAllowed}
+	}
+	if !slices.Contains(paths, path) {
+		return &StatusTransitionError{From: order.Status, To: to, Path: path, Err: ErrStatusTransitionWrongPath}
+	}
+	if to != models.StatusPartiallyShipped && to != models.StatusShipped && to != models.StatusDelivered {
+		return nil
+	}
+	if order.RiskReviewStatus == models.RiskReviewPending {
+		return &StatusTransitionError{From: order.Status, To: to, Path: path, Err: ErrOrderRiskReviewPending}
+	}
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+		return err
+	}
+	// A partial shipment needs the units that left paid for; a complete one
+	// needs everything that was not cancelled.
+	shipping := func(item models.OrderItem) int { return item.Quantity - item.QuantityCancelled }
+	if to == models.StatusPartiallyShipped {
+		shipping = func(item models.OrderItem) int { return item.QuantityFulfilled }
+	}
+	unreleased, err := UnreleasedPreorderItems(tx, items, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if unreleased[item.ID] && shipping(item) > 0 {
+			return &StatusTransitionError{From: order.Status, To: to, Path: path, Err: ErrPreorderNotReleased}
+		}
+	}
+	captured, err := capturedAmount(tx, order.ID)
+	if err != nil {
+		return err
+	}
+	if captured < orderValueShare(order.Total, items, shipping) {
+		return &StatusTransitionError{From: order.Status, To: to, Path: path, Err: ErrOrderPaymentNotCaptured}
+	}
+	return nil
+}
+
+// ApplyStatusTransition moves an order to a new status, commits or returns
+// stock and opens or cancels its fulfillment order as the order enters or
+// leaves a stock-committed status, and records the change in the order's
+// status history. Asking for the current status is
+// a no-op.
+func ApplyStatusTransition(tx *gorm.DB, order *models.Order, transition StatusTransition) error {
+	if order == nil {
+		return fmt.Errorf("order is required")
+	}
+	newStatus := transition.To
+	if order.Status == newStatus {
+		return nil
+	}
+	if err := CheckStatusTransition(tx, *order, newStatus, transition.Path); err != nil {
+		return err
+	}
+
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+		return err
+	}
+
+	wasStockCommitted := models.IsStockCommittedOrderStatus(order.Status)
+	willCommitStock := models.IsStockCommittedOrderStatus(newStatus)
+
+	if willCommitStock && !wasStockCommitted {
+		consumed, err := inventoryservice.ConsumeReservationsForOrder(tx, order.ID, fmt.Sprintf("order-status:%d:%s", order.ID, newStatus))
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			if err := DeductStockForItems(tx, order.ID, items); err != nil {
+				return err
+			}
+		}
+		if err := openFulfillmentOrder(tx, order.ID, items); err != nil {
+			return err
+		}
+	} else if !willCommitStock && wasStockCommitted {
+		now := time.Now().UTC()
+		backordered, err := inventoryservice.ReleaseBackordersForOrder(tx, order.ID, now)
+		if err != nil {
+			return err
+		}
+		if err := ReplenishStockForItems(tx, order.ID, committedItems(unshippedItems(items), backordered)); err != nil {
+			return err
+		}
+		if err := cancelOpenFulfillmentOrders(tx, order.ID, now); err != nil {
+			return err
+		}
+		if err := cancelUnshippedQuantities(tx, items); err != nil {
+			return err
+		}
+	} else if !willCommitStock {
+		if err := inventoryservice.ReleaseReservationsForOrder(tx, order.ID, fmt.Sprintf("order-status:%d:%s", order.ID, newStatus)); err != nil {
+			return err
+		}
+	}
+
+	from := order.Status
+	order.Status = newStatus
+	if err := tx.Save(order).Error; err != nil {
+		return err
+	}
+	source := strings.TrimSpace(transition.Source)
+	if source == "" {
+		source = transition.Path
+	}
+	return tx.Create(&models.OrderStatusHistory{
+		OrderID: order.ID, FromStatus: from, ToStatus: newStatus, Reason: transition.Reason,
+		Source: source, Actor: transition.Actor, CorrelationID: transition.CorrelationID,
+	}).Error
+}
@@ -0,0 +1,64 @@
+package orders
+
+import (
+	"testing"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusTransitionsFollowTheDeclaredTable(t *testing.T) {
+	db := newOrdersTestDB(t)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+
+	delivered := models.Order{UserID: &userID, CheckoutSessionID: session.ID, Total: models.MoneyFromFloat(20), Status: models.StatusDelivered}
+	require.NoError(t, db.Create(&delivered).Error)
+	err := ApplyStatusTransition(db, &delivered, StatusTransition{To: models.StatusPending, Path: PathAdmin})
+	var transitionErr *StatusTransitionError
+	require.ErrorAs(t, err, &transitionErr)
+	assert.ErrorIs(t, err, ErrStatusTransitionNotAllowed)
+	assert.Equal(t, models.StatusDelivered, transitionErr.From)
+
+	err = ApplyStatusTransition(db, &delivered, StatusTransition{To: models.StatusRefunded, Path: PathAdmin})
+	assert.ErrorIs(t, err, ErrStatusTransitionWrongPath)
+	assert.Equal(t, models.StatusDelivered, delivered.Status)
+
+	assert.Equal(t, []string{models.StatusCancelled, models.StatusShipped}, AllowedTransitions(models.StatusPaid, PathAdmin))
+	assert.Empty(t, AllowedTransitions(models.StatusRefunded, PathPayment))
+}
+
+func TestShippingRequiresCapturedPaymentAndRecordsHistory(t *testing.T) {
+	db := newOrdersTestDB(t)
+	userID := uint(1)
+	session := seedOrderSession(t, db, &userID)
+	order := models.Order{UserID: &userID, CheckoutSessionID: session.ID, Total: models.MoneyFromFloat(20), Status: models.StatusPaid}
+	require.NoError(t, db.Create(&order).Error)
+	intent := models.PaymentIntent{
+		OrderID: order.ID, Provider: "mock", Status: models.PaymentIntentStatusAuthorized,
+		AuthorizedAmount: models.MoneyFromFloat(20), Currency: "USD",
+	}
+	require.NoError(t, db.Create(&intent).Error)
+
+	ship := StatusTransition{
+		To: models.StatusShipped, Path: PathAdmin, Reason: "handed_to_carrier",
+		Actor: "admin@example.com", CorrelationID: "corr-ship",
+	}
+	assert.ErrorIs(t, ApplyStatusTransition(db, &order, ship), ErrOrderPaymentNotCaptured)
+
+	require.NoError(t, db.Model(&intent).Update("captured_amount", models.MoneyFromFloat(20)).Error)
+	require.NoError(t, ApplyStatusTransition(db, &order, ship))
+	assert.Equal(t, models.StatusShipped, order.Status)
+
+	var history []models.OrderStatusHistory
+	require.NoError(t, db.Where("order_id = ?", order.ID).Find(&history).Error)
+	require.Len(t, history, 1)
+	assert.Equal(t, models.StatusPaid, history[0].FromStatus)
+	assert.Equal(t, models.StatusShipped, history[0].ToStatus)
+	assert.Equal(t, "handed_to_carrier", history[0].Reason)
+	assert.Equal(t, PathAdmin, history[0].Source)
+	assert.Equal(t, "admin@example.com", history[0].Actor)
+	assert.Equal(t, "corr-ship", history[0].CorrelationID)
+}
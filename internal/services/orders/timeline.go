@@ -0,0 +1,234 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	inventoryservice "ecommerce/internal/services/inventory"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// Timeline event types. Payment events are "payment." followed by the
+// lowercased transaction operation, e.g. payment.capture.
+const (
+	TimelineOrderPlaced         = "order.placed"
+	TimelineStatusChanged       = "order.status_changed"
+	TimelinePaymentPrefix       = "payment."
+	TimelineProviderOperation   = "provider.operation"
+	TimelineShipmentCreated     = "shipment.created"
+	TimelineShipmentTracking    = "shipment.tracking"
+	TimelineInventoryMovement   = "inventory.movement"
+	TimelineReservationReleased = "inventory.reservation_released"
+	TimelineWebhookReceived     = "webhook.received"
+	TimelineDiscountRedeemed    = "discount.redeemed"
+	TimelineComment             = "comment"
+)
+
+// TimelineEvent is one entry of an order's timeline. Source and SourceID
+// name the row it was read from.
+type TimelineEvent struct {
+	Type          string
+	OccurredAt    time.Time
+	Source        string
+	SourceID      uint
+	Actor         string
+	CorrelationID string
+	Summary       string
+	Details       map[string]string
+}
+
+// OrderTimeline merges everything recorded about an order into one list,
+// oldest first: status changes, payments and the provider calls behind them,
+// shipments and tracking updates, stock movements and released reservations,
+// webhooks, discount redemptions and staff notes, which show up as comments.
+func OrderTimeline(db *gorm.DB, orderID uint) ([]TimelineEvent, error) {
+	var order models.Order
+	if err := db.Preload("User").First(&order, orderID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	placedBy := "guest"
+	switch {
+	case order.User != nil:
+		placedBy = "user:" + order.User.Username
+	case order.GuestEmail != nil:
+		placedBy = "guest:" + *order.GuestEmail
+	}
+	events := []TimelineEvent{{
+		Type: TimelineOrderPlaced, OccurredAt: order.CreatedAt, Source: "orders", SourceID: order.ID, Actor: placedBy,
+		Summary: fmt.Sprintf("Order placed for %s", order.Total), Details: map[string]string{"total": order.Total.String()},
+	}}
+
+	var history []models.OrderStatusHistory
+	if err := db.Where("order_id = ?", orderID).Find(&history).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range history {
+		summary := fmt.Sprintf("%s → %s", row.FromStatus, row.ToStatus)
+		if row.Reason != "" {
+			summary += ": " + row.Reason
+		}
+		events = append(events, TimelineEvent{
+			Type: TimelineStatusChanged, OccurredAt: row.CreatedAt, Source: "order_status_histories", SourceID: row.ID,
+			Actor: row.Actor, CorrelationID: row.CorrelationID, Summary: summary,
+			Details: map[string]string{"from": row.FromStatus, "to": row.ToStatus, "source": row.Source},
+		})
+	}
+
+	var intents []models.PaymentIntent
+	if err := db.Preload("Transactions").Where("order_id = ?", orderID).Find(&intents).Error; err != nil {
+		return nil, err
+	}
+	intentIDs := make([]uint, 0, len(intents))
+	for _, intent := range intents {
+		intentIDs = append(intentIDs, intent.ID)
+		for _, txn := range intent.Transactions {
+			events = append(events, TimelineEvent{
+				Type: TimelinePaymentPrefix + strings.ToLower(txn.Operation), OccurredAt: txn.CreatedAt, Source: "payment_transactions", SourceID: txn.ID,
+				Actor: "provider:" + intent.Provider, CorrelationID: txn.IdempotencyKey,
+				Summary: fmt.Sprintf("%s of %s %s %s", strings.ToLower(txn.Operation), txn.Amount, intent.Currency, strings.ToLower(txn.Status)),
+				Details: map[string]string{"payment_intent_id": fmt.Sprint(intent.ID), "status": txn.Status, "amount": txn.Amount.String(), "provider_reference": txn.ProviderTxnID},
+			})
+		}
+	}
+
+	var shipments []models.Shipment
+	if err := db.Preload("TrackingEvents").Where("order_id = ?", orderID).Find(&shipments).Error; err != nil {
+		return nil, err
+	}
+	shipmentIDs := make([]uint, 0, len(shipments))
+	for _, shipment := range shipments {
+		shipmentIDs = append(shipmentIDs, shipment.ID)
+		events = append(events, TimelineEvent{
+			Type: TimelineShipmentCreated, OccurredAt: shipment.CreatedAt, Source: "shipments", SourceID: shipment.ID,
+			Actor: "provider:" + shipment.Provider, Summary: fmt.Sprintf("Shipment via %s", shipment.ServiceName),
+			Details: map[string]string{"status": shipment.Status, "tracking_number": shipment.TrackingNumber},
+		})
+		for _, tracking := range shipment.TrackingEvents {
+			summary := tracking.Status
+			if tracking.Description != "" {
+				summary += ": " + tracking.Description
+			}
+			events = append(events, TimelineEvent{
+				Type: TimelineShipmentTracking, OccurredAt: tracking.OccurredAt, Source: "tracking_events", SourceID: tracking.ID,
+				Actor: "provider:" + tracking.Provider, Summary: summary,
+				Details: map[string]string{"shipment_id": fmt.Sprint(shipment.ID), "status": tracking.Status, "location": tracking.Location},
+			})
+		}
+	}
+
+	var operations []models.ProviderOperation
+	if err := db.Where("(entity_type = ? AND entity_id = ?) OR (entity_type = ? AND entity_id IN ?) OR (entity_type = ? AND entity_id IN ?)",
+		"order", orderID, "payment_intent", intentIDs, "shipment", shipmentIDs).Find(&operations).Error; err != nil {
+		return nil, err
+	}
+	for _, operation := range operations {
+		details := map[string]string{"provider_type": operation.ProviderType, "status": operation.Status, "entity": fmt.Sprintf("%s:%d", operation.EntityType, operation.EntityID)}
+		if operation.LastError != "" {
+			details["last_error"] = operation.LastError
+		}
+		events = append(events, TimelineEvent{
+			Type: TimelineProviderOperation, OccurredAt: operation.CreatedAt, Source: "provider_operations", SourceID: operation.ID,
+			Actor: "provider:" + operation.ProviderID, CorrelationID: operation.CorrelationID,
+			Summary: fmt.Sprintf("%s %s %s", operation.ProviderType, operation.Operation, strings.ToLower(operation.Status)), Details: details,
+		})
+	}
+
+	// Stock committed for the order's reservations, including backorders
+	// allocated once stock arrived, is recorded against the reservation.
+	// Releases and expiries only return reserved units, so they show up as
+	// reservation events rather than movements.
+	var reservations []models.InventoryReservation
+	if err := db.Preload("InventoryItem.ProductVariant").Where("order_id = ?", orderID).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	reservationIDs := make([]uint, 0, len(reservations))
+	for _, reservation := range reservations {
+		reservationIDs = append(reservationIDs, reservation.ID)
+		releasedAt := reservation.ReleasedAt
+		if reservation.Status == models.InventoryReservationStatusExpired {
+			releasedAt = reservation.ExpiredAt
+		}
+		if releasedAt == nil {
+			continue
+		}
+		events = append(events, TimelineEvent{
+			Type: TimelineReservationReleased, OccurredAt: *releasedAt, Source: "inventory_reservations", SourceID: reservation.ID, Actor: "system",
+			Summary: fmt.Sprintf("Reservation of %d %s %s", reservation.Quantity, reservation.InventoryItem.ProductVariant.SKU, strings.ToLower(reservation.Status)),
+			Details: map[string]string{"inventory_item_id": fmt.Sprint(reservation.InventoryItemID), "stock_location_id": fmt.Sprint(reservation.StockLocationID), "status": reservation.Status},
+		})
+	}
+
+	var movements []models.InventoryMovement
+	if err := db.Preload("InventoryItem.ProductVariant").
+		Where("(reference_type = ? AND reference_id = ?) OR (reference_type = ? AND reference_id IN ?)",
+			inventoryservice.ReferenceTypeOrder, orderID, inventoryservice.ReferenceTypeReservation, reservationIDs).
+		Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	for _, movement := range movements {
+		actor := strings.ToLower(movement.ActorType)
+		if movement.ActorID != nil {
+			actor = fmt.Sprintf("%s:%d", actor, *movement.ActorID)
+		}
+		events = append(events, TimelineEvent{
+			Type: TimelineInventoryMovement, OccurredAt: movement.CreatedAt, Source: "inventory_movements", SourceID: movement.ID, Actor: actor,
+			Summary: fmt.Sprintf("%s %+d of %s", movement.MovementType, movement.QuantityDelta, movement.InventoryItem.ProductVariant.SKU),
+			Details: map[string]string{"inventory_item_id": fmt.Sprint(movement.InventoryItemID), "stock_location_id": fmt.Sprint(movement.StockLocationID), "reason": movement.ReasonCode},
+		})
+	}
+
+	var webhooks []models.WebhookEvent
+	if err := db.Where("order_id = ?", orderID).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		details := map[string]string{"provider_event_id": webhook.ProviderEventID}
+		if webhook.LastError != "" {
+			details["last_error"] = webhook.LastError
+		}
+		events = append(events, TimelineEvent{
+			Type: TimelineWebhookReceived, OccurredAt: webhook.ReceivedAt, Source: "webhook_events", SourceID: webhook.ID,
+			Actor: "provider:" + webhook.Provider, CorrelationID: fmt.Sprintf("webhook:%d", webhook.ID),
+			Summary: webhook.EventType, Details: details,
+		})
+	}
+
+	var redemptions []models.DiscountRedemption
+	if err := db.Preload("Campaign").Where("order_id = ?", orderID).Find(&redemptions).Error; err != nil {
+		return nil, err
+	}
+	for _, redemption := range redemptions {
+		campaign := fmt.Sprintf("campaign %d", redemption.CampaignID)
+		if redemption.Campaign != nil {
+			campaign = redemption.Campaign.Name
+		}
+		events = append(events, TimelineEvent{
+			Type: TimelineDiscountRedeemed, OccurredAt: redemption.AppliedAt, Source: "discount_redemptions", SourceID: redemption.ID, Actor: placedBy,
+			Summary: fmt.Sprintf("%s applied, %s off", campaign, redemption.AppliedAmount),
+			Details: map[string]string{"campaign_id": fmt.Sprint(redemption.CampaignID), "applied_amount": redemption.AppliedAmount.String()},
+		})
+	}
+
+	var notes []models.OrderNote
+	if err := db.Where("order_id = ?", orderID).Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		events = append(events, TimelineEvent{
+			Type: TimelineComment, OccurredAt: note.CreatedAt, Source: "order_notes", SourceID: note.ID, Actor: note.Author, Summary: note.Body,
+		})
+	}
+
+	// Events at the same instant keep the order they were gathered in, which
+	// puts the order's own changes before their side effects.
+	slices.SortStableFunc(events, func(a, b TimelineEvent) int { return a.OccurredAt.Compare(b.OccurredAt) })
+	return events, nil
+}
@@ -4,17 +4,23 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	orderservice "ecommerce/internal/services/orders"
 	"ecommerce/models"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// PrepareCapturePaymentIntent records a pending capture. capturable caps the
+// amount at what the order has shipped or packed so far when its fulfillment
+// is tracked; nil allows the whole authorization to be captured.
 func PrepareCapturePaymentIntent(
 	tx *gorm.DB,
 	intent *models.PaymentIntent,
 	amount *models.Money,
+	capturable *models.Money,
 	idempotencyKey string,
 	correlationID string,
 ) (models.PaymentTransaction, CaptureRequest, error) {
@@ -33,7 +39,10 @@ func PrepareCapturePaymentIntent(
 	if intent.Status != models.PaymentIntentStatusAuthorized && intent.Status != models.PaymentIntentStatusPartiallyCaptured {
 		return models.PaymentTransaction{}, CaptureRequest{}, ErrCaptureNotAllowed
 	}
-	remaining := intent.AuthorizedAmount - intent.CapturedAmount
+	remaining := OpenAuthorizationAmount(*intent)
+	if capturable != nil {
+		remaining = min(remaining, *capturable)
+	}
 	captureAmount, err := resolveLifecycleAmount(amount, remaining)
 	if err != nil {
 		return models.PaymentTransaction{}, CaptureRequest{}, err
@@ -75,7 +84,7 @@ func PrepareVoidPaymentIntent(
 	request := VoidRequest{
 		OrderID:          intent.OrderID,
 		IntentID:         intent.ID,
-		Amount:           intent.AuthorizedAmount,
+		Amount:           OpenAuthorizationAmount(*intent),
 		Currency:         intent.Currency,
 		Provider:         intent.Provider,
 		CorrelationID:    correlationID,
@@ -85,6 +94,38 @@ func PrepareVoidPaymentIntent(
 	return txn, request, err
 }
 
+// PrepareAuthorizationReduction records a pending void of part of an
+// intent's open authorization. Releasing all of it voids the intent.
+func PrepareAuthorizationReduction(
+	tx *gorm.DB,
+	intent *models.PaymentIntent,
+	amount models.Money,
+	idempotencyKey string,
+	correlationID string,
+) (models.PaymentTransaction, VoidRequest, error) {
+	if intent == nil {
+		return models.PaymentTransaction{}, VoidRequest{}, fmt.Errorf("payment intent is required")
+	}
+	request := VoidRequest{
+		OrderID: intent.OrderID, IntentID: intent.ID, Amount: amount, Currency: intent.Currency,
+		Provider: intent.Provider, CorrelationID: correlationID,
+		ProviderTxnIDRef: latestProviderTxnID(intent.Transactions, models.PaymentTransactionOperationAuthorize),
+	}
+	if existing, ok, err := existingLifecycleTransaction(intent, models.PaymentTransactionOperationVoid, idempotencyKey, &amount); err != nil {
+		return models.PaymentTransaction{}, VoidRequest{}, err
+	} else if ok {
+		return existing, request, nil
+	}
+	if intent.Status != models.PaymentIntentStatusAuthorized && intent.Status != models.PaymentIntentStatusPartiallyCaptured {
+		return models.PaymentTransaction{}, VoidRequest{}, ErrVoidNotAllowed
+	}
+	if amount <= 0 || amount > OpenAuthorizationAmount(*intent) {
+		return models.PaymentTransaction{}, VoidRequest{}, ErrAmountExceedsAvailable
+	}
+	txn, err := prepareLifecycleTransaction(tx, intent, models.PaymentTransactionOperationVoid, amount, idempotencyKey)
+	return txn, request, err
+}
+
 func PrepareRefundPaymentIntent(
 	tx *gorm.DB,
 	intent *models.PaymentIntent,
@@ -188,7 +229,7 @@ func PreparedAuthorizationRequest(intent models.PaymentIntent, transaction model
 		return AuthorizeRequest{}, err
 	}
 	return AuthorizeRequest{
-		OrderID: intent.OrderID, SnapshotID: snapshot.ID, Amount: snapshot.Total, Currency: snapshot.Currency,
+		OrderID: intent.OrderID, SnapshotID: snapshot.ID, Amount: intent.AuthorizedAmount, Currency: snapshot.Currency,
 		Provider: snapshot.PaymentProviderID, CorrelationID: correlationID, PaymentMethodDisplay: snapshot.PaymentMethodDisplay,
 		PaymentData: paymentData,
 	}, nil
@@ -210,8 +251,8 @@ func FinalizeCapturePaymentIntent(tx *gorm.DB, intentID, transactionID uint, pro
 		return models.PaymentIntent{}, models.PaymentTransaction{}, err
 	}
 	intent.CapturedAmount += txn.Amount
-	if intent.CapturedAmount >= intent.AuthorizedAmount {
-		intent.CapturedAmount = intent.AuthorizedAmount
+	if capturable := intent.AuthorizedAmount - voidedAmount(intent.Transactions); intent.CapturedAmount >= capturable {
+		intent.CapturedAmount = capturable
 		intent.Status = models.PaymentIntentStatusCaptured
 	} else {
 		intent.Status = models.PaymentIntentStatusPartiallyCaptured
@@ -244,6 +285,34 @@ func FinalizeVoidPaymentIntent(tx *gorm.DB, intentID, transactionID uint, provid
 	return intent, txn, nil
 }
 
+// FinalizeAuthorizationReduction applies a succeeded partial void. The
+// intent is voided once nothing is left of the authorization and is captured
+// once what was captured covers what is left.
+func FinalizeAuthorizationReduction(tx *gorm.DB, intentID, transactionID uint, providerResult ProviderOperationResult) (models.PaymentIntent, models.PaymentTransaction, error) {
+	intent, txn, alreadyFinalized, err := lockLifecycleTransaction(tx, intentID, transactionID, models.PaymentTransactionOperationVoid)
+	if err != nil || alreadyFinalized {
+		return intent, txn, err
+	}
+	if intent.Status != models.PaymentIntentStatusAuthorized && intent.Status != models.PaymentIntentStatusPartiallyCaptured {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, ErrVoidNotAllowed
+	}
+	if err := markLifecycleTransactionSucceeded(tx, &txn, providerResult); err != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	replaceTransaction(&intent.Transactions, txn)
+	switch open := OpenAuthorizationAmount(intent); {
+	case open <= 0 && intent.CapturedAmount > 0:
+		intent.Status = models.PaymentIntentStatusCaptured
+	case open <= 0:
+		intent.Status = models.PaymentIntentStatusVoided
+	}
+	intent.Version++
+	if err := tx.Save(&intent).Error; err != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	return intent, txn, nil
+}
+
 func FinalizeRefundPaymentIntent(tx *gorm.DB, intentID, transactionID uint, providerResult ProviderOperationResult) (models.PaymentIntent, models.PaymentTransaction, error) {
 	intent, txn, alreadyFinalized, err := lockLifecycleTransaction(tx, intentID, transactionID, models.PaymentTransactionOperationRefund)
 	if err != nil || alreadyFinalized {
@@ -266,6 +335,9 @@ func FinalizeRefundPaymentIntent(tx *gorm.DB, intentID, transactionID uint, prov
 	if err := tx.Save(&intent).Error; err != nil {
 		return models.PaymentIntent{}, models.PaymentTransaction{}, err
 	}
+	if err := orderservice.IssueRefundCreditNote(tx, intent.OrderID, txn, time.Now().UTC()); err != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
 	return intent, txn, nil
 }
 
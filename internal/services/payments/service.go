@@ -31,6 +31,7 @@ var (
 	ErrAmountMustBePositive        = errors.New("amount must be greater than zero")
 	ErrAmountExceedsAvailable      = errors.New("amount exceeds available balance")
 	ErrProviderTransactionNotFound = errors.New("provider transaction not found")
+	ErrNothingDue                  = errors.New("order has no balance left for the payment provider")
 )
 
 type SnapshotItemInput struct {
@@ -56,8 +57,10 @@ type CreateCheckoutSnapshotInput struct {
 	TaxData               map[string]string
 	PaymentMethodDisplay  string
 	ShippingAddressPretty string
-	Items                 []SnapshotItemInput
-	Now                   time.Time
+	// Attributes are the validated merchant checkout field values.
+	Attributes map[string]string
+	Items      []SnapshotItemInput
+	Now        time.Time
 }
 
 func CreateCheckoutSnapshot(db *gorm.DB, input CreateCheckoutSnapshotInput) (models.OrderCheckoutSnapshot, error) {
@@ -73,6 +76,10 @@ func CreateCheckoutSnapshot(db *gorm.DB, input CreateCheckoutSnapshotInput) (mod
 	if err != nil {
 		return models.OrderCheckoutSnapshot{}, err
 	}
+	attributesJSON, err := marshalStringMap(input.Attributes)
+	if err != nil {
+		return models.OrderCheckoutSnapshot{}, err
+	}
 
 	now := input.Now.UTC()
 	snapshot := models.OrderCheckoutSnapshot{
@@ -90,6 +97,7 @@ func CreateCheckoutSnapshot(db *gorm.DB, input CreateCheckoutSnapshotInput) (mod
 		TaxDataJSON:           taxDataJSON,
 		PaymentMethodDisplay:  input.PaymentMethodDisplay,
 		ShippingAddressPretty: input.ShippingAddressPretty,
+		AttributesJSON:        attributesJSON,
 		ExpiresAt:             now.Add(CheckoutSnapshotTTL),
 	}
 
@@ -266,13 +274,20 @@ func PrepareAuthorizedPaymentIntent(
 		Preload("Transactions", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at ASC, id ASC")
 		}).
-		Where("order_id = ?", orderID).
+		Where("order_id = ? AND provider NOT IN ?", orderID, []string{models.PaymentProviderGiftCard, models.PaymentProviderOffline}).
 		Order("id DESC").
 		First(&existing).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	amount, dueErr := AmountDue(tx, orderID, snapshot)
+	if dueErr != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, dueErr
+	}
 	if err == nil && models.IsActivePaymentIntentStatus(existing.Status) {
 		if existing.Provider == snapshot.PaymentProviderID &&
 			existing.SnapshotID == snapshot.ID &&
-			existing.AuthorizedAmount == snapshot.Total {
+			existing.AuthorizedAmount == amount {
 			if existing.Status == models.PaymentIntentStatusRequiresAction {
 				if txn, ok := authorizeTransaction(existing.Transactions); ok && txn.Status == models.PaymentTransactionStatusPending {
 					return existing, txn, nil
@@ -286,8 +301,8 @@ func PrepareAuthorizedPaymentIntent(
 		}
 		return models.PaymentIntent{}, models.PaymentTransaction{}, ErrActivePaymentIntentExists
 	}
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	if amount <= 0 {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, ErrNothingDue
 	}
 
 	intent := models.PaymentIntent{
@@ -295,7 +310,7 @@ func PrepareAuthorizedPaymentIntent(
 		SnapshotID:       snapshot.ID,
 		Provider:         snapshot.PaymentProviderID,
 		Status:           models.PaymentIntentStatusRequiresAction,
-		AuthorizedAmount: snapshot.Total,
+		AuthorizedAmount: amount,
 		CapturedAmount:   0,
 		Currency:         snapshot.Currency,
 		Version:          1,
@@ -309,7 +324,7 @@ func PrepareAuthorizedPaymentIntent(
 		Operation:           models.PaymentTransactionOperationAuthorize,
 		ProviderTxnID:       "",
 		IdempotencyKey:      idempotencyKey,
-		Amount:              snapshot.Total,
+		Amount:              amount,
 		Status:              models.PaymentTransactionStatusPending,
 		RawResponseRedacted: "",
 	}
@@ -320,6 +335,110 @@ func PrepareAuthorizedPaymentIntent(
 	return intent, txn, nil
 }
 
+// PrepareSupplementalAuthorization records a pending authorization of amount
+// on top of what the order already holds, as when an edit raises its total.
+// It uses the payment method of the order's checkout snapshot, and a retry
+// with the same idempotency key returns the intent it created.
+func PrepareSupplementalAuthorization(
+	tx *gorm.DB,
+	orderID uint,
+	snapshot models.OrderCheckoutSnapshot,
+	amount models.Money,
+	idempotencyKey string,
+) (models.PaymentIntent, models.PaymentTransaction, error) {
+	if amount <= 0 {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, ErrAmountMustBePositive
+	}
+	var existing models.PaymentTransaction
+	err := tx.Joins("JOIN payment_intents ON payment_intents.id = payment_transactions.payment_intent_id").
+		Where("payment_intents.order_id = ? AND payment_transactions.operation = ? AND payment_transactions.idempotency_key = ?",
+			orderID, models.PaymentTransactionOperationAuthorize, idempotencyKey).
+		First(&existing).Error
+	if err == nil {
+		intent, err := GetPaymentIntentForUpdate(tx, orderID, existing.PaymentIntentID)
+		return intent, existing, err
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	intent := models.PaymentIntent{
+		OrderID:          orderID,
+		SnapshotID:       snapshot.ID,
+		Provider:         snapshot.PaymentProviderID,
+		Status:           models.PaymentIntentStatusRequiresAction,
+		AuthorizedAmount: amount,
+		Currency:         snapshot.Currency,
+		Version:          1,
+	}
+	if err := tx.Create(&intent).Error; err != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	txn := models.PaymentTransaction{
+		PaymentIntentID: intent.ID,
+		Operation:       models.PaymentTransactionOperationAuthorize,
+		IdempotencyKey:  idempotencyKey,
+		Amount:          amount,
+		Status:          models.PaymentTransactionStatusPending,
+	}
+	if err := tx.Create(&txn).Error; err != nil {
+		return models.PaymentIntent{}, models.PaymentTransaction{}, err
+	}
+	intent.Transactions = []models.PaymentTransaction{txn}
+	return intent, txn, nil
+}
+
+// RefundableIntentForAmount locks the most recent provider intent on the
+// order with at least amount captured and not yet refunded. Gift card tenders
+// and offline payments are left out; an admin refunds those by hand.
+func RefundableIntentForAmount(tx *gorm.DB, orderID uint, amount models.Money) (models.PaymentIntent, error) {
+	var intents []models.PaymentIntent
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Transactions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		Where("order_id = ? AND provider NOT IN ? AND captured_amount > 0", orderID, []string{models.PaymentProviderGiftCard, models.PaymentProviderOffline}).
+		Order("id DESC").
+		Find(&intents).Error; err != nil {
+		return models.PaymentIntent{}, err
+	}
+	for _, intent := range intents {
+		if intent.CapturedAmount-refundedAmount(intent.Transactions) >= amount {
+			return intent, nil
+		}
+	}
+	return models.PaymentIntent{}, ErrAmountExceedsAvailable
+}
+
+// ReducibleIntentForAmount locks the most recent provider intent on the
+// order whose open authorization, what is neither captured nor released,
+// covers amount. Lowering what an uncaptured order owes releases part of the
+// authorization instead of refunding money that was never taken.
+func ReducibleIntentForAmount(tx *gorm.DB, orderID uint, amount models.Money) (models.PaymentIntent, error) {
+	var intents []models.PaymentIntent
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Transactions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC, id ASC")
+		}).
+		Where("order_id = ? AND provider NOT IN ? AND status IN ?", orderID,
+			[]string{models.PaymentProviderGiftCard, models.PaymentProviderOffline},
+			[]string{models.PaymentIntentStatusAuthorized, models.PaymentIntentStatusPartiallyCaptured}).
+		Order("id DESC").
+		Find(&intents).Error; err != nil {
+		return models.PaymentIntent{}, err
+	}
+	for _, intent := range intents {
+		if OpenAuthorizationAmount(intent) >= amount {
+			return intent, nil
+		}
+	}
+	return models.PaymentIntent{}, ErrAmountExceedsAvailable
+}
+
+// OpenAuthorizationAmount is what can still be captured on an intent.
+func OpenAuthorizationAmount(intent models.PaymentIntent) models.Money {
+	return intent.AuthorizedAmount - intent.CapturedAmount - voidedAmount(intent.Transactions)
+}
+
 func ApplyAuthorizedCheckoutState(
 	tx *gorm.DB,
 	order *models.Order,
@@ -338,6 +457,11 @@ func ApplyAuthorizedCheckoutState(
 	order.Total = snapshot.Total
 	order.PaymentMethodDisplay = snapshot.PaymentMethodDisplay
 	order.ShippingAddressPretty = snapshot.ShippingAddressPretty
+	// The quoted attributes are what the shopper confirmed, so they win over
+	// anything sent when the order was opened.
+	if attributes := strings.TrimSpace(snapshot.AttributesJSON); attributes != "" && attributes != "{}" {
+		order.AttributesJSON = attributes
+	}
 	if err := tx.Save(order).Error; err != nil {
 		return err
 	}
@@ -356,6 +480,16 @@ func ApplyAuthorizedCheckoutState(
 	if err := checkoutservice.ClearOrderedItemsFromCart(tx, order.CheckoutSessionID, order.Items); err != nil {
 		return err
 	}
+	var session models.CheckoutSession
+	if err := tx.Select("id", "kind").Where("id = ?", order.CheckoutSessionID).Limit(1).Find(&session).Error; err != nil {
+		return err
+	}
+	// Paying through a draft order's payment link completes the draft.
+	if session.Kind == models.CheckoutSessionKindDraftOrder {
+		if err := orderservice.CompleteDraftOrderForSession(tx, session.ID, order.ID, snapshot.PaymentProviderID, time.Now().UTC()); err != nil {
+			return err
+		}
+	}
 	return tx.Model(&models.CheckoutSession{}).
 		Where("id = ?", order.CheckoutSessionID).
 		Updates(map[string]any{
@@ -364,6 +498,91 @@ func ApplyAuthorizedCheckoutState(
 		}).Error
 }
 
+// ApplyPrepaidCheckoutState completes checkout for an order whose total is
+// already captured by gift card tenders, so no provider authorization follows.
+func ApplyPrepaidCheckoutState(
+	tx *gorm.DB,
+	order *models.Order,
+	snapshot models.OrderCheckoutSnapshot,
+	correlationID string,
+) error {
+	if err := ApplyAuthorizedCheckoutState(tx, order, snapshot, correlationID); err != nil {
+		return err
+	}
+	return orderservice.ApplyStatusTransition(tx, order, orderservice.StatusTransition{
+		To: models.StatusPaid, Path: orderservice.PathCheckout, Source: "checkout",
+		Reason: "payment_captured", Actor: "customer", CorrelationID: correlationID,
+	})
+}
+
+// OfflinePaymentInput describes a payment staff took outside checkout, such
+// as cash or a bank transfer.
+type OfflinePaymentInput struct {
+	Method        string
+	Reference     string
+	Actor         string
+	CorrelationID string
+}
+
+// MarkDraftOrderPaid places a draft order as paid by an offline payment. The
+// order gets a checkout snapshot of the draft and a captured intent from the
+// offline provider for its total, so capture checks, refunds and fulfillment
+// treat it like any paid order, and moves to PAID.
+func MarkDraftOrderPaid(tx *gorm.DB, draftID uint, input OfflinePaymentInput, now time.Time) (models.DraftOrder, models.Order, error) {
+	method := strings.TrimSpace(input.Method)
+	if method == "" {
+		return models.DraftOrder{}, models.Order{}, fmt.Errorf("%w: a payment method is required", orderservice.ErrInvalidDraftOrder)
+	}
+	reference := strings.TrimSpace(input.Reference)
+	draft, order, err := orderservice.PlaceDraftOrder(tx, draftID, "Offline payment: "+method, now)
+	if err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	shippingData, err := unmarshalStringMap(draft.ShippingDataJSON)
+	if err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	items := make([]SnapshotItemInput, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, SnapshotItemInput{ProductVariantID: item.ProductVariantID, VariantSKU: item.VariantSKU, VariantTitle: item.VariantTitle, Quantity: item.Quantity, Price: item.Price})
+	}
+	snapshot, err := CreateCheckoutSnapshot(tx, CreateCheckoutSnapshotInput{
+		CheckoutSessionID: order.CheckoutSessionID, Currency: draft.Currency, Subtotal: draft.Subtotal, ShippingAmount: draft.ShippingAmount,
+		TaxAmount: draft.TaxAmount, Total: draft.Total, PaymentProviderID: models.PaymentProviderOffline, ShippingProviderID: draft.ShippingProviderID,
+		ShippingData: shippingData, PaymentMethodDisplay: order.PaymentMethodDisplay, ShippingAddressPretty: order.ShippingAddressPretty,
+		Items: items, Now: now,
+	})
+	if err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	if err := BindSnapshotToOrder(tx, &snapshot, order.ID, now); err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	intent := models.PaymentIntent{
+		OrderID: order.ID, SnapshotID: snapshot.ID, Provider: models.PaymentProviderOffline, Status: models.PaymentIntentStatusCaptured,
+		AuthorizedAmount: draft.Total, CapturedAmount: draft.Total, Currency: draft.Currency, Version: 1,
+	}
+	if err := tx.Create(&intent).Error; err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	if err := tx.Create(&models.PaymentTransaction{
+		PaymentIntentID: intent.ID, Operation: models.PaymentTransactionOperationCapture, ProviderTxnID: reference,
+		IdempotencyKey: fmt.Sprintf("draft-order:%d", draft.ID), Amount: draft.Total, Status: models.PaymentTransactionStatusSucceeded,
+	}).Error; err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	if err := orderservice.ApplyStatusTransition(tx, &order, orderservice.StatusTransition{
+		To: models.StatusPaid, Path: orderservice.PathPayment, Source: "admin",
+		Reason: "offline_payment", Actor: input.Actor, CorrelationID: input.CorrelationID,
+	}); err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	if err := orderservice.CompleteDraftOrder(tx, draft.ID, order.CheckoutSessionID, order.ID, method, reference, now); err != nil {
+		return models.DraftOrder{}, models.Order{}, err
+	}
+	return draft, order, nil
+}
+
 func GetPaymentIntentForUpdate(tx *gorm.DB, orderID, intentID uint) (models.PaymentIntent, error) {
 	var intent models.PaymentIntent
 	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
@@ -398,6 +617,56 @@ func RefundedAmount(intent models.PaymentIntent) models.Money {
 	return refundedAmount(intent.Transactions)
 }
 
+// GiftCardTenderedAmount is the net amount gift card intents currently cover
+// for an order's checkout snapshot.
+func GiftCardTenderedAmount(tx *gorm.DB, orderID, snapshotID uint) (models.Money, error) {
+	var intents []models.PaymentIntent
+	if err := tx.Preload("Transactions").
+		Where("order_id = ? AND snapshot_id = ? AND provider = ?", orderID, snapshotID, models.PaymentProviderGiftCard).
+		Find(&intents).Error; err != nil {
+		return 0, err
+	}
+	var tendered models.Money
+	for _, intent := range intents {
+		tendered += intent.CapturedAmount - refundedAmount(intent.Transactions)
+	}
+	return tendered, nil
+}
+
+// AmountDue is what remains of the snapshot total for the payment provider
+// once gift card tenders are taken off.
+func AmountDue(tx *gorm.DB, orderID uint, snapshot models.OrderCheckoutSnapshot) (models.Money, error) {
+	tendered, err := GiftCardTenderedAmount(tx, orderID, snapshot.ID)
+	if err != nil {
+		return 0, err
+	}
+	due := snapshot.Total - tendered
+	if due < 0 {
+		due = 0
+	}
+	return due, nil
+}
+
+// OrderFullyRefunded reports whether every captured intent on the order,
+// provider and gift card tenders alike, has been refunded in full.
+func OrderFullyRefunded(tx *gorm.DB, orderID uint) (bool, error) {
+	intents, err := GetOrderPaymentLedger(tx, orderID)
+	if err != nil {
+		return false, err
+	}
+	captured := false
+	for _, intent := range intents {
+		if intent.CapturedAmount <= 0 {
+			continue
+		}
+		captured = true
+		if refundedAmount(intent.Transactions) < intent.CapturedAmount {
+			return false, nil
+		}
+	}
+	return captured, nil
+}
+
 func ApplyWebhookPaymentEvent(
 	tx *gorm.DB,
 	event VerifiedWebhookEvent,
@@ -439,6 +708,8 @@ func ApplyWebhookPaymentEvent(
 		return models.PaymentTransaction{}, models.PaymentIntent{}, models.Order{}, err
 	}
 
+	refundSettled := txn.Operation == models.PaymentTransactionOperationRefund &&
+		txn.Status != targetTxnStatus && targetTxnStatus == models.PaymentTransactionStatusSucceeded
 	if txn.Status != targetTxnStatus {
 		txn.Status = targetTxnStatus
 		if err := tx.Save(&txn).Error; err != nil {
@@ -446,6 +717,13 @@ func ApplyWebhookPaymentEvent(
 		}
 	}
 	replaceTransaction(&intent.Transactions, txn)
+	// A refund the provider settles asynchronously is credited when its
+	// webhook confirms it, as FinalizeRefundPaymentIntent does for the rest.
+	if refundSettled {
+		if err := orderservice.IssueRefundCreditNote(tx, order.ID, txn, time.Now().UTC()); err != nil {
+			return models.PaymentTransaction{}, models.PaymentIntent{}, models.Order{}, err
+		}
+	}
 
 	capturedAmount, nextStatus := deriveWebhookIntentState(intent)
 	if intent.CapturedAmount != capturedAmount || intent.Status != nextStatus {
@@ -498,14 +776,24 @@ func resolveLifecycleAmount(requested *models.Money, available models.Money) (mo
 }
 
 func refundedAmount(transactions []models.PaymentTransaction) models.Money {
-	var refunded models.Money
+	return succeededAmount(transactions, models.PaymentTransactionOperationRefund)
+}
+
+// voidedAmount is how much of the authorization has been released. A void
+// for less than the open authorization only reduces it; the intent stays
+// authorized for the rest.
+func voidedAmount(transactions []models.PaymentTransaction) models.Money {
+	return succeededAmount(transactions, models.PaymentTransactionOperationVoid)
+}
+
+func succeededAmount(transactions []models.PaymentTransaction, operation string) models.Money {
+	var total models.Money
 	for _, txn := range transactions {
-		if txn.Operation == models.PaymentTransactionOperationRefund &&
-			txn.Status == models.PaymentTransactionStatusSucceeded {
-			refunded += txn.Amount
+		if txn.Operation == operation && txn.Status == models.PaymentTransactionStatusSucceeded {
+			total += txn.Amount
 		}
 	}
-	return refunded
+	return total
 }
 
 func latestProviderTxnID(transactions []models.PaymentTransaction, operation string) string {
@@ -651,9 +939,9 @@ func deriveWebhookIntentState(intent models.PaymentIntent) (models.Money, string
 	var (
 		capturedAmount     models.Money
 		refunded           models.Money
+		voided             models.Money
 		authorizeSucceeded bool
 		authorizeFailed    bool
-		voidSucceeded      bool
 	)
 
 	for _, txn := range intent.Transactions {
@@ -675,7 +963,7 @@ func deriveWebhookIntentState(intent models.PaymentIntent) (models.Money, string
 			}
 		case models.PaymentTransactionOperationVoid:
 			if txn.Status == models.PaymentTransactionStatusSucceeded {
-				voidSucceeded = true
+				voided += txn.Amount
 			}
 		}
 	}
@@ -687,11 +975,11 @@ func deriveWebhookIntentState(intent models.PaymentIntent) (models.Money, string
 	switch {
 	case capturedAmount > 0 && refunded >= capturedAmount:
 		return capturedAmount, models.PaymentIntentStatusRefunded
-	case capturedAmount >= intent.AuthorizedAmount && intent.AuthorizedAmount > 0:
+	case capturedAmount >= intent.AuthorizedAmount-voided && intent.AuthorizedAmount > voided:
 		return capturedAmount, models.PaymentIntentStatusCaptured
 	case capturedAmount > 0:
 		return capturedAmount, models.PaymentIntentStatusPartiallyCaptured
-	case voidSucceeded:
+	case voided >= intent.AuthorizedAmount:
 		return 0, models.PaymentIntentStatusVoided
 	case authorizeSucceeded:
 		return 0, models.PaymentIntentStatusAuthorized
@@ -721,6 +1009,7 @@ func applyWebhookOrderStatus(
 	switch intent.Status {
 	case models.PaymentIntentStatusCaptured, models.PaymentIntentStatusPartiallyCaptured:
 		if order.Status != models.StatusPaid &&
+			order.Status != models.StatusPartiallyShipped &&
 			order.Status != models.StatusShipped &&
 			order.Status != models.StatusDelivered &&
 			order.Status != models.StatusRefunded {
@@ -748,20 +1037,19 @@ func applyWebhookOrderStatus(
 		return nil
 	}
 
-	fromStatus := order.Status
-	if err := orderservice.ApplyStatusTransition(tx, order, targetStatus); err != nil {
-		return err
+	// Providers report what happened to the money, which can arrive after
+	// the order has moved on, for example a void on a shipped order. Such
+	// reports are kept on the intent but leave the order status alone, so
+	// the webhook is not retried forever.
+	err := orderservice.ApplyStatusTransition(tx, order, orderservice.StatusTransition{
+		To: targetStatus, Path: orderservice.PathPayment, Source: "webhook", Reason: reason,
+		Actor: "provider:" + strings.TrimSpace(provider), CorrelationID: correlationID,
+	})
+	var rejected *orderservice.StatusTransitionError
+	if errors.As(err, &rejected) {
+		return nil
 	}
-	return AppendOrderStatusHistory(
-		tx,
-		order.ID,
-		fromStatus,
-		order.Status,
-		reason,
-		"webhook",
-		"provider:"+strings.TrimSpace(provider),
-		correlationID,
-	)
+	return err
 }
 
 func unmarshalStringMap(value string) (map[string]string, error) {
@@ -73,6 +73,11 @@ func newPaymentRecoveryDB(t *testing.T) *gorm.DB {
 		&models.ProviderOperationAttempt{},
 		&models.ProviderReconciliationCase{},
 		&models.ProviderCallAudit{},
+		&models.Order{},
+		&models.OrderItem{},
+		&models.OrderCheckoutSnapshot{},
+		&models.OrderDocument{},
+		&models.LegalEntity{},
 	))
 	return db
 }
@@ -235,8 +240,10 @@ func TestPaymentVoidFinalizationEscalatesToReconciliation(t *testing.T) {
 
 func seedRecoveryPaymentIntent(t *testing.T, db *gorm.DB, status, operation string) (models.PaymentIntent, models.PaymentTransaction) {
 	t.Helper()
+	order := models.Order{Status: models.StatusPaid, Total: models.MoneyFromFloat(10)}
+	require.NoError(t, db.Create(&order).Error)
 	intent := models.PaymentIntent{
-		OrderID: 1, SnapshotID: 1, Provider: "test", Status: status,
+		OrderID: order.ID, SnapshotID: 1, Provider: "test", Status: status,
 		AuthorizedAmount: models.MoneyFromFloat(10), Currency: "USD", Version: 1,
 	}
 	require.NoError(t, db.Create(&intent).Error)
@@ -237,7 +237,7 @@ func (s *ReconciliationService) discoverTargets(ctx context.Context) ([]Reconcil
 	}
 
 	var paymentProviders []string
-	if err := s.db.WithContext(ctx).Model(&models.PaymentIntent{}).Distinct().Pluck("provider", &paymentProviders).Error; err != nil {
+	if err := s.db.WithContext(ctx).Model(&models.PaymentIntent{}).Where("provider NOT IN ?", []string{models.PaymentProviderGiftCard, models.PaymentProviderOffline}).Distinct().Pluck("provider", &paymentProviders).Error; err != nil {
 		return nil, err
 	}
 	for _, providerID := range paymentProviders {
@@ -0,0 +1,42 @@
+package risk
+
+import (
+	"context"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// Service provides context-first order screening and review APIs.
+type Service struct {
+	db      *gorm.DB
+	scorers ScorerRegistry
+}
+
+func NewService(db *gorm.DB, scorers ScorerRegistry) *Service {
+	return &Service{db: db, scorers: scorers}
+}
+
+func (s *Service) Assess(ctx context.Context, input AssessInput, now time.Time) (Assessment, error) {
+	return Assess(s.db.WithContext(ctx), s.scorers, input, now)
+}
+func (s *Service) Settings(ctx context.Context) (models.RiskSettings, error) {
+	return GetSettings(s.db.WithContext(ctx))
+}
+func (s *Service) UpdateSettings(ctx context.Context, input SettingsInput) (models.RiskSettings, error) {
+	return UpdateSettings(s.db.WithContext(ctx), input)
+}
+func (s *Service) ListReviews(ctx context.Context, input ReviewListInput) ([]models.Order, int64, error) {
+	return ListReviews(s.db.WithContext(ctx), input)
+}
+func (s *Service) ListAssessments(ctx context.Context, orderID uint) ([]models.OrderRiskAssessment, error) {
+	return ListAssessments(s.db.WithContext(ctx), orderID)
+}
+func (s *Service) Approve(ctx context.Context, orderID uint, input ReviewInput, now time.Time) (models.Order, error) {
+	return Approve(s.db.WithContext(ctx), orderID, input, now)
+}
+func (s *Service) Reject(ctx context.Context, orderID uint, input ReviewInput, now time.Time) (models.Order, error) {
+	return Reject(s.db.WithContext(ctx), orderID, input, now)
+}
@@ -0,0 +1,108 @@
+package risk
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	orderservice "ecommerce/internal/services/orders"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewListInput struct {
+	// Status filters on the review state and defaults to PENDING.
+	Status string
+	Limit  int
+	Offset int
+}
+
+type ReviewInput struct {
+	Actor         string
+	Note          string
+	CorrelationID string
+}
+
+// ListReviews returns orders that screening held for review, oldest first so
+// the queue is worked in arrival order.
+func ListReviews(db *gorm.DB, input ReviewListInput) ([]models.Order, int64, error) {
+	status := strings.ToUpper(strings.TrimSpace(input.Status))
+	if status == "" {
+		status = models.RiskReviewPending
+	}
+	switch status {
+	case models.RiskReviewPending, models.RiskReviewApproved, models.RiskReviewRejected:
+	default:
+		return nil, 0, ErrInvalidReview
+	}
+	query := db.Model(&models.Order{}).Where("risk_review_status = ?", status)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if input.Limit <= 0 {
+		input.Limit = 20
+	}
+	var orders []models.Order
+	if err := query.Preload("Items").Order("created_at ASC").Order("id ASC").Limit(input.Limit).Offset(input.Offset).Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// ListAssessments returns every screening run for an order, newest first.
+func ListAssessments(db *gorm.DB, orderID uint) ([]models.OrderRiskAssessment, error) {
+	var assessments []models.OrderRiskAssessment
+	err := db.Where("order_id = ?", orderID).Order("created_at DESC").Order("id DESC").Find(&assessments).Error
+	return assessments, err
+}
+
+// Approve releases a held order for fulfillment and capture.
+func Approve(db *gorm.DB, orderID uint, input ReviewInput, now time.Time) (models.Order, error) {
+	return decide(db, orderID, models.RiskReviewApproved, input, now)
+}
+
+// Reject cancels a held order. Any authorization on it is left for the admin
+// to void through the payment lifecycle endpoints.
+func Reject(db *gorm.DB, orderID uint, input ReviewInput, now time.Time) (models.Order, error) {
+	return decide(db, orderID, models.RiskReviewRejected, input, now)
+}
+
+func decide(db *gorm.DB, orderID uint, decision string, input ReviewInput, now time.Time) (models.Order, error) {
+	actor := strings.TrimSpace(input.Actor)
+	if actor == "" {
+		actor = "admin"
+	}
+	var order models.Order
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+			return err
+		}
+		if order.RiskReviewStatus != models.RiskReviewPending {
+			return ErrReviewNotPending
+		}
+		order.RiskReviewStatus = decision
+		order.RiskReviewedBy = actor
+		order.RiskReviewedAt = &now
+		if decision == models.RiskReviewApproved || !models.IsUserCancelableOrderStatus(order.Status) {
+			return tx.Save(&order).Error
+		}
+		reason := "risk_review_rejected"
+		if note := strings.TrimSpace(input.Note); note != "" {
+			reason += ": " + note
+		}
+		return orderservice.ApplyStatusTransition(tx, &order, orderservice.StatusTransition{
+			To: models.StatusCancelled, Path: orderservice.PathRisk, Source: "admin", Reason: reason,
+			Actor: actor, CorrelationID: input.CorrelationID,
+		})
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Order{}, orderservice.ErrOrderNotFound
+	}
+	if err != nil {
+		return models.Order{}, err
+	}
+	return order, nil
+}
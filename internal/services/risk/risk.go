@@ -0,0 +1,284 @@
+// Package risk screens checkout orders for fraud before payment authorization.
+// Built-in rules and an optional external scorer each contribute weighted
+// signals; the summed score is mapped to allow, hold for review, or reject.
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	orderservice "ecommerce/internal/services/orders"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+const maxScore = 100
+
+var (
+	ErrUnknownScorer       = errors.New("unknown risk scorer")
+	ErrInvalidRiskSettings = errors.New("invalid risk settings")
+	ErrReviewNotPending    = errors.New("order is not awaiting risk review")
+	ErrInvalidReview       = errors.New("invalid risk review decision")
+)
+
+// Signal is one reason contributing to a risk score.
+type Signal struct {
+	Code    string `json:"code"`
+	Score   int    `json:"score"`
+	Message string `json:"message"`
+}
+
+type Address struct {
+	Country    string `json:"country,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+}
+
+// Input is what scorers see about an order. It is also the payload sent to
+// external scorer plugins, so it carries no raw card data beyond the BIN.
+type Input struct {
+	OrderID           uint    `json:"order_id"`
+	UserID            *uint   `json:"user_id,omitempty"`
+	Email             string  `json:"email"`
+	ClientIP          string  `json:"client_ip"`
+	UserAgent         string  `json:"user_agent"`
+	CardBIN           string  `json:"card_bin,omitempty"`
+	Currency          string  `json:"currency"`
+	Total             float64 `json:"total"`
+	ItemCount         int     `json:"item_count"`
+	PaymentProviderID string  `json:"payment_provider_id"`
+	Billing           Address `json:"billing"`
+	Shipping          Address `json:"shipping"`
+}
+
+// Scorer contributes signals for an order. Implementations must not mutate
+// state; Assess persists the combined result.
+type Scorer interface {
+	Score(ctx context.Context, input Input) ([]Signal, error)
+}
+
+type ScorerRegistry interface {
+	Scorer(id string) (Scorer, error)
+}
+
+// AssessInput identifies the order being paid and the request it came from.
+type AssessInput struct {
+	Order         models.Order
+	Snapshot      models.OrderCheckoutSnapshot
+	ClientIP      string
+	UserAgent     string
+	CorrelationID string
+}
+
+// Assessment is the outcome of one screening run. Skipped is true when
+// screening is disabled, in which case nothing was recorded.
+type Assessment struct {
+	Record  models.OrderRiskAssessment
+	Signals []Signal
+	Skipped bool
+}
+
+func (a Assessment) Action() string {
+	if a.Skipped {
+		return models.RiskActionAllow
+	}
+	return a.Record.Action
+}
+
+// Assess scores the order, records the assessment, and applies its action:
+// held orders enter the review queue and rejected orders are marked FAILED so
+// they cannot be paid.
+func Assess(db *gorm.DB, scorers ScorerRegistry, input AssessInput, now time.Time) (Assessment, error) {
+	settings, err := GetSettings(db)
+	if err != nil {
+		return Assessment{}, err
+	}
+	if !settings.Enabled {
+		return Assessment{Skipped: true}, nil
+	}
+	scoring, err := buildInput(db, input)
+	if err != nil {
+		return Assessment{}, err
+	}
+	signals, err := builtInSignals(db, settings, scoring, now)
+	if err != nil {
+		return Assessment{}, err
+	}
+	if id := strings.TrimSpace(settings.ExternalScorerID); id != "" {
+		signals = append(signals, externalSignals(db.Statement.Context, scorers, id, scoring)...)
+	}
+
+	total := 0
+	for index := range signals {
+		signals[index].Score = clampScore(signals[index].Score)
+		total += signals[index].Score
+	}
+	total = clampScore(total)
+	action := models.RiskActionAllow
+	switch {
+	case total >= settings.RejectScore:
+		action = models.RiskActionReject
+	case total >= settings.ReviewScore:
+		action = models.RiskActionReview
+	}
+	reasons, err := json.Marshal(signals)
+	if err != nil {
+		return Assessment{}, err
+	}
+	record := models.OrderRiskAssessment{
+		CreatedAt: now, OrderID: input.Order.ID, Email: scoring.Email, ClientIP: scoring.ClientIP, CardBIN: scoring.CardBIN,
+		Score: total, Action: action, ReasonsJSON: string(reasons), ScorerID: strings.TrimSpace(settings.ExternalScorerID),
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+		var order models.Order
+		if err := tx.First(&order, input.Order.ID).Error; err != nil {
+			return err
+		}
+		order.RiskScore = &total
+		order.RiskAction = action
+		order.RiskReasonsJSON = string(reasons)
+		order.RiskReviewStatus = ""
+		order.RiskReviewedBy = ""
+		order.RiskReviewedAt = nil
+		if action == models.RiskActionReview {
+			order.RiskReviewStatus = models.RiskReviewPending
+		}
+		if action != models.RiskActionReject || order.Status == models.StatusFailed {
+			return tx.Save(&order).Error
+		}
+		return orderservice.ApplyStatusTransition(tx, &order, orderservice.StatusTransition{
+			To: models.StatusFailed, Path: orderservice.PathRisk, Source: "risk", Reason: "risk_rejected",
+			Actor: "system", CorrelationID: input.CorrelationID,
+		})
+	})
+	if err != nil {
+		return Assessment{}, err
+	}
+	return Assessment{Record: record, Signals: signals}, nil
+}
+
+// Reasons decodes the signals stored on an assessment or order.
+func Reasons(encoded string) []Signal {
+	signals := []Signal{}
+	_ = json.Unmarshal([]byte(encoded), &signals)
+	return signals
+}
+
+func buildInput(db *gorm.DB, input AssessInput) (Input, error) {
+	order, snapshot := input.Order, input.Snapshot
+	scoring := Input{
+		OrderID: order.ID, UserID: order.UserID, ClientIP: strings.TrimSpace(input.ClientIP), UserAgent: strings.TrimSpace(input.UserAgent),
+		Currency: snapshot.Currency, Total: snapshot.Total.Float64(), PaymentProviderID: snapshot.PaymentProviderID,
+	}
+	for _, item := range snapshot.Items {
+		scoring.ItemCount += item.Quantity
+	}
+	if order.GuestEmail != nil {
+		scoring.Email = *order.GuestEmail
+	}
+	if order.UserID != nil {
+		var user models.User
+		if err := db.Select("email").First(&user, *order.UserID).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return Input{}, err
+		}
+		if user.Email != "" {
+			scoring.Email = user.Email
+		}
+	}
+	scoring.Email = strings.ToLower(strings.TrimSpace(scoring.Email))
+
+	payment := decodeData(snapshot.PaymentDataJSON)
+	scoring.CardBIN = cardBIN(payment)
+	scoring.Billing = Address{
+		Country: normalizeRegion(payment["billing_country"]), State: normalizeRegion(payment["billing_state"]),
+		PostalCode: normalizeRegion(payment["billing_postal_code"]),
+	}
+	shipping := decodeData(snapshot.ShippingDataJSON)
+	scoring.Shipping = Address{
+		Country: normalizeRegion(shipping["country"]), State: normalizeRegion(shipping["state"]), PostalCode: normalizeRegion(shipping["postal_code"]),
+	}
+	return scoring, nil
+}
+
+func decodeData(encoded string) map[string]string {
+	values := map[string]string{}
+	if strings.TrimSpace(encoded) == "" {
+		return values
+	}
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(encoded), &raw); err != nil {
+		return values
+	}
+	for key, value := range raw {
+		values[key] = strings.TrimSpace(fmt.Sprint(value))
+	}
+	return values
+}
+
+// cardBIN keeps only the issuer prefix. A provider may pass card_bin directly;
+// otherwise the first six digits of card_number are used.
+func cardBIN(payment map[string]string) string {
+	source := payment["card_bin"]
+	if source == "" {
+		source = payment["card_number"]
+	}
+	digits := make([]rune, 0, 6)
+	for _, r := range source {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+			if len(digits) == 6 {
+				return string(digits)
+			}
+		}
+	}
+	return ""
+}
+
+func normalizeRegion(value string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+}
+
+func externalSignals(ctx context.Context, scorers ScorerRegistry, id string, input Input) []Signal {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	unavailable := func(err error) []Signal {
+		return []Signal{{Code: "external_scorer_unavailable", Message: fmt.Sprintf("External scorer %s could not score the order: %v", id, err)}}
+	}
+	if scorers == nil {
+		return unavailable(ErrUnknownScorer)
+	}
+	scorer, err := scorers.Scorer(id)
+	if err != nil {
+		return unavailable(err)
+	}
+	signals, err := scorer.Score(ctx, input)
+	if err != nil {
+		return unavailable(err)
+	}
+	for index := range signals {
+		if strings.TrimSpace(signals[index].Code) == "" {
+			signals[index].Code = "external"
+		}
+		signals[index].Code = id + ":" + signals[index].Code
+	}
+	return signals
+}
+
+func clampScore(score int) int {
+	if score < 0 {
+		return 0
+	}
+	if score > maxScore {
+		return maxScore
+	}
+	return score
+}
@@ -0,0 +1,231 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	orderservice "ecommerce/internal/services/orders"
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newRiskTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dbName := strings.ReplaceAll(t.Name(), "/", "_")
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", dbName)), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Order{},
+		&models.OrderItem{},
+		&models.OrderStatusHistory{},
+		&models.OrderCheckoutSnapshot{},
+		&models.InventoryReservation{},
+		&models.RiskSettings{},
+		&models.OrderRiskAssessment{},
+	))
+	return db
+}
+
+type riskOrder struct {
+	email    string
+	total    float64
+	billing  string
+	shipping string
+}
+
+func createRiskOrder(t *testing.T, db *gorm.DB, input riskOrder) AssessInput {
+	t.Helper()
+	email := input.email
+	order := models.Order{CheckoutSessionID: 1, GuestEmail: &email, Total: models.MoneyFromFloat(input.total), Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+	snapshot := models.OrderCheckoutSnapshot{
+		CheckoutSessionID: 1, OrderID: &order.ID, Currency: "USD", Subtotal: order.Total, Total: order.Total,
+		PaymentProviderID: "dummy-card", ShippingProviderID: "dummy-ground", ExpiresAt: time.Now().Add(time.Hour),
+		PaymentDataJSON:  fmt.Sprintf(`{"card_number":"4242 4242 4242 4242","billing_country":%q,"billing_postal_code":"10001"}`, input.billing),
+		ShippingDataJSON: fmt.Sprintf(`{"country":%q,"postal_code":"10001"}`, input.shipping),
+	}
+	require.NoError(t, db.Create(&snapshot).Error)
+	return AssessInput{Order: order, Snapshot: snapshot, ClientIP: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+}
+
+func signalCodes(signals []Signal) []string {
+	codes := make([]string, 0, len(signals))
+	for _, signal := range signals {
+		codes = append(codes, signal.Code)
+	}
+	return codes
+}
+
+type stubScorers map[string]Scorer
+
+func (s stubScorers) Scorer(id string) (Scorer, error) {
+	if scorer, ok := s[id]; ok {
+		return scorer, nil
+	}
+	return nil, ErrUnknownScorer
+}
+
+type scorerFunc func(context.Context, Input) ([]Signal, error)
+
+func (f scorerFunc) Score(ctx context.Context, input Input) ([]Signal, error) { return f(ctx, input) }
+
+func TestAssessAllowsCleanOrderAndRecordsAssessment(t *testing.T) {
+	db := newRiskTestDB(t)
+	now := time.Date(2026, 8, 20, 9, 0, 0, 0, time.UTC)
+	input := createRiskOrder(t, db, riskOrder{email: "Shopper@Example.com", total: 40, billing: "US", shipping: "us"})
+
+	assessment, err := Assess(db, nil, input, now)
+	require.NoError(t, err)
+	require.Equal(t, models.RiskActionAllow, assessment.Action())
+	require.Empty(t, assessment.Signals)
+	require.Equal(t, "shopper@example.com", assessment.Record.Email)
+	require.Equal(t, "424242", assessment.Record.CardBIN)
+
+	var order models.Order
+	require.NoError(t, db.First(&order, input.Order.ID).Error)
+	require.NotNil(t, order.RiskScore)
+	require.Equal(t, 0, *order.RiskScore)
+	require.Equal(t, models.RiskActionAllow, order.RiskAction)
+	require.Empty(t, order.RiskReviewStatus)
+}
+
+func TestAssessHoldsOrderForReviewAndBlocksShippingUntilApproved(t *testing.T) {
+	db := newRiskTestDB(t)
+	now := time.Date(2026, 8, 20, 9, 0, 0, 0, time.UTC)
+	input := createRiskOrder(t, db, riskOrder{email: "buyer@mailinator.com", total: 40, billing: "US", shipping: "CA"})
+
+	assessment, err := Assess(db, nil, input, now)
+	require.NoError(t, err)
+	require.Equal(t, models.RiskActionReview, assessment.Action())
+	require.Equal(t, 55, assessment.Record.Score)
+	require.ElementsMatch(t, []string{"disposable_email", "billing_shipping_country_mismatch"}, signalCodes(assessment.Signals))
+
+	var order models.Order
+	require.NoError(t, db.First(&order, input.Order.ID).Error)
+	require.Equal(t, models.RiskReviewPending, order.RiskReviewStatus)
+	order.Status = models.StatusPaid
+	require.NoError(t, db.Save(&order).Error)
+	require.ErrorIs(t, orderservice.ApplyStatusTransition(db, &order, orderservice.StatusTransition{To: models.StatusShipped, Path: orderservice.PathAdmin}), orderservice.ErrOrderRiskReviewPending)
+
+	queue, total, err := ListReviews(db, ReviewListInput{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	require.Equal(t, input.Order.ID, queue[0].ID)
+
+	approved, err := Approve(db, input.Order.ID, ReviewInput{Actor: "admin@example.com"}, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, models.RiskReviewApproved, approved.RiskReviewStatus)
+	require.Equal(t, "admin@example.com", approved.RiskReviewedBy)
+	_, err = Approve(db, input.Order.ID, ReviewInput{}, now.Add(time.Hour))
+	require.ErrorIs(t, err, ErrReviewNotPending)
+}
+
+func TestAssessRejectsHighRiskOrderAndMarksItFailed(t *testing.T) {
+	db := newRiskTestDB(t)
+	now := time.Date(2026, 8, 20, 9, 0, 0, 0, time.UTC)
+	input := createRiskOrder(t, db, riskOrder{email: "buyer@yopmail.com", total: 900, billing: "US", shipping: "GB"})
+
+	assessment, err := Assess(db, nil, input, now)
+	require.NoError(t, err)
+	require.Equal(t, models.RiskActionReject, assessment.Action())
+	require.Contains(t, signalCodes(assessment.Signals), "high_value_first_order")
+
+	var order models.Order
+	require.NoError(t, db.First(&order, input.Order.ID).Error)
+	require.Equal(t, models.StatusFailed, order.Status)
+	var history models.OrderStatusHistory
+	require.NoError(t, db.Where("order_id = ?", order.ID).First(&history).Error)
+	require.Equal(t, "risk_rejected", history.Reason)
+}
+
+func TestAssessFlagsVelocityAcrossOrders(t *testing.T) {
+	db := newRiskTestDB(t)
+	now := time.Date(2026, 8, 20, 9, 0, 0, 0, time.UTC)
+	for index := 0; index < 3; index++ {
+		earlier := createRiskOrder(t, db, riskOrder{email: fmt.Sprintf("shopper%d@example.com", index), total: 20, billing: "US", shipping: "US"})
+		_, err := Assess(db, nil, earlier, now.Add(-time.Duration(10*(index+1))*time.Minute))
+		require.NoError(t, err)
+	}
+	stale := createRiskOrder(t, db, riskOrder{email: "old@example.com", total: 20, billing: "US", shipping: "US"})
+	_, err := Assess(db, nil, stale, now.Add(-2*time.Hour))
+	require.NoError(t, err)
+
+	input := createRiskOrder(t, db, riskOrder{email: "fresh@example.com", total: 20, billing: "US", shipping: "US"})
+	assessment, err := Assess(db, nil, input, now)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"velocity_ip", "velocity_card_bin"}, signalCodes(assessment.Signals))
+	require.Equal(t, models.RiskActionReview, assessment.Action())
+}
+
+func TestAssessUsesExternalScorerAndToleratesItsFailure(t *testing.T) {
+	db := newRiskTestDB(t)
+	now := time.Date(2026, 8, 20, 9, 0, 0, 0, time.UTC)
+	_, err := UpdateSettings(db, SettingsInput{
+		Enabled: true, ReviewScore: 40, RejectScore: 90, HighValueThreshold: models.MoneyFromFloat(500),
+		VelocityWindowMinutes: 60, VelocityLimit: 3, ExternalScorerID: "acme-fraud",
+	})
+	require.NoError(t, err)
+
+	var seen Input
+	scorers := stubScorers{"acme-fraud": scorerFunc(func(_ context.Context, input Input) ([]Signal, error) {
+		seen = input
+		return []Signal{{Code: "device", Score: 45, Message: "Device seen on chargebacks"}}, nil
+	})}
+	input := createRiskOrder(t, db, riskOrder{email: "shopper@example.com", total: 40, billing: "US", shipping: "US"})
+	assessment, err := Assess(db, scorers, input, now)
+	require.NoError(t, err)
+	require.Equal(t, models.RiskActionReview, assessment.Action())
+	require.Equal(t, []string{"acme-fraud:device"}, signalCodes(assessment.Signals))
+	require.Equal(t, "acme-fraud", assessment.Record.ScorerID)
+	require.Equal(t, "424242", seen.CardBIN)
+
+	failing := stubScorers{"acme-fraud": scorerFunc(func(context.Context, Input) ([]Signal, error) {
+		return nil, errors.New("timeout")
+	})}
+	other := createRiskOrder(t, db, riskOrder{email: "other@example.com", total: 40, billing: "US", shipping: "US"})
+	assessment, err = Assess(db, failing, other, now)
+	require.NoError(t, err)
+	require.Equal(t, models.RiskActionAllow, assessment.Action())
+	require.Equal(t, []string{"external_scorer_unavailable"}, signalCodes(assessment.Signals))
+}
+
+func TestAssessSkipsWhenDisabledAndRejectReviewCancelsOrder(t *testing.T) {
+	db := newRiskTestDB(t)
+	now := time.Date(2026, 8, 20, 9, 0, 0, 0, time.UTC)
+	settings, err := UpdateSettings(db, SettingsInput{
+		Enabled: false, ReviewScore: 50, RejectScore: 80, VelocityWindowMinutes: 60, VelocityLimit: 3, DisposableDomains: "Example.org, @burner.test",
+	})
+	require.NoError(t, err)
+	require.False(t, settings.Enabled)
+	require.Equal(t, "example.org\nburner.test", settings.DisposableDomains)
+
+	input := createRiskOrder(t, db, riskOrder{email: "a@burner.test", total: 40, billing: "US", shipping: "CA"})
+	assessment, err := Assess(db, nil, input, now)
+	require.NoError(t, err)
+	require.True(t, assessment.Skipped)
+	var count int64
+	require.NoError(t, db.Model(&models.OrderRiskAssessment{}).Count(&count).Error)
+	require.Zero(t, count)
+
+	settings.Enabled = true
+	require.NoError(t, db.Save(&settings).Error)
+	assessment, err = Assess(db, nil, input, now)
+	require.NoError(t, err)
+	require.Equal(t, models.RiskActionReview, assessment.Action())
+
+	rejected, err := Reject(db, input.Order.ID, ReviewInput{Actor: "admin", Note: "stolen card"}, now)
+	require.NoError(t, err)
+	require.Equal(t, models.StatusCancelled, rejected.Status)
+	require.Equal(t, models.RiskReviewRejected, rejected.RiskReviewStatus)
+
+	_, err = UpdateSettings(db, SettingsInput{Enabled: true, ReviewScore: 70, RejectScore: 60, VelocityWindowMinutes: 60, VelocityLimit: 3})
+	require.ErrorIs(t, err, ErrInvalidRiskSettings)
+}
@@ -0,0 +1,137 @@
+package risk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	disposableEmailScore = 30
+	velocityScore        = 25
+	countryMismatchScore = 25
+	postalMismatchScore  = 10
+	highValueFirstScore  = 30
+	automatedClientScore = 15
+)
+
+// defaultDisposableDomains covers the throwaway inbox services seen most often;
+// merchants extend the list through RiskSettings.DisposableDomains.
+var defaultDisposableDomains = []string{
+	"10minutemail.com", "dispostable.com", "fakeinbox.com", "getnada.com", "guerrillamail.com",
+	"maildrop.cc", "mailinator.com", "sharklasers.com", "temp-mail.org", "tempmail.com",
+	"throwawaymail.com", "trashmail.com", "yopmail.com",
+}
+
+func builtInSignals(db *gorm.DB, settings models.RiskSettings, input Input, now time.Time) ([]Signal, error) {
+	var signals []Signal
+	if domain := emailDomain(input.Email); domain != "" && isDisposableDomain(domain, settings.DisposableDomains) {
+		signals = append(signals, Signal{Code: "disposable_email", Score: disposableEmailScore, Message: fmt.Sprintf("Email uses the disposable domain %s.", domain)})
+	}
+
+	since := now.Add(-time.Duration(settings.VelocityWindowMinutes) * time.Minute)
+	for _, check := range []struct {
+		code, column, value, label string
+	}{
+		{"velocity_email", "email", input.Email, "email address"},
+		{"velocity_ip", "client_ip", input.ClientIP, "client address"},
+		{"velocity_card_bin", "card_bin", input.CardBIN, "card BIN"},
+	} {
+		if check.value == "" {
+			continue
+		}
+		var attempts int64
+		if err := db.Model(&models.OrderRiskAssessment{}).
+			Where(check.column+" = ? AND created_at >= ? AND order_id <> ?", check.value, since, input.OrderID).
+			Count(&attempts).Error; err != nil {
+			return nil, err
+		}
+		if attempts >= int64(settings.VelocityLimit) {
+			signals = append(signals, Signal{
+				Code: check.code, Score: velocityScore,
+				Message: fmt.Sprintf("%d other orders used this %s in the last %d minutes.", attempts, check.label, settings.VelocityWindowMinutes),
+			})
+		}
+	}
+
+	billing, shipping := input.Billing, input.Shipping
+	switch {
+	case billing.Country != "" && shipping.Country != "" && billing.Country != shipping.Country:
+		signals = append(signals, Signal{Code: "billing_shipping_country_mismatch", Score: countryMismatchScore, Message: fmt.Sprintf("Billing country %s differs from shipping country %s.", billing.Country, shipping.Country)})
+	case billing.PostalCode != "" && shipping.PostalCode != "" && billing.PostalCode != shipping.PostalCode:
+		signals = append(signals, Signal{Code: "billing_shipping_postal_mismatch", Score: postalMismatchScore, Message: "Billing postal code differs from the shipping postal code."})
+	}
+
+	if threshold := settings.HighValueThreshold; threshold > 0 && models.MoneyFromFloat(input.Total) >= threshold {
+		first, err := isFirstOrder(db, input)
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			signals = append(signals, Signal{Code: "high_value_first_order", Score: highValueFirstScore, Message: fmt.Sprintf("First order from this customer totals %.2f %s.", input.Total, input.Currency)})
+		}
+	}
+
+	if input.UserAgent == "" {
+		signals = append(signals, Signal{Code: "automated_client", Score: automatedClientScore, Message: "The request did not identify a browser or app."})
+	}
+	return signals, nil
+}
+
+// isFirstOrder reports whether the customer has no earlier paid order, matched
+// by account or, for guests, by email.
+func isFirstOrder(db *gorm.DB, input Input) (bool, error) {
+	query := db.Model(&models.Order{}).Where("id <> ? AND status IN ?", input.OrderID, models.PaidOrderStatuses)
+	switch {
+	case input.UserID != nil:
+		query = query.Where("user_id = ?", *input.UserID)
+	case input.Email != "":
+		query = query.Where("LOWER(guest_email) = ?", input.Email)
+	default:
+		return true, nil
+	}
+	var previous int64
+	if err := query.Count(&previous).Error; err != nil {
+		return false, err
+	}
+	return previous == 0, nil
+}
+
+func emailDomain(email string) string {
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(domain))
+}
+
+func isDisposableDomain(domain, configured string) bool {
+	for _, candidate := range defaultDisposableDomains {
+		if domain == candidate {
+			return true
+		}
+	}
+	for _, candidate := range splitDomains(configured) {
+		if domain == candidate || strings.HasSuffix(domain, "."+candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitDomains(value string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' ' || r == '\t' || r == '\r'
+	})
+	domains := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.Trim(field, "@."); field != "" {
+			domains = append(domains, field)
+		}
+	}
+	return domains
+}
@@ -0,0 +1,84 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// SettingsInput replaces the screening configuration. DisposableDomains is a
+// comma or newline separated list added to the built-in domains.
+type SettingsInput struct {
+	Enabled               bool
+	ReviewScore           int
+	RejectScore           int
+	HighValueThreshold    models.Money
+	VelocityWindowMinutes int
+	VelocityLimit         int
+	DisposableDomains     string
+	ExternalScorerID      string
+}
+
+func defaultSettings() models.RiskSettings {
+	return models.RiskSettings{
+		ID: models.RiskSettingsSingletonID, Enabled: true, ReviewScore: 50, RejectScore: 80,
+		HighValueThreshold: models.MoneyFromFloat(500), VelocityWindowMinutes: 60, VelocityLimit: 3,
+	}
+}
+
+// GetSettings returns the stored configuration, or the defaults when the
+// merchant has never saved one.
+func GetSettings(db *gorm.DB) (models.RiskSettings, error) {
+	var settings models.RiskSettings
+	err := db.First(&settings, models.RiskSettingsSingletonID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return defaultSettings(), nil
+	}
+	return settings, err
+}
+
+func UpdateSettings(db *gorm.DB, input SettingsInput) (models.RiskSettings, error) {
+	input.ExternalScorerID = strings.TrimSpace(input.ExternalScorerID)
+	switch {
+	case input.ReviewScore < 1 || input.ReviewScore > maxScore, input.RejectScore < 1 || input.RejectScore > maxScore:
+		return models.RiskSettings{}, fmt.Errorf("%w: scores must be between 1 and %d", ErrInvalidRiskSettings, maxScore)
+	case input.RejectScore < input.ReviewScore:
+		return models.RiskSettings{}, fmt.Errorf("%w: reject score must not be below review score", ErrInvalidRiskSettings)
+	case input.HighValueThreshold < 0:
+		return models.RiskSettings{}, fmt.Errorf("%w: high value threshold must not be negative", ErrInvalidRiskSettings)
+	case input.VelocityWindowMinutes < 1 || input.VelocityLimit < 1:
+		return models.RiskSettings{}, fmt.Errorf("%w: velocity window and limit must be positive", ErrInvalidRiskSettings)
+	}
+
+	var settings models.RiskSettings
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if settings, err = GetSettings(tx); err != nil {
+			return err
+		}
+		// Create the defaults first: gorm omits a false Enabled on insert and the
+		// column default would switch screening back on.
+		if settings.CreatedAt.IsZero() {
+			if err := tx.Create(&settings).Error; err != nil {
+				return err
+			}
+		}
+		settings.Enabled = input.Enabled
+		settings.ReviewScore = input.ReviewScore
+		settings.RejectScore = input.RejectScore
+		settings.HighValueThreshold = input.HighValueThreshold
+		settings.VelocityWindowMinutes = input.VelocityWindowMinutes
+		settings.VelocityLimit = input.VelocityLimit
+		settings.DisposableDomains = strings.Join(splitDomains(input.DisposableDomains), "\n")
+		settings.ExternalScorerID = input.ExternalScorerID
+		return tx.Save(&settings).Error
+	})
+	if err != nil {
+		return models.RiskSettings{}, err
+	}
+	return settings, nil
+}
@@ -0,0 +1,43 @@
+package segments
+
+import (
+	"context"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+)
+
+// Service provides context-first customer segment APIs.
+type Service struct{ db *gorm.DB }
+
+func NewService(db *gorm.DB) *Service { return &Service{db: db} }
+
+func (s *Service) List(ctx context.Context, activeOnly bool) ([]models.CustomerSegment, error) {
+	return ListSegments(s.db.WithContext(ctx), activeOnly)
+}
+func (s *Service) Get(ctx context.Context, id uint) (models.CustomerSegment, error) {
+	return GetSegment(s.db.WithContext(ctx), id)
+}
+func (s *Service) Create(ctx context.Context, input SegmentInput) (models.CustomerSegment, error) {
+	return CreateSegment(s.db.WithContext(ctx), input)
+}
+func (s *Service) Update(ctx context.Context, id uint, input SegmentInput) (models.CustomerSegment, error) {
+	return UpdateSegment(s.db.WithContext(ctx), id, input)
+}
+func (s *Service) Delete(ctx context.Context, id uint) error {
+	return DeleteSegment(s.db.WithContext(ctx), id)
+}
+func (s *Service) Refresh(ctx context.Context, id uint, now time.Time) (RefreshResult, error) {
+	return RefreshSegment(s.db.WithContext(ctx), id, now)
+}
+func (s *Service) RefreshAll(ctx context.Context, now time.Time) ([]RefreshResult, error) {
+	return RefreshAll(s.db.WithContext(ctx), now)
+}
+func (s *Service) ListMembers(ctx context.Context, id uint, limit, offset int) ([]models.CustomerSegmentMembership, int64, error) {
+	return ListMembers(s.db.WithContext(ctx), id, limit, offset)
+}
+func (s *Service) KeysForCustomer(ctx context.Context, userID uint) ([]string, error) {
+	return KeysForCustomer(s.db.WithContext(ctx), userID)
+}
@@ -0,0 +1,186 @@
+package segments
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+)
+
+// Rules are the criteria a customer must satisfy to belong to a segment. Every
+// populated criterion must match; list criteria match when any value matches.
+type Rules struct {
+	MinOrderCount        *int          `json:"min_order_count,omitempty"`
+	MaxOrderCount        *int          `json:"max_order_count,omitempty"`
+	MinLifetimeSpend     *models.Money `json:"min_lifetime_spend,omitempty"`
+	MaxLifetimeSpend     *models.Money `json:"max_lifetime_spend,omitempty"`
+	FirstOrderAfter      *time.Time    `json:"first_order_after,omitempty"`
+	FirstOrderBefore     *time.Time    `json:"first_order_before,omitempty"`
+	FirstOrderWithinDays *int          `json:"first_order_within_days,omitempty"`
+	Countries            []string      `json:"countries,omitempty"`
+	Roles                []string      `json:"roles,omitempty"`
+	SignedUpAfter        *time.Time    `json:"signed_up_after,omitempty"`
+	SignedUpBefore       *time.Time    `json:"signed_up_before,omitempty"`
+	SignedUpWithinDays   *int          `json:"signed_up_within_days,omitempty"`
+}
+
+// CustomerProfile is the per-customer aggregate that rules are evaluated
+// against. Only orders in a stock-committed status count toward order totals.
+type CustomerProfile struct {
+	UserID        uint
+	Role          string
+	SignedUpAt    time.Time
+	OrderCount    int
+	LifetimeSpend models.Money
+	FirstOrderAt  *time.Time
+	Countries     []string
+}
+
+func (r Rules) empty() bool {
+	return r.MinOrderCount == nil && r.MaxOrderCount == nil &&
+		r.MinLifetimeSpend == nil && r.MaxLifetimeSpend == nil &&
+		r.FirstOrderAfter == nil && r.FirstOrderBefore == nil && r.FirstOrderWithinDays == nil &&
+		len(r.Countries) == 0 && len(r.Roles) == 0 &&
+		r.SignedUpAfter == nil && r.SignedUpBefore == nil && r.SignedUpWithinDays == nil
+}
+
+func (r Rules) normalized() Rules {
+	countries := make([]string, 0, len(r.Countries))
+	for _, country := range r.Countries {
+		countries = append(countries, strings.ToUpper(strings.TrimSpace(country)))
+	}
+	roles := make([]string, 0, len(r.Roles))
+	for _, role := range r.Roles {
+		roles = append(roles, strings.ToLower(strings.TrimSpace(role)))
+	}
+	r.Countries = countries
+	r.Roles = roles
+	r.FirstOrderAfter = utcTimePtr(r.FirstOrderAfter)
+	r.FirstOrderBefore = utcTimePtr(r.FirstOrderBefore)
+	r.SignedUpAfter = utcTimePtr(r.SignedUpAfter)
+	r.SignedUpBefore = utcTimePtr(r.SignedUpBefore)
+	return r
+}
+
+func (r Rules) validate() error {
+	if r.empty() {
+		return fmt.Errorf("%w: at least one rule is required", ErrInvalidSegment)
+	}
+	if (r.MinOrderCount != nil && *r.MinOrderCount < 0) || (r.MaxOrderCount != nil && *r.MaxOrderCount < 0) {
+		return fmt.Errorf("%w: order count bounds cannot be negative", ErrInvalidSegment)
+	}
+	if r.MinOrderCount != nil && r.MaxOrderCount != nil && *r.MinOrderCount > *r.MaxOrderCount {
+		return fmt.Errorf("%w: min_order_count cannot exceed max_order_count", ErrInvalidSegment)
+	}
+	if (r.MinLifetimeSpend != nil && *r.MinLifetimeSpend < 0) || (r.MaxLifetimeSpend != nil && *r.MaxLifetimeSpend < 0) {
+		return fmt.Errorf("%w: lifetime spend bounds cannot be negative", ErrInvalidSegment)
+	}
+	if r.MinLifetimeSpend != nil && r.MaxLifetimeSpend != nil && *r.MinLifetimeSpend > *r.MaxLifetimeSpend {
+		return fmt.Errorf("%w: min_lifetime_spend cannot exceed max_lifetime_spend", ErrInvalidSegment)
+	}
+	if r.FirstOrderAfter != nil && r.FirstOrderBefore != nil && !r.FirstOrderBefore.After(*r.FirstOrderAfter) {
+		return fmt.Errorf("%w: first_order_before must be after first_order_after", ErrInvalidSegment)
+	}
+	if r.SignedUpAfter != nil && r.SignedUpBefore != nil && !r.SignedUpBefore.After(*r.SignedUpAfter) {
+		return fmt.Errorf("%w: signed_up_before must be after signed_up_after", ErrInvalidSegment)
+	}
+	if (r.FirstOrderWithinDays != nil && *r.FirstOrderWithinDays < 1) || (r.SignedUpWithinDays != nil && *r.SignedUpWithinDays < 1) {
+		return fmt.Errorf("%w: relative day windows must be positive", ErrInvalidSegment)
+	}
+	for _, country := range r.Countries {
+		if len(country) != 2 {
+			return fmt.Errorf("%w: countries must be ISO 3166-1 alpha-2 codes", ErrInvalidSegment)
+		}
+	}
+	for _, role := range r.Roles {
+		if role == "" {
+			return fmt.Errorf("%w: roles cannot be blank", ErrInvalidSegment)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether profile satisfies every populated rule at now.
+func (r Rules) Matches(profile CustomerProfile, now time.Time) bool {
+	if r.MinOrderCount != nil && profile.OrderCount < *r.MinOrderCount {
+		return false
+	}
+	if r.MaxOrderCount != nil && profile.OrderCount > *r.MaxOrderCount {
+		return false
+	}
+	if r.MinLifetimeSpend != nil && profile.LifetimeSpend < *r.MinLifetimeSpend {
+		return false
+	}
+	if r.MaxLifetimeSpend != nil && profile.LifetimeSpend > *r.MaxLifetimeSpend {
+		return false
+	}
+	if r.FirstOrderAfter != nil || r.FirstOrderBefore != nil || r.FirstOrderWithinDays != nil {
+		if profile.FirstOrderAt == nil || !withinWindow(*profile.FirstOrderAt, r.FirstOrderAfter, r.FirstOrderBefore, r.FirstOrderWithinDays, now) {
+			return false
+		}
+	}
+	if !withinWindow(profile.SignedUpAt, r.SignedUpAfter, r.SignedUpBefore, r.SignedUpWithinDays, now) {
+		return false
+	}
+	if len(r.Roles) > 0 && !containsFold(r.Roles, profile.Role) {
+		return false
+	}
+	if len(r.Countries) > 0 {
+		matched := false
+		for _, country := range profile.Countries {
+			if containsFold(r.Countries, country) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeRules parses a stored rules document.
+func DecodeRules(raw string) (Rules, error) {
+	var rules Rules
+	if strings.TrimSpace(raw) == "" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return Rules{}, fmt.Errorf("%w: decode rules: %v", ErrInvalidSegment, err)
+	}
+	return rules, nil
+}
+
+func withinWindow(value time.Time, after, before *time.Time, withinDays *int, now time.Time) bool {
+	if after != nil && value.Before(*after) {
+		return false
+	}
+	if before != nil && !value.Before(*before) {
+		return false
+	}
+	if withinDays != nil && value.Before(now.UTC().AddDate(0, 0, -*withinDays)) {
+		return false
+	}
+	return true
+}
+
+func containsFold(values []string, candidate string) bool {
+	candidate = strings.TrimSpace(candidate)
+	for _, value := range values {
+		if strings.EqualFold(value, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func utcTimePtr(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	converted := value.UTC()
+	return &converted
+}
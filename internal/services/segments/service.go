@@ -0,0 +1,441 @@
+package segments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidSegment      = errors.New("invalid customer segment")
+	ErrSegmentNotFound     = errors.New("customer segment not found")
+	ErrDuplicateSegmentKey = errors.New("customer segment key already exists")
+)
+
+var segmentKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+type SegmentInput struct {
+	Key         string
+	Name        string
+	Description string
+	Rules       Rules
+	IsActive    *bool
+	ActorID     *uint
+}
+
+type RefreshResult struct {
+	SegmentID uint
+	Key       string
+	Added     int
+	Removed   int
+	Members   int
+}
+
+func NormalizeKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+func validateInput(input SegmentInput) (SegmentInput, error) {
+	input.Key = NormalizeKey(input.Key)
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	input.Rules = input.Rules.normalized()
+	if !segmentKeyPattern.MatchString(input.Key) {
+		return input, fmt.Errorf("%w: key must be 1-64 lowercase letters, digits, '-' or '_'", ErrInvalidSegment)
+	}
+	if input.Name == "" {
+		return input, fmt.Errorf("%w: name is required", ErrInvalidSegment)
+	}
+	return input, input.Rules.validate()
+}
+
+func CreateSegment(db *gorm.DB, input SegmentInput) (models.CustomerSegment, error) {
+	input, err := validateInput(input)
+	if err != nil {
+		return models.CustomerSegment{}, err
+	}
+	raw, err := json.Marshal(input.Rules)
+	if err != nil {
+		return models.CustomerSegment{}, err
+	}
+	active := true
+	if input.IsActive != nil {
+		active = *input.IsActive
+	}
+	if err := ensureKeyAvailable(db, input.Key, 0); err != nil {
+		return models.CustomerSegment{}, err
+	}
+	segment := models.CustomerSegment{
+		Key:         input.Key,
+		Name:        input.Name,
+		Description: input.Description,
+		RulesJSON:   string(raw),
+		IsActive:    active,
+		CreatedBy:   input.ActorID,
+		UpdatedBy:   input.ActorID,
+	}
+	if err := db.Create(&segment).Error; err != nil {
+		return models.CustomerSegment{}, err
+	}
+	return segment, nil
+}
+
+func UpdateSegment(db *gorm.DB, id uint, input SegmentInput) (models.CustomerSegment, error) {
+	input, err := validateInput(input)
+	if err != nil {
+		return models.CustomerSegment{}, err
+	}
+	raw, err := json.Marshal(input.Rules)
+	if err != nil {
+		return models.CustomerSegment{}, err
+	}
+	var segment models.CustomerSegment
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&segment, id).Error; err != nil {
+			return segmentLookupError(err)
+		}
+		if err := ensureKeyAvailable(tx, input.Key, id); err != nil {
+			return err
+		}
+		segment.Key = input.Key
+		segment.Name = input.Name
+		segment.Description = input.Description
+		segment.RulesJSON = string(raw)
+		if input.IsActive != nil {
+			segment.IsActive = *input.IsActive
+		}
+		segment.UpdatedBy = input.ActorID
+		return tx.Save(&segment).Error
+	})
+	if err != nil {
+		return models.CustomerSegment{}, err
+	}
+	return segment, nil
+}
+
+// DeleteSegment removes the segment and its materialized membership so it
+// stops resolving immediately and its key can be reused.
+func DeleteSegment(db *gorm.DB, id uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var segment models.CustomerSegment
+		if err := tx.First(&segment, id).Error; err != nil {
+			return segmentLookupError(err)
+		}
+		if err := tx.Where("segment_id = ?", id).Delete(&models.CustomerSegmentMembership{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&segment).Error
+	})
+}
+
+func ensureKeyAvailable(db *gorm.DB, key string, exceptID uint) error {
+	var count int64
+	if err := db.Model(&models.CustomerSegment{}).Where("key = ? AND id <> ?", key, exceptID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicateSegmentKey
+	}
+	return nil
+}
+
+func GetSegment(db *gorm.DB, id uint) (models.CustomerSegment, error) {
+	var segment models.CustomerSegment
+	if err := db.First(&segment, id).Error; err != nil {
+		return models.CustomerSegment{}, segmentLookupError(err)
+	}
+	return segment, nil
+}
+
+func ListSegments(db *gorm.DB, activeOnly bool) ([]models.CustomerSegment, error) {
+	query := db.Order("key ASC").Order("id ASC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	var segments []models.CustomerSegment
+	if err := query.Find(&segments).Error; err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
+
+func ListMembers(db *gorm.DB, id uint, limit, offset int) ([]models.CustomerSegmentMembership, int64, error) {
+	if _, err := GetSegment(db, id); err != nil {
+		return nil, 0, err
+	}
+	query := db.Model(&models.CustomerSegmentMembership{}).Where("segment_id = ?", id)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var members []models.CustomerSegmentMembership
+	if err := query.Order("user_id ASC").Limit(limit).Offset(offset).Find(&members).Error; err != nil {
+		return nil, 0, err
+	}
+	return members, total, nil
+}
+
+// RefreshSegment re-evaluates one segment's rules against current customer
+// data and reconciles its materialized membership.
+func RefreshSegment(db *gorm.DB, id uint, now time.Time) (RefreshResult, error) {
+	segment, err := GetSegment(db, id)
+	if err != nil {
+		return RefreshResult{}, err
+	}
+	matched, err := matchSegments(db, []models.CustomerSegment{segment}, now)
+	if err != nil {
+		return RefreshResult{}, err
+	}
+	return refreshSegment(db, segment, matched[segment.ID], now)
+}
+
+// RefreshAll refreshes every segment, including inactive ones so that their
+// membership is cleared. Customer profiles are loaded once per run.
+func RefreshAll(db *gorm.DB, now time.Time) ([]RefreshResult, error) {
+	segments, err := ListSegments(db, false)
+	if err != nil || len(segments) == 0 {
+		return nil, err
+	}
+	matched, err := matchSegments(db, segments, now)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]RefreshResult, 0, len(segments))
+	for _, segment := range segments {
+		result, err := refreshSegment(db, segment, matched[segment.ID], now)
+		if err != nil {
+			return results, fmt.Errorf("refresh segment %s: %w", segment.Key, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// matchSegments evaluates the rules of every active segment against all
+// customers, one page of profiles at a time, and returns the IDs of the
+// customers each segment matches. Inactive segments match nobody.
+func matchSegments(db *gorm.DB, segments []models.CustomerSegment, now time.Time) (map[uint]map[uint]struct{}, error) {
+	now = now.UTC()
+	matched := make(map[uint]map[uint]struct{}, len(segments))
+	rules := make(map[uint]Rules, len(segments))
+	for _, segment := range segments {
+		matched[segment.ID] = map[uint]struct{}{}
+		if !segment.IsActive {
+			continue
+		}
+		decoded, err := DecodeRules(segment.RulesJSON)
+		if err != nil {
+			return nil, fmt.Errorf("refresh segment %s: %w", segment.Key, err)
+		}
+		rules[segment.ID] = decoded
+	}
+	if len(rules) == 0 {
+		return matched, nil
+	}
+	var afterID uint
+	for {
+		profiles, err := LoadProfiles(db, afterID, profilePageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, profile := range profiles {
+			for segmentID, segmentRules := range rules {
+				if segmentRules.Matches(profile, now) {
+					matched[segmentID][profile.UserID] = struct{}{}
+				}
+			}
+		}
+		if len(profiles) < profilePageSize {
+			return matched, nil
+		}
+		afterID = profiles[len(profiles)-1].UserID
+	}
+}
+
+func refreshSegment(db *gorm.DB, segment models.CustomerSegment, matched map[uint]struct{}, now time.Time) (RefreshResult, error) {
+	now = now.UTC()
+	result := RefreshResult{SegmentID: segment.ID, Key: segment.Key}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var locked models.CustomerSegment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, segment.ID).Error; err != nil {
+			return segmentLookupError(err)
+		}
+		var existing []uint
+		if err := tx.Model(&models.CustomerSegmentMembership{}).Where("segment_id = ?", segment.ID).Pluck("user_id", &existing).Error; err != nil {
+			return err
+		}
+		current := make(map[uint]struct{}, len(existing))
+		stale := make([]uint, 0)
+		for _, userID := range existing {
+			current[userID] = struct{}{}
+			if _, ok := matched[userID]; !ok {
+				stale = append(stale, userID)
+			}
+		}
+		if len(stale) > 0 {
+			if err := tx.Where("segment_id = ? AND user_id IN ?", segment.ID, stale).Delete(&models.CustomerSegmentMembership{}).Error; err != nil {
+				return err
+			}
+		}
+		added := make([]models.CustomerSegmentMembership, 0)
+		for userID := range matched {
+			if _, ok := current[userID]; !ok {
+				added = append(added, models.CustomerSegmentMembership{SegmentID: segment.ID, UserID: userID, MatchedAt: now})
+			}
+		}
+		sort.Slice(added, func(i, j int) bool { return added[i].UserID < added[j].UserID })
+		if len(added) > 0 {
+			if err := tx.CreateInBatches(&added, 500).Error; err != nil {
+				return err
+			}
+		}
+		result.Added = len(added)
+		result.Removed = len(stale)
+		result.Members = len(matched)
+		return tx.Model(&models.CustomerSegment{}).Where("id = ?", segment.ID).Updates(map[string]any{
+			"member_count":      len(matched),
+			"last_refreshed_at": now,
+		}).Error
+	})
+	if err != nil {
+		return RefreshResult{}, err
+	}
+	return result, nil
+}
+
+// profilePageSize is how many customers are loaded and matched at a time.
+const profilePageSize = 500
+
+// LoadProfiles aggregates the customer data segment rules are evaluated over
+// for up to limit customers with an ID above afterID, in ID order. Order
+// counts, spend and first order dates are aggregated by the database.
+func LoadProfiles(db *gorm.DB, afterID uint, limit int) ([]CustomerProfile, error) {
+	var users []struct {
+		ID        uint
+		Role      string
+		CreatedAt time.Time
+	}
+	if err := db.Model(&models.User{}).Select("id", "role", "created_at").Where("id > ?", afterID).
+		Order("id ASC").Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	profiles := make([]CustomerProfile, 0, len(users))
+	if len(users) == 0 {
+		return profiles, nil
+	}
+	index := make(map[uint]int, len(users))
+	userIDs := make([]uint, 0, len(users))
+	for _, user := range users {
+		index[user.ID] = len(profiles)
+		userIDs = append(userIDs, user.ID)
+		profiles = append(profiles, CustomerProfile{UserID: user.ID, Role: user.Role, SignedUpAt: user.CreatedAt.UTC()})
+	}
+
+	var totals []struct {
+		UserID        uint
+		OrderCount    int
+		LifetimeSpend models.Money
+	}
+	if err := db.Model(&models.Order{}).Select("user_id, COUNT(*) AS order_count, COALESCE(SUM(total), 0) AS lifetime_spend").
+		Where("user_id IN ? AND status IN ?", userIDs, models.PaidOrderStatuses).
+		Group("user_id").Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	for _, total := range totals {
+		profile := &profiles[index[total.UserID]]
+		profile.OrderCount = total.OrderCount
+		profile.LifetimeSpend = total.LifetimeSpend
+	}
+
+	// The first order is picked as the one no earlier order precedes, which
+	// keeps created_at a plain column that every driver scans as a time.
+	var firsts []struct {
+		UserID    uint
+		CreatedAt time.Time
+	}
+	if err := db.Table("orders AS o").Select("o.user_id, o.created_at").
+		Where("o.deleted_at IS NULL AND o.user_id IN ? AND o.status IN ?", userIDs, models.PaidOrderStatuses).
+		Where("NOT EXISTS (SELECT 1 FROM orders e WHERE e.deleted_at IS NULL AND e.user_id = o.user_id AND e.status IN ? AND e.created_at < o.created_at)", models.PaidOrderStatuses).
+		Scan(&firsts).Error; err != nil {
+		return nil, err
+	}
+	for _, first := range firsts {
+		createdAt := first.CreatedAt.UTC()
+		profiles[index[first.UserID]].FirstOrderAt = &createdAt
+	}
+
+	var addresses []struct {
+		UserID  uint
+		Country string
+	}
+	if err := db.Model(&models.SavedAddress{}).Select("user_id", "country").Where("user_id IN ?", userIDs).Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+	for _, address := range addresses {
+		position := index[address.UserID]
+		country := strings.ToUpper(strings.TrimSpace(address.Country))
+		if country == "" || containsFold(profiles[position].Countries, country) {
+			continue
+		}
+		profiles[position].Countries = append(profiles[position].Countries, country)
+	}
+	return profiles, nil
+}
+
+// KeysForCustomer resolves the active segment keys a customer currently
+// belongs to. It is the shared resolution path for discount and CMS
+// targeting; guests resolve to no segments.
+func KeysForCustomer(db *gorm.DB, userID uint) ([]string, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	var keys []string
+	err := db.Model(&models.CustomerSegment{}).
+		Joins("JOIN customer_segment_memberships ON customer_segment_memberships.segment_id = customer_segments.id").
+		Where("customer_segment_memberships.user_id = ?", userID).
+		Where("customer_segments.is_active = ?", true).
+		Order("customer_segments.key ASC").
+		Pluck("customer_segments.key", &keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RunRefresh refreshes every active segment once and logs the segments whose
+// membership changed. It is meant to be scheduled periodically.
+func RunRefresh(db *gorm.DB, logger *log.Logger) {
+	results, err := RefreshAll(db, time.Now().UTC())
+	if err != nil {
+		if logger != nil && !errors.Is(err, context.Canceled) {
+			logger.Printf("[ERROR] Customer segment refresh failed: %v", err)
+		}
+		return
+	}
+	if logger == nil {
+		return
+	}
+	for _, result := range results {
+		if result.Added > 0 || result.Removed > 0 {
+			logger.Printf("[INFO] Customer segment refresh segment=%s added=%d removed=%d members=%d", result.Key, result.Added, result.Removed, result.Members)
+		}
+	}
+}
+
+func segmentLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrSegmentNotFound
+	}
+	return err
+}
@@ -0,0 +1,193 @@
+package segments
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newSegmentTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dbName := strings.ReplaceAll(t.Name(), "/", "_")
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", dbName)), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.SavedAddress{},
+		&models.Order{},
+		&models.CustomerSegment{},
+		&models.CustomerSegmentMembership{},
+	))
+	return db
+}
+
+func createSegmentUser(t *testing.T, db *gorm.DB, username, role string, createdAt time.Time) models.User {
+	t.Helper()
+	user := models.User{Subject: "segment-" + username, Username: username, Email: username + "@example.com", Role: role}
+	user.CreatedAt = createdAt
+	require.NoError(t, db.Create(&user).Error)
+	return user
+}
+
+func createSegmentOrder(t *testing.T, db *gorm.DB, userID uint, total float64, status string, createdAt time.Time) {
+	t.Helper()
+	order := models.Order{UserID: &userID, CheckoutSessionID: 1, Total: models.MoneyFromFloat(total), Status: status}
+	order.CreatedAt = createdAt
+	require.NoError(t, db.Create(&order).Error)
+}
+
+func intPtr(value int) *int { return &value }
+
+func moneyPtr(value float64) *models.Money {
+	amount := models.MoneyFromFloat(value)
+	return &amount
+}
+
+func TestRefreshSegmentMaterializesRuleMatches(t *testing.T) {
+	db := newSegmentTestDB(t)
+	now := time.Date(2026, 8, 10, 12, 0, 0, 0, time.UTC)
+	loyal := createSegmentUser(t, db, "loyal", "customer", now.AddDate(-1, 0, 0))
+	newcomer := createSegmentUser(t, db, "newcomer", "customer", now.AddDate(0, 0, -3))
+	admin := createSegmentUser(t, db, "admin", "admin", now.AddDate(-2, 0, 0))
+
+	createSegmentOrder(t, db, loyal.ID, 80, models.StatusPaid, now.AddDate(0, -6, 0))
+	createSegmentOrder(t, db, loyal.ID, 45, models.StatusDelivered, now.AddDate(0, -1, 0))
+	createSegmentOrder(t, db, loyal.ID, 500, models.StatusCancelled, now.AddDate(0, 0, -1))
+	createSegmentOrder(t, db, newcomer.ID, 20, models.StatusPending, now.AddDate(0, 0, -1))
+	createSegmentOrder(t, db, admin.ID, 300, models.StatusShipped, now.AddDate(0, -2, 0))
+	require.NoError(t, db.Create(&models.SavedAddress{UserID: loyal.ID, Line1: "1 Main", City: "Toronto", PostalCode: "M5V", Country: "ca"}).Error)
+	require.NoError(t, db.Create(&models.SavedAddress{UserID: admin.ID, Line1: "2 Main", City: "Austin", PostalCode: "73301", Country: "US"}).Error)
+
+	vip, err := CreateSegment(db, SegmentInput{
+		Key:   " VIP-Canada ",
+		Name:  "Canadian repeat buyers",
+		Rules: Rules{MinOrderCount: intPtr(2), MinLifetimeSpend: moneyPtr(100), Countries: []string{"CA"}, Roles: []string{"customer"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "vip-canada", vip.Key)
+
+	result, err := RefreshSegment(db, vip.ID, now)
+	require.NoError(t, err)
+	require.Equal(t, RefreshResult{SegmentID: vip.ID, Key: "vip-canada", Added: 1, Members: 1}, result)
+
+	newCustomers, err := CreateSegment(db, SegmentInput{
+		Key:   "new-customers",
+		Name:  "Recent signups without orders",
+		Rules: Rules{MaxOrderCount: intPtr(0), SignedUpWithinDays: intPtr(7)},
+	})
+	require.NoError(t, err)
+	_, err = RefreshSegment(db, newCustomers.ID, now)
+	require.NoError(t, err)
+
+	keys, err := KeysForCustomer(db, loyal.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"vip-canada"}, keys)
+	keys, err = KeysForCustomer(db, newcomer.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"new-customers"}, keys)
+	keys, err = KeysForCustomer(db, admin.ID)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	stored, err := GetSegment(db, vip.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, stored.MemberCount)
+	require.NotNil(t, stored.LastRefreshedAt)
+}
+
+func TestRefreshAllRemovesStaleAndInactiveMembership(t *testing.T) {
+	db := newSegmentTestDB(t)
+	now := time.Date(2026, 8, 10, 12, 0, 0, 0, time.UTC)
+	user := createSegmentUser(t, db, "buyer", "customer", now.AddDate(0, -3, 0))
+	createSegmentOrder(t, db, user.ID, 60, models.StatusPaid, now.AddDate(0, 0, -10))
+
+	firstOrder, err := CreateSegment(db, SegmentInput{Key: "first-order-month", Name: "First order this month", Rules: Rules{FirstOrderWithinDays: intPtr(30)}})
+	require.NoError(t, err)
+	spenders, err := CreateSegment(db, SegmentInput{Key: "spenders", Name: "Spenders", Rules: Rules{MinLifetimeSpend: moneyPtr(50)}})
+	require.NoError(t, err)
+
+	results, err := RefreshAll(db, now)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	keys, err := KeysForCustomer(db, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"first-order-month", "spenders"}, keys)
+
+	inactive := false
+	_, err = UpdateSegment(db, spenders.ID, SegmentInput{Key: "spenders", Name: "Spenders", Rules: Rules{MinLifetimeSpend: moneyPtr(50)}, IsActive: &inactive})
+	require.NoError(t, err)
+	results, err = RefreshAll(db, now.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	for _, result := range results {
+		require.Equal(t, 1, result.Removed, result.Key)
+	}
+	keys, err = KeysForCustomer(db, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	members, total, err := ListMembers(db, firstOrder.ID, 10, 0)
+	require.NoError(t, err)
+	require.Zero(t, total)
+	require.Empty(t, members)
+}
+
+func TestSegmentValidationAndKeyReuse(t *testing.T) {
+	db := newSegmentTestDB(t)
+
+	_, err := CreateSegment(db, SegmentInput{Key: "everyone", Name: "Everyone"})
+	require.ErrorIs(t, err, ErrInvalidSegment)
+	_, err = CreateSegment(db, SegmentInput{Key: "bad key", Name: "Bad", Rules: Rules{Roles: []string{"customer"}}})
+	require.ErrorIs(t, err, ErrInvalidSegment)
+	_, err = CreateSegment(db, SegmentInput{Key: "range", Name: "Range", Rules: Rules{MinOrderCount: intPtr(3), MaxOrderCount: intPtr(1)}})
+	require.ErrorIs(t, err, ErrInvalidSegment)
+	_, err = CreateSegment(db, SegmentInput{Key: "country", Name: "Country", Rules: Rules{Countries: []string{"CAN"}}})
+	require.ErrorIs(t, err, ErrInvalidSegment)
+
+	segment, err := CreateSegment(db, SegmentInput{Key: "customers", Name: "Customers", Rules: Rules{Roles: []string{"customer"}}})
+	require.NoError(t, err)
+	_, err = CreateSegment(db, SegmentInput{Key: "CUSTOMERS", Name: "Duplicate", Rules: Rules{Roles: []string{"customer"}}})
+	require.ErrorIs(t, err, ErrDuplicateSegmentKey)
+
+	require.NoError(t, DeleteSegment(db, segment.ID))
+	_, err = GetSegment(db, segment.ID)
+	require.ErrorIs(t, err, ErrSegmentNotFound)
+	_, err = CreateSegment(db, SegmentInput{Key: "customers", Name: "Customers again", Rules: Rules{Roles: []string{"customer"}}})
+	require.NoError(t, err)
+}
+
+func TestLoadProfilesPagesCustomersAndAggregatesOrders(t *testing.T) {
+	db := newSegmentTestDB(t)
+	now := time.Date(2026, 8, 10, 12, 0, 0, 0, time.UTC)
+	buyer := createSegmentUser(t, db, "buyer", "customer", now.AddDate(0, -3, 0))
+	browser := createSegmentUser(t, db, "browser", "customer", now.AddDate(0, -1, 0))
+	createSegmentOrder(t, db, buyer.ID, 30, models.StatusDelivered, now.AddDate(0, 0, -5))
+	createSegmentOrder(t, db, buyer.ID, 20, models.StatusPaid, now.AddDate(0, 0, -20))
+	createSegmentOrder(t, db, buyer.ID, 90, models.StatusCancelled, now.AddDate(0, 0, -40))
+
+	profiles, err := LoadProfiles(db, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	require.Equal(t, buyer.ID, profiles[0].UserID)
+	require.Equal(t, 2, profiles[0].OrderCount)
+	require.Equal(t, models.MoneyFromFloat(50), profiles[0].LifetimeSpend)
+	require.NotNil(t, profiles[0].FirstOrderAt)
+	require.True(t, now.AddDate(0, 0, -20).Equal(*profiles[0].FirstOrderAt))
+
+	profiles, err = LoadProfiles(db, buyer.ID, 1)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	require.Equal(t, browser.ID, profiles[0].UserID)
+	require.Zero(t, profiles[0].OrderCount)
+	require.Nil(t, profiles[0].FirstOrderAt)
+
+	profiles, err = LoadProfiles(db, browser.ID, 1)
+	require.NoError(t, err)
+	require.Empty(t, profiles)
+}
@@ -33,8 +33,8 @@ func (s *Service) PersistQuotedRates(ctx context.Context, plan RateQuotePlan, ra
 	return stored, err
 }
 
-func (s *Service) PrepareLabelPurchase(ctx context.Context, orderID, rateID uint, pkg PackageInput, key string) (PreparedLabelPurchase, error) {
-	return PrepareLabelPurchase(s.db.WithContext(ctx), orderID, rateID, pkg, key)
+func (s *Service) PrepareLabelPurchase(ctx context.Context, orderID, rateID uint, pkg PackageInput, fulfillmentOrderID *uint, key string) (PreparedLabelPurchase, error) {
+	return PrepareLabelPurchase(s.db.WithContext(ctx), orderID, rateID, pkg, fulfillmentOrderID, key)
 }
 
 func (s *Service) FinalizeLabelPurchase(ctx context.Context, shipmentID uint, result ProviderShipment, now time.Time) (models.Shipment, error) {
@@ -48,3 +48,31 @@ func (s *Service) GetShipment(ctx context.Context, shipmentID uint) (models.Ship
 func (s *Service) GetOrderShipments(ctx context.Context, orderID uint) ([]models.Shipment, error) {
 	return GetOrderShipments(s.db.WithContext(ctx), orderID)
 }
+
+func (s *Service) ListFulfillmentQueue(ctx context.Context, input FulfillmentQueueInput) ([]models.FulfillmentOrder, int64, error) {
+	return ListFulfillmentQueue(s.db.WithContext(ctx), input)
+}
+
+func (s *Service) GetFulfillmentOrder(ctx context.Context, fulfillmentOrderID uint) (models.FulfillmentOrder, error) {
+	return GetFulfillmentOrder(s.db.WithContext(ctx), fulfillmentOrderID)
+}
+
+func (s *Service) GetOrderFulfillmentOrders(ctx context.Context, orderID uint) ([]models.FulfillmentOrder, error) {
+	return GetOrderFulfillmentOrders(s.db.WithContext(ctx), orderID)
+}
+
+func (s *Service) StartPicking(ctx context.Context, fulfillmentOrderID uint, input FulfillmentStepInput, now time.Time) (models.FulfillmentOrder, error) {
+	return StartPicking(s.db.WithContext(ctx), fulfillmentOrderID, input, now)
+}
+
+func (s *Service) ConfirmPacking(ctx context.Context, fulfillmentOrderID uint, input FulfillmentStepInput, now time.Time) (models.FulfillmentOrder, error) {
+	return ConfirmPacking(s.db.WithContext(ctx), fulfillmentOrderID, input, now)
+}
+
+func (s *Service) MarkFulfillmentShipped(ctx context.Context, fulfillmentOrderID uint, input FulfillmentStepInput, now time.Time) (models.FulfillmentOrder, error) {
+	return MarkFulfillmentShipped(s.db.WithContext(ctx), fulfillmentOrderID, input, now)
+}
+
+func (s *Service) RerouteFulfillmentOrder(ctx context.Context, fulfillmentOrderID uint, input RerouteInput, now time.Time) (models.FulfillmentOrder, error) {
+	return RerouteFulfillmentOrder(s.db.WithContext(ctx), fulfillmentOrderID, input, now)
+}
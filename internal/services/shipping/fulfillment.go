@@ -0,0 +1,377 @@
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	inventoryservice "ecommerce/internal/services/inventory"
+	orderservice "ecommerce/internal/services/orders"
+	"ecommerce/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrFulfillmentOrderNotFound = errors.New("fulfillment order not found")
+	ErrInvalidFulfillmentQuery  = errors.New("invalid fulfillment queue filter")
+	// ErrFulfillmentStepNotAllowed rejects a step the fulfillment order is not
+	// ready for, such as packing before the pick list was printed.
+	ErrFulfillmentStepNotAllowed = errors.New("fulfillment order is not ready for this step")
+	// ErrFulfillmentPackingMismatch rejects a packing confirmation that packs
+	// nothing, or more units or other lines than the fulfillment order holds.
+	ErrFulfillmentPackingMismatch = errors.New("packed quantities must match the fulfillment lines")
+	// ErrInvalidRerouteLocation rejects routing a fulfillment order to a
+	// location that cannot sell stock.
+	ErrInvalidRerouteLocation = errors.New("fulfillment orders can only be routed to an active, sellable location")
+)
+
+type FulfillmentQueueInput struct {
+	// Status filters on one fulfillment status. Empty lists every order the
+	// warehouse still has to ship.
+	Status       string
+	LocationCode string
+	Limit        int
+	Offset       int
+}
+
+type FulfillmentStepInput struct {
+	Actor         string
+	Note          string
+	CorrelationID string
+	// Lines confirms the packed quantity per fulfillment line. Empty packs
+	// every line in full.
+	Lines []PackedLineInput
+}
+
+type PackedLineInput struct {
+	LineID   uint
+	Quantity int
+}
+
+type RerouteInput struct {
+	LocationCode string
+	Reason       string
+	Actor        string
+}
+
+// ListFulfillmentQueue returns fulfillment orders oldest first, so the
+// warehouse works them in the order they were paid. The default queue leaves
+// out orders held for risk review.
+func ListFulfillmentQueue(db *gorm.DB, input FulfillmentQueueInput) ([]models.FulfillmentOrder, int64, error) {
+	query := db.Model(&models.FulfillmentOrder{})
+	if status := strings.ToUpper(strings.TrimSpace(input.Status)); status != "" {
+		if !models.IsValidFulfillmentStatus(status) {
+			return nil, 0, ErrInvalidFulfillmentQuery
+		}
+		query = query.Where("status = ?", status)
+	} else {
+		query = query.Where("status IN ?", []string{
+			models.FulfillmentStatusUnfulfilled,
+			models.FulfillmentStatusPicking,
+			models.FulfillmentStatusPacked,
+			models.FulfillmentStatusLabelPurchased,
+		}).Where("order_id NOT IN (?)", db.Model(&models.Order{}).Select("id").Where("risk_review_status = ?", models.RiskReviewPending))
+	}
+	if location := strings.TrimSpace(input.LocationCode); location != "" {
+		query = query.Where("location_code = ?", location)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if input.Limit <= 0 {
+		input.Limit = 20
+	}
+	var fulfillments []models.FulfillmentOrder
+	if err := preloadFulfillmentLines(query).Order("created_at ASC").Order("id ASC").
+		Limit(input.Limit).Offset(input.Offset).Find(&fulfillments).Error; err != nil {
+		return nil, 0, err
+	}
+	return fulfillments, total, nil
+}
+
+func GetFulfillmentOrder(db *gorm.DB, fulfillmentOrderID uint) (models.FulfillmentOrder, error) {
+	var fulfillment models.FulfillmentOrder
+	err := preloadFulfillmentLines(db).First(&fulfillment, fulfillmentOrderID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.FulfillmentOrder{}, ErrFulfillmentOrderNotFound
+	}
+	return fulfillment, err
+}
+
+func GetOrderFulfillmentOrders(db *gorm.DB, orderID uint) ([]models.FulfillmentOrder, error) {
+	var fulfillments []models.FulfillmentOrder
+	err := preloadFulfillmentLines(db).Where("order_id = ?", orderID).Order("created_at ASC").Order("id ASC").Find(&fulfillments).Error
+	return fulfillments, err
+}
+
+// StartPicking prints the pick list: the fulfillment order moves to PICKING
+// and the picker and time are recorded against it. Orders held for risk
+// review and pre-ordered units before their release are not picked.
+func StartPicking(db *gorm.DB, fulfillmentOrderID uint, input FulfillmentStepInput, now time.Time) (models.FulfillmentOrder, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		fulfillment, err := lockFulfillmentOrder(tx, fulfillmentOrderID)
+		if err != nil {
+			return err
+		}
+		if fulfillment.Status != models.FulfillmentStatusUnfulfilled {
+			return ErrFulfillmentStepNotAllowed
+		}
+		if err := checkRiskReview(tx, fulfillment.OrderID); err != nil {
+			return err
+		}
+		itemIDs := make([]uint, 0, len(fulfillment.Lines))
+		for _, line := range fulfillment.Lines {
+			itemIDs = append(itemIDs, line.OrderItemID)
+		}
+		var items []models.OrderItem
+		if err := tx.Where("id IN ?", itemIDs).Find(&items).Error; err != nil {
+			return err
+		}
+		unreleased, err := orderservice.UnreleasedPreorderItems(tx, items, now)
+		if err != nil {
+			return err
+		}
+		if len(unreleased) > 0 {
+			return orderservice.ErrPreorderNotReleased
+		}
+		timestamp := now.UTC()
+		return tx.Model(&models.FulfillmentOrder{}).Where("id = ?", fulfillment.ID).Updates(map[string]any{
+			"status": models.FulfillmentStatusPicking, "pick_list_at": timestamp, "picked_by": fulfillmentActor(input.Actor), "updated_at": timestamp,
+		}).Error
+	})
+	if err != nil {
+		return models.FulfillmentOrder{}, err
+	}
+	return GetFulfillmentOrder(db, fulfillmentOrderID)
+}
+
+// ConfirmPacking records what went into the box. Lines may be packed short
+// when stock is missing: the packed units go on with this fulfillment order
+// and the rest are split onto a new one at the same location that waits for
+// the next pick.
+func ConfirmPacking(db *gorm.DB, fulfillmentOrderID uint, input FulfillmentStepInput, now time.Time) (models.FulfillmentOrder, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		fulfillment, err := lockFulfillmentOrder(tx, fulfillmentOrderID)
+		if err != nil {
+			return err
+		}
+		if fulfillment.Status != models.FulfillmentStatusPicking {
+			return ErrFulfillmentStepNotAllowed
+		}
+		packed := make(map[uint]int, len(input.Lines))
+		for _, line := range input.Lines {
+			packed[line.LineID] += line.Quantity
+		}
+		timestamp := now.UTC()
+		var remainder []models.FulfillmentOrderLine
+		total := 0
+		for _, line := range fulfillment.Lines {
+			quantity, confirmed := packed[line.ID]
+			if len(input.Lines) == 0 {
+				quantity, confirmed = line.Quantity, true
+			}
+			delete(packed, line.ID)
+			if !confirmed {
+				quantity = 0
+			}
+			if quantity < 0 || quantity > line.Quantity {
+				return ErrFulfillmentPackingMismatch
+			}
+			total += quantity
+			if left := line.Quantity - quantity; left > 0 {
+				remainder = append(remainder, models.FulfillmentOrderLine{
+					OrderItemID: line.OrderItemID, ProductVariantID: line.ProductVariantID,
+					VariantSKU: line.VariantSKU, VariantTitle: line.VariantTitle, Quantity: left,
+				})
+			}
+			if quantity == 0 {
+				if err := tx.Delete(&models.FulfillmentOrderLine{}, line.ID).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Model(&models.FulfillmentOrderLine{}).Where("id = ?", line.ID).Updates(map[string]any{
+				"quantity": quantity, "quantity_picked": quantity, "quantity_packed": quantity, "updated_at": timestamp,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if len(packed) > 0 || total == 0 {
+			return ErrFulfillmentPackingMismatch
+		}
+		if len(remainder) > 0 {
+			if err := tx.Create(&models.FulfillmentOrder{
+				OrderID: fulfillment.OrderID, LocationCode: fulfillment.LocationCode,
+				Status: models.FulfillmentStatusUnfulfilled, Lines: remainder,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.FulfillmentOrder{}).Where("id = ?", fulfillment.ID).Updates(map[string]any{
+			"status": models.FulfillmentStatusPacked, "packed_at": timestamp, "packed_by": fulfillmentActor(input.Actor),
+			"packing_note": strings.TrimSpace(input.Note), "updated_at": timestamp,
+		}).Error
+	})
+	if err != nil {
+		return models.FulfillmentOrder{}, err
+	}
+	return GetFulfillmentOrder(db, fulfillmentOrderID)
+}
+
+// MarkFulfillmentShipped records the hand-over to the carrier. The order
+// moves to PARTIALLY_SHIPPED while other units are still to go, and to
+// SHIPPED once none are.
+func MarkFulfillmentShipped(db *gorm.DB, fulfillmentOrderID uint, input FulfillmentStepInput, now time.Time) (models.FulfillmentOrder, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		fulfillment, err := lockFulfillmentOrder(tx, fulfillmentOrderID)
+		if err != nil {
+			return err
+		}
+		if fulfillment.Status != models.FulfillmentStatusLabelPurchased {
+			return ErrFulfillmentStepNotAllowed
+		}
+		if err := advanceFulfillment(tx, fulfillment.ID, models.FulfillmentStatusShipped, now); err != nil {
+			return err
+		}
+		return orderservice.SyncFulfillmentStatus(tx, fulfillment.OrderID, orderservice.StatusTransition{
+			Path: orderservice.PathFulfillment, Source: "fulfillment",
+			Reason: "fulfillment_shipped", Actor: fulfillmentActor(input.Actor), CorrelationID: input.CorrelationID,
+		})
+	})
+	if err != nil {
+		return models.FulfillmentOrder{}, err
+	}
+	return GetFulfillmentOrder(db, fulfillmentOrderID)
+}
+
+// RerouteFulfillmentOrder overrides the routing rules for a fulfillment order
+// nobody has started picking. The order's stock for its lines moves to the
+// new location, which must hold enough of it, and the explanation records
+// who moved it and why.
+func RerouteFulfillmentOrder(db *gorm.DB, fulfillmentOrderID uint, input RerouteInput, now time.Time) (models.FulfillmentOrder, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		fulfillment, err := lockFulfillmentOrder(tx, fulfillmentOrderID)
+		if err != nil {
+			return err
+		}
+		if fulfillment.Status != models.FulfillmentStatusUnfulfilled {
+			return ErrFulfillmentStepNotAllowed
+		}
+		to, err := inventoryservice.ResolveLocation(tx, input.LocationCode)
+		if err != nil {
+			return err
+		}
+		if !to.CountsTowardsStorefront() {
+			return ErrInvalidRerouteLocation
+		}
+		if to.Code == fulfillment.LocationCode {
+			return nil
+		}
+		// The current location may since have been deactivated, so it is
+		// looked up directly rather than resolved.
+		var from models.StockLocation
+		if err := tx.Where("code = ?", fulfillment.LocationCode).Limit(1).Find(&from).Error; err != nil {
+			return err
+		}
+		if err := orderservice.RelocateFulfillmentStock(tx, fulfillment.OrderID, fulfillment.Lines, from, to); err != nil {
+			return err
+		}
+		explanation := fmt.Sprintf("Rerouted from %s to %s by %s", fulfillment.LocationCode, to.Code, fulfillmentActor(input.Actor))
+		if reason := strings.TrimSpace(input.Reason); reason != "" {
+			explanation += ": " + reason
+		}
+		return tx.Model(&models.FulfillmentOrder{}).Where("id = ?", fulfillment.ID).Updates(map[string]any{
+			"location_code": to.Code, "routing_explanation": explanation + ".", "updated_at": now.UTC(),
+		}).Error
+	})
+	if err != nil {
+		return models.FulfillmentOrder{}, err
+	}
+	return GetFulfillmentOrder(db, fulfillmentOrderID)
+}
+
+// advanceFulfillment moves a fulfillment order forward and stamps the time of
+// the step. Carrier events can arrive late or twice, so moving to a status the
+// order has already passed is a no-op. Units count as fulfilled on the order
+// lines when the parcel leaves with the carrier.
+func advanceFulfillment(tx *gorm.DB, fulfillmentOrderID uint, status string, now time.Time) error {
+	timestamp := now.UTC()
+	updates := map[string]any{"status": status, "updated_at": timestamp}
+	var from []string
+	switch status {
+	case models.FulfillmentStatusLabelPurchased:
+		updates["label_purchased_at"] = timestamp
+		from = []string{models.FulfillmentStatusPacked}
+	case models.FulfillmentStatusShipped:
+		updates["shipped_at"] = timestamp
+		from = []string{models.FulfillmentStatusLabelPurchased}
+	case models.FulfillmentStatusDelivered:
+		updates["delivered_at"] = timestamp
+		from = []string{models.FulfillmentStatusLabelPurchased, models.FulfillmentStatusShipped}
+	default:
+		return ErrFulfillmentStepNotAllowed
+	}
+	var current models.FulfillmentOrder
+	if err := tx.Select("id", "status").First(&current, fulfillmentOrderID).Error; err != nil {
+		return err
+	}
+	if !slices.Contains(from, current.Status) {
+		return nil
+	}
+	if err := tx.Model(&models.FulfillmentOrder{}).Where("id = ? AND status = ?", fulfillmentOrderID, current.Status).Updates(updates).Error; err != nil {
+		return err
+	}
+	if current.Status != models.FulfillmentStatusLabelPurchased {
+		return nil
+	}
+	var lines []models.FulfillmentOrderLine
+	if err := tx.Where("fulfillment_order_id = ?", fulfillmentOrderID).Find(&lines).Error; err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if err := tx.Model(&models.OrderItem{}).Where("id = ?", line.OrderItemID).
+			Update("quantity_fulfilled", gorm.Expr("quantity_fulfilled + ?", line.Quantity)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRiskReview rejects warehouse work on an order held for risk review.
+func checkRiskReview(tx *gorm.DB, orderID uint) error {
+	var order models.Order
+	if err := tx.Select("id", "risk_review_status").First(&order, orderID).Error; err != nil {
+		return err
+	}
+	if order.RiskReviewStatus == models.RiskReviewPending {
+		return orderservice.ErrOrderRiskReviewPending
+	}
+	return nil
+}
+
+func lockFulfillmentOrder(tx *gorm.DB, fulfillmentOrderID uint) (models.FulfillmentOrder, error) {
+	var fulfillment models.FulfillmentOrder
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Lines").First(&fulfillment, fulfillmentOrderID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.FulfillmentOrder{}, ErrFulfillmentOrderNotFound
+	}
+	return fulfillment, err
+}
+
+func preloadFulfillmentLines(db *gorm.DB) *gorm.DB {
+	return db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	})
+}
+
+func fulfillmentActor(actor string) string {
+	if actor = strings.TrimSpace(actor); actor != "" {
+		return actor
+	}
+	return "admin"
+}
@@ -0,0 +1,364 @@
+package shipping
+
+import (
+	"testing"
+	"time"
+
+	inventoryservice "ecommerce/internal/services/inventory"
+	orderservice "ecommerce/internal/services/orders"
+	paymentservice "ecommerce/internal/services/payments"
+	"ecommerce/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newFulfillmentTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := newShippingServiceTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&models.CheckoutSession{},
+		&models.Product{},
+		&models.ProductVariant{},
+		&models.InventoryItem{},
+		&models.InventoryLevel{},
+		&models.StockLocation{},
+		&models.OrderRoutingRule{},
+		&models.InventoryMovement{},
+		&models.InventoryReservation{},
+		&models.InventoryThreshold{},
+		&models.InventoryAlert{},
+		&models.Order{},
+		&models.OrderItem{},
+		&models.OrderStatusHistory{},
+		&models.OrderCheckoutSnapshot{},
+		&models.PaymentIntent{},
+		&models.FulfillmentOrder{},
+		&models.FulfillmentOrderLine{},
+		&models.ShipmentPackageLine{},
+	))
+	return db
+}
+
+// seedPaidFulfillmentOrder pays for an order of two units so that the order
+// service opens its fulfillment order.
+func seedPaidFulfillmentOrder(t *testing.T, db *gorm.DB) (models.Order, models.PaymentIntent) {
+	t.Helper()
+	product := models.Product{SKU: "FULFIL", Name: "Fulfil", Price: models.MoneyFromFloat(10), Stock: 5, IsPublished: true}
+	require.NoError(t, db.Create(&product).Error)
+	variant := models.ProductVariant{ProductID: product.ID, SKU: "FULFIL-default", Title: "Fulfil", Price: models.MoneyFromFloat(10), Stock: 5, Position: 1, IsPublished: true}
+	require.NoError(t, db.Create(&variant).Error)
+	session := models.CheckoutSession{PublicToken: t.Name(), Status: models.CheckoutSessionStatusActive, ExpiresAt: time.Now().Add(time.Hour).UTC(), LastSeenAt: time.Now().UTC()}
+	require.NoError(t, db.Create(&session).Error)
+	order := models.Order{CheckoutSessionID: session.ID, Total: models.MoneyFromFloat(20), Status: models.StatusPending}
+	require.NoError(t, db.Create(&order).Error)
+	require.NoError(t, db.Create(&models.OrderItem{
+		OrderID: order.ID, ProductVariantID: variant.ID, VariantSKU: variant.SKU, VariantTitle: variant.Title, Quantity: 2, Price: models.MoneyFromFloat(10),
+	}).Error)
+	intent := models.PaymentIntent{OrderID: order.ID, Provider: "mock", Status: models.PaymentIntentStatusAuthorized, AuthorizedAmount: order.Total, Currency: "USD"}
+	require.NoError(t, db.Create(&intent).Error)
+	require.NoError(t, orderservice.ApplyStatusTransition(db, &order, orderservice.StatusTransition{To: models.StatusPaid, Path: orderservice.PathPayment}))
+	return order, intent
+}
+
+func TestFulfillmentOrderMovesThroughPickPackLabelAndShip(t *testing.T) {
+	db := newFulfillmentTestDB(t)
+	now := time.Date(2026, 8, 23, 9, 0, 0, 0, time.UTC)
+	order, intent := seedPaidFulfillmentOrder(t, db)
+
+	queue, total, err := ListFulfillmentQueue(db, FulfillmentQueueInput{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	fulfillment := queue[0]
+	assert.Equal(t, order.ID, fulfillment.OrderID)
+	assert.Equal(t, models.FulfillmentStatusUnfulfilled, fulfillment.Status)
+	require.Len(t, fulfillment.Lines, 1)
+	line := fulfillment.Lines[0]
+	assert.Equal(t, 2, line.Quantity)
+
+	step := FulfillmentStepInput{Actor: "picker@example.com"}
+	_, err = ConfirmPacking(db, fulfillment.ID, step, now)
+	require.ErrorIs(t, err, ErrFulfillmentStepNotAllowed)
+	fulfillment, err = StartPicking(db, fulfillment.ID, step, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.FulfillmentStatusPicking, fulfillment.Status)
+	assert.Equal(t, "picker@example.com", fulfillment.PickedBy)
+	require.NotNil(t, fulfillment.PickListAt)
+
+	_, err = ConfirmPacking(db, fulfillment.ID, FulfillmentStepInput{Lines: []PackedLineInput{{LineID: line.ID, Quantity: 3}}}, now)
+	require.ErrorIs(t, err, ErrFulfillmentPackingMismatch)
+	fulfillment, err = ConfirmPacking(db, fulfillment.ID, FulfillmentStepInput{
+		Actor: "packer@example.com", Note: "gift wrap", Lines: []PackedLineInput{{LineID: line.ID, Quantity: 2}},
+	}, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.FulfillmentStatusPacked, fulfillment.Status)
+	assert.Equal(t, "gift wrap", fulfillment.PackingNote)
+	assert.Equal(t, 2, fulfillment.Lines[0].QuantityPacked)
+
+	snapshot := models.OrderCheckoutSnapshot{
+		CheckoutSessionID: order.CheckoutSessionID, Currency: "USD", PaymentProviderID: "mock", ShippingProviderID: "ship",
+		ExpiresAt: now.Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&snapshot).Error)
+	rate := models.ShipmentRate{OrderID: order.ID, SnapshotID: snapshot.ID, Provider: "ship", ProviderRateID: "r1", ServiceCode: "standard", ServiceName: "Standard", Currency: "USD"}
+	require.NoError(t, db.Create(&rate).Error)
+	prepared, err := PrepareLabelPurchase(db, order.ID, rate.ID, PackageInput{}, &fulfillment.ID, "label-1")
+	require.NoError(t, err)
+	shipment, err := FinalizeLabelPurchase(db, prepared.Shipment.ID, ProviderShipment{ProviderShipmentID: "ps-1", TrackingNumber: "TRK1"}, now)
+	require.NoError(t, err)
+	require.NotNil(t, shipment.FulfillmentOrderID)
+	require.Len(t, shipment.Packages, 1)
+	require.Len(t, shipment.Packages[0].Lines, 1)
+	assert.Equal(t, line.ID, shipment.Packages[0].Lines[0].FulfillmentOrderLineID)
+	assert.Equal(t, 2, shipment.Packages[0].Lines[0].Quantity)
+
+	// Shipping waits for the payment to be captured.
+	_, err = MarkFulfillmentShipped(db, fulfillment.ID, step, now)
+	require.ErrorIs(t, err, orderservice.ErrOrderPaymentNotCaptured)
+	fulfillment, err = GetFulfillmentOrder(db, fulfillment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.FulfillmentStatusLabelPurchased, fulfillment.Status)
+
+	require.NoError(t, db.Model(&intent).Update("captured_amount", order.Total).Error)
+	fulfillment, err = MarkFulfillmentShipped(db, fulfillment.ID, step, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.FulfillmentStatusShipped, fulfillment.Status)
+	require.NoError(t, db.First(&order, order.ID).Error)
+	assert.Equal(t, models.StatusShipped, order.Status)
+
+	queue, total, err = ListFulfillmentQueue(db, FulfillmentQueueInput{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, queue)
+}
+
+func TestCancellingPaidOrderCancelsOpenFulfillment(t *testing.T) {
+	db := newFulfillmentTestDB(t)
+	order, _ := seedPaidFulfillmentOrder(t, db)
+
+	require.NoError(t, orderservice.ApplyStatusTransition(db, &order, orderservice.StatusTransition{To: models.StatusCancelled, Path: orderservice.PathAdmin}))
+
+	fulfillments, err := GetOrderFulfillmentOrders(db, order.ID)
+	require.NoError(t, err)
+	require.Len(t, fulfillments, 1)
+	assert.Equal(t, models.FulfillmentStatusCancelled, fulfillments[0].Status)
+	assert.NotNil(t, fulfillments[0].CancelledAt)
+}
+
+func TestOrderHeldForRiskReviewIsKeptOutOfTheWarehouse(t *testing.T) {
+	db := newFulfillmentTestDB(t)
+	now := time.Date(2026, 8, 23, 9, 0, 0, 0, time.UTC)
+	order, _ := seedPaidFulfillmentOrder(t, db)
+	require.NoError(t, db.Model(&models.Order{}).Where("id = ?", order.ID).Update("risk_review_status", models.RiskReviewPending).Error)
+	fulfillments, err := GetOrderFulfillmentOrders(db, order.ID)
+	require.NoError(t, err)
+	require.Len(t, fulfillments, 1)
+
+	_, total, err := ListFulfillmentQueue(db, FulfillmentQueueInput{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	_, err = StartPicking(db, fulfillments[0].ID, FulfillmentStepInput{Actor: "picker"}, now)
+	assert.ErrorIs(t, err, orderservice.ErrOrderRiskReviewPending)
+
+	snapshot := models.OrderCheckoutSnapshot{
+		CheckoutSessionID: order.CheckoutSessionID, Currency: "USD", PaymentProviderID: "mock", ShippingProviderID: "ship",
+		ExpiresAt: now.Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&snapshot).Error)
+	rate := models.ShipmentRate{OrderID: order.ID, SnapshotID: snapshot.ID, Provider: "ship", ProviderRateID: "r1", ServiceCode: "standard", ServiceName: "Standard", Currency: "USD"}
+	require.NoError(t, db.Create(&rate).Error)
+	_, err = PrepareLabelPurchase(db, order.ID, rate.ID, PackageInput{}, nil, "label-held")
+	assert.ErrorIs(t, err, orderservice.ErrOrderRiskReviewPending)
+
+	require.NoError(t, db.Model(&models.Order{}).Where("id = ?", order.ID).Update("risk_review_status", models.RiskReviewApproved).Error)
+	_, total, err = ListFulfillmentQueue(db, FulfillmentQueueInput{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	_, err = StartPicking(db, fulfillments[0].ID, FulfillmentStepInput{Actor: "picker"}, now)
+	require.NoError(t, err)
+}
+
+func TestPreorderIsNotPickedBeforeRelease(t *testing.T) {
+	db := newFulfillmentTestDB(t)
+	now := time.Date(2026, 8, 23, 9, 0, 0, 0, time.UTC)
+	order, _ := seedPaidFulfillmentOrder(t, db)
+	release := now.Add(7 * 24 * time.Hour)
+	require.NoError(t, db.Model(&models.ProductVariant{}).Where("sku = ?", "FULFIL-default").
+		Updates(map[string]any{"inventory_policy": models.InventoryPolicyPreorder, "preorder_release_at": release}).Error)
+	require.NoError(t, db.Model(&models.OrderItem{}).Where("order_id = ?", order.ID).Update("inventory_policy", models.InventoryPolicyPreorder).Error)
+	fulfillments, err := GetOrderFulfillmentOrders(db, order.ID)
+	require.NoError(t, err)
+	require.Len(t, fulfillments, 1)
+
+	_, err = StartPicking(db, fulfillments[0].ID, FulfillmentStepInput{Actor: "picker"}, now)
+	assert.ErrorIs(t, err, orderservice.ErrPreorderNotReleased)
+
+	picked, err := StartPicking(db, fulfillments[0].ID, FulfillmentStepInput{Actor: "picker"}, release)
+	require.NoError(t, err)
+	assert.Equal(t, models.FulfillmentStatusPicking, picked.Status)
+}
+
+func TestTrackingEventOnCancelledOrderIsRecordedWithoutStatusChange(t *testing.T) {
+	db := newFulfillmentTestDB(t)
+	order, _ := seedPaidFulfillmentOrder(t, db)
+	shipment := models.Shipment{
+		OrderID: order.ID, SnapshotID: 1, Provider: "dummy", ShipmentRateID: 1, ProviderShipmentID: "ship-late",
+		Status: models.ShipmentStatusLabelPurchased, Currency: "USD", ServiceCode: "ground", ServiceName: "Ground",
+	}
+	require.NoError(t, db.Create(&shipment).Error)
+	require.NoError(t, orderservice.ApplyStatusTransition(db, &order, orderservice.StatusTransition{To: models.StatusCancelled, Path: orderservice.PathAdmin}))
+
+	updated, duplicate, err := ApplyTrackingEvent(db, TrackingWebhookEvent{
+		Provider: "dummy", ProviderEventID: "evt-late", ProviderShipmentID: "ship-late",
+		Status: models.ShipmentStatusInTransit, OccurredAt: time.Now().UTC(),
+	}, "corr-late")
+	require.NoError(t, err)
+	assert.False(t, duplicate)
+	assert.Equal(t, models.ShipmentStatusInTransit, updated.Status)
+
+	var events int64
+	require.NoError(t, db.Model(&models.TrackingEvent{}).Where("shipment_id = ?", shipment.ID).Count(&events).Error)
+	assert.EqualValues(t, 1, events)
+	require.NoError(t, db.First(&order, order.ID).Error)
+	assert.Equal(t, models.StatusCancelled, order.Status)
+}
+
+func TestTrackingEventOnOrderThatMayNotShipIsRecordedWithoutStatusChange(t *testing.T) {
+	db := newFulfillmentTestDB(t)
+	order, _ := seedPaidFulfillmentOrder(t, db)
+	shipment := models.Shipment{
+		OrderID: order.ID, SnapshotID: 1, Provider: "dummy", ShipmentRateID: 1, ProviderShipmentID: "ship-held",
+		Status: models.ShipmentStatusLabelPurchased, Currency: "USD", ServiceCode: "ground", ServiceName: "Ground",
+	}
+	require.NoError(t, db.Create(&shipment).Error)
+	require.NoError(t, db.Model(&models.Order{}).Where("id = ?", order.ID).Update("risk_review_status", models.RiskReviewPending).Error)
+
+	_, _, err := ApplyTrackingEvent(db, TrackingWebhookEvent{
+		Provider: "dummy", ProviderEventID: "evt-held", ProviderShipmentID: "ship-held",
+		Status: models.ShipmentStatusInTransit, OccurredAt: time.Now().UTC(),
+	}, "corr-held")
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&models.Order{}).Where("id = ?", order.ID).Update("risk_review_status", models.RiskReviewApproved).Error)
+	// Still uncaptured, so the order may not ship yet either.
+	_, _, err = ApplyTrackingEvent(db, TrackingWebhookEvent{
+		Provider: "dummy", ProviderEventID: "evt-uncaptured", ProviderShipmentID: "ship-held",
+		Status: models.ShipmentStatusInTransit, OccurredAt: time.Now().UTC(),
+	}, "corr-uncaptured")
+	require.NoError(t, err)
+
+	var events int64
+	require.NoError(t, db.Model(&models.TrackingEvent{}).Where("shipment_id = ?", shipment.ID).Count(&events).Error)
+	assert.EqualValues(t, 2, events)
+	require.NoError(t, db.First(&order, order.ID).Error)
+	assert.Equal(t, models.StatusPaid, order.Status)
+}
+
+func TestRerouteMovesCommittedStockToTheNewLocation(t *testing.T) {
+	db := newFulfillmentTestDB(t)
+	now := time.Date(2026, 8, 26, 9, 0, 0, 0, time.UTC)
+	order, _ := seedPaidFulfillmentOrder(t, db)
+	fulfillments, err := GetOrderFulfillmentOrders(db, order.ID)
+	require.NoError(t, err)
+	require.Len(t, fulfillments, 1)
+	fulfillment := fulfillments[0]
+	assert.Equal(t, "Routed to default: can ship the whole order; no destination address to compare; location priority 0.", fulfillment.RoutingExplanation)
+
+	store, err := inventoryservice.CreateLocation(db, inventoryservice.LocationInput{Code: "store-1", Name: "High Street", Kind: models.StockLocationKindStore})
+	require.NoError(t, err)
+	variantID := fulfillment.Lines[0].ProductVariantID
+	_, err = inventoryservice.ApplyMovement(db, inventoryservice.MovementInput{ProductVariantID: variantID, StockLocationID: store.ID, MovementType: "RESTOCK", QuantityDelta: 1})
+	require.NoError(t, err)
+	_, err = RerouteFulfillmentOrder(db, fulfillment.ID, RerouteInput{LocationCode: store.Code}, now)
+	var availabilityErr *inventoryservice.InsufficientAvailabilityError
+	require.ErrorAs(t, err, &availabilityErr)
+	sellable := false
+	_, err = inventoryservice.CreateLocation(db, inventoryservice.LocationInput{Code: "returns", Name: "Returns", Sellable: &sellable})
+	require.NoError(t, err)
+	_, err = RerouteFulfillmentOrder(db, fulfillment.ID, RerouteInput{LocationCode: "returns"}, now)
+	require.ErrorIs(t, err, ErrInvalidRerouteLocation)
+
+	_, err = inventoryservice.ApplyMovement(db, inventoryservice.MovementInput{ProductVariantID: variantID, StockLocationID: store.ID, MovementType: "RESTOCK", QuantityDelta: 2})
+	require.NoError(t, err)
+	fulfillment, err = RerouteFulfillmentOrder(db, fulfillment.ID, RerouteInput{LocationCode: store.Code, Reason: "customer collects in store", Actor: "ops@example.com"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, store.Code, fulfillment.LocationCode)
+	assert.Equal(t, "Rerouted from default to store-1 by ops@example.com: customer collects in store.", fulfillment.RoutingExplanation)
+	levels, err := inventoryservice.GetLocationLevels(db, variantID)
+	require.NoError(t, err)
+	require.Len(t, levels, 2)
+	assert.Equal(t, 5, levels[0].OnHand)
+	assert.Equal(t, 1, levels[1].OnHand)
+
+	_, err = StartPicking(db, fulfillment.ID, FulfillmentStepInput{}, now)
+	require.NoError(t, err)
+	_, err = RerouteFulfillmentOrder(db, fulfillment.ID, RerouteInput{LocationCode: models.DefaultStockLocationCode}, now)
+	require.ErrorIs(t, err, ErrFulfillmentStepNotAllowed)
+}
+
+func TestPartialPackShipsSplitAndCancelledRemainderCompletesOrder(t *testing.T) {
+	db := newFulfillmentTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.PaymentTransaction{}))
+	now := time.Date(2026, 8, 24, 9, 0, 0, 0, time.UTC)
+	order, intent := seedPaidFulfillmentOrder(t, db)
+	fulfillments, err := GetOrderFulfillmentOrders(db, order.ID)
+	require.NoError(t, err)
+	first := fulfillments[0]
+
+	// Nothing has been packed, so nothing may be captured yet.
+	capturable, err := orderservice.CapturableAmount(db, order)
+	require.NoError(t, err)
+	require.NotNil(t, capturable)
+	assert.Zero(t, *capturable)
+
+	_, err = StartPicking(db, first.ID, FulfillmentStepInput{}, now)
+	require.NoError(t, err)
+	first, err = ConfirmPacking(db, first.ID, FulfillmentStepInput{Lines: []PackedLineInput{{LineID: first.Lines[0].ID, Quantity: 1}}}, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.FulfillmentStatusPacked, first.Status)
+	assert.Equal(t, 1, first.Lines[0].Quantity)
+
+	fulfillments, err = GetOrderFulfillmentOrders(db, order.ID)
+	require.NoError(t, err)
+	require.Len(t, fulfillments, 2)
+	rest := fulfillments[1]
+	assert.Equal(t, models.FulfillmentStatusUnfulfilled, rest.Status)
+	assert.Equal(t, first.LocationCode, rest.LocationCode)
+	require.Len(t, rest.Lines, 1)
+	assert.Equal(t, 1, rest.Lines[0].Quantity)
+
+	// Only the packed unit can be captured.
+	capturable, err = orderservice.CapturableAmount(db, order)
+	require.NoError(t, err)
+	assert.Equal(t, models.MoneyFromFloat(10), *capturable)
+	_, _, err = paymentservice.PrepareCapturePaymentIntent(db, &intent, &order.Total, capturable, "capture-all", "")
+	require.ErrorIs(t, err, paymentservice.ErrAmountExceedsAvailable)
+	_, request, err := paymentservice.PrepareCapturePaymentIntent(db, &intent, nil, capturable, "capture-packed", "")
+	require.NoError(t, err)
+	assert.Equal(t, models.MoneyFromFloat(10), request.Amount)
+	require.NoError(t, db.Model(&intent).Update("captured_amount", request.Amount).Error)
+
+	require.NoError(t, db.Model(&models.FulfillmentOrder{}).Where("id = ?", first.ID).Update("status", models.FulfillmentStatusLabelPurchased).Error)
+	_, err = MarkFulfillmentShipped(db, first.ID, FulfillmentStepInput{}, now)
+	require.NoError(t, err)
+	require.NoError(t, db.Preload("Items").First(&order, order.ID).Error)
+	assert.Equal(t, models.StatusPartiallyShipped, order.Status)
+	assert.Equal(t, 1, order.Items[0].QuantityFulfilled)
+	assert.Equal(t, 1, order.Items[0].RemainingQuantity())
+
+	// The missing unit will not arrive: cancelling it completes the order.
+	err = orderservice.CancelItemQuantity(db, order.ID, order.Items[0].ID, 2, orderservice.StatusTransition{Path: orderservice.PathFulfillment}, now)
+	require.ErrorIs(t, err, orderservice.ErrItemQuantityNotCancellable)
+	require.NoError(t, orderservice.CancelItemQuantity(db, order.ID, order.Items[0].ID, 1, orderservice.StatusTransition{Path: orderservice.PathFulfillment}, now))
+	require.NoError(t, db.Preload("Items").First(&order, order.ID).Error)
+	assert.Equal(t, models.StatusShipped, order.Status)
+	assert.Equal(t, 1, order.Items[0].QuantityCancelled)
+	assert.Zero(t, order.Items[0].RemainingQuantity())
+
+	rest, err = GetFulfillmentOrder(db, rest.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.FulfillmentStatusCancelled, rest.Status)
+	var variant models.ProductVariant
+	require.NoError(t, db.First(&variant, order.Items[0].ProductVariantID).Error)
+	assert.Equal(t, 4, variant.Stock)
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	orderservice "ecommerce/internal/services/orders"
-	paymentservice "ecommerce/internal/services/payments"
 	"ecommerce/models"
 
 	"gorm.io/gorm"
@@ -140,11 +139,13 @@ type PreparedLabelPurchase struct {
 	AlreadyDone  bool
 }
 
-func PrepareLabelPurchase(db *gorm.DB, orderID, rateID uint, pkg PackageInput, idempotencyKey string) (PreparedLabelPurchase, error) {
+// PrepareLabelPurchase drafts the shipment for a label. With a fulfillment
+// order, which must be packed, the package records the lines it carries.
+func PrepareLabelPurchase(db *gorm.DB, orderID, rateID uint, pkg PackageInput, fulfillmentOrderID *uint, idempotencyKey string) (PreparedLabelPurchase, error) {
 	var prepared PreparedLabelPurchase
 	err := db.Transaction(func(tx *gorm.DB) error {
 		var err error
-		prepared.Shipment, prepared.Rate, prepared.Snapshot, prepared.EffectiveKey, prepared.AlreadyDone, err = prepareLabelPurchase(tx, orderID, rateID, pkg, idempotencyKey)
+		prepared.Shipment, prepared.Rate, prepared.Snapshot, prepared.EffectiveKey, prepared.AlreadyDone, err = prepareLabelPurchase(tx, orderID, rateID, pkg, fulfillmentOrderID, idempotencyKey)
 		prepared.Package = pkg
 		return err
 	})
@@ -168,11 +169,12 @@ func PurchaseLabel(
 	orderID uint,
 	rateID uint,
 	pkg PackageInput,
+	fulfillmentOrderID *uint,
 	idempotencyKey string,
 	correlationID string,
 	now time.Time,
 ) (models.Shipment, error) {
-	prepared, err := PrepareLabelPurchase(db, orderID, rateID, pkg, idempotencyKey)
+	prepared, err := PrepareLabelPurchase(db, orderID, rateID, pkg, fulfillmentOrderID, idempotencyKey)
 	if err != nil {
 		return models.Shipment{}, err
 	}
@@ -210,6 +212,9 @@ func GetShipment(db *gorm.DB, shipmentID uint) (models.Shipment, error) {
 		Preload("Packages", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at ASC, id ASC")
 		}).
+		Preload("Packages.Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
 		Preload("TrackingEvents", func(db *gorm.DB) *gorm.DB {
 			return db.Order("occurred_at ASC, id ASC")
 		}).
@@ -231,6 +236,9 @@ func GetOrderShipments(db *gorm.DB, orderID uint) ([]models.Shipment, error) {
 		Preload("Packages", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at ASC, id ASC")
 		}).
+		Preload("Packages.Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
 		Preload("TrackingEvents", func(db *gorm.DB) *gorm.DB {
 			return db.Order("occurred_at ASC, id ASC")
 		}).
@@ -298,41 +306,57 @@ func ApplyTrackingEvent(
 	if err := tx.Model(&models.Shipment{}).Where("id = ?", shipment.ID).Updates(updates).Error; err != nil {
 		return models.Shipment{}, false, err
 	}
-
-	var order models.Order
-	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, shipment.OrderID).Error; err != nil {
-		return models.Shipment{}, false, err
-	}
-
 	targetStatus := ""
 	reason := ""
 	switch strings.ToUpper(strings.TrimSpace(event.Status)) {
 	case models.ShipmentStatusInTransit:
-		if order.Status != models.StatusShipped && order.Status != models.StatusDelivered {
-			targetStatus = models.StatusShipped
-			reason = "tracking_in_transit"
-		}
+		targetStatus = models.StatusShipped
+		reason = "tracking_in_transit"
 	case models.ShipmentStatusDelivered:
-		if order.Status != models.StatusDelivered {
-			targetStatus = models.StatusDelivered
-			reason = "tracking_delivered"
+		targetStatus = models.StatusDelivered
+		reason = "tracking_delivered"
+	}
+	transition := orderservice.StatusTransition{
+		To: targetStatus, Path: orderservice.PathFulfillment, Source: "webhook", Reason: reason,
+		Actor: "provider:" + event.Provider, CorrelationID: correlationID,
+	}
+
+	// A shipment bought for a fulfillment order carries only part of the
+	// order, so the order status is derived from every parcel rather than
+	// taken from this one.
+	if shipment.FulfillmentOrderID != nil {
+		if targetStatus != "" {
+			fulfillmentStatus := models.FulfillmentStatusShipped
+			if targetStatus == models.StatusDelivered {
+				fulfillmentStatus = models.FulfillmentStatusDelivered
+			}
+			if err := advanceFulfillment(tx, *shipment.FulfillmentOrderID, fulfillmentStatus, event.OccurredAt); err != nil {
+				return models.Shipment{}, false, err
+			}
+			var rejected *orderservice.StatusTransitionError
+			if err := orderservice.SyncFulfillmentStatus(tx, shipment.OrderID, transition); err != nil && !errors.As(err, &rejected) {
+				return models.Shipment{}, false, err
+			}
 		}
+		loaded, err := GetShipment(tx, shipment.ID)
+		return loaded, false, err
+	}
+
+	var order models.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, shipment.OrderID).Error; err != nil {
+		return models.Shipment{}, false, err
+	}
+	if order.Status == targetStatus || (targetStatus == models.StatusShipped && order.Status == models.StatusDelivered) {
+		targetStatus = ""
 	}
+	// Carriers keep reporting after the order has moved on, for example an
+	// in-transit scan on a cancelled or refunded order, or before it may
+	// ship, such as while it is held for risk review. The tracking event is
+	// kept but the order status is left alone, so the webhook is not retried
+	// forever.
 	if targetStatus != "" {
-		fromStatus := order.Status
-		if err := orderservice.ApplyStatusTransition(tx, &order, targetStatus); err != nil {
-			return models.Shipment{}, false, err
-		}
-		if err := paymentservice.AppendOrderStatusHistory(
-			tx,
-			order.ID,
-			fromStatus,
-			order.Status,
-			reason,
-			"webhook",
-			"provider:"+event.Provider,
-			correlationID,
-		); err != nil {
+		var rejected *orderservice.StatusTransitionError
+		if err := orderservice.ApplyStatusTransition(tx, &order, transition); err != nil && !errors.As(err, &rejected) {
 			return models.Shipment{}, false, err
 		}
 	}
@@ -357,6 +381,7 @@ func prepareLabelPurchase(
 	orderID uint,
 	rateID uint,
 	pkg PackageInput,
+	fulfillmentOrderID *uint,
 	idempotencyKey string,
 ) (models.Shipment, models.ShipmentRate, models.OrderCheckoutSnapshot, string, bool, error) {
 	var rate models.ShipmentRate
@@ -381,6 +406,9 @@ func prepareLabelPurchase(
 			loaded, loadErr := GetShipment(tx, existing.ID)
 			return loaded, rate, models.OrderCheckoutSnapshot{}, "", true, loadErr
 		}
+		if err := checkRiskReview(tx, orderID); err != nil {
+			return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, err
+		}
 		var snapshot models.OrderCheckoutSnapshot
 		if err := tx.First(&snapshot, rate.SnapshotID).Error; err != nil {
 			return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, err
@@ -390,13 +418,30 @@ func prepareLabelPurchase(
 	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, err
 	}
+	if err := checkRiskReview(tx, orderID); err != nil {
+		return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, err
+	}
 
 	var snapshot models.OrderCheckoutSnapshot
 	if err := tx.First(&snapshot, rate.SnapshotID).Error; err != nil {
 		return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, err
 	}
+	var fulfillment models.FulfillmentOrder
+	if fulfillmentOrderID != nil {
+		fulfillment, err = lockFulfillmentOrder(tx, *fulfillmentOrderID)
+		if err != nil {
+			return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, err
+		}
+		if fulfillment.OrderID != orderID {
+			return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, ErrFulfillmentOrderNotFound
+		}
+		if fulfillment.Status != models.FulfillmentStatusPacked {
+			return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, ErrFulfillmentStepNotAllowed
+		}
+	}
 
 	draft := models.Shipment{
+		FulfillmentOrderID:    fulfillmentOrderID,
 		OrderID:               orderID,
 		SnapshotID:            snapshot.ID,
 		Provider:              rate.Provider,
@@ -427,7 +472,7 @@ func prepareLabelPurchase(
 		return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, err
 	}
 
-	if pkg.Reference != "" || pkg.WeightGrams > 0 || pkg.LengthCM > 0 || pkg.WidthCM > 0 || pkg.HeightCM > 0 {
+	if fulfillmentOrderID != nil || pkg.Reference != "" || pkg.WeightGrams > 0 || pkg.LengthCM > 0 || pkg.WidthCM > 0 || pkg.HeightCM > 0 {
 		record := models.ShipmentPackage{
 			ShipmentID:  draft.ID,
 			Reference:   strings.TrimSpace(pkg.Reference),
@@ -436,6 +481,9 @@ func prepareLabelPurchase(
 			WidthCM:     pkg.WidthCM,
 			HeightCM:    pkg.HeightCM,
 		}
+		for _, line := range fulfillment.Lines {
+			record.Lines = append(record.Lines, models.ShipmentPackageLine{FulfillmentOrderLineID: line.ID, Quantity: line.QuantityPacked})
+		}
 		if err := tx.Create(&record).Error; err != nil {
 			return models.Shipment{}, models.ShipmentRate{}, models.OrderCheckoutSnapshot{}, "", false, err
 		}
@@ -474,6 +522,11 @@ func finalizeLabelPurchase(
 		Updates(updates).Error; err != nil {
 		return models.Shipment{}, err
 	}
+	if shipment.FulfillmentOrderID != nil {
+		if err := advanceFulfillment(tx, *shipment.FulfillmentOrderID, models.FulfillmentStatusLabelPurchased, now); err != nil {
+			return models.Shipment{}, err
+		}
+	}
 
 	return GetShipment(tx, shipment.ID)
 }
@@ -310,22 +310,32 @@ func (s *Service) ProcessStoredEvent(ctx context.Context, eventID uint) error {
 		if processErr != nil {
 			return markWebhookFailure(tx, &locked, processErr)
 		}
+		var orderID *uint
 		if paymentEvent != nil {
-			if _, _, _, err := paymentservice.ApplyWebhookPaymentEvent(tx, *paymentEvent, fmt.Sprintf("webhook:%d", locked.ID)); err != nil {
+			_, _, order, err := paymentservice.ApplyWebhookPaymentEvent(tx, *paymentEvent, fmt.Sprintf("webhook:%d", locked.ID))
+			if err != nil {
 				processErr = err
 				return markWebhookFailure(tx, &locked, err)
 			}
+			if order.ID != 0 {
+				orderID = &order.ID
+			}
 		} else if shippingEvent != nil {
-			if _, _, err := shippingservice.ApplyTrackingEvent(tx, *shippingEvent, fmt.Sprintf("webhook:%d", locked.ID)); err != nil {
+			shipment, _, err := shippingservice.ApplyTrackingEvent(tx, *shippingEvent, fmt.Sprintf("webhook:%d", locked.ID))
+			if err != nil {
 				processErr = err
 				return markWebhookFailure(tx, &locked, err)
 			}
+			if shipment.OrderID != 0 {
+				orderID = &shipment.OrderID
+			}
 		}
 
 		now := time.Now().UTC()
 		return tx.Model(&models.WebhookEvent{}).Where("id = ?", locked.ID).Updates(map[string]any{
 			"processed_at": now,
 			"last_error":   "",
+			"order_id":     orderID,
 		}).Error
 	})
 	if err != nil {
@@ -251,4 +251,9 @@ func TestProcessStoredEventAppliesPaymentWebhookState(t *testing.T) {
 	assert.Equal(t, "payment_captured", history[0].Reason)
 	assert.Equal(t, "webhook", history[0].Source)
 	assert.Equal(t, "provider:dummy-card", history[0].Actor)
+
+	var processed models.WebhookEvent
+	require.NoError(t, db.First(&processed, event.ID).Error)
+	require.NotNil(t, processed.OrderID, "processed events record the order they applied to")
+	assert.Equal(t, order.ID, *processed.OrderID)
 }
@@ -20,20 +20,22 @@ import (
 	"ecommerce/internal/media"
 	"ecommerce/internal/migrations"
 	"ecommerce/internal/providerplugins"
+	"ecommerce/internal/ratelimit"
 	accountservice "ecommerce/internal/services/account"
 	"ecommerce/internal/services/accountdata"
 	authservice "ecommerce/internal/services/auth"
 	checkoutservice "ecommerce/internal/services/checkout"
 	cmsservice "ecommerce/internal/services/cms"
+	giftcardservice "ecommerce/internal/services/giftcards"
 	inventoryservice "ecommerce/internal/services/inventory"
 	paymentservice "ecommerce/internal/services/payments"
 	providerops "ecommerce/internal/services/providerops"
+	riskservice "ecommerce/internal/services/risk"
+	segmentservice "ecommerce/internal/services/segments"
 	shippingservice "ecommerce/internal/services/shipping"
 	taxservice "ecommerce/internal/services/tax"
 	webhookservice "ecommerce/internal/services/webhooks"
 
-	"github.com/didip/tollbooth/v7"
-	"github.com/didip/tollbooth_gin"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"gorm.io/driver/postgres"
@@ -160,10 +162,6 @@ func run(parentCtx context.Context) error {
 		r.Use(cors.New(config))
 	}
 
-	// Global rate limit (100 requests/second)
-	lmt := tollbooth.NewLimiter(100, nil)
-	r.Use(tollbooth_gin.LimitHandler(lmt))
-
 	// Pass the secret key from your .env file
 	jwtSecret := cfg.JWTSecret
 
@@ -205,6 +203,7 @@ func run(parentCtx context.Context) error {
 	var paymentProviders paymentservice.ProviderRegistry = paymentservice.NewDefaultProviderRegistry()
 	var shippingProviders shippingservice.ProviderRegistry = shippingservice.NewDefaultProviderRegistry()
 	var taxProviders taxservice.ProviderRegistry = taxservice.NewDefaultProviderRegistry()
+	var riskScorers riskservice.ScorerRegistry
 
 	if cfg.ProviderPluginManifestsDir != "" {
 		if cfg.ProviderPluginManifestsDir != cfg.CheckoutPluginManifestsDir {
@@ -227,6 +226,7 @@ func run(parentCtx context.Context) error {
 		paymentProviders = loadedProviders.PaymentProviders
 		shippingProviders = loadedProviders.ShippingProviders
 		taxProviders = loadedProviders.TaxProviders
+		riskScorers = loadedProviders.RiskScorers
 		log.Printf("[INFO] Loaded %d external provider plugins from %s", loadedProviders.LoadedCount, cfg.ProviderPluginManifestsDir)
 	}
 
@@ -249,6 +249,22 @@ func run(parentCtx context.Context) error {
 		})
 	}
 
+	// Rate limits are declared per operation in the contract; the store decides
+	// whether their counters are shared across replicas.
+	var rateLimiter ratelimit.Store = ratelimit.NewMemoryStore()
+	var rateLimitCleanupWorker func()
+	if cfg.RateLimitStore == config.RateLimitStoreDatabase {
+		databaseLimiter := ratelimit.NewDatabaseStore(db)
+		rateLimiter = databaseLimiter
+		rateLimitCleanupWorker = func() {
+			runPeriodic(ctx, time.Minute, false, func(workerCtx context.Context) {
+				if _, cleanupErr := databaseLimiter.Cleanup(workerCtx, time.Now().UTC()); cleanupErr != nil && !errors.Is(cleanupErr, context.Canceled) {
+					log.Printf("[ERROR] Rate limit counter cleanup failed: %v", cleanupErr)
+				}
+			})
+		}
+	}
+
 	keyring, err := providerops.ParseKeyringConfig(cfg.ProviderCredentialsKeys)
 	if err != nil {
 		return fmt.Errorf("failed to parse provider credential keys: %w", err)
@@ -318,8 +334,10 @@ func run(parentCtx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("initialize CMS/media endpoints: %w", err)
 	}
+	cartRecoveryLinks := checkoutservice.RecoveryLinks{Secret: checkoutservice.DeriveRecoverySecret(jwtSecret), BaseURL: cfg.PublicURL}
 	checkoutProviderEndpoints, err := httpapi.NewCheckoutProviderEndpoints(httpapi.CheckoutProviderEndpointsOptions{
 		DB: db, Media: mediaService, CheckoutPlugins: pluginManager, ProviderRuntime: providerRuntime, Webhooks: webhookService, Renderer: renderer,
+		CartRecovery: cartRecoveryLinks, RiskScorers: riskScorers,
 	})
 	if err != nil {
 		return fmt.Errorf("initialize checkout/provider endpoints: %w", err)
@@ -334,7 +352,7 @@ func run(parentCtx context.Context) error {
 	}
 	if err := httpapi.RegisterStrict(r, apiServer, httpapi.RegisterStrictOptions{
 		Strict: httpapi.StrictOptions{Policies: policies}, Renderer: renderer,
-		Security: httpapi.SecurityOptions{PreviewSecret: jwtSecret, Authenticator: httpapi.JWTAuthenticator{
+		Security: httpapi.SecurityOptions{PreviewSecret: jwtSecret, RateLimiter: rateLimiter, Authenticator: httpapi.JWTAuthenticator{
 			Secret: []byte(jwtSecret), ResolveAccountID: func(resolveCtx context.Context, subject string) (uint, error) {
 				user, resolveErr := accountService.UserBySubject(resolveCtx, subject)
 				if errors.Is(resolveErr, accountservice.ErrUserNotFound) {
@@ -354,9 +372,23 @@ func run(parentCtx context.Context) error {
 	if reconciliationWorker != nil {
 		startWorker(reconciliationWorker)
 	}
+	if rateLimitCleanupWorker != nil {
+		startWorker(rateLimitCleanupWorker)
+	}
 	inventoryservice.StartReservationExpiryWorker(ctx, db.WithContext(ctx), time.Minute, log.Default())
 	cmsservice.StartDeliveryWorker(ctx, db.WithContext(ctx), time.Minute, log.Default(), mediaService)
 	cmsservice.StartInvalidationWorker(ctx, db.WithContext(ctx), cfg.CMSInvalidationWebhookURL, time.Minute, log.Default())
+	startWorker(func() {
+		runPeriodic(ctx, 15*time.Minute, true, func(workerCtx context.Context) {
+			segmentservice.RunRefresh(db.WithContext(workerCtx), log.Default())
+		})
+	})
+	giftcardservice.StartExpiryWorker(ctx, db.WithContext(ctx), time.Hour, log.Default())
+	checkoutservice.StartAbandonedCartWorker(ctx, db.WithContext(ctx), checkoutservice.AbandonedCartPipeline{
+		IdleAfter: cfg.AbandonedCartIdleAfter,
+		Notifier:  checkoutservice.NewAbandonedCartNotifier(cfg.AbandonedCartOutboxPath, log.Default()),
+		Links:     cartRecoveryLinks,
+	}, 15*time.Minute, log.Default())
 
 	requestRootCtx := context.WithoutCancel(ctx)
 	server := &http.Server{
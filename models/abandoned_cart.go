@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+const (
+	AbandonedCartStatusQueued    = "QUEUED"
+	AbandonedCartStatusSent      = "SENT"
+	AbandonedCartStatusFailed    = "FAILED"
+	AbandonedCartStatusSkipped   = "SKIPPED"
+	AbandonedCartStatusRestored  = "RESTORED"
+	AbandonedCartStatusRecovered = "RECOVERED"
+	// AbandonedCartStatusExpired closes a contacted cart that did not turn
+	// into an order within the attribution window.
+	AbandonedCartStatusExpired = "EXPIRED"
+)
+
+// AbandonedCart records an idle checkout session whose owner can be reached by
+// email. ItemsJSON snapshots the cart at detection time so a restore link
+// still works after the original session expires.
+type AbandonedCart struct {
+	ID                uint `gorm:"primaryKey"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	CheckoutSessionID uint       `gorm:"not null;uniqueIndex"`
+	UserID            *uint      `gorm:"index"`
+	Email             string     `gorm:"not null"`
+	ItemsJSON         string     `gorm:"column:items_json;type:text;not null;default:'[]'"`
+	ItemCount         int        `gorm:"not null;default:0"`
+	Subtotal          Money      `gorm:"type:numeric(12,2);not null;default:0"`
+	Status            string     `gorm:"not null;index"`
+	DetectedAt        time.Time  `gorm:"not null;index"`
+	Attempts          int        `gorm:"not null;default:0"`
+	LastError         string     `gorm:"type:text;not null;default:''"`
+	NotifiedAt        *time.Time `gorm:"index"`
+	RestoredSessionID *uint      `gorm:"index"`
+	RestoredAt        *time.Time
+	RestoreCount      int        `gorm:"not null;default:0"`
+	RecoveredOrderID  *uint      `gorm:"index"`
+	RecoveredAt       *time.Time `gorm:"index"`
+}
+
+// AbandonedCartItem is one line of an AbandonedCart items snapshot.
+type AbandonedCartItem struct {
+	ProductVariantID uint   `json:"product_variant_id"`
+	SKU              string `json:"sku"`
+	Title            string `json:"title"`
+	ProductName      string `json:"product_name"`
+	Quantity         int    `json:"quantity"`
+	Price            Money  `json:"price"`
+}
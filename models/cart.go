@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Cart struct {
 	BaseModel
 	CheckoutSessionID uint            `json:"checkout_session_id" gorm:"uniqueIndex"`
@@ -14,4 +16,28 @@ type CartItem struct {
 	ProductVariantID uint           `json:"product_variant_id"`
 	ProductVariant   ProductVariant `json:"product_variant" gorm:"foreignKey:ProductVariantID"`
 	Quantity         int            `json:"quantity" gorm:"default:1"`
+	// LockedPrice is set when the line came from a price-locked saved cart and
+	// applies until LockedUntil, for at most LockedQuantity units.
+	LockedPrice    *Money     `json:"locked_price" gorm:"type:numeric(12,2)"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	LockedQuantity int        `json:"locked_quantity" gorm:"not null;default:0"`
+	SavedCartID    *uint      `json:"saved_cart_id" gorm:"index"`
+}
+
+// UnitPrice is the price charged for the line at now: a still-valid quote lock
+// or the variant's current price. A line grown past the quantity the lock was
+// quoted for is charged at the current price.
+func (i CartItem) UnitPrice(now time.Time) Money {
+	if i.PriceLocked(now) {
+		return *i.LockedPrice
+	}
+	return i.ProductVariant.Price
+}
+
+// PriceLocked reports whether the quote lock still applies to the line.
+func (i CartItem) PriceLocked(now time.Time) bool {
+	if i.LockedPrice == nil || i.Quantity > i.LockedQuantity {
+		return false
+	}
+	return i.LockedUntil == nil || now.Before(*i.LockedUntil)
 }
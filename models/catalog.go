@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Brand struct {
 	BaseModel
 	Name        string  `json:"name" gorm:"not null"`
@@ -43,22 +45,88 @@ type ProductOptionValue struct {
 	Position        int    `json:"position" gorm:"not null;default:1"`
 }
 
+// ProductVariant is one purchasable SKU. MinQuantity, MaxQuantity and
+// QuantityStep bound a single cart line or order, with QuantityStep selling
+// the variant only in multiples such as packs of 6. PurchaseLimit caps how
+// many one customer may buy across all of their orders.
+//
+// InventoryPolicy decides what happens once stock runs out: DENY stops
+// selling and BACKORDER keeps selling up to BackorderLimit extra units (no
+// cap when nil). PREORDER sells a variant that is not released until
+// PreorderReleaseAt: before then every unit is backordered against
+// BackorderLimit whatever is in stock, and none of them are picked, shipped
+// or captured; from then on it sells from stock like DENY.
+//
+// IsGiftCard marks a variant that sells gift cards: every unit of a paid
+// order line becomes a card worth the line's unit price.
 type ProductVariant struct {
 	BaseModel
-	ProductID        uint                        `json:"product_id" gorm:"not null;index"`
-	Product          Product                     `json:"-" gorm:"foreignKey:ProductID"`
-	SKU              string                      `json:"sku" gorm:"not null;index"`
-	Title            string                      `json:"title" gorm:"not null"`
-	Price            Money                       `json:"price" gorm:"type:numeric(12,2);not null"`
-	CompareAtPrice   *Money                      `json:"compare_at_price,omitempty" gorm:"type:numeric(12,2)"`
-	Stock            int                         `json:"stock" gorm:"not null;default:0"`
-	Position         int                         `json:"position" gorm:"not null;default:1"`
-	IsPublished      bool                        `json:"is_published" gorm:"not null;default:true;index"`
-	WeightGrams      *int                        `json:"weight_grams,omitempty"`
-	LengthCm         *float64                    `json:"length_cm,omitempty"`
-	WidthCm          *float64                    `json:"width_cm,omitempty"`
-	HeightCm         *float64                    `json:"height_cm,omitempty"`
-	OptionValueLinks []ProductVariantOptionValue `json:"option_value_links,omitempty"`
+	ProductID         uint                        `json:"product_id" gorm:"not null;index"`
+	Product           Product                     `json:"-" gorm:"foreignKey:ProductID"`
+	SKU               string                      `json:"sku" gorm:"not null;index"`
+	Title             string                      `json:"title" gorm:"not null"`
+	Price             Money                       `json:"price" gorm:"type:numeric(12,2);not null"`
+	CompareAtPrice    *Money                      `json:"compare_at_price,omitempty" gorm:"type:numeric(12,2)"`
+	Stock             int                         `json:"stock" gorm:"not null;default:0"`
+	Position          int                         `json:"position" gorm:"not null;default:1"`
+	IsPublished       bool                        `json:"is_published" gorm:"not null;default:true;index"`
+	WeightGrams       *int                        `json:"weight_grams,omitempty"`
+	LengthCm          *float64                    `json:"length_cm,omitempty"`
+	WidthCm           *float64                    `json:"width_cm,omitempty"`
+	HeightCm          *float64                    `json:"height_cm,omitempty"`
+	MinQuantity       int                         `json:"min_quantity" gorm:"not null;default:1"`
+	MaxQuantity       *int                        `json:"max_quantity,omitempty"`
+	QuantityStep      int                         `json:"quantity_step" gorm:"not null;default:1"`
+	PurchaseLimit     *int                        `json:"purchase_limit,omitempty"`
+	InventoryPolicy   string                      `json:"inventory_policy" gorm:"not null;size:32;default:'DENY'"`
+	BackorderLimit    *int                        `json:"backorder_limit,omitempty"`
+	PreorderReleaseAt *time.Time                  `json:"preorder_release_at,omitempty"`
+	PromisedShipAt    *time.Time                  `json:"promised_ship_at,omitempty"`
+	IsGiftCard        bool                        `json:"is_gift_card" gorm:"not null;default:false"`
+	OptionValueLinks  []ProductVariantOptionValue `json:"option_value_links,omitempty"`
+}
+
+const (
+	InventoryPolicyDeny      = "DENY"
+	InventoryPolicyBackorder = "BACKORDER"
+	InventoryPolicyPreorder  = "PREORDER"
+)
+
+func IsValidInventoryPolicy(policy string) bool {
+	switch policy {
+	case InventoryPolicyDeny, InventoryPolicyBackorder, InventoryPolicyPreorder:
+		return true
+	default:
+		return false
+	}
+}
+
+// AllowsOverselling reports whether the variant keeps selling past its stock
+// at now.
+func (v ProductVariant) AllowsOverselling(now time.Time) bool {
+	return v.InventoryPolicy == InventoryPolicyBackorder || v.Preordering(now)
+}
+
+// Preordering reports whether the variant is a pre-order not yet released at
+// now.
+func (v ProductVariant) Preordering(now time.Time) bool {
+	return v.InventoryPolicy == InventoryPolicyPreorder && v.PreorderReleaseAt != nil && now.Before(*v.PreorderReleaseAt)
+}
+
+// PromisedShipDate is the date shown to shoppers for units sold past stock.
+// Pre-orders fall back to their release date.
+func (v ProductVariant) PromisedShipDate() *time.Time {
+	switch v.InventoryPolicy {
+	case InventoryPolicyPreorder:
+		if v.PromisedShipAt != nil {
+			return v.PromisedShipAt
+		}
+		return v.PreorderReleaseAt
+	case InventoryPolicyBackorder:
+		return v.PromisedShipAt
+	default:
+		return nil
+	}
 }
 
 type ProductVariantOptionValue struct {
@@ -168,6 +236,15 @@ type ProductVariantDraft struct {
 	LengthCm               *float64                         `json:"length_cm,omitempty"`
 	WidthCm                *float64                         `json:"width_cm,omitempty"`
 	HeightCm               *float64                         `json:"height_cm,omitempty"`
+	MinQuantity            int                              `json:"min_quantity" gorm:"not null;default:1"`
+	MaxQuantity            *int                             `json:"max_quantity,omitempty"`
+	QuantityStep           int                              `json:"quantity_step" gorm:"not null;default:1"`
+	PurchaseLimit          *int                             `json:"purchase_limit,omitempty"`
+	InventoryPolicy        string                           `json:"inventory_policy" gorm:"not null;size:32;default:'DENY'"`
+	BackorderLimit         *int                             `json:"backorder_limit,omitempty"`
+	PreorderReleaseAt      *time.Time                       `json:"preorder_release_at,omitempty"`
+	PromisedShipAt         *time.Time                       `json:"promised_ship_at,omitempty"`
+	IsGiftCard             bool                             `json:"is_gift_card" gorm:"not null;default:false"`
 	IsDeleted              bool                             `json:"is_deleted" gorm:"not null;default:false"`
 	OptionValueDraftLinks  []ProductVariantOptionValueDraft `json:"option_value_draft_links,omitempty"`
 }
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+const (
+	CheckoutFieldTypeText     = "text"
+	CheckoutFieldTypeSelect   = "select"
+	CheckoutFieldTypeCheckbox = "checkbox"
+	CheckoutFieldTypeDate     = "date"
+)
+
+// CheckoutField is a merchant-defined input collected at checkout, such as a
+// gift message or PO number. Submitted values are stored as order attributes
+// keyed by Key.
+type CheckoutField struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Key         string    `json:"key" gorm:"not null;size:64;uniqueIndex"`
+	Label       string    `json:"label" gorm:"not null"`
+	Type        string    `json:"type" gorm:"not null"`
+	Required    bool      `json:"required" gorm:"not null;default:false"`
+	Placeholder string    `json:"placeholder" gorm:"not null;default:''"`
+	HelpText    string    `json:"help_text" gorm:"type:text;not null;default:''"`
+	// OptionsJSON lists the {value,label} choices of a select field.
+	OptionsJSON string `json:"options_json" gorm:"type:text;not null;default:'[]'"`
+	// ChannelsJSON limits the field to the listed sales channels; an empty
+	// list shows it everywhere.
+	ChannelsJSON string `json:"channels_json" gorm:"type:text;not null;default:'[]'"`
+	MaxLength    int    `json:"max_length" gorm:"not null;default:0"`
+	Position     int    `json:"position" gorm:"not null;default:0"`
+	Active       bool   `json:"active" gorm:"not null;default:true;index"`
+}
@@ -6,6 +6,18 @@ const (
 	CheckoutSessionStatusActive    = "ACTIVE"
 	CheckoutSessionStatusConverted = "CONVERTED"
 	CheckoutSessionStatusExpired   = "EXPIRED"
+	CheckoutSessionStatusMerged    = "MERGED"
+)
+
+const (
+	CheckoutSessionKindStandard = "STANDARD"
+	// CheckoutSessionKindExpress marks a short-lived buy-now session whose
+	// one-item cart is kept apart from the shopper's regular cart.
+	CheckoutSessionKindExpress = "EXPRESS"
+	// CheckoutSessionKindDraftOrder marks a session opened from a draft
+	// order's payment link. Its cart holds the draft's lines at the draft's
+	// prices and cannot be changed.
+	CheckoutSessionKindDraftOrder = "DRAFT_ORDER"
 )
 
 type CheckoutSession struct {
@@ -17,4 +29,15 @@ type CheckoutSession struct {
 	Status      string    `json:"status" gorm:"not null;default:ACTIVE"`
 	ExpiresAt   time.Time `json:"expires_at" gorm:"not null"`
 	LastSeenAt  time.Time `json:"last_seen_at" gorm:"not null"`
+	// MergedIntoSessionID points at the signed-in session that absorbed this
+	// guest session's cart.
+	MergedIntoSessionID *uint      `json:"merged_into_session_id"`
+	MergedAt            *time.Time `json:"merged_at"`
+	// CheckoutStateJSON stores the single-page checkout steps (contact,
+	// shipping, payment, review) entered so far.
+	CheckoutStateJSON string `json:"-" gorm:"type:text;not null;default:'{}'"`
+	Kind              string `json:"kind" gorm:"not null;default:STANDARD;index"`
+	// ParentSessionID is the regular session an express session was started
+	// from.
+	ParentSessionID *uint `json:"parent_session_id" gorm:"index"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// CustomerSegment is a named, rule-defined audience. Membership is
+// materialized into CustomerSegmentMembership rows by the refresh worker so
+// discount and CMS targeting can resolve segments without re-evaluating rules.
+type CustomerSegment struct {
+	BaseModel
+	Key             string     `json:"key" gorm:"not null;size:64;uniqueIndex"`
+	Name            string     `json:"name" gorm:"not null"`
+	Description     string     `json:"description" gorm:"type:text;not null;default:''"`
+	RulesJSON       string     `json:"rules_json" gorm:"type:text;not null;default:'{}'"`
+	IsActive        bool       `json:"is_active" gorm:"not null;default:true;index"`
+	MemberCount     int        `json:"member_count" gorm:"not null;default:0"`
+	LastRefreshedAt *time.Time `json:"last_refreshed_at"`
+	CreatedBy       *uint      `json:"created_by" gorm:"index"`
+	UpdatedBy       *uint      `json:"updated_by" gorm:"index"`
+}
+
+type CustomerSegmentMembership struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	SegmentID uint      `json:"segment_id" gorm:"not null;index:idx_customer_segment_memberships_member,unique"`
+	UserID    uint      `json:"user_id" gorm:"not null;index:idx_customer_segment_memberships_member,unique;index"`
+	MatchedAt time.Time `json:"matched_at" gorm:"not null"`
+}
@@ -33,28 +33,30 @@ const (
 
 type DiscountCampaign struct {
 	BaseModel
-	Name                string           `json:"name" gorm:"not null"`
-	Type                string           `json:"type" gorm:"not null;index"`
-	Status              string           `json:"status" gorm:"not null;index"`
-	StartsAt            time.Time        `json:"starts_at" gorm:"not null;index"`
-	EndsAt              *time.Time       `json:"ends_at" gorm:"index"`
-	Timezone            string           `json:"timezone" gorm:"not null;default:'UTC'"`
-	IsArchived          bool             `json:"is_archived" gorm:"not null;default:false;index"`
-	Priority            int              `json:"priority" gorm:"not null;default:0;index"`
-	IsExclusive         bool             `json:"is_exclusive" gorm:"not null;default:false"`
-	DiscountMode        string           `json:"discount_mode" gorm:"not null"`
-	DiscountValue       Money            `json:"discount_value" gorm:"type:numeric(12,2);not null"`
-	MetadataJSON        string           `json:"metadata_json" gorm:"type:text;not null;default:'{}'"`
-	CouponCode          *string          `json:"coupon_code,omitempty" gorm:"uniqueIndex"`
-	ChannelsJSON        string           `json:"channels_json" gorm:"type:text;not null;default:'[]'"`
-	CustomerSegment     string           `json:"customer_segment" gorm:"not null;default:''"`
-	GlobalUsageCap      *int             `json:"global_usage_cap"`
-	PerCustomerUsageCap *int             `json:"per_customer_usage_cap"`
-	CreatedBy           *uint            `json:"created_by" gorm:"index"`
-	UpdatedBy           *uint            `json:"updated_by" gorm:"index"`
-	Targets             []DiscountTarget `json:"targets,omitempty" gorm:"foreignKey:CampaignID"`
-	Rules               []DiscountRule   `json:"rules,omitempty" gorm:"foreignKey:CampaignID"`
-	Levels              []DiscountLevel  `json:"levels,omitempty" gorm:"foreignKey:CampaignID"`
+	Name                 string           `json:"name" gorm:"not null"`
+	Type                 string           `json:"type" gorm:"not null;index"`
+	Status               string           `json:"status" gorm:"not null;index"`
+	StartsAt             time.Time        `json:"starts_at" gorm:"not null;index"`
+	EndsAt               *time.Time       `json:"ends_at" gorm:"index"`
+	Timezone             string           `json:"timezone" gorm:"not null;default:'UTC'"`
+	IsArchived           bool             `json:"is_archived" gorm:"not null;default:false;index"`
+	Priority             int              `json:"priority" gorm:"not null;default:0;index"`
+	IsExclusive          bool             `json:"is_exclusive" gorm:"not null;default:false"`
+	DiscountMode         string           `json:"discount_mode" gorm:"not null"`
+	DiscountValue        Money            `json:"discount_value" gorm:"type:numeric(12,2);not null"`
+	MetadataJSON         string           `json:"metadata_json" gorm:"type:text;not null;default:'{}'"`
+	CouponCode           *string          `json:"coupon_code,omitempty" gorm:"uniqueIndex"`
+	ChannelsJSON         string           `json:"channels_json" gorm:"type:text;not null;default:'[]'"`
+	CustomerSegment      string           `json:"customer_segment" gorm:"not null;default:''"`
+	GlobalUsageCap       *int             `json:"global_usage_cap"`
+	PerCustomerUsageCap  *int             `json:"per_customer_usage_cap"`
+	BudgetAmount         *Money           `json:"budget_amount" gorm:"type:numeric(12,2)"`
+	BudgetThresholdsJSON string           `json:"budget_thresholds_json" gorm:"type:text;not null;default:'[]'"`
+	CreatedBy            *uint            `json:"created_by" gorm:"index"`
+	UpdatedBy            *uint            `json:"updated_by" gorm:"index"`
+	Targets              []DiscountTarget `json:"targets,omitempty" gorm:"foreignKey:CampaignID"`
+	Rules                []DiscountRule   `json:"rules,omitempty" gorm:"foreignKey:CampaignID"`
+	Levels               []DiscountLevel  `json:"levels,omitempty" gorm:"foreignKey:CampaignID"`
 }
 
 type DiscountRedemption struct {
@@ -69,6 +71,18 @@ type DiscountRedemption struct {
 	EvaluationSnapshotHash string            `json:"evaluation_snapshot_hash" gorm:"not null;default:''"`
 }
 
+// DiscountBudgetAlert records the first time a campaign's redeemed discount
+// crossed a percent of its budget. ThresholdPercent 100 marks exhaustion.
+type DiscountBudgetAlert struct {
+	BaseModel
+	CampaignID       uint              `json:"campaign_id" gorm:"not null;uniqueIndex:idx_discount_budget_alerts_threshold"`
+	Campaign         *DiscountCampaign `json:"-" gorm:"foreignKey:CampaignID"`
+	ThresholdPercent int               `json:"threshold_percent" gorm:"not null;uniqueIndex:idx_discount_budget_alerts_threshold"`
+	BudgetAmount     Money             `json:"budget_amount" gorm:"type:numeric(12,2);not null;uniqueIndex:idx_discount_budget_alerts_threshold"`
+	SpentAmount      Money             `json:"spent_amount" gorm:"type:numeric(12,2);not null"`
+	TriggeredAt      time.Time         `json:"triggered_at" gorm:"not null;index"`
+}
+
 type PromotionTemplate struct {
 	BaseModel
 	Name         string `json:"name" gorm:"not null"`
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+const (
+	DraftOrderStatusOpen      = "OPEN"
+	DraftOrderStatusInvoiced  = "INVOICED"
+	DraftOrderStatusCompleted = "COMPLETED"
+	DraftOrderStatusCancelled = "CANCELLED"
+)
+
+// PaymentProviderOffline is the provider id of payments staff record by hand,
+// such as cash, bank transfers or card terminals, when marking a draft order
+// paid.
+const PaymentProviderOffline = "offline"
+
+// DraftOrder is an order staff put together for a customer, for example one
+// taken over the phone. It is priced like checkout but nothing is reserved or
+// charged until it is either paid through a payment link, which opens a
+// checkout session bound to it, or marked paid offline. Either way OrderID
+// is set once the order exists.
+type DraftOrder struct {
+	ID             uint `gorm:"primaryKey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Status         string  `gorm:"size:16;not null;index"`
+	CustomerUserID *uint   `gorm:"index"`
+	GuestEmail     *string `gorm:"size:320"`
+	Note           string  `gorm:"type:text;not null;default:''"`
+	CreatedBy      string  `gorm:"not null;default:''"`
+	// ShippingProviderID and ShippingDataJSON are the shipping selection as
+	// checkout would send it, address included.
+	ShippingProviderID    string `gorm:"not null;default:''"`
+	ShippingDataJSON      string `gorm:"type:text;not null;default:'{}'"`
+	ShippingAddressPretty string `gorm:"type:text;not null;default:''"`
+	Currency              string `gorm:"size:3;not null;default:'USD'"`
+	Subtotal              Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	ShippingAmount        Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	TaxAmount             Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	Total                 Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	// PaymentToken identifies the draft in its payment link.
+	PaymentToken         string `gorm:"uniqueIndex;not null"`
+	PaymentLinkExpiresAt *time.Time
+	// CheckoutSessionID is the session the payment link opened, or the one
+	// recorded for an offline payment.
+	CheckoutSessionID *uint  `gorm:"index"`
+	OrderID           *uint  `gorm:"index"`
+	PaymentMethod     string `gorm:"not null;default:''"`
+	PaymentReference  string `gorm:"not null;default:''"`
+	InvoicedAt        *time.Time
+	CompletedAt       *time.Time
+	CancelledAt       *time.Time
+	Lines             []DraftOrderLine `gorm:"foreignKey:DraftOrderID"`
+}
+
+// DraftOrderLine sells Quantity units of a variant at FinalUnitPrice:
+// UnitPrice, which staff may set below or above the list price, less
+// DiscountPercent, in whole cents.
+type DraftOrderLine struct {
+	ID               uint `gorm:"primaryKey"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DraftOrderID     uint `gorm:"not null;index"`
+	ProductVariantID uint `gorm:"not null"`
+	VariantSKU       string
+	VariantTitle     string
+	Quantity         int   `gorm:"not null"`
+	ListPrice        Money `gorm:"type:numeric(12,2);not null"`
+	UnitPrice        Money `gorm:"type:numeric(12,2);not null"`
+	// DiscountPercent is stored like a percent campaign's value: 12.50 is
+	// 12.5%.
+	DiscountPercent Money `gorm:"type:numeric(5,2);not null;default:0"`
+	FinalUnitPrice  Money `gorm:"type:numeric(12,2);not null"`
+	Position        int   `gorm:"not null;default:0"`
+}
+
+// PaymentLinkExpired reports whether an invoiced draft's link can no longer
+// be opened.
+func (d DraftOrder) PaymentLinkExpired(now time.Time) bool {
+	return d.PaymentLinkExpiresAt != nil && !d.PaymentLinkExpiresAt.After(now)
+}
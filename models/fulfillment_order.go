@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+const (
+	FulfillmentStatusUnfulfilled    = "UNFULFILLED"
+	FulfillmentStatusPicking        = "PICKING"
+	FulfillmentStatusPacked         = "PACKED"
+	FulfillmentStatusLabelPurchased = "LABEL_PURCHASED"
+	FulfillmentStatusShipped        = "SHIPPED"
+	FulfillmentStatusDelivered      = "DELIVERED"
+	FulfillmentStatusCancelled      = "CANCELLED"
+)
+
+// DefaultFulfillmentLocation is where fulfillment orders are opened for units
+// not yet committed from a particular location, such as backorders.
+const DefaultFulfillmentLocation = DefaultStockLocationCode
+
+func IsValidFulfillmentStatus(status string) bool {
+	switch status {
+	case FulfillmentStatusUnfulfilled, FulfillmentStatusPicking, FulfillmentStatusPacked,
+		FulfillmentStatusLabelPurchased, FulfillmentStatusShipped, FulfillmentStatusDelivered,
+		FulfillmentStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsOpenFulfillmentStatus reports whether the warehouse still has work to do
+// before the parcel leaves.
+func IsOpenFulfillmentStatus(status string) bool {
+	switch status {
+	case FulfillmentStatusUnfulfilled, FulfillmentStatusPicking, FulfillmentStatusPacked, FulfillmentStatusLabelPurchased:
+		return true
+	default:
+		return false
+	}
+}
+
+// FulfillmentOrder is the warehouse's unit of work for one order at one
+// location. The pick list is recorded when picking starts and the packing
+// confirmation when every line has been packed.
+type FulfillmentOrder struct {
+	ID               uint `gorm:"primaryKey"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	OrderID          uint   `gorm:"not null;index"`
+	LocationCode     string `gorm:"not null;default:'default';index"`
+	Status           string `gorm:"not null;index"`
+	PickListAt       *time.Time
+	PickedBy         string `gorm:"not null;default:''"`
+	PackedAt         *time.Time
+	PackedBy         string `gorm:"not null;default:''"`
+	PackingNote      string `gorm:"type:text;not null;default:''"`
+	LabelPurchasedAt *time.Time
+	ShippedAt        *time.Time
+	DeliveredAt      *time.Time
+	CancelledAt      *time.Time
+	// RoutingExplanation records why the order was sent to this location,
+	// whether chosen by the routing rules or overridden by an admin.
+	RoutingExplanation string                 `gorm:"type:text;not null;default:''"`
+	Lines              []FulfillmentOrderLine `gorm:"foreignKey:FulfillmentOrderID"`
+}
+
+type FulfillmentOrderLine struct {
+	ID                 uint `gorm:"primaryKey"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	FulfillmentOrderID uint   `gorm:"not null;index"`
+	OrderItemID        uint   `gorm:"not null;index"`
+	ProductVariantID   uint   `gorm:"not null;index"`
+	VariantSKU         string `gorm:"not null;default:''"`
+	VariantTitle       string `gorm:"not null;default:''"`
+	Quantity           int    `gorm:"not null"`
+	QuantityPicked     int    `gorm:"not null;default:0"`
+	QuantityPacked     int    `gorm:"not null;default:0"`
+}
+
+// ShipmentPackageLine records how many units of a fulfillment line travel in
+// a package.
+type ShipmentPackageLine struct {
+	ID                     uint `gorm:"primaryKey"`
+	CreatedAt              time.Time
+	ShipmentPackageID      uint `gorm:"not null;index"`
+	FulfillmentOrderLineID uint `gorm:"not null;index"`
+	Quantity               int  `gorm:"not null"`
+}
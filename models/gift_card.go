@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const (
+	GiftCardStatusActive   = "ACTIVE"
+	GiftCardStatusDisabled = "DISABLED"
+	GiftCardStatusExpired  = "EXPIRED"
+)
+
+const (
+	GiftCardSourcePurchased    = "PURCHASED"
+	GiftCardSourceAdmin        = "ADMIN"
+	GiftCardSourceRefundCredit = "REFUND_CREDIT"
+)
+
+const (
+	GiftCardLedgerOperationIssue  = "ISSUE"
+	GiftCardLedgerOperationRedeem = "REDEEM"
+	GiftCardLedgerOperationRefund = "REFUND"
+	GiftCardLedgerOperationAdjust = "ADJUST"
+	GiftCardLedgerOperationExpire = "EXPIRE"
+)
+
+// GiftCard is a stored-value instrument. Only a hash of the redemption code is
+// kept; Balance always equals the sum of the card's ledger entries.
+type GiftCard struct {
+	ID            uint `gorm:"primaryKey"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CodeHash      string                `gorm:"not null;size:64;uniqueIndex"`
+	CodeLast4     string                `gorm:"not null;size:4"`
+	Currency      string                `gorm:"not null;size:3"`
+	InitialAmount Money                 `gorm:"type:numeric(12,2);not null"`
+	Balance       Money                 `gorm:"type:numeric(12,2);not null"`
+	Status        string                `gorm:"not null;index"`
+	Source        string                `gorm:"not null;index"`
+	UserID        *uint                 `gorm:"index"`
+	OrderID       *uint                 `gorm:"index"`
+	OrderItemID   *uint                 `gorm:"index"`
+	ExpiresAt     *time.Time            `gorm:"index"`
+	Note          string                `gorm:"type:text;not null;default:''"`
+	IssuedBy      string                `gorm:"not null;default:''"`
+	Version       int                   `gorm:"not null;default:1"`
+	Ledger        []GiftCardLedgerEntry `gorm:"foreignKey:GiftCardID"`
+}
+
+// GiftCardLedgerEntry is an append-only balance movement. Amount is positive
+// for credits to the card and negative for debits.
+type GiftCardLedgerEntry struct {
+	ID              uint `gorm:"primaryKey"`
+	CreatedAt       time.Time
+	GiftCardID      uint   `gorm:"not null;index:idx_gift_card_ledger_card_operation_key,unique"`
+	Operation       string `gorm:"not null;index:idx_gift_card_ledger_card_operation_key,unique"`
+	IdempotencyKey  string `gorm:"not null;index:idx_gift_card_ledger_card_operation_key,unique"`
+	Amount          Money  `gorm:"type:numeric(12,2);not null"`
+	BalanceAfter    Money  `gorm:"type:numeric(12,2);not null"`
+	PaymentIntentID *uint  `gorm:"index"`
+	OrderID         *uint  `gorm:"index"`
+	Actor           string `gorm:"not null;default:''"`
+	Reason          string `gorm:"type:text;not null;default:''"`
+}
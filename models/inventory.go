@@ -8,10 +8,12 @@ type InventoryItem struct {
 	ProductVariant   ProductVariant `json:"-" gorm:"foreignKey:ProductVariantID"`
 }
 
+// InventoryLevel is an item's stock at one location.
 type InventoryLevel struct {
 	BaseModel
-	InventoryItemID uint          `json:"inventory_item_id" gorm:"not null;uniqueIndex"`
+	InventoryItemID uint          `json:"inventory_item_id" gorm:"not null;index;uniqueIndex:idx_inventory_levels_item_location,priority:1"`
 	InventoryItem   InventoryItem `json:"-" gorm:"foreignKey:InventoryItemID"`
+	StockLocationID uint          `json:"stock_location_id" gorm:"not null;default:0;uniqueIndex:idx_inventory_levels_item_location,priority:2"`
 	OnHand          int           `json:"on_hand" gorm:"not null;default:0"`
 	Reserved        int           `json:"reserved" gorm:"not null;default:0"`
 	Available       int           `json:"available" gorm:"not null;default:0"`
@@ -21,6 +23,7 @@ type InventoryMovement struct {
 	BaseModel
 	InventoryItemID uint          `json:"inventory_item_id" gorm:"not null;index"`
 	InventoryItem   InventoryItem `json:"-" gorm:"foreignKey:InventoryItemID"`
+	StockLocationID uint          `json:"stock_location_id" gorm:"not null;default:0;index"`
 	MovementType    string        `json:"movement_type" gorm:"not null;size:64;index"`
 	QuantityDelta   int           `json:"quantity_delta" gorm:"not null"`
 	ReferenceType   string        `json:"reference_type" gorm:"not null;size:64;default:'';index"`
@@ -45,6 +48,7 @@ type InventoryAdjustment struct {
 	InventoryItem    InventoryItem  `json:"-" gorm:"foreignKey:InventoryItemID"`
 	ProductVariantID uint           `json:"product_variant_id" gorm:"not null;index"`
 	ProductVariant   ProductVariant `json:"-" gorm:"foreignKey:ProductVariantID"`
+	StockLocationID  uint           `json:"stock_location_id" gorm:"not null;default:0;index"`
 	QuantityDelta    int            `json:"quantity_delta" gorm:"not null"`
 	ReasonCode       string         `json:"reason_code" gorm:"not null;size:64;index"`
 	Notes            string         `json:"notes" gorm:"type:text;not null;default:''"`
@@ -60,13 +64,21 @@ const (
 	InventoryReservationStatusConsumed = "CONSUMED"
 	InventoryReservationStatusReleased = "RELEASED"
 	InventoryReservationStatusExpired  = "EXPIRED"
+	// InventoryReservationStatusBackordered holds a paid order's backordered
+	// units until a receipt brings in stock to commit.
+	InventoryReservationStatusBackordered = "BACKORDERED"
 )
 
+// InventoryReservation holds units for a checkout or order at one location.
+// A Backordered reservation was taken without stock behind it: it has no
+// location and does not count towards InventoryLevel.Reserved until
+// AllocatedAt, when stock received at a location covers it.
 type InventoryReservation struct {
 	BaseModel
 	InventoryItemID   uint          `json:"inventory_item_id" gorm:"not null;index"`
 	InventoryItem     InventoryItem `json:"-" gorm:"foreignKey:InventoryItemID"`
 	ProductVariantID  uint          `json:"product_variant_id" gorm:"not null;index"`
+	StockLocationID   uint          `json:"stock_location_id" gorm:"not null;default:0;index"`
 	Quantity          int           `json:"quantity" gorm:"not null"`
 	Status            string        `json:"status" gorm:"not null;size:32;index"`
 	ExpiresAt         time.Time     `json:"expires_at" gorm:"not null;index"`
@@ -75,6 +87,8 @@ type InventoryReservation struct {
 	CheckoutSessionID *uint         `json:"checkout_session_id,omitempty" gorm:"index"`
 	OrderID           *uint         `json:"order_id,omitempty" gorm:"index"`
 	IdempotencyKey    string        `json:"idempotency_key" gorm:"not null;size:255;uniqueIndex"`
+	Backordered       bool          `json:"backordered" gorm:"not null;default:false;index"`
+	AllocatedAt       *time.Time    `json:"allocated_at,omitempty"`
 	ConsumedAt        *time.Time    `json:"consumed_at,omitempty"`
 	ReleasedAt        *time.Time    `json:"released_at,omitempty"`
 	ExpiredAt         *time.Time    `json:"expired_at,omitempty"`
@@ -157,6 +171,7 @@ type InventoryReceipt struct {
 	BaseModel
 	PurchaseOrderID uint                   `json:"purchase_order_id" gorm:"not null;index"`
 	PurchaseOrder   PurchaseOrder          `json:"-" gorm:"foreignKey:PurchaseOrderID"`
+	StockLocationID uint                   `json:"stock_location_id" gorm:"not null;default:0;index"`
 	ReceivedAt      time.Time              `json:"received_at" gorm:"not null;index"`
 	ActorType       string                 `json:"actor_type" gorm:"not null;size:64;default:''"`
 	ActorID         *uint                  `json:"actor_id,omitempty"`
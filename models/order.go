@@ -1,29 +1,40 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+)
+
 const (
-	StatusPending   = "PENDING"
-	StatusPaid      = "PAID"
-	StatusFailed    = "FAILED"
-	StatusShipped   = "SHIPPED"
-	StatusDelivered = "DELIVERED"
-	StatusCancelled = "CANCELLED"
-	StatusRefunded  = "REFUNDED"
+	StatusPending          = "PENDING"
+	StatusPaid             = "PAID"
+	StatusFailed           = "FAILED"
+	StatusPartiallyShipped = "PARTIALLY_SHIPPED"
+	StatusShipped          = "SHIPPED"
+	StatusDelivered        = "DELIVERED"
+	StatusCancelled        = "CANCELLED"
+	StatusRefunded         = "REFUNDED"
 )
 
+// PaidOrderStatuses are the statuses of an order whose sale went through.
+var PaidOrderStatuses = []string{StatusPaid, StatusPartiallyShipped, StatusShipped, StatusDelivered}
+
 var validOrderStatuses = map[string]struct{}{
-	StatusPending:   {},
-	StatusPaid:      {},
-	StatusFailed:    {},
-	StatusShipped:   {},
-	StatusDelivered: {},
-	StatusCancelled: {},
-	StatusRefunded:  {},
+	StatusPending:          {},
+	StatusPaid:             {},
+	StatusFailed:           {},
+	StatusPartiallyShipped: {},
+	StatusShipped:          {},
+	StatusDelivered:        {},
+	StatusCancelled:        {},
+	StatusRefunded:         {},
 }
 
 var stockCommittedOrderStatuses = map[string]struct{}{
-	StatusPaid:      {},
-	StatusShipped:   {},
-	StatusDelivered: {},
+	StatusPaid:             {},
+	StatusPartiallyShipped: {},
+	StatusShipped:          {},
+	StatusDelivered:        {},
 }
 
 var userCancelableOrderStatuses = map[string]struct{}{
@@ -60,7 +71,27 @@ type Order struct {
 	CanCancel             bool            `json:"can_cancel" gorm:"-"`
 	PaymentMethodDisplay  string          `json:"payment_method_display"`
 	ShippingAddressPretty string          `json:"shipping_address_pretty"`
-	Items                 []OrderItem     `json:"items" gorm:"foreignKey:OrderID"`
+	// AttributesJSON holds the merchant checkout field values, keyed by
+	// CheckoutField.Key.
+	AttributesJSON string `json:"-" gorm:"type:text;not null;default:'{}'"`
+	// Risk* summarise the latest fraud screening; the full history lives in
+	// OrderRiskAssessment. RiskReviewStatus is PENDING while the order is held
+	// in the admin review queue.
+	RiskScore        *int        `json:"-"`
+	RiskAction       string      `json:"-" gorm:"not null;default:''"`
+	RiskReasonsJSON  string      `json:"-" gorm:"type:text;not null;default:'[]'"`
+	RiskReviewStatus string      `json:"-" gorm:"not null;default:'';index"`
+	RiskReviewedBy   string      `json:"-" gorm:"not null;default:''"`
+	RiskReviewedAt   *time.Time  `json:"-"`
+	Items            []OrderItem `json:"items" gorm:"foreignKey:OrderID"`
+}
+
+// Attributes decodes AttributesJSON, returning an empty map when none were
+// collected.
+func (o Order) Attributes() map[string]string {
+	attributes := map[string]string{}
+	_ = json.Unmarshal([]byte(o.AttributesJSON), &attributes)
+	return attributes
 }
 
 type OrderItem struct {
@@ -73,4 +104,17 @@ type OrderItem struct {
 	VariantTitle     string         `json:"variant_title"`
 	Quantity         int            `json:"quantity"`
 	Price            Money          `json:"price" gorm:"type:numeric(12,2);not null"` // Price at time of order (snapshot)
+	// InventoryPolicy is BACKORDER or PREORDER when the line was sold past
+	// stock, with PromisedShipAt the date the shopper was shown.
+	InventoryPolicy string     `json:"inventory_policy" gorm:"not null;size:32;default:''"`
+	PromisedShipAt  *time.Time `json:"promised_ship_at,omitempty"`
+	// QuantityFulfilled counts units that have left in a shipment and
+	// QuantityCancelled units that never will.
+	QuantityFulfilled int `json:"quantity_fulfilled" gorm:"not null;default:0"`
+	QuantityCancelled int `json:"quantity_cancelled" gorm:"not null;default:0"`
+}
+
+// RemainingQuantity is the number of units still to be shipped.
+func (i OrderItem) RemainingQuantity() int {
+	return max(i.Quantity-i.QuantityFulfilled-i.QuantityCancelled, 0)
 }
@@ -21,6 +21,7 @@ type OrderCheckoutSnapshot struct {
 	TaxDataJSON           string    `gorm:"type:text;not null;default:''"`
 	PaymentMethodDisplay  string    `gorm:"type:text;not null;default:''"`
 	ShippingAddressPretty string    `gorm:"type:text;not null;default:''"`
+	AttributesJSON        string    `gorm:"type:text;not null;default:'{}'"`
 	ExpiresAt             time.Time `gorm:"not null;index"`
 	AuthorizedAt          *time.Time
 	Items                 []OrderCheckoutSnapshotItem `gorm:"foreignKey:SnapshotID"`
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+const (
+	OrderDocumentTypeInvoice     = "INVOICE"
+	OrderDocumentTypePackingSlip = "PACKING_SLIP"
+	OrderDocumentTypeCreditNote  = "CREDIT_NOTE"
+)
+
+func IsValidOrderDocumentType(documentType string) bool {
+	switch documentType {
+	case OrderDocumentTypeInvoice, OrderDocumentTypePackingSlip, OrderDocumentTypeCreditNote:
+		return true
+	default:
+		return false
+	}
+}
+
+// LegalEntity is a company invoices and credit notes are issued in the name
+// of. Orders shipping to Country use it; the default entity covers every
+// other country. Each entity numbers its invoices and credit notes in its own
+// gap-free sequence.
+type LegalEntity struct {
+	BaseModel
+	Code    string `json:"code" gorm:"not null;size:64;uniqueIndex"`
+	Name    string `json:"name" gorm:"not null;size:255"`
+	Address string `json:"address" gorm:"type:text;not null;default:''"`
+	TaxID   string `json:"tax_id" gorm:"not null;size:64;default:''"`
+	Country string `json:"country" gorm:"not null;size:2;default:'';index"`
+	// IsDefault marks the entity used when none is registered for the
+	// order's shipping country. At most one entity is the default.
+	IsDefault            bool   `json:"is_default" gorm:"not null;default:false"`
+	InvoicePrefix        string `json:"invoice_prefix" gorm:"not null;size:32;default:'INV-'"`
+	CreditNotePrefix     string `json:"credit_note_prefix" gorm:"not null;size:32;default:'CN-'"`
+	NextInvoiceNumber    int    `json:"next_invoice_number" gorm:"not null;default:1"`
+	NextCreditNoteNumber int    `json:"next_credit_note_number" gorm:"not null;default:1"`
+}
+
+// OrderDocument is an issued invoice, packing slip or credit note. The HTML
+// and PDF renderings are stored as issued and never regenerated, so a
+// document reads the same however the order changes later.
+type OrderDocument struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	OrderID   uint   `gorm:"not null;index"`
+	Type      string `gorm:"not null;size:32;index"`
+	// Number is the invoice or credit note number within LegalEntityID's
+	// sequence. Packing slips are numbered after their fulfillment order and
+	// belong to no entity.
+	Number             string `gorm:"not null;size:64;index"`
+	LegalEntityID      *uint  `gorm:"index"`
+	FulfillmentOrderID *uint  `gorm:"index"`
+	// PaymentTransactionID is the refund a credit note was issued for, or
+	// GiftCardID the store credit issued in place of one, and InvoiceID the
+	// invoice it corrects.
+	PaymentTransactionID *uint     `gorm:"uniqueIndex"`
+	GiftCardID           *uint     `gorm:"uniqueIndex"`
+	InvoiceID            *uint     `gorm:"index"`
+	Currency             string    `gorm:"size:3;not null;default:'USD'"`
+	Total                Money     `gorm:"type:numeric(12,2);not null;default:0"`
+	HTML                 string    `gorm:"type:text;not null"`
+	PDF                  []byte    `gorm:"not null"`
+	Checksum             string    `gorm:"not null;size:64"`
+	IssuedBy             string    `gorm:"not null;default:''"`
+	IssuedAt             time.Time `gorm:"not null;index"`
+}
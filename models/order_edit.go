@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+const (
+	OrderEditStatusOpen      = "OPEN"
+	OrderEditStatusCommitted = "COMMITTED"
+	OrderEditStatusCancelled = "CANCELLED"
+)
+
+// Order edit change kinds. REMOVE_ITEM is recorded when SET_QUANTITY takes a
+// line to zero; OPEN, COMMIT and CANCEL record the edit's own lifecycle.
+const (
+	OrderEditChangeAddItem       = "ADD_ITEM"
+	OrderEditChangeRemoveItem    = "REMOVE_ITEM"
+	OrderEditChangeSetQuantity   = "SET_QUANTITY"
+	OrderEditChangeSwapVariant   = "SWAP_VARIANT"
+	OrderEditChangeUpdateAddress = "UPDATE_ADDRESS"
+	OrderEditChangeOpen          = "OPEN"
+	OrderEditChangeCommit        = "COMMIT"
+	OrderEditChangeCancel        = "CANCEL"
+)
+
+// OrderEdit stages changes to a paid order. Its lines are the order as it
+// will be after the edit, priced again on every change, and BalanceDue is
+// what the customer owes (positive) or is owed (negative) against the order
+// total when the edit was opened.
+type OrderEdit struct {
+	ID                    uint `gorm:"primaryKey"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	OrderID               uint   `gorm:"not null;index"`
+	SnapshotID            uint   `gorm:"not null;default:0"`
+	Status                string `gorm:"size:16;not null;index"`
+	Reason                string `gorm:"type:text;not null;default:''"`
+	Actor                 string `gorm:"not null;default:''"`
+	ShippingDataJSON      string `gorm:"type:text;not null;default:''"`
+	ShippingAddressPretty string `gorm:"type:text;not null;default:''"`
+	Currency              string `gorm:"size:3;not null;default:'USD'"`
+	Subtotal              Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	DiscountAmount        Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	ShippingAmount        Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	TaxAmount             Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	Total                 Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	OriginalTotal         Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	BalanceDue            Money  `gorm:"type:numeric(12,2);not null;default:0"`
+	// PaymentOperationKey is the provider operation that settles the balance,
+	// set once a commit has started one.
+	PaymentOperationKey string `gorm:"not null;default:''"`
+	CommittedAt         *time.Time
+	CancelledAt         *time.Time
+	Lines               []OrderEditLine   `gorm:"foreignKey:OrderEditID"`
+	Changes             []OrderEditChange `gorm:"foreignKey:OrderEditID"`
+}
+
+// OrderEditLine is one line of the edited order. OrderItemID is nil for a
+// line the edit adds; a swapped line keeps the item it replaces.
+type OrderEditLine struct {
+	ID               uint `gorm:"primaryKey"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	OrderEditID      uint  `gorm:"not null;index"`
+	OrderItemID      *uint `gorm:"index"`
+	ProductVariantID uint  `gorm:"not null"`
+	VariantSKU       string
+	VariantTitle     string
+	Quantity         int   `gorm:"not null"`
+	UnitPrice        Money `gorm:"type:numeric(12,2);not null"`
+	// DiscountAmount is the discount on the whole line: the order's own
+	// discount for the units it was placed with, campaigns for added units.
+	DiscountAmount Money `gorm:"type:numeric(12,2);not null;default:0"`
+}
+
+// OrderEditChange is the history of an edit: one row per staged change, plus
+// rows for opening it and for its commit or cancellation.
+type OrderEditChange struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	OrderEditID uint   `gorm:"not null;index"`
+	Kind        string `gorm:"size:32;not null"`
+	Summary     string `gorm:"type:text;not null;default:''"`
+	Actor       string `gorm:"not null;default:''"`
+}
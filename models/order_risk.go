@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+const (
+	RiskActionAllow  = "ALLOW"
+	RiskActionReview = "REVIEW"
+	RiskActionReject = "REJECT"
+)
+
+const (
+	RiskReviewPending  = "PENDING"
+	RiskReviewApproved = "APPROVED"
+	RiskReviewRejected = "REJECTED"
+)
+
+const RiskSettingsSingletonID uint = 1
+
+// RiskSettings configures order screening. Scores are 0-100; an assessment at
+// or above RejectScore is refused, at or above ReviewScore is held for manual
+// review, and anything lower is allowed.
+type RiskSettings struct {
+	ID                    uint   `gorm:"primaryKey;autoIncrement:false"`
+	Enabled               bool   `gorm:"not null;default:true"`
+	ReviewScore           int    `gorm:"not null;default:50"`
+	RejectScore           int    `gorm:"not null;default:80"`
+	HighValueThreshold    Money  `gorm:"type:numeric(12,2);not null;default:500"`
+	VelocityWindowMinutes int    `gorm:"not null;default:60"`
+	VelocityLimit         int    `gorm:"not null;default:3"`
+	DisposableDomains     string `gorm:"type:text;not null;default:''"`
+	ExternalScorerID      string `gorm:"not null;default:''"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// OrderRiskAssessment records one screening run. Velocity rules count earlier
+// rows, so the identifiers are kept in the form they are compared in: a
+// lower-cased email, the client address, and only the card BIN.
+type OrderRiskAssessment struct {
+	ID          uint      `gorm:"primaryKey"`
+	CreatedAt   time.Time `gorm:"index"`
+	OrderID     uint      `gorm:"not null;index"`
+	Email       string    `gorm:"not null;default:'';index"`
+	ClientIP    string    `gorm:"not null;default:'';index"`
+	CardBIN     string    `gorm:"column:card_bin;not null;default:'';index"`
+	Score       int       `gorm:"not null"`
+	Action      string    `gorm:"not null"`
+	ReasonsJSON string    `gorm:"type:text;not null;default:'[]'"`
+	ScorerID    string    `gorm:"not null;default:''"`
+}
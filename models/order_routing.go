@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Order routing rules rank the locations that could fulfill an order. They
+// are applied in position order, each breaking the ties left by the ones
+// before it.
+const (
+	// OrderRoutingRuleFullOrder prefers locations that can ship every line,
+	// so an order is only split when no single location covers it.
+	OrderRoutingRuleFullOrder = "FULL_ORDER"
+	// OrderRoutingRuleProximity prefers locations in the destination's state,
+	// then in its country.
+	OrderRoutingRuleProximity = "PROXIMITY"
+	// OrderRoutingRulePriority prefers locations with a lower position.
+	OrderRoutingRulePriority = "LOCATION_PRIORITY"
+)
+
+// DefaultOrderRoutingRules is the ranking used until an admin configures one.
+var DefaultOrderRoutingRules = []string{OrderRoutingRuleFullOrder, OrderRoutingRuleProximity, OrderRoutingRulePriority}
+
+func IsValidOrderRoutingRule(rule string) bool {
+	switch rule {
+	case OrderRoutingRuleFullOrder, OrderRoutingRuleProximity, OrderRoutingRulePriority:
+		return true
+	default:
+		return false
+	}
+}
+
+type OrderRoutingRule struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Rule      string `gorm:"not null;size:32;uniqueIndex"`
+	Position  int    `gorm:"not null;default:0"`
+	Active    bool   `gorm:"not null;default:true"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// OrderTag is a free-form label staff put on an order, such as "vip" or
+// "chargeback-risk". Tags are stored lowercased and are unique per order.
+type OrderTag struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	OrderID   uint   `gorm:"not null;uniqueIndex:idx_order_tags_order_tag"`
+	Tag       string `gorm:"not null;size:64;uniqueIndex:idx_order_tags_order_tag;index"`
+	CreatedBy string `gorm:"not null;default:''"`
+}
+
+// OrderNote is an internal comment on an order. Notes are only shown to
+// staff and are never sent to the customer.
+type OrderNote struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	OrderID   uint   `gorm:"not null;index"`
+	Body      string `gorm:"type:text;not null"`
+	Author    string `gorm:"not null;default:''"`
+}
+
+// OrderSavedView is a named set of admin order list filters. Views belong to
+// the admin account that saved them; OwnerUserID is 0 for views saved by
+// the local CLI, which runs without an account.
+type OrderSavedView struct {
+	ID          uint `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	OwnerUserID uint   `gorm:"not null;uniqueIndex:idx_order_saved_views_owner_name"`
+	Name        string `gorm:"not null;size:128;uniqueIndex:idx_order_saved_views_owner_name"`
+	// FiltersJSON holds the view's orders.ViewFilters.
+	FiltersJSON string `gorm:"type:text;not null;default:'{}'"`
+}
@@ -12,6 +12,10 @@ const (
 	PaymentIntentStatusFailed            = "FAILED"
 )
 
+// PaymentProviderGiftCard is the provider id of intents tendered from gift
+// card balances rather than an external payment provider.
+const PaymentProviderGiftCard = "gift_card"
+
 func IsActivePaymentIntentStatus(status string) bool {
 	switch status {
 	case PaymentIntentStatusRequiresAction, PaymentIntentStatusAuthorized, PaymentIntentStatusPartiallyCaptured:
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RateLimitCounter is one fixed-window hit counter shared by every API
+// replica. BucketKey is a digest of the policy bucket and caller identity so
+// raw client addresses and session tokens are never persisted.
+type RateLimitCounter struct {
+	BucketKey   string    `gorm:"primaryKey;size:64"`
+	WindowStart time.Time `gorm:"primaryKey"`
+	Hits        int       `gorm:"not null;default:0"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
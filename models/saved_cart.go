@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	SavedCartStatusActive   = "ACTIVE"
+	SavedCartStatusArchived = "ARCHIVED"
+)
+
+// SavedCart is a named, shareable copy of a cart. With LockPrices set it acts
+// as a quote: converting it pins each line to UnitPrice until ExpiresAt.
+type SavedCart struct {
+	ID              uint `gorm:"primaryKey"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Name            string     `gorm:"not null"`
+	Note            string     `gorm:"type:text;not null;default:''"`
+	ShareToken      string     `gorm:"not null;size:64;uniqueIndex"`
+	OwnerUserID     *uint      `gorm:"index"`
+	CustomerUserID  *uint      `gorm:"index"`
+	CreatedBy       string     `gorm:"not null;default:''"`
+	LockPrices      bool       `gorm:"not null;default:false"`
+	ExpiresAt       *time.Time `gorm:"index"`
+	Status          string     `gorm:"not null;index"`
+	ConversionCount int        `gorm:"not null;default:0"`
+	LastConvertedAt *time.Time
+	Items           []SavedCartItem `gorm:"foreignKey:SavedCartID"`
+}
+
+// SavedCartItem stores the quantity and the unit price captured when the
+// saved cart was created.
+type SavedCartItem struct {
+	ID               uint `gorm:"primaryKey"`
+	CreatedAt        time.Time
+	SavedCartID      uint           `gorm:"not null;index"`
+	ProductVariantID uint           `gorm:"not null"`
+	ProductVariant   ProductVariant `gorm:"foreignKey:ProductVariantID"`
+	Quantity         int            `gorm:"not null"`
+	UnitPrice        Money          `gorm:"type:numeric(12,2);not null"`
+	Position         int            `gorm:"not null;default:0"`
+}
+
+func (c SavedCart) Expired(now time.Time) bool {
+	return c.ExpiresAt != nil && !now.Before(*c.ExpiresAt)
+}
@@ -18,6 +18,7 @@ type Shipment struct {
 	SnapshotID            uint   `gorm:"not null;index"`
 	Provider              string `gorm:"not null;index"`
 	ShipmentRateID        uint   `gorm:"not null;uniqueIndex"`
+	FulfillmentOrderID    *uint  `gorm:"index"`
 	ProviderShipmentID    string `gorm:"not null;index"`
 	Status                string `gorm:"not null;index"`
 	Currency              string `gorm:"not null;size:3"`
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+const (
+	StockLocationKindWarehouse = "WAREHOUSE"
+	StockLocationKindStore     = "STORE"
+)
+
+// DefaultStockLocationCode is the location single-warehouse stock lives in.
+// Inventory without an explicit location is kept there.
+const DefaultStockLocationCode = "default"
+
+func IsValidStockLocationKind(kind string) bool {
+	return kind == StockLocationKindWarehouse || kind == StockLocationKindStore
+}
+
+// StockLocation is a place stock is held: a warehouse or a retail store. Only
+// active, sellable locations count towards what the storefront offers.
+type StockLocation struct {
+	BaseModel
+	Code     string `json:"code" gorm:"not null;size:64;uniqueIndex"`
+	Name     string `json:"name" gorm:"not null;size:255"`
+	Kind     string `json:"kind" gorm:"not null;size:32;default:'WAREHOUSE'"`
+	Sellable bool   `json:"sellable" gorm:"not null;default:true"`
+	Active   bool   `json:"active" gorm:"not null;default:true;index"`
+	// Position orders locations when stock is drawn for an order; the lowest
+	// position that can cover a line is used first.
+	Position int `json:"position" gorm:"not null;default:0"`
+	// Country and State place the location for proximity routing, as ISO
+	// 3166 country and subdivision codes.
+	Country string `json:"country" gorm:"not null;size:2;default:''"`
+	State   string `json:"state" gorm:"not null;size:64;default:''"`
+}
+
+// CountsTowardsStorefront reports whether the location's stock can be sold.
+func (l StockLocation) CountsTowardsStorefront() bool {
+	return l.Active && l.Sellable
+}
+
+const (
+	InventoryTransferStatusDraft     = "DRAFT"
+	InventoryTransferStatusInTransit = "IN_TRANSIT"
+	InventoryTransferStatusReceived  = "RECEIVED"
+	InventoryTransferStatusCancelled = "CANCELLED"
+)
+
+// InventoryTransfer moves stock between two locations. Units leave the source
+// when the transfer ships and arrive at the destination when it is received.
+type InventoryTransfer struct {
+	BaseModel
+	FromLocationID uint                    `json:"from_location_id" gorm:"not null;index"`
+	FromLocation   StockLocation           `json:"-" gorm:"foreignKey:FromLocationID"`
+	ToLocationID   uint                    `json:"to_location_id" gorm:"not null;index"`
+	ToLocation     StockLocation           `json:"-" gorm:"foreignKey:ToLocationID"`
+	Status         string                  `json:"status" gorm:"not null;size:32;index"`
+	Notes          string                  `json:"notes" gorm:"type:text;not null;default:''"`
+	ActorType      string                  `json:"actor_type" gorm:"not null;size:64;default:''"`
+	ActorID        *uint                   `json:"actor_id,omitempty"`
+	ShippedAt      *time.Time              `json:"shipped_at,omitempty"`
+	ReceivedAt     *time.Time              `json:"received_at,omitempty"`
+	CancelledAt    *time.Time              `json:"cancelled_at,omitempty"`
+	Items          []InventoryTransferItem `json:"items,omitempty"`
+}
+
+type InventoryTransferItem struct {
+	BaseModel
+	InventoryTransferID uint           `json:"inventory_transfer_id" gorm:"not null;index"`
+	ProductVariantID    uint           `json:"product_variant_id" gorm:"not null;index"`
+	ProductVariant      ProductVariant `json:"-" gorm:"foreignKey:ProductVariantID"`
+	Quantity            int            `json:"quantity" gorm:"not null"`
+}
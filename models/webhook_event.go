@@ -15,4 +15,6 @@ type WebhookEvent struct {
 	ProcessedAt     *time.Time `gorm:"index"`
 	AttemptCount    int        `gorm:"not null;default:0"`
 	LastError       string     `gorm:"type:text;not null;default:''"`
+	// OrderID is the order the event was applied to, once processed.
+	OrderID *uint `gorm:"index"`
 }